@@ -0,0 +1,333 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/log"
+	"github.com/mmcdole/kino/internal/mediaserver"
+	"github.com/mmcdole/kino/internal/player"
+	"github.com/mmcdole/kino/internal/playlist"
+	"github.com/mmcdole/kino/internal/search"
+	"github.com/mmcdole/kino/internal/store"
+)
+
+// errUsage signals a malformed subcommand; main prints usage and exits 2
+var errUsage = errors.New("usage")
+
+// cliUsage lists the headless subcommands
+const cliUsage = `Usage:
+  kino list libraries
+  kino list movies <library>
+  kino list shows <library>
+  kino search <query>
+  kino mark-watched <id>
+  kino mark-unwatched <id>
+  kino playlist export <name> [--format m3u|json] [--no-urls] [-o <file>]
+  kino playlist import <file> [--name <title>]
+  kino sync-watched --from <plex|jellyfin> --to <plex|jellyfin> [--dry-run]
+  kino syncplay [join <group>]
+
+<library> is a library name or ID. Output is JSON on stdout, except for
+playlist, sync-watched and syncplay: an export writes the playlist file,
+syncplay lists or joins Jellyfin sync groups, the others print a report.`
+
+// isSubcommand reports whether the first argument names a headless command
+func isSubcommand(name string) bool {
+	switch name {
+	case "list", "search", "mark-watched", "mark-unwatched":
+		return true
+	}
+	return false
+}
+
+// cliEnv holds the services a headless command runs against: the same
+// config, cache and adapters as the TUI
+type cliEnv struct {
+	store     domain.Store
+	library   *library.Service
+	search    *search.Service
+	playback  *player.Service
+	playlists *playlist.Service
+}
+
+// openCLIEnv loads the config and wires the service layer without a player
+// or TUI. Logs go to the log file, never stdout, so JSON stays parseable.
+func openCLIEnv() (*cliEnv, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger, err := log.SetupLogger(&cfg.Logging)
+	if err != nil {
+		logger = log.NullLogger()
+	}
+	slog.SetDefault(logger)
+
+	if !cfg.IsConfigured() {
+		return nil, fmt.Errorf("no server configured: run kino once to set up a server first")
+	}
+
+	client, err := mediaserver.NewClient(cfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create media client: %w", err)
+	}
+
+	libraryStore, err := store.NewLibraryStore(config.DefaultCachePath(), cfg.Server.URL, cfg.Server.UserID)
+	if err != nil {
+		logger.Warn("store unavailable, continuing memory-only", "error", err)
+		libraryStore, _ = store.NewLibraryStore("", "", "")
+	}
+
+	librarySvc := library.NewService(client, libraryStore, logger)
+	librarySvc.SetDeepIndex(cfg.Search.DeepIndex)
+	searchSvc := search.NewService(libraryStore)
+	searchSvc.SetRanking(searchRanking(cfg.Search))
+
+	return &cliEnv{
+		store:     libraryStore,
+		library:   librarySvc,
+		search:    searchSvc,
+		playback:  player.NewService(nil, client, logger),
+		playlists: playlist.NewService(client, libraryStore, logger),
+	}, nil
+}
+
+// runSubcommand runs a headless subcommand and prints its JSON result
+func runSubcommand(args []string) error {
+	// Validate the arguments before touching config or the network
+	var cmd func(ctx context.Context, e *cliEnv) (interface{}, error)
+	switch {
+	case len(args) == 2 && args[0] == "list" && args[1] == "libraries":
+		cmd = func(ctx context.Context, e *cliEnv) (interface{}, error) { return e.listLibraries(ctx) }
+	case len(args) >= 3 && args[0] == "list" && (args[1] == "movies" || args[1] == "shows"):
+		cmd = func(ctx context.Context, e *cliEnv) (interface{}, error) {
+			return e.listContent(ctx, args[1], strings.Join(args[2:], " "))
+		}
+	case len(args) >= 2 && args[0] == "search":
+		cmd = func(ctx context.Context, e *cliEnv) (interface{}, error) {
+			return e.searchCached(ctx, strings.Join(args[1:], " "))
+		}
+	case len(args) == 2 && (args[0] == "mark-watched" || args[0] == "mark-unwatched"):
+		cmd = func(ctx context.Context, e *cliEnv) (interface{}, error) {
+			return e.setWatched(ctx, args[1], args[0] == "mark-watched")
+		}
+	default:
+		return errUsage
+	}
+
+	env, err := openCLIEnv()
+	if err != nil {
+		return err
+	}
+	defer env.store.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	out, err := cmd(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// jsonLibrary is the scripting view of a library
+type jsonLibrary struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// jsonItem is the scripting view of a movie, show or episode
+type jsonItem struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Year      int    `json:"year,omitempty"`
+	LibraryID string `json:"library_id,omitempty"`
+
+	// Movies and episodes
+	Watched         *bool `json:"watched,omitempty"`
+	DurationSeconds int   `json:"duration_seconds,omitempty"`
+	OffsetSeconds   int   `json:"offset_seconds,omitempty"`
+
+	// Episodes
+	ShowTitle string `json:"show_title,omitempty"`
+	Season    int    `json:"season,omitempty"`
+	Episode   int    `json:"episode,omitempty"`
+
+	// Shows
+	Seasons   int `json:"seasons,omitempty"`
+	Episodes  int `json:"episodes,omitempty"`
+	Unwatched int `json:"unwatched,omitempty"`
+}
+
+func mediaItemJSON(m *domain.MediaItem) jsonItem {
+	watched := m.IsPlayed
+	item := jsonItem{
+		ID:              m.ID,
+		Type:            m.GetItemType(),
+		Title:           m.Title,
+		Year:            m.Year,
+		LibraryID:       m.LibraryID,
+		Watched:         &watched,
+		DurationSeconds: int(m.Duration.Seconds()),
+		OffsetSeconds:   int(m.ViewOffset.Seconds()),
+	}
+	if m.Type == domain.MediaTypeEpisode {
+		item.ShowTitle = m.ShowTitle
+		item.Season = m.SeasonNum
+		item.Episode = m.EpisodeNum
+	}
+	return item
+}
+
+func showJSON(s *domain.Show) jsonItem {
+	return jsonItem{
+		ID:        s.ID,
+		Type:      s.GetItemType(),
+		Title:     s.Title,
+		Year:      s.Year,
+		LibraryID: s.LibraryID,
+		Seasons:   s.SeasonCount,
+		Episodes:  s.EpisodeCount,
+		Unwatched: s.UnwatchedCount,
+	}
+}
+
+// listLibraries fetches the server's libraries (refreshing the cache)
+func (e *cliEnv) listLibraries(ctx context.Context) ([]jsonLibrary, error) {
+	libs, err := e.library.FetchLibraries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]jsonLibrary, 0, len(libs))
+	for _, lib := range libs {
+		out = append(out, jsonLibrary{ID: lib.ID, Name: lib.Name, Type: lib.Type})
+	}
+	return out, nil
+}
+
+// libraries returns the cached library list, fetching it on a cold cache
+func (e *cliEnv) libraries(ctx context.Context) ([]domain.Library, error) {
+	if libs, ok := e.store.GetLibraries(); ok {
+		return libs, nil
+	}
+	return e.library.FetchLibraries(ctx)
+}
+
+// findLibrary resolves a library by ID or case-insensitive name
+func (e *cliEnv) findLibrary(ctx context.Context, ref string) (domain.Library, error) {
+	libs, err := e.libraries(ctx)
+	if err != nil {
+		return domain.Library{}, err
+	}
+	for _, lib := range libs {
+		if lib.ID == ref || strings.EqualFold(lib.Name, ref) {
+			return lib, nil
+		}
+	}
+	return domain.Library{}, fmt.Errorf("no library named %q", ref)
+}
+
+// listContent syncs a library (a cheap freshness check when cached) and
+// prints its movies or shows; mixed libraries contribute the requested kind
+func (e *cliEnv) listContent(ctx context.Context, kind, ref string) ([]jsonItem, error) {
+	lib, err := e.findLibrary(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := e.library.SyncLibrary(ctx, lib, nil); err != nil {
+		return nil, err
+	}
+
+	out := []jsonItem{}
+	switch lib.Type {
+	case "movie":
+		movies, _ := e.store.GetMovies(lib.ID)
+		if kind == "movies" {
+			for _, m := range movies {
+				out = append(out, mediaItemJSON(m))
+			}
+		}
+	case "show":
+		shows, _ := e.store.GetShows(lib.ID)
+		if kind == "shows" {
+			for _, s := range shows {
+				out = append(out, showJSON(s))
+			}
+		}
+	case "mixed":
+		items, _ := e.store.GetMixedContent(lib.ID)
+		for _, item := range items {
+			switch v := item.(type) {
+			case *domain.MediaItem:
+				if kind == "movies" {
+					out = append(out, mediaItemJSON(v))
+				}
+			case *domain.Show:
+				if kind == "shows" {
+					out = append(out, showJSON(v))
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+// searchCached fuzzy-searches movies and shows (and episodes, with
+// search.deep_index) across every library, the
+// same matching the TUI's global search uses. Libraries are synced first so
+// a cold cache still finds results.
+func (e *cliEnv) searchCached(ctx context.Context, query string) ([]jsonItem, error) {
+	libs, err := e.libraries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, lib := range libs {
+		if _, err := e.library.SyncLibrary(ctx, lib, nil); err != nil {
+			return nil, fmt.Errorf("failed to sync %s: %w", lib.Name, err)
+		}
+	}
+
+	out := []jsonItem{}
+	for _, r := range e.search.FilterLocal(query, libs) {
+		switch v := r.Item.(type) {
+		case *domain.MediaItem:
+			out = append(out, mediaItemJSON(v))
+		case *domain.Show:
+			out = append(out, showJSON(v))
+		}
+	}
+	return out, nil
+}
+
+// setWatched marks an item watched or unwatched on the server and patches
+// the cache so the TUI shows it without a resync
+func (e *cliEnv) setWatched(ctx context.Context, id string, watched bool) (interface{}, error) {
+	var err error
+	if watched {
+		err = e.playback.MarkWatched(ctx, id)
+	} else {
+		err = e.playback.MarkUnwatched(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	e.library.SetWatchState(id, watched)
+	return map[string]interface{}{"id": id, "watched": watched}, nil
+}
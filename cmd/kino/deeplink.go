@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/player"
+)
+
+// resolveTimeout bounds deep-link resolution: a server search plus, for
+// episodes, a seasons and an episodes fetch
+const resolveTimeout = 30 * time.Second
+
+// resolveLink resolves a --play/--goto reference against the cached
+// libraries, falling back to the server
+func resolveLink(svc *library.Service, store domain.Store, ref string) (*library.Resolved, error) {
+	link, err := library.ParseLink(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+
+	libs, ok := store.GetLibraries()
+	if !ok {
+		if libs, err = svc.FetchLibraries(ctx); err != nil {
+			return nil, fmt.Errorf("failed to load libraries: %w", err)
+		}
+	}
+
+	res, err := svc.Resolve(ctx, libs, link)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve %q: %w", ref, err)
+	}
+	return res, nil
+}
+
+// playLink launches playback of a deep link without starting the TUI,
+// resuming in-progress items
+func playLink(svc *library.Service, store domain.Store, playback *player.Service, ref string) error {
+	res, err := resolveLink(svc, store, ref)
+	if err != nil {
+		return err
+	}
+	item := res.Playable()
+	if item == nil {
+		return fmt.Errorf("cannot play %q: not a movie or episode", ref)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	if item.ShouldResume() {
+		fmt.Printf("Resuming %s from %s\n", describeItem(item), item.FormattedOffset())
+		return playback.Resume(ctx, *item)
+	}
+	fmt.Printf("Playing %s\n", describeItem(item))
+	return playback.Play(ctx, *item)
+}
+
+// describeItem names an item for terminal output
+func describeItem(item *domain.MediaItem) string {
+	if item.Type == domain.MediaTypeEpisode {
+		return fmt.Sprintf("%s %s %s", item.ShowTitle, item.EpisodeCode(), item.Title)
+	}
+	if item.Year > 0 {
+		return fmt.Sprintf("%s (%d)", item.Title, item.Year)
+	}
+	return item.Title
+}
@@ -3,19 +3,29 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/arr"
 	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/format"
+	"github.com/mmcdole/kino/internal/i18n"
 	"github.com/mmcdole/kino/internal/library"
 	"github.com/mmcdole/kino/internal/log"
 	"github.com/mmcdole/kino/internal/mediaserver"
+	"github.com/mmcdole/kino/internal/mediaserver/plex"
+	"github.com/mmcdole/kino/internal/metrics"
 	"github.com/mmcdole/kino/internal/player"
 	"github.com/mmcdole/kino/internal/playlist"
 	"github.com/mmcdole/kino/internal/search"
@@ -35,20 +45,100 @@ func main() {
 	var showVersion bool
 	flag.BoolVar(&showVersion, "v", false, "print version")
 	flag.BoolVar(&showVersion, "version", false, "print version")
+	var private bool
+	flag.BoolVar(&private, "private", false, "start in a private session (no watch state reported)")
+	var traktImport, dryRun bool
+	flag.BoolVar(&traktImport, "trakt-import", false, "import Trakt watched history into the server and exit")
+	flag.BoolVar(&dryRun, "dry-run", false, "with --trakt-import: report what would change without writing")
+	var opts startOptions
+	flag.StringVar(&opts.play, "play", "", `play an item without the TUI: "Movie Title" or "Show/S02E05"`)
+	flag.StringVar(&opts.goTo, "goto", "", `open the TUI at an item: "Movie Title", "Show", "Show/S02" or "Show/S02E05"`)
+	flag.BoolVar(&opts.switchUser, "switch-user", false, "pick another user on the server (Plex Home, Jellyfin) before starting")
 	flag.Parse()
+	opts.private = private
 
 	if showVersion {
 		fmt.Printf("kino %s\n", Version)
 		return
 	}
 
-	if err := run(); err != nil {
+	if args := flag.Args(); len(args) > 0 {
+		var err error
+		switch {
+		case args[0] == "sync-watched":
+			err = runSyncWatched(args[1:])
+		case args[0] == "playlist":
+			err = runPlaylist(args[1:])
+		case args[0] == "syncplay":
+			err = runSyncPlay(args[1:])
+		case isSubcommand(args[0]):
+			err = runSubcommand(args)
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s\n", args[0], cliUsage)
+			os.Exit(2)
+		}
+		if err != nil {
+			if errors.Is(err, errUsage) {
+				fmt.Fprintln(os.Stderr, cliUsage)
+				os.Exit(2)
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if traktImport {
+		if err := runTraktImport(dryRun); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if opts.play != "" && opts.goTo != "" {
+		fmt.Fprintln(os.Stderr, "Error: --play and --goto are mutually exclusive")
+		os.Exit(2)
+	}
+
+	if err := run(opts); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func run() error {
+// autoSkipKinds lists the segments the player config asks mpv to skip
+func autoSkipKinds(c config.PlayerConfig) []domain.SegmentKind {
+	var kinds []domain.SegmentKind
+	if c.SkipIntros {
+		kinds = append(kinds, domain.SegmentIntro, domain.SegmentRecap)
+	}
+	if c.SkipCredits {
+		kinds = append(kinds, domain.SegmentCredits)
+	}
+	return kinds
+}
+
+// searchRanking converts the configured search weights
+func searchRanking(c config.SearchConfig) search.Ranking {
+	return search.Ranking{
+		PrefixBoost:     c.PrefixBoost,
+		RecentBoost:     c.RecentBoost,
+		RecentDays:      c.RecentDays,
+		InProgressBoost: c.InProgressBoost,
+		WatchedPenalty:  c.WatchedPenalty,
+	}
+}
+
+// startOptions are the command-line choices for a TUI (or headless play) run
+type startOptions struct {
+	private    bool
+	play       string // deep link to play headless
+	goTo       string // deep link to open the TUI at
+	switchUser bool   // pick another server user first
+}
+
+func run(opts startOptions) error {
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -65,6 +155,18 @@ func run() error {
 
 	logger.Info("starting kino", "version", Version)
 
+	if cfg.UI.Accessible {
+		styles.EnableAccessible()
+	}
+	if locale := i18n.Detect(cfg.UI.Locale); !i18n.SetLocale(locale) && cfg.UI.Locale != "" {
+		logger.Warn("unsupported locale, using English", "locale", locale, "supported", i18n.Locales())
+	}
+	format.Configure(format.Options{
+		Clock:    cfg.UI.TimeFormat,
+		Relative: cfg.UI.RelativeDates,
+		Duration: cfg.UI.DurationFormat,
+	})
+
 	// Check if configured
 	if !cfg.IsConfigured() {
 		if err := runSetupFlow(cfg, logger); err != nil {
@@ -72,6 +174,19 @@ func run() error {
 		}
 		// Fall through into normal startup with the fresh credentials —
 		// no need to make the user run kino a second time
+	} else if opts.switchUser {
+		if err := runSwitchUser(cfg, logger); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Metrics.Listen != "" {
+		srv, err := metrics.Serve(cfg.Metrics.Listen, logger)
+		if err != nil {
+			logger.Warn("metrics listener unavailable", "addr", cfg.Metrics.Listen, "error", err)
+		} else {
+			defer srv.Close()
+		}
 	}
 
 	// Create media source client
@@ -80,6 +195,15 @@ func run() error {
 		return fmt.Errorf("failed to create media client: %w", err)
 	}
 
+	// A logout clears the cache after the store closes (deferred calls run
+	// last to first): Windows can't delete the open database
+	var loggedOut bool
+	defer func() {
+		if loggedOut {
+			finishLogout(logger)
+		}
+	}()
+
 	// Create store (persistence layer)
 	libraryStore, err := store.NewLibraryStore(config.DefaultCachePath(), cfg.Server.URL, cfg.Server.UserID)
 	if err != nil {
@@ -90,15 +214,68 @@ func run() error {
 
 	// Create launcher (uses configured player or auto-detects)
 	launcher := player.NewLauncher(cfg.Player.Command, cfg.Player.Args, cfg.Player.StartFlag, logger)
+	launcher.SetPreference(cfg.Player.Preference)
 
 	// Create services
 	librarySvc := library.NewService(client, libraryStore, logger)
+	librarySvc.SetExtras(cfg.UI.Specials != config.SpecialsHide || len(cfg.UI.SpecialsByShow) > 0)
+	librarySvc.SetDeepIndex(cfg.Search.DeepIndex)
+	// Runs before the store closes: in-flight cache writes land first
+	defer shutdownServices(librarySvc, logger)
 	playlistSvc := playlist.NewService(client, libraryStore, logger)
 	searchSvc := search.NewService(libraryStore)
+	searchSvc.SetRanking(searchRanking(cfg.Search))
 	playbackSvc := player.NewService(launcher, client, logger)
+	if opts.private {
+		playbackSvc.SetPrivate(true)
+	}
+	if cfg.Player.MaxBitrateMbps > 0 {
+		playbackSvc.SetMaxBitrate(cfg.Player.MaxBitrateMbps * 1000)
+	}
+	playbackSvc.SetAutoSkip(autoSkipKinds(cfg.Player)...)
+	playbackSvc.SetHooks(cfg.Player.PreHook, cfg.Player.PostHook)
+	playbackSvc.SetSessionFile(config.DefaultNowPlayingPath())
+
+	if opts.play != "" {
+		return playLink(librarySvc, libraryStore, playbackSvc, opts.play)
+	}
 
 	// Create TUI model with Store and concrete service types
-	model := tui.NewModel(libraryStore, librarySvc, playlistSvc, searchSvc, playbackSvc, cfg.UI)
+	model := tui.NewModel(libraryStore, librarySvc, playlistSvc, searchSvc, playbackSvc, newArrService(cfg, logger), cfg.UI)
+	if cfg.Security.LockTimeout > 0 && cfg.Security.PINHash == "" {
+		logger.Warn("security.lock_timeout is set without security.pin; inactivity lock disabled")
+	}
+	model.SetLock(time.Duration(cfg.Security.LockTimeout)*time.Minute, cfg.Security.PINHash)
+	model.SetSyncConcurrency(cfg.Server.SyncConcurrency)
+	model.SetStartupSync(cfg.Server.StartupSync)
+	model.SetAutoRefresh(cfg.Server.AutoRefreshIntervals())
+	model.SetNextEpisode(cfg.Player.NextEpisode)
+	model.SetRequestTrace(mediaserver.Trace)
+	if reauth, err := mediaserver.NewReauth(cfg, client, logger); err != nil {
+		logger.Warn("signing in again from the TUI unavailable", "error", err)
+	} else {
+		model.SetReauth(reauth)
+	}
+	if opts.goTo != "" {
+		res, err := resolveLink(librarySvc, libraryStore, opts.goTo)
+		if err != nil {
+			return err
+		}
+		model.SetStartAt(res)
+	} else if cfg.UI.RestoreSession {
+		session, err := config.LoadSession(config.DefaultSessionPath())
+		if err != nil {
+			logger.Warn("ignoring unreadable session file", "error", err)
+		} else if session != nil && session.Server == cfg.Server.URL && session.User == cfg.Server.UserID {
+			model.SetSession(session)
+		}
+	}
+
+	if bookmarks, err := config.LoadBookmarks(config.DefaultBookmarksPath()); err != nil {
+		logger.Warn("ignoring unreadable bookmarks file", "error", err)
+	} else if bookmarks != nil && bookmarks.Server == cfg.Server.URL && bookmarks.User == cfg.Server.UserID {
+		model.SetBookmarks(bookmarks.Slots)
+	}
 
 	// Run the TUI
 	p := tea.NewProgram(
@@ -109,21 +286,75 @@ func run() error {
 
 	logger.Info("starting TUI")
 
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		logger.Error("TUI error", "error", err)
 		return fmt.Errorf("TUI error: %w", err)
 	}
 
+	if fm, ok := final.(tui.Model); ok && fm.LoggedOut() {
+		loggedOut = true
+		logger.Info("shutting down after logout")
+		return nil
+	}
+
+	if fm, ok := final.(tui.Model); ok && cfg.UI.RestoreSession {
+		session := fm.Session(cfg.Server.URL)
+		session.User = cfg.Server.UserID
+		if err := config.SaveSession(config.DefaultSessionPath(), session); err != nil {
+			logger.Warn("failed to save session", "error", err)
+		}
+	}
+
+	if fm, ok := final.(tui.Model); ok {
+		if slots, changed := fm.Bookmarks(); changed {
+			bookmarks := config.Bookmarks{Server: cfg.Server.URL, User: cfg.Server.UserID, Slots: slots}
+			if err := config.SaveBookmarks(config.DefaultBookmarksPath(), bookmarks); err != nil {
+				logger.Warn("failed to save bookmarks", "error", err)
+			}
+		}
+	}
+
 	logger.Info("shutting down")
 	return nil
 }
 
+// shutdownTimeout bounds how long exit waits on background work
+const shutdownTimeout = 3 * time.Second
+
+// shutdownServices stops the services' background work before the store
+// closes. Playback needs no hook: launched players stream directly and
+// kino reports no playing sessions to the server.
+func shutdownServices(librarySvc *library.Service, logger *slog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := librarySvc.Shutdown(ctx); err != nil {
+		logger.Warn("library shutdown incomplete", "error", err)
+	}
+}
+
+// finishLogout clears the cache once nothing holds it open and tells the
+// user, on the normal screen, how to sign in again
+func finishLogout(logger *slog.Logger) {
+	if err := config.ClearCache(); err != nil {
+		logger.Warn("failed to clear cache after logout", "error", err)
+		fmt.Println(styles.ASCII("✗") + " " + i18n.T("setup.logout_cache", err))
+	}
+	fmt.Println(styles.ASCII("✓") + " " + i18n.T("setup.logged_out"))
+}
+
 // runSetupFlow handles the initial setup when not configured
 func runSetupFlow(cfg *config.Config, logger *slog.Logger) error {
 	fmt.Println()
-	fmt.Println("Welcome to Kino!")
+	fmt.Println(i18n.T("setup.welcome"))
 	fmt.Println()
 
+	// TLS settings (self-signed certificates, private CA) apply to setup too
+	transport, err := mediaserver.NewTransport(cfg.Server)
+	if err != nil {
+		return err
+	}
+
 	// Loop until we get a valid server URL
 	var serverURL string
 	var serverType config.SourceType
@@ -131,7 +362,8 @@ func runSetupFlow(cfg *config.Config, logger *slog.Logger) error {
 	for {
 		// Prompt for server URL
 		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("Enter your server URL (e.g., http://192.168.1.100:32400): ")
+		fmt.Println(i18n.T("setup.server_url"))
+		fmt.Print(i18n.T("setup.server_url_plex") + " ")
 		input, err := reader.ReadString('\n')
 		if err != nil {
 			return fmt.Errorf("failed to read input: %w", err)
@@ -139,22 +371,24 @@ func runSetupFlow(cfg *config.Config, logger *slog.Logger) error {
 		serverURL = strings.TrimSpace(input)
 
 		if serverURL == "" {
-			fmt.Println("Server URL cannot be empty. Please try again.")
-			continue
+			return runPlexDiscovery(cfg, transport, logger)
 		}
 
 		// Scheme-less input ("192.168.1.100:32400") would otherwise die with
-		// a cryptic "unsupported protocol scheme" error
-		if !strings.HasPrefix(serverURL, "http://") && !strings.HasPrefix(serverURL, "https://") {
+		// a cryptic "unsupported protocol scheme" error. A folder is kept as
+		// a path, for the local source.
+		if folder, ok := localFolder(serverURL); ok {
+			serverURL = folder
+		} else if !strings.HasPrefix(serverURL, "http://") && !strings.HasPrefix(serverURL, "https://") {
 			serverURL = "http://" + serverURL
 		}
 
 		// Detect server type with spinner
 		fmt.Println()
-		detectedType, err := detectServerWithSpinner(serverURL)
+		detectedType, err := detectServerWithSpinner(serverURL, transport)
 		if err != nil {
-			fmt.Printf("\n✗ Could not detect server type: %v\n", err)
-			fmt.Println("Please check the URL and try again.")
+			fmt.Printf("\n%s %s\n", styles.ASCII("✗"), i18n.T("setup.detect_failed", err))
+			fmt.Println(i18n.T("setup.check_url"))
 			fmt.Println()
 			continue
 		}
@@ -168,7 +402,7 @@ func runSetupFlow(cfg *config.Config, logger *slog.Logger) error {
 	cfg.Server.Type = serverType
 
 	// Run the appropriate auth flow
-	authFlow, err := mediaserver.NewAuthFlow(serverType, cfg.Server.DeviceID, logger)
+	authFlow, err := mediaserver.NewAuthFlow(serverType, cfg.Server.DeviceID, transport, logger)
 	if err != nil {
 		return fmt.Errorf("failed to create auth flow: %w", err)
 	}
@@ -186,19 +420,130 @@ func runSetupFlow(cfg *config.Config, logger *slog.Logger) error {
 	cfg.Server.Token = result.Token
 	cfg.Server.UserID = result.UserID
 	cfg.Server.Username = result.Username
+	if serverType == config.SourceTypePlex {
+		if err := chooseHomeUser(ctx, cfg, logger); err != nil {
+			return err
+		}
+	}
 
 	if err := config.SaveConfig(cfg); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
 	fmt.Println()
-	fmt.Println("✓ Configuration saved! Starting kino...")
+	fmt.Println(styles.ASCII("✓") + " " + i18n.T("setup.saved"))
 
 	return nil
 }
 
+// localFolder returns the absolute path of input if it names a folder,
+// expanding a leading ~
+func localFolder(input string) (string, bool) {
+	path := strings.TrimPrefix(input, "file://")
+	if rest, ok := strings.CutPrefix(path, "~"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false
+		}
+		path = filepath.Join(home, rest)
+	}
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return "", false
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	return abs, true
+}
+
+// runPlexDiscovery signs in through plex.tv and lets the user pick one of
+// their servers, connecting over LAN, remote, or relay addresses as
+// reachable. For users away from home or without a known server URL.
+func runPlexDiscovery(cfg *config.Config, transport http.RoundTripper, logger *slog.Logger) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	flow := plex.NewAuthFlow(cfg.Server.DeviceID, logger)
+	flow.SetTransport(transport)
+	_, servers, err := flow.Discover(ctx)
+	if err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+	if len(servers) == 0 {
+		return errors.New("no Plex servers found on this account")
+	}
+
+	fmt.Println()
+	fmt.Println(i18n.T("setup.servers"))
+	for i, s := range servers {
+		var notes []string
+		if !s.Owned {
+			notes = append(notes, i18n.T("setup.shared"))
+		}
+		if !s.Online {
+			notes = append(notes, i18n.T("setup.offline"))
+		}
+		label := s.Name
+		if len(notes) > 0 {
+			label += " (" + strings.Join(notes, ", ") + ")"
+		}
+		fmt.Printf("  %d. %s\n", i+1, label)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		server := servers[0]
+		if len(servers) > 1 {
+			fmt.Printf("%s [1-%d]: ", i18n.T("setup.choose_server"), len(servers))
+			input, err := reader.ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("failed to read input: %w", err)
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(input))
+			if err != nil || n < 1 || n > len(servers) {
+				fmt.Println(i18n.T("setup.invalid_choice"))
+				continue
+			}
+			server = servers[n-1]
+		}
+
+		fmt.Println(i18n.T("setup.connecting", server.Name))
+		conn, err := flow.PickConnection(ctx, server)
+		if err != nil {
+			fmt.Println(styles.ASCII("✗") + " " + i18n.T("setup.unreachable", server.Name))
+			if len(servers) == 1 {
+				return fmt.Errorf("%s: %w", server.Name, err)
+			}
+			continue
+		}
+		if conn.Relay {
+			fmt.Println(i18n.T("setup.relay"))
+		}
+
+		cfg.Server.Type = config.SourceTypePlex
+		cfg.Server.URL = conn.URI
+		cfg.Server.Token = server.AccessToken
+		cfg.Server.UserID = ""
+		cfg.Server.Username = ""
+		// Home members get their own token for the owner's servers only
+		if server.Owned {
+			if err := chooseHomeUser(ctx, cfg, logger); err != nil {
+				return err
+			}
+		}
+		if err := config.SaveConfig(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		fmt.Println()
+		fmt.Println(styles.ASCII("✓") + " " + i18n.T("setup.saved"))
+		return nil
+	}
+}
+
 // detectServerWithSpinner detects the server type with a visual spinner
-func detectServerWithSpinner(serverURL string) (config.SourceType, error) {
+func detectServerWithSpinner(serverURL string, transport http.RoundTripper) (config.SourceType, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
@@ -211,7 +556,7 @@ func detectServerWithSpinner(serverURL string) (config.SourceType, error) {
 
 	// Start detection in background
 	go func() {
-		serverType, err := mediaserver.DetectServerType(ctx, serverURL)
+		serverType, err := mediaserver.DetectServerType(ctx, serverURL, transport)
 		resultCh <- result{serverType, err}
 	}()
 
@@ -219,7 +564,7 @@ func detectServerWithSpinner(serverURL string) (config.SourceType, error) {
 	frame := 0
 
 	// Print initial spinner
-	fmt.Printf("\r%s Detecting server type...", styles.SpinnerFrames[frame])
+	fmt.Printf("\r%s %s", styles.SpinnerFrames[frame], i18n.T("setup.detecting"))
 
 	ticker := time.NewTicker(80 * time.Millisecond)
 	defer ticker.Stop()
@@ -236,19 +581,16 @@ func detectServerWithSpinner(serverURL string) (config.SourceType, error) {
 
 			// Show success with server type
 			serverName := "Unknown"
-			switch res.serverType {
-			case config.SourceTypePlex:
-				serverName = "Plex Media Server"
-			case config.SourceTypeJellyfin:
-				serverName = "Jellyfin"
+			if source, ok := mediaserver.Lookup(res.serverType); ok {
+				serverName = source.Name
 			}
-			fmt.Printf("✓ Detected: %s\n", serverName)
+			fmt.Println(styles.ASCII("✓") + " " + i18n.T("setup.detected", serverName))
 
 			return res.serverType, nil
 
 		case <-ticker.C:
 			frame++
-			fmt.Printf("\r%s Detecting server type...", styles.SpinnerFrames[frame%len(styles.SpinnerFrames)])
+			fmt.Printf("\r%s %s", styles.SpinnerFrames[frame%len(styles.SpinnerFrames)], i18n.T("setup.detecting"))
 
 		case <-ctx.Done():
 			fmt.Print(clearSpinnerLine)
@@ -256,3 +598,18 @@ func detectServerWithSpinner(serverURL string) (config.SourceType, error) {
 		}
 	}
 }
+
+// newArrService wires the optional Sonarr/Radarr connections
+func newArrService(cfg *config.Config, logger *slog.Logger) *arr.Service {
+	client := func(kind arr.Kind, c config.ArrConfig) *arr.Client {
+		if !c.Enabled() {
+			return nil
+		}
+		return arr.NewClient(kind, c.URL, c.APIKey, arr.Options{
+			QualityProfileID:  c.QualityProfileID,
+			RootFolder:        c.RootFolder,
+			LanguageProfileID: c.LanguageProfileID,
+		}, logger)
+	}
+	return arr.NewService(client(arr.KindRadarr, cfg.Radarr), client(arr.KindSonarr, cfg.Sonarr), logger)
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/playlist"
+)
+
+// runPlaylist runs kino playlist export / import
+func runPlaylist(args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	flags := flag.NewFlagSet("playlist", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	format := flags.String("format", "", "export format: m3u or json (default from -o, else m3u)")
+	output := flags.String("o", "", "export to a file instead of stdout")
+	noURLs := flags.Bool("no-urls", false, "leave stream URLs (which carry the server token) out of the export")
+	name := flags.String("name", "", "title of the imported playlist (default from the file)")
+	rest, err := parseInterspersed(flags, args[1:])
+	if err != nil || len(rest) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "export":
+		f := playlist.Format(*format)
+		if f == "" && *output != "" {
+			if f, err = playlist.FormatForPath(*output); err != nil {
+				return err
+			}
+		}
+		if f == "" {
+			f = playlist.FormatM3U
+		}
+		if f != playlist.FormatM3U && f != playlist.FormatJSON {
+			return fmt.Errorf("unknown format %q: use m3u or json", f)
+		}
+		return exportPlaylist(strings.Join(rest, " "), f, *output, !*noURLs)
+	case "import":
+		if len(rest) != 1 {
+			return errUsage
+		}
+		return importPlaylist(rest[0], *name)
+	}
+	return errUsage
+}
+
+// parseInterspersed parses flags wherever they appear among the positional
+// arguments, which it returns
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
+
+// exportPlaylist writes a playlist, found by ID or case-insensitive title,
+// to output (stdout when empty)
+func exportPlaylist(ref string, format playlist.Format, output string, withURLs bool) error {
+	env, err := openCLIEnv()
+	if err != nil {
+		return err
+	}
+	defer env.store.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	playlists, err := env.playlists.FetchPlaylists(ctx)
+	if err != nil {
+		return err
+	}
+	var target *domain.Playlist
+	for _, p := range playlists {
+		if p.ID == ref || strings.EqualFold(p.Title, ref) {
+			target = p
+			break
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("no playlist named %q", ref)
+	}
+
+	var resolve playlist.URLResolver
+	if withURLs {
+		resolve = env.playback.PlayableURL
+	}
+	doc, err := env.playlists.Export(ctx, target.ID, resolve)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		return playlist.Write(os.Stdout, doc, format)
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	if err := playlist.Write(f, doc, format); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d items from %s to %s\n", len(doc.Items), target.Title, output)
+	return nil
+}
+
+// importPlaylist creates a server playlist from an M3U or JSON file,
+// matching its entries against the cached libraries
+func importPlaylist(path, title string) error {
+	format, err := playlist.FormatForPath(path)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	doc, err := playlist.Read(f, format)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	if title == "" {
+		title = doc.Title
+	}
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	env, err := openCLIEnv()
+	if err != nil {
+		return err
+	}
+	defer env.store.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	libs, err := env.libraries(ctx)
+	if err != nil {
+		return err
+	}
+	resolve := func(ctx context.Context, ref string) (*domain.MediaItem, error) {
+		link, err := library.ParseLink(ref)
+		if err != nil {
+			return nil, nil
+		}
+		res, err := env.library.Resolve(ctx, libs, link)
+		if errors.Is(err, library.ErrLinkNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return res.Playable(), nil
+	}
+
+	result, err := env.playlists.Import(ctx, doc, title, resolve)
+	if result != nil && len(result.Unmatched) > 0 {
+		fmt.Printf("Not found (%d):\n", len(result.Unmatched))
+		for _, ref := range result.Unmatched {
+			fmt.Printf("  ? %s\n", ref)
+		}
+		fmt.Println()
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Created playlist %s with %d of %d items\n", title, result.Matched, len(doc.Items))
+	return nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/term"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/mediaserver"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// runSwitchUser lists the server's users (Plex Home members, Jellyfin
+// users), signs in as the chosen one and saves their credentials. The new
+// user ID gives them their own cache, so watch state never mixes.
+func runSwitchUser(cfg *config.Config, logger *slog.Logger) error {
+	switcher, err := mediaserver.NewProfileSwitcher(cfg, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	profiles, err := switcher.Profiles(ctx)
+	if errors.Is(err, domain.ErrAuthFailed) {
+		return fmt.Errorf("the saved token can't list users (sign in with the account owner, then try again): %w", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+	if len(profiles) == 0 {
+		return errors.New("no users found on this server")
+	}
+
+	profile, result, err := chooseProfile(ctx, switcher, profiles, i18n.T("setup.switch_to"))
+	if err != nil {
+		return err
+	}
+
+	cfg.Server.Token = result.Token
+	cfg.Server.UserID = result.UserID
+	cfg.Server.Username = result.Username
+	if err := config.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	logger.Info("switched user", "user", result.Username)
+
+	fmt.Println()
+	fmt.Println(styles.ASCII("✓") + " " + i18n.T("setup.signed_in_as", profile.Name))
+	return nil
+}
+
+// chooseHomeUser asks who is watching when the freshly signed-in Plex
+// account belongs to a Home with several members, and switches to the
+// chosen one (with their PIN for protected users). A failed lookup is not
+// fatal: kino carries on as the account owner.
+func chooseHomeUser(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
+	switcher, err := mediaserver.NewProfileSwitcher(cfg, logger)
+	if err != nil {
+		return err
+	}
+	profiles, err := switcher.Profiles(ctx)
+	if err != nil {
+		logger.Warn("could not list Plex Home users", "error", err)
+		return nil
+	}
+	if len(profiles) < 2 {
+		return nil
+	}
+
+	_, result, err := chooseProfile(ctx, switcher, profiles, i18n.T("setup.whos_watching"))
+	if err != nil {
+		return err
+	}
+	cfg.Server.Token = result.Token
+	cfg.Server.UserID = result.UserID
+	cfg.Server.Username = result.Username
+	return nil
+}
+
+// chooseProfile lists profiles, asks for one (and its PIN or password) and
+// signs in as it, asking again after a wrong secret
+func chooseProfile(ctx context.Context, switcher mediaserver.ProfileSwitcher, profiles []mediaserver.Profile, prompt string) (mediaserver.Profile, *mediaserver.AuthResult, error) {
+	fmt.Println()
+	fmt.Println(i18n.T("setup.users"))
+	for i, p := range profiles {
+		var notes []string
+		if p.Current {
+			notes = append(notes, i18n.T("setup.current"))
+		}
+		if p.Secret != "" {
+			notes = append(notes, p.Secret)
+		}
+		label := p.Name
+		if len(notes) > 0 {
+			label += " (" + strings.Join(notes, ", ") + ")"
+		}
+		fmt.Printf("  %d. %s\n", i+1, label)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Printf("%s [1-%d]: ", prompt, len(profiles))
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return mediaserver.Profile{}, nil, fmt.Errorf("failed to read input: %w", err)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(input))
+		if err != nil || n < 1 || n > len(profiles) {
+			fmt.Println(i18n.T("setup.invalid_choice"))
+			continue
+		}
+		profile := profiles[n-1]
+
+		var secret string
+		if profile.Secret != "" {
+			fmt.Print(i18n.T("setup.secret_for", strings.ToUpper(profile.Secret[:1])+profile.Secret[1:], profile.Name))
+			secretBytes, err := term.ReadPassword(int(syscall.Stdin))
+			fmt.Println()
+			if err != nil {
+				return mediaserver.Profile{}, nil, fmt.Errorf("failed to read %s: %w", profile.Secret, err)
+			}
+			secret = string(secretBytes)
+		}
+
+		result, err := switcher.Switch(ctx, profile, secret)
+		if errors.Is(err, domain.ErrAuthFailed) && profile.Secret != "" {
+			fmt.Println(styles.ASCII("✗") + " " + i18n.T("setup.wrong_secret", profile.Secret))
+			continue
+		}
+		if err != nil {
+			return mediaserver.Profile{}, nil, fmt.Errorf("failed to switch to %s: %w", profile.Name, err)
+		}
+		return profile, result, nil
+	}
+}
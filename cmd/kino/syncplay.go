@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/log"
+	"github.com/mmcdole/kino/internal/mediaserver"
+	"github.com/mmcdole/kino/internal/player"
+	"github.com/mmcdole/kino/internal/syncplay"
+)
+
+// runSyncPlay runs kino syncplay: without arguments it lists the server's
+// sync groups, with join <group> it follows one in mpv until mpv closes
+func runSyncPlay(args []string) error {
+	join := len(args) >= 2 && args[0] == "join"
+	if len(args) > 0 && !join {
+		return errUsage
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	logger, err := log.SetupLogger(&cfg.Logging)
+	if err != nil {
+		logger = log.NullLogger()
+	}
+	slog.SetDefault(logger)
+
+	if !cfg.IsConfigured() {
+		return fmt.Errorf("no server configured: run kino once to set up a server first")
+	}
+	client, err := mediaserver.NewClient(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create media client: %w", err)
+	}
+	sp, ok := client.(domain.SyncPlayClient)
+	if !ok {
+		// Plex Watch Together runs through plex.tv with no public API
+		return fmt.Errorf("sync groups need a Jellyfin server (SyncPlay); %s has none kino can join", cfg.Server.Type)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	groups, err := sp.ListSyncGroups(ctx)
+	if err != nil {
+		return err
+	}
+	if !join {
+		printSyncGroups(groups)
+		return nil
+	}
+
+	group, err := findSyncGroup(groups, strings.Join(args[1:], " "))
+	if err != nil {
+		return err
+	}
+	launcher := player.NewLauncher(cfg.Player.Command, cfg.Player.Args, cfg.Player.StartFlag, logger)
+	launcher.SetPreference(cfg.Player.Preference)
+	playback := player.NewService(launcher, client, logger)
+	if cfg.Player.MaxBitrateMbps > 0 {
+		playback.SetMaxBitrate(cfg.Player.MaxBitrateMbps * 1000)
+	}
+	mpv, err := playback.LaunchIdle()
+	if errors.Is(err, player.ErrNotMPV) {
+		return fmt.Errorf("sync groups play in mpv: install it or set player.command to mpv")
+	}
+	if err != nil {
+		return err
+	}
+	defer mpv.Close()
+
+	session, err := sp.JoinSyncGroup(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Joined %s. Close mpv or press Ctrl-C to leave.\n", group.Name)
+	mpv.ShowText("Joined " + group.Name)
+
+	err = syncplay.NewFollower(session, mpv, playback.StreamURL, logger).Run(ctx)
+	leaveCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	session.Leave(leaveCtx)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// findSyncGroup picks a group by ID or case-insensitive name
+func findSyncGroup(groups []domain.SyncGroup, ref string) (domain.SyncGroup, error) {
+	for _, g := range groups {
+		if g.ID == ref || strings.EqualFold(g.Name, ref) {
+			return g, nil
+		}
+	}
+	return domain.SyncGroup{}, fmt.Errorf("no sync group %q: run kino syncplay to list them", ref)
+}
+
+// printSyncGroups lists groups one per line with their members
+func printSyncGroups(groups []domain.SyncGroup) {
+	if len(groups) == 0 {
+		fmt.Println("No sync groups. Start one from a Jellyfin client, then join it here.")
+		return
+	}
+	for _, g := range groups {
+		fmt.Printf("%s  [%s]  %s\n", g.Name, g.State, strings.Join(g.Participants, ", "))
+		fmt.Printf("    id: %s\n", g.ID)
+	}
+}
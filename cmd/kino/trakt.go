@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/log"
+	"github.com/mmcdole/kino/internal/mediaserver"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/trakt"
+)
+
+// runTraktImport copies Trakt watched history to the configured server
+func runTraktImport(dryRun bool) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger, err := log.SetupLogger(&cfg.Logging)
+	if err != nil {
+		logger = log.NullLogger()
+	}
+	slog.SetDefault(logger)
+
+	if !cfg.IsConfigured() {
+		return fmt.Errorf("no server configured: run kino once to set up a server first")
+	}
+	if cfg.Trakt.ClientID == "" || cfg.Trakt.ClientSecret == "" {
+		return fmt.Errorf("trakt.client_id and trakt.client_secret must be set in the config (create an app at https://trakt.tv/oauth/applications)")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	traktClient := trakt.NewClient(cfg.Trakt.ClientID, cfg.Trakt.ClientSecret, cfg.Trakt.AccessToken, logger)
+	if cfg.Trakt.AccessToken == "" {
+		token, err := authenticateTrakt(ctx, traktClient)
+		if err != nil {
+			return fmt.Errorf("trakt authentication failed: %w", err)
+		}
+		cfg.Trakt.AccessToken = token
+		if err := config.SaveConfig(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+
+	client, err := mediaserver.NewClient(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create media client: %w", err)
+	}
+
+	libraryStore, err := store.NewLibraryStore(config.DefaultCachePath(), cfg.Server.URL, cfg.Server.UserID)
+	if err != nil {
+		logger.Warn("store unavailable, continuing memory-only", "error", err)
+		libraryStore, _ = store.NewLibraryStore("", "", "")
+	}
+	defer libraryStore.Close()
+
+	librarySvc := library.NewService(client, libraryStore, logger)
+	importer := trakt.NewImporter(librarySvc, client, traktClient, logger)
+
+	fmt.Println("Matching Trakt history against your server...")
+	report, err := importer.Run(ctx, dryRun)
+	if err != nil {
+		return err
+	}
+
+	printTraktReport(report)
+	return nil
+}
+
+// authenticateTrakt runs the device code flow, mirroring the Plex PIN flow
+func authenticateTrakt(ctx context.Context, client *trakt.Client) (string, error) {
+	code, err := client.RequestDeviceCode(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Println()
+	fmt.Println("To authorize kino with Trakt:")
+	fmt.Printf("  1. Visit: %s\n", code.VerificationURL)
+	fmt.Printf("  2. Enter code: %s\n", code.UserCode)
+	fmt.Println()
+	fmt.Println("Waiting for authorization...")
+
+	token, err := client.WaitForDeviceToken(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	fmt.Println("✓ Trakt authorized")
+	fmt.Println()
+	return token, nil
+}
+
+// printTraktReport prints what the import did (or would do)
+func printTraktReport(r *trakt.Report) {
+	verb := "Marked watched"
+	if r.DryRun {
+		verb = "Would mark watched"
+	}
+
+	fmt.Println()
+	fmt.Printf("%s (%d):\n", verb, len(r.ToMark))
+	for _, e := range r.ToMark {
+		fmt.Printf("  + %s (%d plays on Trakt)\n", e.Label, e.Plays)
+	}
+	if len(r.Failed) > 0 {
+		fmt.Printf("\nFailed (%d):\n", len(r.Failed))
+		for _, e := range r.Failed {
+			fmt.Printf("  ✗ %s\n", e.Label)
+		}
+	}
+	if len(r.Unmatched) > 0 {
+		fmt.Printf("\nNot found on server (%d):\n", len(r.Unmatched))
+		for _, e := range r.Unmatched {
+			fmt.Printf("  ? %s\n", e.Label)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("%d to mark, %d already watched, %d not found",
+		len(r.ToMark), len(r.AlreadyWatched), len(r.Unmatched))
+	if len(r.Failed) > 0 {
+		fmt.Printf(", %d failed", len(r.Failed))
+	}
+	fmt.Println()
+	if r.DryRun {
+		fmt.Println("Dry run: no changes were made. Re-run without --dry-run to apply.")
+	}
+	fmt.Println("Note: servers only store a watched flag; Trakt play counts are not copied.")
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/log"
+	"github.com/mmcdole/kino/internal/mediaserver"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/watchsync"
+)
+
+// syncWatchedUsage describes kino sync-watched
+const syncWatchedUsage = `Usage: kino sync-watched --from <plex|jellyfin> --to <plex|jellyfin> [--dry-run]
+
+Copies watched flags and resume positions from one server to the other.
+One side is the configured server; the other is the "peer" section of the
+config file (see config.example.yaml).`
+
+// runSyncWatched copies watch state between the configured server and the
+// peer server
+func runSyncWatched(args []string) error {
+	flags := flag.NewFlagSet("sync-watched", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	from := flags.String("from", "", "server type to copy from")
+	to := flags.String("to", "", "server type to copy to")
+	dryRun := flags.Bool("dry-run", false, "report what would change without writing")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 || *from == "" || *to == "" || *from == *to {
+		fmt.Fprintln(os.Stderr, syncWatchedUsage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	logger, err := log.SetupLogger(&cfg.Logging)
+	if err != nil {
+		logger = log.NullLogger()
+	}
+	slog.SetDefault(logger)
+
+	if !cfg.IsConfigured() {
+		return fmt.Errorf("no server configured: run kino once to set up a server first")
+	}
+	if cfg.Peer.URL == "" {
+		return fmt.Errorf("no peer server configured: add a peer section with the other server's url (see config.example.yaml)")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := preparePeer(ctx, cfg, logger); err != nil {
+		return err
+	}
+	servers := map[config.SourceType]config.ServerConfig{cfg.Server.Type: cfg.Server, cfg.Peer.Type: cfg.Peer}
+	source, ok := servers[config.SourceType(*from)]
+	if !ok {
+		return fmt.Errorf("neither the configured server nor the peer is %s", *from)
+	}
+	target, ok := servers[config.SourceType(*to)]
+	if !ok {
+		return fmt.Errorf("neither the configured server nor the peer is %s", *to)
+	}
+
+	sourceLib, _, err := openServer(source, logger)
+	if err != nil {
+		return err
+	}
+	targetLib, targetClient, err := openServer(target, logger)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Matching %s watch state against %s...\n", *from, *to)
+	report, err := watchsync.NewSyncer(sourceLib, targetLib, targetClient, logger).Run(ctx, *dryRun)
+	if err != nil {
+		return err
+	}
+	printSyncReport(report)
+	return nil
+}
+
+// preparePeer fills in the peer server's type and signs in to it when it
+// has no token yet, saving both
+func preparePeer(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
+	peer := &cfg.Peer
+	if peer.DeviceID == "" {
+		peer.DeviceID = cfg.Server.DeviceID
+	}
+	if peer.Type != "" && peer.Token != "" {
+		return nil
+	}
+
+	transport, err := mediaserver.NewTransport(*peer)
+	if err != nil {
+		return err
+	}
+	if peer.Type == "" {
+		detectCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+		defer cancel()
+		if peer.Type, err = mediaserver.DetectServerType(detectCtx, peer.URL, transport); err != nil {
+			return fmt.Errorf("could not detect the peer server type: %w", err)
+		}
+	}
+	if peer.Type == cfg.Server.Type {
+		return fmt.Errorf("the peer server is %s too: sync-watched copies between a Plex and a Jellyfin server", peer.Type)
+	}
+	if peer.Token == "" {
+		fmt.Printf("Sign in to the peer server (%s)\n", peer.URL)
+		flow, err := mediaserver.NewAuthFlow(peer.Type, peer.DeviceID, transport, logger)
+		if err != nil {
+			return err
+		}
+		result, err := flow.Run(ctx, peer.URL)
+		if err != nil {
+			return fmt.Errorf("peer authentication failed: %w", err)
+		}
+		peer.Token, peer.UserID, peer.Username = result.Token, result.UserID, result.Username
+	}
+	if err := config.SavePeerServer(*peer); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
+
+// openServer connects to a server with a memory-only cache: watch state
+// must be read fresh, and the peer has no cache of its own
+func openServer(server config.ServerConfig, logger *slog.Logger) (*library.Service, mediaserver.MediaSource, error) {
+	client, err := mediaserver.NewClient(&config.Config{Server: server}, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to %s: %w", server.URL, err)
+	}
+	memory, _ := store.NewLibraryStore("", "", "")
+	return library.NewService(client, memory, logger), client, nil
+}
+
+// printSyncReport prints what the sync did (or would do)
+func printSyncReport(r *watchsync.Report) {
+	markVerb, resumeVerb := "Marked watched", "Resume positions set"
+	if r.DryRun {
+		markVerb, resumeVerb = "Would mark watched", "Would set resume positions"
+	}
+
+	fmt.Println()
+	fmt.Printf("%s (%d):\n", markVerb, len(r.ToMark))
+	for _, e := range r.ToMark {
+		fmt.Printf("  + %s\n", e.Label)
+	}
+	if len(r.ToResume) > 0 {
+		fmt.Printf("\n%s (%d):\n", resumeVerb, len(r.ToResume))
+		for _, e := range r.ToResume {
+			fmt.Printf("  > %s at %s\n", e.Label, e.Offset.Round(time.Second))
+		}
+	}
+	if len(r.Failed) > 0 {
+		fmt.Printf("\nFailed (%d):\n", len(r.Failed))
+		for _, e := range r.Failed {
+			fmt.Printf("  ✗ %s\n", e.Label)
+		}
+	}
+	if len(r.Unmatched) > 0 {
+		fmt.Printf("\nNot found on the target (%d):\n", len(r.Unmatched))
+		for _, label := range r.Unmatched {
+			fmt.Printf("  ? %s\n", label)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("%d to mark, %d to resume, %d already in sync, %d not found",
+		len(r.ToMark), len(r.ToResume), r.InSync, len(r.Unmatched))
+	if len(r.Failed) > 0 {
+		fmt.Printf(", %d failed", len(r.Failed))
+	}
+	fmt.Println()
+	if r.ResumeUnsupported {
+		fmt.Println("Note: the target server can't take resume positions; only watched flags were compared.")
+	}
+	if r.DryRun {
+		fmt.Println("Dry run: no changes were made. Re-run without --dry-run to apply.")
+	}
+}
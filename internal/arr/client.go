@@ -0,0 +1,249 @@
+// Package arr talks to Sonarr and Radarr so search results missing from the
+// media server can show their download status and be requested from Kino.
+package arr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+const (
+	defaultTimeout = 15 * time.Second
+	queuePageSize  = 200
+)
+
+// Kind selects which *arr application a client talks to
+type Kind string
+
+const (
+	KindRadarr Kind = "radarr" // movies
+	KindSonarr Kind = "sonarr" // series
+)
+
+// Options are the defaults used when adding a title
+type Options struct {
+	QualityProfileID  int
+	RootFolder        string
+	LanguageProfileID int // Sonarr v3 only; v4 dropped language profiles
+}
+
+// Client is a minimal Sonarr/Radarr v3 API client: lookup, queue and add
+type Client struct {
+	kind       Kind
+	baseURL    string
+	apiKey     string
+	opts       Options
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewClient creates a client for a Sonarr or Radarr instance
+func NewClient(kind Kind, baseURL, apiKey string, opts Options, logger *slog.Logger) *Client {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Client{
+		kind:    kind,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		opts:    opts,
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		logger: logger,
+	}
+}
+
+// resource is the API resource name for this kind
+func (c *Client) resource() string {
+	if c.kind == KindSonarr {
+		return "series"
+	}
+	return "movie"
+}
+
+// do performs an API request. 401 → domain.ErrAuthFailed, transport
+// failures → domain.ErrServerOffline, any 2xx → success.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, jsonBody interface{}) ([]byte, error) {
+	var reqBody io.Reader
+	if jsonBody != nil {
+		b, err := json.Marshal(jsonBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Api-Key", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	if jsonBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	c.logger.Debug("arr request", "kind", c.kind, "method", method, "path", path)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.Warn("arr request failed", "kind", c.kind, "error", err, "path", path)
+		return nil, fmt.Errorf("%w: %v", domain.ErrServerOffline, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, domain.ErrAuthFailed
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return body, nil
+	default:
+		return nil, fmt.Errorf("%s: unexpected status code: %d", c.kind, resp.StatusCode)
+	}
+}
+
+// Lookup searches the indexer metadata (TMDb/TVDb) for a title. Titles the
+// application already manages come back with ArrID set.
+func (c *Client) Lookup(ctx context.Context, term string) ([]Result, error) {
+	body, err := c.do(ctx, http.MethodGet, "/api/v3/"+c.resource()+"/lookup", url.Values{"term": {term}}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal(body, &raws); err != nil {
+		return nil, fmt.Errorf("failed to parse lookup response: %w", err)
+	}
+
+	results := make([]Result, 0, len(raws))
+	for _, raw := range raws {
+		var res lookupResource
+		if err := json.Unmarshal(raw, &res); err != nil {
+			continue
+		}
+		r := Result{
+			Kind:      c.kind,
+			Title:     res.Title,
+			Year:      res.Year,
+			ArrID:     res.ID,
+			Monitored: res.ID > 0 && res.Monitored,
+			HasFile:   res.HasFile,
+			raw:       raw,
+		}
+		if c.kind == KindSonarr {
+			r.ExternalID = res.TvdbID
+			r.HasFile = res.Statistics != nil && res.Statistics.EpisodeFileCount > 0
+		} else {
+			r.ExternalID = res.TmdbID
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// Queue returns active downloads keyed by the application's movie/series ID.
+// A series with several episodes downloading reports the combined progress.
+func (c *Client) Queue(ctx context.Context) (map[int]QueueStatus, error) {
+	body, err := c.do(ctx, http.MethodGet, "/api/v3/queue", url.Values{
+		"pageSize": {fmt.Sprint(queuePageSize)},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var page queuePage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse queue response: %w", err)
+	}
+
+	type sums struct {
+		size, left float64
+		status     string
+	}
+	acc := make(map[int]*sums)
+	for _, rec := range page.Records {
+		id := rec.MovieID
+		if c.kind == KindSonarr {
+			id = rec.SeriesID
+		}
+		if id == 0 {
+			continue
+		}
+		s := acc[id]
+		if s == nil {
+			s = &sums{status: rec.Status}
+			acc[id] = s
+		}
+		s.size += rec.Size
+		s.left += rec.SizeLeft
+		if rec.Status == "downloading" {
+			s.status = rec.Status // any active download wins over queued/paused
+		}
+	}
+
+	queue := make(map[int]QueueStatus, len(acc))
+	for id, s := range acc {
+		q := QueueStatus{State: s.status}
+		if s.size > 0 {
+			q.Progress = (s.size - s.left) / s.size
+		}
+		queue[id] = q
+	}
+	return queue, nil
+}
+
+// Add adds a lookup result as monitored and starts a search for it
+func (c *Client) Add(ctx context.Context, r Result) (int, error) {
+	if c.opts.QualityProfileID == 0 || c.opts.RootFolder == "" {
+		return 0, fmt.Errorf("%s: quality_profile_id and root_folder must be set in the config", c.kind)
+	}
+
+	// Post the lookup resource back with the add settings merged in, which
+	// carries the metadata (images, seasons, slug) the API expects
+	var payload map[string]interface{}
+	if err := json.Unmarshal(r.raw, &payload); err != nil {
+		return 0, fmt.Errorf("failed to prepare add request: %w", err)
+	}
+	payload["qualityProfileId"] = c.opts.QualityProfileID
+	payload["rootFolderPath"] = c.opts.RootFolder
+	payload["monitored"] = true
+	if c.kind == KindSonarr {
+		payload["seasonFolder"] = true
+		if c.opts.LanguageProfileID > 0 {
+			payload["languageProfileId"] = c.opts.LanguageProfileID
+		}
+		payload["addOptions"] = addOptions{SearchForMissingEpisodes: true, Monitor: "all"}
+	} else {
+		payload["addOptions"] = addOptions{SearchForMovie: true}
+	}
+
+	body, err := c.do(ctx, http.MethodPost, "/api/v3/"+c.resource(), nil, payload)
+	if err != nil {
+		return 0, err
+	}
+	var added lookupResource
+	if err := json.Unmarshal(body, &added); err != nil {
+		return 0, fmt.Errorf("failed to parse add response: %w", err)
+	}
+	return added.ID, nil
+}
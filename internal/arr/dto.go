@@ -0,0 +1,40 @@
+package arr
+
+// lookupResource is the subset of a Radarr movie / Sonarr series resource
+// Kino reads. The full lookup object is kept raw for the add request.
+type lookupResource struct {
+	ID        int    `json:"id"` // 0 when not yet added
+	Title     string `json:"title"`
+	Year      int    `json:"year"`
+	TmdbID    int    `json:"tmdbId"` // Radarr
+	TvdbID    int    `json:"tvdbId"` // Sonarr
+	Monitored bool   `json:"monitored"`
+	HasFile   bool   `json:"hasFile"` // Radarr
+	// Sonarr reports file counts per series instead of HasFile
+	Statistics *struct {
+		EpisodeFileCount int `json:"episodeFileCount"`
+	} `json:"statistics,omitempty"`
+}
+
+// queuePage is a page of /api/v3/queue
+type queuePage struct {
+	TotalRecords int           `json:"totalRecords"`
+	Records      []queueRecord `json:"records"`
+}
+
+// queueRecord is one download in the activity queue
+type queueRecord struct {
+	MovieID              int     `json:"movieId"`  // Radarr
+	SeriesID             int     `json:"seriesId"` // Sonarr
+	Status               string  `json:"status"`   // queued, downloading, paused, completed...
+	TrackedDownloadState string  `json:"trackedDownloadState"`
+	Size                 float64 `json:"size"`
+	SizeLeft             float64 `json:"sizeleft"`
+}
+
+// addOptions are the per-kind options on the add request
+type addOptions struct {
+	SearchForMovie           bool   `json:"searchForMovie,omitempty"`
+	SearchForMissingEpisodes bool   `json:"searchForMissingEpisodes,omitempty"`
+	Monitor                  string `json:"monitor,omitempty"`
+}
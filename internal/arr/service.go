@@ -0,0 +1,169 @@
+package arr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// maxResultsPerKind caps lookup results; metadata lookups return dozens of
+// loosely related titles
+const maxResultsPerKind = 5
+
+// QueueStatus is the download state of a title being grabbed
+type QueueStatus struct {
+	State    string  // queued, downloading, paused, completed...
+	Progress float64 // 0..1
+}
+
+// Result is a lookup hit from Sonarr or Radarr
+type Result struct {
+	Kind       Kind
+	Title      string
+	Year       int
+	ExternalID int // TMDb ID for movies, TVDb ID for series
+	ArrID      int // Sonarr/Radarr ID; 0 when not added yet
+	Monitored  bool
+	HasFile    bool         // Downloaded (any episode, for series)
+	Queue      *QueueStatus // Set while a download is in the queue
+
+	raw json.RawMessage // lookup resource, posted back on add
+}
+
+// Added reports whether the title is already managed by Sonarr/Radarr
+func (r Result) Added() bool {
+	return r.ArrID > 0
+}
+
+// StatusLabel describes the result for display: "downloading 45%",
+// "monitored", "not monitored" or "" when it can be added
+func (r Result) StatusLabel() string {
+	switch {
+	case r.Queue != nil && r.Queue.State == "downloading":
+		return fmt.Sprintf("downloading %d%%", int(r.Queue.Progress*100))
+	case r.Queue != nil:
+		return r.Queue.State
+	case !r.Added():
+		return ""
+	case r.HasFile:
+		return "downloaded"
+	case r.Monitored:
+		return "monitored"
+	default:
+		return "not monitored"
+	}
+}
+
+// Service combines the optional Radarr and Sonarr clients
+type Service struct {
+	radarr *Client
+	sonarr *Client
+	logger *slog.Logger
+}
+
+// NewService creates the service. Either client may be nil when that
+// application isn't configured.
+func NewService(radarr, sonarr *Client, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Service{radarr: radarr, sonarr: sonarr, logger: logger}
+}
+
+// Enabled reports whether any application is configured
+func (s *Service) Enabled() bool {
+	return s != nil && (s.radarr != nil || s.sonarr != nil)
+}
+
+// Search looks the query up on every configured application and annotates
+// the hits with queue status. One application failing doesn't hide the
+// other's results; the error is returned only when all of them fail.
+func (s *Service) Search(ctx context.Context, query string) ([]Result, error) {
+	var clients []*Client
+	for _, c := range []*Client{s.radarr, s.sonarr} {
+		if c != nil {
+			clients = append(clients, c)
+		}
+	}
+
+	type outcome struct {
+		results []Result
+		err     error
+	}
+	outcomes := make([]outcome, len(clients))
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func(i int, c *Client) {
+			defer wg.Done()
+			results, err := s.search(ctx, c, query)
+			outcomes[i] = outcome{results, err}
+		}(i, c)
+	}
+	wg.Wait()
+
+	var all []Result
+	var firstErr error
+	failed := 0
+	for i, o := range outcomes {
+		if o.err != nil {
+			s.logger.Warn("arr lookup failed", "kind", clients[i].kind, "error", o.err)
+			failed++
+			if firstErr == nil {
+				firstErr = o.err
+			}
+			continue
+		}
+		all = append(all, o.results...)
+	}
+	if failed > 0 && failed == len(clients) {
+		return nil, firstErr
+	}
+	return all, nil
+}
+
+// search runs one application's lookup and joins its queue
+func (s *Service) search(ctx context.Context, c *Client, query string) ([]Result, error) {
+	results, err := c.Lookup(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) > maxResultsPerKind {
+		results = results[:maxResultsPerKind]
+	}
+
+	// Queue status is decoration: a failure here keeps the lookup results
+	queue, err := c.Queue(ctx)
+	if err != nil {
+		s.logger.Warn("arr queue fetch failed", "kind", c.kind, "error", err)
+		return results, nil
+	}
+	for i := range results {
+		if q, ok := queue[results[i].ArrID]; ok && results[i].Added() {
+			q := q
+			results[i].Queue = &q
+		}
+	}
+	return results, nil
+}
+
+// Add requests a title: adds it monitored and triggers a search
+func (s *Service) Add(ctx context.Context, r Result) (Result, error) {
+	c := s.radarr
+	if r.Kind == KindSonarr {
+		c = s.sonarr
+	}
+	if c == nil {
+		return r, fmt.Errorf("%s is not configured", r.Kind)
+	}
+	id, err := c.Add(ctx, r)
+	if err != nil {
+		return r, err
+	}
+	r.ArrID = id
+	r.Monitored = true
+	s.logger.Info("added to arr", "kind", r.Kind, "title", r.Title, "id", id)
+	return r, nil
+}
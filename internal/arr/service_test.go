@@ -0,0 +1,69 @@
+package arr
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Lookup hits are joined with the queue, and add posts the lookup resource
+// back with the configured profile and root folder
+func TestRadarrSearchAndAdd(t *testing.T) {
+	var added map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/movie/lookup":
+			if r.URL.Query().Get("term") != "dune" {
+				t.Errorf("term = %q", r.URL.Query().Get("term"))
+			}
+			_, _ = io.WriteString(w, `[
+				{"id": 7, "title": "Dune", "year": 2021, "tmdbId": 438631, "monitored": true},
+				{"title": "Dune", "year": 1984, "tmdbId": 841, "titleSlug": "dune-841"}
+			]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/queue":
+			_, _ = io.WriteString(w, `{"records": [{"movieId": 7, "status": "downloading", "size": 100, "sizeleft": 55}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v3/movie":
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &added)
+			_, _ = io.WriteString(w, `{"id": 8, "title": "Dune"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	radarr := NewClient(KindRadarr, srv.URL+"/", "key", Options{QualityProfileID: 4, RootFolder: "/movies"}, nil)
+	svc := NewService(radarr, nil, nil)
+
+	results, err := svc.Search(context.Background(), "dune")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if got := results[0].StatusLabel(); got != "downloading 45%" {
+		t.Fatalf("queued title status = %q", got)
+	}
+	if results[1].Added() || results[1].StatusLabel() != "" {
+		t.Fatalf("missing title reported as added: %+v", results[1])
+	}
+
+	r, err := svc.Add(context.Background(), results[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ArrID != 8 || !r.Monitored {
+		t.Fatalf("add result = %+v", r)
+	}
+	if added["titleSlug"] != "dune-841" || added["rootFolderPath"] != "/movies" || added["qualityProfileId"] != float64(4) {
+		t.Fatalf("add payload = %v", added)
+	}
+}
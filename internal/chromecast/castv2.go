@@ -0,0 +1,198 @@
+package chromecast
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// The CASTV2 channels kino talks on
+const (
+	nsConnection = "urn:x-cast:com.google.cast.tp.connection"
+	nsHeartbeat  = "urn:x-cast:com.google.cast.tp.heartbeat"
+	nsReceiver   = "urn:x-cast:com.google.cast.receiver"
+	nsMedia      = "urn:x-cast:com.google.cast.media"
+)
+
+const (
+	senderID   = "sender-0"
+	receiverID = "receiver-0"
+)
+
+// maxMessage bounds a message from the device; status messages are a few
+// kilobytes
+const maxMessage = 1 << 20
+
+// message is a CastMessage with a UTF-8 payload, the only kind kino sends
+// or reads
+type message struct {
+	source, destination string
+	namespace           string
+	payload             []byte
+}
+
+// marshal encodes the message as the protobuf CastMessage: fields 1
+// protocol_version (CASTV2_1_0 = 0), 2 source_id, 3 destination_id,
+// 4 namespace, 5 payload_type (STRING = 0) and 6 payload_utf8
+func (m message) marshal() []byte {
+	b := []byte{0x08, 0}
+	b = appendField(b, 2, []byte(m.source))
+	b = appendField(b, 3, []byte(m.destination))
+	b = appendField(b, 4, []byte(m.namespace))
+	b = append(b, 0x28, 0)
+	return appendField(b, 6, m.payload)
+}
+
+func appendField(b []byte, field int, data []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(field)<<3|2)
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+// unmarshalMessage decodes a CastMessage, skipping the fields kino doesn't
+// read
+func unmarshalMessage(b []byte) (message, error) {
+	var m message
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return m, errors.New("malformed cast message")
+		}
+		b = b[n:]
+		switch key & 7 {
+		case 0:
+			if _, n = binary.Uvarint(b); n <= 0 {
+				return m, errors.New("malformed cast message")
+			}
+			b = b[n:]
+		case 2:
+			size, n := binary.Uvarint(b)
+			if n <= 0 || size > uint64(len(b)-n) {
+				return m, errors.New("malformed cast message")
+			}
+			data := b[n : n+int(size)]
+			b = b[n+int(size):]
+			switch key >> 3 {
+			case 2:
+				m.source = string(data)
+			case 3:
+				m.destination = string(data)
+			case 4:
+				m.namespace = string(data)
+			case 6:
+				m.payload = data
+			}
+		default:
+			return m, fmt.Errorf("unexpected cast message wire type %d", key&7)
+		}
+	}
+	return m, nil
+}
+
+// session is a CASTV2 connection to a device: each message is framed by
+// its length as a 4-byte big-endian prefix
+type session struct {
+	conn      net.Conn
+	requestID int
+}
+
+func newSession(conn net.Conn) *session {
+	return &session{conn: conn}
+}
+
+// send writes a JSON payload to a destination on a channel
+func (s *session) send(namespace, destination string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	body := message{source: senderID, destination: destination, namespace: namespace, payload: data}.marshal()
+	frame := binary.BigEndian.AppendUint32(make([]byte, 0, 4+len(body)), uint32(len(body)))
+	if _, err := s.conn.Write(append(frame, body...)); err != nil {
+		return fmt.Errorf("failed to send to device: %w", err)
+	}
+	return nil
+}
+
+// receive reads the next message, answering the device's heartbeat
+// pings on the way
+func (s *session) receive() (message, error) {
+	for {
+		var size [4]byte
+		if _, err := io.ReadFull(s.conn, size[:]); err != nil {
+			return message{}, fmt.Errorf("failed to read from device: %w", err)
+		}
+		n := binary.BigEndian.Uint32(size[:])
+		if n > maxMessage {
+			return message{}, fmt.Errorf("cast message of %d bytes is too large", n)
+		}
+		body := make([]byte, n)
+		if _, err := io.ReadFull(s.conn, body); err != nil {
+			return message{}, fmt.Errorf("failed to read from device: %w", err)
+		}
+		msg, err := unmarshalMessage(body)
+		if err != nil {
+			return message{}, err
+		}
+		if msg.namespace == nsHeartbeat {
+			if typeOf(msg.payload) == "PING" {
+				if err := s.send(nsHeartbeat, msg.source, map[string]string{"type": "PONG"}); err != nil {
+					return message{}, err
+				}
+			}
+			continue
+		}
+		return msg, nil
+	}
+}
+
+// connect opens the virtual connection to a destination, the receiver
+// itself or an app's transport
+func (s *session) connect(destination string) error {
+	return s.send(nsConnection, destination, map[string]string{"type": "CONNECT"})
+}
+
+// request sends a payload with the next request ID and waits for the
+// reply to it, skipping the status broadcasts in between
+func (s *session) request(namespace, destination string, payload map[string]any) (message, error) {
+	s.requestID++
+	payload["requestId"] = s.requestID
+	if err := s.send(namespace, destination, payload); err != nil {
+		return message{}, err
+	}
+	for {
+		msg, err := s.receive()
+		if err != nil {
+			return message{}, err
+		}
+		var reply struct {
+			RequestID int `json:"requestId"`
+		}
+		if json.Unmarshal(msg.payload, &reply) == nil && reply.RequestID == s.requestID && msg.namespace == namespace {
+			return msg, nil
+		}
+	}
+}
+
+// setDeadline bounds the whole exchange by ctx
+func (s *session) setDeadline(ctx context.Context) {
+	if d, ok := ctx.Deadline(); ok {
+		s.conn.SetDeadline(d)
+	} else {
+		s.conn.SetDeadline(time.Time{})
+	}
+}
+
+// typeOf reads a payload's message type, e.g. "RECEIVER_STATUS"
+func typeOf(payload []byte) string {
+	var p struct {
+		Type string `json:"type"`
+	}
+	json.Unmarshal(payload, &p)
+	return p.Type
+}
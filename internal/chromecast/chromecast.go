@@ -0,0 +1,284 @@
+// Package chromecast finds Cast devices on the LAN over mDNS and plays
+// streams on them over the CASTV2 protocol, using the device's Default
+// Media Receiver app.
+package chromecast
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// defaultReceiver is the app ID of the Default Media Receiver, which
+// plays a URL it is given
+const defaultReceiver = "CC1AD845"
+
+// Seek steps for rewind and fast-forward, matching the Plex players'
+const (
+	rewindStep      = 15 * time.Second
+	fastForwardStep = 30 * time.Second
+)
+
+// ErrNotPlaying is returned by Control when the device plays nothing kino
+// can control
+var ErrNotPlaying = errors.New("nothing is playing on the device")
+
+// Device is a Cast device that answered discovery
+type Device struct {
+	ID    string // The device's UUID, stable across restarts
+	Name  string // "Living Room TV", the name set in Google Home
+	Model string // "Chromecast Ultra"
+	Addr  string // host:port of its CASTV2 endpoint
+}
+
+// Media is what Play hands the device
+type Media struct {
+	URL    string
+	Title  string
+	Offset time.Duration
+}
+
+// receiverStatus is the part of a RECEIVER_STATUS kino reads
+type receiverStatus struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+	Status struct {
+		Applications []struct {
+			AppID       string `json:"appId"`
+			SessionID   string `json:"sessionId"`
+			TransportID string `json:"transportId"`
+			Namespaces  []struct {
+				Name string `json:"name"`
+			} `json:"namespaces"`
+		} `json:"applications"`
+	} `json:"status"`
+}
+
+// mediaApp finds the running app that plays media: the one Play launched,
+// or another sender's
+func (r receiverStatus) mediaApp(appID string) (sessionID, transportID string, ok bool) {
+	for _, app := range r.Status.Applications {
+		if appID != "" {
+			if app.AppID == appID {
+				return app.SessionID, app.TransportID, true
+			}
+			continue
+		}
+		for _, ns := range app.Namespaces {
+			if ns.Name == nsMedia {
+				return app.SessionID, app.TransportID, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// mediaStatus is the part of a MEDIA_STATUS kino reads
+type mediaStatus struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+	Status []struct {
+		MediaSessionID int     `json:"mediaSessionId"`
+		PlayerState    string  `json:"playerState"`
+		CurrentTime    float64 `json:"currentTime"`
+	} `json:"status"`
+}
+
+// dial opens a CASTV2 session. Devices present a certificate of their own
+// making, so it isn't verified.
+func dial(ctx context.Context, addr string) (*session, error) {
+	dialer := &tls.Dialer{Config: &tls.Config{InsecureSkipVerify: true}}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	s := newSession(conn)
+	s.setDeadline(ctx)
+	if err := s.connect(receiverID); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Play launches the Default Media Receiver on the device at addr and has
+// it stream the media
+func Play(ctx context.Context, addr string, media Media) error {
+	s, err := dial(ctx, addr)
+	if err != nil {
+		return err
+	}
+	defer s.conn.Close()
+	return s.play(media)
+}
+
+func (s *session) play(media Media) error {
+	reply, err := s.request(nsReceiver, receiverID, map[string]any{"type": "LAUNCH", "appId": defaultReceiver})
+	if err != nil {
+		return err
+	}
+	// The reply may come before the app is up; its status follows
+	var transportID string
+	for {
+		if reply.namespace == nsReceiver {
+			var status receiverStatus
+			if err := json.Unmarshal(reply.payload, &status); err != nil {
+				return fmt.Errorf("failed to parse receiver status: %w", err)
+			}
+			if status.Type == "LAUNCH_ERROR" || status.Type == "INVALID_REQUEST" {
+				return fmt.Errorf("device could not start the media receiver: %s", status.Reason)
+			}
+			var ok bool
+			if _, transportID, ok = status.mediaApp(defaultReceiver); ok && transportID != "" {
+				break
+			}
+		}
+		if reply, err = s.receive(); err != nil {
+			return err
+		}
+	}
+
+	if err := s.connect(transportID); err != nil {
+		return err
+	}
+	reply, err = s.request(nsMedia, transportID, map[string]any{
+		"type": "LOAD",
+		"media": map[string]any{
+			"contentId":   s.reachable(media.URL),
+			"streamType":  "BUFFERED",
+			"contentType": contentType(media.URL),
+			"metadata":    map[string]any{"metadataType": 0, "title": media.Title},
+		},
+		"autoplay":    true,
+		"currentTime": media.Offset.Seconds(),
+	})
+	if err != nil {
+		return err
+	}
+	var status mediaStatus
+	if err := json.Unmarshal(reply.payload, &status); err != nil {
+		return fmt.Errorf("failed to parse media status: %w", err)
+	}
+	if status.Type != "MEDIA_STATUS" {
+		return fmt.Errorf("device refused the stream: %s", strings.ToLower(status.Type))
+	}
+	return nil
+}
+
+// Control sends a remote-control command to whatever media the device at
+// addr plays
+func Control(ctx context.Context, addr string, cmd domain.CastCommand) error {
+	s, err := dial(ctx, addr)
+	if err != nil {
+		return err
+	}
+	defer s.conn.Close()
+	return s.control(cmd)
+}
+
+func (s *session) control(cmd domain.CastCommand) error {
+	reply, err := s.request(nsReceiver, receiverID, map[string]any{"type": "GET_STATUS"})
+	if err != nil {
+		return err
+	}
+	var receiver receiverStatus
+	if err := json.Unmarshal(reply.payload, &receiver); err != nil {
+		return fmt.Errorf("failed to parse receiver status: %w", err)
+	}
+	sessionID, transportID, ok := receiver.mediaApp("")
+	if !ok {
+		return ErrNotPlaying
+	}
+	if cmd == domain.CastStop {
+		_, err := s.request(nsReceiver, receiverID, map[string]any{"type": "STOP", "sessionId": sessionID})
+		return err
+	}
+
+	if err := s.connect(transportID); err != nil {
+		return err
+	}
+	reply, err = s.request(nsMedia, transportID, map[string]any{"type": "GET_STATUS"})
+	if err != nil {
+		return err
+	}
+	var media mediaStatus
+	if err := json.Unmarshal(reply.payload, &media); err != nil {
+		return fmt.Errorf("failed to parse media status: %w", err)
+	}
+	if len(media.Status) == 0 {
+		return ErrNotPlaying
+	}
+	current := media.Status[0]
+
+	payload := map[string]any{"mediaSessionId": current.MediaSessionID}
+	switch cmd {
+	case domain.CastPlayPause:
+		payload["type"] = "PAUSE"
+		if current.PlayerState == "PAUSED" {
+			payload["type"] = "PLAY"
+		}
+	case domain.CastRewind:
+		payload["type"] = "SEEK"
+		payload["currentTime"] = max(current.CurrentTime-rewindStep.Seconds(), 0)
+	case domain.CastFastForward:
+		payload["type"] = "SEEK"
+		payload["currentTime"] = current.CurrentTime + fastForwardStep.Seconds()
+	default:
+		return fmt.Errorf("unknown cast command %d", cmd)
+	}
+	_, err = s.request(nsMedia, transportID, payload)
+	return err
+}
+
+// reachable swaps a loopback host in a stream URL for this machine's
+// address on the device's network: a server on localhost is on this
+// machine, not on the device
+func (s *session) reachable(stream string) string {
+	u, err := url.Parse(stream)
+	if err != nil {
+		return stream
+	}
+	ip := net.ParseIP(u.Hostname())
+	if u.Hostname() != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return stream
+	}
+	local, ok := s.conn.LocalAddr().(*net.TCPAddr)
+	if !ok {
+		return stream
+	}
+	if port := u.Port(); port != "" {
+		u.Host = net.JoinHostPort(local.IP.String(), port)
+	} else {
+		u.Host = local.IP.String()
+	}
+	return u.String()
+}
+
+// contentType guesses a stream's MIME type from its path; the receiver
+// needs it to pick a player, and servers' direct streams are mostly MP4
+// or Matroska
+func contentType(stream string) string {
+	u, err := url.Parse(stream)
+	if err != nil {
+		return "video/mp4"
+	}
+	switch strings.ToLower(path.Ext(u.Path)) {
+	case ".m3u8":
+		return "application/x-mpegurl"
+	case ".mkv":
+		return "video/x-matroska"
+	case ".webm":
+		return "video/webm"
+	default:
+		return "video/mp4"
+	}
+}
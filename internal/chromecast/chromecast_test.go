@@ -0,0 +1,160 @@
+package chromecast
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// record appends a DNS resource record; name is raw, so a test can use a
+// compression pointer
+func record(msg, name []byte, rtype uint16, data []byte) []byte {
+	msg = append(msg, name...)
+	msg = binary.BigEndian.AppendUint16(msg, rtype)
+	msg = binary.BigEndian.AppendUint16(msg, 0x8001)
+	msg = binary.BigEndian.AppendUint32(msg, 120)
+	msg = binary.BigEndian.AppendUint16(msg, uint16(len(data)))
+	return append(msg, data...)
+}
+
+// A device's answer names it by its TXT record and addresses it by its
+// SRV port and A record, with names compressed
+func TestDiscoveryAnswer(t *testing.T) {
+	msg := make([]byte, 12)
+	binary.BigEndian.PutUint16(msg[2:], 0x8400)
+	binary.BigEndian.PutUint16(msg[6:], 1) // PTR answer
+	binary.BigEndian.PutUint16(msg[10:], 3)
+
+	serviceOff := len(msg)
+	ptr := append([]byte{14}, "Chromecast-abc"...)
+	ptr = append(ptr, 0xc0, byte(serviceOff)) // Then the service name
+	msg = record(msg, appendName(nil, service), typePTR, ptr)
+	instanceOff := serviceOff + len(appendName(nil, service)) + 10
+
+	srvData := []byte{0, 0, 0, 0, 0x1f, 0x49} // Port 8009
+	srvData = appendName(srvData, "abc.local.")
+	msg = record(msg, []byte{0xc0, byte(instanceOff)}, typeSRV, srvData)
+
+	var txt []byte
+	for _, kv := range []string{"id=abc123", "md=Chromecast Ultra", "fn=Living Room TV"} {
+		txt = append(append(txt, byte(len(kv))), kv...)
+	}
+	msg = record(msg, []byte{0xc0, byte(instanceOff)}, typeTXT, txt)
+	msg = record(msg, appendName(nil, "abc.local."), typeA, []byte{192, 168, 1, 40})
+
+	found := newAnswers()
+	if err := found.parse(msg, net.IPv4(192, 168, 1, 99)); err != nil {
+		t.Fatal(err)
+	}
+	found.parse(msg, net.IPv4(192, 168, 1, 99)) // Answered twice
+
+	devices := found.devices()
+	want := Device{ID: "abc123", Name: "Living Room TV", Model: "Chromecast Ultra", Addr: "192.168.1.40:8009"}
+	if len(devices) != 1 || devices[0] != want {
+		t.Fatalf("devices = %+v, want [%+v]", devices, want)
+	}
+}
+
+// fakeDevice answers a sender over the CASTV2 framing, calling reply for
+// each message it receives, and returns the sender's end. It listens on
+// TCP rather than a pipe, since both ends write at once.
+func fakeDevice(t *testing.T, reply func(dev *session, msg message, payload map[string]any)) *session {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	sender, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sender.Close() })
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender.SetDeadline(time.Now().Add(5 * time.Second))
+
+	dev := newSession(conn)
+	go func() {
+		defer conn.Close()
+		for {
+			msg, err := dev.receive()
+			if err != nil {
+				return
+			}
+			var payload map[string]any
+			json.Unmarshal(msg.payload, &payload)
+			reply(dev, msg, payload)
+		}
+	}()
+	return newSession(sender)
+}
+
+// Playing launches the media receiver, waits for it to come up and loads
+// the stream at the offset, answering the device's pings on the way
+func TestPlayLoadsStream(t *testing.T) {
+	loads := make(chan map[string]any, 1)
+	s := fakeDevice(t, func(dev *session, msg message, p map[string]any) {
+		switch p["type"] {
+		case "LAUNCH":
+			dev.send(nsHeartbeat, msg.source, map[string]string{"type": "PING"})
+			dev.send(nsReceiver, msg.source, map[string]any{"type": "RECEIVER_STATUS", "requestId": p["requestId"]})
+			dev.send(nsReceiver, msg.source, map[string]any{"type": "RECEIVER_STATUS", "requestId": 0, "status": map[string]any{
+				"applications": []map[string]any{{"appId": defaultReceiver, "sessionId": "s-1", "transportId": "t-1"}},
+			}})
+		case "LOAD":
+			if msg.destination != "t-1" {
+				t.Errorf("LOAD went to %q, want the app's transport", msg.destination)
+			}
+			loads <- p
+			dev.send(nsMedia, msg.source, map[string]any{"type": "MEDIA_STATUS", "requestId": p["requestId"]})
+		}
+	})
+
+	err := s.play(Media{URL: "http://localhost:32400/video/start.m3u8?X-Plex-Token=x", Title: "Heat", Offset: 90 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := <-loads
+	media, _ := loaded["media"].(map[string]any)
+	if media["contentId"] != "http://127.0.0.1:32400/video/start.m3u8?X-Plex-Token=x" {
+		t.Errorf("contentId = %v, want the server at the address the device reaches", media["contentId"])
+	}
+	if media["contentType"] != "application/x-mpegurl" || loaded["currentTime"] != 90.0 {
+		t.Errorf("LOAD = %v", loaded)
+	}
+}
+
+// Rewinding seeks back from the current position, never before the start
+func TestControlRewind(t *testing.T) {
+	seeks := make(chan map[string]any, 1)
+	s := fakeDevice(t, func(dev *session, msg message, p map[string]any) {
+		switch {
+		case msg.namespace == nsReceiver && p["type"] == "GET_STATUS":
+			dev.send(nsReceiver, msg.source, map[string]any{"type": "RECEIVER_STATUS", "requestId": p["requestId"], "status": map[string]any{
+				"applications": []map[string]any{{"appId": "other", "sessionId": "s-1", "transportId": "t-1",
+					"namespaces": []map[string]string{{"name": nsMedia}}}},
+			}})
+		case msg.namespace == nsMedia && p["type"] == "GET_STATUS":
+			dev.send(nsMedia, msg.source, map[string]any{"type": "MEDIA_STATUS", "requestId": p["requestId"], "status": []map[string]any{
+				{"mediaSessionId": 7, "playerState": "PLAYING", "currentTime": 5.0},
+			}})
+		case p["type"] == "SEEK":
+			seeks <- p
+			dev.send(nsMedia, msg.source, map[string]any{"type": "MEDIA_STATUS", "requestId": p["requestId"]})
+		}
+	})
+
+	if err := s.control(domain.CastRewind); err != nil {
+		t.Fatal(err)
+	}
+	if seek := <-seeks; seek["mediaSessionId"] != 7.0 || seek["currentTime"] != 0.0 {
+		t.Errorf("SEEK = %v", seek)
+	}
+}
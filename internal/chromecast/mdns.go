@@ -0,0 +1,257 @@
+package chromecast
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// service is the mDNS service type Cast devices advertise
+const service = "_googlecast._tcp.local."
+
+// mdnsGroup is where mDNS queries go. Asked from an ephemeral port, the
+// devices answer to that port directly (a "legacy unicast" query).
+var mdnsGroup = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+const (
+	typeA   = 1
+	typePTR = 12
+	typeTXT = 16
+	typeSRV = 33
+)
+
+// Discover asks the LAN for Cast devices and lists those that answer
+// within wait (or before ctx ends)
+func Discover(ctx context.Context, wait time.Duration) ([]Device, error) {
+	conn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mDNS socket: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.WriteToUDP(query(service), mdnsGroup); err != nil {
+		return nil, fmt.Errorf("mDNS query failed: %w", err)
+	}
+	deadline := time.Now().Add(wait)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetReadDeadline(deadline)
+
+	found := newAnswers()
+	buf := make([]byte, 9000)
+	for {
+		n, from, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Timeout() {
+				break
+			}
+			return nil, fmt.Errorf("mDNS read failed: %w", err)
+		}
+		// A malformed answer is some other responder's problem
+		_ = found.parse(buf[:n], from.IP)
+	}
+	return found.devices(), nil
+}
+
+// query builds a DNS question for the PTR records of name, asking for a
+// unicast answer
+func query(name string) []byte {
+	msg := make([]byte, 12, 64)
+	binary.BigEndian.PutUint16(msg[4:], 1) // One question
+	msg = appendName(msg, name)
+	msg = binary.BigEndian.AppendUint16(msg, typePTR)
+	return binary.BigEndian.AppendUint16(msg, 0x8001) // IN, unicast response
+}
+
+func appendName(msg []byte, name string) []byte {
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	return append(msg, 0)
+}
+
+// srv is where an instance listens
+type srv struct {
+	host string
+	port int
+}
+
+// answers gathers the records of every response; a device's records may
+// arrive split across them
+type answers struct {
+	instances []string // PTR targets, in the order seen
+	srv       map[string]srv
+	txt       map[string]map[string]string
+	addr      map[string]net.IP // A records by host name
+	from      map[string]net.IP // Who answered for an instance
+}
+
+func newAnswers() *answers {
+	return &answers{
+		srv:  make(map[string]srv),
+		txt:  make(map[string]map[string]string),
+		addr: make(map[string]net.IP),
+		from: make(map[string]net.IP),
+	}
+}
+
+// parse reads the records of one DNS message sent by from
+func (a *answers) parse(msg []byte, from net.IP) error {
+	if len(msg) < 12 {
+		return errors.New("short DNS message")
+	}
+	questions := int(binary.BigEndian.Uint16(msg[4:]))
+	records := int(binary.BigEndian.Uint16(msg[6:])) + int(binary.BigEndian.Uint16(msg[8:])) + int(binary.BigEndian.Uint16(msg[10:]))
+	off := 12
+	for range questions {
+		_, next, err := readName(msg, off)
+		if err != nil {
+			return err
+		}
+		off = next + 4
+	}
+	for range records {
+		name, next, err := readName(msg, off)
+		if err != nil {
+			return err
+		}
+		if next+10 > len(msg) {
+			return errors.New("truncated DNS record")
+		}
+		rtype := binary.BigEndian.Uint16(msg[next:])
+		length := int(binary.BigEndian.Uint16(msg[next+8:]))
+		data := next + 10
+		if data+length > len(msg) {
+			return errors.New("truncated DNS record")
+		}
+		off = data + length
+		key := strings.ToLower(name)
+
+		switch rtype {
+		case typePTR:
+			if key != service {
+				continue
+			}
+			target, _, err := readName(msg, data)
+			if err != nil {
+				return err
+			}
+			target = strings.ToLower(target)
+			if _, seen := a.from[target]; !seen {
+				a.instances = append(a.instances, target)
+			}
+			a.from[target] = from
+		case typeSRV:
+			if length < 7 {
+				continue
+			}
+			host, _, err := readName(msg, data+6)
+			if err != nil {
+				return err
+			}
+			a.srv[key] = srv{host: strings.ToLower(host), port: int(binary.BigEndian.Uint16(msg[data+4:]))}
+		case typeTXT:
+			a.txt[key] = readTXT(msg[data:off])
+		case typeA:
+			if length == 4 {
+				a.addr[key] = net.IP(append([]byte(nil), msg[data:off]...))
+			}
+		}
+	}
+	return nil
+}
+
+// devices lists each advertised instance once, by device ID
+func (a *answers) devices() []Device {
+	var devices []Device
+	seen := make(map[string]bool)
+	for _, instance := range a.instances {
+		txt := a.txt[instance]
+		id := txt["id"]
+		if id == "" {
+			id = instance
+		}
+		if seen[id] {
+			continue
+		}
+		ip, port := a.from[instance], 8009
+		if s, ok := a.srv[instance]; ok {
+			port = s.port
+			if addr, ok := a.addr[s.host]; ok {
+				ip = addr
+			}
+		}
+		name := txt["fn"]
+		if name == "" {
+			name, _, _ = strings.Cut(instance, ".")
+		}
+		seen[id] = true
+		devices = append(devices, Device{
+			ID:    id,
+			Name:  name,
+			Model: txt["md"],
+			Addr:  net.JoinHostPort(ip.String(), strconv.Itoa(port)),
+		})
+	}
+	return devices
+}
+
+// readName decodes the possibly compressed name at off and returns it with
+// the offset just past it
+func readName(msg []byte, off int) (string, int, error) {
+	var labels []string
+	end := -1
+	for jumps := 0; ; {
+		if off >= len(msg) {
+			return "", 0, errors.New("truncated DNS name")
+		}
+		n := int(msg[off])
+		switch {
+		case n == 0:
+			if end < 0 {
+				end = off + 1
+			}
+			return strings.Join(labels, ".") + ".", end, nil
+		case n&0xc0 == 0xc0:
+			if off+1 >= len(msg) {
+				return "", 0, errors.New("truncated DNS name")
+			}
+			if jumps++; jumps > 16 {
+				return "", 0, errors.New("DNS name loops")
+			}
+			if end < 0 {
+				end = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(msg[off:]) & 0x3fff)
+		default:
+			if off+1+n > len(msg) {
+				return "", 0, errors.New("truncated DNS name")
+			}
+			labels = append(labels, string(msg[off+1:off+1+n]))
+			off += 1 + n
+		}
+	}
+}
+
+// readTXT splits TXT record data into its key=value strings
+func readTXT(data []byte) map[string]string {
+	txt := make(map[string]string)
+	for len(data) > 0 {
+		n := int(data[0])
+		if 1+n > len(data) {
+			break
+		}
+		key, value, _ := strings.Cut(string(data[1:1+n]), "=")
+		txt[strings.ToLower(key)] = value
+		data = data[1+n:]
+	}
+	return txt
+}
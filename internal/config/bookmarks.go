@@ -0,0 +1,53 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Bookmarks are navigation locations saved to the number keys, kept across
+// launches
+type Bookmarks struct {
+	// Server and User scope the IDs in the slots, as for Session
+	Server string `json:"server"`
+	User   string `json:"user,omitempty"`
+
+	// Slots maps a number key (1-9) to its location
+	Slots map[int]Bookmark `json:"slots,omitempty"`
+}
+
+// Bookmark is one saved location: a library and the columns opened below
+// it, in the shape a Session restores
+type Bookmark struct {
+	// Label names the location in notices, e.g. "Breaking Bad › Season 2"
+	Label     string          `json:"label"`
+	LibraryID string          `json:"library_id"`
+	Columns   []ColumnSession `json:"columns,omitempty"`
+}
+
+// DefaultBookmarksPath returns the bookmarks file path, next to the session
+func DefaultBookmarksPath() string {
+	return filepath.Join(filepath.Dir(DefaultCachePath()), "bookmarks.json")
+}
+
+// LoadBookmarks reads saved bookmarks. A missing file returns nil, nil.
+func LoadBookmarks(path string) (*Bookmarks, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var b Bookmarks
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// SaveBookmarks writes the bookmarks atomically, like SaveSession
+func SaveBookmarks(path string, b Bookmarks) error {
+	return writeJSONAtomic(path, b)
+}
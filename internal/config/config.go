@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"runtime"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -18,6 +18,10 @@ type SourceType string
 const (
 	SourceTypePlex     SourceType = "plex"
 	SourceTypeJellyfin SourceType = "jellyfin"
+	SourceTypeLocal    SourceType = "local"
+	SourceTypeDLNA     SourceType = "dlna"
+	// SourceTypeTorrServer is experimental and only built with -tags torrent
+	SourceTypeTorrServer SourceType = "torrserver"
 )
 
 // Config holds all application configuration
@@ -26,77 +30,301 @@ type Config struct {
 	Player  PlayerConfig  `mapstructure:"player"`
 	UI      UIConfig      `mapstructure:"ui"`
 	Logging LoggingConfig `mapstructure:"logging"`
+	Trakt   TraktConfig   `mapstructure:"trakt"`
+	Radarr  ArrConfig     `mapstructure:"radarr"`
+	Sonarr  ArrConfig     `mapstructure:"sonarr"`
+
+	Search   SearchConfig   `mapstructure:"search"`
+	Security SecurityConfig `mapstructure:"security"`
+	Metrics  MetricsConfig  `mapstructure:"metrics"`
+
+	// Peer is a second server to copy watch state to or from with
+	// kino sync-watched. Browsing always uses Server.
+	Peer ServerConfig `mapstructure:"peer"`
 }
 
 // ServerConfig holds media server configuration
 type ServerConfig struct {
-	Type     SourceType `mapstructure:"type"`      // "plex" or "jellyfin"
-	URL      string     `mapstructure:"url"`       // Server URL
+	Type     SourceType `mapstructure:"type"`      // "plex", "jellyfin", "local" or "dlna"
+	URL      string     `mapstructure:"url"`       // Server URL, or the folder of a local source
 	Token    string     `mapstructure:"token"`     // Plex token OR Jellyfin API key
-	UserID   string     `mapstructure:"user_id"`   // Jellyfin only
-	Username string     `mapstructure:"username"`  // Jellyfin only (display)
+	UserID   string     `mapstructure:"user_id"`   // Jellyfin user, or the Plex Home user switched to
+	Username string     `mapstructure:"username"`  // Display name of UserID
 	DeviceID string     `mapstructure:"device_id"` // Unique per-install device identifier
+
+	// TokenStore is where the token lives: "keychain" (set automatically
+	// when the OS keychain is usable) or "plaintext" to keep it in this file
+	TokenStore string `mapstructure:"token_store"`
+
+	// KeepAlivePing sends HTTP/2 pings on idle connections to detect
+	// connections dropped by NATs/proxies (HTTPS servers only)
+	KeepAlivePing bool `mapstructure:"keepalive_ping"`
+
+	// TLS for servers with self-signed or privately issued certificates
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"` // Accept any certificate (not recommended)
+	CAFile             string `mapstructure:"ca_file"`              // Extra PEM CA bundle to trust
+
+	// Request limits so parallel syncs don't overwhelm small servers
+	MaxConcurrentRequests int     `mapstructure:"max_concurrent_requests"` // In-flight API requests; 0 = unlimited
+	RequestsPerSecond     float64 `mapstructure:"requests_per_second"`     // 0 = unlimited
+	SyncConcurrency       int     `mapstructure:"sync_concurrency"`        // Libraries synced at once, selected one first; 0 = all
+
+	// SyncProfile is "full", "fast" or "remote", or "auto" (default) to
+	// pick full or remote by the server's round-trip time. Fast listings
+	// skip media details (codecs, resolution, file size) for quicker
+	// syncs; remote ones also come in larger pages, the details loaded
+	// when the inspector shows an item.
+	SyncProfile string `mapstructure:"sync_profile"`
+
+	// StartupSync is what happens to the libraries when kino starts:
+	// "eager" (default) checks and syncs them all, "lazy" syncs one when it
+	// is opened, "cache-only" shows the cache and leaves the network alone
+	// until a refresh
+	StartupSync string `mapstructure:"startup_sync"`
+
+	// AutoRefresh re-syncs every library in the background this many
+	// minutes apart while kino runs, announcing new items; 0 turns it off.
+	// AutoRefreshByLibrary overrides it per library name or ID, 0 again
+	// turning one off.
+	AutoRefresh          int            `mapstructure:"auto_refresh"`
+	AutoRefreshByLibrary map[string]int `mapstructure:"auto_refresh_by_library"`
 }
 
+// Sync profiles for ServerConfig.SyncProfile
+const (
+	SyncProfileAuto   = "auto"
+	SyncProfileFull   = "full"
+	SyncProfileFast   = "fast"
+	SyncProfileRemote = "remote"
+)
+
+// Startup modes for ServerConfig.StartupSync
+const (
+	StartupSyncEager     = "eager"
+	StartupSyncLazy      = "lazy"
+	StartupSyncCacheOnly = "cache-only"
+)
+
 // PlayerConfig holds media player configuration
 type PlayerConfig struct {
 	Command   string   `mapstructure:"command"`
 	Args      []string `mapstructure:"args"`
 	StartFlag string   `mapstructure:"start_flag"` // e.g., "--start=" or "--start-time="
+
+	// Preference lists the players auto-detection tries first, best first
+	// ("mpv", "mpv-flatpak", "vlc", "iina", "mpc-hc", ...), when Command is
+	// empty
+	Preference []string `mapstructure:"preference"`
+
+	// NextEpisode is what happens when an episode plays to its end in mpv:
+	// "ask" offers the next one, "auto" starts it, "off" does nothing
+	NextEpisode string `mapstructure:"next_episode"`
+
+	// MaxBitrateMbps caps stream quality through server transcoding, for
+	// slow links (Q changes it for a session); 0 plays the original file
+	MaxBitrateMbps int `mapstructure:"max_bitrate_mbps"`
+
+	// SkipIntros and SkipCredits make mpv jump over the intro and credits
+	// markers the server detected. mpv shows them as chapters either way.
+	SkipIntros  bool `mapstructure:"skip_intros"`
+	SkipCredits bool `mapstructure:"skip_credits"`
+
+	// PreHook and PostHook are shell commands run before a launch and after
+	// the player exits, given the item as KINO_* variables and JSON on stdin
+	PreHook  string `mapstructure:"pre_hook"`
+	PostHook string `mapstructure:"post_hook"`
 }
 
+// Next-episode behaviours for PlayerConfig.NextEpisode
+const (
+	NextEpisodeOff  = "off"
+	NextEpisodeAsk  = "ask"
+	NextEpisodeAuto = "auto"
+)
+
 // UIConfig holds UI configuration
 type UIConfig struct {
-	ShowWatchStatus   bool `mapstructure:"show_watch_status"`   // Show watched/unwatched/in-progress indicators
-	ShowLibraryCounts bool `mapstructure:"show_library_counts"` // Keep library item counts visible after sync
+	ShowWatchStatus   bool   `mapstructure:"show_watch_status"`   // Show watched/unwatched/in-progress indicators
+	ShowLibraryCounts bool   `mapstructure:"show_library_counts"` // Keep library item counts visible after sync
+	AutoResume        bool   `mapstructure:"auto_resume"`         // Resume in-progress items without asking
+	RestoreSession    bool   `mapstructure:"restore_session"`     // Reopen where the last session left off
+	NewEpisodeDays    int    `mapstructure:"new_episode_days"`    // NEW badge on shows/seasons with an episode added this many days ago; 0 disables
+	AllowDelete       bool   `mapstructure:"allow_delete"`        // Let x delete movies, shows and episodes from the server
+	RemoteMode        bool   `mapstructure:"remote_mode"`         // Arrows, Enter and Back only: Enter opens a context menu of actions
+	Home              bool   `mapstructure:"home"`                // Start on the home screen instead of the library list
+	Locale            string `mapstructure:"locale"`              // Interface language and date format ("de"); empty follows LANG
+	Accessible        bool   `mapstructure:"accessible"`          // ASCII glyphs and high-contrast colors for screen readers and limited fonts
+	TimeFormat        string `mapstructure:"time_format"`         // "12h" or "24h"; empty follows the locale
+	RelativeDates     bool   `mapstructure:"relative_dates"`      // "3 days ago" instead of dates
+	DurationFormat    string `mapstructure:"duration_format"`     // "short" (1h 52m) or "minutes" (112 min)
+
+	// HomeRows are the home screen's rows, top to bottom: "continue",
+	// "next_up", "recent" (one row per library), "recent:<library>" and
+	// "collection:<name>"
+	HomeRows []string `mapstructure:"home_rows"`
+
+	// MergedMovies combines several movie libraries into one virtual library
+	MergedMovies MergedLibraryConfig `mapstructure:"merged_movies"`
+
+	// HiddenLibraries are library names or IDs left out of the library
+	// column, startup sync and search (toggled with H)
+	HiddenLibraries []string `mapstructure:"hidden_libraries"`
+
+	// Specials places "Season 0 / Specials" and the Extras group in the
+	// seasons column: "show" (natural order), "sink" (last) or "hide".
+	// SpecialsByShow overrides it per show ID (set with S).
+	Specials       string            `mapstructure:"specials"`
+	SpecialsByShow map[string]string `mapstructure:"specials_by_show"`
+
+	// Sort is the saved sort order per library ID or column type ("movies",
+	// "shows", "mixed", "episodes"): a sort field, optionally ":desc"
+	Sort map[string]string `mapstructure:"sort"`
+}
+
+// Home screen rows for UIConfig.HomeRows. Recent and collection rows take
+// an argument after a colon.
+const (
+	HomeRowContinue   = "continue"
+	HomeRowNextUp     = "next_up"
+	HomeRowRecent     = "recent"
+	HomeRowCollection = "collection"
+)
+
+// Specials placements for UIConfig.Specials
+const (
+	SpecialsShow = "show"
+	SpecialsSink = "sink"
+	SpecialsHide = "hide"
+)
+
+// MergedLibraryConfig configures a virtual library that concatenates and
+// dedupes several movie libraries. Empty Libraries disables it.
+type MergedLibraryConfig struct {
+	Name      string   `mapstructure:"name"`      // Display name (default "All Movies")
+	Libraries []string `mapstructure:"libraries"` // Member library names or IDs, in preference order
+}
+
+// SearchConfig weighs global search ranking beyond the fuzzy title match,
+// in fuzzy score points (lower ranks higher: an exact word scores 0, a
+// word prefix 10, a typo 120). Boosts lift a match, the penalty sinks it.
+type SearchConfig struct {
+	PrefixBoost     int `mapstructure:"prefix_boost"`      // Title starts with the query
+	RecentBoost     int `mapstructure:"recent_boost"`      // Added within RecentDays
+	RecentDays      int `mapstructure:"recent_days"`       // 0 disables the recency boost
+	InProgressBoost int `mapstructure:"in_progress_boost"` // Started, not finished
+	WatchedPenalty  int `mapstructure:"watched_penalty"`   // Fully watched
+
+	// DeepIndex indexes every episode of show libraries at sync, so search
+	// finds episodes by title or code, not only movies and shows
+	DeepIndex bool `mapstructure:"deep_index"`
 }
 
 // LoggingConfig holds logging configuration
 type LoggingConfig struct {
 	File  string `mapstructure:"file"`
 	Level string `mapstructure:"level"`
+
+	// MaxSizeMB is the size at which the log file is moved aside for a new
+	// one, keeping MaxFiles old ones; 0 lets it grow without bound
+	MaxSizeMB int `mapstructure:"max_size_mb"`
+	MaxFiles  int `mapstructure:"max_files"`
+}
+
+// TraktConfig holds Trakt.tv API credentials for watch history import
+type TraktConfig struct {
+	ClientID     string `mapstructure:"client_id"`     // Trakt API application client ID
+	ClientSecret string `mapstructure:"client_secret"` // Trakt API application client secret
+	AccessToken  string `mapstructure:"access_token"`  // Auto-populated after device authentication
+}
+
+// SecurityConfig holds the inactivity lock settings for shared machines
+type SecurityConfig struct {
+	LockTimeout int    `mapstructure:"lock_timeout"` // Minutes idle before locking; 0 disables
+	PIN         string `mapstructure:"pin"`          // PIN to unlock; replaced by PINHash on load
+	PINHash     string `mapstructure:"pin_hash"`     // Salted PIN hash (HashPIN); the lock is off without one
+}
+
+// MetricsConfig holds the optional local metrics and profiling listener
+type MetricsConfig struct {
+	Listen string `mapstructure:"listen"` // e.g. 127.0.0.1:9464; empty disables
+}
+
+// ArrConfig holds a Sonarr or Radarr connection. Empty URL disables it.
+type ArrConfig struct {
+	URL               string `mapstructure:"url"`                 // e.g. http://localhost:7878
+	APIKey            string `mapstructure:"api_key"`             // Settings → General → API Key
+	QualityProfileID  int    `mapstructure:"quality_profile_id"`  // Profile for titles added from Kino
+	RootFolder        string `mapstructure:"root_folder"`         // Root folder for titles added from Kino
+	LanguageProfileID int    `mapstructure:"language_profile_id"` // Sonarr v3 only
+}
+
+// AutoRefreshIntervals returns the auto-refresh settings as durations: the
+// default and the per-library overrides
+func (c ServerConfig) AutoRefreshIntervals() (time.Duration, map[string]time.Duration) {
+	byLibrary := make(map[string]time.Duration, len(c.AutoRefreshByLibrary))
+	for lib, minutes := range c.AutoRefreshByLibrary {
+		byLibrary[lib] = time.Duration(minutes) * time.Minute
+	}
+	return time.Duration(c.AutoRefresh) * time.Minute, byLibrary
+}
+
+// Enabled reports whether the connection is configured
+func (c ArrConfig) Enabled() bool {
+	return c.URL != "" && c.APIKey != ""
 }
 
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	return &Config{
+		Server: ServerConfig{
+			MaxConcurrentRequests: 6,
+			SyncConcurrency:       2,
+			StartupSync:           StartupSyncEager,
+		},
+		Player: PlayerConfig{
+			NextEpisode: NextEpisodeAsk,
+		},
 		UI: UIConfig{
 			ShowWatchStatus:   true,
 			ShowLibraryCounts: false,
+			AutoResume:        false,
+			RestoreSession:    true,
+			NewEpisodeDays:    7,
+			AllowDelete:       false,
+			RemoteMode:        false,
+			Home:              false,
+			HomeRows:          []string{HomeRowContinue, HomeRowNextUp, HomeRowRecent},
+			Specials:          SpecialsShow,
+			DurationFormat:    "short",
+		},
+		Search: SearchConfig{
+			PrefixBoost:     15,
+			RecentBoost:     5,
+			RecentDays:      30,
+			InProgressBoost: 10,
+			WatchedPenalty:  5,
 		},
 		Logging: LoggingConfig{
-			File:  defaultLogPath(),
-			Level: "INFO",
+			File:      defaultLogPath(),
+			Level:     "INFO",
+			MaxSizeMB: 5,
+			MaxFiles:  5,
 		},
 	}
 }
 
 // defaultLogPath returns the default log file path for the current OS
 func defaultLogPath() string {
-	switch runtime.GOOS {
-	case "windows":
-		return filepath.Join(os.Getenv("APPDATA"), "kino", "kino.log")
-	default:
-		home, _ := os.UserHomeDir()
-		return filepath.Join(home, ".local", "share", "kino", "kino.log")
-	}
+	return filepath.Join(platformDirs().data(), "kino.log")
 }
 
 // defaultConfigPath returns the default config file path for the current OS
 func defaultConfigPath() string {
-	switch runtime.GOOS {
-	case "windows":
-		return filepath.Join(os.Getenv("APPDATA"), "kino")
-	default:
-		home, _ := os.UserHomeDir()
-		return filepath.Join(home, ".config", "kino")
-	}
+	return platformDirs().config()
 }
 
 // LoadConfig loads configuration from file and environment
 func LoadConfig() (*Config, error) {
-	cfg := DefaultConfig()
-
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
 	viper.AddConfigPath(defaultConfigPath())
@@ -114,29 +342,66 @@ func LoadConfig() (*Config, error) {
 	viper.AutomaticEnv()
 	for _, key := range []string{
 		"server.type", "server.url", "server.token", "server.user_id",
-		"server.username", "server.device_id",
-		"player.command", "player.start_flag",
-		"ui.show_watch_status", "ui.show_library_counts",
+		"server.username", "server.device_id", "server.keepalive_ping", "server.token_store",
+		"server.insecure_skip_verify", "server.ca_file",
+		"server.max_concurrent_requests", "server.requests_per_second", "server.sync_profile",
+		"server.sync_concurrency", "server.startup_sync", "server.auto_refresh",
+		"player.command", "player.start_flag", "player.next_episode", "player.max_bitrate_mbps",
+		"player.skip_intros", "player.skip_credits", "player.pre_hook", "player.post_hook",
+		"ui.show_watch_status", "ui.show_library_counts", "ui.auto_resume", "ui.restore_session",
+		"ui.allow_delete", "ui.remote_mode", "ui.home", "ui.locale", "ui.accessible",
+		"ui.time_format", "ui.relative_dates", "ui.duration_format",
+		"ui.merged_movies.name",
+		"search.prefix_boost", "search.recent_boost", "search.recent_days",
+		"search.in_progress_boost", "search.watched_penalty", "search.deep_index",
 		"logging.file", "logging.level",
+		"trakt.client_id", "trakt.client_secret", "trakt.access_token",
+		"radarr.url", "radarr.api_key", "radarr.quality_profile_id", "radarr.root_folder",
+		"sonarr.url", "sonarr.api_key", "sonarr.quality_profile_id", "sonarr.root_folder",
+		"sonarr.language_profile_id",
+		"security.lock_timeout", "security.pin", "security.pin_hash",
+		"metrics.listen",
+		"peer.type", "peer.url", "peer.token", "peer.user_id",
 	} {
 		_ = viper.BindEnv(key)
 	}
 
-	// Read config file if it exists
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return nil, fmt.Errorf("error reading config file: %w", err)
+	writeMu.Lock()
+	cfg, err := readConfig()
+	writeMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Move a plaintext token into the OS keychain (or read it back from
+	// there) so the config file stops holding credentials
+	if resolveToken(cfg) && viper.ConfigFileUsed() != "" {
+		err := updateConfig(func(v *viper.Viper) {
+			v.Set("server.token", "")
+			v.Set("server.token_store", TokenStoreKeychain)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to move token to keychain: %w", err)
 		}
-		// Config file not found is OK, use defaults
 	}
 
-	// Tighten pre-existing config files created with the old 0644 default
-	if configFile := viper.ConfigFileUsed(); configFile != "" {
-		_ = os.Chmod(configFile, 0o600)
+	// Keep only a salted hash of the lock PIN in the config file
+	migrated, err := resolvePIN(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if migrated && viper.ConfigFileUsed() != "" {
+		err := updateConfig(func(v *viper.Viper) {
+			v.Set("security.pin", "")
+			v.Set("security.pin_hash", cfg.Security.PINHash)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to replace the PIN with its hash: %w", err)
+		}
 	}
 
-	if err := viper.Unmarshal(cfg); err != nil {
-		return nil, fmt.Errorf("error parsing config: %w", err)
+	if cfg.Peer.Token == "" && cfg.Peer.TokenStore == TokenStoreKeychain && secrets != nil {
+		cfg.Peer.Token, _ = secrets.Get(tokenAccount(cfg.Peer))
 	}
 
 	// Ensure this install has a stable, unique device ID. Media servers
@@ -146,9 +411,11 @@ func LoadConfig() (*Config, error) {
 		cfg.Server.DeviceID = generateDeviceID()
 		// Persist immediately for already-configured installs so the ID
 		// stays stable across runs. Fresh installs save during setup.
-		if configFile := viper.ConfigFileUsed(); configFile != "" {
-			viper.Set("server.device_id", cfg.Server.DeviceID)
-			if err := viper.WriteConfigAs(configFile); err != nil {
+		if viper.ConfigFileUsed() != "" {
+			err := updateConfig(func(v *viper.Viper) {
+				v.Set("server.device_id", cfg.Server.DeviceID)
+			})
+			if err != nil {
 				return nil, fmt.Errorf("failed to save device ID: %w", err)
 			}
 		}
@@ -172,89 +439,207 @@ func generateDeviceID() string {
 // was loaded (a ./config.yaml stays in place instead of forking a stale copy
 // into the default path) or to the default path for fresh installs.
 func SaveConfig(cfg *Config) error {
-	configFile := viper.ConfigFileUsed()
-	if configFile == "" {
-		configPath := defaultConfigPath()
-		if err := os.MkdirAll(configPath, 0755); err != nil {
-			return fmt.Errorf("failed to create config directory: %w", err)
+	return updateConfig(func(v *viper.Viper) {
+		// Set server fields individually to ensure correct key names (snake_case)
+		v.Set("server.type", cfg.Server.Type)
+		v.Set("server.url", cfg.Server.URL)
+		token, tokenStore := persistToken(cfg.Server)
+		v.Set("server.token", token)
+		v.Set("server.token_store", tokenStore)
+		v.Set("server.user_id", cfg.Server.UserID)
+		v.Set("server.username", cfg.Server.Username)
+		v.Set("server.device_id", cfg.Server.DeviceID)
+		v.Set("server.keepalive_ping", cfg.Server.KeepAlivePing)
+		if cfg.Server.InsecureSkipVerify || cfg.Server.CAFile != "" {
+			v.Set("server.insecure_skip_verify", cfg.Server.InsecureSkipVerify)
+			v.Set("server.ca_file", cfg.Server.CAFile)
+		}
+		v.Set("server.max_concurrent_requests", cfg.Server.MaxConcurrentRequests)
+		v.Set("server.sync_concurrency", cfg.Server.SyncConcurrency)
+		if cfg.Server.RequestsPerSecond > 0 {
+			v.Set("server.requests_per_second", cfg.Server.RequestsPerSecond)
+		}
+		if cfg.Server.SyncProfile != "" {
+			v.Set("server.sync_profile", cfg.Server.SyncProfile)
+		}
+		v.Set("server.startup_sync", cfg.Server.StartupSync)
+		if cfg.Server.AutoRefresh > 0 {
+			v.Set("server.auto_refresh", cfg.Server.AutoRefresh)
+		}
+		if len(cfg.Server.AutoRefreshByLibrary) > 0 {
+			v.Set("server.auto_refresh_by_library", cfg.Server.AutoRefreshByLibrary)
 		}
-		configFile = filepath.Join(configPath, "config.yaml")
-	}
-
-	viper.SetConfigPermissions(0o600)
 
-	// Set server fields individually to ensure correct key names (snake_case)
-	viper.Set("server.type", cfg.Server.Type)
-	viper.Set("server.url", cfg.Server.URL)
-	viper.Set("server.token", cfg.Server.Token)
-	viper.Set("server.user_id", cfg.Server.UserID)
-	viper.Set("server.username", cfg.Server.Username)
-	viper.Set("server.device_id", cfg.Server.DeviceID)
-
-	// Set player fields
-	viper.Set("player.command", cfg.Player.Command)
-	viper.Set("player.args", cfg.Player.Args)
-	viper.Set("player.start_flag", cfg.Player.StartFlag)
-
-	// Set UI fields
-	viper.Set("ui.show_watch_status", cfg.UI.ShowWatchStatus)
-	viper.Set("ui.show_library_counts", cfg.UI.ShowLibraryCounts)
-
-	// Set logging fields
-	viper.Set("logging.file", cfg.Logging.File)
-	viper.Set("logging.level", cfg.Logging.Level)
-
-	if err := viper.WriteConfigAs(configFile); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
-	}
+		// Set player fields
+		v.Set("player.command", cfg.Player.Command)
+		v.Set("player.args", cfg.Player.Args)
+		v.Set("player.start_flag", cfg.Player.StartFlag)
+		if len(cfg.Player.Preference) > 0 {
+			v.Set("player.preference", cfg.Player.Preference)
+		}
+		v.Set("player.next_episode", cfg.Player.NextEpisode)
+		v.Set("player.max_bitrate_mbps", cfg.Player.MaxBitrateMbps)
+		v.Set("player.skip_intros", cfg.Player.SkipIntros)
+		v.Set("player.skip_credits", cfg.Player.SkipCredits)
+		v.Set("player.pre_hook", cfg.Player.PreHook)
+		v.Set("player.post_hook", cfg.Player.PostHook)
+
+		// Set UI fields
+		v.Set("ui.show_watch_status", cfg.UI.ShowWatchStatus)
+		v.Set("ui.show_library_counts", cfg.UI.ShowLibraryCounts)
+		v.Set("ui.auto_resume", cfg.UI.AutoResume)
+		v.Set("ui.restore_session", cfg.UI.RestoreSession)
+		v.Set("ui.new_episode_days", cfg.UI.NewEpisodeDays)
+		v.Set("ui.allow_delete", cfg.UI.AllowDelete)
+		v.Set("ui.remote_mode", cfg.UI.RemoteMode)
+		v.Set("ui.home", cfg.UI.Home)
+		v.Set("ui.locale", cfg.UI.Locale)
+		v.Set("ui.accessible", cfg.UI.Accessible)
+		v.Set("ui.time_format", cfg.UI.TimeFormat)
+		v.Set("ui.relative_dates", cfg.UI.RelativeDates)
+		v.Set("ui.duration_format", cfg.UI.DurationFormat)
+		v.Set("ui.home_rows", cfg.UI.HomeRows)
+		v.Set("ui.merged_movies.name", cfg.UI.MergedMovies.Name)
+		v.Set("ui.merged_movies.libraries", cfg.UI.MergedMovies.Libraries)
+		if len(cfg.UI.HiddenLibraries) > 0 {
+			v.Set("ui.hidden_libraries", cfg.UI.HiddenLibraries)
+		}
+		v.Set("ui.specials", cfg.UI.Specials)
+		if len(cfg.UI.SpecialsByShow) > 0 {
+			v.Set("ui.specials_by_show", cfg.UI.SpecialsByShow)
+		}
+		if len(cfg.UI.Sort) > 0 {
+			v.Set("ui.sort", cfg.UI.Sort)
+		}
 
-	return nil
+		// Set logging fields
+		v.Set("logging.file", cfg.Logging.File)
+		v.Set("logging.level", cfg.Logging.Level)
+		v.Set("logging.max_size_mb", cfg.Logging.MaxSizeMB)
+		v.Set("logging.max_files", cfg.Logging.MaxFiles)
+
+		// Set Trakt fields
+		v.Set("trakt.client_id", cfg.Trakt.ClientID)
+		v.Set("trakt.client_secret", cfg.Trakt.ClientSecret)
+		v.Set("trakt.access_token", cfg.Trakt.AccessToken)
+		if cfg.Security.LockTimeout > 0 || cfg.Security.PINHash != "" {
+			v.Set("security.lock_timeout", cfg.Security.LockTimeout)
+			v.Set("security.pin_hash", cfg.Security.PINHash)
+		}
+		if cfg.Metrics.Listen != "" {
+			v.Set("metrics.listen", cfg.Metrics.Listen)
+		}
+		for name, arr := range map[string]ArrConfig{"radarr": cfg.Radarr, "sonarr": cfg.Sonarr} {
+			if !arr.Enabled() {
+				continue // keep unused sections out of the written file
+			}
+			v.Set(name+".url", arr.URL)
+			v.Set(name+".api_key", arr.APIKey)
+			v.Set(name+".quality_profile_id", arr.QualityProfileID)
+			v.Set(name+".root_folder", arr.RootFolder)
+			if arr.LanguageProfileID > 0 {
+				v.Set(name+".language_profile_id", arr.LanguageProfileID)
+			}
+		}
+	})
 }
 
-// IsConfigured returns true if the server URL and token are set
+// IsConfigured returns true if the server URL and token are set. Local
+// folders, DLNA servers and torrent engines have no token.
 func (c *Config) IsConfigured() bool {
-	return c.Server.URL != "" && c.Server.Token != ""
+	anonymous := c.Server.Type == SourceTypeLocal || c.Server.Type == SourceTypeDLNA || c.Server.Type == SourceTypeTorrServer
+	return c.Server.URL != "" && (c.Server.Token != "" || anonymous)
 }
 
 // DefaultCachePath returns the default cache directory path for the current OS
 func DefaultCachePath() string {
-	switch runtime.GOOS {
-	case "windows":
-		return filepath.Join(os.Getenv("LOCALAPPDATA"), "kino", "cache")
-	default:
-		home, _ := os.UserHomeDir()
-		return filepath.Join(home, ".local", "share", "kino", "cache")
-	}
+	return filepath.Join(platformDirs().data(), "cache")
 }
 
 // ClearServerConfig removes all server-related configuration (type, URL, credentials)
 // while preserving other settings (player, UI, logging)
 func ClearServerConfig() error {
-	// Clear server fields in viper
-	viper.Set("server.type", "")
-	viper.Set("server.url", "")
-	viper.Set("server.token", "")
-	viper.Set("server.user_id", "")
-	viper.Set("server.username", "")
+	forgetToken()
 
 	// Write back to the loaded config file: clearing credentials in a copy
 	// at the default path while a ./config.yaml still holds the token would
 	// be a sign-out that doesn't sign out
-	configFile := viper.ConfigFileUsed()
-	if configFile == "" {
-		configPath := defaultConfigPath()
-		if err := os.MkdirAll(configPath, 0755); err != nil {
-			return fmt.Errorf("failed to create config directory: %w", err)
+	return updateConfig(func(v *viper.Viper) {
+		v.Set("server.type", "")
+		v.Set("server.url", "")
+		v.Set("server.token", "")
+		v.Set("server.user_id", "")
+		v.Set("server.username", "")
+	})
+}
+
+// SaveServerToken records a new token for the configured server, after
+// signing in again, in the keychain or the loaded config file
+func SaveServerToken(server ServerConfig) error {
+	token, tokenStore := persistToken(server)
+	return updateConfig(func(v *viper.Viper) {
+		v.Set("server.token", token)
+		v.Set("server.token_store", tokenStore)
+		v.Set("server.username", server.Username)
+	})
+}
+
+// SavePeerServer records the sync-watched peer server in the loaded config
+// file, its token in the keychain when possible
+func SavePeerServer(peer ServerConfig) error {
+	token, tokenStore := persistToken(peer)
+	return updateConfig(func(v *viper.Viper) {
+		v.Set("peer.type", peer.Type)
+		v.Set("peer.url", peer.URL)
+		v.Set("peer.token", token)
+		v.Set("peer.token_store", tokenStore)
+		v.Set("peer.user_id", peer.UserID)
+		v.Set("peer.username", peer.Username)
+	})
+}
+
+// SaveSortPreference records the sort order for a library ID or column type
+// in the loaded config file. An empty value forgets it.
+func SaveSortPreference(key, value string) error {
+	return updateConfig(func(v *viper.Viper) {
+		sorts := v.GetStringMapString("ui.sort")
+		key = strings.ToLower(key) // viper keys are case-insensitive
+		if value == "" {
+			delete(sorts, key)
+		} else {
+			sorts[key] = value
 		}
-		configFile = filepath.Join(configPath, "config.yaml")
-	}
+		v.Set("ui.sort", sorts)
+	})
+}
 
-	viper.SetConfigPermissions(0o600)
-	if err := viper.WriteConfigAs(configFile); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
-	}
+// SaveSpecialsPreference records where a show's specials go in the loaded
+// config file. An empty value falls back to the global setting.
+func SaveSpecialsPreference(showID, value string) error {
+	return updateConfig(func(v *viper.Viper) {
+		byShow := v.GetStringMapString("ui.specials_by_show")
+		showID = strings.ToLower(showID) // viper keys are case-insensitive
+		if value == "" {
+			delete(byShow, showID)
+		} else {
+			byShow[showID] = value
+		}
+		v.Set("ui.specials_by_show", byShow)
+	})
+}
 
-	return nil
+// SaveHiddenLibraries records the hidden libraries in the loaded config file
+func SaveHiddenLibraries(libraries []string) error {
+	return updateConfig(func(v *viper.Viper) {
+		v.Set("ui.hidden_libraries", libraries)
+	})
+}
+
+// SaveLogLevel records the log level changed while kino runs
+func SaveLogLevel(level string) error {
+	return updateConfig(func(v *viper.Viper) {
+		v.Set("logging.level", level)
+	})
 }
 
 // ClearCache removes all cached data
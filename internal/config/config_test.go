@@ -4,9 +4,29 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 )
 
+// Tests must never touch the developer's real keychain
+func TestMain(m *testing.M) {
+	SetSecretStore(nil)
+	os.Exit(m.Run())
+}
+
+// memSecrets is an in-memory SecretStore
+type memSecrets map[string]string
+
+func (s memSecrets) Get(account string) (string, error) {
+	v, ok := s[account]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return v, nil
+}
+func (s memSecrets) Set(account, secret string) error { s[account] = secret; return nil }
+func (s memSecrets) Delete(account string) error      { delete(s, account); return nil }
+
 // KINO_* environment overrides must reach the unmarshaled config — they were
 // previously dead (no env key replacer, no BindEnv registration).
 func TestEnvVarOverrides(t *testing.T) {
@@ -91,3 +111,235 @@ func TestLoadConfigGeneratesDeviceID(t *testing.T) {
 		t.Fatalf("config file mode = %o, want 600", perm)
 	}
 }
+
+// A plaintext token in an existing config moves into the keychain on load,
+// leaves the file, and is read back from the keychain on the next start.
+func TestTokenMigratesToKeychain(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", home)
+	store := memSecrets{}
+	SetSecretStore(store)
+	t.Cleanup(func() { SetSecretStore(nil) })
+
+	configDir := filepath.Join(home, ".config", "kino")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	configFile := filepath.Join(configDir, "config.yaml")
+	existing := `server:
+  type: "plex"
+  url: "http://localhost:32400"
+  token: "secret-token"
+  device_id: "kino-test"
+`
+	if err := os.WriteFile(configFile, []byte(existing), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Token != "secret-token" {
+		t.Fatalf("token = %q after migration", cfg.Server.Token)
+	}
+	if store["http://localhost:32400|"] != "secret-token" {
+		t.Fatalf("token not stored in keychain: %v", store)
+	}
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Fatalf("token still in config file:\n%s", data)
+	}
+
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Token != "secret-token" {
+		t.Fatalf("token = %q on reload, want it read from keychain", cfg.Server.Token)
+	}
+
+	// Logging out removes the keychain entry
+	if err := ClearServerConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if len(store) != 0 {
+		t.Fatalf("keychain entry survived logout: %v", store)
+	}
+}
+
+// A plaintext lock PIN is replaced by its salted hash on load: the file
+// keeps only the hash, which still verifies the PIN.
+func TestPINReplacedByHash(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", home)
+
+	configDir := filepath.Join(home, ".config", "kino")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	configFile := filepath.Join(configDir, "config.yaml")
+	existing := `server:
+  device_id: "kino-test"
+security:
+  lock_timeout: 5
+  pin: "4711"
+`
+	if err := os.WriteFile(configFile, []byte(existing), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Security.PIN != "" || cfg.Security.PINHash == "" {
+		t.Fatalf("security = %+v, want only a hash", cfg.Security)
+	}
+	if RehashPIN(cfg.Security.PINHash, "4711") != cfg.Security.PINHash {
+		t.Error("hash does not verify the PIN")
+	}
+	if RehashPIN(cfg.Security.PINHash, "4712") == cfg.Security.PINHash {
+		t.Error("hash verifies a wrong PIN")
+	}
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "4711") || !strings.Contains(string(data), cfg.Security.PINHash) {
+		t.Fatalf("config file should hold the hash, not the PIN:\n%s", data)
+	}
+
+	reloaded, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Security.PINHash != cfg.Security.PINHash || reloaded.Security.LockTimeout != 5 {
+		t.Fatalf("security on reload = %+v", reloaded.Security)
+	}
+}
+
+// Writers apply their change to the file as it is on disk: concurrent
+// saves and an edit made outside kino all survive, and only the outside
+// edit counts as a change to reload
+func TestConfigWritesKeepOtherChanges(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", home)
+	configDir := filepath.Join(home, ".config", "kino")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	configFile := filepath.Join(configDir, "config.yaml")
+	if err := os.WriteFile(configFile, []byte("server:\n  device_id: kino-test\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for _, key := range []string{"movies", "shows", "music"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := SaveSortPreference(key, "title"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if FileChanged() {
+		t.Fatal("kino's own writes should not count as outside edits")
+	}
+
+	// An edit from outside, e.g. another kino or an editor
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(data, "player:\n  next_episode: auto\n"...)
+	if err := os.WriteFile(configFile, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if !FileChanged() {
+		t.Fatal("outside edit not detected")
+	}
+	if err := SaveHiddenLibraries([]string{"Photos"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ReloadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.UI.Sort) != 3 || cfg.Player.NextEpisode != "auto" || len(cfg.UI.HiddenLibraries) != 1 ||
+		cfg.Server.DeviceID != "kino-test" {
+		t.Fatalf("reloaded config lost changes: sort %v, next episode %q, hidden %v, device %q",
+			cfg.UI.Sort, cfg.Player.NextEpisode, cfg.UI.HiddenLibraries, cfg.Server.DeviceID)
+	}
+	if FileChanged() {
+		t.Error("a reloaded file should not count as changed")
+	}
+	if info, err := os.Stat(configFile); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("config file mode = %v, %v; want 600", info.Mode().Perm(), err)
+	}
+}
+
+// Each platform's directories, checked on any OS: Windows keeps the cache
+// and log in %LOCALAPPDATA% and falls back to the profile's AppData when
+// the variables are unset.
+func TestPlatformDirs(t *testing.T) {
+	home := filepath.Join("home", "ana")
+	env := map[string]string{
+		"APPDATA":      filepath.Join("C:", "Users", "ana", "AppData", "Roaming"),
+		"LOCALAPPDATA": filepath.Join("C:", "Users", "ana", "AppData", "Local"),
+	}
+	for _, tc := range []struct {
+		name         string
+		d            dirs
+		config, data string
+	}{
+		{"linux", dirs{goos: "linux", getenv: func(string) string { return "" }, home: home},
+			filepath.Join(home, ".config", "kino"), filepath.Join(home, ".local", "share", "kino")},
+		{"windows", dirs{goos: "windows", getenv: func(k string) string { return env[k] }, home: home},
+			filepath.Join(env["APPDATA"], "kino"), filepath.Join(env["LOCALAPPDATA"], "kino")},
+		{"windows without env", dirs{goos: "windows", getenv: func(string) string { return "" }, home: home},
+			filepath.Join(home, "AppData", "Roaming", "kino"), filepath.Join(home, "AppData", "Local", "kino")},
+	} {
+		if got := tc.d.config(); got != tc.config {
+			t.Errorf("%s: config dir = %q, want %q", tc.name, got, tc.config)
+		}
+		if got := tc.d.data(); got != tc.data {
+			t.Errorf("%s: data dir = %q, want %q", tc.name, got, tc.data)
+		}
+	}
+}
+
+// A symlinked config stays a symlink: the write replaces its target
+func TestWriteFileAtomicFollowsSymlink(t *testing.T) {
+	dotfiles, configDir := t.TempDir(), t.TempDir()
+	target := filepath.Join(dotfiles, "kino.yaml")
+	if err := os.WriteFile(target, []byte("old\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(configDir, "config.yaml")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	if err := writeFileAtomic(link, []byte("new\n")); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("config.yaml is no longer a symlink (%v)", err)
+	}
+	if data, _ := os.ReadFile(target); string(data) != "new\n" {
+		t.Fatalf("target = %q, want the new content", data)
+	}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// dirs locates kino's files on one platform. It takes the OS and the
+// environment as values, so every platform's layout can be checked on any
+// machine.
+type dirs struct {
+	goos   string
+	getenv func(string) string
+	home   string
+}
+
+// platformDirs returns the layout of the running system
+func platformDirs() dirs {
+	home, _ := os.UserHomeDir()
+	return dirs{goos: runtime.GOOS, getenv: os.Getenv, home: home}
+}
+
+// config is where config.yaml lives: %APPDATA%\kino on Windows, so it
+// roams with the profile, ~/.config/kino elsewhere
+func (d dirs) config() string {
+	if d.goos == "windows" {
+		return filepath.Join(d.windowsDir("APPDATA", "Roaming"), "kino")
+	}
+	return filepath.Join(d.home, ".config", "kino")
+}
+
+// data is where the cache, session files and log live: %LOCALAPPDATA%\kino
+// on Windows, which stays on the machine, ~/.local/share/kino elsewhere
+func (d dirs) data() string {
+	if d.goos == "windows" {
+		return filepath.Join(d.windowsDir("LOCALAPPDATA", "Local"), "kino")
+	}
+	return filepath.Join(d.home, ".local", "share", "kino")
+}
+
+// windowsDir returns a known folder from its environment variable, or its
+// usual place in the profile when the variable is unset (services, some SSH
+// sessions), rather than a path relative to wherever kino was started
+func (d dirs) windowsDir(env, folder string) string {
+	if dir := d.getenv(env); dir != "" {
+		return dir
+	}
+	return filepath.Join(d.home, "AppData", folder)
+}
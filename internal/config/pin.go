@@ -0,0 +1,75 @@
+package config
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// pinIterations is the PBKDF2 work factor for new PIN hashes. A PIN has few
+// digits, so the hash only slows guessing down; it keeps the PIN itself out
+// of config.yaml and its backups.
+const pinIterations = 600000
+
+// HashPIN returns a salted hash of pin for security.pin_hash, in the form
+// "pbkdf2-sha256$iterations$salt$key"
+func HashPIN(pin string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate PIN salt: %w", err)
+	}
+	return derivePIN(pin, salt, pinIterations)
+}
+
+// RehashPIN hashes pin with the salt and work factor of hash, so the result
+// equals hash exactly when pin is the hashed PIN. A malformed hash yields
+// "", which matches no hash.
+func RehashPIN(hash, pin string) string {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != "pbkdf2-sha256" {
+		return ""
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return ""
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return ""
+	}
+	rehashed, err := derivePIN(pin, salt, iterations)
+	if err != nil {
+		return ""
+	}
+	return rehashed
+}
+
+func derivePIN(pin string, salt []byte, iterations int) (string, error) {
+	key, err := pbkdf2.Key(sha256.New, pin, salt, iterations, sha256.Size)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash PIN: %w", err)
+	}
+	return fmt.Sprintf("pbkdf2-sha256$%d$%s$%s", iterations,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// resolvePIN replaces a plaintext security.pin with its hash, and reports
+// whether the config file needs rewriting without it. A PIN from
+// KINO_SECURITY_PIN is hashed in memory only.
+func resolvePIN(cfg *Config) (migrated bool, err error) {
+	if cfg.Security.PIN == "" {
+		return false, nil
+	}
+	hash, err := HashPIN(cfg.Security.PIN)
+	if err != nil {
+		return false, err
+	}
+	cfg.Security.PINHash = hash
+	cfg.Security.PIN = ""
+	return os.Getenv("KINO_SECURITY_PIN") == "", nil
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// ErrSecretNotFound is returned by SecretStore.Get when nothing is stored
+// for the account
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretStore keeps credentials outside the config file. The system
+// implementation wraps the OS keychain (macOS Keychain, libsecret, Windows
+// Credential Manager); without one, tokens stay in config.yaml.
+type SecretStore interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+}
+
+// Token storage modes (server.token_store)
+const (
+	TokenStoreKeychain  = "keychain"  // Token lives in the SecretStore
+	TokenStorePlaintext = "plaintext" // Opt out: keep the token in config.yaml
+)
+
+// secretService names kino's entries in the OS keychain
+const secretService = "kino"
+
+// secrets is the active store; nil means plaintext only
+var secrets = systemSecretStore()
+
+// SetSecretStore replaces the secret store. nil keeps tokens in the config
+// file.
+func SetSecretStore(s SecretStore) {
+	secrets = s
+}
+
+// tokenAccount is the keychain account for a server login. Like the cache
+// key it includes the user, so two accounts on one server don't collide.
+func tokenAccount(server ServerConfig) string {
+	return server.URL + "|" + server.UserID
+}
+
+// resolveToken fills a keychain-stored server token in, and reports whether
+// a plaintext token was moved into the keychain (so the config file needs
+// rewriting without it). A token from KINO_SERVER_TOKEN is left alone.
+func resolveToken(cfg *Config) (migrated bool) {
+	if secrets == nil || cfg.Server.TokenStore == TokenStorePlaintext || os.Getenv("KINO_SERVER_TOKEN") != "" {
+		return false
+	}
+	if cfg.Server.Token == "" {
+		if cfg.Server.TokenStore == TokenStoreKeychain && cfg.Server.URL != "" {
+			// A missing or unreadable entry leaves the install unconfigured,
+			// which sends the user through login again
+			cfg.Server.Token, _ = secrets.Get(tokenAccount(cfg.Server))
+		}
+		return false
+	}
+	if err := secrets.Set(tokenAccount(cfg.Server), cfg.Server.Token); err != nil {
+		return false // Keychain unusable: keep the plaintext token
+	}
+	cfg.Server.TokenStore = TokenStoreKeychain
+	return true
+}
+
+// persistToken stores the server token in the keychain when possible and
+// returns the token and storage mode to write to the config file
+func persistToken(server ServerConfig) (fileToken, mode string) {
+	if secrets == nil || server.TokenStore == TokenStorePlaintext || server.Token == "" || server.URL == "" {
+		return server.Token, server.TokenStore
+	}
+	if err := secrets.Set(tokenAccount(server), server.Token); err != nil {
+		return server.Token, "" // Fall back to plaintext
+	}
+	return "", TokenStoreKeychain
+}
+
+// forgetToken removes a keychain-stored token for the configured server
+func forgetToken() {
+	if secrets == nil || viper.GetString("server.token_store") != TokenStoreKeychain {
+		return
+	}
+	_ = secrets.Delete(tokenAccount(ServerConfig{
+		URL:    viper.GetString("server.url"),
+		UserID: viper.GetString("server.user_id"),
+	}))
+}
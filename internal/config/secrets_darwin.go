@@ -0,0 +1,58 @@
+package config
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// macKeychain stores secrets in the login keychain via security(1)
+type macKeychain struct{}
+
+func systemSecretStore() SecretStore {
+	if _, err := exec.LookPath("security"); err != nil {
+		return nil
+	}
+	return macKeychain{}
+}
+
+// errSecItemNotFound is security(1)'s exit status for a missing item
+const errSecItemNotFound = 44
+
+func (macKeychain) Get(account string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password",
+		"-s", secretService, "-a", account, "-w").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == errSecItemNotFound {
+			return "", ErrSecretNotFound
+		}
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Set runs security in interactive mode so the secret goes over stdin
+// instead of showing up in the process list
+func (macKeychain) Set(account, secret string) error {
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader("add-generic-password -U -s " + quoteArg(secretService) +
+		" -a " + quoteArg(account) + " -l " + quoteArg("kino server token") +
+		" -w " + quoteArg(secret) + "\n")
+	return cmd.Run()
+}
+
+func (macKeychain) Delete(account string) error {
+	err := exec.Command("security", "delete-generic-password",
+		"-s", secretService, "-a", account).Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == errSecItemNotFound {
+		return nil
+	}
+	return err
+}
+
+// quoteArg quotes a word for security's interactive command parser
+func quoteArg(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
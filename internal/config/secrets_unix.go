@@ -0,0 +1,49 @@
+//go:build !darwin && !windows
+
+package config
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// libsecret stores secrets in the desktop keyring (GNOME Keyring, KWallet)
+// via secret-tool(1)
+type libsecret struct{}
+
+func systemSecretStore() SecretStore {
+	// secret-tool needs a session bus; headless boxes fall back to plaintext
+	if _, err := exec.LookPath("secret-tool"); err != nil || os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return nil
+	}
+	return libsecret{}
+}
+
+func (libsecret) Get(account string) (string, error) {
+	out, err := exec.Command("secret-tool", "lookup",
+		"service", secretService, "account", account).Output()
+	if err != nil {
+		// lookup exits 1 with no output when nothing matches
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(out) == 0 && len(exitErr.Stderr) == 0 {
+			return "", ErrSecretNotFound
+		}
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Set passes the secret on stdin so it never appears in the process list
+func (libsecret) Set(account, secret string) error {
+	cmd := exec.Command("secret-tool", "store", "--label=kino server token",
+		"service", secretService, "account", account)
+	cmd.Stdin = strings.NewReader(secret)
+	return cmd.Run()
+}
+
+func (libsecret) Delete(account string) error {
+	return exec.Command("secret-tool", "clear",
+		"service", secretService, "account", account).Run()
+}
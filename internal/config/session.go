@@ -0,0 +1,80 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Session is the navigation state saved on exit and restored on launch, so
+// kino reopens where it was left
+type Session struct {
+	// Server is the URL the IDs below belong to; a session saved against
+	// another server is ignored
+	Server string `json:"server"`
+	// User is the server user the session belongs to; switching users
+	// starts fresh
+	User string `json:"user,omitempty"`
+
+	// LibraryID is the library selected in the library list
+	LibraryID string `json:"library_id,omitempty"`
+
+	// Columns are the columns opened below the library list, outermost
+	// first
+	Columns []ColumnSession `json:"columns,omitempty"`
+
+	ShowInspector bool `json:"show_inspector"`
+}
+
+// ColumnSession is one open column: its selection and sort
+type ColumnSession struct {
+	SelectedID string `json:"selected_id,omitempty"`
+	Sort       string `json:"sort,omitempty"` // empty = the column's default order
+	Desc       bool   `json:"desc,omitempty"`
+}
+
+// DefaultSessionPath returns the session file path, next to the cache
+func DefaultSessionPath() string {
+	return filepath.Join(filepath.Dir(DefaultCachePath()), "session.json")
+}
+
+// LoadSession reads a saved session. A missing file returns nil, nil.
+func LoadSession(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SaveSession writes the session, replacing the file atomically so a crash
+// mid-write can't leave a truncated session behind
+func SaveSession(path string, s Session) error {
+	return writeJSONAtomic(path, s)
+}
+
+// writeJSONAtomic writes v as indented JSON through a temporary file and a
+// rename
+func writeJSONAtomic(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// DefaultNowPlayingPath returns the file naming the mpv kino is watching,
+// next to the cache, so a restarted kino can reattach to it
+func DefaultNowPlayingPath() string {
+	return filepath.Join(filepath.Dir(DefaultCachePath()), "nowplaying.json")
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Config file writes. Settings, signing in again, logout and other kino
+// processes can all write config.yaml at once. Each write holds the file's
+// lock, applies its changes to what is on disk at that moment rather than
+// to what was loaded, and replaces the file through a rename: concurrent
+// writers keep each other's changes and a crash never leaves half a file.
+
+// writeMu serializes config access within the process; viper is not safe
+// for concurrent use
+var writeMu sync.Mutex
+
+// fileStamp tells versions of the config file apart
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// loaded is the version of the config file last read or written
+var loaded fileStamp
+
+// stampFile returns path's current stamp, zero when it doesn't exist
+func stampFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
+
+// configFile is the config file that was loaded, or where a fresh install
+// writes one
+func configFile() string {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path
+	}
+	return filepath.Join(defaultConfigPath(), "config.yaml")
+}
+
+// readConfig reads the config file, if there is one, into viper and a new
+// Config. The caller holds writeMu.
+func readConfig() (*Config, error) {
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+		// Config file not found is OK, use defaults
+	}
+
+	// Tighten pre-existing config files created with the old 0644 default
+	if path := viper.ConfigFileUsed(); path != "" {
+		_ = os.Chmod(path, 0o600)
+		loaded = stampFile(path)
+	}
+
+	cfg := DefaultConfig()
+	if err := viper.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("error parsing config: %w", err)
+	}
+	return cfg, nil
+}
+
+// ReloadConfig reads the config file again after it was edited outside
+// kino. Unlike LoadConfig it writes nothing back.
+func ReloadConfig() (*Config, error) {
+	writeMu.Lock()
+	defer writeMu.Unlock()
+
+	// A file that fails to parse counts as seen, so it is reported once
+	loaded = stampFile(configFile())
+	cfg, err := readConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Server.Token == "" && cfg.Server.TokenStore == TokenStoreKeychain && secrets != nil {
+		cfg.Server.Token, _ = secrets.Get(tokenAccount(cfg.Server))
+	}
+	if cfg.Peer.Token == "" && cfg.Peer.TokenStore == TokenStoreKeychain && secrets != nil {
+		cfg.Peer.Token, _ = secrets.Get(tokenAccount(cfg.Peer))
+	}
+	return cfg, nil
+}
+
+// FileChanged reports whether the config file changed on disk since kino
+// last read or wrote it
+func FileChanged() bool {
+	writeMu.Lock()
+	defer writeMu.Unlock()
+	return stampFile(configFile()) != loaded
+}
+
+// updateConfig applies changes to the config file as it is on disk now and
+// writes it back atomically, holding the file's lock throughout
+func updateConfig(apply func(v *viper.Viper)) error {
+	writeMu.Lock()
+	defer writeMu.Unlock()
+
+	path := configFile()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	unlock, err := lockFile(path + ".lock")
+	if err != nil {
+		return fmt.Errorf("failed to lock config file: %w", err)
+	}
+	defer unlock()
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+	apply(v)
+
+	var buf bytes.Buffer
+	if err := v.WriteConfigTo(&buf); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := writeFileAtomic(path, buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	loaded = stampFile(path)
+
+	// Keep what later reads of viper see in step with the file
+	_ = viper.ReadConfig(bytes.NewReader(buf.Bytes()))
+	return nil
+}
+
+// writeFileAtomic replaces path with data through a temporary file and a
+// rename, so readers see the old file or the new one, never part of it.
+// A symlink is followed and its target replaced, keeping dotfile setups
+// linked. The file is private: the config holds credentials.
+func writeFileAtomic(path string, data []byte) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // Gone after the rename; cleans up a failure
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
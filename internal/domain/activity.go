@@ -0,0 +1,30 @@
+package domain
+
+import "context"
+
+// ActivityClient is an optional capability for backends that report the
+// work the server itself is doing, so the user can tell why new files have
+// not shown up yet.
+type ActivityClient interface {
+	// GetActivities returns the tasks running on the server right now
+	GetActivities(ctx context.Context) ([]Activity, error)
+}
+
+// ActivityKind groups server activities by what they do
+type ActivityKind int
+
+const (
+	ActivityOther     ActivityKind = iota
+	ActivityScan                   // Scanning a library for added or removed files
+	ActivityRefresh                // Refreshing metadata
+	ActivityTranscode              // Converting or optimizing media
+)
+
+// Activity is one task running on the server
+type Activity struct {
+	ID       string       // Server-specific identifier, stable while it runs
+	Kind     ActivityKind // What the task does
+	Title    string       // "Scanning Movies"
+	Subtitle string       // The item or step it is on; may be empty
+	Progress int          // Percent done; -1 when the server does not say
+}
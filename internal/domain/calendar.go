@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CalendarClient is an optional capability for listing episodes by air
+// date, for the calendar view.
+type CalendarClient interface {
+	// GetAiringEpisodes returns episodes of shows in the user's libraries
+	// that aired (or will air) between from and to, ordered by AiredAt.
+	// Episodes with AiredAt in the future are not playable yet.
+	GetAiringEpisodes(ctx context.Context, from, to time.Time) ([]*MediaItem, error)
+}
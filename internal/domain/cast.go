@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CastTarget is a device that can play on kino's behalf, fetching the
+// stream from the server itself: a Plex player on the network, another
+// app signed in to the Jellyfin server, or a Chromecast on the LAN
+type CastTarget struct {
+	ID      string
+	Name    string // "Living Room", the device's own name
+	Product string // "Plex for Android (TV)", "Jellyfin Web", "Chromecast"
+	Addr    string // host:port of a Chromecast kino drives itself; empty for the server's devices
+}
+
+// CastCommand is a remote-control action for a cast target
+type CastCommand int
+
+const (
+	CastPlayPause   CastCommand = iota // Pause, or resume when paused
+	CastRewind                         // Jump back by the target's step
+	CastFastForward                    // Jump forward by the target's step
+	CastStop
+)
+
+// CastClient is an optional capability for backends that can start and
+// control playback on other devices
+type CastClient interface {
+	// CastTargets lists the devices playback can be sent to, never kino
+	// itself
+	CastTargets(ctx context.Context) ([]CastTarget, error)
+	// Cast starts the item on a target at offset
+	Cast(ctx context.Context, targetID, itemID string, offset time.Duration) error
+	// ControlCast sends a remote-control command to a target
+	ControlCast(ctx context.Context, targetID string, cmd CastCommand) error
+}
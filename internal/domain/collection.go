@@ -0,0 +1,20 @@
+package domain
+
+import "context"
+
+// CollectionClient is an optional capability for backends that group
+// movies and shows into collections ("Marvel Cinematic Universe").
+type CollectionClient interface {
+	// GetCollections returns the collections of every library
+	GetCollections(ctx context.Context) ([]Collection, error)
+
+	// GetCollectionItems returns a collection's movies and shows
+	GetCollectionItems(ctx context.Context, collectionID string) ([]ListItem, error)
+}
+
+// Collection is a named group of movies and shows
+type Collection struct {
+	ID        string // Server-specific unique identifier
+	Title     string // Display name
+	LibraryID string // Library it belongs to; empty when it spans libraries
+}
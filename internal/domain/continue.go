@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// ContinueWatchingClient is an optional capability for backends that keep a
+// list of partly watched movies and episodes across all libraries.
+type ContinueWatchingClient interface {
+	// GetContinueWatching returns the in-progress items, most recently
+	// watched first, with LastViewedAt set
+	GetContinueWatching(ctx context.Context) ([]*MediaItem, error)
+
+	// RemoveFromContinueWatching drops an item from the list without
+	// touching its watch progress
+	RemoveFromContinueWatching(ctx context.Context, itemID string) error
+}
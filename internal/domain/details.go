@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ItemDetails is the full metadata of a movie, episode or show that library
+// listings leave out: credits, genres and chapters
+type ItemDetails struct {
+	Tagline   string
+	Studio    string
+	Genres    []string
+	Directors []Credit
+	Writers   []string
+	Cast      []Credit
+	Chapters  []Chapter
+	// Files are the item's files with their media details, for when the
+	// listing left them out (fast and remote sync)
+	Files []MediaVersion
+}
+
+// Credit is a person credited on an item: an actor and the character they
+// play, or a director (no role)
+type Credit struct {
+	ID   string // Server-specific person ID; empty when the server gives none
+	Name string
+	Role string
+}
+
+// Chapter is a named position within a video
+type Chapter struct {
+	Title string
+	Start time.Duration
+}
+
+// DetailsClient is an optional capability for backends that return an item's
+// full metadata on request
+type DetailsClient interface {
+	GetItemDetails(ctx context.Context, itemID string) (*ItemDetails, error)
+}
+
+// PersonClient is an optional capability for backends that list the items
+// a person appears in
+type PersonClient interface {
+	// GetPersonItems returns the movies and shows a person acted in or, with
+	// director set, directed
+	GetPersonItems(ctx context.Context, personID string, director bool) ([]ListItem, error)
+}
+
+// SimilarClient is an optional capability for backends that suggest titles
+// like a movie or show
+type SimilarClient interface {
+	// GetSimilar returns movies and shows similar to the item, best match
+	// first
+	GetSimilar(ctx context.Context, itemID string) ([]ListItem, error)
+}
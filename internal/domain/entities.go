@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -13,6 +14,7 @@ const (
 	MediaTypeShow
 	MediaTypeSeason
 	MediaTypeEpisode
+	MediaTypeChannel // Live TV channel
 )
 
 // MediaItem represents a playable item (Movie or Episode)
@@ -30,19 +32,27 @@ type MediaItem struct {
 	IsPlayed   bool          // Whether item is marked as watched
 	Type       MediaType     // Movie or Episode
 
+	LastViewedAt int64 // Unix timestamp of the last play (0 = never or unknown)
+
 	// Episode-specific fields (empty for movies)
 	ShowTitle  string // Parent show name
 	ShowID     string // Parent show ID (for navigation)
 	SeasonNum  int    // Season number (0 = specials)
 	EpisodeNum int    // Episode number within season
 	ParentID   string // Season ID (for navigation)
+	AiredAt    int64  // Unix timestamp of the original air date (0 = unknown)
+	ExtraType  string // Kind of extra, e.g. "Deleted Scene" (extras only)
 
 	// Rating (0-10 scale, audience/community rating)
-	Rating float64
+	Rating     float64
+	UserRating float64 // The user's own rating, 1-10 (0 = unrated)
 
 	// Content rating (e.g., "PG-13", "R", "TV-MA")
 	ContentRating string
 
+	// IDs at IMDb, TMDB and TVDB
+	External ExternalIDs
+
 	// Technical metadata
 	FileSize      int64  // File size in bytes
 	Bitrate       int    // Bitrate in kbps
@@ -53,9 +63,18 @@ type MediaItem struct {
 	AudioChannels int    // Channel count: 2, 6, 8
 	Container     string // "mkv", "mp4"
 
+	// Every file of the item when it has more than one; empty otherwise.
+	// The technical metadata above describes the first, which plays by
+	// default.
+	Versions []MediaVersion
+
 	// Image URLs
 	ThumbURL string // Poster/thumbnail image URL
 	ArtURL   string // Background art URL
+
+	// Live TV channel fields (empty for movies/episodes)
+	ChannelNumber string // Tuner channel number, e.g. "4.1"
+	NowPlaying    string // Title of the program currently airing
 }
 
 // WatchStatus returns the watch status of the media item
@@ -74,6 +93,14 @@ func (m MediaItem) ShouldResume() bool {
 	return m.ViewOffset > 0 && !m.IsPlayed
 }
 
+// PercentWatched returns how far into the item the watch position is, 0-100
+func (m MediaItem) PercentWatched() int {
+	if m.Duration <= 0 || m.ViewOffset <= 0 {
+		return 0
+	}
+	return min(int(m.ViewOffset*100/m.Duration), 100)
+}
+
 // FormattedDuration returns the duration in a human-readable format
 func (m MediaItem) FormattedDuration() string {
 	h := int(m.Duration.Hours())
@@ -84,6 +111,17 @@ func (m MediaItem) FormattedDuration() string {
 	return fmt.Sprintf("%dm", mins)
 }
 
+// FormattedOffset returns the watch position as a clock, e.g. "47:15" or
+// "1:02:03"
+func (m MediaItem) FormattedOffset() string {
+	total := int(m.ViewOffset.Seconds())
+	h, mins, secs := total/3600, (total/60)%60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, mins, secs)
+	}
+	return fmt.Sprintf("%d:%02d", mins, secs)
+}
+
 // EpisodeCode returns the formatted episode code (e.g., "S01E05")
 func (m MediaItem) EpisodeCode() string {
 	if m.Type != MediaTypeEpisode {
@@ -94,25 +132,80 @@ func (m MediaItem) EpisodeCode() string {
 
 // Resolution returns a human-readable resolution string based on video height
 func (m MediaItem) Resolution() string {
+	return resolutionLabel(m.Height)
+}
+
+// FormattedFileSize returns the file size in a human-readable format
+func (m MediaItem) FormattedFileSize() string {
+	return fileSizeLabel(m.FileSize)
+}
+
+// ChannelLayout returns the audio channel layout as a string
+func (m MediaItem) ChannelLayout() string {
+	return channelLayout(m.AudioChannels)
+}
+
+// MediaVersion is one of the files of an item that has several, such as a
+// 4K remux next to a 1080p encode, or a theatrical cut and a director's cut
+type MediaVersion struct {
+	ID            string // Backend ID of the file, for playing this version
+	Name          string // Edition or file name, when the server has one
+	FileSize      int64  // File size in bytes
+	Bitrate       int    // Bitrate in kbps
+	Width         int    // Video width in pixels
+	Height        int    // Video height in pixels
+	VideoCodec    string // Normalized: "HEVC", "H.264", "AV1"
+	AudioCodec    string // Normalized: "AAC", "AC3", "DTS"
+	AudioChannels int    // Channel count: 2, 6, 8
+	Container     string // "mkv", "mp4"
+}
+
+// Label summarizes the version on one line, e.g.
+// "4K · HEVC · TrueHD 7.1 · MKV · 58.2 GB"
+func (v MediaVersion) Label() string {
+	var parts []string
+	if r := resolutionLabel(v.Height); r != "" {
+		parts = append(parts, r)
+	}
+	if v.VideoCodec != "" {
+		parts = append(parts, v.VideoCodec)
+	}
+	if audio := strings.TrimSpace(v.AudioCodec + " " + channelLayout(v.AudioChannels)); audio != "" {
+		parts = append(parts, audio)
+	}
+	if v.Container != "" {
+		parts = append(parts, strings.ToUpper(v.Container))
+	}
+	if size := fileSizeLabel(v.FileSize); size != "" {
+		parts = append(parts, size)
+	}
+	if len(parts) == 0 {
+		return v.Name
+	}
+	return strings.Join(parts, " · ")
+}
+
+// resolutionLabel names a video height, e.g. "4K" or "1080p"
+func resolutionLabel(height int) string {
 	switch {
-	case m.Height >= 2160:
+	case height >= 2160:
 		return "4K"
-	case m.Height >= 1080:
+	case height >= 1080:
 		return "1080p"
-	case m.Height >= 720:
+	case height >= 720:
 		return "720p"
-	case m.Height >= 480:
+	case height >= 480:
 		return "480p"
-	case m.Height > 0:
-		return fmt.Sprintf("%dp", m.Height)
+	case height > 0:
+		return fmt.Sprintf("%dp", height)
 	default:
 		return ""
 	}
 }
 
-// FormattedFileSize returns the file size in a human-readable format
-func (m MediaItem) FormattedFileSize() string {
-	if m.FileSize <= 0 {
+// fileSizeLabel formats a file size in GB or MB, empty when unknown
+func fileSizeLabel(size int64) string {
+	if size <= 0 {
 		return ""
 	}
 	const (
@@ -120,16 +213,16 @@ func (m MediaItem) FormattedFileSize() string {
 		mb = 1024 * 1024
 	)
 	switch {
-	case m.FileSize >= gb:
-		return fmt.Sprintf("%.1f GB", float64(m.FileSize)/float64(gb))
+	case size >= gb:
+		return fmt.Sprintf("%.1f GB", float64(size)/float64(gb))
 	default:
-		return fmt.Sprintf("%d MB", m.FileSize/mb)
+		return fmt.Sprintf("%d MB", size/mb)
 	}
 }
 
-// ChannelLayout returns the audio channel layout as a string
-func (m MediaItem) ChannelLayout() string {
-	switch m.AudioChannels {
+// channelLayout names an audio channel count, e.g. "5.1"
+func channelLayout(channels int) string {
+	switch channels {
 	case 8:
 		return "7.1"
 	case 6:
@@ -166,12 +259,17 @@ func (m *MediaItem) GetItemType() string {
 		return "movie"
 	case MediaTypeEpisode:
 		return "episode"
+	case MediaTypeChannel:
+		return "channel"
 	default:
 		return "unknown"
 	}
 }
 
 func (m *MediaItem) GetDescription() string {
+	if m.Type == MediaTypeChannel {
+		return m.NowPlaying
+	}
 	if m.Type == MediaTypeEpisode {
 		return m.FormattedDuration()
 	}
@@ -200,13 +298,18 @@ type Show struct {
 	SeasonCount    int    // Total number of seasons
 	EpisodeCount   int    // Total number of episodes
 	UnwatchedCount int    // Number of unwatched episodes
+	LastAddedAt    int64  // Unix timestamp the newest episode was added (0 = unknown)
 
 	// Rating (0-10 scale, audience/community rating)
-	Rating float64
+	Rating     float64
+	UserRating float64 // The user's own rating, 1-10 (0 = unrated)
 
 	// Content rating (e.g., "TV-MA", "TV-PG")
 	ContentRating string
 
+	// IDs at IMDb, TMDB and TVDB
+	External ExternalIDs
+
 	// Image URLs
 	ThumbURL string // Poster/thumbnail image URL
 	ArtURL   string // Background art URL
@@ -262,6 +365,8 @@ type Season struct {
 	Title          string // "Season 1" or custom name
 	EpisodeCount   int    // Total number of episodes
 	UnwatchedCount int    // Number of unwatched episodes
+	LastAddedAt    int64  // Unix timestamp the newest episode was added (0 = unknown)
+	Extras         bool   // Synthetic group of the show's extras (see ExtrasSeasonID)
 
 	// Image URLs
 	ThumbURL string // Poster/thumbnail image URL
@@ -280,6 +385,9 @@ func (s Season) WatchStatus() WatchStatus {
 
 // DisplayTitle returns the display title for the season
 func (s Season) DisplayTitle() string {
+	if s.Extras {
+		return "Extras"
+	}
 	if s.SeasonNum == 0 {
 		return "Specials"
 	}
@@ -341,6 +449,7 @@ func (l *Library) CanDrillDown() bool          { return true }
 type Playlist struct {
 	ID           string        // Playlist identifier
 	Title        string        // Display title
+	Description  string        // Plex summary / Jellyfin overview
 	PlaylistType string        // "video", "audio", "photo"
 	Smart        bool          // Smart/dynamic playlist
 	ItemCount    int           // Number of items in playlist
@@ -1,6 +1,11 @@
 package domain
 
-import "errors"
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+)
 
 // Sentinel errors for domain operations
 var (
@@ -10,6 +15,57 @@ var (
 	// ErrServerOffline indicates the media server is unreachable
 	ErrServerOffline = errors.New("media server is unreachable")
 
+	// ErrServerError indicates the media server failed the request (5xx)
+	ErrServerError = errors.New("media server error")
+
 	// ErrAuthFailed indicates the server rejected our token (revoked or expired)
 	ErrAuthFailed = errors.New("authentication token is invalid or expired")
+
+	// ErrForbidden indicates the account may not perform the request (403)
+	ErrForbidden = errors.New("not permitted on this server")
+
+	// ErrPrivateSession indicates a watch-state write was suppressed because
+	// the session is in private mode
+	ErrPrivateSession = errors.New("private session: watch state is not reported")
 )
+
+// ErrorKind classifies a failure by what the user can do about it
+type ErrorKind int
+
+const (
+	ErrorUnknown   ErrorKind = iota
+	ErrorNetwork             // The server could not be reached or took too long
+	ErrorAuth                // The server rejected the token
+	ErrorNotFound            // The item is gone from the server
+	ErrorServer              // The server failed the request
+	ErrorParse               // The server's reply could not be read
+	ErrorForbidden           // The account lacks permission for the request
+)
+
+// KindOf classifies err. Backends wrap the sentinels above; replies that
+// fail to decode are recognized by the decoder's error types.
+func KindOf(err error) ErrorKind {
+	var (
+		jsonSyntax *json.SyntaxError
+		jsonType   *json.UnmarshalTypeError
+		xmlSyntax  *xml.SyntaxError
+	)
+	switch {
+	case err == nil:
+		return ErrorUnknown
+	case errors.Is(err, ErrServerOffline), errors.Is(err, context.DeadlineExceeded):
+		return ErrorNetwork
+	case errors.Is(err, ErrAuthFailed):
+		return ErrorAuth
+	case errors.Is(err, ErrItemNotFound):
+		return ErrorNotFound
+	case errors.Is(err, ErrServerError):
+		return ErrorServer
+	case errors.Is(err, ErrForbidden):
+		return ErrorForbidden
+	case errors.As(err, &jsonSyntax), errors.As(err, &jsonType), errors.As(err, &xmlSyntax):
+		return ErrorParse
+	default:
+		return ErrorUnknown
+	}
+}
@@ -0,0 +1,33 @@
+package domain
+
+// ExternalIDs are an item's IDs at public metadata providers, as reported
+// by the server's metadata agent. Empty when unknown.
+type ExternalIDs struct {
+	IMDb string // e.g. "tt0113277"
+	TMDB string // e.g. "949"
+	TVDB string
+}
+
+// ExternalURL returns the item's IMDb page, else its TMDB page (movies
+// only: TMDB episode pages need the series ID). Empty when neither is known.
+func (m MediaItem) ExternalURL() string {
+	if m.External.IMDb != "" {
+		return "https://www.imdb.com/title/" + m.External.IMDb + "/"
+	}
+	if m.External.TMDB != "" && m.Type == MediaTypeMovie {
+		return "https://www.themoviedb.org/movie/" + m.External.TMDB
+	}
+	return ""
+}
+
+// ExternalURL returns the show's IMDb page, else its TMDB page. Empty when
+// neither is known.
+func (s Show) ExternalURL() string {
+	if s.External.IMDb != "" {
+		return "https://www.imdb.com/title/" + s.External.IMDb + "/"
+	}
+	if s.External.TMDB != "" {
+		return "https://www.themoviedb.org/tv/" + s.External.TMDB
+	}
+	return ""
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// ExtrasClient is an optional capability for backends that list a show's
+// extras (behind-the-scenes, deleted scenes, featurettes). Extras are
+// MediaItems with ExtraType set.
+type ExtrasClient interface {
+	GetExtras(ctx context.Context, itemID string) ([]*MediaItem, error)
+}
+
+// extrasSuffix marks the ID of the synthetic season grouping a show's extras
+const extrasSuffix = ":extras"
+
+// ExtrasSeasonID returns the ID of the synthetic "Extras" season of a show
+func ExtrasSeasonID(showID string) string {
+	return showID + extrasSuffix
+}
+
+// ExtrasShowID returns the show an extras season ID belongs to, and false
+// for the ID of a real season
+func ExtrasShowID(seasonID string) (string, bool) {
+	return strings.CutSuffix(seasonID, extrasSuffix)
+}
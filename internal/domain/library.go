@@ -17,3 +17,60 @@ type LibraryClient interface {
 	// don't reliably change when items are added.
 	GetLibraryItemCount(ctx context.Context, libID, libType string) (int, error)
 }
+
+// Validator identifies a version of a library's content the way HTTP
+// caching does: by ETag, or by Last-Modified date
+type Validator struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// IsZero reports whether the server sent no validator
+func (v Validator) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// ConditionalClient is an optional capability for backends whose library
+// listings carry validators, letting a sync confirm a library unchanged
+// with one conditional request even when its timestamp moved
+type ConditionalClient interface {
+	// CheckLibrary reports whether a library's content changed since prev
+	// was current, along with the current validator (zero when the server
+	// sends none). A zero prev only fetches the validator.
+	CheckLibrary(ctx context.Context, libID string, prev Validator) (current Validator, changed bool, err error)
+}
+
+// RatingClient is an optional capability for backends that store the
+// user's own rating of an item
+type RatingClient interface {
+	// SetUserRating rates an item 1-10; 0 clears the rating
+	SetUserRating(ctx context.Context, itemID string, rating float64) error
+}
+
+// DeleteClient is an optional capability for backends that let an admin
+// remove media from the server, files included
+type DeleteClient interface {
+	// DeleteItem deletes a movie, show, season or episode
+	DeleteItem(ctx context.Context, itemID string) error
+}
+
+// PingClient is an optional capability for backends with a cheap endpoint
+// that tells whether the server is up, for health checks
+type PingClient interface {
+	Ping(ctx context.Context) error
+}
+
+// PageSizeClient is an optional capability for backends that want library
+// listings fetched in pages of their own size: larger ones for a distant
+// server, where each page costs a round trip
+type PageSizeClient interface {
+	// PageSize returns the items per page; 0 keeps the default
+	PageSize() int
+}
+
+// EpisodeIndexClient is an optional capability for backends that list
+// every episode in a library with one request, so global search can index
+// episodes without walking each show's seasons
+type EpisodeIndexClient interface {
+	GetAllEpisodes(ctx context.Context, libID string) ([]*MediaItem, error)
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "context"
+
+// LiveTVClient is an optional capability for backends with tuner support.
+// Backends without Live TV simply don't implement it.
+type LiveTVClient interface {
+	// HasLiveTV reports whether the server has Live TV enabled with at
+	// least one tuner service configured
+	HasLiveTV(ctx context.Context) (bool, error)
+
+	// GetChannels returns all channels with their current program. Channels
+	// are MediaItems of type MediaTypeChannel.
+	GetChannels(ctx context.Context) ([]*MediaItem, error)
+
+	// ResolveChannelURL opens a live stream on the tuner and returns a URL
+	// an external player can consume
+	ResolveChannelURL(ctx context.Context, channelID string) (string, error)
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "context"
+
+// LiveUpdateClient is an optional capability for backends that push
+// change notifications, so a running client learns about new or removed
+// items without polling.
+type LiveUpdateClient interface {
+	// WatchUpdates opens the server's notification stream. The channel is
+	// closed when ctx ends or the connection drops.
+	WatchUpdates(ctx context.Context) (<-chan LiveUpdate, error)
+}
+
+// LiveUpdate is one change the server announced
+type LiveUpdate struct {
+	// LibraryID is the library whose content changed: items added,
+	// updated or removed, or a scan of it finished
+	LibraryID string
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// SegmentKind is what a marked stretch of a video contains
+type SegmentKind string
+
+const (
+	SegmentIntro      SegmentKind = "intro"
+	SegmentCredits    SegmentKind = "credits"
+	SegmentRecap      SegmentKind = "recap"
+	SegmentPreview    SegmentKind = "preview"
+	SegmentCommercial SegmentKind = "commercial"
+)
+
+// Label names the kind for display, e.g. "Intro"
+func (k SegmentKind) Label() string {
+	switch k {
+	case SegmentIntro:
+		return "Intro"
+	case SegmentCredits:
+		return "Credits"
+	case SegmentRecap:
+		return "Recap"
+	case SegmentPreview:
+		return "Preview"
+	case SegmentCommercial:
+		return "Commercial"
+	default:
+		return string(k)
+	}
+}
+
+// Segment is a marked stretch of a video that a player can skip
+type Segment struct {
+	Kind  SegmentKind
+	Start time.Duration
+	End   time.Duration
+}
+
+// Markers are the positions within an item handed to the player: its
+// chapters and its skippable segments
+type Markers struct {
+	Chapters []Chapter
+	Segments []Segment
+}
+
+// MarkerClient is an optional capability for backends that know where an
+// item's chapters, intro and credits are
+type MarkerClient interface {
+	GetMarkers(ctx context.Context, itemID string) (*Markers, error)
+}
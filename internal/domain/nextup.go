@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// NextUpClient is an optional capability for backends that suggest the
+// next unwatched episode of each show the user is part way through.
+type NextUpClient interface {
+	// GetNextUp returns the next episode to watch of each show in
+	// progress, most recently watched show first
+	GetNextUp(ctx context.Context) ([]*MediaItem, error)
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // PlaybackClient provides network operations for media playback.
 type PlaybackClient interface {
@@ -8,3 +11,33 @@ type PlaybackClient interface {
 	MarkPlayed(ctx context.Context, itemID string) error
 	MarkUnplayed(ctx context.Context, itemID string) error
 }
+
+// TranscodeClient is an optional capability for backends that can transcode
+// a stream down to a bitrate cap, for playing over slow links. Backends
+// without it always direct-play.
+type TranscodeClient interface {
+	// ResolveTranscodedURL returns a stream URL for the item with video
+	// and audio capped at maxKbps in total
+	ResolveTranscodedURL(ctx context.Context, itemID string, maxKbps int) (string, error)
+}
+
+// VersionClient is an optional capability for backends that can play a
+// chosen file of an item with several (MediaItem.Versions)
+type VersionClient interface {
+	ResolveVersionURL(ctx context.Context, itemID, versionID string) (string, error)
+}
+
+// PlayCountClient is an optional capability for backends that can set how
+// often an unwatched item was played, for imported watch history. Plex
+// can't set a count, only add one per scrobble, so it doesn't implement
+// this: replaying a large count would cost a request per play, and an
+// import marks its items played once instead.
+type PlayCountClient interface {
+	SetPlayCount(ctx context.Context, itemID string, plays int) error
+}
+
+// ResumeClient is an optional capability for backends that can set an
+// item's resume position directly, outside of a playback session
+type ResumeClient interface {
+	SetViewOffset(ctx context.Context, itemID string, offset time.Duration) error
+}
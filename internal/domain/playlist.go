@@ -10,4 +10,11 @@ type PlaylistClient interface {
 	AddToPlaylist(ctx context.Context, playlistID string, itemIDs []string) error
 	RemoveFromPlaylist(ctx context.Context, playlistID string, itemID string) error
 	DeletePlaylist(ctx context.Context, playlistID string) error
+	UpdatePlaylist(ctx context.Context, playlistID, title, description string) error
+}
+
+// EmptyPlaylistClient is an optional capability for backends that create
+// playlists with no items (Jellyfin). Others need at least one to start.
+type EmptyPlaylistClient interface {
+	CreatesEmptyPlaylists() bool
 }
@@ -9,4 +9,19 @@ type SyncResult struct {
 	LibraryID string // Which library this result is for
 	FromCache bool   // true if cache was fresh (no network fetch)
 	Count     int    // total items after sync
+	Unwatched int    // unwatched items after sync; episodes for show libraries
+
+	// NewIDs are the items missing from the cached listing the sync
+	// replaced. Empty on a library's first sync, when everything is new.
+	NewIDs []string
+}
+
+// SyncCheckpoint is how far an interrupted library sync got, so the next
+// sync can resume there instead of starting over.
+type SyncCheckpoint struct {
+	ServerTS int64      // library version being fetched
+	Offset   int        // offset of the next page to fetch
+	Skipped  int        // pages skipped as failed so far
+	Total    int        // listing total the pages reported; 0 if unknown
+	Items    []ListItem // items fetched before Offset
 }
@@ -17,6 +17,11 @@ type Store interface {
 	GetMixedContent(libID string) ([]ListItem, bool)
 	SaveMixedContent(libID string, items []ListItem, serverTS int64) error
 
+	// GetContentPage reads one page of a library's content (whatever kind
+	// it holds) and the total item count, without loading the rest.
+	// limit <= 0 reads to the end.
+	GetContentPage(libID string, offset, limit int) ([]ListItem, int, bool)
+
 	// === Hierarchical (TV) ===
 	GetSeasons(libID, showID string) ([]*Season, bool)
 	SaveSeasons(libID, showID string, seasons []*Season) error
@@ -24,6 +29,18 @@ type Store interface {
 	GetEpisodes(libID, showID, seasonID string) ([]*MediaItem, bool)
 	SaveEpisodes(libID, showID, seasonID string, episodes []*MediaItem) error
 
+	// GetStale* return cached seasons and episodes past their freshness,
+	// for browsing while the server is unreachable.
+	GetStaleSeasons(libID, showID string) ([]*Season, bool)
+	GetStaleEpisodes(libID, showID, seasonID string) ([]*MediaItem, bool)
+
+	// GetLibraryEpisodes returns a library's episode index (see
+	// EpisodeIndexClient), fresh under the same rules as episode lists;
+	// GetStaleLibraryEpisodes returns it however old it is.
+	GetLibraryEpisodes(libID string) ([]*MediaItem, bool)
+	GetStaleLibraryEpisodes(libID string) ([]*MediaItem, bool)
+	SaveLibraryEpisodes(libID string, episodes []*MediaItem) error
+
 	// === Playlists ===
 	GetPlaylists() ([]*Playlist, bool)
 	SavePlaylists(playlists []*Playlist) error
@@ -34,12 +51,36 @@ type Store interface {
 	// === Freshness ===
 	IsValid(libID string, serverTS int64) bool
 
+	// GetLibraryValidator returns the validator a library's cached content
+	// was fetched under (see ConditionalClient)
+	GetLibraryValidator(libID string) (Validator, bool)
+	SaveLibraryValidator(libID string, v Validator) error
+
+	// TouchLibrary marks a library's cached content current as of serverTS,
+	// once the server has confirmed it unchanged
+	TouchLibrary(libID string, serverTS int64) error
+
+	// === Sync checkpoints ===
+	// GetSyncCheckpoint returns an interrupted sync's checkpoint if it was
+	// fetching the same library version (serverTS) recently enough to resume.
+	GetSyncCheckpoint(libID string, serverTS int64) (SyncCheckpoint, bool)
+	SaveSyncCheckpoint(libID string, cp SyncCheckpoint) error
+	ClearSyncCheckpoint(libID string)
+
 	// === In-place updates ===
 	// SetWatchState patches a media item's watch state everywhere it is
 	// cached (and adjusts season/show unwatched counters) without
 	// invalidating anything.
 	SetWatchState(itemID string, played bool)
 
+	// SetUserRating patches an item's user rating everywhere it is cached
+	SetUserRating(itemID string, rating float64)
+
+	// PruneItems drops everything cached under items deleted from a
+	// library: a removed show's seasons and episodes, and removed items in
+	// cached playlists.
+	PruneItems(libID string, itemIDs []string)
+
 	// === Invalidation ===
 	InvalidateLibrary(libID string)
 	InvalidateShow(libID, showID string)
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// SyncGroup is a watch party on the server: several players kept at the
+// same position in the same item
+type SyncGroup struct {
+	ID           string
+	Name         string
+	State        string // e.g. "Idle", "Playing", "Paused"
+	Participants []string
+}
+
+// SyncEventKind is what the group asks its players to do
+type SyncEventKind int
+
+const (
+	SyncQueue   SyncEventKind = iota // Load ItemID and hold at Position
+	SyncUnpause                      // Play from Position at When
+	SyncPause                        // Pause at Position
+	SyncSeek                         // Jump to Position and report ready
+	SyncStop                         // Stop playback; the group stays
+	SyncLeft                         // The group is gone or we were removed
+)
+
+// SyncEvent is one instruction from a sync group. When is already on the
+// local clock.
+type SyncEvent struct {
+	Kind     SyncEventKind
+	ItemID   string // SyncQueue
+	Playing  bool   // SyncQueue: whether the group is already playing
+	Position time.Duration
+	When     time.Time
+}
+
+// SyncSession is membership in a sync group. Events is closed when the
+// session ends, after Leave or when the connection drops.
+type SyncSession interface {
+	Group() SyncGroup
+	Events() <-chan SyncEvent
+
+	// Ready tells the group this player has loaded and sits at position;
+	// Buffering that it is still loading
+	Ready(ctx context.Context, position time.Duration, playing bool) error
+	Buffering(ctx context.Context, position time.Duration, playing bool) error
+
+	// RequestPause and RequestUnpause ask the group to pause or play for
+	// everyone, after a local pause or unpause
+	RequestPause(ctx context.Context) error
+	RequestUnpause(ctx context.Context) error
+
+	Leave(ctx context.Context) error
+}
+
+// SyncPlayClient is an optional capability for backends with watch
+// parties a player can join
+type SyncPlayClient interface {
+	ListSyncGroups(ctx context.Context) ([]SyncGroup, error)
+	JoinSyncGroup(ctx context.Context, groupID string) (SyncSession, error)
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WatchlistClient is an optional capability for the account's watchlist
+// (Plex Discover). Watchlisted titles need not be on the server.
+type WatchlistClient interface {
+	// GetWatchlist returns the watchlisted titles, most recently added
+	// first, with LocalID set on those the server has
+	GetWatchlist(ctx context.Context) ([]*WatchlistItem, error)
+
+	// AddToWatchlist watchlists a movie or show of the server and returns
+	// its watchlist ID
+	AddToWatchlist(ctx context.Context, itemID string) (string, error)
+
+	// RemoveFromWatchlist drops a title by its watchlist ID
+	RemoveFromWatchlist(ctx context.Context, watchlistID string) error
+}
+
+// WatchlistItem is a watchlisted movie or show
+type WatchlistItem struct {
+	ID        string    // Watchlist ID (not a server item ID)
+	Title     string    // Display title
+	Year      int       // Release or first air year
+	Type      MediaType // MediaTypeMovie or MediaTypeShow
+	Summary   string    // Plot synopsis
+	AddedAt   int64     // Unix timestamp when watchlisted
+	LocalID   string    // The server's copy; empty when it has none
+	LibraryID string    // Library of the server's copy
+}
+
+// Available reports whether the server has the title
+func (w *WatchlistItem) Available() bool { return w.LocalID != "" }
+
+// ListItem interface implementation for WatchlistItem
+
+func (w *WatchlistItem) GetID() string               { return w.ID }
+func (w *WatchlistItem) GetTitle() string            { return w.Title }
+func (w *WatchlistItem) GetSortTitle() string        { return w.Title }
+func (w *WatchlistItem) GetYear() int                { return w.Year }
+func (w *WatchlistItem) GetAddedAt() int64           { return w.AddedAt }
+func (w *WatchlistItem) GetUpdatedAt() int64         { return w.AddedAt }
+func (w *WatchlistItem) GetDuration() time.Duration  { return 0 }
+func (w *WatchlistItem) GetRating() float64          { return 0 }
+func (w *WatchlistItem) GetWatchStatus() WatchStatus { return WatchStatusUnwatched }
+func (w *WatchlistItem) CanDrillDown() bool          { return false }
+func (w *WatchlistItem) GetItemType() string {
+	if w.Type == MediaTypeShow {
+		return "show"
+	}
+	return "movie"
+}
+
+func (w *WatchlistItem) GetDescription() string {
+	if w.Year > 0 {
+		return fmt.Sprintf("%d", w.Year)
+	}
+	return ""
+}
@@ -0,0 +1,177 @@
+// Package format renders durations, dates and times the way the user
+// configured (ui.time_format, ui.relative_dates, ui.duration_format), in
+// the current locale. Views format through it rather than on their own so
+// the settings apply everywhere.
+package format
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/mmcdole/kino/internal/i18n"
+)
+
+// Clock settings for Options.Clock
+const (
+	Clock12h = "12h"
+	Clock24h = "24h"
+)
+
+// Duration styles for Options.Duration
+const (
+	DurationShort   = "short"   // 1h 52m
+	DurationMinutes = "minutes" // 112 min
+)
+
+// Options are the display settings
+type Options struct {
+	Clock    string // Clock12h or Clock24h; empty follows the locale
+	Relative bool   // "3 days ago" instead of the date
+	Duration string // DurationShort (default) or DurationMinutes
+}
+
+var options atomic.Pointer[Options]
+
+// now is the clock relative dates count from
+var now = time.Now
+
+func init() {
+	options.Store(&Options{})
+}
+
+// Configure sets the display settings
+func Configure(o Options) {
+	options.Store(&o)
+}
+
+// Duration formats a runtime: "1h 52m" or "112 min"
+func Duration(d time.Duration) string {
+	mins := int(d.Minutes())
+	if options.Load().Duration == DurationMinutes {
+		return i18n.T("duration.minutes", mins)
+	}
+	if h := mins / 60; h > 0 {
+		return fmt.Sprintf("%dh %dm", h, mins%60)
+	}
+	return fmt.Sprintf("%dm", mins)
+}
+
+// Position formats a playback position: "4:05" or "1:02:03"
+func Position(d time.Duration) string {
+	secs := max(0, int(d.Seconds()))
+	if secs >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", secs/3600, secs%3600/60, secs%60)
+	}
+	return fmt.Sprintf("%d:%02d", secs/60, secs%60)
+}
+
+// Date formats a day: "Mar 5, 2024", or "3 days ago" with relative dates
+func Date(t time.Time) string {
+	if options.Load().Relative {
+		return relative(t, false)
+	}
+	return i18n.Date(t)
+}
+
+// MonthYear formats a date where the month is precise enough: "Mar 2024",
+// or "3 months ago" with relative dates
+func MonthYear(t time.Time) string {
+	if options.Load().Relative {
+		return relative(t, false)
+	}
+	return i18n.MonthYear(t)
+}
+
+// DateTime formats a moment: "Mar 5, 2024 14:02", or "2 hours ago" with
+// relative dates
+func DateTime(t time.Time) string {
+	if options.Load().Relative {
+		return relative(t, true)
+	}
+	return i18n.Date(t) + " " + Time(t)
+}
+
+// Time formats a time of day: "14:02" or "2:02 PM"
+func Time(t time.Time) string {
+	if clock24() {
+		return t.Format("15:04")
+	}
+	return t.Format("3:04 PM")
+}
+
+// TimeSeconds formats a time of day to the second: "14:02:09" or
+// "2:02:09 PM"
+func TimeSeconds(t time.Time) string {
+	if clock24() {
+		return t.Format("15:04:05")
+	}
+	return t.Format("3:04:05 PM")
+}
+
+func clock24() bool {
+	switch options.Load().Clock {
+	case Clock12h:
+		return false
+	case Clock24h:
+		return true
+	}
+	return i18n.Clock24()
+}
+
+// relative describes t from now: "just now", "5 min ago", "yesterday",
+// "in 3 days". Without clock (dates), anything today is "today".
+func relative(t time.Time, clock bool) string {
+	current := now()
+	if clock {
+		d := current.Sub(t)
+		past := d >= 0
+		if !past {
+			d = -d
+		}
+		switch {
+		case d < time.Minute:
+			return i18n.T("ago.now")
+		case d < time.Hour:
+			return since(past, "minutes", int(d.Minutes()))
+		case d < 24*time.Hour:
+			return since(past, "hours", int(d.Hours()))
+		}
+	}
+
+	// Whole calendar days, so last night is "yesterday" whatever the hour
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := current.Date()
+	days := int(time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC).Sub(time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+	past := days >= 0
+	if !past {
+		days = -days
+	}
+	switch {
+	case days == 0:
+		return i18n.T("ago.today")
+	case days == 1 && past:
+		return i18n.T("ago.yesterday")
+	case days == 1:
+		return i18n.T("in.tomorrow")
+	case days < 7:
+		return since(past, "days", days)
+	case days < 30:
+		return since(past, "weeks", days/7)
+	case days < 365:
+		return since(past, "months", days/30)
+	}
+	return since(past, "years", days/365)
+}
+
+// since picks the "n units ago" or "in n units" message, singular for 1
+func since(past bool, unit string, n int) string {
+	prefix := "in."
+	if past {
+		prefix = "ago."
+	}
+	if n == 1 {
+		return i18n.T(prefix + unit + ".one")
+	}
+	return i18n.T(prefix+unit, n)
+}
@@ -0,0 +1,85 @@
+package format
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	defer Configure(Options{})
+	d := 112 * time.Minute
+
+	if got := Duration(d); got != "1h 52m" {
+		t.Errorf("short = %q", got)
+	}
+	if got := Duration(45 * time.Minute); got != "45m" {
+		t.Errorf("short under an hour = %q", got)
+	}
+	Configure(Options{Duration: DurationMinutes})
+	if got := Duration(d); got != "112 min" {
+		t.Errorf("minutes = %q", got)
+	}
+}
+
+func TestPosition(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                 "0:00",
+		245 * time.Second: "4:05",
+		time.Hour + 2*time.Minute + 3*time.Second: "1:02:03",
+	} {
+		if got := Position(d); got != want {
+			t.Errorf("Position(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestClock(t *testing.T) {
+	defer Configure(Options{})
+	at := time.Date(2024, time.March, 5, 14, 2, 9, 0, time.UTC)
+
+	Configure(Options{Clock: Clock24h})
+	if got := Time(at) + " " + TimeSeconds(at); got != "14:02 14:02:09" {
+		t.Errorf("24h = %q", got)
+	}
+	Configure(Options{Clock: Clock12h})
+	if got := Time(at) + " " + TimeSeconds(at); got != "2:02 PM 2:02:09 PM" {
+		t.Errorf("12h = %q", got)
+	}
+	if got := DateTime(at); got != "Mar 5, 2024 2:02 PM" {
+		t.Errorf("DateTime = %q", got)
+	}
+}
+
+func TestRelative(t *testing.T) {
+	defer Configure(Options{})
+	defer func() { now = time.Now }()
+	current := time.Date(2024, time.March, 5, 9, 0, 0, 0, time.Local)
+	now = func() time.Time { return current }
+
+	day := time.Date(2024, time.March, 2, 12, 0, 0, 0, time.Local)
+	if got := Date(day); got != "Mar 2, 2024" {
+		t.Errorf("absolute = %q", got)
+	}
+
+	Configure(Options{Relative: true})
+	for _, c := range []struct {
+		at   time.Time
+		want string
+		fn   func(time.Time) string
+	}{
+		{current.Add(-20 * time.Second), "just now", DateTime},
+		{current.Add(-5 * time.Minute), "5 min ago", DateTime},
+		{current.Add(-1 * time.Hour), "1 hour ago", DateTime},
+		{current.Add(-3 * time.Hour), "today", Date},
+		{current.Add(-10 * time.Hour), "yesterday", Date},
+		{day, "3 days ago", Date},
+		{current.AddDate(0, 0, 1), "tomorrow", Date},
+		{current.AddDate(0, 0, 16), "in 2 weeks", Date},
+		{current.AddDate(0, -4, 0), "4 months ago", MonthYear},
+		{current.AddDate(-1, 0, 0), "1 year ago", MonthYear},
+	} {
+		if got := c.fn(c.at); got != c.want {
+			t.Errorf("%v = %q, want %q", c.at, got, c.want)
+		}
+	}
+}
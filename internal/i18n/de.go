@@ -0,0 +1,357 @@
+package i18n
+
+// german is the German catalog
+var german = locale{
+	weekdays:  [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	months:    [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+	date:      "{d}. {mon} {yyyy}",
+	dayDate:   "{wd} {d}. {mon}",
+	monthYear: "{mon} {yyyy}",
+	clock24:   true,
+	messages: map[string]string{
+		// Help screen
+		"help.navigation":         "NAVIGATION",
+		"help.up_down":            "Hoch/runter",
+		"help.parent_drill":       "Zurück/öffnen",
+		"help.back":               "Zurück (Spalte schließen)",
+		"help.first":              "Erster Eintrag",
+		"help.last":               "Letzter Eintrag",
+		"help.page":               "Seitenweise blättern",
+		"help.half_page":          "Halbe Seite blättern",
+		"help.mark_batch":         "Für Stapel markieren",
+		"help.peek":               "Inhalt ansehen",
+		"help.search_view":        "SUCHE & ANSICHT",
+		"help.filter":             "Filtern",
+		"help.watch_filter":       "Gesehen-Filter",
+		"help.global_search":      "Globale Suche",
+		"help.arr_lookup":         "Sonarr/Radarr (in der Suche)",
+		"help.sort":               "Sortieren",
+		"help.specials":           "Specials/Extras",
+		"help.hide_libraries":     "Mediatheken ein-/ausblenden",
+		"help.inspector":          "Inspektor ein/aus",
+		"help.details":            "Details im Vollbild",
+		"help.people":             "Besetzung & Stab",
+		"help.open_imdb":          "IMDb/TMDB öffnen",
+		"help.new_items":          "Neue Einträge (letzter Sync)",
+		"help.home":               "Startseite",
+		"help.split":              "Geteilte Ansicht",
+		"help.switch_pane":        "Bereich wechseln",
+		"help.playback":           "WIEDERGABE",
+		"help.play_resume":        "Abspielen/fortsetzen",
+		"help.play_start":         "Von vorn abspielen",
+		"help.mark_watched":       "Als gesehen markieren",
+		"help.mark_unwatched":     "Als ungesehen markieren",
+		"help.rate":               "Bewerten (1-10)",
+		"help.quality":            "Streamqualität",
+		"help.private":            "Private Sitzung",
+		"help.cast":               "Auf anderem Gerät abspielen",
+		"help.pause_player":       "Player pausieren/fortsetzen",
+		"help.seek_player":        "Zurück/vor spulen",
+		"help.stop_player":        "Player beenden",
+		"help.playlists":          "PLAYLISTS",
+		"help.playlist_toggle":    "Eintrag hinzufügen/entfernen",
+		"help.delete":             "Löschen / entfernen",
+		"help.edit_playlist":      "Playlist bearbeiten",
+		"help.export":             "Als M3U exportieren",
+		"help.new_playlist":       "Neue Playlist",
+		"help.other":              "SONSTIGES",
+		"help.refresh":            "Ansicht aktualisieren",
+		"help.refresh_all":        "Alles aktualisieren",
+		"help.jobs":               "Hintergrundaufgaben",
+		"help.watchlist":          "Merkliste hinzufügen/entfernen",
+		"help.activity":           "Serveraktivität",
+		"help.command":            "Befehlszeile",
+		"help.bookmark":           "Position merken",
+		"help.jump_bookmark":      "Zur Position springen",
+		"help.request_log":        "API-Anfrageprotokoll",
+		"help.logout":             "Abmelden",
+		"help.close":              "Schließen / abbrechen",
+		"help.quit":               "Beenden",
+		"help.return":             "Beliebige Taste zum Zurückkehren...",
+		"keys.conflicts_title":    "Konflikte bei Tastenbelegungen",
+		"keys.conflicts_disabled": "Diese Tasten sind deaktiviert, bis die Belegungen korrigiert sind.",
+		"keys.conflicts_continue": "Beliebige Taste zum Fortfahren...",
+
+		// Footer
+		"footer.dismiss":     "Esc zum Schließen",
+		"footer.delete":      "Löschen",
+		"footer.remove":      "Entfernen",
+		"footer.menu":        "Menü",
+		"footer.marked":      "%d markiert",
+		"footer.marked_hint": "w/u/p/Leertaste anwenden · Esc aufheben",
+		"footer.help":        "Hilfe",
+		"footer.private":     "privat",
+		"footer.on_device":   "auf %s",
+		"footer.jobs":        "%d Aufgaben",
+		"footer.job":         "1 Aufgabe",
+
+		// Confirmations
+		"confirm.buttons":       "[Y] Ja      [N] Nein",
+		"logout.title":          "Abmelden?",
+		"logout.body1":          "Zugangsdaten, Server-URL und",
+		"logout.body2":          "alle zwischengespeicherten Daten werden gelöscht.",
+		"playlist.delete_title": "Playlist löschen?",
+		"playlist.delete_body1": "wird endgültig",
+		"playlist.delete_body2": "vom Server gelöscht.",
+		"next_episode.title":    "Nächste Folge abspielen?",
+
+		// Status messages
+		"status.launched":                  "Gestartet: %s",
+		"status.launching":                 "Starte: %s",
+		"status.launching_version":         "Starte: %s (%s)",
+		"status.queueing":                  "%d Einträge werden eingereiht...",
+		"status.queue_launched":            "Warteschlange mit %d Einträgen gestartet",
+		"status.not_aired":                 "Noch nicht ausgestrahlt: %s",
+		"status.marked_watched":            "Als gesehen markiert: %s",
+		"status.marked_unwatched":          "Als ungesehen markiert: %s",
+		"status.marks_cleared":             "Markierungen aufgehoben",
+		"status.rated":                     "%s mit %g/10 bewertet",
+		"status.rating_cleared":            "Bewertung entfernt: %s",
+		"status.no_ratings":                "Dieser Server speichert keine Bewertungen",
+		"status.not_available":             "%s ist für diesen Eintrag nicht verfügbar",
+		"status.no_inspector_split":        "Kein Inspektor in der geteilten Ansicht",
+		"status.navigation_cancelled":      "Navigation abgebrochen",
+		"status.sync_cancelled":            "Sync abgebrochen: %s",
+		"status.library_gone":              "Mediathek existiert nicht mehr auf dem Server — Navigation zurückgesetzt",
+		"status.showing":                   "Anzeige: %s",
+		"status.opening":                   "Öffne %s",
+		"status.quality":                   "Streamqualität: %s",
+		"status.player_stopped":            "Beendet: %s",
+		"status.reattached":                "Wieder verbunden: %s",
+		"status.finding_devices":           "Suche Geräte...",
+		"status.no_devices":                "Keine Geräte zum Abspielen",
+		"status.casting":                   "Sende %s an %s...",
+		"status.cast_started":              "%s läuft auf %s",
+		"status.up_next":                   "Als Nächstes: %s",
+		"status.next_episode_failed":       "Nächste Folge: %v",
+		"status.show_finished":             "%s zu Ende geschaut",
+		"status.private_on":                "Private Sitzung an: Gesehen-Status wird nicht gemeldet",
+		"status.private_off":               "Private Sitzung aus",
+		"status.private_unreported":        "Private Sitzung: Gesehen-Status nicht gemeldet",
+		"status.private_unreported_hint":   "Private Sitzung: Gesehen-Status nicht gemeldet (P zum Beenden)",
+		"status.sort_save_failed":          "Sortierung konnte nicht gespeichert werden: %v",
+		"status.logout_failed":             "Abmelden fehlgeschlagen: %v",
+		"status.config_reloaded":           "Konfiguration neu geladen",
+		"status.config_reload_failed":      "Konfiguration konnte nicht neu geladen werden: %v",
+		"status.log_level":                 "Log-Level: %s",
+		"status.log_level_unknown":         "Unbekanntes Log-Level: %s",
+		"status.log_level_hint":            "debug, info, warn oder error",
+		"status.log_level_save_failed":     "Log-Level konnte nicht gespeichert werden: %v",
+		"status.people_failed":             "Besetzung & Stab konnten nicht geladen werden: %v",
+		"status.loading_playlists":         "Lade Playlists...",
+		"status.smart_playlist":            "Intelligente Playlists können hier nicht bearbeitet werden",
+		"status.playlist_title_empty":      "Der Playlist-Titel darf nicht leer sein",
+		"status.playlist_edit_cancelled":   "Bearbeitung der Playlist abgebrochen",
+		"status.playlist_updated":          "Playlist aktualisiert",
+		"status.playlist_update_failed":    "Playlist konnte nicht aktualisiert werden: %v",
+		"status.playlist_created":          "Playlist erstellt: %s",
+		"status.playlist_create_failed":    "Playlist konnte nicht erstellt werden: %v",
+		"status.playlist_title_updated":    "Playlist aktualisiert: %s",
+		"status.exporting_playlist":        "Exportiere Playlist...",
+		"status.playlist_exported":         "%d Einträge nach %s exportiert",
+		"status.playlist_export_failed":    "Playlist konnte nicht exportiert werden: %v",
+		"status.playlist_deleted":          "Playlist gelöscht",
+		"status.playlist_delete_failed":    "Playlist konnte nicht gelöscht werden: %v",
+		"status.arr_lookup_failed":         "Sonarr/Radarr-Suche fehlgeschlagen: %v",
+		"status.arr_adding":                "Füge hinzu: %s",
+		"status.arr_requested":             "Angefragt: %s",
+		"status.arr_add_failed":            "%s konnte nicht hinzugefügt werden: %v",
+		"status.server_unreachable":        "Server nicht erreichbar — verbinde neu",
+		"status.server_online":             "Server wieder erreichbar",
+		"status.new_items.one":             "1 neuer Eintrag in %s — a zum Ansehen",
+		"status.new_items":                 "%d neue Einträge in %s — a zum Ansehen",
+		"status.no_new_items":              "Keine neuen Einträge seit dem Start von kino",
+		"status.new_items_gone":            "Die neuen Einträge sind nicht mehr in %s",
+		"status.playlist_needs_marks":      "Einträge mit v markieren, dann mit n eine Playlist daraus machen",
+		"status.playlist_needs_items":      "Playlists auf diesem Server brauchen Einträge: einige mit v markieren, dann n drücken",
+		"status.arr_status":                "%s: %s",
+		"status.batch_cancelled_watched":   "Abgebrochen nach %d als gesehen markierten",
+		"status.batch_cancelled_unwatched": "Abgebrochen nach %d als ungesehen markierten",
+		"status.batch_failed_watched":      "%d als gesehen markiert, %d fehlgeschlagen: %v",
+		"status.batch_failed_unwatched":    "%d als ungesehen markiert, %d fehlgeschlagen: %v",
+		"status.batch_marked_watched":      "%d Einträge als gesehen markiert",
+		"status.batch_marked_unwatched":    "%d Einträge als ungesehen markiert",
+		"status.specials_save_failed":      "Specials-Einstellung konnte nicht gespeichert werden: %v",
+		"status.hidden_save_failed":        "Ausgeblendete Mediatheken konnten nicht gespeichert werden: %v",
+		"status.specials_sink":             "Specials und Extras: am Ende",
+		"status.specials_hide":             "Specials und Extras: ausgeblendet",
+		"status.specials_order":            "Specials und Extras: in Reihenfolge",
+		"status.finding_person":            "Suche Einträge mit %s...",
+		"status.no_people":                 "Für diesen Eintrag ist keine Besetzung angegeben",
+		"status.person_not_found":          "Nichts mit %s in deinen Mediatheken",
+		"status.watchlist_needs_plex":      "Die Merkliste braucht einen Plex-Server",
+		"status.watchlist_types":           "Nur Filme und Serien kommen auf die Merkliste",
+		"status.watchlist_added":           "Zur Merkliste hinzugefügt: %s",
+		"status.watchlist_removed":         "Von der Merkliste entfernt: %s",
+		"status.remove_playlists_only":     "Entfernen (x) geht nur in Playlists",
+		"status.no_delete":                 "Dieser Server unterstützt kein Löschen von Medien",
+		"status.deleted":                   "Vom Server gelöscht: %s",
+		"status.command_invalid":           ":%s: %v",
+		"status.unknown_command":           "Unbekannter Befehl: %s",
+		"status.sort_needs_field":          ":sort braucht ein Feld",
+		"status.cannot_sort":               "Hier kann nicht nach %s sortiert werden",
+		"status.unknown_direction":         "Unbekannte Richtung: %s",
+		"status.filter_needs_state":        ":filter braucht einen Gesehen-Status",
+		"status.unknown_watch_state":       "Unbekannter Gesehen-Status: %s",
+		"status.goto_needs_title":          ":goto braucht einen Titel",
+		"status.goto_failed":               "goto: %v",
+		"status.finding":                   "Suche %s...",
+		"status.playlist_needs_name":       ":playlist braucht add und einen Namen",
+		"status.split_view":                "Geteilte Ansicht",
+		"status.not_split":                 "Nicht geteilt",
+		"status.no_peek_split":             "Keine Vorschau in geteilter Ansicht",
+		"status.show_library_not_found":    "Keine Mediathek für %s gefunden",
+		"status.item_not_found":            "Eintrag nicht gefunden (Mediathek hat sich evtl. geändert)",
+		"status.navigation_failed":         "Navigation fehlgeschlagen",
+		"status.library_not_found":         "Mediathek nicht gefunden: %s",
+		"status.continue_removed":          "Aus Weiterschauen entfernt: %s",
+		"status.auth_failed":               "Sitzung abgelaufen oder widerrufen — L zum Abmelden, dann kino starten und neu anmelden",
+		"status.token_save_failed":         "Angemeldet, aber das Token konnte nicht gespeichert werden: %v",
+		"status.signed_in":                 "Wieder angemeldet",
+		"status.not_signed_in":             "Nicht angemeldet — Anfragen schlagen fehl, bis du dich neu anmeldest",
+		"status.scan_finished":             "Server fertig: %s",
+		"status.no_activities":             "Dieser Server meldet seine Aktivität nicht",
+		"status.libraries_shown":           "%d von %d Mediatheken angezeigt",
+		"status.bookmark_which":            "Position auf welche Taste merken? 1-9",
+		"status.bookmarked":                "Gemerkt auf %d: %s",
+		"status.no_bookmark":               "Keine Position auf %[1]d (m%[1]d merkt eine)",
+		"status.bookmark_gone":             "Die Mediathek von Position %d ist weg: %s",
+		"status.jumped":                    "Gesprungen zu %s",
+		"status.job_cancelled":             "Abgebrochen: %s",
+		"hint.allow_delete":                "ui.allow_delete setzen, um Medien vom Server zu löschen",
+		"hint.sort_example":                "z. B. :sort added desc",
+		"hint.sort_directions":             "asc oder desc",
+		"hint.watch_states":                "all, unwatched oder inprogress",
+		"hint.goto_example":                "z. B. :goto show \"The Wire\" s3e5",
+		"hint.playlist_example":            "z. B. :playlist add Favoriten",
+		"hint.split_keys":                  "ctrl+w wechselt den Bereich · | schließt",
+		"hint.split":                       "| teilt die Ansicht",
+		"hint.refresh_all":                 "R zum Aktualisieren",
+		"hint.retry":                       "r für neuen Versuch",
+		"hint.retrying":                    "neuer Versuch, sobald er wieder da ist",
+		"hint.resumes":                     "geht weiter, sobald er wieder da ist",
+		"hint.close_split":                 "| schließt die Teilung",
+
+		// Actions named in "not available" notices
+		"action.sort":           "Sortieren (s)",
+		"action.mark_watched":   "Als gesehen markieren (w)",
+		"action.mark_unwatched": "Als ungesehen markieren (u)",
+		"action.open_imdb":      "IMDb/TMDB öffnen (o)",
+		"action.play":           "Abspielen (p)",
+		"action.playlists":      "Playlists (Leertaste)",
+		"action.mark":           "Markieren (v)",
+		"action.watch_filter":   "Gesehen-Filter (W)",
+		"action.export":         "Exportieren (E)",
+		"action.rate":           "Bewerten (*)",
+		"action.play_on":        "Abspielen auf (C)",
+		"action.people":         "Besetzung & Stab (c)",
+		"action.delete":         "Löschen (x)",
+		"action.debug":          "Debug-Overlay (D)",
+		"action.specials":       "Specials (S)",
+		"action.details":        "Details (I)",
+		"action.libraries":      "Mediatheken (H)",
+		"action.bookmark":       "Position merken (m)",
+
+		// Failure reasons and what to do about them
+		"error.network":          "Server nicht erreichbar",
+		"error.network_action":   "Server-URL prüfen und ob der Server läuft",
+		"error.auth":             "Anmeldung abgelehnt",
+		"error.auth_action":      "L zum Abmelden, dann neu anmelden",
+		"error.not_found":        "nicht mehr auf dem Server",
+		"error.not_found_action": "r zum Aktualisieren",
+		"error.server":           "der Server hat die Anfrage nicht erfüllt",
+		"error.server_action":    "gleich noch einmal versuchen oder die Server-Logs prüfen",
+		"error.forbidden":        "für dieses Konto nicht erlaubt",
+		"error.forbidden_action": "den Server-Admin um die Berechtigung bitten",
+		"error.parse":            "unerwartete Antwort vom Server",
+		"error.parse_action":     "die Serverversion wird evtl. nicht unterstützt; siehe Log",
+
+		// What failed, ahead of the reason ("loading seasons: ...")
+		"context.loading_libraries":  "Laden der Mediatheken",
+		"context.loading_movies":     "Laden der Filme",
+		"context.loading_shows":      "Laden der Serien",
+		"context.loading_library":    "Laden der Mediathek",
+		"context.loading_seasons":    "Laden der Staffeln",
+		"context.loading_episodes":   "Laden der Folgen",
+		"context.starting_playback":  "Starten der Wiedergabe",
+		"context.opening_browser":    "Öffnen des Browsers",
+		"context.marking_watched":    "Markieren als gesehen",
+		"context.marking_unwatched":  "Markieren als ungesehen",
+		"context.rating":             "Bewerten von %s",
+		"context.deleting":           "Löschen von %s",
+		"context.starting_queue":     "Starten der Warteschlange",
+		"context.loading_channels":   "Laden der Sender",
+		"context.loading_calendar":   "Laden des Kalenders",
+		"context.loading_continue":   "Laden von Weiterschauen",
+		"context.removing_continue":  "Entfernen aus Weiterschauen",
+		"context.loading_watchlist":  "Laden der Merkliste",
+		"context.adding_watchlist":   "Hinzufügen zur Merkliste",
+		"context.removing_watchlist": "Entfernen von der Merkliste",
+		"context.loading_playlists":  "Laden der Playlists",
+		"context.loading_playlist":   "Laden der Playlist",
+		"context.checking_playlists": "Prüfen der Playlists",
+		"context.finding_devices":    "Suche nach Geräten",
+		"context.playing_on":         "Wiedergabe auf %s",
+		"context.controlling":        "Steuern von %s",
+		"context.controlling_player": "Steuern des Players",
+		"context.finding_person":     "Suche nach Einträgen mit %s",
+
+		// Durations and relative dates
+		"duration.minutes": "%d Min.",
+		"ago.now":          "gerade eben",
+		"ago.today":        "heute",
+		"ago.yesterday":    "gestern",
+		"ago.minutes.one":  "vor 1 Min.",
+		"ago.minutes":      "vor %d Min.",
+		"ago.hours.one":    "vor 1 Stunde",
+		"ago.hours":        "vor %d Stunden",
+		"ago.days.one":     "vor 1 Tag",
+		"ago.days":         "vor %d Tagen",
+		"ago.weeks.one":    "vor 1 Woche",
+		"ago.weeks":        "vor %d Wochen",
+		"ago.months.one":   "vor 1 Monat",
+		"ago.months":       "vor %d Monaten",
+		"ago.years.one":    "vor 1 Jahr",
+		"ago.years":        "vor %d Jahren",
+		"in.tomorrow":      "morgen",
+		"in.minutes.one":   "in 1 Min.",
+		"in.minutes":       "in %d Min.",
+		"in.hours.one":     "in 1 Stunde",
+		"in.hours":         "in %d Stunden",
+		"in.days.one":      "in 1 Tag",
+		"in.days":          "in %d Tagen",
+		"in.weeks.one":     "in 1 Woche",
+		"in.weeks":         "in %d Wochen",
+		"in.months.one":    "in 1 Monat",
+		"in.months":        "in %d Monaten",
+		"in.years.one":     "in 1 Jahr",
+		"in.years":         "in %d Jahren",
+
+		// First-run setup
+		"setup.welcome":         "Willkommen bei Kino!",
+		"setup.server_url":      "Server-URL (z. B. http://192.168.1.100:32400) oder Medienordner eingeben,",
+		"setup.server_url_plex": "oder Enter drücken, um dich bei Plex anzumelden und einen Server zu wählen:",
+		"setup.detecting":       "Servertyp wird erkannt...",
+		"setup.detected":        "Erkannt: %s",
+		"setup.detect_failed":   "Servertyp konnte nicht erkannt werden: %v",
+		"setup.check_url":       "Bitte URL prüfen und erneut versuchen.",
+		"setup.servers":         "Server in deinem Konto:",
+		"setup.shared":          "geteilt",
+		"setup.offline":         "offline",
+		"setup.choose_server":   "Server wählen",
+		"setup.invalid_choice":  "Ungültige Auswahl. Bitte erneut versuchen.",
+		"setup.connecting":      "Verbinde mit %s...",
+		"setup.unreachable":     "%s ist unter keiner seiner Adressen erreichbar.",
+		"setup.relay":           "Über das Plex-Relay verbunden (begrenzte Bandbreite).",
+		"setup.users":           "Benutzer:",
+		"setup.current":         "aktuell",
+		"setup.whos_watching":   "Wer schaut?",
+		"setup.switch_to":       "Wechseln zu",
+		"setup.secret_for":      "%s für %s: ",
+		"setup.wrong_secret":    "Falsche Eingabe (%s). Bitte erneut versuchen.",
+		"setup.signed_in_as":    "Angemeldet als %s. Kino startet...",
+		"setup.saved":           "Konfiguration gespeichert! Kino startet...",
+		"setup.logged_out":      "Abgemeldet. Starte kino erneut, um dich anzumelden.",
+		"setup.logout_cache":    "Cache konnte nicht gelöscht werden: %v",
+	},
+}
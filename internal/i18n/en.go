@@ -0,0 +1,357 @@
+package i18n
+
+// english is the source catalog: every key has an English message
+var english = locale{
+	weekdays:  [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	months:    [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	date:      "{mon} {d}, {yyyy}",
+	dayDate:   "{wd} {mon} {d}",
+	monthYear: "{mon} {yyyy}",
+	clock24:   false,
+	messages: map[string]string{
+		// Help screen
+		"help.navigation":         "NAVIGATION",
+		"help.up_down":            "Up/down",
+		"help.parent_drill":       "Parent/drill in",
+		"help.back":               "Back (close column)",
+		"help.first":              "First item",
+		"help.last":               "Last item",
+		"help.page":               "Scroll page",
+		"help.half_page":          "Scroll half page",
+		"help.mark_batch":         "Mark for batch",
+		"help.peek":               "Peek at children",
+		"help.search_view":        "SEARCH & VIEW",
+		"help.filter":             "Filter",
+		"help.watch_filter":       "Watch filter",
+		"help.global_search":      "Global search",
+		"help.arr_lookup":         "Sonarr/Radarr lookup (in search)",
+		"help.sort":               "Sort",
+		"help.specials":           "Specials/extras",
+		"help.hide_libraries":     "Show/hide libraries",
+		"help.inspector":          "Toggle inspector",
+		"help.details":            "Full-screen details",
+		"help.people":             "Cast & crew",
+		"help.open_imdb":          "Open IMDb/TMDB",
+		"help.new_items":          "New items (last sync)",
+		"help.home":               "Home screen",
+		"help.split":              "Split view",
+		"help.switch_pane":        "Switch pane",
+		"help.playback":           "PLAYBACK",
+		"help.play_resume":        "Play/resume",
+		"help.play_start":         "Play from start",
+		"help.mark_watched":       "Mark watched",
+		"help.mark_unwatched":     "Mark unwatched",
+		"help.rate":               "Rate (1-10)",
+		"help.quality":            "Stream quality",
+		"help.private":            "Private session",
+		"help.cast":               "Play on another device",
+		"help.pause_player":       "Pause/resume player",
+		"help.seek_player":        "Seek back/forward",
+		"help.stop_player":        "Stop player",
+		"help.playlists":          "PLAYLISTS",
+		"help.playlist_toggle":    "Add/remove item",
+		"help.delete":             "Delete / remove",
+		"help.edit_playlist":      "Edit playlist",
+		"help.export":             "Export to M3U",
+		"help.new_playlist":       "New playlist",
+		"help.other":              "OTHER",
+		"help.refresh":            "Refresh view",
+		"help.refresh_all":        "Refresh all",
+		"help.jobs":               "Background jobs",
+		"help.watchlist":          "Watchlist add/remove",
+		"help.activity":           "Server activity",
+		"help.command":            "Command line",
+		"help.bookmark":           "Bookmark location",
+		"help.jump_bookmark":      "Jump to bookmark",
+		"help.request_log":        "API request log",
+		"help.logout":             "Logout",
+		"help.close":              "Close / Cancel",
+		"help.quit":               "Quit",
+		"help.return":             "Press any key to return...",
+		"keys.conflicts_title":    "Key binding conflicts",
+		"keys.conflicts_disabled": "These keys are disabled until the bindings are fixed.",
+		"keys.conflicts_continue": "Press any key to continue...",
+
+		// Footer
+		"footer.dismiss":     "esc to dismiss",
+		"footer.delete":      "Delete",
+		"footer.remove":      "Remove",
+		"footer.menu":        "menu",
+		"footer.marked":      "%d marked",
+		"footer.marked_hint": "w/u/p/space apply · esc clear",
+		"footer.help":        "help",
+		"footer.private":     "private",
+		"footer.on_device":   "on %s",
+		"footer.jobs":        "%d jobs",
+		"footer.job":         "1 job",
+
+		// Confirmations
+		"confirm.buttons":       "[Y] Yes      [N] No",
+		"logout.title":          "Log Out?",
+		"logout.body1":          "This will clear your credentials,",
+		"logout.body2":          "server URL, and all cached data.",
+		"playlist.delete_title": "Delete Playlist?",
+		"playlist.delete_body1": "will be permanently deleted",
+		"playlist.delete_body2": "from the server.",
+		"next_episode.title":    "Play Next Episode?",
+
+		// Status messages
+		"status.launched":                  "Launched: %s",
+		"status.launching":                 "Launching: %s",
+		"status.launching_version":         "Launching: %s (%s)",
+		"status.queueing":                  "Queueing %d items...",
+		"status.queue_launched":            "Launched queue of %d items",
+		"status.not_aired":                 "Not aired yet: %s",
+		"status.marked_watched":            "Marked watched: %s",
+		"status.marked_unwatched":          "Marked unwatched: %s",
+		"status.marks_cleared":             "Marks cleared",
+		"status.rated":                     "Rated %s %g/10",
+		"status.rating_cleared":            "Cleared rating: %s",
+		"status.no_ratings":                "This server does not store ratings",
+		"status.not_available":             "%s is not available for this item",
+		"status.no_inspector_split":        "No inspector in split view",
+		"status.navigation_cancelled":      "Navigation cancelled",
+		"status.sync_cancelled":            "Sync cancelled: %s",
+		"status.library_gone":              "Library no longer exists on server — navigation reset",
+		"status.showing":                   "Showing: %s",
+		"status.opening":                   "Opening %s",
+		"status.quality":                   "Stream quality: %s",
+		"status.player_stopped":            "Stopped: %s",
+		"status.reattached":                "Reattached: %s",
+		"status.finding_devices":           "Finding devices...",
+		"status.no_devices":                "No devices to play on",
+		"status.casting":                   "Sending %s to %s...",
+		"status.cast_started":              "Playing %s on %s",
+		"status.up_next":                   "Up next: %s",
+		"status.next_episode_failed":       "Next episode: %v",
+		"status.show_finished":             "Finished %s",
+		"status.private_on":                "Private session on: watch state won't be reported",
+		"status.private_off":               "Private session off",
+		"status.private_unreported":        "Private session: watch state not reported",
+		"status.private_unreported_hint":   "Private session: watch state not reported (P to end)",
+		"status.sort_save_failed":          "Couldn't save sort: %v",
+		"status.logout_failed":             "Logout failed: %v",
+		"status.config_reloaded":           "Config reloaded",
+		"status.config_reload_failed":      "Couldn't reload config: %v",
+		"status.log_level":                 "Log level: %s",
+		"status.log_level_unknown":         "Unknown log level: %s",
+		"status.log_level_hint":            "debug, info, warn or error",
+		"status.log_level_save_failed":     "Couldn't save the log level: %v",
+		"status.people_failed":             "Couldn't load cast & crew: %v",
+		"status.loading_playlists":         "Loading playlists...",
+		"status.smart_playlist":            "Smart playlists can't be edited here",
+		"status.playlist_title_empty":      "Playlist title can't be empty",
+		"status.playlist_edit_cancelled":   "Playlist edit cancelled",
+		"status.playlist_updated":          "Playlist updated",
+		"status.playlist_update_failed":    "Failed to update playlist: %v",
+		"status.playlist_created":          "Created playlist: %s",
+		"status.playlist_create_failed":    "Failed to create playlist: %v",
+		"status.playlist_title_updated":    "Updated playlist: %s",
+		"status.exporting_playlist":        "Exporting playlist...",
+		"status.playlist_exported":         "Exported %d items to %s",
+		"status.playlist_export_failed":    "Failed to export playlist: %v",
+		"status.playlist_deleted":          "Playlist deleted",
+		"status.playlist_delete_failed":    "Failed to delete playlist: %v",
+		"status.arr_lookup_failed":         "Sonarr/Radarr lookup failed: %v",
+		"status.arr_adding":                "Adding: %s",
+		"status.arr_requested":             "Requested: %s",
+		"status.arr_add_failed":            "Failed to add %s: %v",
+		"status.server_unreachable":        "Server unreachable — reconnecting",
+		"status.server_online":             "Server back online",
+		"status.new_items.one":             "1 new item in %s — a to view",
+		"status.new_items":                 "%d new items in %s — a to view",
+		"status.no_new_items":              "No new items since kino started",
+		"status.new_items_gone":            "The new items are gone from %s",
+		"status.playlist_needs_marks":      "Mark items with v, then press n for a playlist of them",
+		"status.playlist_needs_items":      "Playlists on this server need items: mark some with v, then press n",
+		"status.arr_status":                "%s: %s",
+		"status.batch_cancelled_watched":   "Cancelled after marking %d watched",
+		"status.batch_cancelled_unwatched": "Cancelled after marking %d unwatched",
+		"status.batch_failed_watched":      "Marked %d watched, %d failed: %v",
+		"status.batch_failed_unwatched":    "Marked %d unwatched, %d failed: %v",
+		"status.batch_marked_watched":      "Marked %d items watched",
+		"status.batch_marked_unwatched":    "Marked %d items unwatched",
+		"status.specials_save_failed":      "Couldn't save specials setting: %v",
+		"status.hidden_save_failed":        "Couldn't save hidden libraries: %v",
+		"status.specials_sink":             "Specials and extras: at the bottom",
+		"status.specials_hide":             "Specials and extras: hidden",
+		"status.specials_order":            "Specials and extras: in order",
+		"status.finding_person":            "Finding items with %s...",
+		"status.no_people":                 "No cast or crew listed for this item",
+		"status.person_not_found":          "Nothing with %s in your libraries",
+		"status.watchlist_needs_plex":      "The watchlist needs a Plex server",
+		"status.watchlist_types":           "Only movies and shows go on the watchlist",
+		"status.watchlist_added":           "Added to watchlist: %s",
+		"status.watchlist_removed":         "Removed from watchlist: %s",
+		"status.remove_playlists_only":     "Remove (x) only works in playlists",
+		"status.no_delete":                 "This server does not support deleting media",
+		"status.deleted":                   "Deleted from server: %s",
+		"status.command_invalid":           ":%s: %v",
+		"status.unknown_command":           "Unknown command: %s",
+		"status.sort_needs_field":          ":sort needs a field",
+		"status.cannot_sort":               "Cannot sort by %s here",
+		"status.unknown_direction":         "Unknown direction: %s",
+		"status.filter_needs_state":        ":filter needs a watch state",
+		"status.unknown_watch_state":       "Unknown watch state: %s",
+		"status.goto_needs_title":          ":goto needs a title",
+		"status.goto_failed":               "goto: %v",
+		"status.finding":                   "Finding %s...",
+		"status.playlist_needs_name":       ":playlist needs add and a name",
+		"status.split_view":                "Split view",
+		"status.not_split":                 "Not split",
+		"status.no_peek_split":             "No peek in split view",
+		"status.show_library_not_found":    "Library not found for %s",
+		"status.item_not_found":            "Item not found (library may have changed)",
+		"status.navigation_failed":         "Navigation failed",
+		"status.library_not_found":         "Library not found: %s",
+		"status.continue_removed":          "Removed from Continue Watching: %s",
+		"status.auth_failed":               "Session expired or revoked — press L to log out, then run kino to sign in again",
+		"status.token_save_failed":         "Signed in, but saving the token failed: %v",
+		"status.signed_in":                 "Signed in again",
+		"status.not_signed_in":             "Not signed in — requests will fail until you sign in again",
+		"status.scan_finished":             "Server finished: %s",
+		"status.no_activities":             "This server does not report its activity",
+		"status.libraries_shown":           "%d of %d libraries shown",
+		"status.bookmark_which":            "Bookmark to which key? 1-9",
+		"status.bookmarked":                "Bookmarked %d: %s",
+		"status.no_bookmark":               "No bookmark on %[1]d (m%[1]d sets it)",
+		"status.bookmark_gone":             "Bookmark %d's library is gone: %s",
+		"status.jumped":                    "Jumped to %s",
+		"status.job_cancelled":             "Cancelled: %s",
+		"hint.allow_delete":                "set ui.allow_delete to delete media from the server",
+		"hint.sort_example":                "e.g. :sort added desc",
+		"hint.sort_directions":             "asc or desc",
+		"hint.watch_states":                "all, unwatched or inprogress",
+		"hint.goto_example":                "e.g. :goto show \"The Wire\" s3e5",
+		"hint.playlist_example":            "e.g. :playlist add Favorites",
+		"hint.split_keys":                  "ctrl+w switches panes · | closes",
+		"hint.split":                       "| splits the view",
+		"hint.refresh_all":                 "R to refresh",
+		"hint.retry":                       "r to retry",
+		"hint.retrying":                    "retrying once it is back",
+		"hint.resumes":                     "resumes once it is back",
+		"hint.close_split":                 "| closes the split",
+
+		// Actions named in "not available" notices
+		"action.sort":           "Sort (s)",
+		"action.mark_watched":   "Mark watched (w)",
+		"action.mark_unwatched": "Mark unwatched (u)",
+		"action.open_imdb":      "Open IMDb/TMDB (o)",
+		"action.play":           "Play (p)",
+		"action.playlists":      "Playlists (space)",
+		"action.mark":           "Mark (v)",
+		"action.watch_filter":   "Watch filter (W)",
+		"action.export":         "Export (E)",
+		"action.rate":           "Rate (*)",
+		"action.play_on":        "Play on (C)",
+		"action.people":         "Cast & crew (c)",
+		"action.delete":         "Delete (x)",
+		"action.debug":          "Debug overlay (D)",
+		"action.specials":       "Specials (S)",
+		"action.details":        "Details (I)",
+		"action.libraries":      "Libraries (H)",
+		"action.bookmark":       "Bookmark (m)",
+
+		// Failure reasons and what to do about them
+		"error.network":          "server unreachable",
+		"error.network_action":   "check the server URL and that the server is running",
+		"error.auth":             "sign-in rejected",
+		"error.auth_action":      "press L to log out, then sign in again",
+		"error.not_found":        "no longer on the server",
+		"error.not_found_action": "r to refresh",
+		"error.server":           "the server failed the request",
+		"error.server_action":    "try again shortly, or check the server's logs",
+		"error.forbidden":        "not permitted for this account",
+		"error.forbidden_action": "ask the server's admin for the permission",
+		"error.parse":            "unexpected reply from the server",
+		"error.parse_action":     "the server version may be unsupported; see the log",
+
+		// What failed, ahead of the reason ("loading seasons: ...")
+		"context.loading_libraries":  "loading libraries",
+		"context.loading_movies":     "loading movies",
+		"context.loading_shows":      "loading shows",
+		"context.loading_library":    "loading library content",
+		"context.loading_seasons":    "loading seasons",
+		"context.loading_episodes":   "loading episodes",
+		"context.starting_playback":  "starting playback",
+		"context.opening_browser":    "opening browser",
+		"context.marking_watched":    "marking as watched",
+		"context.marking_unwatched":  "marking as unwatched",
+		"context.rating":             "rating %s",
+		"context.deleting":           "deleting %s",
+		"context.starting_queue":     "starting playback queue",
+		"context.loading_channels":   "loading channels",
+		"context.loading_calendar":   "loading calendar",
+		"context.loading_continue":   "loading continue watching",
+		"context.removing_continue":  "removing from continue watching",
+		"context.loading_watchlist":  "loading watchlist",
+		"context.adding_watchlist":   "adding to watchlist",
+		"context.removing_watchlist": "removing from watchlist",
+		"context.loading_playlists":  "loading playlists",
+		"context.loading_playlist":   "loading playlist items",
+		"context.checking_playlists": "checking playlist membership",
+		"context.finding_devices":    "finding devices",
+		"context.playing_on":         "playing on %s",
+		"context.controlling":        "controlling %s",
+		"context.controlling_player": "controlling the player",
+		"context.finding_person":     "finding items with %s",
+
+		// Durations and relative dates
+		"duration.minutes": "%d min",
+		"ago.now":          "just now",
+		"ago.today":        "today",
+		"ago.yesterday":    "yesterday",
+		"ago.minutes.one":  "1 min ago",
+		"ago.minutes":      "%d min ago",
+		"ago.hours.one":    "1 hour ago",
+		"ago.hours":        "%d hours ago",
+		"ago.days.one":     "1 day ago",
+		"ago.days":         "%d days ago",
+		"ago.weeks.one":    "1 week ago",
+		"ago.weeks":        "%d weeks ago",
+		"ago.months.one":   "1 month ago",
+		"ago.months":       "%d months ago",
+		"ago.years.one":    "1 year ago",
+		"ago.years":        "%d years ago",
+		"in.tomorrow":      "tomorrow",
+		"in.minutes.one":   "in 1 min",
+		"in.minutes":       "in %d min",
+		"in.hours.one":     "in 1 hour",
+		"in.hours":         "in %d hours",
+		"in.days.one":      "in 1 day",
+		"in.days":          "in %d days",
+		"in.weeks.one":     "in 1 week",
+		"in.weeks":         "in %d weeks",
+		"in.months.one":    "in 1 month",
+		"in.months":        "in %d months",
+		"in.years.one":     "in 1 year",
+		"in.years":         "in %d years",
+
+		// First-run setup
+		"setup.welcome":         "Welcome to Kino!",
+		"setup.server_url":      "Enter your server URL (e.g., http://192.168.1.100:32400) or a media folder,",
+		"setup.server_url_plex": "or press Enter to sign in with Plex and pick a server:",
+		"setup.detecting":       "Detecting server type...",
+		"setup.detected":        "Detected: %s",
+		"setup.detect_failed":   "Could not detect server type: %v",
+		"setup.check_url":       "Please check the URL and try again.",
+		"setup.servers":         "Servers on your account:",
+		"setup.shared":          "shared",
+		"setup.offline":         "offline",
+		"setup.choose_server":   "Choose a server",
+		"setup.invalid_choice":  "Invalid choice. Please try again.",
+		"setup.connecting":      "Connecting to %s...",
+		"setup.unreachable":     "Could not reach %s on any of its addresses.",
+		"setup.relay":           "Connected through the Plex relay (bandwidth-limited).",
+		"setup.users":           "Users:",
+		"setup.current":         "current",
+		"setup.whos_watching":   "Who's watching?",
+		"setup.switch_to":       "Switch to",
+		"setup.secret_for":      "%s for %s: ",
+		"setup.wrong_secret":    "Wrong %s. Please try again.",
+		"setup.signed_in_as":    "Signed in as %s. Starting kino...",
+		"setup.saved":           "Configuration saved! Starting kino...",
+		"setup.logged_out":      "Logged out. Run kino again to sign in.",
+		"setup.logout_cache":    "Could not clear the cache: %v",
+	},
+}
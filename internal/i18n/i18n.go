@@ -0,0 +1,129 @@
+// Package i18n holds the interface's message catalogs and formats dates the
+// way the chosen locale writes them. English is the fallback for locales
+// and messages without a translation.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// locale is one language's catalog and date conventions. Date layouts use
+// {wd} (weekday), {d} (day), {mon} (month) and {yyyy} (year), with the
+// names abbreviated.
+type locale struct {
+	messages  map[string]string
+	weekdays  [7]string // Sunday first
+	months    [12]string
+	date      string // A full date: "Jan 2, 2006"
+	dayDate   string // A date this year: "Mon Jan 2"
+	monthYear string // "Jan 2006"
+	clock24   bool   // 24-hour clock
+}
+
+// locales are the supported locales by language
+var locales = map[string]*locale{
+	"en": &english,
+	"de": &german,
+}
+
+var current atomic.Pointer[locale]
+
+func init() {
+	current.Store(&english)
+}
+
+// Detect returns the locale to use: the configured one, else the
+// environment's (LC_ALL, LC_MESSAGES, LANG), else English
+func Detect(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(name); v != "" && v != "C" && v != "POSIX" {
+			return v
+		}
+	}
+	return "en"
+}
+
+// SetLocale switches the interface to a locale such as "de", "de_DE" or
+// "de_DE.UTF-8". Unsupported locales fall back to English and return false.
+func SetLocale(tag string) bool {
+	lang := strings.ToLower(tag)
+	if i := strings.IndexAny(lang, ".@"); i >= 0 {
+		lang = lang[:i]
+	}
+	if i := strings.IndexAny(lang, "_-"); i >= 0 {
+		lang = lang[:i]
+	}
+	l, ok := locales[lang]
+	if !ok {
+		current.Store(&english)
+		return false
+	}
+	current.Store(l)
+	return true
+}
+
+// Locales returns the supported locales
+func Locales() []string {
+	tags := make([]string, 0, len(locales))
+	for tag := range locales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// T returns the message for key in the current locale, formatted with args
+// like fmt.Sprintf. Keys missing from the catalog fall back to English,
+// then to the key itself.
+func T(key string, args ...any) string {
+	msg, ok := current.Load().messages[key]
+	if !ok {
+		if msg, ok = english.messages[key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Date formats a full date: "Jan 2, 2006"
+func Date(t time.Time) string {
+	l := current.Load()
+	return l.format(l.date, t)
+}
+
+// DayDate formats a date with its weekday and no year: "Mon Jan 2"
+func DayDate(t time.Time) string {
+	l := current.Load()
+	return l.format(l.dayDate, t)
+}
+
+// MonthYear formats a month: "Jan 2006"
+func MonthYear(t time.Time) string {
+	l := current.Load()
+	return l.format(l.monthYear, t)
+}
+
+// Clock24 reports whether the locale uses a 24-hour clock
+func Clock24() bool {
+	return current.Load().clock24
+}
+
+func (l *locale) format(layout string, t time.Time) string {
+	return strings.NewReplacer(
+		"{wd}", l.weekdays[t.Weekday()],
+		"{d}", fmt.Sprint(t.Day()),
+		"{mon}", l.months[t.Month()-1],
+		"{yyyy}", fmt.Sprint(t.Year()),
+	).Replace(layout)
+}
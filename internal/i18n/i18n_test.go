@@ -0,0 +1,77 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+)
+
+var verbRE = regexp.MustCompile(`%[a-z]`)
+
+// Translations must be of English keys and take the same arguments
+func TestCatalogsMatchEnglish(t *testing.T) {
+	for tag, l := range locales {
+		for key, msg := range l.messages {
+			en, ok := english.messages[key]
+			if !ok {
+				t.Errorf("%s: %q is not an English key", tag, key)
+				continue
+			}
+			if got, want := verbRE.FindAllString(msg, -1), verbRE.FindAllString(en, -1); !slices.Equal(got, want) {
+				t.Errorf("%s: %q takes %v, English takes %v", tag, key, got, want)
+			}
+		}
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	defer SetLocale("en")
+
+	for _, tag := range []string{"de", "de_DE.UTF-8", "de-AT", "DE_CH@euro"} {
+		if !SetLocale(tag) || T("help.quit") != "Beenden" {
+			t.Errorf("SetLocale(%q) did not pick German", tag)
+		}
+	}
+	if SetLocale("tlh") || T("help.quit") != "Quit" {
+		t.Error("an unsupported locale should fall back to English")
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("unknown key = %q, want the key", got)
+	}
+
+	SetLocale("de")
+	if got := T("status.marked_watched", "Heat"); got != "Als gesehen markiert: Heat" {
+		t.Errorf("formatted message = %q", got)
+	}
+}
+
+func TestDates(t *testing.T) {
+	defer SetLocale("en")
+	day := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+
+	SetLocale("en")
+	if got := Date(day) + " | " + DayDate(day) + " | " + MonthYear(day); got != "Mar 5, 2024 | Tue Mar 5 | Mar 2024" {
+		t.Errorf("English dates = %q", got)
+	}
+	SetLocale("de")
+	if got := Date(day) + " | " + DayDate(day) + " | " + MonthYear(day); got != "5. Mär 2024 | Di 5. Mär | Mär 2024" {
+		t.Errorf("German dates = %q", got)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+	if got := Detect(""); got != "de_DE.UTF-8" {
+		t.Errorf("Detect = %q, want LANG", got)
+	}
+	if got := Detect("en"); got != "en" {
+		t.Errorf("Detect = %q, want the configured locale", got)
+	}
+	t.Setenv("LANG", "C")
+	if got := Detect(""); got != "en" {
+		t.Errorf("Detect = %q, want English for the C locale", got)
+	}
+}
@@ -0,0 +1,141 @@
+package library
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// detailsCache holds the full metadata fetched this session, per item.
+// Credits and chapters rarely change, so entries live until InvalidateAll.
+type detailsCache struct {
+	mu    sync.Mutex
+	items map[string]*domain.ItemDetails
+}
+
+// HasDetails reports whether the backend can fetch an item's full metadata
+func (s *Service) HasDetails() bool {
+	_, ok := s.client.(domain.DetailsClient)
+	return ok
+}
+
+// CachedDetails returns an item's full metadata if it was fetched before
+func (s *Service) CachedDetails(itemID string) (*domain.ItemDetails, bool) {
+	c := &s.details
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	d, ok := c.items[itemID]
+	return d, ok
+}
+
+// FetchDetails returns an item's full metadata: credits, genres, tagline
+// and chapters. Results are cached per item.
+func (s *Service) FetchDetails(ctx context.Context, itemID string) (*domain.ItemDetails, error) {
+	if d, ok := s.CachedDetails(itemID); ok {
+		return d, nil
+	}
+	dc, ok := s.client.(domain.DetailsClient)
+	if !ok {
+		return nil, domain.ErrItemNotFound
+	}
+	d, err := dc.GetItemDetails(ctx, itemID)
+	if err != nil {
+		if ctx.Err() != context.Canceled {
+			s.logger.Warn("failed to fetch details", "error", err, "itemID", itemID)
+		}
+		return nil, err
+	}
+
+	c := &s.details
+	c.mu.Lock()
+	if c.items == nil {
+		c.items = make(map[string]*domain.ItemDetails)
+	}
+	c.items[itemID] = d
+	c.mu.Unlock()
+	return d, nil
+}
+
+// HasSimilar reports whether the backend suggests similar titles
+func (s *Service) HasSimilar() bool {
+	_, ok := s.client.(domain.SimilarClient)
+	return ok
+}
+
+// FetchSimilar returns titles similar to a movie or show. Not cached: it is
+// only asked for when the detail view opens.
+func (s *Service) FetchSimilar(ctx context.Context, itemID string) ([]domain.ListItem, error) {
+	sc, ok := s.client.(domain.SimilarClient)
+	if !ok {
+		return nil, domain.ErrItemNotFound
+	}
+	items, err := sc.GetSimilar(ctx, itemID)
+	if err != nil {
+		s.logger.Warn("failed to fetch similar titles", "error", err, "itemID", itemID)
+		return nil, err
+	}
+	return items, nil
+}
+
+// HasPersonItems reports whether the backend lists the items of a person
+func (s *Service) HasPersonItems() bool {
+	_, ok := s.client.(domain.PersonClient)
+	return ok
+}
+
+// FetchPersonItems returns the movies and shows a person acted in or
+// directed, newest first. Items the cache of libs holds are returned as
+// cached, with their current watch state and library.
+func (s *Service) FetchPersonItems(ctx context.Context, libs []domain.Library, person domain.Credit, director bool) ([]domain.ListItem, error) {
+	pc, ok := s.client.(domain.PersonClient)
+	if !ok || person.ID == "" {
+		return nil, domain.ErrItemNotFound
+	}
+	items, err := pc.GetPersonItems(ctx, person.ID, director)
+	if err != nil {
+		s.logger.Warn("failed to fetch person items", "error", err, "person", person.Name)
+		return nil, err
+	}
+
+	cached := make(map[string]domain.ListItem)
+	for _, lib := range libs {
+		var listing []domain.ListItem
+		switch lib.Type {
+		case "movie":
+			movies, _ := s.store.GetMovies(lib.ID)
+			for _, m := range movies {
+				listing = append(listing, m)
+			}
+		case "show":
+			shows, _ := s.store.GetShows(lib.ID)
+			for _, sh := range shows {
+				listing = append(listing, sh)
+			}
+		default:
+			listing, _ = s.store.GetMixedContent(lib.ID)
+		}
+		for _, item := range listing {
+			cached[item.GetID()] = item
+		}
+	}
+	for i, item := range items {
+		if c, ok := cached[item.GetID()]; ok {
+			items[i] = c
+		}
+	}
+	slices.SortStableFunc(items, func(a, b domain.ListItem) int {
+		return cmp.Compare(b.GetYear(), a.GetYear())
+	})
+	return items, nil
+}
+
+// clearDetails drops every cached item's metadata
+func (s *Service) clearDetails() {
+	c := &s.details
+	c.mu.Lock()
+	c.items = nil
+	c.mu.Unlock()
+}
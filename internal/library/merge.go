@@ -0,0 +1,82 @@
+package library
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// CachedMergedMovies returns the merged movies of several libraries when
+// every one of them is cached, without touching the network
+func (s *Service) CachedMergedMovies(libs []domain.Library) ([]*domain.MediaItem, bool) {
+	lists := make([][]*domain.MediaItem, 0, len(libs))
+	for _, lib := range libs {
+		movies, ok := s.store.GetMovies(lib.ID)
+		if !ok {
+			return nil, false
+		}
+		lists = append(lists, movies)
+	}
+	return MergeMovies(lists), true
+}
+
+// FetchMergedMovies loads every library (from cache where possible) and
+// merges them into one deduplicated list
+func (s *Service) FetchMergedMovies(ctx context.Context, libs []domain.Library) ([]*domain.MediaItem, error) {
+	lists := make([][]*domain.MediaItem, 0, len(libs))
+	for _, lib := range libs {
+		movies, ok := s.store.GetMovies(lib.ID)
+		if !ok {
+			var err error
+			movies, err = s.FetchMovies(ctx, lib.ID, lib.UpdatedAt, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		lists = append(lists, movies)
+	}
+	merged := MergeMovies(lists)
+	s.logger.Debug("merged movie libraries", "libraries", len(libs), "count", len(merged))
+	return merged, nil
+}
+
+// MergeMovies concatenates movie lists and drops duplicates — the same
+// movie in "Movies" and "4K" has different IDs, so identity is normalized
+// title + year. The copy from the earliest list wins, letting the configured
+// library order express a preference. The result is ordered by sort title:
+// concatenation order means nothing to the user.
+func MergeMovies(lists [][]*domain.MediaItem) []*domain.MediaItem {
+	seen := make(map[string]bool)
+	var merged []*domain.MediaItem
+	for _, movies := range lists {
+		for _, m := range movies {
+			key := movieKey(m)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, m)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return strings.ToLower(merged[i].GetSortTitle()) < strings.ToLower(merged[j].GetSortTitle())
+	})
+	return merged
+}
+
+// movieKey is a movie's cross-library identity
+func movieKey(m *domain.MediaItem) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(m.Title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('|')
+	b.WriteString(strconv.Itoa(m.Year))
+	return b.String()
+}
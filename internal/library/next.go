@@ -0,0 +1,63 @@
+package library
+
+import (
+	"context"
+	"slices"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// NextEpisode returns the episode after ep in airing order, crossing into
+// the next season when ep ends its season. Specials and extras are never
+// "next". Returns nil at the end of the show. Seasons and episodes come
+// from the cache when present, else they are fetched (and cached).
+func (s *Service) NextEpisode(ctx context.Context, libID string, ep domain.MediaItem) (*domain.MediaItem, error) {
+	if ep.Type != domain.MediaTypeEpisode || ep.ShowID == "" || ep.ParentID == "" {
+		return nil, nil
+	}
+	seasons, ok := s.store.GetSeasons(libID, ep.ShowID)
+	if !ok {
+		var err error
+		if seasons, err = s.FetchSeasons(ctx, libID, ep.ShowID); err != nil {
+			return nil, err
+		}
+	}
+
+	regular := make([]*domain.Season, 0, len(seasons))
+	for _, season := range seasons {
+		if season != nil && !season.Extras && season.SeasonNum > 0 {
+			regular = append(regular, season)
+		}
+	}
+	slices.SortStableFunc(regular, func(a, b *domain.Season) int { return a.SeasonNum - b.SeasonNum })
+
+	current := slices.IndexFunc(regular, func(season *domain.Season) bool { return season.ID == ep.ParentID })
+	if current < 0 {
+		return nil, nil // A special: no natural successor
+	}
+	for i, season := range regular[current:] {
+		episodes, err := s.seasonEpisodes(ctx, libID, ep.ShowID, season.ID)
+		if err != nil {
+			return nil, err
+		}
+		start := 0
+		if i == 0 {
+			start = slices.IndexFunc(episodes, func(e *domain.MediaItem) bool { return e.ID == ep.ID }) + 1
+			if start == 0 {
+				return nil, nil // Not in its own season any more
+			}
+		}
+		if start < len(episodes) {
+			return episodes[start], nil
+		}
+	}
+	return nil, nil
+}
+
+// seasonEpisodes returns a season's episodes, cached or fetched
+func (s *Service) seasonEpisodes(ctx context.Context, libID, showID, seasonID string) ([]*domain.MediaItem, error) {
+	if episodes, ok := s.store.GetEpisodes(libID, showID, seasonID); ok {
+		return episodes, nil
+	}
+	return s.FetchEpisodes(ctx, libID, showID, seasonID)
+}
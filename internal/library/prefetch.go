@@ -0,0 +1,112 @@
+package library
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// prefetchDelay is how long a show must stay selected before it is
+// prefetched, so scrolling through a list doesn't fire requests per row
+const prefetchDelay = 300 * time.Millisecond
+
+// prefetchTimeout bounds one show's prefetch once it starts
+const prefetchTimeout = 30 * time.Second
+
+// prefetcher runs at most one show prefetch at a time. gen identifies the
+// current one so a finished prefetch only clears its own state.
+type prefetcher struct {
+	mu     sync.Mutex
+	showID string
+	cancel context.CancelFunc
+	gen    int
+}
+
+// PrefetchShow warms the cache for a selected show in the background: its
+// seasons, then its first season's episodes, so drilling in is instant.
+// Anything already cached is not refetched. It returns immediately; a
+// PrefetchShow for another show or CancelPrefetch stops it.
+func (s *Service) PrefetchShow(libID, showID string) {
+	p := &s.prefetch
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		if p.showID == showID {
+			return
+		}
+		p.cancel()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), prefetchDelay+prefetchTimeout)
+	p.gen++
+	gen := p.gen
+	p.showID, p.cancel = showID, cancel
+
+	go func() {
+		defer func() {
+			p.mu.Lock()
+			if p.gen == gen {
+				p.showID, p.cancel = "", nil
+			}
+			p.mu.Unlock()
+			cancel()
+		}()
+		s.prefetchShow(ctx, libID, showID)
+	}()
+}
+
+// CancelPrefetch stops the prefetch in flight, if any
+func (s *Service) CancelPrefetch() {
+	p := &s.prefetch
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		p.cancel()
+		p.showID, p.cancel = "", nil
+	}
+}
+
+// prefetchShow waits out the selection delay, then fetches what is missing
+func (s *Service) prefetchShow(ctx context.Context, libID, showID string) {
+	timer := time.NewTimer(prefetchDelay)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return
+	case <-timer.C:
+	}
+
+	seasons, ok := s.store.GetSeasons(libID, showID)
+	if !ok {
+		var err error
+		if seasons, err = s.FetchSeasons(ctx, libID, showID); err != nil {
+			return
+		}
+	}
+	season := firstSeason(seasons)
+	if season == nil {
+		return
+	}
+	if _, ok := s.store.GetEpisodes(libID, showID, season.ID); ok {
+		return
+	}
+	if _, err := s.FetchEpisodes(ctx, libID, showID, season.ID); err == nil {
+		s.logger.Debug("prefetched show", "showID", showID, "seasonID", season.ID)
+	}
+}
+
+// firstSeason is the season a drill-in most likely opens: the first regular
+// season, or specials when the show has nothing else
+func firstSeason(seasons []*domain.Season) *domain.Season {
+	var first *domain.Season
+	for _, season := range seasons {
+		if season == nil {
+			continue
+		}
+		if first == nil || season.SeasonNum > 0 && (first.SeasonNum == 0 || season.SeasonNum < first.SeasonNum) {
+			first = season
+		}
+	}
+	return first
+}
@@ -0,0 +1,342 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// ErrLinkNotFound is returned when a deep link matches nothing
+var ErrLinkNotFound = errors.New("no matching item")
+
+// Link is a parsed deep-link reference: "Movie Title", "Movie Title (1999)",
+// "Show/S02E05" or "Show/S02"
+type Link struct {
+	Title   string
+	Year    int // 0 = any
+	Season  int // -1 = none; 0 is the specials season
+	Episode int // 0 = none
+}
+
+var (
+	linkYearRe    = regexp.MustCompile(`^(.*\S)\s*\((\d{4})\)$`)
+	linkEpisodeRe = regexp.MustCompile(`(?i)^s(\d{1,3})(?:e(\d{1,4}))?$`)
+	queryCodeRe   = regexp.MustCompile(`(?i)^(.*\S)\s+(?:s(\d{1,3})\s*e(\d{1,4})|(\d{1,3})x(\d{1,4}))$`)
+)
+
+// ParseLink parses a deep-link reference. The part after the last "/" is
+// read as an episode code only when it looks like one, so titles containing
+// a slash still work ("Face/Off").
+func ParseLink(ref string) (Link, error) {
+	link := Link{Title: strings.TrimSpace(ref), Season: -1}
+	if i := strings.LastIndex(link.Title, "/"); i >= 0 {
+		if m := linkEpisodeRe.FindStringSubmatch(strings.TrimSpace(link.Title[i+1:])); m != nil {
+			link.Season, _ = strconv.Atoi(m[1])
+			if m[2] != "" {
+				link.Episode, _ = strconv.Atoi(m[2])
+			}
+			link.Title = strings.TrimSpace(link.Title[:i])
+		}
+	}
+	if m := linkYearRe.FindStringSubmatch(link.Title); m != nil {
+		link.Title = m[1]
+		link.Year, _ = strconv.Atoi(m[2])
+	}
+	if link.Title == "" {
+		return Link{}, fmt.Errorf("empty title in %q", ref)
+	}
+	return link, nil
+}
+
+// ParseEpisodeQuery reads a search query that ends in an episode code,
+// "wire s03e05" or "breaking bad 2x08", as a link to that episode of a
+// show named by the words before it
+func ParseEpisodeQuery(query string) (Link, bool) {
+	m := queryCodeRe.FindStringSubmatch(strings.TrimSpace(query))
+	if m == nil {
+		return Link{}, false
+	}
+	season, episode := m[2], m[3]
+	if season == "" {
+		season, episode = m[4], m[5]
+	}
+	link := Link{Title: m[1]}
+	link.Season, _ = strconv.Atoi(season)
+	link.Episode, _ = strconv.Atoi(episode)
+	if link.Episode == 0 {
+		return Link{}, false
+	}
+	return link, true
+}
+
+// ItemLink returns the deep-link reference for a movie or episode, the
+// inverse of ParseLink: "Heat (1995)" or "The Wire/S01E02"
+func ItemLink(item *domain.MediaItem) string {
+	if item.Type == domain.MediaTypeEpisode {
+		return fmt.Sprintf("%s/S%02dE%02d", item.ShowTitle, item.SeasonNum, item.EpisodeNum)
+	}
+	if item.Year > 0 {
+		return fmt.Sprintf("%s (%d)", item.Title, item.Year)
+	}
+	return item.Title
+}
+
+// IsEpisode reports whether the link names a season or episode of a show
+func (l Link) IsEpisode() bool {
+	return l.Season >= 0
+}
+
+// Resolved is the target of a deep link with its navigation context
+type Resolved struct {
+	Library domain.Library
+	Movie   *domain.MediaItem // Set for movie links
+	Show    *domain.Show      // Set for show, season and episode links
+	Season  *domain.Season    // Set for season and episode links
+	Episode *domain.MediaItem // Set for episode links
+}
+
+// Playable returns the item to play: the movie, or the episode
+func (r *Resolved) Playable() *domain.MediaItem {
+	if r.Episode != nil {
+		return r.Episode
+	}
+	return r.Movie
+}
+
+// Resolve finds a deep link's target. Titles are matched case-insensitively
+// against the cached libraries first; when nothing matches (first run, or
+// an item added since the last sync) the server's search is asked instead.
+// Seasons and episodes come from the cache when present, else the network.
+func (s *Service) Resolve(ctx context.Context, libs []domain.Library, link Link) (*Resolved, error) {
+	res := s.resolveCached(libs, link)
+	if res == nil {
+		var err error
+		if res, err = s.resolveSearch(ctx, libs, link); err != nil {
+			return nil, err
+		}
+	}
+	if res == nil {
+		return nil, fmt.Errorf("%w: %q", ErrLinkNotFound, link.Title)
+	}
+	if !link.IsEpisode() {
+		return res, nil
+	}
+	if res.Show == nil {
+		return nil, fmt.Errorf("%w: %q is not a show", ErrLinkNotFound, link.Title)
+	}
+	return res, s.resolveEpisode(ctx, res, link)
+}
+
+// titleMatches compares titles case-insensitively, and the year if given
+func titleMatches(link Link, title string, year int) bool {
+	return strings.EqualFold(strings.TrimSpace(title), link.Title) && (link.Year == 0 || link.Year == year)
+}
+
+// resolveCached looks the title up in the cached library content
+func (s *Service) resolveCached(libs []domain.Library, link Link) *Resolved {
+	for _, lib := range libs {
+		if !link.IsEpisode() {
+			if movies, ok := s.store.GetMovies(lib.ID); ok {
+				for _, m := range movies {
+					if titleMatches(link, m.Title, m.Year) {
+						return &Resolved{Library: lib, Movie: m}
+					}
+				}
+			}
+		}
+		if shows, ok := s.store.GetShows(lib.ID); ok {
+			for _, sh := range shows {
+				if titleMatches(link, sh.Title, sh.Year) {
+					return &Resolved{Library: lib, Show: sh}
+				}
+			}
+		}
+		if items, ok := s.store.GetMixedContent(lib.ID); ok {
+			for _, item := range items {
+				switch v := item.(type) {
+				case *domain.MediaItem:
+					if !link.IsEpisode() && titleMatches(link, v.Title, v.Year) {
+						return &Resolved{Library: lib, Movie: v}
+					}
+				case *domain.Show:
+					if titleMatches(link, v.Title, v.Year) {
+						return &Resolved{Library: lib, Show: v}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolveSearch asks the server's search for the title. Results outside
+// the known libraries are skipped: navigation needs the library context.
+func (s *Service) resolveSearch(ctx context.Context, libs []domain.Library, link Link) (*Resolved, error) {
+	searcher, ok := s.client.(domain.SearchClient)
+	if !ok {
+		return nil, nil
+	}
+	items, err := searcher.Search(ctx, link.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]domain.Library, len(libs))
+	for _, lib := range libs {
+		byID[lib.ID] = lib
+	}
+	for _, item := range items {
+		lib, known := byID[item.LibraryID]
+		if !known || !titleMatches(link, item.Title, item.Year) {
+			continue
+		}
+		switch {
+		case item.Type == domain.MediaTypeMovie && !link.IsEpisode():
+			return &Resolved{Library: lib, Movie: item}, nil
+		case item.Type == domain.MediaTypeShow:
+			return &Resolved{Library: lib, Show: searchedShow(item, lib)}, nil
+		}
+	}
+	return nil, nil
+}
+
+// searchedShow is the show a server search result stands for
+func searchedShow(item *domain.MediaItem, lib domain.Library) *domain.Show {
+	return &domain.Show{
+		ID:        item.ID,
+		Title:     item.Title,
+		SortTitle: item.SortTitle,
+		LibraryID: lib.ID,
+		Year:      item.Year,
+	}
+}
+
+// ResolveEpisodeQuery finds the episode a search query names (see
+// ParseEpisodeQuery). The title need not be exact: the show is the cached
+// one whose title holds every word of it, the shortest such title when
+// several do ("wire" finds "The Wire"), or failing that the server search's
+// first show that does. The episode itself comes from the cache when
+// present, else the network, so it resolves without a deep index.
+func (s *Service) ResolveEpisodeQuery(ctx context.Context, libs []domain.Library, link Link) (*Resolved, error) {
+	res := s.matchCachedShow(libs, link.Title)
+	if res == nil {
+		var err error
+		if res, err = s.matchSearchedShow(ctx, libs, link.Title); err != nil {
+			return nil, err
+		}
+	}
+	if res == nil {
+		return nil, fmt.Errorf("%w: %q", ErrLinkNotFound, link.Title)
+	}
+	return res, s.resolveEpisode(ctx, res, link)
+}
+
+// wordsMatch reports whether every word of query starts a word of title,
+// ignoring case
+func wordsMatch(query, title string) bool {
+	words := strings.Fields(strings.ToLower(title))
+	for _, q := range strings.Fields(strings.ToLower(query)) {
+		found := false
+		for _, w := range words {
+			if strings.HasPrefix(w, q) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// matchCachedShow looks a loose show title up in the cached libraries
+func (s *Service) matchCachedShow(libs []domain.Library, title string) *Resolved {
+	var best *Resolved
+	consider := func(lib domain.Library, show *domain.Show) {
+		if wordsMatch(title, show.Title) && (best == nil || len(show.Title) < len(best.Show.Title)) {
+			best = &Resolved{Library: lib, Show: show}
+		}
+	}
+	for _, lib := range libs {
+		if shows, ok := s.store.GetShows(lib.ID); ok {
+			for _, sh := range shows {
+				consider(lib, sh)
+			}
+		}
+		if items, ok := s.store.GetMixedContent(lib.ID); ok {
+			for _, item := range items {
+				if sh, ok := item.(*domain.Show); ok {
+					consider(lib, sh)
+				}
+			}
+		}
+	}
+	return best
+}
+
+// matchSearchedShow asks the server's search for a loose show title
+func (s *Service) matchSearchedShow(ctx context.Context, libs []domain.Library, title string) (*Resolved, error) {
+	searcher, ok := s.client.(domain.SearchClient)
+	if !ok {
+		return nil, nil
+	}
+	items, err := searcher.Search(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.Type != domain.MediaTypeShow || !wordsMatch(title, item.Title) {
+			continue
+		}
+		for _, lib := range libs {
+			if lib.ID == item.LibraryID {
+				return &Resolved{Library: lib, Show: searchedShow(item, lib)}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// resolveEpisode fills in the season (and episode) of a resolved show
+func (s *Service) resolveEpisode(ctx context.Context, res *Resolved, link Link) error {
+	seasons, ok := s.store.GetSeasons(res.Library.ID, res.Show.ID)
+	if !ok {
+		var err error
+		if seasons, err = s.FetchSeasons(ctx, res.Library.ID, res.Show.ID); err != nil {
+			return err
+		}
+	}
+	for _, season := range seasons {
+		if season.SeasonNum == link.Season {
+			res.Season = season
+			break
+		}
+	}
+	if res.Season == nil {
+		return fmt.Errorf("%w: %s has no season %d", ErrLinkNotFound, res.Show.Title, link.Season)
+	}
+	if link.Episode == 0 {
+		return nil
+	}
+
+	episodes, ok := s.store.GetEpisodes(res.Library.ID, res.Show.ID, res.Season.ID)
+	if !ok {
+		var err error
+		if episodes, err = s.FetchEpisodes(ctx, res.Library.ID, res.Show.ID, res.Season.ID); err != nil {
+			return err
+		}
+	}
+	for _, ep := range episodes {
+		if ep.EpisodeNum == link.Episode {
+			res.Episode = ep
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s has no S%02dE%02d", ErrLinkNotFound, res.Show.Title, link.Season, link.Episode)
+}
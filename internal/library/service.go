@@ -2,18 +2,58 @@ package library
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/metrics"
 )
 
 const defaultChunkSize = 50
 
+// calendarDays is how many days back and ahead the calendar covers
+const calendarDays = 7
+
+// freshnessConcurrency bounds the parallel item count checks at startup
+const freshnessConcurrency = 4
+
+// chunkConcurrency bounds the pages of one library fetched in parallel. The
+// HTTP limiter caps the total across libraries.
+const chunkConcurrency = 4
+
+// maxChunkFailures is how many pages in a row may fail before a library
+// sync gives up. Fewer are skipped and the sync carries on: one item the
+// server cannot serialize should not cost the whole library.
+const maxChunkFailures = 3
+
+// checkpointPages is how many pages a library sync fetches between
+// checkpoints. A sync interrupted by quitting resumes from its last
+// checkpoint rather than from the first page.
+const checkpointPages = 10
+
 // Service orchestrates library client + store operations.
 type Service struct {
 	client domain.LibraryClient
 	store  domain.Store
 	logger *slog.Logger
+
+	prefetch prefetcher
+	inflight inflight
+
+	// extras adds a synthetic "Extras" season to shows with extras, on
+	// backends that list them (domain.ExtrasClient)
+	extras bool
+
+	// deepIndex keeps an index of every episode in show libraries for
+	// global search, on backends that list them in one request
+	// (domain.EpisodeIndexClient)
+	deepIndex bool
+
+	details detailsCache
 }
 
 // NewService creates a new library service.
@@ -24,6 +64,18 @@ func NewService(client domain.LibraryClient, store domain.Store, logger *slog.Lo
 	return &Service{client: client, store: store, logger: logger}
 }
 
+// SetExtras turns the "Extras" season on or off. Off saves a request per
+// show when the user hides extras anyway.
+func (s *Service) SetExtras(enabled bool) {
+	s.extras = enabled
+}
+
+// SetDeepIndex turns the episode index on or off. Off, search finds shows
+// and movies only.
+func (s *Service) SetDeepIndex(enabled bool) {
+	s.deepIndex = enabled
+}
+
 func (s *Service) FetchLibraries(ctx context.Context) ([]domain.Library, error) {
 	libs, err := s.client.GetLibraries(ctx)
 	if err != nil {
@@ -37,64 +89,269 @@ func (s *Service) FetchLibraries(ctx context.Context) ([]domain.Library, error)
 	return libs, nil
 }
 
+// Ping checks the server is reachable and returns how long it took to
+// answer. Backends without a ping endpoint (domain.PingClient) are asked
+// for their libraries instead, which is small but not free.
+func (s *Service) Ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	var err error
+	if pc, ok := s.client.(domain.PingClient); ok {
+		err = pc.Ping(ctx)
+	} else {
+		_, err = s.client.GetLibraries(ctx)
+	}
+	return time.Since(start), err
+}
+
+// HasActivities reports whether the backend reports its running tasks
+func (s *Service) HasActivities() bool {
+	_, ok := s.client.(domain.ActivityClient)
+	return ok
+}
+
+// FetchActivities returns the tasks running on the server. Not cached:
+// they change by the second.
+func (s *Service) FetchActivities(ctx context.Context) ([]domain.Activity, error) {
+	ac, ok := s.client.(domain.ActivityClient)
+	if !ok {
+		return nil, nil
+	}
+	return ac.GetActivities(ctx)
+}
+
+// HasLiveUpdates reports whether the backend pushes library changes
+func (s *Service) HasLiveUpdates() bool {
+	_, ok := s.client.(domain.LiveUpdateClient)
+	return ok
+}
+
+// WatchUpdates opens the server's change notifications; nil without the
+// capability. The channel is closed when ctx ends or the connection drops.
+func (s *Service) WatchUpdates(ctx context.Context) (<-chan domain.LiveUpdate, error) {
+	lc, ok := s.client.(domain.LiveUpdateClient)
+	if !ok {
+		return nil, nil
+	}
+	return lc.WatchUpdates(ctx)
+}
+
 func (s *Service) SyncLibrary(
 	ctx context.Context,
 	lib domain.Library,
 	onProgress domain.ProgressFunc,
-) (domain.SyncResult, error) {
+) (result domain.SyncResult, err error) {
+	ctx, done := s.track(ctx)
+	defer done()
+	defer s.indexEpisodes(ctx, lib, &err)
+	defer observeSync(time.Now(), &result, &err)
+
+	var validator domain.Validator
+
 	// 1. Freshness check. The library timestamp alone is not enough: servers
 	// don't reliably bump it when items are added (Jellyfin's Views only
 	// expose the library's creation date), so also verify the item count
 	// with a cheap metadata-only request.
 	if s.store.IsValid(lib.ID, lib.UpdatedAt) {
-		count := s.getCachedCount(lib)
+		count, unwatched := s.cachedCounts(lib)
+		cached := domain.SyncResult{LibraryID: lib.ID, FromCache: true, Count: count, Unwatched: unwatched}
 		serverCount, err := s.client.GetLibraryItemCount(ctx, lib.ID, lib.Type)
 		if err != nil {
 			// Can't verify; serve cache rather than fail or refetch blindly
 			s.logger.Warn("item count check failed, serving cache", "libID", lib.ID, "error", err)
-			return domain.SyncResult{LibraryID: lib.ID, FromCache: true, Count: count}, nil
+			return cached, nil
 		}
 		if serverCount == count {
 			s.logger.Debug("cache fresh", "libID", lib.ID, "count", count)
-			return domain.SyncResult{LibraryID: lib.ID, FromCache: true, Count: count}, nil
+			return cached, nil
 		}
 		s.logger.Debug("item count changed", "libID", lib.ID, "cached", count, "server", serverCount)
+	} else if prev, ok := s.store.GetLibraryValidator(lib.ID); ok {
+		// 2. Conditional check. The timestamp moved, but servers bump it
+		// for more than content changes; one whose listings carry an ETag
+		// or Last-Modified can confirm the cached content is still current.
+		var changed bool
+		validator, changed = s.checkLibrary(ctx, lib.ID, prev)
+		if !changed {
+			err := s.store.TouchLibrary(lib.ID, lib.UpdatedAt)
+			if err == nil {
+				count, unwatched := s.cachedCounts(lib)
+				s.logger.Debug("cache confirmed unchanged", "libID", lib.ID, "count", count)
+				return domain.SyncResult{LibraryID: lib.ID, FromCache: true, Count: count, Unwatched: unwatched}, nil
+			}
+			s.logger.Warn("cannot serve unchanged library", "error", err, "libID", lib.ID)
+		}
 	}
 
-	// 2. Fetch based on library type
+	// 3. Fetch based on library type
 	s.logger.Debug("cache stale, fetching", "libID", lib.ID)
+	return s.refetchLibrary(ctx, lib, validator, onProgress)
+}
+
+// RefetchLibrary fetches a library's full content and caches it, skipping
+// the freshness check. For libraries already known to be stale.
+func (s *Service) RefetchLibrary(
+	ctx context.Context,
+	lib domain.Library,
+	onProgress domain.ProgressFunc,
+) (result domain.SyncResult, err error) {
+	ctx, done := s.track(ctx)
+	defer done()
+	defer s.indexEpisodes(ctx, lib, &err)
+	defer observeSync(time.Now(), &result, &err)
+	return s.refetchLibrary(ctx, lib, domain.Validator{}, onProgress)
+}
 
+// refetchLibrary is RefetchLibrary without tracking or metrics. validator
+// is the library's current one if the caller has it; otherwise it is asked
+// for before fetching, so a change during the fetch shows up next time.
+func (s *Service) refetchLibrary(
+	ctx context.Context,
+	lib domain.Library,
+	validator domain.Validator,
+	onProgress domain.ProgressFunc,
+) (domain.SyncResult, error) {
+	if validator.IsZero() {
+		validator, _ = s.checkLibrary(ctx, lib.ID, domain.Validator{})
+	}
+	previous := s.cachedIDs(lib)
+	var items []domain.ListItem
+	var unwatched int
 	switch lib.Type {
 	case "movie":
-		movies, err := s.fetchMoviesWithProgress(ctx, lib.ID, onProgress)
+		movies, err := s.FetchMovies(ctx, lib.ID, lib.UpdatedAt, onProgress)
 		if err != nil {
 			return domain.SyncResult{}, err
 		}
-		if err := s.store.SaveMovies(lib.ID, movies, lib.UpdatedAt); err != nil {
-			s.logger.Error("failed to save movies", "error", err, "libID", lib.ID)
-		}
-		return domain.SyncResult{LibraryID: lib.ID, FromCache: false, Count: len(movies)}, nil
+		items, unwatched = toListItems(movies), countUnwatched(movies)
 
 	case "show":
-		shows, err := s.fetchShowsWithProgress(ctx, lib.ID, onProgress)
+		shows, err := s.FetchShows(ctx, lib.ID, lib.UpdatedAt, onProgress)
 		if err != nil {
 			return domain.SyncResult{}, err
 		}
-		if err := s.store.SaveShows(lib.ID, shows, lib.UpdatedAt); err != nil {
-			s.logger.Error("failed to save shows", "error", err, "libID", lib.ID)
-		}
-		return domain.SyncResult{LibraryID: lib.ID, FromCache: false, Count: len(shows)}, nil
+		items, unwatched = toListItems(shows), countUnwatched(shows)
 
 	default: // mixed
-		items, err := s.fetchMixedWithProgress(ctx, lib.ID, onProgress)
+		mixed, err := s.FetchMixedContent(ctx, lib.ID, lib.UpdatedAt, onProgress)
 		if err != nil {
 			return domain.SyncResult{}, err
 		}
-		if err := s.store.SaveMixedContent(lib.ID, items, lib.UpdatedAt); err != nil {
-			s.logger.Error("failed to save mixed content", "error", err, "libID", lib.ID)
+		items, unwatched = mixed, countUnwatched(mixed)
+	}
+
+	// A fetch that skipped pages is cached as outdated and must not be
+	// vouched for by the validator
+	if s.store.IsValid(lib.ID, lib.UpdatedAt) {
+		if err := s.store.SaveLibraryValidator(lib.ID, validator); err != nil {
+			s.logger.Error("failed to save library validator", "error", err, "libID", lib.ID)
+		}
+	}
+	return domain.SyncResult{LibraryID: lib.ID, FromCache: false, Count: len(items), Unwatched: unwatched,
+		NewIDs: addedIDs(previous, itemIDs(items))}, nil
+}
+
+// indexEpisodes refreshes a show library's episode index after a sync, when
+// deep indexing is on and the index is missing or stale: older than the
+// episode cache TTL or than the library's content. A failure only costs
+// search its episodes until the next sync.
+func (s *Service) indexEpisodes(ctx context.Context, lib domain.Library, err *error) {
+	if *err != nil || !s.deepIndex || lib.Type == "movie" {
+		return
+	}
+	ec, ok := s.client.(domain.EpisodeIndexClient)
+	if !ok {
+		return
+	}
+	if _, ok := s.store.GetLibraryEpisodes(lib.ID); ok {
+		return
+	}
+	episodes, fetchErr := ec.GetAllEpisodes(ctx, lib.ID)
+	if fetchErr != nil {
+		s.logger.Warn("episode index failed", "libID", lib.ID, "error", fetchErr)
+		return
+	}
+	if saveErr := s.store.SaveLibraryEpisodes(lib.ID, episodes); saveErr != nil {
+		s.logger.Error("failed to save episode index", "error", saveErr, "libID", lib.ID)
+		return
+	}
+	s.logger.Debug("indexed episodes", "count", len(episodes), "libID", lib.ID)
+}
+
+// checkLibrary asks a server whose listings carry validators whether a
+// library changed since prev (see domain.ConditionalClient). Anything short
+// of a clear "unchanged" counts as changed.
+func (s *Service) checkLibrary(ctx context.Context, libID string, prev domain.Validator) (domain.Validator, bool) {
+	cc, ok := s.client.(domain.ConditionalClient)
+	if !ok {
+		return domain.Validator{}, true
+	}
+	current, changed, err := cc.CheckLibrary(ctx, libID, prev)
+	if err != nil {
+		s.logger.Warn("conditional library check failed", "libID", libID, "error", err)
+		return domain.Validator{}, true
+	}
+	return current, changed || prev.IsZero()
+}
+
+// observeSync records a finished sync's duration and outcome
+func observeSync(start time.Time, result *domain.SyncResult, err *error) {
+	metrics.SyncDuration.Observe(time.Since(start))
+	switch {
+	case *err != nil:
+		metrics.SyncsFailed.Inc()
+	case result.FromCache:
+		metrics.SyncsFromCache.Inc()
+	default:
+		metrics.SyncsFetched.Inc()
+	}
+}
+
+// CachedCount returns a library's cached item and unwatched counts and
+// whether the cache
+// timestamp still matches the server's. Purely local: a true result still
+// needs the count verified (see StaleLibraries) before it is trusted.
+func (s *Service) CachedCount(lib domain.Library) (count, unwatched int, ok bool) {
+	if !s.store.IsValid(lib.ID, lib.UpdatedAt) {
+		return 0, 0, false
+	}
+	count, unwatched = s.cachedCounts(lib)
+	return count, unwatched, true
+}
+
+// StaleLibraries runs the item count check for libraries whose cache
+// timestamp is current and returns the ones whose count changed. A failed
+// check counts as fresh, matching SyncLibrary's serve-the-cache fallback.
+func (s *Service) StaleLibraries(ctx context.Context, libs []domain.Library) []domain.Library {
+	stale := make([]bool, len(libs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, freshnessConcurrency)
+	for i, lib := range libs {
+		wg.Add(1)
+		go func(i int, lib domain.Library) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			serverCount, err := s.client.GetLibraryItemCount(ctx, lib.ID, lib.Type)
+			if err != nil {
+				s.logger.Warn("item count check failed, serving cache", "libID", lib.ID, "error", err)
+				return
+			}
+			if count, _ := s.cachedCounts(lib); serverCount != count {
+				s.logger.Debug("item count changed", "libID", lib.ID, "cached", count, "server", serverCount)
+				stale[i] = true
+			}
+		}(i, lib)
+	}
+	wg.Wait()
+
+	var out []domain.Library
+	for i, lib := range libs {
+		if stale[i] {
+			out = append(out, lib)
 		}
-		return domain.SyncResult{LibraryID: lib.ID, FromCache: false, Count: len(items)}, nil
 	}
+	return out
 }
 
 // Fetch* fetch a library's full content and cache it. serverTS is the
@@ -109,13 +366,23 @@ func (s *Service) FetchMovies(
 	serverTS int64,
 	onProgress domain.ProgressFunc,
 ) ([]*domain.MediaItem, error) {
-	movies, err := s.fetchMoviesWithProgress(ctx, libID, onProgress)
+	ctx, done := s.track(ctx)
+	defer done()
+	movies, skipped, err := s.fetchMoviesWithProgress(ctx, libID, serverTS, onProgress)
 	if err != nil {
 		return nil, err
 	}
+	previous, _ := s.store.GetMovies(libID)
+	if skipped > 0 {
+		s.logger.Warn("sync skipped pages that failed", "libID", libID, "pages", skipped)
+		movies = keepSkipped(previous, movies)
+		serverTS = 0
+	}
 	if err := s.store.SaveMovies(libID, movies, serverTS); err != nil {
 		s.logger.Error("failed to save movies", "error", err, "libID", libID)
 	}
+	s.store.ClearSyncCheckpoint(libID)
+	s.pruneDeleted(libID, itemIDs(previous), itemIDs(movies))
 	s.logger.Debug("fetched movies", "count", len(movies), "libID", libID)
 	return movies, nil
 }
@@ -126,13 +393,23 @@ func (s *Service) FetchShows(
 	serverTS int64,
 	onProgress domain.ProgressFunc,
 ) ([]*domain.Show, error) {
-	shows, err := s.fetchShowsWithProgress(ctx, libID, onProgress)
+	ctx, done := s.track(ctx)
+	defer done()
+	shows, skipped, err := s.fetchShowsWithProgress(ctx, libID, serverTS, onProgress)
 	if err != nil {
 		return nil, err
 	}
+	previous, _ := s.store.GetShows(libID)
+	if skipped > 0 {
+		s.logger.Warn("sync skipped pages that failed", "libID", libID, "pages", skipped)
+		shows = keepSkipped(previous, shows)
+		serverTS = 0
+	}
 	if err := s.store.SaveShows(libID, shows, serverTS); err != nil {
 		s.logger.Error("failed to save shows", "error", err, "libID", libID)
 	}
+	s.store.ClearSyncCheckpoint(libID)
+	s.pruneDeleted(libID, itemIDs(previous), itemIDs(shows))
 	s.logger.Debug("fetched shows", "count", len(shows), "libID", libID)
 	return shows, nil
 }
@@ -143,23 +420,47 @@ func (s *Service) FetchMixedContent(
 	serverTS int64,
 	onProgress domain.ProgressFunc,
 ) ([]domain.ListItem, error) {
-	items, err := s.fetchMixedWithProgress(ctx, libID, onProgress)
+	ctx, done := s.track(ctx)
+	defer done()
+	items, skipped, err := s.fetchMixedWithProgress(ctx, libID, serverTS, onProgress)
 	if err != nil {
 		return nil, err
 	}
+	previous, _ := s.store.GetMixedContent(libID)
+	if skipped > 0 {
+		s.logger.Warn("sync skipped pages that failed", "libID", libID, "pages", skipped)
+		items = keepSkipped(previous, items)
+		serverTS = 0
+	}
 	if err := s.store.SaveMixedContent(libID, items, serverTS); err != nil {
 		s.logger.Error("failed to save mixed content", "error", err, "libID", libID)
 	}
+	s.store.ClearSyncCheckpoint(libID)
+	s.pruneDeleted(libID, itemIDs(previous), itemIDs(items))
 	s.logger.Debug("fetched mixed content", "count", len(items), "libID", libID)
 	return items, nil
 }
 
 func (s *Service) FetchSeasons(ctx context.Context, libID, showID string) ([]*domain.Season, error) {
+	ctx, done := s.track(ctx)
+	defer done()
 	seasons, err := s.client.GetSeasons(ctx, showID)
 	if err != nil {
-		s.logger.Error("failed to fetch seasons", "error", err, "showID", showID)
+		// Offline: browse whatever was cached, however old
+		if errors.Is(err, domain.ErrServerOffline) {
+			if cached, ok := s.store.GetStaleSeasons(libID, showID); ok {
+				s.logger.Warn("server offline, serving cached seasons", "showID", showID)
+				return cached, nil
+			}
+		}
+		if ctx.Err() != context.Canceled { // a cancelled prefetch is not a failure
+			s.logger.Error("failed to fetch seasons", "error", err, "showID", showID)
+		}
 		return nil, err
 	}
+	if extras := s.fetchExtrasSeason(ctx, libID, showID, seasons); extras != nil {
+		seasons = append(seasons, extras)
+	}
 	if err := s.store.SaveSeasons(libID, showID, seasons); err != nil {
 		s.logger.Error("failed to save seasons", "error", err, "showID", showID)
 	}
@@ -168,9 +469,25 @@ func (s *Service) FetchSeasons(ctx context.Context, libID, showID string) ([]*do
 }
 
 func (s *Service) FetchEpisodes(ctx context.Context, libID, showID, seasonID string) ([]*domain.MediaItem, error) {
-	episodes, err := s.client.GetEpisodes(ctx, seasonID)
+	ctx, done := s.track(ctx)
+	defer done()
+	var episodes []*domain.MediaItem
+	var err error
+	if extrasShowID, ok := domain.ExtrasShowID(seasonID); ok {
+		episodes, err = s.fetchExtras(ctx, extrasShowID)
+	} else {
+		episodes, err = s.client.GetEpisodes(ctx, seasonID)
+	}
 	if err != nil {
-		s.logger.Error("failed to fetch episodes", "error", err, "seasonID", seasonID)
+		if errors.Is(err, domain.ErrServerOffline) {
+			if cached, ok := s.store.GetStaleEpisodes(libID, showID, seasonID); ok {
+				s.logger.Warn("server offline, serving cached episodes", "seasonID", seasonID)
+				return cached, nil
+			}
+		}
+		if ctx.Err() != context.Canceled {
+			s.logger.Error("failed to fetch episodes", "error", err, "seasonID", seasonID)
+		}
 		return nil, err
 	}
 	if err := s.store.SaveEpisodes(libID, showID, seasonID, episodes); err != nil {
@@ -180,6 +497,104 @@ func (s *Service) FetchEpisodes(ctx context.Context, libID, showID, seasonID str
 	return episodes, nil
 }
 
+// fetchExtrasSeason fetches a show's extras and, when there are any, caches
+// them as the episodes of a synthetic "Extras" season and returns it. Extras
+// are optional decoration: failures are logged and the show goes without.
+func (s *Service) fetchExtrasSeason(ctx context.Context, libID, showID string, seasons []*domain.Season) *domain.Season {
+	if !s.extras {
+		return nil
+	}
+	if _, ok := s.client.(domain.ExtrasClient); !ok {
+		return nil
+	}
+	extras, err := s.fetchExtras(ctx, showID)
+	if err != nil {
+		if ctx.Err() != context.Canceled {
+			s.logger.Warn("failed to fetch extras", "error", err, "showID", showID)
+		}
+		return nil
+	}
+	if len(extras) == 0 {
+		return nil
+	}
+
+	season := &domain.Season{
+		ID:             domain.ExtrasSeasonID(showID),
+		ShowID:         showID,
+		SeasonNum:      -1,
+		Title:          "Extras",
+		EpisodeCount:   len(extras),
+		UnwatchedCount: countUnwatched(extras),
+		Extras:         true,
+	}
+	if len(seasons) > 0 {
+		season.ShowTitle = seasons[0].ShowTitle
+	}
+	if err := s.store.SaveEpisodes(libID, showID, season.ID, extras); err != nil {
+		s.logger.Error("failed to save extras", "error", err, "showID", showID)
+	}
+	return season
+}
+
+// fetchExtras lists a show's extras, or nothing on backends without them
+func (s *Service) fetchExtras(ctx context.Context, showID string) ([]*domain.MediaItem, error) {
+	ec, ok := s.client.(domain.ExtrasClient)
+	if !ok {
+		return nil, nil
+	}
+	return ec.GetExtras(ctx, showID)
+}
+
+// pruneDeleted drops cache entries that reference items present in the
+// previous listing but gone from the new one (deleted on the server):
+// removed shows' seasons and episodes, and removed items in cached
+// playlists. Search reads the library listings, so it is already clean.
+func (s *Service) pruneDeleted(libID string, previous, current []string) {
+	if len(previous) == 0 {
+		return
+	}
+	keep := make(map[string]bool, len(current))
+	for _, id := range current {
+		keep[id] = true
+	}
+	var removed []string
+	for _, id := range previous {
+		if !keep[id] {
+			removed = append(removed, id)
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+	s.store.PruneItems(libID, removed)
+	s.logger.Info("pruned deleted items from cache", "libID", libID, "count", len(removed))
+}
+
+// keepSkipped adds to a listing that skipped failed pages the cached items
+// it lacks: they may be on those pages, so they are kept rather than pruned
+// as deleted. The caller saves the result as outdated (timestamp 0) so the
+// next sync fetches the library again.
+func keepSkipped[T domain.ListItem](previous, fetched []T) []T {
+	have := make(map[string]bool, len(fetched))
+	for _, item := range fetched {
+		have[item.GetID()] = true
+	}
+	for _, item := range previous {
+		if !have[item.GetID()] {
+			fetched = append(fetched, item)
+		}
+	}
+	return fetched
+}
+
+func itemIDs[T domain.ListItem](items []T) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.GetID()
+	}
+	return ids
+}
+
 // SetWatchState patches the cached watch state for an item in place,
 // avoiding cache invalidation. The next sync reconciles with the server.
 func (s *Service) SetWatchState(itemID string, played bool) {
@@ -187,6 +602,71 @@ func (s *Service) SetWatchState(itemID string, played bool) {
 	s.logger.Debug("patched cached watch state", "itemID", itemID, "played", played)
 }
 
+// CanRate reports whether the backend stores the user's ratings
+func (s *Service) CanRate() bool {
+	_, ok := s.client.(domain.RatingClient)
+	return ok
+}
+
+// RateItem sets the user's rating of an item (1-10, 0 clears) on the
+// server. The cache is patched separately with SetUserRating.
+func (s *Service) RateItem(ctx context.Context, itemID string, rating float64) error {
+	rc, ok := s.client.(domain.RatingClient)
+	if !ok {
+		return domain.ErrItemNotFound
+	}
+	return rc.SetUserRating(ctx, itemID, rating)
+}
+
+// SetUserRating patches the cached rating for an item in place
+func (s *Service) SetUserRating(itemID string, rating float64) {
+	s.store.SetUserRating(itemID, rating)
+	s.logger.Debug("patched cached user rating", "itemID", itemID, "rating", rating)
+}
+
+// CanDelete reports whether the backend can delete media from the server
+func (s *Service) CanDelete() bool {
+	_, ok := s.client.(domain.DeleteClient)
+	return ok
+}
+
+// DeleteItem deletes a movie, show or episode from the server, then drops
+// what the cache holds of it: an episode's show, or a movie or show along
+// with its library listing. libID is the library it was browsed in, which
+// the cache is keyed by; the item's own LibraryID is only a fallback, as
+// Jellyfin leaves it empty on episodes and sets it to the parent folder
+// otherwise.
+func (s *Service) DeleteItem(ctx context.Context, libID string, item domain.ListItem) error {
+	dc, ok := s.client.(domain.DeleteClient)
+	if !ok {
+		return domain.ErrItemNotFound
+	}
+	if err := dc.DeleteItem(ctx, item.GetID()); err != nil {
+		return err
+	}
+	s.logger.Info("deleted item from server", "itemID", item.GetID(), "title", item.GetTitle())
+
+	switch v := item.(type) {
+	case *domain.MediaItem:
+		if libID == "" {
+			libID = v.LibraryID
+		}
+		if v.Type == domain.MediaTypeEpisode {
+			s.InvalidateShow(libID, v.ShowID) // Season episode counts change too
+			return nil
+		}
+		s.store.PruneItems(libID, []string{v.ID})
+		s.InvalidateLibrary(libID)
+	case *domain.Show:
+		if libID == "" {
+			libID = v.LibraryID
+		}
+		s.store.PruneItems(libID, []string{v.ID})
+		s.InvalidateLibrary(libID)
+	}
+	return nil
+}
+
 func (s *Service) InvalidateLibrary(libID string) {
 	s.store.InvalidateLibrary(libID)
 	s.logger.Info("invalidated library cache", "libID", libID)
@@ -204,39 +684,276 @@ func (s *Service) InvalidateSeason(libID, showID, seasonID string) {
 
 func (s *Service) InvalidateAll() {
 	s.store.InvalidateAll()
+	s.clearDetails()
 	s.logger.Info("invalidated all cache")
 }
 
+// LiveTVAvailable reports whether the backend supports Live TV and the
+// server has a tuner configured. Errors are logged and treated as "no".
+func (s *Service) LiveTVAvailable(ctx context.Context) bool {
+	lt, ok := s.client.(domain.LiveTVClient)
+	if !ok {
+		return false
+	}
+	available, err := lt.HasLiveTV(ctx)
+	if err != nil {
+		s.logger.Debug("live tv capability check failed", "error", err)
+		return false
+	}
+	s.logger.Info("live tv capability", "available", available)
+	return available
+}
+
+// FetchChannels returns Live TV channels. Not cached: the current program
+// changes every half hour, so stale data is worse than a fetch.
+func (s *Service) FetchChannels(ctx context.Context) ([]*domain.MediaItem, error) {
+	lt, ok := s.client.(domain.LiveTVClient)
+	if !ok {
+		return nil, domain.ErrItemNotFound
+	}
+	channels, err := lt.GetChannels(ctx)
+	if err != nil {
+		s.logger.Error("failed to fetch channels", "error", err)
+		return nil, err
+	}
+	s.logger.Debug("fetched channels", "count", len(channels))
+	return channels, nil
+}
+
+// HasCalendar reports whether the backend can list episodes by air date
+func (s *Service) HasCalendar() bool {
+	_, ok := s.client.(domain.CalendarClient)
+	return ok
+}
+
+// FetchCalendar returns episodes that aired in the past week or air in the
+// coming week, ordered by air date. Not cached: it's a view over dates.
+func (s *Service) FetchCalendar(ctx context.Context) ([]*domain.MediaItem, error) {
+	cal, ok := s.client.(domain.CalendarClient)
+	if !ok {
+		return nil, domain.ErrItemNotFound
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	from := today.AddDate(0, 0, -calendarDays)
+	to := today.AddDate(0, 0, calendarDays+1).Add(-time.Second)
+
+	episodes, err := cal.GetAiringEpisodes(ctx, from, to)
+	if err != nil {
+		s.logger.Error("failed to fetch calendar", "error", err)
+		return nil, err
+	}
+	s.logger.Debug("fetched calendar", "count", len(episodes))
+	return episodes, nil
+}
+
+// HasContinueWatching reports whether the backend lists in-progress items
+// across libraries
+func (s *Service) HasContinueWatching() bool {
+	_, ok := s.client.(domain.ContinueWatchingClient)
+	return ok
+}
+
+// FetchContinueWatching returns the in-progress movies and episodes of
+// every library, most recently watched first. Not cached: it changes with
+// every play.
+func (s *Service) FetchContinueWatching(ctx context.Context) ([]*domain.MediaItem, error) {
+	cw, ok := s.client.(domain.ContinueWatchingClient)
+	if !ok {
+		return nil, domain.ErrItemNotFound
+	}
+	items, err := cw.GetContinueWatching(ctx)
+	if err != nil {
+		s.logger.Error("failed to fetch continue watching", "error", err)
+		return nil, err
+	}
+	s.logger.Debug("fetched continue watching", "count", len(items))
+	return items, nil
+}
+
+// RemoveFromContinueWatching dismisses an item from Continue Watching,
+// keeping its progress
+func (s *Service) RemoveFromContinueWatching(ctx context.Context, itemID string) error {
+	cw, ok := s.client.(domain.ContinueWatchingClient)
+	if !ok {
+		return domain.ErrItemNotFound
+	}
+	return cw.RemoveFromContinueWatching(ctx, itemID)
+}
+
+// HasNextUp reports whether the backend suggests the next episode of shows
+// in progress
+func (s *Service) HasNextUp() bool {
+	_, ok := s.client.(domain.NextUpClient)
+	return ok
+}
+
+// FetchNextUp returns the next unwatched episode of each show in progress.
+// Not cached, like Continue Watching.
+func (s *Service) FetchNextUp(ctx context.Context) ([]*domain.MediaItem, error) {
+	nu, ok := s.client.(domain.NextUpClient)
+	if !ok {
+		return nil, domain.ErrItemNotFound
+	}
+	items, err := nu.GetNextUp(ctx)
+	if err != nil {
+		s.logger.Error("failed to fetch next up", "error", err)
+		return nil, err
+	}
+	s.logger.Debug("fetched next up", "count", len(items))
+	return items, nil
+}
+
+// HasCollections reports whether the backend groups items into collections
+func (s *Service) HasCollections() bool {
+	_, ok := s.client.(domain.CollectionClient)
+	return ok
+}
+
+// FetchCollectionItems returns the movies and shows of the collection
+// titled name (case-insensitive), searching every library
+func (s *Service) FetchCollectionItems(ctx context.Context, name string) ([]domain.ListItem, error) {
+	cc, ok := s.client.(domain.CollectionClient)
+	if !ok {
+		return nil, domain.ErrItemNotFound
+	}
+	collections, err := cc.GetCollections(ctx)
+	if err != nil {
+		s.logger.Error("failed to fetch collections", "error", err)
+		return nil, err
+	}
+	for _, c := range collections {
+		if strings.EqualFold(c.Title, name) {
+			return cc.GetCollectionItems(ctx, c.ID)
+		}
+	}
+	return nil, fmt.Errorf("no collection named %q: %w", name, domain.ErrItemNotFound)
+}
+
+// HasWatchlist reports whether the backend keeps an account watchlist
+func (s *Service) HasWatchlist() bool {
+	_, ok := s.client.(domain.WatchlistClient)
+	return ok
+}
+
+// FetchWatchlist returns the watchlisted titles, marking those the server
+// has. Not cached: the watchlist lives outside the server.
+func (s *Service) FetchWatchlist(ctx context.Context) ([]*domain.WatchlistItem, error) {
+	wl, ok := s.client.(domain.WatchlistClient)
+	if !ok {
+		return nil, domain.ErrItemNotFound
+	}
+	items, err := wl.GetWatchlist(ctx)
+	if err != nil {
+		s.logger.Error("failed to fetch watchlist", "error", err)
+		return nil, err
+	}
+	s.logger.Debug("fetched watchlist", "count", len(items))
+	return items, nil
+}
+
+// AddToWatchlist watchlists a movie or show, returning its watchlist ID
+func (s *Service) AddToWatchlist(ctx context.Context, itemID string) (string, error) {
+	wl, ok := s.client.(domain.WatchlistClient)
+	if !ok {
+		return "", domain.ErrItemNotFound
+	}
+	return wl.AddToWatchlist(ctx, itemID)
+}
+
+// RemoveFromWatchlist drops a title from the watchlist
+func (s *Service) RemoveFromWatchlist(ctx context.Context, watchlistID string) error {
+	wl, ok := s.client.(domain.WatchlistClient)
+	if !ok {
+		return domain.ErrItemNotFound
+	}
+	return wl.RemoveFromWatchlist(ctx, watchlistID)
+}
+
 // --- Private helpers ---
 
-func (s *Service) getCachedCount(lib domain.Library) int {
+// cachedCounts returns a library's cached item count and unwatched count
+func (s *Service) cachedCounts(lib domain.Library) (count, unwatched int) {
 	switch lib.Type {
 	case "movie":
 		if movies, ok := s.store.GetMovies(lib.ID); ok {
-			return len(movies)
+			return len(movies), countUnwatched(movies)
 		}
 	case "show":
 		if shows, ok := s.store.GetShows(lib.ID); ok {
-			return len(shows)
+			return len(shows), countUnwatched(shows)
 		}
 	default:
 		if items, ok := s.store.GetMixedContent(lib.ID); ok {
-			return len(items)
+			return len(items), countUnwatched(items)
+		}
+	}
+	return 0, 0
+}
+
+// cachedIDs returns the IDs of a library's cached listing, nil when it has
+// never been synced
+func (s *Service) cachedIDs(lib domain.Library) []string {
+	switch lib.Type {
+	case "movie":
+		if movies, ok := s.store.GetMovies(lib.ID); ok {
+			return itemIDs(movies)
+		}
+	case "show":
+		if shows, ok := s.store.GetShows(lib.ID); ok {
+			return itemIDs(shows)
+		}
+	default:
+		if items, ok := s.store.GetMixedContent(lib.ID); ok {
+			return itemIDs(items)
+		}
+	}
+	return nil
+}
+
+// addedIDs returns the IDs in current that previous lacks. With no previous
+// listing nothing counts as added: a first sync is not news.
+func addedIDs(previous, current []string) []string {
+	if len(previous) == 0 {
+		return nil
+	}
+	known := make(map[string]bool, len(previous))
+	for _, id := range previous {
+		known[id] = true
+	}
+	var added []string
+	for _, id := range current {
+		if !known[id] {
+			added = append(added, id)
+		}
+	}
+	return added
+}
+
+// countUnwatched counts what is left to watch: movies and episodes not yet
+// watched, with shows contributing their unwatched episodes
+func countUnwatched[T domain.ListItem](items []T) int {
+	n := 0
+	for _, item := range items {
+		if show, ok := any(item).(*domain.Show); ok {
+			n += show.UnwatchedCount
+		} else if item.GetWatchStatus() != domain.WatchStatusWatched {
+			n++
 		}
 	}
-	return 0
+	return n
 }
 
 func (s *Service) fetchMoviesWithProgress(
 	ctx context.Context,
 	libID string,
+	serverTS int64,
 	onProgress domain.ProgressFunc,
-) ([]*domain.MediaItem, error) {
-	return fetchAll(ctx,
+) ([]*domain.MediaItem, int, error) {
+	return fetchLibrary(ctx, s, libID, serverTS,
 		func(ctx context.Context, offset, limit int) ([]*domain.MediaItem, int, error) {
 			return s.client.GetMovies(ctx, libID, offset, limit)
 		},
-		defaultChunkSize,
 		onProgress,
 	)
 }
@@ -244,13 +961,13 @@ func (s *Service) fetchMoviesWithProgress(
 func (s *Service) fetchShowsWithProgress(
 	ctx context.Context,
 	libID string,
+	serverTS int64,
 	onProgress domain.ProgressFunc,
-) ([]*domain.Show, error) {
-	return fetchAll(ctx,
+) ([]*domain.Show, int, error) {
+	return fetchLibrary(ctx, s, libID, serverTS,
 		func(ctx context.Context, offset, limit int) ([]*domain.Show, int, error) {
 			return s.client.GetShows(ctx, libID, offset, limit)
 		},
-		defaultChunkSize,
 		onProgress,
 	)
 }
@@ -258,66 +975,269 @@ func (s *Service) fetchShowsWithProgress(
 func (s *Service) fetchMixedWithProgress(
 	ctx context.Context,
 	libID string,
+	serverTS int64,
 	onProgress domain.ProgressFunc,
-) ([]domain.ListItem, error) {
-	return fetchAll(ctx,
+) ([]domain.ListItem, int, error) {
+	return fetchLibrary(ctx, s, libID, serverTS,
 		func(ctx context.Context, offset, limit int) ([]domain.ListItem, int, error) {
 			return s.client.GetMixedContent(ctx, libID, offset, limit)
 		},
-		defaultChunkSize,
 		onProgress,
 	)
 }
 
-// fetchAll is a generic pagination helper. Items are deduplicated by ID:
-// offset pagination under concurrent server-side mutation can shift pages
-// and repeat items, and duplicates would otherwise be cached as truth. An
-// empty page always terminates, so a server reporting total=0 alongside a
-// non-empty page still gets fully paginated rather than truncated.
+// fetchLibrary fetches a library's content with fetchAll, resuming from the
+// checkpoint an interrupted sync of the same library version left and
+// checkpointing as it goes. The caller clears the checkpoint once the
+// result is saved.
+func fetchLibrary[T domain.ListItem](
+	ctx context.Context,
+	s *Service,
+	libID string,
+	serverTS int64,
+	fetch func(ctx context.Context, offset, limit int) ([]T, int, error),
+	onProgress domain.ProgressFunc,
+) ([]T, int, error) {
+	var from fetchState[T]
+	if cp, ok := s.store.GetSyncCheckpoint(libID, serverTS); ok {
+		from = fetchState[T]{offset: cp.Offset, items: fromListItems[T](cp.Items), skipped: cp.Skipped, total: cp.Total}
+		s.logger.Info("resuming interrupted sync", "libID", libID, "offset", cp.Offset, "items", len(from.items))
+	}
+	checkpoint := func(at fetchState[T]) {
+		cp := domain.SyncCheckpoint{ServerTS: serverTS, Offset: at.offset, Skipped: at.skipped, Total: at.total, Items: toListItems(at.items)}
+		if err := s.store.SaveSyncCheckpoint(libID, cp); err != nil {
+			s.logger.Warn("failed to save sync checkpoint", "error", err, "libID", libID)
+		}
+	}
+	items, skipped, err := fetchAll(ctx, fetch, s.chunkSize(), from, checkpoint, onProgress)
+	if errors.Is(err, errListingChanged) {
+		s.logger.Info("library changed since the sync checkpoint, starting over", "libID", libID)
+		s.store.ClearSyncCheckpoint(libID)
+		return fetchAll(ctx, fetch, s.chunkSize(), fetchState[T]{}, checkpoint, onProgress)
+	}
+	return items, skipped, err
+}
+
+// errListingChanged ends a resumed fetch whose first page reports another
+// total than the one it was checkpointed at: items before the offset may
+// have been added or deleted, shifting the pages, so resuming would skip
+// some and keep others that are gone
+var errListingChanged = errors.New("library listing changed since the checkpoint")
+
+// chunkSize is the page size for library listings: the backend's own, if
+// it has one
+func (s *Service) chunkSize() int {
+	if pc, ok := s.client.(domain.PageSizeClient); ok && pc.PageSize() > 0 {
+		return pc.PageSize()
+	}
+	return defaultChunkSize
+}
+
+// fetchState is how far a paginated fetch has got: the offset of the next
+// page, the items before it, how many pages were skipped and the listing
+// total the pages reported
+type fetchState[T domain.ListItem] struct {
+	offset  int
+	items   []T
+	skipped int
+	total   int
+}
+
+func toListItems[T domain.ListItem](items []T) []domain.ListItem {
+	out := make([]domain.ListItem, len(items))
+	for i, item := range items {
+		out[i] = item
+	}
+	return out
+}
+
+func fromListItems[T domain.ListItem](items []domain.ListItem) []T {
+	out := make([]T, 0, len(items))
+	for _, item := range items {
+		if v, ok := item.(T); ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// fetchAll is a generic pagination helper. The first page reports the
+// total; the remaining pages it implies are fetched chunkConcurrency at a
+// time and reassembled in order, so the result and the progress reports
+// match a sequential fetch. Items are deduplicated by ID: offset pagination
+// under concurrent server-side mutation can shift pages and repeat items,
+// and duplicates would otherwise be cached as truth. After the known pages,
+// fetching continues sequentially until an empty page, so a server
+// reporting total=0 alongside a non-empty page still gets fully paginated
+// rather than truncated, and items added mid-sync are picked up.
+//
+// A page that fails with a server error is skipped and counted in skipped;
+// maxChunkFailures in a row, or an offline, auth or cancellation error,
+// fail the whole fetch.
+//
+// The fetch starts from a previous one's state (the zero state starts from
+// the first page), and hands its state to checkpoint, if set, every
+// checkpointPages pages. A resumed fetch whose first page reports a total
+// other than the state's fails with errListingChanged.
 func fetchAll[T domain.ListItem](
 	ctx context.Context,
 	fetch func(ctx context.Context, offset, limit int) ([]T, int, error),
 	chunkSize int,
+	from fetchState[T],
+	checkpoint func(fetchState[T]),
 	onProgress domain.ProgressFunc,
-) ([]T, error) {
+) (all []T, skipped int, err error) {
 	if chunkSize <= 0 {
 		chunkSize = defaultChunkSize
 	}
 
-	var all []T
 	seen := make(map[string]bool)
-	offset := 0
-
-	for {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-
-		items, total, err := fetch(ctx, offset, chunkSize)
+	for _, item := range from.items {
+		seen[item.GetID()] = true
+	}
+	all = from.items
+	skipped = from.skipped
+	total := 0
+	failures := 0
+	next := from.offset // offset of the page after the one page takes
+	// page takes one page's result, returning the error that ends the fetch
+	page := func(items []T, pageTotal int, err error) error {
+		next += chunkSize
 		if err != nil {
-			return nil, err
+			if !skippablePageError(ctx, err) {
+				return err
+			}
+			skipped++
+			if failures++; failures >= maxChunkFailures {
+				return fmt.Errorf("%d pages in a row failed: %w", failures, err)
+			}
+		} else {
+			failures = 0
+			total = pageTotal
+			for _, item := range items {
+				id := item.GetID()
+				if id != "" && seen[id] {
+					continue
+				}
+				seen[id] = true
+				all = append(all, item)
+			}
+			if onProgress != nil {
+				onProgress(len(all), total)
+			}
+		}
+		if checkpoint != nil && (next-from.offset)/chunkSize%checkpointPages == 0 {
+			checkpoint(fetchState[T]{offset: next, items: all, skipped: skipped, total: total})
 		}
+		return nil
+	}
 
-		for _, item := range items {
-			id := item.GetID()
-			if id != "" && seen[id] {
-				continue
-			}
-			seen[id] = true
-			all = append(all, item)
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	items, pageTotal, err := fetch(ctx, from.offset, chunkSize)
+	if err == nil && from.offset > 0 && from.total > 0 && pageTotal != from.total {
+		return nil, 0, errListingChanged
+	}
+	if err := page(items, pageTotal, err); err != nil {
+		return nil, 0, err
+	}
+	if err == nil && (len(items) == 0 || (total > 0 && len(all) >= total)) {
+		return all, skipped, nil
+	}
+	offset := from.offset + chunkSize
+
+	if total > offset {
+		pages := (total - offset + chunkSize - 1) / chunkSize
+		if err := fetchPages(ctx, fetch, offset, chunkSize, pages, page); err != nil {
+			return nil, 0, err
 		}
+		offset += pages * chunkSize
+	}
 
-		if onProgress != nil {
-			onProgress(len(all), total)
+	for total == 0 || len(all) < total {
+		select {
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		default:
 		}
 
-		if len(items) == 0 || (total > 0 && len(all) >= total) {
+		items, pageTotal, err := fetch(ctx, offset, chunkSize)
+		if err := page(items, pageTotal, err); err != nil {
+			return nil, 0, err
+		}
+		if err == nil && len(items) == 0 {
 			break
 		}
 		offset += chunkSize
 	}
 
-	return all, nil
+	return all, skipped, nil
+}
+
+// skippablePageError reports whether a failed page can be skipped: a
+// server error on that page, not the server or the sync going away
+func skippablePageError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return !errors.Is(err, domain.ErrServerOffline) &&
+		!errors.Is(err, domain.ErrAuthFailed) &&
+		!errors.Is(err, domain.ErrForbidden)
+}
+
+// fetchPages fetches pages consecutive pages starting at offset, at most
+// chunkConcurrency in flight, handing each result, failed or not, to
+// onPage in page order. An error from onPage cancels the pages still
+// running and is returned.
+func fetchPages[T domain.ListItem](
+	ctx context.Context,
+	fetch func(ctx context.Context, offset, limit int) ([]T, int, error),
+	offset, chunkSize, pages int,
+	onPage func(items []T, total int, err error) error,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type page struct {
+		index int
+		items []T
+		total int
+		err   error
+	}
+
+	indexes := make(chan int, pages)
+	for i := 0; i < pages; i++ {
+		indexes <- i
+	}
+	close(indexes)
+
+	// Buffered for every page, so workers never block after an early return
+	results := make(chan page, pages)
+	for w := 0; w < min(chunkConcurrency, pages); w++ {
+		go func() {
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					results <- page{index: i, err: err}
+					continue
+				}
+				items, total, err := fetch(ctx, offset+i*chunkSize, chunkSize)
+				results <- page{index: i, items: items, total: total, err: err}
+			}
+		}()
+	}
+
+	pending := make(map[int]page)
+	for next := 0; next < pages; {
+		p := <-results
+		pending[p.index] = p
+		for ready, ok := pending[next]; ok; ready, ok = pending[next] {
+			delete(pending, next)
+			if err := onPage(ready.items, ready.total, ready.err); err != nil {
+				return err
+			}
+			next++
+		}
+	}
+	return nil
 }
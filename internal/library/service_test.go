@@ -3,7 +3,11 @@ package library
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/mmcdole/kino/internal/domain"
 	"github.com/mmcdole/kino/internal/store"
@@ -12,10 +16,14 @@ import (
 // fakeClient implements domain.LibraryClient with canned data
 type fakeClient struct {
 	movies     []*domain.MediaItem
+	seasons    []*domain.Season
+	episodes   map[string][]*domain.MediaItem // by season ID
+	seasonsErr error
 	count      int
 	countErr   error
 	fetchCalls int
 	countCalls int
+	mu         sync.Mutex // count checks run in parallel
 }
 
 func (f *fakeClient) GetLibraries(ctx context.Context) ([]domain.Library, error) { return nil, nil }
@@ -34,14 +42,16 @@ func (f *fakeClient) GetMixedContent(ctx context.Context, libID string, offset,
 }
 
 func (f *fakeClient) GetSeasons(ctx context.Context, showID string) ([]*domain.Season, error) {
-	return nil, nil
+	return f.seasons, f.seasonsErr
 }
 
 func (f *fakeClient) GetEpisodes(ctx context.Context, seasonID string) ([]*domain.MediaItem, error) {
-	return nil, nil
+	return f.episodes[seasonID], nil
 }
 
 func (f *fakeClient) GetLibraryItemCount(ctx context.Context, libID, libType string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	f.countCalls++
 	return f.count, f.countErr
 }
@@ -74,7 +84,7 @@ func TestSyncLibraryDetectsNewItems(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.FromCache || res.Count != 2 {
+	if res.FromCache || res.Count != 2 || len(res.NewIDs) != 0 {
 		t.Fatalf("initial sync: got %+v", res)
 	}
 
@@ -104,6 +114,9 @@ func TestSyncLibraryDetectsNewItems(t *testing.T) {
 	if res.Count != 3 {
 		t.Fatalf("expected 3 items after refetch, got %d", res.Count)
 	}
+	if !slices.Equal(res.NewIDs, []string{"c"}) {
+		t.Fatalf("NewIDs = %v, want [c]", res.NewIDs)
+	}
 }
 
 // TestSyncLibraryTimestampInvalidates verifies the original timestamp path
@@ -134,16 +147,45 @@ type pagedClient struct {
 	fakeClient
 	pages [][]*domain.MediaItem
 	total int
+	fail  map[int]error // By page index
 }
 
 func (p *pagedClient) GetMovies(ctx context.Context, libID string, offset, limit int) ([]*domain.MediaItem, int, error) {
 	idx := offset / limit
+	if err := p.fail[idx]; err != nil {
+		return nil, 0, err
+	}
 	if idx >= len(p.pages) {
 		return nil, p.total, nil
 	}
 	return p.pages[idx], p.total, nil
 }
 
+// sizedClient asks for pages of its own size and records the limits asked
+type sizedClient struct {
+	fakeClient
+	limits []int
+}
+
+func (s *sizedClient) PageSize() int { return 200 }
+
+func (s *sizedClient) GetMovies(ctx context.Context, libID string, offset, limit int) ([]*domain.MediaItem, int, error) {
+	s.limits = append(s.limits, limit)
+	return []*domain.MediaItem{movie("a")}, 1, nil
+}
+
+// A backend with its own page size gets listings in pages of that size
+func TestFetchUsesClientPageSize(t *testing.T) {
+	client := &sizedClient{}
+	svc := NewService(client, mustStore(t), nil)
+	if _, err := svc.FetchMovies(context.Background(), "lib1", 100, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.limits) != 1 || client.limits[0] != 200 {
+		t.Fatalf("page limits = %v, want [200]", client.limits)
+	}
+}
+
 // Offset pagination under concurrent server mutation can repeat items across
 // pages; duplicates must not be cached as truth.
 func TestFetchMoviesDeduplicatesAcrossPages(t *testing.T) {
@@ -193,6 +235,208 @@ func TestFetchMoviesZeroTotalStillPaginates(t *testing.T) {
 	}
 }
 
+// Pages after the first are fetched in parallel, yet the result and the
+// progress reports come out in page order
+func TestFetchAllParallelPagesStayOrdered(t *testing.T) {
+	const total, chunk = 230, 50
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	fetch := func(ctx context.Context, offset, limit int) ([]*domain.MediaItem, int, error) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		// Later pages answer first, so reassembly has to reorder them
+		time.Sleep(time.Duration(total-offset) * 50 * time.Microsecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		var items []*domain.MediaItem
+		for i := offset; i < min(offset+limit, total); i++ {
+			items = append(items, movie(fmt.Sprintf("m%03d", i)))
+		}
+		return items, total, nil
+	}
+
+	var loaded []int
+	all, _, err := fetchAll(context.Background(), fetch, chunk, fetchState[*domain.MediaItem]{}, nil, func(n, _ int) { loaded = append(loaded, n) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != total {
+		t.Fatalf("got %d items, want %d", len(all), total)
+	}
+	for i, item := range all {
+		if want := fmt.Sprintf("m%03d", i); item.ID != want {
+			t.Fatalf("item %d = %s, want %s", i, item.ID, want)
+		}
+	}
+	if want := []int{50, 100, 150, 200, 230}; !slices.Equal(loaded, want) {
+		t.Fatalf("progress = %v, want %v", loaded, want)
+	}
+	if peak < 2 || peak > chunkConcurrency {
+		t.Fatalf("peak in-flight pages = %d, want 2..%d", peak, chunkConcurrency)
+	}
+}
+
+// A page the server fails on is skipped rather than failing the sync, and
+// the cached items it may have held are kept for the next sync to settle
+func TestFetchMoviesSkipsFailedPage(t *testing.T) {
+	client := &pagedClient{
+		pages: [][]*domain.MediaItem{
+			{movie("a"), movie("b")},
+			{movie("c"), movie("d")},
+			{movie("e")},
+		},
+		total: 5,
+	}
+	svc := NewService(client, mustStore(t), nil)
+	if _, err := svc.FetchMovies(context.Background(), "lib1", 100, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	client.fail = map[int]error{1: fmt.Errorf("%w: 500", domain.ErrServerError)}
+	movies, err := svc.FetchMovies(context.Background(), "lib1", 200, nil)
+	if err != nil {
+		t.Fatalf("a failed page failed the sync: %v", err)
+	}
+	if got := itemIDs(movies); !slices.Equal(got, []string{"a", "b", "e", "c", "d"}) {
+		t.Fatalf("movies = %v, want the fetched pages plus the cached c and d", got)
+	}
+	if svc.store.IsValid("lib1", 200) {
+		t.Fatal("a partial listing should be saved as outdated")
+	}
+}
+
+func TestFetchAllGivesUp(t *testing.T) {
+	serverErr := fmt.Errorf("%w: 500", domain.ErrServerError)
+	for _, c := range []struct {
+		name string
+		fail map[int]error
+	}{
+		{"pages in a row", map[int]error{1: serverErr, 2: serverErr, 3: serverErr}},
+		{"offline", map[int]error{1: domain.ErrServerOffline}},
+		{"auth", map[int]error{2: domain.ErrAuthFailed}},
+	} {
+		client := &pagedClient{total: 500, fail: c.fail}
+		for i := 0; i < 10; i++ {
+			client.pages = append(client.pages, []*domain.MediaItem{movie(fmt.Sprint(i))})
+		}
+		fetch := func(ctx context.Context, offset, limit int) ([]*domain.MediaItem, int, error) {
+			return client.GetMovies(ctx, "lib1", offset, limit)
+		}
+		if _, _, err := fetchAll(context.Background(), fetch, 50, fetchState[*domain.MediaItem]{}, nil, nil); err == nil {
+			t.Errorf("%s: fetch succeeded, want it to give up", c.name)
+		}
+	}
+}
+
+// A sync cut off partway resumes from its last checkpoint: the pages before
+// it are not fetched again
+func TestFetchMoviesResumesFromCheckpoint(t *testing.T) {
+	const pages = 15
+	client := &pagedClient{total: pages, fail: map[int]error{12: domain.ErrServerOffline}}
+	for i := 0; i < pages; i++ {
+		client.pages = append(client.pages, []*domain.MediaItem{movie(fmt.Sprint(i))})
+	}
+	svc := NewService(client, mustStore(t), nil)
+	if _, err := svc.FetchMovies(context.Background(), "lib1", 100, nil); err == nil {
+		t.Fatal("sync succeeded despite the server going offline")
+	}
+	cp, ok := svc.store.GetSyncCheckpoint("lib1", 100)
+	if !ok || cp.Offset != checkpointPages*defaultChunkSize || len(cp.Items) != checkpointPages {
+		t.Fatalf("checkpoint = %+v ok=%v, want %d pages in", cp, ok, checkpointPages)
+	}
+	if _, ok := svc.store.GetSyncCheckpoint("lib1", 200); ok {
+		t.Fatal("checkpoint served for a newer library version")
+	}
+
+	// Pages before the checkpoint would fail if fetched again
+	client.fail = map[int]error{}
+	for i := 0; i < checkpointPages; i++ {
+		client.fail[i] = domain.ErrServerOffline
+	}
+	movies, err := svc.FetchMovies(context.Background(), "lib1", 100, nil)
+	if err != nil {
+		t.Fatalf("resumed sync refetched checkpointed pages: %v", err)
+	}
+	if len(movies) != pages || movies[0].ID != "0" || movies[pages-1].ID != fmt.Sprint(pages-1) {
+		t.Fatalf("movies = %v, want all %d in order", itemIDs(movies), pages)
+	}
+	if _, ok := svc.store.GetSyncCheckpoint("lib1", 100); ok {
+		t.Fatal("checkpoint survived a completed sync")
+	}
+}
+
+// A checkpoint the listing moved away from is dropped: with an item before
+// its offset deleted, resuming would skip a live item and keep the deleted
+// one, the count coming out the same
+func TestFetchMoviesDropsCheckpointOnChangedTotal(t *testing.T) {
+	const pages = 15
+	client := &pagedClient{total: pages, fail: map[int]error{12: domain.ErrServerOffline}}
+	for i := 0; i < pages; i++ {
+		client.pages = append(client.pages, []*domain.MediaItem{movie(fmt.Sprint(i))})
+	}
+	svc := NewService(client, mustStore(t), nil)
+	if _, err := svc.FetchMovies(context.Background(), "lib1", 100, nil); err == nil {
+		t.Fatal("sync succeeded despite the server going offline")
+	}
+	if cp, ok := svc.store.GetSyncCheckpoint("lib1", 100); !ok || cp.Total != pages {
+		t.Fatalf("checkpoint = %+v ok=%v, want total %d", cp, ok, pages)
+	}
+
+	// Movie 0 is deleted: every later page moves up one
+	client.pages, client.total, client.fail = client.pages[1:], pages-1, nil
+	movies, err := svc.FetchMovies(context.Background(), "lib1", 100, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(movies) != pages-1 || movies[0].ID != "1" || movies[len(movies)-1].ID != fmt.Sprint(pages-1) {
+		t.Fatalf("movies = %v, want 1 to %d", itemIDs(movies), pages-1)
+	}
+}
+
+// conditionalClient answers conditional library checks from a fixed ETag
+type conditionalClient struct {
+	fakeClient
+	etag string
+}
+
+func (c *conditionalClient) CheckLibrary(ctx context.Context, libID string, prev domain.Validator) (domain.Validator, bool, error) {
+	return domain.Validator{ETag: c.etag}, prev.ETag != c.etag, nil
+}
+
+// A library whose timestamp moved is served from cache when the server
+// confirms its content unchanged, and refetched when it doesn't
+func TestSyncLibraryConditionalCheck(t *testing.T) {
+	client := &conditionalClient{fakeClient: fakeClient{movies: []*domain.MediaItem{movie("a")}, count: 1}, etag: "v1"}
+	svc := NewService(client, mustStore(t), nil)
+	ctx := context.Background()
+
+	if _, err := svc.SyncLibrary(ctx, domain.Library{ID: "lib1", Type: "movie", UpdatedAt: 100}, nil); err != nil {
+		t.Fatal(err)
+	}
+	result, err := svc.SyncLibrary(ctx, domain.Library{ID: "lib1", Type: "movie", UpdatedAt: 200}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.FromCache || result.Count != 1 || client.fetchCalls != 1 {
+		t.Fatalf("result = %+v after %d fetches, want the cache confirmed", result, client.fetchCalls)
+	}
+	if !svc.store.IsValid("lib1", 200) {
+		t.Fatal("confirmed library not marked current")
+	}
+
+	client.etag = "v2"
+	if result, _ := svc.SyncLibrary(ctx, domain.Library{ID: "lib1", Type: "movie", UpdatedAt: 300}, nil); result.FromCache {
+		t.Fatal("changed library served from cache")
+	}
+	if v, _ := svc.store.GetLibraryValidator("lib1"); v.ETag != "v2" {
+		t.Fatalf("validator = %+v, want the refetch's", v)
+	}
+}
+
 func mustStore(t *testing.T) domain.Store {
 	t.Helper()
 	st, err := store.NewLibraryStore("", "", "")
@@ -226,3 +470,477 @@ func TestSyncLibraryCountCheckFailureServesCache(t *testing.T) {
 		t.Fatalf("expected no refetch, got %d fetch calls", client.fetchCalls)
 	}
 }
+
+// The same movie in two libraries (different IDs) appears once, from the
+// earliest library; the merged list is ordered by title
+func TestMergeMoviesDedupesAcrossLibraries(t *testing.T) {
+	movies := []*domain.MediaItem{
+		{ID: "m1", Title: "Heat", Year: 1995},
+		{ID: "m2", Title: "Alien", Year: 1979},
+	}
+	uhd := []*domain.MediaItem{
+		{ID: "4k1", Title: "Heat", Year: 1995},
+		{ID: "4k2", Title: "Heat", Year: 1986}, // different movie, same title
+		{ID: "4k3", Title: "Dune", Year: 2021},
+	}
+
+	merged := MergeMovies([][]*domain.MediaItem{movies, uhd})
+
+	var ids []string
+	for _, m := range merged {
+		ids = append(ids, m.ID)
+	}
+	want := []string{"m2", "4k3", "m1", "4k2"}
+	if len(ids) != len(want) {
+		t.Fatalf("got %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("got %v, want %v", ids, want)
+		}
+	}
+}
+
+// Deep links parse titles, years and episode codes; a slash in a title is
+// only a separator when an episode code follows it
+func TestParseLink(t *testing.T) {
+	cases := []struct {
+		in   string
+		want Link
+	}{
+		{"Heat", Link{Title: "Heat", Season: -1}},
+		{"Heat (1995)", Link{Title: "Heat", Year: 1995, Season: -1}},
+		{"Face/Off", Link{Title: "Face/Off", Season: -1}},
+		{"The Wire/S02E05", Link{Title: "The Wire", Season: 2, Episode: 5}},
+		{"The Wire / s3", Link{Title: "The Wire", Season: 3}},
+		{"Doctor Who (2005)/S00E01", Link{Title: "Doctor Who", Year: 2005, Season: 0, Episode: 1}},
+	}
+	for _, c := range cases {
+		got, err := ParseLink(c.in)
+		if err != nil {
+			t.Fatalf("ParseLink(%q): %v", c.in, err)
+		}
+		if got != c.want {
+			t.Errorf("ParseLink(%q) = %+v, want %+v", c.in, got, c.want)
+		}
+	}
+	if _, err := ParseLink("/S01E01"); err == nil {
+		t.Error("expected an error for a link without a title")
+	}
+}
+
+// Episode links resolve through the cached show, seasons and episodes
+func TestResolveCachedEpisode(t *testing.T) {
+	svc, st := newTestService(t, &fakeClient{})
+	lib := domain.Library{ID: "tv", Type: "show"}
+	_ = st.SaveShows("tv", []*domain.Show{{ID: "sh", Title: "The Wire", LibraryID: "tv"}}, 1)
+	_ = st.SaveSeasons("tv", "sh", []*domain.Season{{ID: "s1", SeasonNum: 1}, {ID: "s2", SeasonNum: 2}})
+	_ = st.SaveEpisodes("tv", "sh", "s2", []*domain.MediaItem{
+		{ID: "e4", EpisodeNum: 4, Type: domain.MediaTypeEpisode},
+		{ID: "e5", EpisodeNum: 5, Type: domain.MediaTypeEpisode},
+	})
+
+	link, _ := ParseLink("the wire/S02E05")
+	res, err := svc.Resolve(context.Background(), []domain.Library{lib}, link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Show.ID != "sh" || res.Season.ID != "s2" || res.Playable().ID != "e5" {
+		t.Fatalf("resolved to %+v", res)
+	}
+
+	link, _ = ParseLink("The Wire/S02E09")
+	if _, err := svc.Resolve(context.Background(), []domain.Library{lib}, link); !errors.Is(err, ErrLinkNotFound) {
+		t.Fatalf("missing episode: err = %v, want ErrLinkNotFound", err)
+	}
+}
+
+func TestParseEpisodeQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Link
+		ok    bool
+	}{
+		{"wire s03e05", Link{Title: "wire", Season: 3, Episode: 5}, true},
+		{"Breaking Bad 2x08", Link{Title: "Breaking Bad", Season: 2, Episode: 8}, true},
+		{" the office S2 E1 ", Link{Title: "the office", Season: 2, Episode: 1}, true},
+		{"s03e05", Link{}, false},
+		{"wire s03", Link{}, false},
+		{"1917", Link{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseEpisodeQuery(tt.query)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseEpisodeQuery(%q) = %+v, %v; want %+v, %v", tt.query, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+type searchingClient struct {
+	fakeClient
+	results []*domain.MediaItem
+}
+
+func (c *searchingClient) Search(ctx context.Context, query string) ([]*domain.MediaItem, error) {
+	return c.results, nil
+}
+
+// An episode query finds its show by loose title in the cache, else
+// through the server's search, and its episode without a deep index
+func TestResolveEpisodeQuery(t *testing.T) {
+	client := &searchingClient{fakeClient: fakeClient{
+		seasons:  []*domain.Season{{ID: "s3", SeasonNum: 3}},
+		episodes: map[string][]*domain.MediaItem{"s3": {{ID: "e5", EpisodeNum: 5, Type: domain.MediaTypeEpisode}}},
+	}}
+	st := mustStore(t)
+	svc := NewService(client, st, nil)
+	libs := []domain.Library{{ID: "tv", Type: "show"}}
+	_ = st.SaveShows("tv", []*domain.Show{
+		{ID: "wired", Title: "Wired for Sound", LibraryID: "tv"},
+		{ID: "wire", Title: "The Wire", LibraryID: "tv"},
+	}, 1)
+	ctx := context.Background()
+
+	link, _ := ParseEpisodeQuery("wire s03e05")
+	res, err := svc.ResolveEpisodeQuery(ctx, libs, link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Show.ID != "wire" || res.Playable().ID != "e5" {
+		t.Fatalf("resolved to %+v", res)
+	}
+
+	client.results = []*domain.MediaItem{
+		{ID: "bcs", Title: "Better Call Saul", Type: domain.MediaTypeShow, LibraryID: "tv"},
+		{ID: "bb", Title: "Breaking Bad", Type: domain.MediaTypeShow, LibraryID: "tv"},
+	}
+	link, _ = ParseEpisodeQuery("breaking bad 3x05")
+	if res, err = svc.ResolveEpisodeQuery(ctx, libs, link); err != nil || res.Show.ID != "bb" {
+		t.Fatalf("server search fallback = %+v, %v", res, err)
+	}
+
+	link, _ = ParseEpisodeQuery("lost s01e01")
+	if _, err := svc.ResolveEpisodeQuery(ctx, libs, link); !errors.Is(err, ErrLinkNotFound) {
+		t.Fatalf("unknown show: err = %v, want ErrLinkNotFound", err)
+	}
+}
+
+// The startup fast path trusts matching timestamps locally, then flags only
+// the libraries whose item count moved; nothing is refetched by the check
+func TestStaleLibrariesChecksCountsOnly(t *testing.T) {
+	client := &fakeClient{count: 1}
+	svc, st := newTestService(t, client)
+
+	fresh := domain.Library{ID: "fresh", Type: "movie", UpdatedAt: 100}
+	grown := domain.Library{ID: "grown", Type: "movie", UpdatedAt: 100}
+	_ = st.SaveMovies("fresh", []*domain.MediaItem{movie("a")}, 100)
+	_ = st.SaveMovies("grown", []*domain.MediaItem{movie("b"), movie("c")}, 100)
+
+	if count, unwatched, ok := svc.CachedCount(fresh); !ok || count != 1 || unwatched != 1 {
+		t.Fatalf("CachedCount(fresh) = %d, %d, %v", count, unwatched, ok)
+	}
+	if _, _, ok := svc.CachedCount(domain.Library{ID: "fresh", Type: "movie", UpdatedAt: 200}); ok {
+		t.Fatal("a newer server timestamp must fail the local check")
+	}
+
+	stale := svc.StaleLibraries(context.Background(), []domain.Library{fresh, grown})
+	if len(stale) != 1 || stale[0].ID != "grown" {
+		t.Fatalf("stale = %v, want [grown]", stale)
+	}
+	if client.countCalls != 2 || client.fetchCalls != 0 {
+		t.Fatalf("count calls %d, fetch calls %d", client.countCalls, client.fetchCalls)
+	}
+}
+
+// Items deleted on the server disappear from the rest of the cache when the
+// library is next refetched, not only from the library listing
+func TestRefetchPrunesDeletedItems(t *testing.T) {
+	client := &fakeClient{movies: []*domain.MediaItem{movie("a"), movie("b")}, count: 2}
+	svc, st := newTestService(t, client)
+	lib := domain.Library{ID: "lib1", Type: "movie", UpdatedAt: 100}
+
+	if _, err := svc.SyncLibrary(context.Background(), lib, nil); err != nil {
+		t.Fatal(err)
+	}
+	_ = st.SavePlaylists([]*domain.Playlist{{ID: "pl1", Title: "Mix", ItemCount: 2}})
+	_ = st.SavePlaylistItems("pl1", []*domain.MediaItem{movie("a"), movie("b")})
+
+	client.movies = []*domain.MediaItem{movie("a")}
+	client.count = 1
+	if _, err := svc.SyncLibrary(context.Background(), lib, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	items, _ := st.GetPlaylistItems("pl1")
+	if len(items) != 1 || items[0].ID != "a" {
+		t.Fatalf("playlist items = %v, want only a", itemIDs(items))
+	}
+	if _, ok := st.GetPlaylists(); ok {
+		t.Fatal("playlist list with stale counts survived the prune")
+	}
+}
+
+// Selecting a show prefetches its seasons and first regular season's
+// episodes; moving on before the delay fetches nothing
+func TestPrefetchShow(t *testing.T) {
+	client := &fakeClient{
+		seasons: []*domain.Season{
+			{ID: "s0", SeasonNum: 0},
+			{ID: "s1", SeasonNum: 1},
+			{ID: "s2", SeasonNum: 2},
+		},
+		episodes: map[string][]*domain.MediaItem{
+			"s1": {{ID: "e1", Type: domain.MediaTypeEpisode}},
+		},
+	}
+	svc, st := newTestService(t, client)
+
+	svc.PrefetchShow("lib", "cancelled")
+	svc.CancelPrefetch()
+
+	svc.PrefetchShow("lib", "show1")
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, ok := st.GetEpisodes("lib", "show1", "s1"); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("first season's episodes were not prefetched")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, ok := st.GetSeasons("lib", "show1"); !ok {
+		t.Fatal("seasons were not prefetched")
+	}
+	if _, ok := st.GetEpisodes("lib", "show1", "s0"); ok {
+		t.Fatal("specials prefetched instead of the first regular season")
+	}
+	if _, ok := st.GetSeasons("lib", "cancelled"); ok {
+		t.Fatal("cancelled prefetch still fetched")
+	}
+}
+
+// An unreachable server falls back to cached seasons however old
+func TestFetchSeasonsOfflineServesStaleCache(t *testing.T) {
+	client := &fakeClient{seasonsErr: fmt.Errorf("%w: dial tcp", domain.ErrServerOffline)}
+	svc, st := newTestService(t, client)
+
+	if _, err := svc.FetchSeasons(context.Background(), "lib", "show1"); !errors.Is(err, domain.ErrServerOffline) {
+		t.Fatalf("err = %v, want offline with nothing cached", err)
+	}
+
+	if err := st.SaveSeasons("lib", "show1", []*domain.Season{{ID: "s1"}}); err != nil {
+		t.Fatal(err)
+	}
+	// The library moved on since: the entry is stale, but better than nothing
+	if err := st.SaveShows("lib", nil, 500); err != nil {
+		t.Fatal(err)
+	}
+	seasons, err := svc.FetchSeasons(context.Background(), "lib", "show1")
+	if err != nil || len(seasons) != 1 {
+		t.Fatalf("seasons = %v, err = %v; want cached season", seasons, err)
+	}
+}
+
+// TestSyncLibraryCountsUnwatched checks the unwatched tally rides along with
+// both a fetch and a cache hit
+func TestSyncLibraryCountsUnwatched(t *testing.T) {
+	watched := movie("b")
+	watched.IsPlayed = true
+	client := &fakeClient{movies: []*domain.MediaItem{movie("a"), watched, movie("c")}, count: 3}
+	svc, _ := newTestService(t, client)
+	lib := domain.Library{ID: "lib1", Type: "movie", UpdatedAt: 100}
+
+	for _, fromCache := range []bool{false, true} {
+		res, err := svc.SyncLibrary(context.Background(), lib, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.FromCache != fromCache || res.Unwatched != 2 {
+			t.Fatalf("sync (from cache %v): got %+v, want 2 unwatched", fromCache, res)
+		}
+	}
+
+	shows := []*domain.Show{{ID: "s1", EpisodeCount: 10, UnwatchedCount: 4}, {ID: "s2", EpisodeCount: 3}}
+	if n := countUnwatched(shows); n != 4 {
+		t.Fatalf("countUnwatched(shows) = %d, want 4 episodes", n)
+	}
+}
+
+// blockingClient holds GetSeasons until its context is cancelled
+type blockingClient struct {
+	fakeClient
+	started chan struct{}
+}
+
+func (b *blockingClient) GetSeasons(ctx context.Context, showID string) ([]*domain.Season, error) {
+	close(b.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// Shutdown cancels fetches in flight, waits for them and refuses new ones
+func TestShutdownCancelsInflight(t *testing.T) {
+	client := &blockingClient{started: make(chan struct{})}
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+	svc := NewService(client, st, nil)
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := svc.FetchSeasons(context.Background(), "lib", "show1")
+		errc <- err
+	}()
+	<-client.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := svc.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("in-flight fetch returned %v, want context.Canceled", err)
+		}
+	default:
+		t.Fatal("Shutdown returned before the in-flight fetch")
+	}
+
+	if _, err := svc.SyncLibrary(context.Background(), domain.Library{ID: "lib", Type: "movie"}, nil); err == nil {
+		t.Fatal("sync after Shutdown succeeded")
+	}
+}
+
+// The last episode of a season continues with the next regular season,
+// skipping specials
+func TestNextEpisodeCrossesSeasons(t *testing.T) {
+	ep := func(id, season string) *domain.MediaItem {
+		return &domain.MediaItem{ID: id, Type: domain.MediaTypeEpisode, ShowID: "show1", ParentID: season}
+	}
+	client := &fakeClient{
+		seasons: []*domain.Season{
+			{ID: "s2", SeasonNum: 2},
+			{ID: "s0", SeasonNum: 0},
+			{ID: "s1", SeasonNum: 1},
+		},
+		episodes: map[string][]*domain.MediaItem{
+			"s0": {ep("sp1", "s0")},
+			"s1": {ep("e1", "s1"), ep("e2", "s1")},
+			"s2": {ep("e3", "s2")},
+		},
+	}
+	svc, _ := newTestService(t, client)
+	ctx := context.Background()
+
+	for _, tc := range []struct{ from, season, want string }{
+		{"e1", "s1", "e2"}, {"e2", "s1", "e3"}, {"e3", "s2", ""}, {"sp1", "s0", ""},
+	} {
+		next, err := svc.NextEpisode(ctx, "lib", *ep(tc.from, tc.season))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ""
+		if next != nil {
+			got = next.ID
+		}
+		if got != tc.want {
+			t.Errorf("NextEpisode(%s) = %q, want %q", tc.from, got, tc.want)
+		}
+	}
+}
+
+type indexClient struct {
+	fakeClient
+	all        []*domain.MediaItem
+	indexCalls int
+}
+
+func (c *indexClient) GetAllEpisodes(ctx context.Context, libID string) ([]*domain.MediaItem, error) {
+	c.indexCalls++
+	return c.all, nil
+}
+
+// With deep indexing on, a show library's sync indexes its episodes once,
+// and again only when the library's content moves
+func TestSyncLibraryIndexesEpisodes(t *testing.T) {
+	client := &indexClient{all: []*domain.MediaItem{{ID: "e1", Title: "Pilot", Type: domain.MediaTypeEpisode}}}
+	svc := NewService(client, mustStore(t), nil)
+	ctx := context.Background()
+	shows := domain.Library{ID: "tv", Type: "show", UpdatedAt: 100}
+
+	if _, err := svc.SyncLibrary(ctx, shows, nil); err != nil {
+		t.Fatal(err)
+	}
+	if client.indexCalls != 0 {
+		t.Fatal("indexed episodes with deep indexing off")
+	}
+
+	svc.SetDeepIndex(true)
+	svc.SyncLibrary(ctx, shows, nil)
+	svc.SyncLibrary(ctx, shows, nil)
+	svc.SyncLibrary(ctx, domain.Library{ID: "movies", Type: "movie"}, nil)
+	if client.indexCalls != 1 {
+		t.Fatalf("indexed %d times, want once while the index is fresh", client.indexCalls)
+	}
+	if eps, ok := svc.store.GetLibraryEpisodes("tv"); !ok || len(eps) != 1 {
+		t.Fatalf("index = %v, %v", eps, ok)
+	}
+
+	shows.UpdatedAt = 200
+	svc.SyncLibrary(ctx, shows, nil)
+	if client.indexCalls != 2 {
+		t.Fatalf("indexed %d times, want a refresh after the library changed", client.indexCalls)
+	}
+}
+
+// deletingClient also deletes items
+type deletingClient struct {
+	*fakeClient
+}
+
+func (c deletingClient) DeleteItem(ctx context.Context, itemID string) error { return nil }
+
+// Deleting cleans the cache of the library it was browsed in, not the
+// item's LibraryID (empty on Jellyfin episodes, a parent folder on its
+// movies), so the item doesn't come back from disk
+func TestDeleteItemCleansBrowsedLibrary(t *testing.T) {
+	dir := t.TempDir()
+	st, err := store.NewLibraryStore(dir, "http://server", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := NewService(deletingClient{&fakeClient{}}, st, nil)
+
+	heat := &domain.MediaItem{ID: "m1", Type: domain.MediaTypeMovie, LibraryID: "folder9"}
+	if err := st.SaveMovies("lib1", []*domain.MediaItem{heat, movie("m2")}, 100); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.SaveSeasons("lib2", "show1", []*domain.Season{{ID: "s1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.DeleteItem(context.Background(), "lib1", heat); err != nil {
+		t.Fatal(err)
+	}
+	episode := &domain.MediaItem{ID: "e1", Type: domain.MediaTypeEpisode, ShowID: "show1"}
+	if err := svc.DeleteItem(context.Background(), "lib2", episode); err != nil {
+		t.Fatal(err)
+	}
+	st.Close()
+
+	st, err = store.NewLibraryStore(dir, "http://server", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+	if movies, _ := st.GetMovies("lib1"); slices.ContainsFunc(movies, func(m *domain.MediaItem) bool { return m.ID == "m1" }) {
+		t.Fatal("deleted movie came back from the disk cache")
+	}
+	if _, ok := st.GetSeasons("lib2", "show1"); ok {
+		t.Fatal("deleted episode's show was not invalidated")
+	}
+}
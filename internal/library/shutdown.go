@@ -0,0 +1,72 @@
+package library
+
+import (
+	"context"
+	"sync"
+)
+
+// inflight tracks the service's cache-writing work so Shutdown can cancel
+// it and wait for the writes already under way
+type inflight struct {
+	mu      sync.Mutex
+	closed  bool
+	next    int
+	cancels map[int]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// track registers a unit of work. The returned context is also cancelled
+// by Shutdown; done must be called when the work returns. After Shutdown
+// the context comes back already cancelled.
+func (s *Service) track(ctx context.Context) (context.Context, func()) {
+	f := &s.inflight
+	ctx, cancel := context.WithCancel(ctx)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		cancel()
+		return ctx, func() {}
+	}
+	if f.cancels == nil {
+		f.cancels = make(map[int]context.CancelFunc)
+	}
+	id := f.next
+	f.next++
+	f.cancels[id] = cancel
+	f.wg.Add(1)
+	return ctx, func() {
+		f.mu.Lock()
+		delete(f.cancels, id)
+		f.mu.Unlock()
+		cancel()
+		f.wg.Done()
+	}
+}
+
+// Shutdown stops background work for a clean exit: it cancels the prefetch
+// and every sync or fetch in flight, then waits for them to return so no
+// cache write is cut off when the store closes. New work is refused. It
+// gives up when ctx is done.
+func (s *Service) Shutdown(ctx context.Context) error {
+	s.CancelPrefetch()
+
+	f := &s.inflight
+	f.mu.Lock()
+	f.closed = true
+	for _, cancel := range f.cancels {
+		cancel()
+	}
+	f.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		f.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -11,6 +11,10 @@ import (
 	"github.com/mmcdole/kino/internal/config"
 )
 
+// level is the level of the logger SetupLogger made; SetLevel changes it
+// while kino runs
+var level slog.LevelVar
+
 // SetupLogger initializes the slog logger with file output
 func SetupLogger(cfg *config.LoggingConfig) (*slog.Logger, error) {
 	// Expand ~ in path
@@ -29,40 +33,54 @@ func SetupLogger(cfg *config.LoggingConfig) (*slog.Logger, error) {
 		return nil, fmt.Errorf("failed to create log directory: %w", err)
 	}
 
-	// Open log file. 0600: log lines include server details and, at Debug
-	// level, request URLs — keep them out of reach of other local users.
-	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	// Open log file, rotated by size
+	logFile, err := openRotating(logPath, int64(cfg.MaxSizeMB)<<20, cfg.MaxFiles)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open log file: %w", err)
 	}
-	// Tighten pre-existing log files created with the old 0644 default
-	_ = logFile.Chmod(0600)
 
 	// Parse log level
-	level := parseLogLevel(cfg.Level)
+	lvl, _ := ParseLevel(cfg.Level)
+	level.Set(lvl)
 
 	// Create JSON handler for structured logging
 	handler := slog.NewJSONHandler(logFile, &slog.HandlerOptions{
-		Level: level,
+		Level: &level,
 	})
 
 	logger := slog.New(handler)
 	return logger, nil
 }
 
-// parseLogLevel converts a string log level to slog.Level
-func parseLogLevel(level string) slog.Level {
-	switch strings.ToUpper(level) {
+// SetLevel changes the level of the running logger. An unknown name
+// leaves it as it is.
+func SetLevel(name string) bool {
+	lvl, ok := ParseLevel(name)
+	if ok {
+		level.Set(lvl)
+	}
+	return ok
+}
+
+// Level returns the running logger's level
+func Level() slog.Level {
+	return level.Level()
+}
+
+// ParseLevel converts a level name to slog.Level, INFO and false when it
+// names none
+func ParseLevel(name string) (slog.Level, bool) {
+	switch strings.ToUpper(name) {
 	case "DEBUG":
-		return slog.LevelDebug
+		return slog.LevelDebug, true
 	case "INFO":
-		return slog.LevelInfo
+		return slog.LevelInfo, true
 	case "WARN", "WARNING":
-		return slog.LevelWarn
+		return slog.LevelWarn, true
 	case "ERROR":
-		return slog.LevelError
+		return slog.LevelError, true
 	default:
-		return slog.LevelInfo
+		return slog.LevelInfo, false
 	}
 }
 
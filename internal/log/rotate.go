@@ -0,0 +1,112 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// rotatingFile is a log file that moves aside once it reaches maxSize:
+// kino.log becomes kino.log.1, kino.log.1 becomes kino.log.2 and so on,
+// keeping at most keep old files. Every kino process appends to the same
+// file, so the size is read from the file rather than counted.
+type rotatingFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64 // 0 never rotates
+	keep    int
+	file    *os.File
+}
+
+// openRotating opens path for appending, rotating it first when it is
+// already full
+func openRotating(path string, maxSize int64, keep int) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize, keep: keep}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	if r.full(0) {
+		if err := r.rotate(); err != nil {
+			r.file.Close()
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// open opens the current file. 0600: log lines include server details and,
+// at Debug level, request URLs — keep them out of reach of other local
+// users.
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	// Tighten pre-existing log files created with the old 0644 default
+	_ = f.Chmod(0600)
+	r.file = f
+	return nil
+}
+
+// full reports whether writing n more bytes would take the file past
+// maxSize. An empty file is never full, so one oversized line still lands.
+func (r *rotatingFile) full(n int) bool {
+	if r.maxSize <= 0 {
+		return false
+	}
+	info, err := r.file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Size() > 0 && info.Size()+int64(n) > r.maxSize
+}
+
+// Write appends p, rotating first when p would overflow the file. A failed
+// rotation keeps writing to the current file rather than losing lines.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.full(len(p)) {
+		if err := r.rotate(); err != nil && r.file == nil {
+			return 0, err
+		}
+	}
+	return r.file.Write(p)
+}
+
+// rotate shifts the old files up one, dropping the oldest, and starts a new
+// current file. The file is closed first: Windows can't rename an open one.
+func (r *rotatingFile) rotate() error {
+	r.file.Close()
+	r.file = nil
+
+	var renameErr error
+	if r.keep > 0 {
+		_ = os.Remove(r.backup(r.keep))
+		for i := r.keep - 1; i >= 1; i-- {
+			_ = os.Rename(r.backup(i), r.backup(i+1))
+		}
+		renameErr = os.Rename(r.path, r.backup(1))
+	} else {
+		renameErr = os.Remove(r.path)
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+	return renameErr
+}
+
+// backup is the name of the i-th most recent old file
+func (r *rotatingFile) backup(i int) string {
+	return fmt.Sprintf("%s.%d", r.path, i)
+}
+
+// Close closes the current file
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Close()
+}
@@ -0,0 +1,49 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// A full log moves to .1, the older ones shift up and only keep of them
+// survive; a log already full at startup rotates before the first write.
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kino.log")
+	line := strings.Repeat("x", 9) + "\n"
+
+	r, err := openRotating(path, 25, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 8; i++ { // Two lines per file: 4 files written, 3 kept
+		if _, err := r.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.Close()
+
+	for _, name := range []string{"kino.log", "kino.log.1", "kino.log.2"} {
+		data, err := os.ReadFile(filepath.Join(filepath.Dir(path), name))
+		if err != nil || string(data) != line+line {
+			t.Errorf("%s = %q (%v), want two lines", name, data, err)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("kept more old logs than max_files")
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("log mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	// Reopening the full log rotates it out of the way
+	r, err = openRotating(path, 15, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+	if info, _ := os.Stat(path); info.Size() != 0 {
+		t.Errorf("reopened full log has %d bytes, want a fresh file", info.Size())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 
 	"github.com/mmcdole/kino/internal/config"
 	"github.com/mmcdole/kino/internal/mediaserver/jellyfin"
@@ -28,21 +29,15 @@ type AuthFlow interface {
 	Run(ctx context.Context, serverURL string) (*AuthResult, error)
 }
 
-// NewAuthFlow creates the appropriate AuthFlow based on server type.
-// - Plex: PIN-based OAuth flow (display PIN -> user visits plex.tv/link -> poll for token)
-// - Jellyfin: Username/password authentication
-// The deviceID uniquely identifies this install to the server.
-func NewAuthFlow(serverType config.SourceType, deviceID string, logger *slog.Logger) (AuthFlow, error) {
-	switch serverType {
-	case config.SourceTypePlex:
-		return &plexAuthAdapter{inner: plex.NewAuthFlow(deviceID, logger)}, nil
-
-	case config.SourceTypeJellyfin:
-		return &jellyfinAuthAdapter{inner: jellyfin.NewAuthFlow(deviceID, logger)}, nil
-
-	default:
+// NewAuthFlow creates the AuthFlow of the source registered for the server
+// type. The deviceID uniquely identifies this install to the server;
+// transport carries the configured TLS settings (nil for stdlib defaults).
+func NewAuthFlow(serverType config.SourceType, deviceID string, transport http.RoundTripper, logger *slog.Logger) (AuthFlow, error) {
+	source, ok := Lookup(serverType)
+	if !ok {
 		return nil, fmt.Errorf("unknown server type: %s", serverType)
 	}
+	return source.NewAuthFlow(deviceID, transport, logger), nil
 }
 
 // plexAuthAdapter wraps plex.AuthFlow to satisfy the AuthFlow interface
@@ -62,6 +57,28 @@ func (a *plexAuthAdapter) Run(ctx context.Context, serverURL string) (*AuthResul
 	}, nil
 }
 
+func (a *plexAuthAdapter) RequestLink(ctx context.Context) (LinkCode, error) {
+	pin, id, err := a.inner.RequestPIN(ctx)
+	if err != nil {
+		return LinkCode{}, err
+	}
+	return LinkCode{Code: pin, URL: plex.LinkURL, id: id}, nil
+}
+
+func (a *plexAuthAdapter) WaitLink(ctx context.Context, code LinkCode) (*AuthResult, error) {
+	token, err := a.inner.WaitPIN(ctx, code.id)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthResult{Token: token}, nil
+}
+
+// SwitchUser moves a fresh account token to a Plex Home member. Protected
+// members need pin.
+func (a *plexAuthAdapter) SwitchUser(ctx context.Context, token, userID, pin string) (string, error) {
+	return a.inner.SwitchHomeUser(ctx, token, userID, pin)
+}
+
 // jellyfinAuthAdapter wraps jellyfin.AuthFlow to satisfy the AuthFlow interface
 type jellyfinAuthAdapter struct {
 	inner *jellyfin.AuthFlow
@@ -78,3 +95,15 @@ func (a *jellyfinAuthAdapter) Run(ctx context.Context, serverURL string) (*AuthR
 		Username: result.Username,
 	}, nil
 }
+
+func (a *jellyfinAuthAdapter) Login(ctx context.Context, serverURL, username, password string) (*AuthResult, error) {
+	result, err := a.inner.Authenticate(ctx, serverURL, username, password)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthResult{
+		Token:    result.Token,
+		UserID:   result.UserID,
+		Username: result.Username,
+	}, nil
+}
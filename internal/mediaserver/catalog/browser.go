@@ -0,0 +1,305 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// errNoPlaylists is returned by playlist edits: a catalog has no playlists
+var errNoPlaylists = fmt.Errorf("%w: this source has no playlists", domain.ErrForbidden)
+
+// ScanFunc lists a source's libraries
+type ScanFunc func(ctx context.Context) ([]Library, error)
+
+// Browser serves a source's catalog. It implements mediaserver.MediaSource:
+// GetLibraries rescans, the other calls read the last scan (scanning first
+// if there is none). Watch state lives in a file on this machine, keyed by
+// item ID.
+type Browser struct {
+	scan      ScanFunc
+	statePath string
+	logger    *slog.Logger
+
+	mu      sync.Mutex
+	catalog *Catalog // Last scan; nil until the first
+	played  map[string]bool
+}
+
+// NewBrowser returns a browser over scan, keeping watch state at
+// statePath
+func NewBrowser(scan ScanFunc, statePath string, logger *slog.Logger) *Browser {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	b := &Browser{scan: scan, statePath: statePath, logger: logger, played: make(map[string]bool)}
+	if data, err := os.ReadFile(statePath); err == nil {
+		if err := json.Unmarshal(data, &b.played); err != nil {
+			logger.Warn("ignoring unreadable watch state", "path", statePath, "error", err)
+		}
+	}
+	return b
+}
+
+// GetLibraries rescans the source, so a refresh picks up added files
+func (b *Browser) GetLibraries(ctx context.Context) ([]domain.Library, error) {
+	libraries, err := b.scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrServerOffline, err)
+	}
+	cat := Build(libraries)
+	b.mu.Lock()
+	b.catalog = cat
+	b.mu.Unlock()
+	return cat.Libraries(), nil
+}
+
+// current returns the last scan, scanning if there is none
+func (b *Browser) current(ctx context.Context) (*Catalog, error) {
+	b.mu.Lock()
+	cat := b.catalog
+	b.mu.Unlock()
+	if cat != nil {
+		return cat, nil
+	}
+	if _, err := b.GetLibraries(ctx); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.catalog, nil
+}
+
+func (b *Browser) GetMovies(ctx context.Context, libID string, offset, limit int) ([]*domain.MediaItem, int, error) {
+	cat, err := b.current(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	all := cat.movies[libID]
+	return b.items(page(all, offset, limit)), len(all), nil
+}
+
+func (b *Browser) GetShows(ctx context.Context, libID string, offset, limit int) ([]*domain.Show, int, error) {
+	cat, err := b.current(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	all := cat.shows[libID]
+	return b.shows(cat, page(all, offset, limit)), len(all), nil
+}
+
+func (b *Browser) GetMixedContent(ctx context.Context, libID string, offset, limit int) ([]domain.ListItem, int, error) {
+	cat, err := b.current(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	var all []domain.ListItem
+	for _, m := range b.items(cat.movies[libID]) {
+		all = append(all, m)
+	}
+	for _, s := range b.shows(cat, cat.shows[libID]) {
+		all = append(all, s)
+	}
+	return page(all, offset, limit), len(all), nil
+}
+
+func (b *Browser) GetSeasons(ctx context.Context, showID string) ([]*domain.Season, error) {
+	cat, err := b.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seasons, ok := cat.seasons[showID]
+	if !ok {
+		return nil, fmt.Errorf("%w: show %s", domain.ErrItemNotFound, showID)
+	}
+	out := make([]*domain.Season, len(seasons))
+	for i, s := range seasons {
+		season := *s
+		season.UnwatchedCount = b.unwatched(cat.episodes[s.ID])
+		out[i] = &season
+	}
+	return out, nil
+}
+
+func (b *Browser) GetEpisodes(ctx context.Context, seasonID string) ([]*domain.MediaItem, error) {
+	cat, err := b.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	episodes, ok := cat.episodes[seasonID]
+	if !ok {
+		return nil, fmt.Errorf("%w: season %s", domain.ErrItemNotFound, seasonID)
+	}
+	return b.items(episodes), nil
+}
+
+func (b *Browser) GetLibraryItemCount(ctx context.Context, libID, libType string) (int, error) {
+	cat, err := b.current(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(cat.movies[libID]) + len(cat.shows[libID]), nil
+}
+
+// ResolvePlayableURL returns the video's locator, which players open
+// directly
+func (b *Browser) ResolvePlayableURL(ctx context.Context, itemID string) (string, error) {
+	cat, err := b.current(ctx)
+	if err != nil {
+		return "", err
+	}
+	locator, ok := cat.locators[itemID]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", domain.ErrItemNotFound, itemID)
+	}
+	return locator, nil
+}
+
+func (b *Browser) MarkPlayed(ctx context.Context, itemID string) error {
+	return b.setPlayed(itemID, true)
+}
+
+func (b *Browser) MarkUnplayed(ctx context.Context, itemID string) error {
+	return b.setPlayed(itemID, false)
+}
+
+// setPlayed records an item's watch state and saves it
+func (b *Browser) setPlayed(itemID string, played bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if played {
+		b.played[itemID] = true
+	} else {
+		delete(b.played, itemID)
+	}
+	data, err := json.Marshal(b.played)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(b.statePath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(b.statePath, data, 0o600)
+}
+
+// Search matches movie and episode titles, and episodes by show title
+func (b *Browser) Search(ctx context.Context, query string) ([]*domain.MediaItem, error) {
+	cat, err := b.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := strings.ToLower(query)
+	var found []*domain.MediaItem
+	match := func(items []*domain.MediaItem) {
+		for _, item := range items {
+			if strings.Contains(strings.ToLower(item.Title), q) || strings.Contains(strings.ToLower(item.ShowTitle), q) {
+				found = append(found, item)
+			}
+		}
+	}
+	for _, lib := range cat.libraries {
+		match(cat.movies[lib.ID])
+	}
+	for _, episodes := range cat.episodes {
+		match(episodes)
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].SortTitle != found[j].SortTitle {
+			return found[i].SortTitle < found[j].SortTitle
+		}
+		if found[i].SeasonNum != found[j].SeasonNum {
+			return found[i].SeasonNum < found[j].SeasonNum
+		}
+		return found[i].EpisodeNum < found[j].EpisodeNum
+	})
+	return b.items(found), nil
+}
+
+// GetPlaylists lists none: playlists need a media server
+func (b *Browser) GetPlaylists(ctx context.Context) ([]*domain.Playlist, error) {
+	return nil, nil
+}
+
+func (b *Browser) GetPlaylistItems(ctx context.Context, playlistID string) ([]*domain.MediaItem, error) {
+	return nil, fmt.Errorf("%w: playlist %s", domain.ErrItemNotFound, playlistID)
+}
+
+func (b *Browser) CreatePlaylist(ctx context.Context, title string, itemIDs []string) (*domain.Playlist, error) {
+	return nil, errNoPlaylists
+}
+
+func (b *Browser) AddToPlaylist(ctx context.Context, playlistID string, itemIDs []string) error {
+	return errNoPlaylists
+}
+
+func (b *Browser) RemoveFromPlaylist(ctx context.Context, playlistID string, itemID string) error {
+	return errNoPlaylists
+}
+
+func (b *Browser) DeletePlaylist(ctx context.Context, playlistID string) error {
+	return errNoPlaylists
+}
+
+func (b *Browser) UpdatePlaylist(ctx context.Context, playlistID, title, description string) error {
+	return errNoPlaylists
+}
+
+// items copies movies or episodes with their watch state
+func (b *Browser) items(templates []*domain.MediaItem) []*domain.MediaItem {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make([]*domain.MediaItem, len(templates))
+	for i, t := range templates {
+		item := *t
+		item.IsPlayed = b.played[item.ID]
+		out[i] = &item
+	}
+	return out
+}
+
+// shows copies shows with their unwatched episode counts
+func (b *Browser) shows(cat *Catalog, templates []*domain.Show) []*domain.Show {
+	out := make([]*domain.Show, len(templates))
+	for i, t := range templates {
+		show := *t
+		show.UnwatchedCount = 0
+		for _, season := range cat.seasons[show.ID] {
+			show.UnwatchedCount += b.unwatched(cat.episodes[season.ID])
+		}
+		out[i] = &show
+	}
+	return out
+}
+
+// unwatched counts the episodes not marked played
+func (b *Browser) unwatched(episodes []*domain.MediaItem) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := 0
+	for _, e := range episodes {
+		if !b.played[e.ID] {
+			n++
+		}
+	}
+	return n
+}
+
+// page returns items [offset, offset+limit); limit <= 0 reads to the end
+func page[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	end := len(items)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return items[offset:end]
+}
@@ -0,0 +1,208 @@
+// Package catalog serves sources that list bare video files rather than a
+// media server's metadata: a local folder, a DLNA share. The source lists
+// its videos with the folders they sit in; Build reads titles, years and
+// episode codes from the names and groups episodes into shows and seasons,
+// and Browser serves the result as a mediaserver.MediaSource with the
+// watch state kept on this machine.
+package catalog
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// Video is one file a source lists
+type Video struct {
+	Locator   string    // What the player opens: a path or a URL. Unique per source.
+	Folders   []string  // Folder names between the library and the file
+	Name      string    // File name without extension
+	ModTime   time.Time // Zero if unknown
+	Size      int64
+	Container string        // "mkv", "mp4"...
+	Duration  time.Duration // 0 if unknown
+}
+
+// Library is a named group of videos. Key identifies it within the source
+// and must be stable across scans.
+type Library struct {
+	Key    string
+	Name   string
+	Videos []Video
+}
+
+// Catalog is one scan of a source. Its items are templates: Browser copies
+// them and applies the watch state.
+type Catalog struct {
+	libraries []domain.Library
+	movies    map[string][]*domain.MediaItem // By library ID
+	shows     map[string][]*domain.Show      // By library ID
+	seasons   map[string][]*domain.Season    // By show ID
+	episodes  map[string][]*domain.MediaItem // By season ID
+	locators  map[string]string              // By item ID
+}
+
+// Build catalogs the libraries, skipping those without videos. Each video
+// with an episode code is an episode and the rest are movies, so a library
+// holding both is "mixed".
+func Build(libraries []Library) *Catalog {
+	cat := &Catalog{
+		movies:   make(map[string][]*domain.MediaItem),
+		shows:    make(map[string][]*domain.Show),
+		seasons:  make(map[string][]*domain.Season),
+		episodes: make(map[string][]*domain.MediaItem),
+		locators: make(map[string]string),
+	}
+	for _, l := range libraries {
+		if len(l.Videos) > 0 {
+			cat.add(l)
+		}
+	}
+	return cat
+}
+
+// Libraries lists the catalog's libraries
+func (cat *Catalog) Libraries() []domain.Library {
+	return append([]domain.Library(nil), cat.libraries...)
+}
+
+func (cat *Catalog) add(l Library) {
+	lib := domain.Library{ID: hashID("lib", l.Key), Name: l.Name}
+	var movies []Video
+	shows := make(map[string]*domain.Show)
+	seasons := make(map[string]*domain.Season)
+	for _, v := range l.Videos {
+		lib.UpdatedAt = max(lib.UpdatedAt, v.ModTime.Unix())
+		ep, ok := parseEpisode(v.Name)
+		title, year := showTitle(v, ep)
+		if !ok || title == "" {
+			movies = append(movies, v)
+			continue
+		}
+
+		showID := hashID("show", lib.ID, strings.ToLower(title))
+		show, ok := shows[showID]
+		if !ok {
+			show = &domain.Show{ID: showID, Title: title, SortTitle: sortTitle(title), LibraryID: lib.ID, Year: year}
+			shows[showID] = show
+		}
+		seasonID := hashID("season", showID, fmt.Sprint(ep.season))
+		season, ok := seasons[seasonID]
+		if !ok {
+			season = &domain.Season{ID: seasonID, ShowID: showID, ShowTitle: title, SeasonNum: ep.season, Title: seasonTitle(ep.season)}
+			seasons[seasonID] = season
+			cat.seasons[showID] = append(cat.seasons[showID], season)
+			show.SeasonCount++
+		}
+
+		e := cat.item(v, lib.ID)
+		e.Type = domain.MediaTypeEpisode
+		e.Title = ep.title
+		if e.Title == "" {
+			e.Title = fmt.Sprintf("Episode %d", ep.episode)
+		}
+		e.SortTitle = sortTitle(title)
+		e.ShowTitle, e.ShowID, e.ParentID = title, showID, seasonID
+		e.SeasonNum, e.EpisodeNum = ep.season, ep.episode
+		cat.episodes[seasonID] = append(cat.episodes[seasonID], e)
+
+		season.EpisodeCount++
+		show.EpisodeCount++
+		season.LastAddedAt = max(season.LastAddedAt, e.AddedAt)
+		show.LastAddedAt = max(show.LastAddedAt, e.AddedAt)
+		show.AddedAt = max(show.AddedAt, e.AddedAt)
+		show.UpdatedAt = show.LastAddedAt
+	}
+
+	for _, v := range movies {
+		title, year := parseTitle(v.Name)
+		if year == 0 && len(v.Folders) > 0 {
+			// "Heat (1995)/heat.mkv": the folder knows better
+			if t, y := parseTitle(v.Folders[len(v.Folders)-1]); y != 0 {
+				title, year = t, y
+			}
+		}
+		m := cat.item(v, lib.ID)
+		m.Type = domain.MediaTypeMovie
+		m.Title, m.SortTitle, m.Year = title, sortTitle(title), year
+		cat.movies[lib.ID] = append(cat.movies[lib.ID], m)
+	}
+	sort.SliceStable(cat.movies[lib.ID], func(i, j int) bool {
+		return cat.movies[lib.ID][i].SortTitle < cat.movies[lib.ID][j].SortTitle
+	})
+
+	list := make([]*domain.Show, 0, len(shows))
+	for _, show := range shows {
+		list = append(list, show)
+		s := cat.seasons[show.ID]
+		sort.Slice(s, func(i, j int) bool { return s[i].SeasonNum < s[j].SeasonNum })
+		for _, season := range s {
+			e := cat.episodes[season.ID]
+			sort.SliceStable(e, func(i, j int) bool { return e[i].EpisodeNum < e[j].EpisodeNum })
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].SortTitle < list[j].SortTitle })
+	cat.shows[lib.ID] = list
+
+	switch {
+	case len(list) == 0:
+		lib.Type = "movie"
+	case len(movies) == 0:
+		lib.Type = "show"
+	default:
+		lib.Type = "mixed"
+	}
+	cat.libraries = append(cat.libraries, lib)
+}
+
+// showTitle names an episode's show: the nearest folder above it that
+// isn't a season folder, unless the file name names a different show
+func showTitle(v Video, ep episodeInfo) (string, int) {
+	fromName, nameYear := parseTitle(ep.show)
+	for i := len(v.Folders) - 1; i >= 0; i-- {
+		if _, ok := parseSeasonDir(v.Folders[i]); ok {
+			continue
+		}
+		folder, year := parseTitle(v.Folders[i])
+		if fromName == "" || strings.EqualFold(folder, fromName) {
+			return folder, year
+		}
+		break
+	}
+	return fromName, nameYear
+}
+
+// item is the part of a movie or episode its file tells
+func (cat *Catalog) item(v Video, libID string) *domain.MediaItem {
+	m := &domain.MediaItem{
+		ID:        hashID("file", v.Locator),
+		LibraryID: libID,
+		FileSize:  v.Size,
+		Container: v.Container,
+		Duration:  v.Duration,
+	}
+	if !v.ModTime.IsZero() {
+		m.AddedAt = v.ModTime.Unix()
+		m.UpdatedAt = m.AddedAt
+	}
+	cat.locators[m.ID] = v.Locator
+	return m
+}
+
+func seasonTitle(n int) string {
+	if n == 0 {
+		return "Specials"
+	}
+	return fmt.Sprintf("Season %d", n)
+}
+
+// hashID derives a stable ID from the parts naming an item
+func hashID(parts ...string) string {
+	sum := sha1.Sum([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,105 @@
+package catalog
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// videoExts are the file extensions scanned as videos
+var videoExts = map[string]bool{
+	".mkv": true, ".mp4": true, ".m4v": true, ".avi": true, ".mov": true,
+	".wmv": true, ".webm": true, ".ts": true, ".m2ts": true, ".mpg": true,
+	".mpeg": true, ".flv": true,
+}
+
+var (
+	// "Show.Name.S01E02.Title", "Show Name - s1e2"
+	seasonEpisodeRE = regexp.MustCompile(`(?i)^(.*?)[\s._-]*\bs(\d{1,2})[\s._-]*e(\d{1,3})\b(.*)$`)
+	// "Show Name 1x02 Title"
+	crossEpisodeRE = regexp.MustCompile(`(?i)^(.*?)[\s._-]*\b(\d{1,2})x(\d{2,3})\b(.*)$`)
+	// "Movie Title (2010)", "Movie.Title.2010.1080p"
+	yearRE = regexp.MustCompile(`^(.*)[\s._(\[-]+((?:19|20)\d{2})(?:[\s._)\]-].*)?$`)
+	// "Season 2", "S02", "Specials"
+	seasonDirRE = regexp.MustCompile(`(?i)^(?:season[\s._-]*(\d{1,2})|s(\d{1,2})|specials)$`)
+	// Release tags that end a title: resolution, source, codec
+	junkRE = regexp.MustCompile(`(?i)[\s._\[(-]+(?:\d{3,4}p|4k|uhd|hdr|web(?:-?dl|rip)?|blu-?ray|bdrip|brrip|dvdrip|hdtv|remux|x26[45]|h\.?26[45]|hevc|avc|aac|ac3|dts|proper|repack)\b.*$`)
+)
+
+// episodeInfo is what an episode's file name tells
+type episodeInfo struct {
+	show    string // Empty when the name starts with the episode code
+	season  int
+	episode int
+	title   string
+}
+
+// parseEpisode reads an episode code from a file name (without extension)
+func parseEpisode(name string) (episodeInfo, bool) {
+	name = strings.ReplaceAll(name, "_", " ") // Underscores defeat \b
+	m := seasonEpisodeRE.FindStringSubmatch(name)
+	if m == nil {
+		m = crossEpisodeRE.FindStringSubmatch(name)
+	}
+	if m == nil {
+		return episodeInfo{}, false
+	}
+	season, _ := strconv.Atoi(m[2])
+	episode, _ := strconv.Atoi(m[3])
+	return episodeInfo{
+		show:    cleanTitle(m[1]),
+		season:  season,
+		episode: episode,
+		title:   cleanTitle(junkRE.ReplaceAllString(m[4], "")),
+	}, true
+}
+
+// parseTitle reads a movie's (or a show folder's) title and year from its
+// name, dropping release tags
+func parseTitle(name string) (title string, year int) {
+	if m := yearRE.FindStringSubmatch(name); m != nil && strings.TrimSpace(m[1]) != "" {
+		year, _ = strconv.Atoi(m[2])
+		return cleanTitle(m[1]), year
+	}
+	return cleanTitle(junkRE.ReplaceAllString(name, "")), 0
+}
+
+// parseSeasonDir reads a season number from a folder name: "Season 2",
+// "S02"; "Specials" is season 0
+func parseSeasonDir(name string) (int, bool) {
+	m := seasonDirRE.FindStringSubmatch(name)
+	if m == nil {
+		return 0, false
+	}
+	for _, n := range m[1:] {
+		if n != "" {
+			season, _ := strconv.Atoi(n)
+			return season, true
+		}
+	}
+	return 0, true
+}
+
+// cleanTitle turns dots and underscores into spaces and trims separators
+func cleanTitle(s string) string {
+	s = strings.NewReplacer(".", " ", "_", " ").Replace(s)
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.Trim(s, " -")
+}
+
+// IsVideo reports whether a file name has a video extension
+func IsVideo(name string) bool {
+	return videoExts[strings.ToLower(filepath.Ext(name))]
+}
+
+// sortTitle is the key titles sort by: lower case, leading article dropped
+func sortTitle(title string) string {
+	t := strings.ToLower(title)
+	for _, article := range []string{"the ", "a ", "an "} {
+		if strings.HasPrefix(t, article) {
+			return t[len(article):]
+		}
+	}
+	return t
+}
@@ -0,0 +1,53 @@
+package catalog
+
+import "testing"
+
+func TestParseEpisode(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		want episodeInfo
+	}{
+		{"The.Wire.S02E05.Undertow.720p.HDTV", episodeInfo{"The Wire", 2, 5, "Undertow"}},
+		{"Show Name - s1e12", episodeInfo{"Show Name", 1, 12, ""}},
+		{"S03E01 - Pilot", episodeInfo{"", 3, 1, "Pilot"}},
+		{"Show_Name_1x04_The_Title", episodeInfo{"Show Name", 1, 4, "The Title"}},
+	} {
+		got, ok := parseEpisode(c.name)
+		if !ok || got != c.want {
+			t.Errorf("parseEpisode(%q) = %+v, %v; want %+v", c.name, got, ok, c.want)
+		}
+	}
+	if _, ok := parseEpisode("Heat.1995.1080p.BluRay"); ok {
+		t.Error("a movie parsed as an episode")
+	}
+}
+
+func TestParseTitle(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		title string
+		year  int
+	}{
+		{"Heat (1995)", "Heat", 1995},
+		{"Heat.1995.1080p.BluRay.x264-GRP", "Heat", 1995},
+		{"Blade Runner 2049 (2017)", "Blade Runner 2049", 2017},
+		{"1917.2019.2160p", "1917", 2019},
+		{"Some_Film.720p.WEB-DL", "Some Film", 0},
+	} {
+		title, year := parseTitle(c.name)
+		if title != c.title || year != c.year {
+			t.Errorf("parseTitle(%q) = %q, %d; want %q, %d", c.name, title, year, c.title, c.year)
+		}
+	}
+}
+
+func TestParseSeasonDir(t *testing.T) {
+	for name, want := range map[string]int{"Season 2": 2, "season.10": 10, "S03": 3, "Specials": 0} {
+		if got, ok := parseSeasonDir(name); !ok || got != want {
+			t.Errorf("parseSeasonDir(%q) = %d, %v; want %d", name, got, ok, want)
+		}
+	}
+	if _, ok := parseSeasonDir("The Wire"); ok {
+		t.Error("a show folder parsed as a season")
+	}
+}
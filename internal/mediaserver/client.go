@@ -1,19 +1,38 @@
 package mediaserver
 
 import (
-	"context"
 	"fmt"
 	"log/slog"
-	"time"
+	"net/http"
 
 	"github.com/mmcdole/kino/internal/config"
 	"github.com/mmcdole/kino/internal/domain"
-	"github.com/mmcdole/kino/internal/mediaserver/jellyfin"
-	"github.com/mmcdole/kino/internal/mediaserver/plex"
+	"github.com/mmcdole/kino/internal/mediaserver/httpclient"
+	"github.com/mmcdole/kino/internal/metrics"
 )
 
-// MediaSource combines all client interfaces that a media server backend must implement.
-// This is the unified interface for browsing, playback, search, and playlist operations.
+// MediaSource is the boundary between kino and a source of media: a media
+// server, or a folder on disk. A source implements the four interfaces
+// below and registers itself (see Register); the library service, player
+// and UI only ever see this interface.
+//
+// The contract, beyond the method signatures:
+//   - IDs are opaque strings, stable across runs: they key the cache and
+//     the watch state.
+//   - Paged listings (GetMovies, GetShows, GetMixedContent) take an offset
+//     and a limit, and return the library's total alongside the page; a
+//     limit of 0 or less asks for everything.
+//   - Library.UpdatedAt must change when a library's content does: the
+//     cache is reused while it stands still.
+//   - Missing items are domain.ErrItemNotFound, unreachable sources
+//     domain.ErrServerOffline, and operations a source can't do
+//     domain.ErrForbidden, all wrapped with %w.
+//   - ResolvePlayableURL returns anything the player opens: a stream URL
+//     or a file path.
+//
+// Everything else (live TV, ratings, people, chapters, SyncPlay...) is an
+// optional interface from the domain package, found by type assertion: a
+// source implements only what it supports.
 type MediaSource interface {
 	domain.LibraryClient  // Browsing: GetLibraries, GetMovies, GetShows, GetSeasons, GetEpisodes
 	domain.PlaybackClient // Playback: ResolvePlayableURL, MarkPlayed/Unplayed
@@ -21,8 +40,25 @@ type MediaSource interface {
 	domain.PlaylistClient // Playlists: GetPlaylists, CreatePlaylist, AddToPlaylist, etc.
 }
 
-// NewClient creates a new MediaSource based on the server type.
-// This factory function abstracts away the specific backend implementation.
+// Trace records the most recent API requests of every client built by
+// NewClient, whichever the backend, for the debug overlay
+var Trace = httpclient.NewTracer(100)
+
+// NewTransport builds the shared HTTP transport for a server's API, auth,
+// and detection requests, applying its TLS settings
+func NewTransport(server config.ServerConfig) (*http.Transport, error) {
+	tlsConfig, err := httpclient.TLSConfig(server.InsecureSkipVerify, server.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	return httpclient.NewTransport(httpclient.Options{
+		KeepAlivePing: server.KeepAlivePing,
+		TLS:           tlsConfig,
+	}), nil
+}
+
+// NewClient creates a new MediaSource using the source registered for the
+// configured server type.
 func NewClient(cfg *config.Config, logger *slog.Logger) (MediaSource, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("config is nil")
@@ -32,31 +68,27 @@ func NewClient(cfg *config.Config, logger *slog.Logger) (MediaSource, error) {
 		return nil, fmt.Errorf("server URL is required")
 	}
 
-	if cfg.Server.Token == "" {
-		return nil, fmt.Errorf("server token is required")
+	source, ok := Lookup(cfg.Server.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown server type: %s", cfg.Server.Type)
 	}
 
-	switch cfg.Server.Type {
-	case config.SourceTypePlex:
-		client := plex.NewClient(cfg.Server.URL, cfg.Server.Token, cfg.Server.DeviceID, logger)
-
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-
-		if err := client.FetchIdentity(ctx); err != nil {
-			logger.Warn("failed to fetch plex identity", "error", err)
-			// Non-fatal: playlist creation will fail but browsing works
-		}
-
-		return client, nil
-
-	case config.SourceTypeJellyfin:
-		if cfg.Server.UserID == "" {
-			return nil, fmt.Errorf("Jellyfin requires user ID")
-		}
-		return jellyfin.NewClient(cfg.Server.URL, cfg.Server.Token, cfg.Server.UserID, cfg.Server.DeviceID, logger), nil
+	if cfg.Server.Token == "" && !source.Capabilities.Anonymous {
+		return nil, fmt.Errorf("server token is required")
+	}
 
-	default:
-		return nil, fmt.Errorf("unknown server type: %s", cfg.Server.Type)
+	transport, err := NewTransport(cfg.Server)
+	if err != nil {
+		return nil, err
 	}
+	// One limiter per client: every sync, search and playback call shares
+	// the same budget against this server
+	limited := httpclient.Limit(httpclient.Compress(metrics.Transport(Trace.Wrap(transport))), httpclient.Limits{
+		MaxConcurrent:     cfg.Server.MaxConcurrentRequests,
+		RequestsPerSecond: cfg.Server.RequestsPerSecond,
+	})
+	// The source sees the profile auto resolved to; the config keeps auto
+	sourceCfg := *cfg
+	sourceCfg.Server.SyncProfile = resolveSyncProfile(cfg.Server, transport, logger)
+	return source.NewClient(&sourceCfg, limited, logger)
 }
@@ -30,99 +30,100 @@ type plexIdentity struct {
 	Version           string   `xml:"version,attr"`
 }
 
-// DetectServerType probes a server URL to determine if it's Plex or Jellyfin.
-// Returns the detected SourceType or an error if detection fails.
-func DetectServerType(ctx context.Context, serverURL string) (config.SourceType, error) {
+// DetectServerType probes a server URL, asking each registered source in
+// turn whether it recognizes the server. Returns the detected SourceType or
+// an error listing why every source declined.
+// transport carries the configured TLS settings (nil for stdlib defaults).
+func DetectServerType(ctx context.Context, serverURL string, transport http.RoundTripper) (config.SourceType, error) {
 	// Normalize URL (remove trailing slash)
 	serverURL = strings.TrimRight(serverURL, "/")
 
 	// Create a client with timeout
 	client := &http.Client{
-		Timeout: detectTimeout,
+		Timeout:   detectTimeout,
+		Transport: transport,
 	}
 
-	// Try Jellyfin first (/System/Info/Public is unauthenticated)
-	jellyfinType, jellyfinErr := tryJellyfin(ctx, client, serverURL)
-	if jellyfinErr == nil {
-		return jellyfinType, nil
-	}
-
-	// Try Plex (/identity is unauthenticated)
-	plexType, plexErr := tryPlex(ctx, client, serverURL)
-	if plexErr == nil {
-		return plexType, nil
+	var tried []string
+	for _, source := range Sources() {
+		err := source.Detect(ctx, client, serverURL)
+		if err == nil {
+			return source.Type, nil
+		}
+		tried = append(tried, fmt.Sprintf("%s (%v)", source.Name, err))
 	}
 
-	// Neither worked
-	return "", fmt.Errorf("could not detect server type: tried Jellyfin (%v), Plex (%v)", jellyfinErr, plexErr)
+	return "", fmt.Errorf("could not detect server type: tried %s", strings.Join(tried, ", "))
 }
 
-// tryJellyfin attempts to detect a Jellyfin server
-func tryJellyfin(ctx context.Context, client *http.Client, serverURL string) (config.SourceType, error) {
+// detectJellyfin recognizes a Jellyfin server by its public system info
+// (/System/Info/Public is unauthenticated)
+func detectJellyfin(ctx context.Context, client *http.Client, serverURL string) error {
 	url := serverURL + "/System/Info/Public"
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
+		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status: %d", resp.StatusCode)
+		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return fmt.Errorf("failed to read response: %w", err)
 	}
 
 	var info jellyfinSystemInfo
 	if err := json.Unmarshal(body, &info); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	// Check if ProductName indicates Jellyfin
 	if strings.Contains(strings.ToLower(info.ProductName), "jellyfin") {
-		return config.SourceTypeJellyfin, nil
+		return nil
 	}
 
-	return "", fmt.Errorf("not a Jellyfin server (ProductName: %s)", info.ProductName)
+	return fmt.Errorf("not a Jellyfin server (ProductName: %s)", info.ProductName)
 }
 
-// tryPlex attempts to detect a Plex server
-func tryPlex(ctx context.Context, client *http.Client, serverURL string) (config.SourceType, error) {
+// detectPlex recognizes a Plex server by its identity endpoint (/identity
+// is unauthenticated)
+func detectPlex(ctx context.Context, client *http.Client, serverURL string) error {
 	url := serverURL + "/identity"
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
+		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status: %d", resp.StatusCode)
+		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return fmt.Errorf("failed to read response: %w", err)
 	}
 
 	// Try XML parsing (Plex default)
 	var identity plexIdentity
 	if err := xml.Unmarshal(body, &identity); err == nil {
 		if identity.MachineIdentifier != "" {
-			return config.SourceTypePlex, nil
+			return nil
 		}
 	}
 
@@ -134,9 +135,9 @@ func tryPlex(ctx context.Context, client *http.Client, serverURL string) (config
 	}
 	if err := json.Unmarshal(body, &jsonIdentity); err == nil {
 		if jsonIdentity.MediaContainer.MachineIdentifier != "" {
-			return config.SourceTypePlex, nil
+			return nil
 		}
 	}
 
-	return "", fmt.Errorf("not a Plex server")
+	return fmt.Errorf("not a Plex server")
 }
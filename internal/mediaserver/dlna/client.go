@@ -0,0 +1,187 @@
+// Package dlna is a media source for DLNA/UPnP media servers: NAS shares,
+// MiniDLNA, Serviio and the DLNA side of most media servers. It browses
+// the server's ContentDirectory, takes each top-level container as a
+// library and reads titles and episode codes from the names of the videos
+// and folders below, as the local folder source does. DLNA keeps no watch
+// state, so kino keeps it in a file beside its cache.
+//
+// The configured URL is the server's device description, e.g.
+// http://nas:8200/rootDesc.xml for MiniDLNA.
+package dlna
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/kino/internal/mediaserver/catalog"
+	"github.com/mmcdole/kino/internal/mediaserver/httpclient"
+)
+
+const (
+	defaultTimeout = 60 * time.Second
+	browsePageSize = 200
+	// maxDepth bounds the walk below a library: DLNA views by genre, date
+	// or artist nest a few levels, folder views as deep as the disk
+	maxDepth = 12
+)
+
+// Client serves a DLNA server's videos as libraries. It implements
+// mediaserver.MediaSource.
+type Client struct {
+	*catalog.Browser
+
+	descURL    string
+	httpClient *http.Client
+	logger     *slog.Logger
+
+	mu      sync.Mutex
+	control string // ContentDirectory control URL, found on first use
+}
+
+// NewClient returns a client for the server described at descURL. Watch
+// state is kept in stateDir.
+func NewClient(descURL, stateDir string, logger *slog.Logger) *Client {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	c := &Client{
+		descURL:    descURL,
+		httpClient: httpclient.New(defaultTimeout, httpclient.Options{}),
+		logger:     logger,
+	}
+	sum := sha1.Sum([]byte(descURL))
+	statePath := filepath.Join(stateDir, "dlna-"+hex.EncodeToString(sum[:8])+".json")
+	c.Browser = catalog.NewBrowser(c.scan, statePath, logger)
+	return c
+}
+
+// SetTransport replaces the client's HTTP transport, keeping its timeout.
+// Used to share one tuned connection pool across the app.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// Detect reports whether descURL is the description of a UPnP media
+// server with a ContentDirectory
+func Detect(ctx context.Context, client *http.Client, descURL string) error {
+	_, _, err := describe(ctx, client, descURL)
+	return err
+}
+
+// controlURL returns the ContentDirectory control URL, reading the device
+// description the first time
+func (c *Client) controlURL(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.control != "" {
+		return c.control, nil
+	}
+	control, _, err := describe(ctx, c.httpClient, c.descURL)
+	if err != nil {
+		return "", err
+	}
+	c.control = control
+	return control, nil
+}
+
+// scan walks the server: each container at the root is a library
+func (c *Client) scan(ctx context.Context) ([]catalog.Library, error) {
+	control, err := c.controlURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	root, err := c.children(ctx, control, "0")
+	if err != nil {
+		return nil, err
+	}
+	var libraries []catalog.Library
+	for _, container := range root.Containers {
+		w := walker{client: c, control: control, seen: make(map[string]int)}
+		if err := w.walk(ctx, container.ID, nil); err != nil {
+			return nil, err
+		}
+		libraries = append(libraries, catalog.Library{Key: container.ID, Name: container.Title, Videos: w.videos})
+	}
+	return libraries, nil
+}
+
+// children lists all of a container's children, page by page
+func (c *Client) children(ctx context.Context, control, objectID string) (*didl, error) {
+	all := &didl{}
+	for start := 0; ; {
+		page, total, err := browse(ctx, c.httpClient, control, objectID, start, browsePageSize)
+		if err != nil {
+			return nil, err
+		}
+		all.Containers = append(all.Containers, page.Containers...)
+		all.Items = append(all.Items, page.Items...)
+		n := len(page.Containers) + len(page.Items)
+		start += n
+		if n == 0 || start >= total {
+			return all, nil
+		}
+	}
+}
+
+// walker collects one library's videos. Servers list a file in several
+// views (all videos, by date, by folder); seen keeps one entry per
+// resource URL, the one with the most folders above it, which is usually
+// the folder view and tells the most about the file.
+type walker struct {
+	client  *Client
+	control string
+	videos  []catalog.Video
+	seen    map[string]int // Index in videos by resource URL
+}
+
+func (w *walker) walk(ctx context.Context, objectID string, folders []string) error {
+	listing, err := w.client.children(ctx, w.control, objectID)
+	if err != nil {
+		return err
+	}
+	for _, item := range listing.Items {
+		if !strings.HasPrefix(item.Class, "object.item.videoItem") || len(item.Res) == 0 {
+			continue
+		}
+		res := item.Res[0]
+		locator := strings.TrimSpace(res.URL)
+		v := catalog.Video{
+			Locator:   locator,
+			Folders:   append([]string(nil), folders...),
+			Name:      item.Title,
+			ModTime:   parseDate(item.Date),
+			Size:      res.Size,
+			Container: res.container(),
+			Duration:  parseDuration(res.Duration),
+		}
+		// Some servers title items by file name
+		if ext := path.Ext(v.Name); catalog.IsVideo(v.Name) {
+			v.Name = strings.TrimSuffix(v.Name, ext)
+		}
+		if i, ok := w.seen[locator]; ok {
+			if len(folders) > len(w.videos[i].Folders) {
+				w.videos[i] = v
+			}
+			continue
+		}
+		w.seen[locator] = len(w.videos)
+		w.videos = append(w.videos, v)
+	}
+	if len(folders) >= maxDepth {
+		return nil
+	}
+	for _, container := range listing.Containers {
+		if err := w.walk(ctx, container.ID, append(folders, container.Title)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package dlna
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+const description = `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+  <device>
+    <friendlyName>NAS</friendlyName>
+    <serviceList>
+      <service>
+        <serviceType>urn:schemas-upnp-org:service:ConnectionManager:1</serviceType>
+        <controlURL>/ctl/ConnectionMgr</controlURL>
+      </service>
+      <service>
+        <serviceType>urn:schemas-upnp-org:service:ContentDirectory:1</serviceType>
+        <controlURL>/ctl/ContentDir</controlURL>
+      </service>
+    </serviceList>
+  </device>
+</root>`
+
+func container(id, title string) string {
+	return fmt.Sprintf(`<container id="%s"><dc:title>%s</dc:title><upnp:class>object.container.storageFolder</upnp:class></container>`, id, title)
+}
+
+func video(srv, title, file string) string {
+	return fmt.Sprintf(`<item id="%s"><dc:title>%s</dc:title><dc:date>2024-03-05T10:00:00</dc:date><upnp:class>object.item.videoItem</upnp:class>`+
+		`<res size="1000" duration="0:58:30.000" protocolInfo="http-get:*:video/x-matroska:*">%s/media/%s</res></item>`, file, title, srv, file)
+}
+
+// fakeServer answers Browse over a MiniDLNA-like tree, where every video
+// shows up in both the flat "All Video" view and the folder view
+func fakeServer(t *testing.T) *httptest.Server {
+	var srv *httptest.Server
+	objectRE := regexp.MustCompile(`<ObjectID>(.*?)</ObjectID>`)
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rootDesc.xml" {
+			io.WriteString(w, description)
+			return
+		}
+		if r.URL.Path != "/ctl/ContentDir" || !strings.Contains(r.Header.Get("SOAPACTION"), "#Browse") {
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		m := objectRE.FindSubmatch(body)
+		if m == nil {
+			t.Errorf("browse without ObjectID: %s", body)
+			return
+		}
+		children := map[string][]string{
+			"0":    {container("1", "Video"), container("2", "Music")},
+			"1":    {container("11", "All Video"), container("12", "Browse Folders")},
+			"11":   {video(srv.URL, "The.Wire.S01E01.The.Target", "wire1.mkv"), video(srv.URL, "Heat.1995.1080p", "heat.mkv")},
+			"12":   {container("121", "TV"), container("122", "Movies")},
+			"121":  {container("1211", "The Wire")},
+			"1211": {container("12111", "Season 1")},
+			"12111": {
+				video(srv.URL, "The.Wire.S01E01.The.Target", "wire1.mkv"),
+				video(srv.URL, "The.Wire.S01E02.The.Detail.mkv", "wire2.mkv"),
+			},
+			"122": {video(srv.URL, "Heat.1995.1080p", "heat.mkv")},
+		}[string(m[1])]
+		result := `<DIDL-Lite xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/">` +
+			strings.Join(children, "") + `</DIDL-Lite>`
+		fmt.Fprintf(w, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body>`+
+			`<u:BrowseResponse xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1"><Result>%s</Result>`+
+			`<NumberReturned>%d</NumberReturned><TotalMatches>%d</TotalMatches></u:BrowseResponse></s:Body></s:Envelope>`,
+			html.EscapeString(result), len(children), len(children))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDetect(t *testing.T) {
+	srv := fakeServer(t)
+	if err := Detect(context.Background(), srv.Client(), srv.URL+"/rootDesc.xml"); err != nil {
+		t.Errorf("Detect = %v", err)
+	}
+	if err := Detect(context.Background(), srv.Client(), srv.URL+"/identity"); err == nil {
+		t.Error("detected a server without a description")
+	}
+}
+
+func TestClientBrowsesFolderView(t *testing.T) {
+	ctx := context.Background()
+	srv := fakeServer(t)
+	c := NewClient(srv.URL+"/rootDesc.xml", t.TempDir(), nil)
+
+	libs, err := c.GetLibraries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Music holds no videos
+	if len(libs) != 1 || libs[0].Name != "Video" || libs[0].Type != "mixed" {
+		t.Fatalf("libraries = %+v", libs)
+	}
+
+	movies, total, err := c.GetMovies(ctx, libs[0].ID, 0, 0)
+	if err != nil || total != 1 || movies[0].Title != "Heat" || movies[0].Year != 1995 {
+		t.Fatalf("GetMovies = %+v, %d, %v", movies, total, err)
+	}
+	if movies[0].Duration != 58*time.Minute+30*time.Second || movies[0].Container != "mkv" || movies[0].FileSize != 1000 {
+		t.Errorf("movie details = %+v", movies[0])
+	}
+
+	shows, _, err := c.GetShows(ctx, libs[0].ID, 0, 0)
+	if err != nil || len(shows) != 1 || shows[0].Title != "The Wire" || shows[0].EpisodeCount != 2 {
+		t.Fatalf("GetShows = %+v, %v", shows, err)
+	}
+	seasons, _ := c.GetSeasons(ctx, shows[0].ID)
+	episodes, err := c.GetEpisodes(ctx, seasons[0].ID)
+	if err != nil || len(episodes) != 2 || episodes[1].Title != "The Detail" {
+		t.Fatalf("GetEpisodes = %+v, %v", episodes, err)
+	}
+	url, err := c.ResolvePlayableURL(ctx, episodes[0].ID)
+	if err != nil || url != srv.URL+"/media/wire1.mkv" {
+		t.Errorf("ResolvePlayableURL = %q, %v", url, err)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"1:02:03":     time.Hour + 2*time.Minute + 3*time.Second,
+		"0:00:01.500": 1500 * time.Millisecond,
+		"":            0,
+	} {
+		if got := parseDuration(in); got != want {
+			t.Errorf("parseDuration(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
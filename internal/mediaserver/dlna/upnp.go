@@ -0,0 +1,216 @@
+package dlna
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const contentDirectory = "urn:schemas-upnp-org:service:ContentDirectory:"
+
+// deviceDescription is the part of a UPnP device description kino reads
+type deviceDescription struct {
+	URLBase string `xml:"URLBase"`
+	Device  device `xml:"device"`
+}
+
+type device struct {
+	FriendlyName string    `xml:"friendlyName"`
+	Services     []service `xml:"serviceList>service"`
+	Devices      []device  `xml:"deviceList>device"`
+}
+
+type service struct {
+	ServiceType string `xml:"serviceType"`
+	ControlURL  string `xml:"controlURL"`
+}
+
+// contentDirectoryURL finds the ContentDirectory service, searching
+// embedded devices too
+func (d device) contentDirectoryURL() string {
+	for _, s := range d.Services {
+		if strings.HasPrefix(s.ServiceType, contentDirectory) {
+			return s.ControlURL
+		}
+	}
+	for _, sub := range d.Devices {
+		if u := sub.contentDirectoryURL(); u != "" {
+			return u
+		}
+	}
+	return ""
+}
+
+// describe fetches the device description at descURL and returns the
+// absolute ContentDirectory control URL and the server's name
+func describe(ctx context.Context, client *http.Client, descURL string) (control, name string, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, descURL, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+
+	var desc deviceDescription
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&desc); err != nil {
+		return "", "", fmt.Errorf("not a UPnP device description")
+	}
+	rel := desc.Device.contentDirectoryURL()
+	if rel == "" {
+		return "", "", fmt.Errorf("no ContentDirectory service")
+	}
+	base := descURL
+	if desc.URLBase != "" {
+		base = desc.URLBase
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", "", err
+	}
+	relURL, err := url.Parse(rel)
+	if err != nil {
+		return "", "", err
+	}
+	return baseURL.ResolveReference(relURL).String(), desc.Device.FriendlyName, nil
+}
+
+// browseResponse is a ContentDirectory Browse result. Result holds a
+// DIDL-Lite document.
+type browseResponse struct {
+	Body struct {
+		Browse struct {
+			Result         string `xml:"Result"`
+			NumberReturned int    `xml:"NumberReturned"`
+			TotalMatches   int    `xml:"TotalMatches"`
+		} `xml:"BrowseResponse"`
+		Fault *struct {
+			String string `xml:"faultstring"`
+		} `xml:"Fault"`
+	} `xml:"Body"`
+}
+
+// didl is a DIDL-Lite listing
+type didl struct {
+	Containers []object `xml:"container"`
+	Items      []object `xml:"item"`
+}
+
+type object struct {
+	ID    string     `xml:"id,attr"`
+	Title string     `xml:"title"`
+	Class string     `xml:"class"`
+	Date  string     `xml:"date"`
+	Res   []resource `xml:"res"`
+}
+
+type resource struct {
+	URL          string `xml:",chardata"`
+	ProtocolInfo string `xml:"protocolInfo,attr"`
+	Size         int64  `xml:"size,attr"`
+	Duration     string `xml:"duration,attr"`
+}
+
+// browse lists one page of a container's children
+func browse(ctx context.Context, client *http.Client, control, objectID string, start, count int) (*didl, int, error) {
+	body := `<?xml version="1.0" encoding="utf-8"?>` +
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">` +
+		`<s:Body><u:Browse xmlns:u="` + contentDirectory + `1">` +
+		`<ObjectID>` + html.EscapeString(objectID) + `</ObjectID>` +
+		`<BrowseFlag>BrowseDirectChildren</BrowseFlag><Filter>*</Filter>` +
+		`<StartingIndex>` + strconv.Itoa(start) + `</StartingIndex>` +
+		`<RequestedCount>` + strconv.Itoa(count) + `</RequestedCount>` +
+		`<SortCriteria></SortCriteria></u:Browse></s:Body></s:Envelope>`
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, control, bytes.NewBufferString(body))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	req.Header.Set("SOAPACTION", `"`+contentDirectory+`1#Browse"`)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var env browseResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode browse response: %w", err)
+	}
+	if env.Body.Fault != nil {
+		return nil, 0, fmt.Errorf("browse %s failed: %s", objectID, env.Body.Fault.String)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+
+	var listing didl
+	if err := xml.Unmarshal([]byte(env.Body.Browse.Result), &listing); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode DIDL-Lite: %w", err)
+	}
+	return &listing, env.Body.Browse.TotalMatches, nil
+}
+
+// parseDuration reads a res duration, "H:MM:SS" with optional fraction
+func parseDuration(s string) time.Duration {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0
+	}
+	h, err1 := strconv.Atoi(parts[0])
+	m, err2 := strconv.Atoi(parts[1])
+	sec, err3 := strconv.ParseFloat(parts[2], 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec*float64(time.Second))
+}
+
+// parseDate reads a dc:date, a date or a full timestamp
+func parseDate(s string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// mimeContainers maps video MIME types to container names
+var mimeContainers = map[string]string{
+	"video/x-matroska": "mkv",
+	"video/mp4":        "mp4",
+	"video/x-m4v":      "m4v",
+	"video/x-msvideo":  "avi",
+	"video/avi":        "avi",
+	"video/quicktime":  "mov",
+	"video/x-ms-wmv":   "wmv",
+	"video/webm":       "webm",
+	"video/mpeg":       "mpeg",
+	"video/mp2t":       "ts",
+	"video/x-flv":      "flv",
+}
+
+// container names a resource's container from its protocolInfo
+// ("http-get:*:video/x-matroska:*")
+func (r resource) container() string {
+	parts := strings.Split(r.ProtocolInfo, ":")
+	if len(parts) < 3 {
+		return ""
+	}
+	return mimeContainers[strings.ToLower(parts[2])]
+}
@@ -0,0 +1,98 @@
+package httpclient
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Rate limit backoff bounds. A 429 without Retry-After pauses for
+// minRateLimitPause, doubling with each one after it; a server asking for
+// more than maxRateLimitPause fails the request instead of stalling the UI.
+const (
+	minRateLimitPause = 1 * time.Second
+	maxRateLimitPause = 60 * time.Second
+)
+
+// Backoff holds back requests to a server that has rate-limited one (429).
+// One is shared by everything talking to the server, so concurrent library
+// syncs pause together instead of each running into the limit in turn. The
+// zero value is ready to use.
+type Backoff struct {
+	mu      sync.Mutex
+	until   time.Time     // no requests before this
+	pause   time.Duration // length of the current pause, for jitter
+	strikes int           // 429s since a request last got through
+}
+
+// Wait blocks until requests may go out again, or ctx ends. Waiters are
+// spread over the first quarter of the pause after it ends, so they don't
+// all retry at the same instant.
+func (b *Backoff) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	wait := time.Until(b.until)
+	pause := b.pause
+	b.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	if spread := int64(pause / 4); spread > 0 {
+		wait += time.Duration(rand.Int64N(spread))
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RateLimited records a 429 response and returns the pause it starts: the
+// server's Retry-After, else a doubling delay. ok is false when the server
+// asks for longer than maxRateLimitPause.
+func (b *Backoff) RateLimited(h http.Header) (pause time.Duration, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	pause, found := RetryAfter(h, time.Now())
+	if !found {
+		pause = minRateLimitPause << min(b.strikes, 5)
+	}
+	b.strikes++
+	if pause > maxRateLimitPause {
+		return pause, false
+	}
+	if until := time.Now().Add(pause); until.After(b.until) {
+		b.until = until
+		b.pause = pause
+	}
+	return pause, true
+}
+
+// Succeeded records a request that got through, resetting the doubling
+func (b *Backoff) Succeeded() {
+	b.mu.Lock()
+	b.strikes = 0
+	b.mu.Unlock()
+}
+
+// RetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date
+func RetryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return max(time.Duration(secs)*time.Second, 0), true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
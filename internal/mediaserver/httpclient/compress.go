@@ -0,0 +1,97 @@
+package httpclient
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding is advertised on every request. The stdlib transport only
+// negotiates gzip on its own; some servers and proxies compress with
+// deflate only, leaving sync responses uncompressed.
+const acceptEncoding = "gzip, deflate"
+
+// Compress wraps rt so requests ask for gzip or deflate responses and
+// bodies arrive decoded. Requests that set their own Accept-Encoding are
+// passed through untouched.
+func Compress(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &compressTransport{rt: rt}
+}
+
+// compressTransport is the RoundTripper returned by Compress
+type compressTransport struct {
+	rt http.RoundTripper
+}
+
+// RoundTrip requests a compressed response and decodes it
+func (c *compressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") != "" || req.Method == http.MethodHead {
+		return c.rt.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+
+	resp, err := c.rt.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+
+	var body io.ReadCloser
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip":
+		body = &lazyReader{raw: resp.Body, open: func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }}
+	case "deflate":
+		body = &lazyReader{raw: resp.Body, open: openDeflate}
+	default:
+		return resp, nil
+	}
+	resp.Body = body
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// openDeflate decodes "deflate" bodies. The spec says zlib-wrapped, but
+// some servers send raw deflate; the zlib header tells them apart.
+func openDeflate(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err == nil && len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(br)
+	}
+	return flate.NewReader(br), nil
+}
+
+// lazyReader opens the decoder on first read so header errors surface from
+// Read, where callers already handle body errors
+type lazyReader struct {
+	raw     io.ReadCloser
+	open    func(io.Reader) (io.Reader, error)
+	decoded io.Reader
+	err     error
+}
+
+// Read decodes the body
+func (l *lazyReader) Read(p []byte) (int, error) {
+	if l.decoded == nil && l.err == nil {
+		l.decoded, l.err = l.open(l.raw)
+	}
+	if l.err != nil {
+		return 0, l.err
+	}
+	return l.decoded.Read(p)
+}
+
+// Close closes the underlying body
+func (l *lazyReader) Close() error {
+	return l.raw.Close()
+}
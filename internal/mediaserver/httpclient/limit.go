@@ -0,0 +1,139 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Limits caps the load a client puts on one server. Parallel library syncs
+// each page through their library concurrently; small servers (Jellyfin on
+// a NAS in particular) answer bursts like that with 500s.
+type Limits struct {
+	// MaxConcurrent bounds in-flight requests, counting a response until
+	// its body is closed; 0 = unlimited
+	MaxConcurrent int
+
+	// RequestsPerSecond paces request starts; 0 = unlimited. Up to one
+	// second's worth of requests may start back to back after an idle spell.
+	RequestsPerSecond float64
+}
+
+// Limit wraps rt so requests through it respect the limits. Waiting
+// requests give up when their context ends. Returns rt unchanged when no
+// limit is set.
+func Limit(rt http.RoundTripper, limits Limits) http.RoundTripper {
+	if limits.MaxConcurrent <= 0 && limits.RequestsPerSecond <= 0 {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	l := &limitedTransport{rt: rt}
+	if limits.MaxConcurrent > 0 {
+		l.slots = make(chan struct{}, limits.MaxConcurrent)
+	}
+	if limits.RequestsPerSecond > 0 {
+		l.bucket = newTokenBucket(limits.RequestsPerSecond)
+	}
+	return l
+}
+
+// limitedTransport is the RoundTripper returned by Limit
+type limitedTransport struct {
+	rt     http.RoundTripper
+	slots  chan struct{} // nil = no concurrency limit
+	bucket *tokenBucket  // nil = no rate limit
+}
+
+// RoundTrip waits for a slot and a rate token, then sends the request. The
+// slot is held until the response body is closed.
+func (l *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if l.bucket != nil {
+		if wait := l.bucket.reserve(time.Now()); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	resp, err := l.rt.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseOnClose frees the request's slot when its body is closed
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Close closes the body and releases the slot once
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// tokenBucket paces requests at a steady rate with a one-second burst
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64 // tokens per second
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+// newTokenBucket returns a full bucket refilling at rate tokens per second
+func newTokenBucket(rate float64) *tokenBucket {
+	capacity := rate
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &tokenBucket{rate: rate, capacity: capacity, tokens: capacity}
+}
+
+// reserve takes a token and returns how long the caller must wait before
+// using it. Tokens may go negative: each waiter queues behind the last.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
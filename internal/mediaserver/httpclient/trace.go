@@ -0,0 +1,119 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Request is one traced API request
+type Request struct {
+	Start    time.Time
+	Method   string
+	Path     string // URL path only: queries carry tokens
+	Status   int    // 0 when the request failed before a response
+	Duration time.Duration
+	Bytes    int64 // Response body bytes as received (before decompression)
+	Err      string
+}
+
+// Tracer keeps the most recent requests made through transports it wraps.
+// Safe for concurrent use; one Tracer can be shared by several clients.
+type Tracer struct {
+	mu   sync.Mutex
+	ring []Request
+	next int
+	full bool
+}
+
+// NewTracer returns a Tracer that keeps the last size requests
+func NewTracer(size int) *Tracer {
+	return &Tracer{ring: make([]Request, max(size, 1))}
+}
+
+// Wrap returns rt with every request recorded. A request is recorded once
+// its response body is closed, so Duration and Bytes cover the whole body.
+func (t *Tracer) Wrap(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &traceTransport{rt: rt, tracer: t}
+}
+
+// Recent returns the recorded requests, newest first
+func (t *Tracer) Recent() []Request {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := t.next
+	if t.full {
+		n = len(t.ring)
+	}
+	out := make([]Request, 0, n)
+	for i := 1; i <= n; i++ {
+		out = append(out, t.ring[(t.next-i+len(t.ring))%len(t.ring)])
+	}
+	return out
+}
+
+// record adds a finished request, dropping the oldest when full
+func (t *Tracer) record(r Request) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.ring[t.next] = r
+	t.next = (t.next + 1) % len(t.ring)
+	if t.next == 0 {
+		t.full = true
+	}
+}
+
+// traceTransport is the RoundTripper returned by Tracer.Wrap
+type traceTransport struct {
+	rt     http.RoundTripper
+	tracer *Tracer
+}
+
+// RoundTrip sends the request and arranges for it to be recorded
+func (tt *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	entry := Request{Start: time.Now(), Method: req.Method, Path: req.URL.Path}
+	resp, err := tt.rt.RoundTrip(req)
+	if err != nil {
+		entry.Duration = time.Since(entry.Start)
+		entry.Err = err.Error()
+		tt.tracer.record(entry)
+		return resp, err
+	}
+	entry.Status = resp.StatusCode
+	if resp.Body == nil {
+		entry.Duration = time.Since(entry.Start)
+		tt.tracer.record(entry)
+		return resp, nil
+	}
+	resp.Body = &countingBody{ReadCloser: resp.Body, entry: entry, tracer: tt.tracer}
+	return resp, nil
+}
+
+// countingBody counts body bytes and records the request on Close
+type countingBody struct {
+	io.ReadCloser
+	entry  Request
+	tracer *Tracer
+	once   sync.Once
+}
+
+// Read counts the bytes read
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.entry.Bytes += int64(n)
+	return n, err
+}
+
+// Close closes the body and records the request once
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.entry.Duration = time.Since(b.entry.Start)
+		b.tracer.record(b.entry)
+	})
+	return err
+}
@@ -0,0 +1,237 @@
+package httpclient
+
+import (
+	"compress/flate"
+	"compress/zlib"
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// A self-signed server is rejected by default and reachable with either
+// insecure_skip_verify or its certificate as a CA bundle
+func TestTLSConfigSelfSigned(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	get := func(insecure bool, caFile string) error {
+		tlsConfig, err := TLSConfig(insecure, caFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := New(5*time.Second, Options{TLS: tlsConfig}).Get(srv.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := get(false, ""); err == nil {
+		t.Fatal("self-signed certificate accepted without configuration")
+	}
+	if err := get(true, ""); err != nil {
+		t.Fatalf("insecure_skip_verify: %v", err)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := get(false, caFile); err != nil {
+		t.Fatalf("ca_file: %v", err)
+	}
+
+	if _, err := TLSConfig(false, filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Fatal("missing CA bundle not reported")
+	}
+}
+
+// Parallel requests through the limiter never exceed the in-flight cap,
+// counting each response until its body is closed
+func TestLimitCapsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: Limit(NewTransport(Options{}), Limits{MaxConcurrent: 2})}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(srv.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 2 {
+		t.Fatalf("peak in-flight requests = %d, want <= 2", got)
+	}
+}
+
+// The bucket allows a one-second burst, then spaces requests at the rate
+func TestTokenBucketPacing(t *testing.T) {
+	b := newTokenBucket(2)
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		if wait := b.reserve(now); wait != 0 {
+			t.Fatalf("burst request %d waited %v", i, wait)
+		}
+	}
+	if wait := b.reserve(now); wait != 500*time.Millisecond {
+		t.Fatalf("third request wait = %v, want 500ms", wait)
+	}
+	if wait := b.reserve(now); wait != time.Second {
+		t.Fatalf("fourth request wait = %v, want 1s", wait)
+	}
+}
+
+// Deflate responses (zlib-wrapped or raw) are requested and decoded; the
+// stdlib transport on its own only negotiates gzip
+func TestCompressDecodesDeflate(t *testing.T) {
+	const payload = `{"Items": []}`
+	raw := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != acceptEncoding {
+			t.Errorf("Accept-Encoding = %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "deflate")
+		var zw io.WriteCloser
+		if raw {
+			zw, _ = flate.NewWriter(w, flate.DefaultCompression)
+		} else {
+			zw = zlib.NewWriter(w)
+		}
+		_, _ = io.WriteString(zw, payload)
+		zw.Close()
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: Compress(NewTransport(Options{}))}
+	for _, raw = range []bool{false, true} {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil || string(body) != payload {
+			t.Fatalf("raw=%v: body %q, err %v", raw, body, err)
+		}
+	}
+}
+
+// The tracer keeps the newest requests with status and body size, never
+// the query string
+func TestTracerRecordsRecent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, "hello")
+	}))
+	defer srv.Close()
+
+	tracer := NewTracer(2)
+	client := &http.Client{Transport: tracer.Wrap(NewTransport(Options{}))}
+	for _, path := range []string{"/a?X-Plex-Token=secret", "/b", "/missing"} {
+		resp, err := client.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+
+	recent := tracer.Recent()
+	if len(recent) != 2 {
+		t.Fatalf("kept %d requests, want 2", len(recent))
+	}
+	if recent[0].Path != "/missing" || recent[0].Status != http.StatusNotFound {
+		t.Fatalf("newest = %+v", recent[0])
+	}
+	if recent[1].Path != "/b" || recent[1].Status != http.StatusOK || recent[1].Bytes != 5 {
+		t.Fatalf("older = %+v", recent[1])
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{"", 0, false},
+		{"30", 30 * time.Second, true},
+		{now.Add(2 * time.Minute).Format(http.TimeFormat), 2 * time.Minute, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	} {
+		h := http.Header{}
+		if c.header != "" {
+			h.Set("Retry-After", c.header)
+		}
+		if got, ok := RetryAfter(h, now); got != c.want || ok != c.ok {
+			t.Errorf("RetryAfter(%q) = %v, %v; want %v, %v", c.header, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+// A 429 holds back every request sharing the backoff; without Retry-After
+// the pause doubles, and a pause past the cap is refused
+func TestBackoffPausesSharedRequests(t *testing.T) {
+	var b Backoff
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("fresh backoff waited: %v", err)
+	}
+
+	pause, ok := b.RateLimited(http.Header{})
+	if !ok || pause != minRateLimitPause {
+		t.Fatalf("first pause = %v ok=%v, want %v", pause, ok, minRateLimitPause)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx); err == nil {
+		t.Fatal("request went out during the pause")
+	}
+
+	if pause, _ := b.RateLimited(http.Header{}); pause != 2*minRateLimitPause {
+		t.Fatalf("second pause = %v, want it doubled", pause)
+	}
+	b.Succeeded()
+	if pause, _ := b.RateLimited(http.Header{}); pause != minRateLimitPause {
+		t.Fatalf("pause after a success = %v, want it reset", pause)
+	}
+
+	h := http.Header{}
+	h.Set("Retry-After", "3600")
+	if _, ok := b.RateLimited(h); ok {
+		t.Fatal("an hour's pause accepted")
+	}
+}
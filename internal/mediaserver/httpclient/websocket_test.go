@@ -0,0 +1,70 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// The client completes the upgrade, reassembles fragments, answers pings
+// and masks what it sends
+func TestWebSocketRoundTrip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "websocket" {
+			http.Error(w, "not an upgrade", http.StatusBadRequest)
+			return
+		}
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+			"Sec-WebSocket-Accept: " + wsAccept(r.Header.Get("Sec-WebSocket-Key")) + "\r\n\r\n")
+		rw.Write([]byte{wsText, 3, 'h', 'e', 'l'}) // Unfinished text frame
+		rw.Write([]byte{0x80 | wsPing, 1, 'p'})
+		rw.Write([]byte{0x80 | wsContinuation, 2, 'l', 'o'})
+		rw.Flush()
+
+		// Expect the pong, then the client's message, and echo the latter
+		peer := &WebSocket{conn: conn, r: rw.Reader}
+		for {
+			_, op, payload, err := peer.readFrame()
+			if err != nil {
+				return
+			}
+			if op == wsPong && string(payload) != "p" {
+				return
+			}
+			if op == wsText {
+				rw.Write(append([]byte{0x80 | wsText, byte(len(payload))}, payload...))
+				rw.Flush()
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	ws, err := DialWebSocket(context.Background(), nil, "ws://"+strings.TrimPrefix(srv.URL, "http://"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	msg, err := ws.ReadMessage()
+	if err != nil || string(msg) != "hello" {
+		t.Fatalf("ReadMessage = %q, %v; want hello", msg, err)
+	}
+	if err := ws.WriteMessage([]byte("echo")); err != nil {
+		t.Fatal(err)
+	}
+	if msg, err = ws.ReadMessage(); err != nil || string(msg) != "echo" {
+		t.Fatalf("echo = %q, %v", msg, err)
+	}
+	if _, err := ws.ReadMessage(); err != io.EOF {
+		t.Fatalf("after server hangup: %v, want EOF", err)
+	}
+}
@@ -51,6 +51,14 @@ func NewAuthFlow(deviceID string, logger *slog.Logger) *AuthFlow {
 	}
 }
 
+// SetTransport replaces the flow's HTTP transport, keeping its timeout.
+// nil keeps the default.
+func (f *AuthFlow) SetTransport(rt http.RoundTripper) {
+	if rt != nil {
+		f.httpClient.Transport = rt
+	}
+}
+
 // Run executes the Jellyfin username/password authentication flow.
 // It prompts the user for credentials and authenticates against the server.
 func (f *AuthFlow) Run(ctx context.Context, serverURL string) (*AuthResult, error) {
@@ -0,0 +1,72 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// castActiveWithin is how recently a session must have been seen to be
+// offered; idle apps linger in /Sessions long after they close
+const castActiveWithin = 15 * time.Minute
+
+// CastTargets lists the user's other signed-in apps that take remote
+// control
+func (c *Client) CastTargets(ctx context.Context) ([]domain.CastTarget, error) {
+	query := url.Values{}
+	query.Set("ControllableByUserId", c.userID)
+	query.Set("ActiveWithinSeconds", strconv.Itoa(int(castActiveWithin.Seconds())))
+	body, err := c.doRequest(ctx, http.MethodGet, "/Sessions", query)
+	if err != nil {
+		return nil, err
+	}
+	var sessions []SessionInfo
+	if err := json.Unmarshal(body, &sessions); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	var targets []domain.CastTarget
+	for _, s := range sessions {
+		if !s.SupportsRemoteControl || s.DeviceID == c.deviceID {
+			continue
+		}
+		targets = append(targets, domain.CastTarget{ID: s.ID, Name: s.DeviceName, Product: s.Client})
+	}
+	return targets, nil
+}
+
+// Cast tells a session to play the item now
+func (c *Client) Cast(ctx context.Context, targetID, itemID string, offset time.Duration) error {
+	query := url.Values{}
+	query.Set("playCommand", "PlayNow")
+	query.Set("itemIds", itemID)
+	if offset > 0 {
+		query.Set("startPositionTicks", strconv.FormatInt(durationToTicks(offset), 10))
+	}
+	_, err := c.do(ctx, http.MethodPost, "/Sessions/"+url.PathEscape(targetID)+"/Playing", query, nil, false)
+	return err
+}
+
+// castCommands are the playstate commands for domain.CastCommand
+var castCommands = map[domain.CastCommand]string{
+	domain.CastPlayPause:   "PlayPause",
+	domain.CastRewind:      "Rewind",
+	domain.CastFastForward: "FastForward",
+	domain.CastStop:        "Stop",
+}
+
+// ControlCast sends a playstate command to a session
+func (c *Client) ControlCast(ctx context.Context, targetID string, cmd domain.CastCommand) error {
+	name, ok := castCommands[cmd]
+	if !ok {
+		return fmt.Errorf("unknown cast command %d", cmd)
+	}
+	path := "/Sessions/" + url.PathEscape(targetID) + "/Playing/" + name
+	_, err := c.do(ctx, http.MethodPost, path, nil, nil, false)
+	return err
+}
@@ -4,16 +4,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/mediaserver/httpclient"
 )
 
 const (
@@ -22,14 +27,29 @@ const (
 	baseRetryDelay = 500 * time.Millisecond
 )
 
+// Listing field sets. Series never carry media details; for movies and
+// episodes they (codec, resolution, size) are the bulk of each item, and the
+// fast sync profile leaves them out.
+const (
+	showFields     = "Overview,ChildCount,RecursiveItemCount,DateCreated,DateLastMediaAdded,ProviderIds"
+	playableFields = "Overview,DateCreated,ProviderIds"
+	mediaFields    = "MediaSources,MediaStreams"
+)
+
 // Client implements the MediaSource interface for Jellyfin
 type Client struct {
 	baseURL    string
-	token      string
+	tokenMu    sync.RWMutex
+	token      string // Replaced by SetToken after signing in again
 	userID     string
 	deviceID   string
 	httpClient *http.Client
+	backoff    httpclient.Backoff // Shared pause after a 429
 	logger     *slog.Logger
+	fastSync   bool // Listings skip media details (see SetFastSync)
+	pageSize   int  // Library listing page size; 0 = the library default (see SetPageSize)
+
+	socketTransport *http.Transport // Bare transport for SyncPlay (see SetSocketTransport)
 }
 
 // NewClient creates a new Jellyfin API client
@@ -38,22 +58,70 @@ func NewClient(baseURL, token, userID, deviceID string, logger *slog.Logger) *Cl
 		logger = slog.Default()
 	}
 	return &Client{
-		baseURL:  strings.TrimRight(baseURL, "/"),
-		token:    token,
-		userID:   userID,
-		deviceID: deviceID,
-		httpClient: &http.Client{
-			Timeout: defaultTimeout,
-		},
-		logger: logger,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		userID:     userID,
+		deviceID:   deviceID,
+		httpClient: httpclient.New(defaultTimeout, httpclient.Options{}),
+		logger:     logger,
+	}
+}
+
+// SetTransport replaces the client's HTTP transport, keeping its timeout.
+// Used to share one tuned connection pool across the app.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// SetToken replaces the token used for every later request, after the
+// user signs in again
+func (c *Client) SetToken(token string) {
+	c.tokenMu.Lock()
+	c.token = token
+	c.tokenMu.Unlock()
+}
+
+// authToken returns the current token
+func (c *Client) authToken() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.token
+}
+
+// SetFastSync trims library listings to what browsing needs, leaving out
+// media details (codecs, resolution, file size) shown in the inspector
+func (c *Client) SetFastSync(fast bool) {
+	c.fastSync = fast
+}
+
+// SetPageSize sets how many items a library listing page holds, for a
+// server where round trips cost more than bytes; 0 keeps the default
+func (c *Client) SetPageSize(n int) {
+	c.pageSize = n
+}
+
+// PageSize implements domain.PageSizeClient
+func (c *Client) PageSize() int {
+	return c.pageSize
+}
+
+// itemFields returns the Fields parameter for a listing of playable items,
+// adding media details unless fast sync is on
+func (c *Client) itemFields(base string) string {
+	if c.fastSync {
+		return base
 	}
+	return base + "," + mediaFields
 }
 
 // do performs an authenticated HTTP request to the Jellyfin API. All error
-// mapping lives here: 401 → domain.ErrAuthFailed, transport failures →
-// domain.ErrServerOffline (wrapped with the cause), any 2xx → success.
+// mapping lives here: 401 → domain.ErrAuthFailed, 404 →
+// domain.ErrItemNotFound, 5xx → domain.ErrServerError, transport failures →
+// domain.ErrServerOffline (each wrapped with the cause), any 2xx → success.
 // Idempotent requests (retry=true) are retried on network errors and 5xx
-// responses with exponential backoff.
+// responses with exponential backoff. A 429 pauses every request to the
+// server for its Retry-After (see httpclient.Backoff) and is retried like a
+// 5xx.
 func (c *Client) do(ctx context.Context, method, path string, query url.Values, jsonBody interface{}, retry bool) ([]byte, error) {
 	reqURL := fmt.Sprintf("%s%s", c.baseURL, path)
 	if query != nil {
@@ -92,6 +160,10 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 			}
 		}
 
+		if err := c.backoff.Wait(ctx); err != nil {
+			return nil, err
+		}
+
 		var reqBody io.Reader
 		if bodyBytes != nil {
 			reqBody = bytes.NewReader(bodyBytes)
@@ -102,7 +174,7 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 		}
 
 		req.Header.Set("Accept", "application/json")
-		req.Header.Set("X-Emby-Authorization", buildAuthHeader(c.token, c.deviceID))
+		req.Header.Set("X-Emby-Authorization", buildAuthHeader(c.authToken(), c.deviceID))
 		if bodyBytes != nil {
 			req.Header.Set("Content-Type", "application/json")
 		}
@@ -126,7 +198,7 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 		case resp.StatusCode == http.StatusUnauthorized:
 			return nil, domain.ErrAuthFailed
 		case resp.StatusCode >= 500:
-			lastErr = fmt.Errorf("server error: %d - %s", resp.StatusCode, truncateForLog(body))
+			lastErr = fmt.Errorf("%w: %d - %s", domain.ErrServerError, resp.StatusCode, truncateForLog(body))
 			c.logger.Warn("jellyfin server error",
 				"status", resp.StatusCode,
 				"attempt", attempt,
@@ -134,8 +206,26 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 				"path", path,
 			)
 			continue
+		case resp.StatusCode == http.StatusTooManyRequests:
+			lastErr = fmt.Errorf("%w: %d - rate limited", domain.ErrServerError, resp.StatusCode)
+			pause, ok := c.backoff.RateLimited(resp.Header)
+			c.logger.Warn("jellyfin rate limited",
+				"pause", pause,
+				"attempt", attempt,
+				"method", method,
+				"path", path,
+			)
+			if !ok {
+				return nil, fmt.Errorf("%w, retry after %s", lastErr, pause)
+			}
+			continue
 		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			c.backoff.Succeeded()
 			return body, nil
+		case resp.StatusCode == http.StatusNotFound:
+			return nil, fmt.Errorf("%w: %s", domain.ErrItemNotFound, path)
+		case resp.StatusCode == http.StatusForbidden:
+			return nil, fmt.Errorf("%w: %s %s", domain.ErrForbidden, method, path)
 		default:
 			c.logger.Error("jellyfin request error", "status", resp.StatusCode, "path", path, "body", truncateForLog(body))
 			return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
@@ -183,7 +273,7 @@ func (c *Client) GetMovies(ctx context.Context, libID string, offset, limit int)
 	query.Set("ParentId", libID)
 	query.Set("IncludeItemTypes", "Movie")
 	query.Set("Recursive", "true")
-	query.Set("Fields", "Overview,DateCreated,MediaSources,MediaStreams")
+	query.Set("Fields", c.itemFields(playableFields))
 	query.Set("StartIndex", strconv.Itoa(offset))
 	if limit > 0 {
 		query.Set("Limit", strconv.Itoa(limit))
@@ -217,7 +307,7 @@ func (c *Client) GetShows(ctx context.Context, libID string, offset, limit int)
 	query.Set("ParentId", libID)
 	query.Set("IncludeItemTypes", "Series")
 	query.Set("Recursive", "true")
-	query.Set("Fields", "Overview,ChildCount,RecursiveItemCount,DateCreated,DateLastMediaAdded,MediaSources,MediaStreams")
+	query.Set("Fields", showFields)
 	query.Set("StartIndex", strconv.Itoa(offset))
 	if limit > 0 {
 		query.Set("Limit", strconv.Itoa(limit))
@@ -252,7 +342,7 @@ func (c *Client) GetMixedContent(ctx context.Context, libID string, offset, limi
 	query.Set("ParentId", libID)
 	query.Set("IncludeItemTypes", "Movie,Series")
 	query.Set("Recursive", "true")
-	query.Set("Fields", "Overview,ChildCount,RecursiveItemCount,DateCreated,DateLastMediaAdded,MediaSources,MediaStreams")
+	query.Set("Fields", c.itemFields(showFields))
 	query.Set("StartIndex", strconv.Itoa(offset))
 	if limit > 0 {
 		query.Set("Limit", strconv.Itoa(limit))
@@ -320,7 +410,7 @@ func (c *Client) GetLibraryItemCount(ctx context.Context, libID, libType string)
 // GetSeasons returns all seasons for a TV show
 func (c *Client) GetSeasons(ctx context.Context, showID string) ([]*domain.Season, error) {
 	query := url.Values{}
-	query.Set("Fields", "ChildCount,RecursiveItemCount")
+	query.Set("Fields", "ChildCount,RecursiveItemCount,DateLastMediaAdded")
 
 	path := fmt.Sprintf("/Shows/%s/Seasons", showID)
 	body, err := c.doRequest(ctx, http.MethodGet, path, query)
@@ -342,7 +432,7 @@ func (c *Client) GetSeasons(ctx context.Context, showID string) ([]*domain.Seaso
 func (c *Client) GetEpisodes(ctx context.Context, seasonID string) ([]*domain.MediaItem, error) {
 	query := url.Values{}
 	query.Set("ParentId", seasonID)
-	query.Set("Fields", "Overview,MediaSources,MediaStreams,DateCreated")
+	query.Set("Fields", c.itemFields(playableFields))
 	query.Set("SortBy", "IndexNumber")
 	query.Set("SortOrder", "Ascending")
 
@@ -360,6 +450,220 @@ func (c *Client) GetEpisodes(ctx context.Context, seasonID string) ([]*domain.Me
 	return MapEpisodes(resp.Items, c.baseURL), nil
 }
 
+// GetAllEpisodes lists every episode in a library with one recursive
+// query, for the search index. Media streams are left out: search shows
+// titles, and playback fetches the season it lands in.
+func (c *Client) GetAllEpisodes(ctx context.Context, libID string) ([]*domain.MediaItem, error) {
+	query := url.Values{}
+	query.Set("ParentId", libID)
+	query.Set("IncludeItemTypes", "Episode")
+	query.Set("Recursive", "true")
+	query.Set("Fields", playableFields)
+	query.Set("SortBy", "SeriesSortName,ParentIndexNumber,IndexNumber")
+	query.Set("SortOrder", "Ascending")
+
+	path := fmt.Sprintf("/Users/%s/Items", c.userID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ItemsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	episodes := MapEpisodes(resp.Items, c.baseURL)
+	for _, ep := range episodes {
+		ep.LibraryID = libID
+	}
+	return episodes, nil
+}
+
+// GetExtras returns a show's special features (behind-the-scenes, deleted
+// scenes...). The endpoint returns a bare array rather than ItemsResponse.
+func (c *Client) GetExtras(ctx context.Context, itemID string) ([]*domain.MediaItem, error) {
+	path := fmt.Sprintf("/Users/%s/Items/%s/SpecialFeatures", c.userID, itemID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	if err := json.Unmarshal(body, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return MapExtras(items, c.baseURL), nil
+}
+
+// Ping checks the server is up with its lightweight ping endpoint. It is
+// not retried: a health check wants the server's state right now.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.do(ctx, http.MethodGet, "/System/Ping", nil, nil, false)
+	return err
+}
+
+// GetActivities lists the scheduled tasks running on the server (library
+// scans, metadata refreshes, chapter image extraction)
+func (c *Client) GetActivities(ctx context.Context) ([]domain.Activity, error) {
+	query := url.Values{}
+	query.Set("isHidden", "false")
+	body, err := c.doRequest(ctx, http.MethodGet, "/ScheduledTasks", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []ScheduledTask
+	if err := json.Unmarshal(body, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return MapActivities(tasks), nil
+}
+
+// GetItemDetails fetches an item's full metadata, with credits and chapters
+func (c *Client) GetItemDetails(ctx context.Context, itemID string) (*domain.ItemDetails, error) {
+	path := fmt.Sprintf("/Users/%s/Items/%s", c.userID, itemID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var item Item
+	if err := json.Unmarshal(body, &item); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return MapDetails(item), nil
+}
+
+// GetPersonItems returns the movies and shows crediting a person as an
+// actor or, with director set, as director
+func (c *Client) GetPersonItems(ctx context.Context, personID string, director bool) ([]domain.ListItem, error) {
+	query := url.Values{}
+	query.Set("PersonIds", personID)
+	if director {
+		query.Set("PersonTypes", "Director")
+	} else {
+		query.Set("PersonTypes", "Actor,GuestStar")
+	}
+	query.Set("IncludeItemTypes", "Movie,Series")
+	query.Set("Recursive", "true")
+	query.Set("Fields", c.itemFields(showFields))
+
+	path := fmt.Sprintf("/Users/%s/Items", c.userID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ItemsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return MapLibraryContent(resp.Items, c.baseURL), nil
+}
+
+// GetSimilar returns the movies or shows Jellyfin considers similar to an
+// item
+func (c *Client) GetSimilar(ctx context.Context, itemID string) ([]domain.ListItem, error) {
+	query := url.Values{}
+	query.Set("UserId", c.userID)
+	query.Set("Limit", "20")
+	query.Set("Fields", c.itemFields(showFields))
+
+	path := fmt.Sprintf("/Items/%s/Similar", itemID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ItemsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return MapLibraryContent(resp.Items, c.baseURL), nil
+}
+
+// GetMarkers fetches an item's chapters and its media segments. Servers
+// before 10.10 have no segments API; the chapters are returned alone.
+func (c *Client) GetMarkers(ctx context.Context, itemID string) (*domain.Markers, error) {
+	path := fmt.Sprintf("/Users/%s/Items/%s", c.userID, itemID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var item Item
+	if err := json.Unmarshal(body, &item); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	markers := &domain.Markers{Chapters: mapChapters(item.Chapters)}
+
+	body, err = c.doRequest(ctx, http.MethodGet, "/MediaSegments/"+itemID, nil)
+	if err != nil {
+		if errors.Is(err, domain.ErrAuthFailed) {
+			return nil, err
+		}
+		c.logger.Debug("media segments unavailable", "error", err, "itemID", itemID)
+		return markers, nil
+	}
+	var segments MediaSegmentsResponse
+	if err := json.Unmarshal(body, &segments); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	markers.Segments = MapSegments(segments.Items)
+	return markers, nil
+}
+
+// GetAiringEpisodes combines recently aired library episodes with the
+// server's upcoming-episodes feed (/Shows/Upcoming), which includes
+// episodes that are announced but not yet in the library
+func (c *Client) GetAiringEpisodes(ctx context.Context, from, to time.Time) ([]*domain.MediaItem, error) {
+	recentQuery := url.Values{}
+	recentQuery.Set("IncludeItemTypes", "Episode")
+	recentQuery.Set("Recursive", "true")
+	recentQuery.Set("IsMissing", "false")
+	recentQuery.Set("MinPremiereDate", from.UTC().Format(time.RFC3339))
+	recentQuery.Set("MaxPremiereDate", to.UTC().Format(time.RFC3339))
+	recentQuery.Set("Fields", "Overview,DateCreated,PremiereDate")
+	recentQuery.Set("SortBy", "PremiereDate")
+
+	body, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/Users/%s/Items", c.userID), recentQuery)
+	if err != nil {
+		return nil, err
+	}
+	var recent ItemsResponse
+	if err := json.Unmarshal(body, &recent); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	upcomingQuery := url.Values{}
+	upcomingQuery.Set("UserId", c.userID)
+	upcomingQuery.Set("Limit", "200")
+	upcomingQuery.Set("Fields", "Overview,PremiereDate")
+
+	body, err = c.doRequest(ctx, http.MethodGet, "/Shows/Upcoming", upcomingQuery)
+	if err != nil {
+		return nil, err
+	}
+	var upcoming ItemsResponse
+	if err := json.Unmarshal(body, &upcoming); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var episodes []*domain.MediaItem
+	for _, ep := range append(MapEpisodes(recent.Items, c.baseURL), MapEpisodes(upcoming.Items, c.baseURL)...) {
+		if seen[ep.ID] || ep.AiredAt < from.Unix() || ep.AiredAt > to.Unix() {
+			continue
+		}
+		seen[ep.ID] = true
+		episodes = append(episodes, ep)
+	}
+	sort.SliceStable(episodes, func(i, j int) bool { return episodes[i].AiredAt < episodes[j].AiredAt })
+	return episodes, nil
+}
+
 // Search performs a search across all libraries
 func (c *Client) Search(ctx context.Context, query string) ([]*domain.MediaItem, error) {
 	params := url.Values{}
@@ -383,6 +687,18 @@ func (c *Client) Search(ctx context.Context, query string) ([]*domain.MediaItem,
 
 // ResolvePlayableURL returns a direct playback URL for an item
 func (c *Client) ResolvePlayableURL(ctx context.Context, itemID string) (string, error) {
+	return c.resolveSourceURL(ctx, itemID, "")
+}
+
+// ResolveVersionURL returns a direct stream URL for one of an item's media
+// sources, identified by its MediaSourceId
+func (c *Client) ResolveVersionURL(ctx context.Context, itemID, versionID string) (string, error) {
+	return c.resolveSourceURL(ctx, itemID, versionID)
+}
+
+// resolveSourceURL returns the direct stream URL of an item's media source,
+// the first one when sourceID is empty
+func (c *Client) resolveSourceURL(ctx context.Context, itemID, sourceID string) (string, error) {
 	// Get playback info to get the stream URL
 	query := url.Values{}
 	query.Set("UserId", c.userID)
@@ -404,15 +720,146 @@ func (c *Client) ResolvePlayableURL(ctx context.Context, itemID string) (string,
 	}
 
 	source := resp.MediaSources[0]
+	if sourceID != "" {
+		i := slices.IndexFunc(resp.MediaSources, func(s MediaSource) bool { return s.ID == sourceID })
+		if i < 0 {
+			return "", domain.ErrItemNotFound
+		}
+		source = resp.MediaSources[i]
+	}
 
 	// Build direct stream URL
 	// Format: /Videos/{itemId}/stream.{container}?static=true&api_key={token}
 	streamURL := fmt.Sprintf("%s/Videos/%s/stream.%s?Static=true&api_key=%s",
-		c.baseURL, itemID, source.Container, c.token)
+		c.baseURL, itemID, source.Container, c.authToken())
+	if sourceID != "" {
+		streamURL += "&MediaSourceId=" + url.QueryEscape(sourceID)
+	}
 
 	return streamURL, nil
 }
 
+// transcodeAudioKbps is the audio share of a transcoded stream's bitrate
+const transcodeAudioKbps = 192
+
+// ResolveTranscodedURL returns an HLS stream of the item that the server
+// transcodes to H.264/AAC within maxKbps
+func (c *Client) ResolveTranscodedURL(ctx context.Context, itemID string, maxKbps int) (string, error) {
+	query := url.Values{}
+	query.Set("UserId", c.userID)
+	query.Set("MaxStreamingBitrate", strconv.Itoa(maxKbps*1000))
+
+	path := fmt.Sprintf("/Items/%s/PlaybackInfo", itemID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, query)
+	if err != nil {
+		return "", err
+	}
+
+	var resp PlaybackInfoResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(resp.MediaSources) == 0 {
+		return "", domain.ErrItemNotFound
+	}
+
+	stream := url.Values{}
+	stream.Set("MediaSourceId", resp.MediaSources[0].ID)
+	if resp.PlaySessionID != "" {
+		stream.Set("PlaySessionId", resp.PlaySessionID)
+	}
+	stream.Set("DeviceId", c.deviceID)
+	stream.Set("VideoCodec", "h264")
+	stream.Set("AudioCodec", "aac")
+	stream.Set("MaxAudioChannels", "2")
+	stream.Set("AudioBitrate", strconv.Itoa(transcodeAudioKbps*1000))
+	stream.Set("VideoBitrate", strconv.Itoa(max(maxKbps-transcodeAudioKbps, transcodeAudioKbps)*1000))
+	stream.Set("SegmentContainer", "ts")
+	stream.Set("api_key", c.authToken())
+	return fmt.Sprintf("%s/Videos/%s/master.m3u8?%s", c.baseURL, itemID, stream.Encode()), nil
+}
+
+// HasLiveTV reports whether Live TV is enabled with at least one tuner
+// service. Servers without Live TV answer with IsEnabled=false.
+func (c *Client) HasLiveTV(ctx context.Context) (bool, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/LiveTv/Info", nil)
+	if err != nil {
+		return false, err
+	}
+
+	var info LiveTvInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return false, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return info.IsEnabled && len(info.Services) > 0, nil
+}
+
+// GetChannels returns Live TV channels with their current program
+func (c *Client) GetChannels(ctx context.Context) ([]*domain.MediaItem, error) {
+	query := url.Values{}
+	query.Set("UserId", c.userID)
+	query.Set("AddCurrentProgram", "true")
+	query.Set("EnableUserData", "false")
+	query.Set("SortBy", "SortName")
+
+	body, err := c.doRequest(ctx, http.MethodGet, "/LiveTv/Channels", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ItemsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return MapChannels(resp.Items, c.baseURL), nil
+}
+
+// ResolveChannelURL opens a live stream for a channel and returns its URL.
+// Unlike library items, a channel has no file to stream statically: the
+// PlaybackInfo call tunes the channel and hands back a live stream ID.
+func (c *Client) ResolveChannelURL(ctx context.Context, channelID string) (string, error) {
+	path := fmt.Sprintf("/Items/%s/PlaybackInfo", channelID)
+	reqBody := PlaybackInfoRequest{
+		UserID:              c.userID,
+		MaxStreamingBitrate: 140000000,
+		AutoOpenLiveStream:  true,
+	}
+	// Not retried: each attempt may open (and hold) another tuner
+	body, err := c.do(ctx, http.MethodPost, path, nil, reqBody, false)
+	if err != nil {
+		return "", err
+	}
+
+	var resp PlaybackInfoResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if len(resp.MediaSources) == 0 {
+		return "", domain.ErrItemNotFound
+	}
+	source := resp.MediaSources[0]
+
+	query := url.Values{}
+	query.Set("Static", "true")
+	query.Set("MediaSourceId", source.ID)
+	if source.LiveStreamID != "" {
+		query.Set("LiveStreamId", source.LiveStreamID)
+	}
+	if resp.PlaySessionID != "" {
+		query.Set("PlaySessionId", resp.PlaySessionID)
+	}
+	query.Set("api_key", c.authToken())
+
+	container := source.Container
+	if container == "" {
+		container = "ts"
+	}
+	return fmt.Sprintf("%s/Videos/%s/stream.%s?%s", c.baseURL, channelID, container, query.Encode()), nil
+}
+
 // MarkPlayed marks an item as fully watched
 func (c *Client) MarkPlayed(ctx context.Context, itemID string) error {
 	path := fmt.Sprintf("/Users/%s/PlayedItems/%s", c.userID, itemID)
@@ -422,6 +869,16 @@ func (c *Client) MarkPlayed(ctx context.Context, itemID string) error {
 	return nil
 }
 
+// SetPlayCount marks an item played with the given play count
+func (c *Client) SetPlayCount(ctx context.Context, itemID string, plays int) error {
+	path := fmt.Sprintf("/Users/%s/Items/%s/UserData", c.userID, itemID)
+	body := map[string]any{"Played": true, "PlayCount": max(plays, 1)}
+	if _, err := c.do(ctx, http.MethodPost, path, nil, body, false); err != nil {
+		return fmt.Errorf("failed to set play count: %w", err)
+	}
+	return nil
+}
+
 // MarkUnplayed marks an item as unwatched
 func (c *Client) MarkUnplayed(ctx context.Context, itemID string) error {
 	path := fmt.Sprintf("/Users/%s/PlayedItems/%s", c.userID, itemID)
@@ -431,12 +888,139 @@ func (c *Client) MarkUnplayed(ctx context.Context, itemID string) error {
 	return nil
 }
 
+// SetViewOffset sets an item's resume position
+func (c *Client) SetViewOffset(ctx context.Context, itemID string, offset time.Duration) error {
+	path := fmt.Sprintf("/Users/%s/Items/%s/UserData", c.userID, itemID)
+	body := map[string]int64{"PlaybackPositionTicks": durationToTicks(offset)}
+	if _, err := c.do(ctx, http.MethodPost, path, nil, body, false); err != nil {
+		return fmt.Errorf("failed to set resume position: %w", err)
+	}
+	return nil
+}
+
+// SetUserRating rates an item 1-10 for the user; 0 clears the rating
+func (c *Client) SetUserRating(ctx context.Context, itemID string, rating float64) error {
+	path := fmt.Sprintf("/Users/%s/Items/%s/UserData", c.userID, itemID)
+	body := map[string]*float64{"Rating": nil}
+	if rating > 0 {
+		body["Rating"] = &rating
+	}
+	if _, err := c.do(ctx, http.MethodPost, path, nil, body, false); err != nil {
+		return fmt.Errorf("failed to set rating: %w", err)
+	}
+	return nil
+}
+
+// DeleteItem removes an item and its media files from the server; the
+// user needs the "allow media deletion" policy
+func (c *Client) DeleteItem(ctx context.Context, itemID string) error {
+	if _, err := c.do(ctx, http.MethodDelete, "/Items/"+itemID, nil, nil, false); err != nil {
+		return fmt.Errorf("failed to delete item: %w", err)
+	}
+	return nil
+}
+
+// GetContinueWatching returns the user's resumable movies and episodes
+// from every library, most recently played first
+func (c *Client) GetContinueWatching(ctx context.Context) ([]*domain.MediaItem, error) {
+	query := url.Values{}
+	query.Set("MediaTypes", "Video")
+	query.Set("Fields", "Overview,MediaSources,DateCreated")
+	query.Set("EnableUserData", "true")
+
+	path := fmt.Sprintf("/Users/%s/Items/Resume", c.userID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ItemsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return MapVideoItems(resp.Items, c.baseURL), nil
+}
+
+// RemoveFromContinueWatching hides an item from the resume list; its
+// position is kept. Needs Jellyfin 10.9 or later.
+func (c *Client) RemoveFromContinueWatching(ctx context.Context, itemID string) error {
+	query := url.Values{}
+	query.Set("hide", "true")
+	path := fmt.Sprintf("/Users/%s/Items/%s/HideFromResume", c.userID, itemID)
+	if _, err := c.do(ctx, http.MethodPost, path, query, nil, false); err != nil {
+		return fmt.Errorf("failed to hide from continue watching: %w", err)
+	}
+	return nil
+}
+
+// GetNextUp returns the next unwatched episode of each show in progress
+func (c *Client) GetNextUp(ctx context.Context) ([]*domain.MediaItem, error) {
+	query := url.Values{}
+	query.Set("UserId", c.userID)
+	query.Set("Fields", "Overview,MediaSources,DateCreated")
+	query.Set("EnableUserData", "true")
+
+	body, err := c.doRequest(ctx, http.MethodGet, "/Shows/NextUp", query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ItemsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return MapEpisodes(resp.Items, c.baseURL), nil
+}
+
+// GetCollections returns the user's collections (box sets). Jellyfin keeps
+// them in a library of their own, so LibraryID is left empty.
+func (c *Client) GetCollections(ctx context.Context) ([]domain.Collection, error) {
+	query := url.Values{}
+	query.Set("IncludeItemTypes", "BoxSet")
+	query.Set("Recursive", "true")
+
+	path := fmt.Sprintf("/Users/%s/Items", c.userID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ItemsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	collections := make([]domain.Collection, len(resp.Items))
+	for i, item := range resp.Items {
+		collections[i] = domain.Collection{ID: item.ID, Title: item.Name}
+	}
+	return collections, nil
+}
+
+// GetCollectionItems returns a collection's movies and shows
+func (c *Client) GetCollectionItems(ctx context.Context, collectionID string) ([]domain.ListItem, error) {
+	query := url.Values{}
+	query.Set("ParentId", collectionID)
+	query.Set("Fields", c.itemFields(showFields))
+
+	path := fmt.Sprintf("/Users/%s/Items", c.userID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ItemsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return MapLibraryContent(resp.Items, c.baseURL), nil
+}
+
 // GetPlaylists returns all user playlists
 func (c *Client) GetPlaylists(ctx context.Context) ([]*domain.Playlist, error) {
 	query := url.Values{}
 	query.Set("IncludeItemTypes", "Playlist")
 	query.Set("Recursive", "true")
-	query.Set("Fields", "ChildCount,DateCreated")
+	query.Set("Fields", "ChildCount,DateCreated,Overview")
 
 	path := fmt.Sprintf("/Users/%s/Items", c.userID)
 	body, err := c.doRequest(ctx, http.MethodGet, path, query)
@@ -469,22 +1053,13 @@ func (c *Client) GetPlaylistItems(ctx context.Context, playlistID string) ([]*do
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	// Map items (could be movies or episodes)
-	items := make([]*domain.MediaItem, 0, len(resp.Items))
-	for _, item := range resp.Items {
-		switch item.Type {
-		case "Movie":
-			movie := mapMovie(item, c.baseURL)
-			items = append(items, &movie)
-		case "Episode":
-			episode := mapEpisode(item, c.baseURL)
-			items = append(items, &episode)
-		}
-	}
-
-	return items, nil
+	// Items could be movies or episodes
+	return MapVideoItems(resp.Items, c.baseURL), nil
 }
 
+// CreatesEmptyPlaylists reports that playlists may start with no items
+func (c *Client) CreatesEmptyPlaylists() bool { return true }
+
 // CreatePlaylist creates a new playlist with the given title and optional initial items
 func (c *Client) CreatePlaylist(ctx context.Context, title string, itemIDs []string) (*domain.Playlist, error) {
 	reqBody := map[string]interface{}{
@@ -585,6 +1160,30 @@ func (c *Client) resolvePlaylistEntryID(ctx context.Context, playlistID, itemID
 	return "", fmt.Errorf("item %s not found in playlist %s", itemID, playlistID)
 }
 
+// UpdatePlaylist sets a playlist's name and overview. /Playlists/{id} only
+// updates the name, so this goes through the generic item update, which
+// replaces the whole item: fetch it and post it back with the two fields
+// changed so nothing else is reset.
+func (c *Client) UpdatePlaylist(ctx context.Context, playlistID, title, description string) error {
+	path := fmt.Sprintf("/Users/%s/Items/%s", c.userID, playlistID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	var item map[string]interface{}
+	if err := json.Unmarshal(body, &item); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	item["Name"] = title
+	item["Overview"] = description
+
+	if _, err := c.do(ctx, http.MethodPost, "/Items/"+playlistID, nil, item, false); err != nil {
+		return fmt.Errorf("failed to update playlist: %w", err)
+	}
+	return nil
+}
+
 // DeletePlaylist deletes a playlist
 func (c *Client) DeletePlaylist(ctx context.Context, playlistID string) error {
 	path := fmt.Sprintf("/Items/%s", playlistID)
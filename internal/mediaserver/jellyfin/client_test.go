@@ -2,13 +2,16 @@ package jellyfin
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mmcdole/kino/internal/domain"
 )
@@ -33,6 +36,7 @@ func TestMutations401MapToErrAuthFailed(t *testing.T) {
 		"MarkUnplayed":   c.MarkUnplayed(ctx, "x"),
 		"AddToPlaylist":  c.AddToPlaylist(ctx, "p", []string{"x"}),
 		"DeletePlaylist": c.DeletePlaylist(ctx, "p"),
+		"UpdatePlaylist": c.UpdatePlaylist(ctx, "p", "t", "d"),
 	}
 	if _, err := c.CreatePlaylist(ctx, "t", []string{"x"}); err != nil {
 		calls["CreatePlaylist"] = err
@@ -88,6 +92,38 @@ func TestRetryPolicy(t *testing.T) {
 	}
 }
 
+// A 429 is retried after the server's Retry-After; one asking for too
+// long a wait fails at once
+func TestRateLimitRetry(t *testing.T) {
+	var calls atomic.Int32
+	retryAfter := "0"
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 || retryAfter != "0" {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"Items":[]}`))
+	}))
+	ctx := context.Background()
+
+	if _, err := c.doRequest(ctx, http.MethodGet, "/Users/user1/Views", nil); err != nil {
+		t.Fatalf("rate-limited request not retried: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("attempts = %d, want 2", got)
+	}
+
+	calls.Store(0)
+	retryAfter = "3600"
+	if _, err := c.doRequest(ctx, http.MethodGet, "/Users/user1/Views", nil); !errors.Is(err, domain.ErrServerError) {
+		t.Fatalf("err = %v, want a server error", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("attempts = %d, want 1 (no hour-long wait)", got)
+	}
+}
+
 // 204 No Content responses are success for mutations.
 func Test2xxAccepted(t *testing.T) {
 	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -120,6 +156,34 @@ func TestRemoveFromPlaylistUsesEntryID(t *testing.T) {
 	}
 }
 
+// UpdatePlaylist posts the full item back so fields other than name and
+// overview survive the replace.
+func TestUpdatePlaylistPreservesItemFields(t *testing.T) {
+	var posted map[string]interface{}
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"Id":"pl1","Name":"Old","Overview":"","Type":"Playlist","Tags":["keep"]}`))
+		case http.MethodPost:
+			if r.URL.Path != "/Items/pl1" {
+				t.Errorf("posted to %s, want /Items/pl1", r.URL.Path)
+			}
+			json.NewDecoder(r.Body).Decode(&posted)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	if err := c.UpdatePlaylist(context.Background(), "pl1", "New", "Friday nights"); err != nil {
+		t.Fatal(err)
+	}
+	if posted["Name"] != "New" || posted["Overview"] != "Friday nights" {
+		t.Fatalf("posted name/overview = %v/%v", posted["Name"], posted["Overview"])
+	}
+	if tags, _ := posted["Tags"].([]interface{}); len(tags) != 1 {
+		t.Fatalf("untouched fields dropped: %v", posted)
+	}
+}
+
 // The auth header carries the per-install device ID on every request.
 func TestDeviceIDInAuthHeader(t *testing.T) {
 	var header string
@@ -135,3 +199,149 @@ func TestDeviceIDInAuthHeader(t *testing.T) {
 		t.Fatalf("auth header missing %s: %q", want, header)
 	}
 }
+
+// Live TV is only offered when enabled with a tuner, and channel playback
+// must open a live stream (POST with AutoOpenLiveStream) and reference it.
+func TestLiveTV(t *testing.T) {
+	var sawAutoOpen bool
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/LiveTv/Info":
+			w.Write([]byte(`{"IsEnabled":true,"Services":[{"Name":"HDHomeRun","Status":"Ok"}]}`))
+		case r.URL.Path == "/LiveTv/Channels":
+			w.Write([]byte(`{"Items":[{"Id":"ch1","Name":"PBS","ChannelNumber":"4.1","CurrentProgram":{"Name":"Nova"}}]}`))
+		case r.URL.Path == "/Items/ch1/PlaybackInfo" && r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			sawAutoOpen = strings.Contains(string(body), `"AutoOpenLiveStream":true`)
+			w.Write([]byte(`{"MediaSources":[{"Id":"src1","Container":"ts","LiveStreamId":"live1"}],"PlaySessionId":"ps1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+
+	ok, err := c.HasLiveTV(ctx)
+	if err != nil || !ok {
+		t.Fatalf("HasLiveTV = %v, %v; want true", ok, err)
+	}
+
+	channels, err := c.GetChannels(ctx)
+	if err != nil || len(channels) != 1 {
+		t.Fatalf("GetChannels = %v, %v", channels, err)
+	}
+	if ch := channels[0]; ch.Type != domain.MediaTypeChannel || ch.ChannelNumber != "4.1" || ch.NowPlaying != "Nova" {
+		t.Fatalf("channel mapped wrong: %+v", ch)
+	}
+
+	streamURL, err := c.ResolveChannelURL(ctx, "ch1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sawAutoOpen {
+		t.Error("PlaybackInfo did not request AutoOpenLiveStream")
+	}
+	u, err := url.Parse(streamURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Path != "/Videos/ch1/stream.ts" || u.Query().Get("LiveStreamId") != "live1" || u.Query().Get("MediaSourceId") != "src1" {
+		t.Fatalf("unexpected stream URL: %s", streamURL)
+	}
+}
+
+func TestHasLiveTVWithoutTuner(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"IsEnabled":true,"Services":[]}`))
+	}))
+	if ok, err := c.HasLiveTV(context.Background()); err != nil || ok {
+		t.Fatalf("HasLiveTV = %v, %v; want false", ok, err)
+	}
+}
+
+// Fast sync drops media details from movie listings; series listings never
+// request them
+func TestFastSyncTrimsListingFields(t *testing.T) {
+	var fields []string
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = append(fields, r.URL.Query().Get("Fields"))
+		_, _ = io.WriteString(w, `{"Items": [], "TotalRecordCount": 0}`)
+	}))
+	ctx := context.Background()
+
+	_, _, _ = c.GetMovies(ctx, "lib", 0, 50)
+	c.SetFastSync(true)
+	_, _, _ = c.GetMovies(ctx, "lib", 0, 50)
+	_, _, _ = c.GetShows(ctx, "lib", 0, 50)
+
+	if !strings.Contains(fields[0], "MediaSources") {
+		t.Errorf("full movie listing missing media details: %q", fields[0])
+	}
+	for _, f := range fields[1:] {
+		if strings.Contains(f, "MediaSources") || strings.Contains(f, "MediaStreams") {
+			t.Errorf("trimmed listing still requests media details: %q", f)
+		}
+	}
+}
+
+// Casting offers the user's other remote-controllable sessions, never
+// kino's own, and plays on one through its session ID
+func TestCast(t *testing.T) {
+	var played, paused string
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Sessions":
+			if r.URL.Query().Get("ControllableByUserId") != "user1" {
+				t.Errorf("sessions query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[
+				{"Id": "s1", "DeviceId": "tv", "DeviceName": "Living Room", "Client": "Jellyfin Android TV", "SupportsRemoteControl": true},
+				{"Id": "s2", "DeviceId": "dev1", "DeviceName": "kino", "Client": "Kino", "SupportsRemoteControl": true},
+				{"Id": "s3", "DeviceId": "web", "DeviceName": "Firefox", "Client": "Jellyfin Web", "SupportsRemoteControl": false}
+			]`))
+		case "/Sessions/s1/Playing":
+			played = r.URL.RawQuery
+		case "/Sessions/s1/Playing/PlayPause":
+			paused = r.Method
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	ctx := context.Background()
+
+	targets, err := c.CastTargets(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(targets) != 1 || targets[0] != (domain.CastTarget{ID: "s1", Name: "Living Room", Product: "Jellyfin Android TV"}) {
+		t.Fatalf("targets = %+v", targets)
+	}
+	if err := c.Cast(ctx, "s1", "item1", 90*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if q, _ := url.ParseQuery(played); q.Get("playCommand") != "PlayNow" || q.Get("itemIds") != "item1" || q.Get("startPositionTicks") != "900000000" {
+		t.Errorf("play query = %s", played)
+	}
+	if err := c.ControlCast(ctx, "s1", domain.CastPlayPause); err != nil || paused != http.MethodPost {
+		t.Errorf("PlayPause sent as %q: %v", paused, err)
+	}
+}
+
+// The episode index is one recursive query over the library
+func TestGetAllEpisodes(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("ParentId") != "lib" || q.Get("Recursive") != "true" || q.Get("IncludeItemTypes") != "Episode" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		_, _ = io.WriteString(w, `{"Items": [{"Id": "e1", "Name": "Pilot", "Type": "Episode", "SeriesName": "Lost",
+			"SeriesId": "s1", "SeasonId": "se1", "ParentIndexNumber": 1, "IndexNumber": 1}], "TotalRecordCount": 1}`)
+	}))
+
+	eps, err := c.GetAllEpisodes(context.Background(), "lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(eps) != 1 || eps[0].EpisodeCode() != "S01E01" || eps[0].ShowID != "s1" || eps[0].ParentID != "se1" || eps[0].LibraryID != "lib" {
+		t.Fatalf("episodes = %+v", eps)
+	}
+}
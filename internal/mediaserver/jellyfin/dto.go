@@ -1,5 +1,10 @@
 package jellyfin
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // AuthResponse represents the response from Jellyfin's AuthenticateByName endpoint
 type AuthResponse struct {
 	User        User   `json:"User"`
@@ -36,33 +41,78 @@ type ItemsResponse struct {
 
 // Item represents a media item from Jellyfin (movie, show, season, episode, etc.)
 type Item struct {
-	ID                 string        `json:"Id"`
-	Name               string        `json:"Name"`
-	SortName           string        `json:"SortName"`
-	Overview           string        `json:"Overview"`
-	Type               string        `json:"Type"`
-	CollectionType     string        `json:"CollectionType,omitempty"` // For libraries: "movies", "tvshows"
-	DateCreated        string        `json:"DateCreated,omitempty"`
-	DateLastMediaAdded string        `json:"DateLastMediaAdded,omitempty"` // When last episode was added to show
-	ProductionYear     int           `json:"ProductionYear,omitempty"`
-	RunTimeTicks       int64         `json:"RunTimeTicks,omitempty"` // Duration in 100-nanosecond units
-	CommunityRating    float64       `json:"CommunityRating,omitempty"`
-	OfficialRating     string        `json:"OfficialRating,omitempty"`
-	ImageTags          ImageTags     `json:"ImageTags,omitempty"`
-	ParentID           string        `json:"ParentId,omitempty"`
-	SeriesID           string        `json:"SeriesId,omitempty"`
-	SeriesName         string        `json:"SeriesName,omitempty"`
-	SeasonID           string        `json:"SeasonId,omitempty"`
-	SeasonName         string        `json:"SeasonName,omitempty"`
-	ParentIndexNumber  int           `json:"ParentIndexNumber,omitempty"`  // Season number
-	IndexNumber        int           `json:"IndexNumber,omitempty"`        // Episode number
-	ChildCount         int           `json:"ChildCount,omitempty"`         // Number of child items (seasons for show, episodes for season)
-	RecursiveItemCount int           `json:"RecursiveItemCount,omitempty"` // Total items recursively (episodes for show)
-	PlaylistItemID     string        `json:"PlaylistItemId,omitempty"`     // Per-entry ID within a playlist
-	UserData           *UserData     `json:"UserData,omitempty"`
-	MediaSources       []MediaSource `json:"MediaSources,omitempty"`
-	Container          string        `json:"Container,omitempty"`
-	MediaStreams       []MediaStream `json:"MediaStreams,omitempty"`
+	ID                 string            `json:"Id"`
+	Name               string            `json:"Name"`
+	SortName           string            `json:"SortName"`
+	Overview           string            `json:"Overview"`
+	Type               string            `json:"Type"`
+	CollectionType     string            `json:"CollectionType,omitempty"` // For libraries: "movies", "tvshows"
+	ExtraType          string            `json:"ExtraType,omitempty"`      // For special features: "DeletedScene", "BehindTheScenes"...
+	DateCreated        string            `json:"DateCreated,omitempty"`
+	DateLastMediaAdded string            `json:"DateLastMediaAdded,omitempty"` // When last episode was added to show
+	PremiereDate       string            `json:"PremiereDate,omitempty"`       // Original air/release date
+	ProductionYear     int               `json:"ProductionYear,omitempty"`
+	RunTimeTicks       int64             `json:"RunTimeTicks,omitempty"` // Duration in 100-nanosecond units
+	CommunityRating    float64           `json:"CommunityRating,omitempty"`
+	OfficialRating     string            `json:"OfficialRating,omitempty"`
+	ImageTags          ImageTags         `json:"ImageTags,omitempty"`
+	ParentID           string            `json:"ParentId,omitempty"`
+	SeriesID           string            `json:"SeriesId,omitempty"`
+	SeriesName         string            `json:"SeriesName,omitempty"`
+	SeasonID           string            `json:"SeasonId,omitempty"`
+	SeasonName         string            `json:"SeasonName,omitempty"`
+	ParentIndexNumber  int               `json:"ParentIndexNumber,omitempty"`  // Season number
+	IndexNumber        int               `json:"IndexNumber,omitempty"`        // Episode number
+	ChildCount         int               `json:"ChildCount,omitempty"`         // Number of child items (seasons for show, episodes for season)
+	RecursiveItemCount int               `json:"RecursiveItemCount,omitempty"` // Total items recursively (episodes for show)
+	PlaylistItemID     string            `json:"PlaylistItemId,omitempty"`     // Per-entry ID within a playlist
+	ProviderIds        map[string]string `json:"ProviderIds,omitempty"`        // External IDs keyed "Imdb", "Tmdb", "Tvdb"
+	UserData           *UserData         `json:"UserData,omitempty"`
+	MediaSources       []MediaSource     `json:"MediaSources,omitempty"`
+	Container          string            `json:"Container,omitempty"`
+	MediaStreams       []MediaStream     `json:"MediaStreams,omitempty"`
+	ChannelNumber      string            `json:"ChannelNumber,omitempty"`  // Live TV channels only
+	CurrentProgram     *Item             `json:"CurrentProgram,omitempty"` // Live TV channels only
+
+	// Credits and chapters, only on a single item's full metadata
+	Taglines []string      `json:"Taglines,omitempty"`
+	Genres   []string      `json:"Genres,omitempty"`
+	Studios  []NameID      `json:"Studios,omitempty"`
+	People   []Person      `json:"People,omitempty"`
+	Chapters []ChapterInfo `json:"Chapters,omitempty"`
+}
+
+// NameID is a named reference such as a studio
+type NameID struct {
+	Name string `json:"Name"`
+	ID   string `json:"Id"`
+}
+
+// Person is someone credited on an item
+type Person struct {
+	ID   string `json:"Id"`
+	Name string `json:"Name"`
+	Role string `json:"Role,omitempty"` // Character played, for actors
+	Type string `json:"Type"`           // "Actor", "Director", "Writer"...
+}
+
+// MediaSegmentsResponse is returned by /MediaSegments/{itemId} (10.10+)
+type MediaSegmentsResponse struct {
+	Items []MediaSegment `json:"Items"`
+}
+
+// MediaSegment is a marked stretch of a video, e.g. from the intro
+// skipper plugin
+type MediaSegment struct {
+	Type       string `json:"Type"` // "Intro", "Outro", "Recap", "Preview", "Commercial"
+	StartTicks int64  `json:"StartTicks"`
+	EndTicks   int64  `json:"EndTicks"`
+}
+
+// ChapterInfo is a chapter marker of a video
+type ChapterInfo struct {
+	Name               string `json:"Name"`
+	StartPositionTicks int64  `json:"StartPositionTicks"`
 }
 
 // ImageTags contains image tag IDs for various image types
@@ -75,12 +125,14 @@ type ImageTags struct {
 
 // UserData contains user-specific data for an item (watch status, progress)
 type UserData struct {
-	PlaybackPositionTicks int64  `json:"PlaybackPositionTicks"` // Progress in 100-nanosecond units
-	PlayCount             int    `json:"PlayCount"`
-	IsFavorite            bool   `json:"IsFavorite"`
-	Played                bool   `json:"Played"`
-	Key                   string `json:"Key"`
-	UnplayedItemCount     int    `json:"UnplayedItemCount,omitempty"` // For containers like shows/seasons
+	PlaybackPositionTicks int64   `json:"PlaybackPositionTicks"` // Progress in 100-nanosecond units
+	PlayCount             int     `json:"PlayCount"`
+	IsFavorite            bool    `json:"IsFavorite"`
+	Played                bool    `json:"Played"`
+	Rating                float64 `json:"Rating,omitempty"` // The user's own rating
+	LastPlayedDate        string  `json:"LastPlayedDate,omitempty"`
+	Key                   string  `json:"Key"`
+	UnplayedItemCount     int     `json:"UnplayedItemCount,omitempty"` // For containers like shows/seasons
 }
 
 // MediaSource represents a media source (file) for an item
@@ -93,11 +145,13 @@ type MediaSource struct {
 	Size                 int64         `json:"Size"`
 	Name                 string        `json:"Name"`
 	RunTimeTicks         int64         `json:"RunTimeTicks"`
+	Bitrate              int           `json:"Bitrate,omitempty"` // bps
 	SupportsDirectPlay   bool          `json:"SupportsDirectPlay"`
 	SupportsDirectStream bool          `json:"SupportsDirectStream"`
 	SupportsTranscoding  bool          `json:"SupportsTranscoding"`
 	MediaStreams         []MediaStream `json:"MediaStreams,omitempty"`
 	DirectStreamURL      string        `json:"DirectStreamUrl,omitempty"`
+	LiveStreamID         string        `json:"LiveStreamId,omitempty"` // Set for opened live streams
 }
 
 // MediaStream represents a video, audio, or subtitle stream
@@ -116,6 +170,26 @@ type MediaStream struct {
 	AspectRatio  string `json:"AspectRatio,omitempty"`
 }
 
+// LiveTvInfo is returned by /LiveTv/Info
+type LiveTvInfo struct {
+	IsEnabled bool                `json:"IsEnabled"`
+	Services  []LiveTvServiceInfo `json:"Services"`
+}
+
+// LiveTvServiceInfo describes one configured tuner service
+type LiveTvServiceInfo struct {
+	Name   string `json:"Name"`
+	Status string `json:"Status"` // "Ok" or "Unavailable"
+}
+
+// PlaybackInfoRequest is the body for POST /Items/{id}/PlaybackInfo. Live
+// channels need AutoOpenLiveStream so the server tunes before answering.
+type PlaybackInfoRequest struct {
+	UserID              string `json:"UserId"`
+	MaxStreamingBitrate int    `json:"MaxStreamingBitrate"`
+	AutoOpenLiveStream  bool   `json:"AutoOpenLiveStream"`
+}
+
 // PlaybackInfoResponse contains playback information for an item
 type PlaybackInfoResponse struct {
 	MediaSources  []MediaSource `json:"MediaSources"`
@@ -142,3 +216,81 @@ type SearchHintsResponse struct {
 	SearchHints      []SearchHint `json:"SearchHints"`
 	TotalRecordCount int          `json:"TotalRecordCount"`
 }
+
+// SyncPlayGroup is a SyncPlay group from /SyncPlay/List
+type SyncPlayGroup struct {
+	GroupID      string   `json:"GroupId"`
+	GroupName    string   `json:"GroupName"`
+	State        string   `json:"State"`
+	Participants []string `json:"Participants"`
+}
+
+// SocketMessage is one message on the /socket WebSocket
+type SocketMessage struct {
+	MessageType string          `json:"MessageType"`
+	Data        json.RawMessage `json:"Data,omitempty"`
+}
+
+// SyncPlayCommand is a SyncPlayCommand message: play, pause, seek or stop
+// at a position, effective at When (server clock)
+type SyncPlayCommand struct {
+	GroupID        string    `json:"GroupId"`
+	PlaylistItemID string    `json:"PlaylistItemId"`
+	When           time.Time `json:"When"`
+	PositionTicks  int64     `json:"PositionTicks"`
+	Command        string    `json:"Command"`
+}
+
+// SyncPlayGroupUpdate is a SyncPlayGroupUpdate message; Data depends on Type
+type SyncPlayGroupUpdate struct {
+	GroupID string          `json:"GroupId"`
+	Type    string          `json:"Type"`
+	Data    json.RawMessage `json:"Data"`
+}
+
+// SyncPlayQueue is the Data of a PlayQueue group update
+type SyncPlayQueue struct {
+	Playlist           []SyncPlayQueueItem `json:"Playlist"`
+	PlayingItemIndex   int                 `json:"PlayingItemIndex"`
+	StartPositionTicks int64               `json:"StartPositionTicks"`
+	IsPlaying          bool                `json:"IsPlaying"`
+}
+
+// SyncPlayQueueItem is one entry of a group's play queue
+type SyncPlayQueueItem struct {
+	ItemID         string `json:"ItemId"`
+	PlaylistItemID string `json:"PlaylistItemId"`
+}
+
+// UtcTime is the server's clock from /GetUtcTime
+type UtcTime struct {
+	RequestReceptionTime     time.Time `json:"RequestReceptionTime"`
+	ResponseTransmissionTime time.Time `json:"ResponseTransmissionTime"`
+}
+
+// SyncPlayBufferRequest is the body of /SyncPlay/Ready and /SyncPlay/Buffering
+type SyncPlayBufferRequest struct {
+	When           time.Time `json:"When"`
+	PositionTicks  int64     `json:"PositionTicks"`
+	IsPlaying      bool      `json:"IsPlaying"`
+	PlaylistItemID string    `json:"PlaylistItemId"`
+}
+
+// ScheduledTask is one of the server's scheduled tasks (/ScheduledTasks)
+type ScheduledTask struct {
+	ID                        string   `json:"Id"`
+	Name                      string   `json:"Name"`
+	Key                       string   `json:"Key"` // e.g. "RefreshLibrary"
+	Category                  string   `json:"Category"`
+	State                     string   `json:"State"` // "Idle", "Running" or "Cancelling"
+	CurrentProgressPercentage *float64 `json:"CurrentProgressPercentage,omitempty"`
+}
+
+// SessionInfo is a signed-in app from /Sessions
+type SessionInfo struct {
+	ID                    string `json:"Id"`
+	DeviceID              string `json:"DeviceId"`
+	DeviceName            string `json:"DeviceName"`
+	Client                string `json:"Client"`
+	SupportsRemoteControl bool   `json:"SupportsRemoteControl"`
+}
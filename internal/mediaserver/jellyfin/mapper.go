@@ -83,6 +83,7 @@ func mapMovie(item Item, serverURL string) domain.MediaItem {
 		Year:      item.ProductionYear,
 		Duration:  ticksToDuration(item.RunTimeTicks),
 		Type:      domain.MediaTypeMovie,
+		External:  mapProviderIDs(item.ProviderIds),
 	}
 
 	if mi.SortTitle == "" {
@@ -99,10 +100,14 @@ func mapMovie(item Item, serverURL string) domain.MediaItem {
 		}
 	}
 
-	// User data (watch status, progress)
+	// User data (watch status, progress, rating)
 	if item.UserData != nil {
 		mi.IsPlayed = item.UserData.Played
 		mi.ViewOffset = ticksToDuration(item.UserData.PlaybackPositionTicks)
+		mi.UserRating = item.UserData.Rating
+		if t, err := time.Parse(time.RFC3339, item.UserData.LastPlayedDate); err == nil {
+			mi.LastViewedAt = t.Unix()
+		}
 	}
 
 	// Image URLs
@@ -120,6 +125,7 @@ func mapMovie(item Item, serverURL string) domain.MediaItem {
 		mi.Bitrate = extractBitrate(item)
 		mi.Width, mi.Height = extractResolution(item)
 	}
+	mi.Versions = mapVersions(item.MediaSources)
 
 	return mi
 }
@@ -148,6 +154,7 @@ func mapShow(item Item, serverURL string) domain.Show {
 		Year:         item.ProductionYear,
 		SeasonCount:  item.ChildCount,
 		EpisodeCount: item.RecursiveItemCount,
+		External:     mapProviderIDs(item.ProviderIds),
 	}
 
 	if show.SortTitle == "" {
@@ -168,6 +175,7 @@ func mapShow(item Item, serverURL string) domain.Show {
 	if item.DateLastMediaAdded != "" {
 		if t, err := time.Parse(time.RFC3339, item.DateLastMediaAdded); err == nil {
 			show.UpdatedAt = t.Unix()
+			show.LastAddedAt = t.Unix()
 		}
 	} else if item.DateCreated != "" {
 		if t, err := time.Parse(time.RFC3339, item.DateCreated); err == nil {
@@ -175,9 +183,10 @@ func mapShow(item Item, serverURL string) domain.Show {
 		}
 	}
 
-	// User data (unwatched count)
+	// User data (unwatched count, rating)
 	if item.UserData != nil {
 		show.UnwatchedCount = item.UserData.UnplayedItemCount
+		show.UserRating = item.UserData.Rating
 	}
 
 	// Image URLs
@@ -212,6 +221,12 @@ func mapSeason(item Item, serverURL string) domain.Season {
 		EpisodeCount: item.ChildCount,
 	}
 
+	if item.DateLastMediaAdded != "" {
+		if t, err := time.Parse(time.RFC3339, item.DateLastMediaAdded); err == nil {
+			season.LastAddedAt = t.Unix()
+		}
+	}
+
 	// User data (unwatched count)
 	if item.UserData != nil {
 		season.UnwatchedCount = item.UserData.UnplayedItemCount
@@ -253,6 +268,7 @@ func mapEpisode(item Item, serverURL string) domain.MediaItem {
 		SeasonNum:  item.ParentIndexNumber,
 		EpisodeNum: item.IndexNumber,
 		ParentID:   item.SeasonID,
+		External:   mapProviderIDs(item.ProviderIds),
 	}
 
 	if mi.SortTitle == "" {
@@ -268,11 +284,20 @@ func mapEpisode(item Item, serverURL string) domain.MediaItem {
 			mi.UpdatedAt = t.Unix() // For episodes, UpdatedAt = AddedAt
 		}
 	}
+	if item.PremiereDate != "" {
+		if t, err := time.Parse(time.RFC3339, item.PremiereDate); err == nil {
+			mi.AiredAt = t.Unix()
+		}
+	}
 
-	// User data (watch status, progress)
+	// User data (watch status, progress, rating)
 	if item.UserData != nil {
 		mi.IsPlayed = item.UserData.Played
 		mi.ViewOffset = ticksToDuration(item.UserData.PlaybackPositionTicks)
+		mi.UserRating = item.UserData.Rating
+		if t, err := time.Parse(time.RFC3339, item.UserData.LastPlayedDate); err == nil {
+			mi.LastViewedAt = t.Unix()
+		}
 	}
 
 	// Image URLs
@@ -290,10 +315,146 @@ func mapEpisode(item Item, serverURL string) domain.MediaItem {
 		mi.Bitrate = extractBitrate(item)
 		mi.Width, mi.Height = extractResolution(item)
 	}
+	mi.Versions = mapVersions(item.MediaSources)
 
 	return mi
 }
 
+// jellyfinExtraTypes names Jellyfin's extra types for display
+var jellyfinExtraTypes = map[string]string{
+	"Trailer":         "Trailer",
+	"DeletedScene":    "Deleted Scene",
+	"Interview":       "Interview",
+	"BehindTheScenes": "Behind the Scenes",
+	"Scene":           "Scene",
+	"Sample":          "Sample",
+	"Featurette":      "Featurette",
+	"Short":           "Short",
+	"Clip":            "Clip",
+}
+
+// MapExtras converts a show's special features to domain media items.
+// Theme songs and videos are background media, not extras to browse.
+func MapExtras(items []Item, serverURL string) []*domain.MediaItem {
+	extras := make([]*domain.MediaItem, 0, len(items))
+	for _, item := range items {
+		if item.ExtraType == "ThemeSong" || item.ExtraType == "ThemeVideo" {
+			continue
+		}
+		mi := domain.MediaItem{
+			ID:        item.ID,
+			Title:     item.Name,
+			SortTitle: item.Name,
+			Summary:   item.Overview,
+			Year:      item.ProductionYear,
+			Duration:  ticksToDuration(item.RunTimeTicks),
+			Type:      domain.MediaTypeMovie, // A standalone clip, not part of a season
+			ExtraType: jellyfinExtraTypes[item.ExtraType],
+		}
+		if mi.ExtraType == "" {
+			mi.ExtraType = "Extra"
+		}
+		if item.UserData != nil {
+			mi.IsPlayed = item.UserData.Played
+			mi.ViewOffset = ticksToDuration(item.UserData.PlaybackPositionTicks)
+		}
+		if item.ImageTags.Primary != "" {
+			mi.ThumbURL = fmt.Sprintf("%s/Items/%s/Images/Primary?tag=%s", serverURL, item.ID, item.ImageTags.Primary)
+		}
+		extras = append(extras, &mi)
+	}
+	return extras
+}
+
+// MapDetails converts an item's full metadata to its domain details
+func MapDetails(item Item) *domain.ItemDetails {
+	d := &domain.ItemDetails{Genres: item.Genres}
+	if len(item.Taglines) > 0 {
+		d.Tagline = item.Taglines[0]
+	}
+	if len(item.Studios) > 0 {
+		d.Studio = item.Studios[0].Name
+	}
+	for _, p := range item.People {
+		switch p.Type {
+		case "Director":
+			d.Directors = append(d.Directors, domain.Credit{ID: p.ID, Name: p.Name})
+		case "Writer":
+			d.Writers = append(d.Writers, p.Name)
+		case "Actor", "GuestStar":
+			d.Cast = append(d.Cast, domain.Credit{ID: p.ID, Name: p.Name, Role: p.Role})
+		}
+	}
+	d.Chapters = mapChapters(item.Chapters)
+	d.Files = mapFiles(item.MediaSources)
+	return d
+}
+
+// mapChapters converts an item's chapter markers
+func mapChapters(chapters []ChapterInfo) []domain.Chapter {
+	var out []domain.Chapter
+	for _, c := range chapters {
+		out = append(out, domain.Chapter{
+			Title: c.Name,
+			Start: ticksToDuration(c.StartPositionTicks),
+		})
+	}
+	return out
+}
+
+// jellyfinSegmentKinds maps media segment types to segment kinds
+var jellyfinSegmentKinds = map[string]domain.SegmentKind{
+	"Intro":      domain.SegmentIntro,
+	"Outro":      domain.SegmentCredits,
+	"Recap":      domain.SegmentRecap,
+	"Preview":    domain.SegmentPreview,
+	"Commercial": domain.SegmentCommercial,
+}
+
+// MapSegments converts media segments, dropping unknown types
+func MapSegments(segments []MediaSegment) []domain.Segment {
+	var out []domain.Segment
+	for _, s := range segments {
+		kind, ok := jellyfinSegmentKinds[s.Type]
+		if !ok {
+			continue
+		}
+		out = append(out, domain.Segment{
+			Kind:  kind,
+			Start: ticksToDuration(s.StartTicks),
+			End:   ticksToDuration(s.EndTicks),
+		})
+	}
+	return out
+}
+
+// MapChannels converts Jellyfin Live TV channels to domain media items
+func MapChannels(items []Item, serverURL string) []*domain.MediaItem {
+	channels := make([]*domain.MediaItem, 0, len(items))
+	for _, item := range items {
+		ch := domain.MediaItem{
+			ID:            item.ID,
+			Title:         item.Name,
+			SortTitle:     item.SortName,
+			Type:          domain.MediaTypeChannel,
+			ChannelNumber: item.ChannelNumber,
+		}
+		if ch.SortTitle == "" {
+			ch.SortTitle = ch.Title
+		}
+		if p := item.CurrentProgram; p != nil {
+			ch.NowPlaying = p.Name
+			ch.Summary = p.Overview
+			ch.Duration = ticksToDuration(p.RunTimeTicks)
+		}
+		if item.ImageTags.Primary != "" {
+			ch.ThumbURL = fmt.Sprintf("%s/Items/%s/Images/Primary?tag=%s", serverURL, item.ID, item.ImageTags.Primary)
+		}
+		channels = append(channels, &ch)
+	}
+	return channels
+}
+
 // MapSearchResults converts Jellyfin search hints to domain media items
 func MapSearchResults(hints []SearchHint, serverURL string) []*domain.MediaItem {
 	items := make([]*domain.MediaItem, 0, len(hints))
@@ -334,11 +495,59 @@ func mapSearchHint(hint SearchHint, serverURL string) *domain.MediaItem {
 	return item
 }
 
+// mapProviderIDs picks IMDb, TMDB and TVDB out of Jellyfin's ProviderIds
+func mapProviderIDs(ids map[string]string) domain.ExternalIDs {
+	return domain.ExternalIDs{IMDb: ids["Imdb"], TMDB: ids["Tmdb"], TVDB: ids["Tvdb"]}
+}
+
 // ticksToDuration converts Jellyfin 100-nanosecond ticks to time.Duration
 func ticksToDuration(ticks int64) time.Duration {
 	return time.Duration(ticks * 100) // 100ns per tick
 }
 
+// durationToTicks converts a duration to Jellyfin ticks
+func durationToTicks(d time.Duration) int64 {
+	return int64(d / 100)
+}
+
+// mapVersions lists an item's media sources when it has several. A version
+// is identified by its MediaSourceId.
+func mapVersions(sources []MediaSource) []domain.MediaVersion {
+	if len(sources) < 2 {
+		return nil
+	}
+	return mapFiles(sources)
+}
+
+// mapFiles lists an item's files, however many
+func mapFiles(sources []MediaSource) []domain.MediaVersion {
+	if len(sources) == 0 {
+		return nil
+	}
+	versions := make([]domain.MediaVersion, 0, len(sources))
+	for _, src := range sources {
+		v := domain.MediaVersion{
+			ID:        src.ID,
+			Name:      src.Name,
+			FileSize:  src.Size,
+			Bitrate:   src.Bitrate / 1000,
+			Container: normalizeContainer(src.Container),
+		}
+		for _, stream := range src.MediaStreams {
+			switch {
+			case stream.Type == "Video" && v.VideoCodec == "":
+				v.VideoCodec = normalizeCodec(stream.Codec)
+				v.Width, v.Height = stream.Width, stream.Height
+			case stream.Type == "Audio" && v.AudioCodec == "":
+				v.AudioCodec = normalizeAudioCodec(stream.Codec)
+				v.AudioChannels = stream.Channels
+			}
+		}
+		versions = append(versions, v)
+	}
+	return versions
+}
+
 // extractVideoCodec extracts the video codec from item media streams
 func extractVideoCodec(item Item) string {
 	// Try MediaSources first
@@ -479,6 +688,23 @@ func extractResolution(item Item) (int, int) {
 	return 0, 0
 }
 
+// MapVideoItems converts Jellyfin items to playable domain media items
+// (movies and episodes), skipping anything else
+func MapVideoItems(items []Item, serverURL string) []*domain.MediaItem {
+	result := make([]*domain.MediaItem, 0, len(items))
+	for _, item := range items {
+		switch item.Type {
+		case "Movie":
+			movie := mapMovie(item, serverURL)
+			result = append(result, &movie)
+		case "Episode":
+			episode := mapEpisode(item, serverURL)
+			result = append(result, &episode)
+		}
+	}
+	return result
+}
+
 // MapPlaylists converts Jellyfin items to domain playlists
 func MapPlaylists(items []Item, serverURL string) []*domain.Playlist {
 	playlists := make([]*domain.Playlist, 0, len(items))
@@ -497,6 +723,7 @@ func mapPlaylist(item Item, serverURL string) domain.Playlist {
 	p := domain.Playlist{
 		ID:           item.ID,
 		Title:        item.Name,
+		Description:  item.Overview,
 		PlaylistType: "video", // Jellyfin playlists don't have a type field; default to video
 		Smart:        false,   // Jellyfin smart playlists would need different detection
 		ItemCount:    item.ChildCount,
@@ -530,3 +757,32 @@ func MapLibraryContent(items []Item, serverURL string) []domain.ListItem {
 	}
 	return result
 }
+
+// MapActivities converts the running scheduled tasks to domain activities;
+// idle tasks are dropped
+func MapActivities(tasks []ScheduledTask) []domain.Activity {
+	var out []domain.Activity
+	for _, t := range tasks {
+		if t.State != "Running" {
+			continue
+		}
+		kind := domain.ActivityOther
+		switch {
+		case t.Key == "RefreshLibrary":
+			kind = domain.ActivityScan
+		case t.Category == "Library":
+			kind = domain.ActivityRefresh
+		}
+		progress := -1
+		if t.CurrentProgressPercentage != nil {
+			progress = int(*t.CurrentProgressPercentage)
+		}
+		out = append(out, domain.Activity{
+			ID:       t.ID,
+			Kind:     kind,
+			Title:    t.Name,
+			Progress: progress,
+		})
+	}
+	return out
+}
@@ -0,0 +1,314 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/mediaserver/httpclient"
+)
+
+// syncPingInterval is how often a session re-measures the server clock and
+// reports its latency to the group
+const syncPingInterval = 30 * time.Second
+
+// SetSocketTransport sets the bare transport the SyncPlay WebSocket dials
+// through (see httpclient.DialWebSocket). Without one the stdlib defaults
+// apply, which ignore the configured TLS settings.
+func (c *Client) SetSocketTransport(t *http.Transport) {
+	c.socketTransport = t
+}
+
+// ListSyncGroups returns the SyncPlay groups the user can join
+func (c *Client) ListSyncGroups(ctx context.Context) ([]domain.SyncGroup, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/SyncPlay/List", nil)
+	if err != nil {
+		return nil, err
+	}
+	var dto []SyncPlayGroup
+	if err := json.Unmarshal(body, &dto); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	groups := make([]domain.SyncGroup, 0, len(dto))
+	for _, g := range dto {
+		groups = append(groups, domain.SyncGroup{
+			ID:           g.GroupID,
+			Name:         g.GroupName,
+			State:        g.State,
+			Participants: g.Participants,
+		})
+	}
+	return groups, nil
+}
+
+// JoinSyncGroup opens the session WebSocket and then joins the group: the
+// server only sends SyncPlay messages to sessions with a socket open. The
+// session lives until Leave or until the socket drops, not until ctx ends.
+func (c *Client) JoinSyncGroup(ctx context.Context, groupID string) (domain.SyncSession, error) {
+	groups, err := c.ListSyncGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s := &syncSession{
+		client: c,
+		events: make(chan domain.SyncEvent, 16),
+		done:   make(chan struct{}),
+	}
+	found := false
+	for _, g := range groups {
+		if g.ID == groupID {
+			s.group, found = g, true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("sync group %s: %w", groupID, domain.ErrItemNotFound)
+	}
+
+	query := url.Values{}
+	query.Set("api_key", c.authToken())
+	query.Set("deviceId", c.deviceID)
+	socketURL := "ws" + strings.TrimPrefix(c.baseURL, "http") + "/socket?" + query.Encode()
+	if s.ws, err = httpclient.DialWebSocket(context.Background(), c.socketTransport, socketURL, nil); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrServerOffline, err)
+	}
+	if err := s.measureClock(ctx); err != nil {
+		c.logger.Warn("failed to measure server clock", "error", err)
+	}
+	go s.readLoop()
+
+	if _, err := c.do(ctx, http.MethodPost, "/SyncPlay/Join", nil, map[string]string{"GroupId": groupID}, false); err != nil {
+		s.close()
+		return nil, err
+	}
+	go s.pingLoop()
+	c.logger.Info("joined sync group", "group", s.group.Name, "groupID", groupID)
+	return s, nil
+}
+
+// syncSession is membership in a SyncPlay group: commands arrive on the
+// socket, state reports go out over REST
+type syncSession struct {
+	client    *Client
+	ws        *httpclient.WebSocket
+	group     domain.SyncGroup
+	events    chan domain.SyncEvent
+	done      chan struct{}
+	closeOnce sync.Once
+	keepAlive sync.Once
+
+	mu             sync.Mutex
+	offset         time.Duration // Server clock minus the local one
+	rtt            time.Duration
+	playlistItemID string // Queue entry that state reports refer to
+}
+
+func (s *syncSession) Group() domain.SyncGroup { return s.group }
+
+func (s *syncSession) Events() <-chan domain.SyncEvent { return s.events }
+
+func (s *syncSession) Ready(ctx context.Context, position time.Duration, playing bool) error {
+	return s.report(ctx, "/SyncPlay/Ready", position, playing)
+}
+
+func (s *syncSession) Buffering(ctx context.Context, position time.Duration, playing bool) error {
+	return s.report(ctx, "/SyncPlay/Buffering", position, playing)
+}
+
+func (s *syncSession) RequestPause(ctx context.Context) error {
+	_, err := s.client.do(ctx, http.MethodPost, "/SyncPlay/Pause", nil, nil, false)
+	return err
+}
+
+func (s *syncSession) RequestUnpause(ctx context.Context) error {
+	_, err := s.client.do(ctx, http.MethodPost, "/SyncPlay/Unpause", nil, nil, false)
+	return err
+}
+
+// Leave leaves the group and closes the socket, which ends Events
+func (s *syncSession) Leave(ctx context.Context) error {
+	_, err := s.client.do(ctx, http.MethodPost, "/SyncPlay/Leave", nil, nil, false)
+	s.close()
+	return err
+}
+
+// report sends the player's state, stamped with the server's clock
+func (s *syncSession) report(ctx context.Context, path string, position time.Duration, playing bool) error {
+	s.mu.Lock()
+	body := SyncPlayBufferRequest{
+		When:           time.Now().Add(s.offset).UTC(),
+		PositionTicks:  durationToTicks(position),
+		IsPlaying:      playing,
+		PlaylistItemID: s.playlistItemID,
+	}
+	s.mu.Unlock()
+	_, err := s.client.do(ctx, http.MethodPost, path, nil, body, false)
+	return err
+}
+
+func (s *syncSession) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.ws.Close()
+	})
+}
+
+// readLoop turns socket messages into events until the socket closes
+func (s *syncSession) readLoop() {
+	defer close(s.events)
+	defer s.close()
+	for {
+		data, err := s.ws.ReadMessage()
+		if err != nil {
+			s.client.logger.Debug("sync socket closed", "error", err)
+			return
+		}
+		var msg SocketMessage
+		if json.Unmarshal(data, &msg) != nil {
+			continue
+		}
+		if msg.MessageType == "ForceKeepAlive" {
+			var secs int
+			if json.Unmarshal(msg.Data, &secs) == nil && secs > 0 {
+				s.keepAlive.Do(func() { go s.keepAliveLoop(time.Duration(secs) * time.Second / 2) })
+			}
+			continue
+		}
+
+		s.mu.Lock()
+		offset := s.offset
+		s.mu.Unlock()
+		event, playlistItemID, ok := mapSyncMessage(msg, offset)
+		if !ok {
+			continue
+		}
+		if playlistItemID != "" {
+			s.mu.Lock()
+			s.playlistItemID = playlistItemID
+			s.mu.Unlock()
+		}
+		select {
+		case s.events <- event:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// keepAliveLoop answers the server's ForceKeepAlive, without which it
+// drops the socket and the session with it
+func (s *syncSession) keepAliveLoop(every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if s.ws.WriteMessage([]byte(`{"MessageType":"KeepAlive"}`)) != nil {
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// pingLoop keeps the clock offset fresh and tells the group our latency,
+// which it adds to the lead time of its commands
+func (s *syncSession) pingLoop() {
+	ticker := time.NewTicker(syncPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := s.measureClock(ctx); err == nil {
+				s.mu.Lock()
+				ping := s.rtt.Milliseconds()
+				s.mu.Unlock()
+				s.client.do(ctx, http.MethodPost, "/SyncPlay/Ping", nil, map[string]int64{"Ping": ping}, false)
+			}
+			cancel()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// measureClock estimates the server's clock offset NTP-style from one
+// /GetUtcTime round trip
+func (s *syncSession) measureClock(ctx context.Context) error {
+	sent := time.Now()
+	body, err := s.client.do(ctx, http.MethodGet, "/GetUtcTime", nil, nil, false)
+	if err != nil {
+		return err
+	}
+	received := time.Now()
+	var t UtcTime
+	if err := json.Unmarshal(body, &t); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	s.mu.Lock()
+	s.offset = (t.RequestReceptionTime.Sub(sent) + t.ResponseTransmissionTime.Sub(received)) / 2
+	s.rtt = received.Sub(sent) - t.ResponseTransmissionTime.Sub(t.RequestReceptionTime)
+	s.mu.Unlock()
+	return nil
+}
+
+// mapSyncMessage maps a socket message to a session event, with the queue
+// entry it refers to. offset (server clock minus local) moves command
+// times onto the local clock. ok is false for messages a player ignores.
+func mapSyncMessage(msg SocketMessage, offset time.Duration) (event domain.SyncEvent, playlistItemID string, ok bool) {
+	switch msg.MessageType {
+	case "SyncPlayCommand":
+		var cmd SyncPlayCommand
+		if json.Unmarshal(msg.Data, &cmd) != nil {
+			return event, "", false
+		}
+		kinds := map[string]domain.SyncEventKind{
+			"Unpause": domain.SyncUnpause,
+			"Pause":   domain.SyncPause,
+			"Seek":    domain.SyncSeek,
+			"Stop":    domain.SyncStop,
+		}
+		kind, known := kinds[cmd.Command]
+		if !known {
+			return event, "", false
+		}
+		return domain.SyncEvent{
+			Kind:     kind,
+			Position: ticksToDuration(cmd.PositionTicks),
+			When:     cmd.When.Add(-offset),
+		}, cmd.PlaylistItemID, true
+
+	case "SyncPlayGroupUpdate":
+		var update SyncPlayGroupUpdate
+		if json.Unmarshal(msg.Data, &update) != nil {
+			return event, "", false
+		}
+		switch update.Type {
+		case "PlayQueue":
+			var queue SyncPlayQueue
+			if json.Unmarshal(update.Data, &queue) != nil {
+				return event, "", false
+			}
+			if queue.PlayingItemIndex < 0 || queue.PlayingItemIndex >= len(queue.Playlist) {
+				return domain.SyncEvent{Kind: domain.SyncStop}, "", true
+			}
+			entry := queue.Playlist[queue.PlayingItemIndex]
+			return domain.SyncEvent{
+				Kind:     domain.SyncQueue,
+				ItemID:   entry.ItemID,
+				Playing:  queue.IsPlaying,
+				Position: ticksToDuration(queue.StartPositionTicks),
+			}, entry.PlaylistItemID, true
+		case "GroupLeft", "NotInGroup", "GroupDoesNotExist", "LibraryAccessDenied":
+			return domain.SyncEvent{Kind: domain.SyncLeft}, "", true
+		}
+	}
+	return event, "", false
+}
@@ -0,0 +1,49 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// PublicUsers lists the users the server shows on its login screen. Hidden
+// users are left out; signing in as one still works through setup.
+func (f *AuthFlow) PublicUsers(ctx context.Context, serverURL string) ([]User, error) {
+	serverURL = strings.TrimRight(serverURL, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, serverURL+"/Users/Public", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Emby-Authorization", buildAuthHeader("", f.deviceID))
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrServerOffline, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		f.logger.Error("Jellyfin public users error", "status", resp.StatusCode, "body", string(body))
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var users []User
+	if err := json.Unmarshal(body, &users); err != nil {
+		return nil, fmt.Errorf("failed to parse users: %w", err)
+	}
+	return users, nil
+}
+
+// Authenticate signs in as username without prompting
+func (f *AuthFlow) Authenticate(ctx context.Context, serverURL, username, password string) (*AuthResult, error) {
+	return f.authenticate(ctx, strings.TrimRight(serverURL, "/"), username, password)
+}
@@ -0,0 +1,71 @@
+package mediaserver
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/kino/internal/config"
+)
+
+// remoteRTT is the round-trip time above which the auto sync profile
+// treats a server as remote. A LAN or nearby server answers well under it.
+const remoteRTT = 60 * time.Millisecond
+
+// remotePageSize is the listing page size on the remote profile: on a
+// distant server each page costs a round trip, the bytes less so
+const remotePageSize = 200
+
+// rttProbes is how many requests the measurement takes the best of. The
+// first also pays for the connection and TLS handshake.
+const rttProbes = 3
+
+// resolveSyncProfile turns the configured profile into the one to sync
+// with: auto measures the server and picks remote or full. A server that
+// can't be measured gets full, as before auto existed.
+func resolveSyncProfile(server config.ServerConfig, rt http.RoundTripper, logger *slog.Logger) string {
+	if server.SyncProfile != "" && server.SyncProfile != config.SyncProfileAuto {
+		return server.SyncProfile
+	}
+	if !strings.HasPrefix(server.URL, "http") {
+		return config.SyncProfileFull // A folder or other local source
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	rtt, err := measureRTT(ctx, rt, server.URL)
+	if err != nil {
+		logger.Debug("could not measure server round trip", "error", err)
+		return config.SyncProfileFull
+	}
+	profile := config.SyncProfileFull
+	if rtt > remoteRTT {
+		profile = config.SyncProfileRemote
+	}
+	logger.Info("picked sync profile", "profile", profile, "rtt", rtt)
+	return profile
+}
+
+// measureRTT returns the fastest of rttProbes requests to url. Any answer
+// counts, an authentication error included: only the time matters.
+func measureRTT(ctx context.Context, rt http.RoundTripper, url string) (time.Duration, error) {
+	client := &http.Client{Transport: rt}
+	best := time.Duration(0)
+	for range rttProbes {
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+		if err != nil {
+			return 0, err
+		}
+		start := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		if took := time.Since(start); best == 0 || took < best {
+			best = took
+		}
+	}
+	return best, nil
+}
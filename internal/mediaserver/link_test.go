@@ -0,0 +1,42 @@
+package mediaserver
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/kino/internal/config"
+)
+
+// Auto picks the remote profile for a server slower to answer than
+// remoteRTT, and full for a near one or one that can't be reached; a
+// configured profile is kept as is
+func TestResolveSyncProfile(t *testing.T) {
+	near := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized) // Answering at all is enough
+	}))
+	defer near.Close()
+	far := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(remoteRTT + 20*time.Millisecond)
+	}))
+	defer far.Close()
+
+	logger := slog.New(slog.DiscardHandler)
+	for _, tc := range []struct {
+		name    string
+		server  config.ServerConfig
+		profile string
+	}{
+		{"near", config.ServerConfig{URL: near.URL}, config.SyncProfileFull},
+		{"far", config.ServerConfig{URL: far.URL, SyncProfile: config.SyncProfileAuto}, config.SyncProfileRemote},
+		{"unreachable", config.ServerConfig{URL: "http://127.0.0.1:1"}, config.SyncProfileFull},
+		{"configured", config.ServerConfig{URL: far.URL, SyncProfile: config.SyncProfileFast}, config.SyncProfileFast},
+		{"folder", config.ServerConfig{URL: "/srv/media"}, config.SyncProfileFull},
+	} {
+		if got := resolveSyncProfile(tc.server, http.DefaultTransport, logger); got != tc.profile {
+			t.Errorf("%s: profile = %q, want %q", tc.name, got, tc.profile)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Package local is a media source backed by a folder of video files, for
+// using kino without a media server. Each folder at the top of the tree is
+// a library (or the tree itself, when its videos sit directly in it).
+// Titles, years and episode numbers come from file and folder names:
+//
+//	Movies/Heat (1995)/Heat.1995.1080p.mkv
+//	TV/The Wire/Season 2/The.Wire.S02E05.Undertow.mkv
+//
+// Watch state is kept in a file beside kino's cache.
+package local
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mmcdole/kino/internal/mediaserver/catalog"
+)
+
+// Client serves a folder tree as libraries. It implements
+// mediaserver.MediaSource.
+type Client struct {
+	*catalog.Browser
+}
+
+// NewClient returns a client for the folder at root. Watch state is kept
+// in stateDir.
+func NewClient(root, stateDir string, logger *slog.Logger) *Client {
+	sum := sha1.Sum([]byte(root))
+	statePath := filepath.Join(stateDir, "local-"+hex.EncodeToString(sum[:8])+".json")
+	scan := func(ctx context.Context) ([]catalog.Library, error) { return scan(root) }
+	return &Client{Browser: catalog.NewBrowser(scan, statePath, logger)}
+}
+
+// scan walks the tree into libraries
+func scan(root string) ([]catalog.Library, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a folder", root)
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var libraries []catalog.Library
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		videos, err := walk(filepath.Join(root, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if len(videos) > 0 {
+			libraries = append(libraries, catalog.Library{Key: e.Name(), Name: e.Name(), Videos: videos})
+		}
+	}
+	if len(libraries) == 0 {
+		// Videos directly in the tree: it is the one library
+		videos, err := walk(root)
+		if err != nil {
+			return nil, err
+		}
+		libraries = append(libraries, catalog.Library{Key: ".", Name: filepath.Base(root), Videos: videos})
+	}
+	return libraries, nil
+}
+
+// walk lists the videos under a library folder, skipping hidden files
+func walk(libPath string) ([]catalog.Video, error) {
+	var videos []catalog.Video
+	err := filepath.WalkDir(libPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Unreadable folders are skipped, not fatal
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != libPath {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !catalog.IsVideo(d.Name()) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(libPath, filepath.Dir(path))
+		var folders []string
+		if rel != "." {
+			folders = strings.Split(rel, string(filepath.Separator))
+		}
+		ext := filepath.Ext(d.Name())
+		videos = append(videos, catalog.Video{
+			Locator:   path,
+			Folders:   folders,
+			Name:      strings.TrimSuffix(d.Name(), ext),
+			ModTime:   info.ModTime(),
+			Size:      info.Size(),
+			Container: strings.TrimPrefix(strings.ToLower(ext), "."),
+		})
+		return nil
+	})
+	return videos, err
+}
+
+// Detect reports whether serverURL names a local folder: a path or a
+// file:// URL
+func Detect(serverURL string) error {
+	info, err := os.Stat(Path(serverURL))
+	if err != nil {
+		return errors.New("not a local folder")
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a folder", serverURL)
+	}
+	return nil
+}
+
+// Path returns the folder a configured URL names
+func Path(serverURL string) string {
+	return strings.TrimPrefix(serverURL, "file://")
+}
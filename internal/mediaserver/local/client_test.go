@@ -0,0 +1,126 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// tree lays out files under a temp folder
+func tree(t *testing.T, files ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, f := range files {
+		path := filepath.Join(root, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestClientScansMoviesAndShows(t *testing.T) {
+	ctx := context.Background()
+	root := tree(t,
+		"Movies/Heat (1995)/heat.mkv",
+		"Movies/The.Matrix.1999.1080p.BluRay.mp4",
+		"Movies/notes.txt",
+		"TV/The Wire/Season 2/The.Wire.S02E05.Undertow.mkv",
+		"TV/The Wire/Season 1/The.Wire.S01E02.The.Detail.mkv",
+		"TV/The Wire/Season 1/The.Wire.S01E01.The.Target.mkv",
+		"TV/Fargo.S01E01.720p.mkv",
+		"Empty/readme.md",
+	)
+	c := NewClient(root, t.TempDir(), nil)
+
+	libs, err := c.GetLibraries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(libs) != 2 || libs[0].Name != "Movies" || libs[0].Type != "movie" || libs[1].Name != "TV" || libs[1].Type != "show" {
+		t.Fatalf("libraries = %+v", libs)
+	}
+
+	movies, total, err := c.GetMovies(ctx, libs[0].ID, 0, 0)
+	if err != nil || total != 2 {
+		t.Fatalf("GetMovies = %d, %v", total, err)
+	}
+	if movies[0].Title != "Heat" || movies[0].Year != 1995 || movies[1].Title != "The Matrix" || movies[1].Year != 1999 {
+		t.Errorf("movies = %+v, %+v", movies[0], movies[1])
+	}
+	if page, _, _ := c.GetMovies(ctx, libs[0].ID, 1, 1); len(page) != 1 || page[0].Title != "The Matrix" {
+		t.Errorf("second page = %+v", page)
+	}
+
+	shows, total, err := c.GetShows(ctx, libs[1].ID, 0, 0)
+	if err != nil || total != 2 || shows[0].Title != "Fargo" || shows[1].Title != "The Wire" {
+		t.Fatalf("GetShows = %+v, %d, %v", shows, total, err)
+	}
+	wire := shows[1]
+	if wire.SeasonCount != 2 || wire.EpisodeCount != 3 || wire.UnwatchedCount != 3 {
+		t.Errorf("The Wire = %+v", wire)
+	}
+	seasons, err := c.GetSeasons(ctx, wire.ID)
+	if err != nil || len(seasons) != 2 || seasons[0].SeasonNum != 1 {
+		t.Fatalf("GetSeasons = %+v, %v", seasons, err)
+	}
+	episodes, err := c.GetEpisodes(ctx, seasons[0].ID)
+	if err != nil || len(episodes) != 2 || episodes[0].Title != "The Target" || episodes[1].EpisodeNum != 2 {
+		t.Fatalf("GetEpisodes = %+v, %v", episodes, err)
+	}
+
+	path, err := c.ResolvePlayableURL(ctx, episodes[0].ID)
+	if err != nil || filepath.Base(path) != "The.Wire.S01E01.The.Target.mkv" {
+		t.Errorf("ResolvePlayableURL = %q, %v", path, err)
+	}
+	if _, err := c.ResolvePlayableURL(ctx, "nope"); !errors.Is(err, domain.ErrItemNotFound) {
+		t.Errorf("unknown item: %v", err)
+	}
+}
+
+// Watch state outlives the client
+func TestClientPersistsWatchState(t *testing.T) {
+	ctx := context.Background()
+	root := tree(t, "TV/Fargo/Fargo.S01E01.mkv", "TV/Fargo/Fargo.S01E02.mkv")
+	state := t.TempDir()
+
+	c := NewClient(root, state, nil)
+	libs, _ := c.GetLibraries(ctx)
+	shows, _, _ := c.GetShows(ctx, libs[0].ID, 0, 0)
+	seasons, _ := c.GetSeasons(ctx, shows[0].ID)
+	episodes, _ := c.GetEpisodes(ctx, seasons[0].ID)
+	if err := c.MarkPlayed(ctx, episodes[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	c = NewClient(root, state, nil)
+	shows, _, _ = c.GetShows(ctx, libs[0].ID, 0, 0)
+	episodes, _ = c.GetEpisodes(ctx, seasons[0].ID)
+	if !episodes[0].IsPlayed || episodes[1].IsPlayed || shows[0].UnwatchedCount != 1 {
+		t.Errorf("after reload: played %v/%v, unwatched %d", episodes[0].IsPlayed, episodes[1].IsPlayed, shows[0].UnwatchedCount)
+	}
+
+	found, err := c.Search(ctx, "fargo")
+	if err != nil || len(found) != 2 {
+		t.Errorf("Search = %d, %v", len(found), err)
+	}
+	if err := c.AddToPlaylist(ctx, "p", nil); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("AddToPlaylist = %v, want ErrForbidden", err)
+	}
+}
+
+// Videos directly in the folder make it the one library
+func TestClientFlatFolder(t *testing.T) {
+	root := tree(t, "Heat.1995.mkv", "Alien.1979.mkv")
+	libs, err := NewClient(root, t.TempDir(), nil).GetLibraries(context.Background())
+	if err != nil || len(libs) != 1 || libs[0].Name != filepath.Base(root) || libs[0].Type != "movie" {
+		t.Errorf("GetLibraries = %+v, %v", libs, err)
+	}
+}
@@ -30,10 +30,15 @@ var (
 const (
 	plexTVBaseURL = "https://plex.tv"
 	pinEndpoint   = "/api/v2/pins"
+	pinTimeout    = 5 * time.Minute
+
+	// LinkURL is where the user enters a PIN to sign kino in
+	LinkURL = "https://plex.tv/link"
 )
 
 // AuthClient handles Plex authentication
 type AuthClient struct {
+	baseURL    string // plex.tv; overridden in tests
 	clientID   string
 	httpClient *http.Client
 	logger     *slog.Logger
@@ -45,6 +50,7 @@ func NewAuthClient(clientID string, logger *slog.Logger) *AuthClient {
 		logger = slog.Default()
 	}
 	return &AuthClient{
+		baseURL:  plexTVBaseURL,
 		clientID: normalizeClientID(clientID),
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
@@ -53,9 +59,17 @@ func NewAuthClient(clientID string, logger *slog.Logger) *AuthClient {
 	}
 }
 
+// SetTransport replaces the auth client's HTTP transport, keeping its
+// timeout. nil keeps the default.
+func (a *AuthClient) SetTransport(rt http.RoundTripper) {
+	if rt != nil {
+		a.httpClient.Transport = rt
+	}
+}
+
 // GetPIN generates a new authentication PIN
 func (a *AuthClient) GetPIN(ctx context.Context) (pin string, id int, err error) {
-	reqURL := fmt.Sprintf("%s%s", plexTVBaseURL, pinEndpoint)
+	reqURL := fmt.Sprintf("%s%s", a.baseURL, pinEndpoint)
 
 	data := url.Values{}
 	data.Set("strong", "false")
@@ -106,7 +120,7 @@ func (a *AuthClient) GetPIN(ctx context.Context) (pin string, id int, err error)
 
 // CheckPIN polls for PIN claim status and returns the auth token
 func (a *AuthClient) CheckPIN(ctx context.Context, pinID int) (token string, claimed bool, err error) {
-	reqURL := fmt.Sprintf("%s%s/%d", plexTVBaseURL, pinEndpoint, pinID)
+	reqURL := fmt.Sprintf("%s%s/%d", a.baseURL, pinEndpoint, pinID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
@@ -197,6 +211,22 @@ func NewAuthFlow(clientID string, logger *slog.Logger) *AuthFlow {
 	}
 }
 
+// SetTransport replaces the flow's HTTP transport (TLS settings)
+func (f *AuthFlow) SetTransport(rt http.RoundTripper) {
+	f.client.SetTransport(rt)
+}
+
+// RequestPIN generates a PIN for the user to enter at LinkURL. For callers
+// that show the PIN themselves; Run prints it.
+func (f *AuthFlow) RequestPIN(ctx context.Context) (pin string, id int, err error) {
+	return f.client.GetPIN(ctx)
+}
+
+// WaitPIN waits for the PIN to be claimed and returns the account token
+func (f *AuthFlow) WaitPIN(ctx context.Context, id int) (string, error) {
+	return f.client.WaitForPIN(ctx, id, pinTimeout)
+}
+
 // Run executes the Plex PIN-based authentication flow.
 // It prompts the user to visit plex.tv/link and enter the displayed PIN.
 func (f *AuthFlow) Run(ctx context.Context, serverURL string) (*AuthResult, error) {
@@ -213,14 +243,14 @@ func (f *AuthFlow) Run(ctx context.Context, serverURL string) (*AuthResult, erro
 
 	fmt.Println()
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Printf("  Go to: https://plex.tv/link\n")
+	fmt.Printf("  Go to: %s\n", LinkURL)
 	fmt.Printf("  Enter PIN: %s\n", pin)
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Println()
 	fmt.Println("Waiting for authentication...")
 
 	// Wait for PIN to be claimed (5 minutes timeout)
-	token, err := f.client.WaitForPIN(ctx, pinID, 5*time.Minute)
+	token, err := f.WaitPIN(ctx, pinID)
 	if err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
 	}
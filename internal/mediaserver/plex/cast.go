@@ -0,0 +1,153 @@
+package plex
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// castPlayers remembers where the players listed last are; remote control
+// goes to each player directly
+type castPlayers struct {
+	mu        sync.Mutex
+	byID      map[string]Player
+	commandID int // Sequence the companion protocol expects per controller
+}
+
+// CastTargets lists the Plex players the server knows on the network
+// that take playback commands
+func (c *Client) CastTargets(ctx context.Context) ([]domain.CastTarget, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/clients", nil)
+	if err != nil {
+		return nil, err
+	}
+	mc, err := c.parseResponse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cast.mu.Lock()
+	defer c.cast.mu.Unlock()
+	c.cast.byID = make(map[string]Player)
+	var targets []domain.CastTarget
+	for _, p := range mc.Server {
+		if !strings.Contains(p.ProtocolCapabilities, "playback") || p.MachineIdentifier == c.clientID {
+			continue
+		}
+		c.cast.byID[p.MachineIdentifier] = p
+		targets = append(targets, domain.CastTarget{ID: p.MachineIdentifier, Name: p.Name, Product: p.Product})
+	}
+	return targets, nil
+}
+
+// Cast tells a player to fetch the item from this server and play it
+func (c *Client) Cast(ctx context.Context, targetID, itemID string, offset time.Duration) error {
+	server, err := url.Parse(c.baseURL)
+	if err != nil {
+		return err
+	}
+	port := server.Port()
+	if port == "" {
+		port = "80"
+		if server.Scheme == "https" {
+			port = "443"
+		}
+	}
+	query := url.Values{}
+	query.Set("key", "/library/metadata/"+itemID)
+	query.Set("offset", strconv.FormatInt(offset.Milliseconds(), 10))
+	query.Set("machineIdentifier", c.machineIdentifier)
+	query.Set("protocol", server.Scheme)
+	query.Set("address", server.Hostname())
+	query.Set("port", port)
+	query.Set("token", c.authToken())
+	query.Set("type", "video")
+	_, err = c.playerCommand(ctx, targetID, "/player/playback/playMedia", query)
+	return err
+}
+
+// castPaths are the companion commands for domain.CastCommand; play/pause
+// has none and asks the player's timeline first
+var castPaths = map[domain.CastCommand]string{
+	domain.CastRewind:      "/player/playback/stepBack",
+	domain.CastFastForward: "/player/playback/stepForward",
+	domain.CastStop:        "/player/playback/stop",
+}
+
+// ControlCast sends a remote-control command to a player
+func (c *Client) ControlCast(ctx context.Context, targetID string, cmd domain.CastCommand) error {
+	if cmd != domain.CastPlayPause {
+		path, ok := castPaths[cmd]
+		if !ok {
+			return fmt.Errorf("unknown cast command %d", cmd)
+		}
+		_, err := c.playerCommand(ctx, targetID, path, url.Values{"type": {"video"}})
+		return err
+	}
+
+	body, err := c.playerCommand(ctx, targetID, "/player/timeline/poll", url.Values{"wait": {"0"}})
+	if err != nil {
+		return err
+	}
+	path := "/player/playback/pause"
+	if playerState(body) == "paused" {
+		path = "/player/playback/play"
+	}
+	_, err = c.playerCommand(ctx, targetID, path, url.Values{"type": {"video"}})
+	return err
+}
+
+// playerCommand sends a companion request to a player, listing the
+// players first when it isn't known yet
+func (c *Client) playerCommand(ctx context.Context, targetID, path string, query url.Values) ([]byte, error) {
+	c.cast.mu.Lock()
+	player, ok := c.cast.byID[targetID]
+	c.cast.mu.Unlock()
+	if !ok {
+		if _, err := c.CastTargets(ctx); err != nil {
+			return nil, err
+		}
+		c.cast.mu.Lock()
+		player, ok = c.cast.byID[targetID]
+		c.cast.mu.Unlock()
+		if !ok {
+			return nil, fmt.Errorf("player %s: %w", targetID, domain.ErrItemNotFound)
+		}
+	}
+
+	c.cast.mu.Lock()
+	c.cast.commandID++
+	query.Set("commandID", strconv.Itoa(c.cast.commandID))
+	c.cast.mu.Unlock()
+	base := "http://" + net.JoinHostPort(player.Address, strconv.Itoa(player.Port))
+	return c.doAt(ctx, base, http.MethodGet, path, query, false)
+}
+
+// playerState reads the video state ("playing", "paused", "stopped") from
+// a timeline poll
+func playerState(body []byte) string {
+	var timeline struct {
+		Timelines []struct {
+			Type  string `xml:"type,attr"`
+			State string `xml:"state,attr"`
+		} `xml:"Timeline"`
+	}
+	if xml.Unmarshal(body, &timeline) != nil {
+		return ""
+	}
+	for _, t := range timeline.Timelines {
+		if t.Type == "video" {
+			return t.State
+		}
+	}
+	return ""
+}
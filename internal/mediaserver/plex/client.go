@@ -9,11 +9,15 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/mediaserver/httpclient"
 )
 
 const (
@@ -23,6 +27,13 @@ const (
 	userAgent      = "Kino/1.0"
 )
 
+// Tag elements Plex embeds in every listed item that the mappers never
+// read. The fast sync profile also drops Media (codec, resolution, size).
+const (
+	unusedElements = "Genre,Country,Director,Writer,Role,Collection,Label"
+	mediaElements  = "Media"
+)
+
 // normalizeClientID ensures a usable X-Plex-Client-Identifier.
 // The identifier must be unique per install: plex.tv tracks devices by it,
 // so a shared static ID makes every kino install look like the same device
@@ -38,11 +49,18 @@ func normalizeClientID(clientID string) string {
 // domain.MetadataRepository, and domain.Scrobbler for Plex
 type Client struct {
 	baseURL           string
-	token             string
+	discoverURL       string // plex.tv Discover, for the watchlist; overridden in tests
+	tokenMu           sync.RWMutex
+	token             string // Replaced by SetToken after signing in again
 	clientID          string // unique per-install X-Plex-Client-Identifier
 	machineIdentifier string // fetched from /identity on init
 	httpClient        *http.Client
+	backoff           httpclient.Backoff // Shared pause after a 429
 	logger            *slog.Logger
+	fastSync          bool // Listings skip media details (see SetFastSync)
+	pageSize          int  // Library listing page size; 0 = the library default (see SetPageSize)
+	cast              castPlayers
+	socketTransport   *http.Transport // Bare transport for the notification socket
 }
 
 // NewClient creates a new Plex API client
@@ -51,16 +69,67 @@ func NewClient(baseURL, token, clientID string, logger *slog.Logger) *Client {
 		logger = slog.Default()
 	}
 	return &Client{
-		baseURL:  strings.TrimRight(baseURL, "/"),
-		token:    token,
-		clientID: normalizeClientID(clientID),
-		httpClient: &http.Client{
-			Timeout: defaultTimeout,
-		},
-		logger: logger,
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		discoverURL: discoverBaseURL,
+		token:       token,
+		clientID:    normalizeClientID(clientID),
+		httpClient:  httpclient.New(defaultTimeout, httpclient.Options{}),
+		logger:      logger,
 	}
 }
 
+// SetTransport replaces the client's HTTP transport, keeping its timeout.
+// Used to share one tuned connection pool across the app.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// SetToken replaces the token used for every later request, after the
+// user signs in again
+func (c *Client) SetToken(token string) {
+	c.tokenMu.Lock()
+	c.token = token
+	c.tokenMu.Unlock()
+}
+
+// authToken returns the current token
+func (c *Client) authToken() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.token
+}
+
+// SetFastSync trims library listings to what browsing needs, leaving out
+// media details (codecs, resolution, file size) shown in the inspector
+func (c *Client) SetFastSync(fast bool) {
+	c.fastSync = fast
+}
+
+// SetPageSize sets how many items a library listing page holds, for a
+// server where round trips cost more than bytes; 0 keeps the default
+func (c *Client) SetPageSize(n int) {
+	c.pageSize = n
+}
+
+// PageSize implements domain.PageSizeClient
+func (c *Client) PageSize() int {
+	return c.pageSize
+}
+
+// listingQuery adds the element exclusions for a library listing
+func (c *Client) listingQuery(query url.Values) url.Values {
+	if query == nil {
+		query = url.Values{}
+	}
+	exclude := unusedElements
+	if c.fastSync {
+		exclude += "," + mediaElements
+	}
+	query.Set("excludeElements", exclude)
+	query.Set("includeGuids", "1") // External IDs (IMDb, TMDB, TVDB)
+	return query
+}
+
 // FetchIdentity fetches and stores the server's machineIdentifier
 func (c *Client) FetchIdentity(ctx context.Context) error {
 	reqURL := fmt.Sprintf("%s/identity", c.baseURL)
@@ -96,7 +165,7 @@ func (c *Client) FetchIdentity(ctx context.Context) error {
 // setHeaders applies the standard Plex request headers
 func (c *Client) setHeaders(req *http.Request) {
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("X-Plex-Token", c.token)
+	req.Header.Set("X-Plex-Token", c.authToken())
 	req.Header.Set("X-Plex-Client-Identifier", c.clientID)
 	req.Header.Set("X-Plex-Product", "Kino")
 	req.Header.Set("X-Plex-Version", "1.0")
@@ -104,12 +173,21 @@ func (c *Client) setHeaders(req *http.Request) {
 }
 
 // do performs an authenticated HTTP request to the Plex server. All error
-// mapping lives here: 401 → domain.ErrAuthFailed, transport failures →
-// domain.ErrServerOffline (wrapped with the cause), any 2xx → success.
+// mapping lives here: 401 → domain.ErrAuthFailed, 404 →
+// domain.ErrItemNotFound, 5xx → domain.ErrServerError, transport failures →
+// domain.ErrServerOffline (each wrapped with the cause), any 2xx → success.
 // Idempotent requests (retry=true) are retried on network errors and 5xx
-// responses with exponential backoff.
+// responses with exponential backoff. A 429 pauses every request to the
+// server for its Retry-After (see httpclient.Backoff) and is retried like a
+// 5xx.
 func (c *Client) do(ctx context.Context, method, path string, query url.Values, retry bool) ([]byte, error) {
-	reqURL := fmt.Sprintf("%s%s", c.baseURL, path)
+	return c.doAt(ctx, c.baseURL, method, path, query, retry)
+}
+
+// doAt is do against another Plex host sharing the account token, such as
+// Discover
+func (c *Client) doAt(ctx context.Context, base, method, path string, query url.Values, retry bool) ([]byte, error) {
+	reqURL := fmt.Sprintf("%s%s", base, path)
 	if query != nil {
 		reqURL = fmt.Sprintf("%s?%s", reqURL, query.Encode())
 	}
@@ -135,6 +213,10 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 			}
 		}
 
+		if err := c.backoff.Wait(ctx); err != nil {
+			return nil, err
+		}
+
 		req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create request: %w", err)
@@ -160,7 +242,7 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 		case resp.StatusCode == http.StatusUnauthorized:
 			return nil, domain.ErrAuthFailed
 		case resp.StatusCode >= 500:
-			lastErr = fmt.Errorf("server error: %d - %s", resp.StatusCode, truncateForLog(body))
+			lastErr = fmt.Errorf("%w: %d - %s", domain.ErrServerError, resp.StatusCode, truncateForLog(body))
 			c.logger.Warn("plex server error",
 				"status", resp.StatusCode,
 				"attempt", attempt,
@@ -168,8 +250,26 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 				"path", path,
 			)
 			continue
+		case resp.StatusCode == http.StatusTooManyRequests:
+			lastErr = fmt.Errorf("%w: %d - rate limited", domain.ErrServerError, resp.StatusCode)
+			pause, ok := c.backoff.RateLimited(resp.Header)
+			c.logger.Warn("plex rate limited",
+				"pause", pause,
+				"attempt", attempt,
+				"method", method,
+				"path", path,
+			)
+			if !ok {
+				return nil, fmt.Errorf("%w, retry after %s", lastErr, pause)
+			}
+			continue
 		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			c.backoff.Succeeded()
 			return body, nil
+		case resp.StatusCode == http.StatusNotFound:
+			return nil, fmt.Errorf("%w: %s", domain.ErrItemNotFound, path)
+		case resp.StatusCode == http.StatusForbidden:
+			return nil, fmt.Errorf("%w: %s %s", domain.ErrForbidden, method, path)
 		default:
 			c.logger.Error("plex request error", "status", resp.StatusCode, "path", path, "body", truncateForLog(body))
 			return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
@@ -233,7 +333,7 @@ func (c *Client) GetMovies(ctx context.Context, libID string, offset, limit int)
 	// NO hardcoded fallback - let Plex use its natural default if limit=0
 
 	path := fmt.Sprintf("/library/sections/%s/all", libID)
-	body, err := c.doRequest(ctx, http.MethodGet, path, query)
+	body, err := c.doRequest(ctx, http.MethodGet, path, c.listingQuery(query))
 	if err != nil {
 		return nil, 0, err
 	}
@@ -264,7 +364,7 @@ func (c *Client) GetShows(ctx context.Context, libID string, offset, limit int)
 	// NO hardcoded fallback - let Plex use its natural default if limit=0
 
 	path := fmt.Sprintf("/library/sections/%s/all", libID)
-	body, err := c.doRequest(ctx, http.MethodGet, path, query)
+	body, err := c.doRequest(ctx, http.MethodGet, path, c.listingQuery(query))
 	if err != nil {
 		return nil, 0, err
 	}
@@ -282,6 +382,47 @@ func (c *Client) GetShows(ctx context.Context, libID string, offset, limit int)
 	return MapShows(container.Metadata, c.baseURL), totalSize, nil
 }
 
+// GetAiringEpisodes returns library episodes whose original air date falls
+// in the window. Plex only knows about episodes it has files for, so the
+// calendar shows recently aired episodes but nothing upcoming.
+func (c *Client) GetAiringEpisodes(ctx context.Context, from, to time.Time) ([]*domain.MediaItem, error) {
+	libs, err := c.GetLibraries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var episodes []*domain.MediaItem
+	for _, lib := range libs {
+		if lib.Type != "show" {
+			continue
+		}
+		// Plex filter operators: key ">>" / "<<" mean after / before
+		query := url.Values{}
+		query.Set("type", "4") // episodes
+		query.Set("originallyAvailableAt>>", from.AddDate(0, 0, -1).Format("2006-01-02"))
+		query.Set("originallyAvailableAt<<", to.AddDate(0, 0, 1).Format("2006-01-02"))
+		query.Set("sort", "originallyAvailableAt")
+
+		path := fmt.Sprintf("/library/sections/%s/all", lib.ID)
+		body, err := c.doRequest(ctx, http.MethodGet, path, query)
+		if err != nil {
+			return nil, err
+		}
+		container, err := c.parseResponse(body)
+		if err != nil {
+			return nil, err
+		}
+		for _, ep := range MapEpisodes(container.Metadata, c.baseURL) {
+			if ep.AiredAt >= from.Unix() && ep.AiredAt <= to.Unix() {
+				episodes = append(episodes, ep)
+			}
+		}
+	}
+
+	sort.SliceStable(episodes, func(i, j int) bool { return episodes[i].AiredAt < episodes[j].AiredAt })
+	return episodes, nil
+}
+
 // GetLibraryItemCount returns the total item count for a library section
 // without fetching the items (X-Plex-Container-Size=0 returns only totalSize).
 // libType is unused: /all already returns the section's native item type.
@@ -304,6 +445,50 @@ func (c *Client) GetLibraryItemCount(ctx context.Context, libID, libType string)
 	return container.TotalSize, nil
 }
 
+// CheckLibrary makes a conditional request for a library listing, sending
+// prev's ETag (If-None-Match) and Last-Modified (If-Modified-Since). A 304,
+// or a 200 carrying the same validator from a server that ignores the
+// conditions, means unchanged. Implements domain.ConditionalClient.
+func (c *Client) CheckLibrary(ctx context.Context, libID string, prev domain.Validator) (domain.Validator, bool, error) {
+	query := url.Values{}
+	query.Set("X-Plex-Container-Start", "0")
+	query.Set("X-Plex-Container-Size", "0")
+	reqURL := fmt.Sprintf("%s/library/sections/%s/all?%s", c.baseURL, libID, c.listingQuery(query).Encode())
+
+	if err := c.backoff.Wait(ctx); err != nil {
+		return domain.Validator{}, true, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return domain.Validator{}, true, fmt.Errorf("failed to create request: %w", err)
+	}
+	c.setHeaders(req)
+	if prev.ETag != "" {
+		req.Header.Set("If-None-Match", prev.ETag)
+	}
+	if prev.LastModified != "" {
+		req.Header.Set("If-Modified-Since", prev.LastModified)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return domain.Validator{}, true, fmt.Errorf("%w: %v", domain.ErrServerOffline, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return prev, false, nil
+	case resp.StatusCode == http.StatusUnauthorized:
+		return domain.Validator{}, true, domain.ErrAuthFailed
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return domain.Validator{}, true, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	current := domain.Validator{ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}
+	return current, current.IsZero() || current != prev, nil
+}
+
 // GetMixedContent returns paginated content (movies AND shows) from a library.
 // Note: Plex doesn't truly support "mixed" libraries at the API level like Jellyfin,
 // so this method fetches all items and returns both types. For pure movie or show
@@ -316,7 +501,7 @@ func (c *Client) GetMixedContent(ctx context.Context, libID string, offset, limi
 	}
 
 	path := fmt.Sprintf("/library/sections/%s/all", libID)
-	body, err := c.doRequest(ctx, http.MethodGet, path, query)
+	body, err := c.doRequest(ctx, http.MethodGet, path, c.listingQuery(query))
 	if err != nil {
 		return nil, 0, err
 	}
@@ -353,7 +538,7 @@ func (c *Client) GetSeasons(ctx context.Context, showID string) ([]*domain.Seaso
 // GetEpisodes returns all episodes for a season
 func (c *Client) GetEpisodes(ctx context.Context, seasonID string) ([]*domain.MediaItem, error) {
 	path := fmt.Sprintf("/library/metadata/%s/children", seasonID)
-	body, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	body, err := c.doRequest(ctx, http.MethodGet, path, c.listingQuery(nil))
 	if err != nil {
 		return nil, err
 	}
@@ -366,6 +551,142 @@ func (c *Client) GetEpisodes(ctx context.Context, seasonID string) ([]*domain.Me
 	return MapEpisodes(container.Metadata, c.baseURL), nil
 }
 
+// GetAllEpisodes lists every episode in a library with one request (type
+// 4 is episode), for the search index
+func (c *Client) GetAllEpisodes(ctx context.Context, libID string) ([]*domain.MediaItem, error) {
+	query := url.Values{}
+	query.Set("type", "4")
+	path := fmt.Sprintf("/library/sections/%s/all", libID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, c.listingQuery(query))
+	if err != nil {
+		return nil, err
+	}
+
+	container, err := c.parseResponse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return MapEpisodes(container.Metadata, c.baseURL), nil
+}
+
+// GetExtras returns a show's extras (behind-the-scenes, deleted scenes...)
+func (c *Client) GetExtras(ctx context.Context, itemID string) ([]*domain.MediaItem, error) {
+	path := fmt.Sprintf("/library/metadata/%s/extras", itemID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	container, err := c.parseResponse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return MapExtras(container.Metadata, c.baseURL), nil
+}
+
+// Ping checks the server is up with its lightweight identity endpoint.
+// It is not retried: a health check wants the server's state right now.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.do(ctx, http.MethodGet, "/identity", nil, false)
+	return err
+}
+
+// GetItemDetails fetches an item's full metadata, with the credits and
+// chapters that listings strip
+func (c *Client) GetItemDetails(ctx context.Context, itemID string) (*domain.ItemDetails, error) {
+	params := url.Values{}
+	params.Set("includeChapters", "1")
+	path := fmt.Sprintf("/library/metadata/%s", itemID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	container, err := c.parseResponse(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(container.Metadata) == 0 {
+		return nil, domain.ErrItemNotFound
+	}
+
+	return MapDetails(container.Metadata[0]), nil
+}
+
+// GetPersonItems returns the movies and shows of every library crediting a
+// person, found with the library's actor or director filter. Directors are
+// credited on movies only; a show's are on its episodes.
+func (c *Client) GetPersonItems(ctx context.Context, personID string, director bool) ([]domain.ListItem, error) {
+	libs, err := c.GetLibraries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter := "actor"
+	if director {
+		filter = "director"
+	}
+	var items []domain.ListItem
+	for _, lib := range libs {
+		if lib.Type != "movie" && (lib.Type != "show" || director) {
+			continue
+		}
+		query := url.Values{}
+		query.Set(filter, personID)
+		path := fmt.Sprintf("/library/sections/%s/all", lib.ID)
+		body, err := c.doRequest(ctx, http.MethodGet, path, c.listingQuery(query))
+		if err != nil {
+			return nil, err
+		}
+		container, err := c.parseResponse(body)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, MapLibraryContent(container.Metadata, c.baseURL)...)
+	}
+	return items, nil
+}
+
+// GetSimilar returns the movies or shows Plex considers similar to an item
+func (c *Client) GetSimilar(ctx context.Context, itemID string) ([]domain.ListItem, error) {
+	params := url.Values{}
+	params.Set("count", "20")
+	path := fmt.Sprintf("/library/metadata/%s/similar", itemID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	container, err := c.parseResponse(body)
+	if err != nil {
+		return nil, err
+	}
+	return MapLibraryContent(container.Metadata, c.baseURL), nil
+}
+
+// GetMarkers fetches an item's chapters and its intro and credits markers
+func (c *Client) GetMarkers(ctx context.Context, itemID string) (*domain.Markers, error) {
+	params := url.Values{}
+	params.Set("includeChapters", "1")
+	params.Set("includeMarkers", "1")
+	path := fmt.Sprintf("/library/metadata/%s", itemID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	container, err := c.parseResponse(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(container.Metadata) == 0 {
+		return nil, domain.ErrItemNotFound
+	}
+
+	return MapMarkers(container.Metadata[0]), nil
+}
+
 // Search performs a search across all libraries
 func (c *Client) Search(ctx context.Context, query string) ([]*domain.MediaItem, error) {
 	params := url.Values{}
@@ -387,6 +708,18 @@ func (c *Client) Search(ctx context.Context, query string) ([]*domain.MediaItem,
 
 // ResolvePlayableURL returns a direct playback URL for an item
 func (c *Client) ResolvePlayableURL(ctx context.Context, itemID string) (string, error) {
+	return c.resolveMediaURL(ctx, itemID, "")
+}
+
+// ResolveVersionURL returns a direct playback URL for one of an item's
+// files, identified by its Media ID
+func (c *Client) ResolveVersionURL(ctx context.Context, itemID, versionID string) (string, error) {
+	return c.resolveMediaURL(ctx, itemID, versionID)
+}
+
+// resolveMediaURL returns the direct URL of an item's file with the given
+// Media ID, or of its first file when mediaID is empty
+func (c *Client) resolveMediaURL(ctx context.Context, itemID, mediaID string) (string, error) {
 	path := fmt.Sprintf("/library/metadata/%s", itemID)
 	body, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -404,17 +737,51 @@ func (c *Client) ResolvePlayableURL(ctx context.Context, itemID string) (string,
 
 	// Extract media URL from the metadata
 	m := container.Metadata[0]
-	if len(m.Media) == 0 || len(m.Media[0].Part) == 0 {
+	if len(m.Media) == 0 {
+		return "", domain.ErrItemNotFound
+	}
+	media := m.Media[0]
+	if mediaID != "" {
+		i := slices.IndexFunc(m.Media, func(md Media) bool { return strconv.Itoa(md.ID) == mediaID })
+		if i < 0 {
+			return "", domain.ErrItemNotFound
+		}
+		media = m.Media[i]
+	}
+	if len(media.Part) == 0 {
 		return "", domain.ErrItemNotFound
 	}
 
-	mediaPath := m.Media[0].Part[0].Key
+	mediaPath := media.Part[0].Key
 	if mediaPath == "" {
 		return "", domain.ErrItemNotFound
 	}
 
 	// Add token to URL for direct play
-	return fmt.Sprintf("%s%s?X-Plex-Token=%s", c.baseURL, mediaPath, c.token), nil
+	return fmt.Sprintf("%s%s?X-Plex-Token=%s", c.baseURL, mediaPath, c.authToken()), nil
+}
+
+// ResolveTranscodedURL returns an HLS stream of the item that the server
+// transcodes down to maxKbps. Each call is its own transcode session.
+func (c *Client) ResolveTranscodedURL(ctx context.Context, itemID string, maxKbps int) (string, error) {
+	query := url.Values{}
+	query.Set("path", "/library/metadata/"+itemID)
+	query.Set("mediaIndex", "0")
+	query.Set("partIndex", "0")
+	query.Set("protocol", "hls")
+	query.Set("fastSeek", "1")
+	query.Set("directPlay", "0")
+	query.Set("directStream", "1")
+	query.Set("directStreamAudio", "1")
+	query.Set("videoQuality", "100")
+	query.Set("maxVideoBitrate", strconv.Itoa(maxKbps))
+	query.Set("location", "wan")
+	query.Set("session", fmt.Sprintf("kino-%s-%d", itemID, time.Now().UnixNano()))
+	query.Set("X-Plex-Client-Identifier", c.clientID)
+	query.Set("X-Plex-Product", "Kino")
+	query.Set("X-Plex-Platform", "Generic")
+	query.Set("X-Plex-Token", c.authToken())
+	return fmt.Sprintf("%s/video/:/transcode/universal/start.m3u8?%s", c.baseURL, query.Encode()), nil
 }
 
 // MarkPlayed marks an item as fully watched
@@ -437,6 +804,140 @@ func (c *Client) MarkUnplayed(ctx context.Context, itemID string) error {
 	return err
 }
 
+// SetUserRating rates an item 1-10 for the user; 0 clears the rating
+// (Plex's -1)
+func (c *Client) SetUserRating(ctx context.Context, itemID string, rating float64) error {
+	if rating == 0 {
+		rating = -1
+	}
+	query := url.Values{}
+	query.Set("key", itemID)
+	query.Set("identifier", "com.plexapp.plugins.library")
+	query.Set("rating", strconv.FormatFloat(rating, 'f', -1, 64))
+
+	_, err := c.doRequest(ctx, http.MethodPut, "/:/rate", query)
+	return err
+}
+
+// GetActivities lists what the server is running: library scans,
+// metadata refreshes, media analysis and conversions
+func (c *Client) GetActivities(ctx context.Context) ([]domain.Activity, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/activities", nil)
+	if err != nil {
+		return nil, err
+	}
+	container, err := c.parseResponse(body)
+	if err != nil {
+		return nil, err
+	}
+	return MapActivities(container.Activity), nil
+}
+
+// DeleteItem removes an item and its media files from the server. The
+// server refuses unless "Allow media deletion" is on and the token is the
+// owner's.
+func (c *Client) DeleteItem(ctx context.Context, itemID string) error {
+	path := fmt.Sprintf("/library/metadata/%s", itemID)
+	if _, err := c.do(ctx, http.MethodDelete, path, nil, false); err != nil {
+		return fmt.Errorf("failed to delete item: %w", err)
+	}
+	return nil
+}
+
+// GetContinueWatching returns the Continue Watching hub: in-progress movies
+// and episodes from every library, most recently watched first
+func (c *Client) GetContinueWatching(ctx context.Context) ([]*domain.MediaItem, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/hubs/continueWatching/items", nil)
+	if err != nil {
+		return nil, err
+	}
+	container, err := c.parseResponse(body)
+	if err != nil {
+		return nil, err
+	}
+	return MapVideoItems(container.Metadata, c.baseURL), nil
+}
+
+// RemoveFromContinueWatching hides an item from Continue Watching until it
+// is played again; its progress is kept
+func (c *Client) RemoveFromContinueWatching(ctx context.Context, itemID string) error {
+	query := url.Values{}
+	query.Set("ratingKey", itemID)
+	_, err := c.doRequest(ctx, http.MethodPut, "/actions/removeFromContinueWatching", query)
+	return err
+}
+
+// GetNextUp returns the On Deck episodes that have not been started: the
+// next episode of each show in progress. Started ones are in Continue
+// Watching.
+func (c *Client) GetNextUp(ctx context.Context) ([]*domain.MediaItem, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/library/onDeck", nil)
+	if err != nil {
+		return nil, err
+	}
+	container, err := c.parseResponse(body)
+	if err != nil {
+		return nil, err
+	}
+	var next []*domain.MediaItem
+	for _, item := range MapVideoItems(container.Metadata, c.baseURL) {
+		if item.Type == domain.MediaTypeEpisode && item.ViewOffset == 0 {
+			next = append(next, item)
+		}
+	}
+	return next, nil
+}
+
+// GetCollections returns the collections of every movie and show library
+func (c *Client) GetCollections(ctx context.Context) ([]domain.Collection, error) {
+	libs, err := c.GetLibraries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var collections []domain.Collection
+	for _, lib := range libs {
+		path := fmt.Sprintf("/library/sections/%s/collections", lib.ID)
+		body, err := c.doRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		container, err := c.parseResponse(body)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range container.Metadata {
+			collections = append(collections, domain.Collection{ID: m.RatingKey, Title: m.Title, LibraryID: lib.ID})
+		}
+	}
+	return collections, nil
+}
+
+// GetCollectionItems returns a collection's movies and shows
+func (c *Client) GetCollectionItems(ctx context.Context, collectionID string) ([]domain.ListItem, error) {
+	path := fmt.Sprintf("/library/collections/%s/children", collectionID)
+	body, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	container, err := c.parseResponse(body)
+	if err != nil {
+		return nil, err
+	}
+	return MapLibraryContent(container.Metadata, c.baseURL), nil
+}
+
+// SetViewOffset sets an item's resume position
+func (c *Client) SetViewOffset(ctx context.Context, itemID string, offset time.Duration) error {
+	query := url.Values{}
+	query.Set("key", itemID)
+	query.Set("identifier", "com.plexapp.plugins.library")
+	query.Set("time", strconv.FormatInt(offset.Milliseconds(), 10))
+	query.Set("state", "stopped")
+
+	_, err := c.doRequest(ctx, http.MethodGet, "/:/progress", query)
+	return err
+}
+
 // GetPlaylists returns all user playlists
 func (c *Client) GetPlaylists(ctx context.Context) ([]*domain.Playlist, error) {
 	body, err := c.doRequest(ctx, http.MethodGet, "/playlists", nil)
@@ -569,6 +1070,19 @@ func (c *Client) RemoveFromPlaylist(ctx context.Context, playlistID string, item
 	return nil
 }
 
+// UpdatePlaylist sets a playlist's title and summary
+func (c *Client) UpdatePlaylist(ctx context.Context, playlistID, title, description string) error {
+	query := url.Values{}
+	query.Set("title", title)
+	query.Set("summary", description)
+
+	path := fmt.Sprintf("/playlists/%s", playlistID)
+	if _, err := c.do(ctx, http.MethodPut, path, query, false); err != nil {
+		return fmt.Errorf("failed to update playlist: %w", err)
+	}
+	return nil
+}
+
 // DeletePlaylist deletes a playlist
 func (c *Client) DeletePlaylist(ctx context.Context, playlistID string) error {
 	path := fmt.Sprintf("/playlists/%s", playlistID)
@@ -2,12 +2,17 @@ package plex
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/base64"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
+	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mmcdole/kino/internal/domain"
 )
@@ -31,8 +36,10 @@ func TestMutations401MapToErrAuthFailed(t *testing.T) {
 
 	calls := map[string]error{
 		"MarkPlayed":     c.MarkPlayed(ctx, "1"),
+		"SetUserRating":  c.SetUserRating(ctx, "1", 8),
 		"AddToPlaylist":  c.AddToPlaylist(ctx, "p", []string{"1"}),
 		"DeletePlaylist": c.DeletePlaylist(ctx, "p"),
+		"UpdatePlaylist": c.UpdatePlaylist(ctx, "p", "t", "d"),
 	}
 	if _, err := c.CreatePlaylist(ctx, "t", []string{"1"}); err != nil {
 		calls["CreatePlaylist"] = err
@@ -85,6 +92,68 @@ func TestRetryPolicy(t *testing.T) {
 	}
 }
 
+// A 429 is retried after the server's Retry-After; one asking for too
+// long a wait fails at once
+func TestRateLimitRetry(t *testing.T) {
+	var calls atomic.Int32
+	retryAfter := "0"
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 || retryAfter != "0" {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"MediaContainer":{}}`))
+	}))
+	ctx := context.Background()
+
+	if _, err := c.GetLibraries(ctx); err != nil {
+		t.Fatalf("rate-limited request not retried: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("attempts = %d, want 2", got)
+	}
+
+	calls.Store(0)
+	retryAfter = "3600"
+	if _, err := c.GetLibraries(ctx); !errors.Is(err, domain.ErrServerError) {
+		t.Fatalf("err = %v, want a server error", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("attempts = %d, want 1 (no hour-long wait)", got)
+	}
+}
+
+// A library listing's ETag comes back as its validator and is sent as
+// If-None-Match, with a 304 meaning unchanged
+func TestCheckLibrary(t *testing.T) {
+	etag := `"v1"`
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/library/sections/1/all" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"MediaContainer":{"totalSize":3}}`))
+	}))
+	ctx := context.Background()
+
+	v, changed, err := c.CheckLibrary(ctx, "1", domain.Validator{})
+	if err != nil || v.ETag != etag || !changed {
+		t.Fatalf("first check = %+v changed=%v err=%v", v, changed, err)
+	}
+	if _, changed, err := c.CheckLibrary(ctx, "1", v); err != nil || changed {
+		t.Fatalf("unchanged library reported changed=%v err=%v", changed, err)
+	}
+	etag = `"v2"`
+	if got, changed, _ := c.CheckLibrary(ctx, "1", v); !changed || got.ETag != etag {
+		t.Fatalf("changed library = %+v changed=%v", got, changed)
+	}
+}
+
 // Scrobble endpoints must carry the identifier parameter some PMS versions
 // require.
 func TestScrobbleIdentifierParam(t *testing.T) {
@@ -104,6 +173,24 @@ func TestScrobbleIdentifierParam(t *testing.T) {
 	}
 }
 
+// Playlist edits PUT the title and summary onto the playlist itself.
+func TestUpdatePlaylistSetsTitleAndSummary(t *testing.T) {
+	var method, path string
+	var query url.Values
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, query = r.Method, r.URL.Path, r.URL.Query()
+	}))
+	if err := c.UpdatePlaylist(context.Background(), "77", "Date Night", "Films for Fridays"); err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodPut || path != "/playlists/77" {
+		t.Fatalf("request = %s %s, want PUT /playlists/77", method, path)
+	}
+	if query.Get("title") != "Date Night" || query.Get("summary") != "Films for Fridays" {
+		t.Fatalf("query = %v", query)
+	}
+}
+
 // Global search results include TV shows (parity with the Jellyfin backend).
 func TestSearchIncludesShows(t *testing.T) {
 	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -130,3 +217,522 @@ func TestSearchIncludesShows(t *testing.T) {
 		t.Fatal("show missing from search results")
 	}
 }
+
+// Listings ask for external GUIDs and map them to provider IDs
+func TestMoviesMapExternalIDs(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("includeGuids") != "1" {
+			t.Errorf("listing without includeGuids: %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"MediaContainer":{"totalSize":1,"Metadata":[
+			{"ratingKey":"1","title":"Heat","type":"movie","Guid":[
+				{"id":"imdb://tt0113277"},{"id":"tmdb://949"},{"id":"tvdb://123"}]}
+		]}}`))
+	}))
+
+	movies, _, err := c.GetMovies(context.Background(), "1", 0, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := domain.ExternalIDs{IMDb: "tt0113277", TMDB: "949", TVDB: "123"}
+	if len(movies) != 1 || movies[0].External != want {
+		t.Fatalf("got %+v, want one movie with %+v", movies, want)
+	}
+	if url := movies[0].ExternalURL(); url != "https://www.imdb.com/title/tt0113277/" {
+		t.Fatalf("ExternalURL = %q", url)
+	}
+}
+
+// Items with several files list each as a version, and a chosen version
+// plays its own part
+func TestVersionsMapAndResolve(t *testing.T) {
+	const heat = `{"MediaContainer":{"totalSize":1,"Metadata":[{"ratingKey":"1","title":"Heat","type":"movie","Media":[
+		{"id":10,"height":2160,"videoCodec":"hevc","audioCodec":"truehd","audioChannels":8,"container":"mkv",
+			"Part":[{"key":"/library/parts/100/file.mkv","file":"/movies/Heat (1995) - 4K.mkv","size":62277025792}]},
+		{"id":11,"height":1080,"videoCodec":"h264","audioCodec":"ac3","audioChannels":6,"container":"mp4",
+			"Part":[{"key":"/library/parts/110/file.mp4","file":"C:\\Movies\\Heat (1995).mp4","size":8589934592}]}
+	]}]}}`
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(heat))
+	}))
+	ctx := context.Background()
+
+	movies, _, err := c.GetMovies(ctx, "1", 0, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(movies) != 1 || len(movies[0].Versions) != 2 {
+		t.Fatalf("got %+v, want one movie with two versions", movies)
+	}
+	v := movies[0].Versions[1]
+	if v.ID != "11" || v.Name != "Heat (1995).mp4" || v.Label() != "1080p · H.264 · AC3 5.1 · MP4 · 8.0 GB" {
+		t.Fatalf("second version = %+v (%q)", v, v.Label())
+	}
+	if movies[0].Height != 2160 {
+		t.Errorf("item tech fields should describe the first version, height %d", movies[0].Height)
+	}
+
+	url, err := c.ResolveVersionURL(ctx, "1", "11")
+	if err != nil || !strings.Contains(url, "/library/parts/110/file.mp4") {
+		t.Fatalf("ResolveVersionURL = %q, %v", url, err)
+	}
+	if _, err := c.ResolveVersionURL(ctx, "1", "99"); !errors.Is(err, domain.ErrItemNotFound) {
+		t.Fatalf("unknown version: %v", err)
+	}
+}
+
+// Full metadata asks for chapters and maps the credits and media details
+// listings strip
+func TestItemDetailsMapsCreditsAndChapters(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/library/metadata/7" || r.URL.Query().Get("includeChapters") != "1" {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"MediaContainer":{"Metadata":[{"ratingKey":"7","title":"Heat","type":"movie",
+			"tagline":"A Los Angeles crime saga","studio":"Warner Bros.",
+			"Genre":[{"tag":"Crime"},{"tag":"Drama"}],
+			"Director":[{"id":40,"tag":"Michael Mann"}],
+			"Role":[{"id":41,"tag":"Al Pacino","role":"Vincent Hanna"}],
+			"Chapter":[{"index":1,"startTimeOffset":0},{"tag":"Bank","index":2,"startTimeOffset":90000}],
+			"Media":[{"id":10,"videoCodec":"hevc","height":2160,"container":"mkv","Part":[{"size":1000,"file":"/m/Heat.mkv"}]}]}]}}`))
+	}))
+
+	d, err := c.GetItemDetails(context.Background(), "7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &domain.ItemDetails{
+		Tagline:   "A Los Angeles crime saga",
+		Studio:    "Warner Bros.",
+		Genres:    []string{"Crime", "Drama"},
+		Directors: []domain.Credit{{ID: "40", Name: "Michael Mann"}},
+		Cast:      []domain.Credit{{ID: "41", Name: "Al Pacino", Role: "Vincent Hanna"}},
+		Chapters: []domain.Chapter{
+			{Title: "Chapter 1"},
+			{Title: "Bank", Start: 90 * time.Second},
+		},
+		Files: []domain.MediaVersion{{ID: "10", Name: "Heat.mkv", FileSize: 1000, Height: 2160, VideoCodec: "HEVC", Container: "mkv"}},
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Fatalf("got %+v, want %+v", d, want)
+	}
+}
+
+// Discovery lists only servers (owned first, shared ones with their own
+// token) and connects over the best address that answers: the LAN address
+// here is dead, so the direct remote one wins over the relay.
+func TestDiscoverServersAndPickConnection(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case resourcesEndpoint:
+			if r.Header.Get("X-Plex-Token") != "account-tok" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`[
+				{"name":"Friend's Server","provides":"server","owned":false,"accessToken":"shared-tok","connections":[]},
+				{"name":"Phone","provides":"player,controller","connections":[]},
+				{"name":"Home","provides":"server","owned":true,"presence":true,"connections":[
+					{"uri":"` + srv.URL + `/relay","relay":true},
+					{"uri":"http://127.0.0.1:1","local":true},
+					{"uri":"` + srv.URL + `/remote"}
+				]}
+			]`))
+		case "/remote/identity", "/relay/identity":
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	a := NewAuthClient("client1", nil)
+	a.baseURL = srv.URL
+
+	servers, err := a.GetServers(context.Background(), "account-tok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(servers) != 2 || servers[0].Name != "Home" || servers[1].AccessToken != "shared-tok" {
+		t.Fatalf("servers = %+v", servers)
+	}
+	if servers[0].AccessToken != "account-tok" {
+		t.Fatalf("owned server token = %q, want the account token", servers[0].AccessToken)
+	}
+
+	conn, err := a.PickConnection(context.Background(), servers[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn.URI != srv.URL+"/remote" {
+		t.Fatalf("picked %s, want the direct remote address", conn.URI)
+	}
+}
+
+// Home users list with any member's token; switching to a protected user
+// sends the PIN, and a wrong one maps to ErrAuthFailed
+func TestHomeUsersAndSwitch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Plex-Token") != "owner-tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == homeUsersEndpoint:
+			w.Write([]byte(`{"users":[
+				{"id":1,"uuid":"u-owner","title":"Owner","admin":true},
+				{"id":2,"uuid":"u-kid","title":"","username":"kid","protected":true}
+			]}`))
+		case r.Method == http.MethodPost && r.URL.Path == homeUsersEndpoint+"/u-kid/switch":
+			if r.URL.Query().Get("pin") != "1234" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"uuid":"u-kid","title":"kid","authToken":"kid-tok"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	a := NewAuthClient("client1", nil)
+	a.baseURL = srv.URL
+
+	users, err := a.HomeUsers(context.Background(), "owner-tok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || !users[0].Admin || users[1].Name != "kid" || !users[1].Protected {
+		t.Fatalf("users = %+v", users)
+	}
+
+	if _, err := a.SwitchHomeUser(context.Background(), "owner-tok", "u-kid", "0000"); !errors.Is(err, domain.ErrAuthFailed) {
+		t.Fatalf("wrong PIN err = %v, want ErrAuthFailed", err)
+	}
+	token, err := a.SwitchHomeUser(context.Background(), "owner-tok", "u-kid", "1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "kid-tok" {
+		t.Fatalf("token = %q, want kid-tok", token)
+	}
+}
+
+// Watchlisted titles are matched to the server by Plex GUID, and a server
+// item is watchlisted under the key its GUID ends in
+func TestWatchlistMatchesByGUID(t *testing.T) {
+	var added string
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == watchlistEndpoint:
+			w.Write([]byte(`{"MediaContainer":{"totalSize":2,"Metadata":[
+				{"ratingKey":"5d776b59","guid":"plex://movie/5d776b59","title":"Heat","type":"movie","year":1995},
+				{"ratingKey":"5d9c086c","guid":"plex://show/5d9c086c","title":"Severance","type":"show","year":2022}
+			]}}`))
+		case r.URL.Path == "/library/all" && r.URL.Query().Get("guid") == "plex://movie/5d776b59":
+			w.Write([]byte(`{"MediaContainer":{"Metadata":[{"ratingKey":"42","librarySectionID":1,"title":"Heat","type":"movie"}]}}`))
+		case r.URL.Path == "/library/all":
+			w.Write([]byte(`{"MediaContainer":{"size":0}}`))
+		case r.URL.Path == "/library/metadata/42":
+			w.Write([]byte(`{"MediaContainer":{"Metadata":[{"ratingKey":"42","guid":"plex://movie/5d776b59","title":"Heat","type":"movie"}]}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/actions/addToWatchlist":
+			added = r.URL.Query().Get("ratingKey")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	c.discoverURL = c.baseURL
+	ctx := context.Background()
+
+	items, err := c.GetWatchlist(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].LocalID != "42" || items[0].LibraryID != "1" ||
+		items[1].Available() || items[1].Type != domain.MediaTypeShow {
+		t.Fatalf("watchlist = %+v %+v", items[0], items[1])
+	}
+
+	id, err := c.AddToWatchlist(ctx, "42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "5d776b59" || added != "5d776b59" {
+		t.Fatalf("AddToWatchlist = %q, sent %q; want 5d776b59", id, added)
+	}
+}
+
+// DeleteItem sends DELETE for the item; a server that refuses it reports
+// ErrForbidden
+func TestDeleteItem(t *testing.T) {
+	var deleted string
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete && r.URL.Path == "/library/metadata/42":
+			deleted = "42"
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+
+	if err := c.DeleteItem(ctx, "42"); err != nil || deleted != "42" {
+		t.Fatalf("DeleteItem = %v, deleted %q", err, deleted)
+	}
+	if err := c.DeleteItem(ctx, "7"); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("refused delete = %v, want ErrForbidden", err)
+	}
+}
+
+// Activities map their type to a kind; indeterminate progress stays -1
+func TestGetActivities(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"MediaContainer":{"size":2,"Activity":[
+			{"uuid":"u1","type":"library.update.section","title":"Scanning Movies","subtitle":"Heat","progress":45},
+			{"uuid":"u2","type":"media.generate.bif","title":"Generating thumbnails","progress":-1}
+		]}}`))
+	}))
+
+	activities, err := c.GetActivities(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(activities) != 2 || activities[0].Kind != domain.ActivityScan || activities[0].Progress != 45 ||
+		activities[0].Subtitle != "Heat" || activities[1].Kind != domain.ActivityOther || activities[1].Progress != -1 {
+		t.Fatalf("activities = %+v", activities)
+	}
+}
+
+// The Continue Watching hub maps movies and episodes with their last view;
+// removing an item sends its rating key
+func TestContinueWatching(t *testing.T) {
+	var removed string
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/hubs/continueWatching/items":
+			w.Write([]byte(`{"MediaContainer":{"size":2,"Metadata":[
+				{"ratingKey":"10","type":"episode","title":"Pilot","grandparentTitle":"Lost","viewOffset":600000,"duration":2400000,"lastViewedAt":1700000200},
+				{"ratingKey":"20","type":"movie","title":"Heat","viewOffset":60000,"duration":9000000,"lastViewedAt":1700000100}
+			]}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/actions/removeFromContinueWatching":
+			removed = r.URL.Query().Get("ratingKey")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+
+	items, err := c.GetContinueWatching(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].Type != domain.MediaTypeEpisode || items[0].ShowTitle != "Lost" ||
+		items[0].LastViewedAt != 1700000200 || items[1].Type != domain.MediaTypeMovie {
+		t.Fatalf("items = %+v", items)
+	}
+	if err := c.RemoveFromContinueWatching(ctx, "20"); err != nil || removed != "20" {
+		t.Fatalf("RemoveFromContinueWatching = %v, removed %q", err, removed)
+	}
+}
+
+func TestNextUpAndCollections(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/library/onDeck":
+			w.Write([]byte(`{"MediaContainer":{"size":2,"Metadata":[
+				{"ratingKey":"10","type":"episode","title":"Pilot","grandparentTitle":"Lost","viewOffset":600000},
+				{"ratingKey":"11","type":"episode","title":"Cabin Pressure","grandparentTitle":"Fargo"}
+			]}}`))
+		case "/library/sections":
+			w.Write([]byte(`{"MediaContainer":{"size":1,"Directory":[{"key":"1","type":"movie","title":"Movies"}]}}`))
+		case "/library/sections/1/collections":
+			w.Write([]byte(`{"MediaContainer":{"size":1,"Metadata":[{"ratingKey":"500","type":"collection","title":"Heist Films"}]}}`))
+		case "/library/collections/500/children":
+			w.Write([]byte(`{"MediaContainer":{"size":1,"Metadata":[{"ratingKey":"20","type":"movie","title":"Heat"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+
+	next, err := c.GetNextUp(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(next) != 1 || next[0].ID != "11" {
+		t.Fatalf("next up = %+v, want only the unstarted episode", next)
+	}
+
+	collections, err := c.GetCollections(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(collections) != 1 || collections[0].ID != "500" || collections[0].LibraryID != "1" {
+		t.Fatalf("collections = %+v", collections)
+	}
+	items, err := c.GetCollectionItems(ctx, "500")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].GetTitle() != "Heat" {
+		t.Fatalf("collection items = %+v", items)
+	}
+}
+
+func TestGetSimilar(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/library/metadata/20/similar" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"MediaContainer":{"size":2,"Metadata":[
+			{"ratingKey":"21","type":"movie","title":"Ronin","year":1998},
+			{"ratingKey":"22","type":"movie","title":"Thief","year":1981}
+		]}}`))
+	}))
+
+	items, err := c.GetSimilar(context.Background(), "20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].GetTitle() != "Ronin" || items[1].GetYear() != 1981 {
+		t.Fatalf("similar = %+v", items)
+	}
+}
+
+func TestGetPersonItems(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/library/sections":
+			w.Write([]byte(`{"MediaContainer":{"Directory":[
+				{"key":"1","type":"movie","title":"Movies"},
+				{"key":"2","type":"show","title":"TV"},
+				{"key":"3","type":"artist","title":"Music"}
+			]}}`))
+		case "/library/sections/1/all":
+			if r.URL.Query().Get("director") != "7" {
+				t.Errorf("movies query = %q, want director=7", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"MediaContainer":{"Metadata":[
+				{"ratingKey":"20","type":"movie","title":"Heat","year":1995,"librarySectionID":1}
+			]}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	// Only movie libraries are searched for directors
+	items, err := c.GetPersonItems(context.Background(), "7", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].GetTitle() != "Heat" {
+		t.Fatalf("items = %+v", items)
+	}
+}
+
+// Casting lists the server's players that take playback commands and
+// sends them companion requests directly; play/pause resumes a paused
+// player and pauses a playing one
+func TestCast(t *testing.T) {
+	var requests []string
+	state := "playing"
+	player := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/player/timeline/poll":
+			w.Write([]byte(`<MediaContainer><Timeline type="music" state="stopped"/><Timeline type="video" state="` + state + `"/></MediaContainer>`))
+		case "/player/playback/playMedia":
+			q := r.URL.Query()
+			if q.Get("key") != "/library/metadata/42" || q.Get("offset") != "90000" || q.Get("machineIdentifier") != "machine1" || q.Get("commandID") == "" {
+				t.Errorf("playMedia query = %s", r.URL.RawQuery)
+			}
+		}
+	}))
+	defer player.Close()
+	playerURL, _ := url.Parse(player.URL)
+
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/clients" {
+			t.Errorf("unexpected server request %s", r.URL.Path)
+			return
+		}
+		w.Write([]byte(`{"MediaContainer": {"Server": [
+			{"name": "Shield", "address": "` + playerURL.Hostname() + `", "port": ` + playerURL.Port() + `, "machineIdentifier": "shield", "product": "Plex for Android (TV)", "protocolCapabilities": "timeline,playback,navigation"},
+			{"name": "Phone", "address": "10.0.0.9", "port": 32500, "machineIdentifier": "phone", "product": "Plex for iOS", "protocolCapabilities": "timeline,navigation"}
+		]}}`))
+	}))
+	ctx := context.Background()
+
+	targets, err := c.CastTargets(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(targets) != 1 || targets[0] != (domain.CastTarget{ID: "shield", Name: "Shield", Product: "Plex for Android (TV)"}) {
+		t.Fatalf("targets = %+v", targets)
+	}
+	if err := c.Cast(ctx, "shield", "42", 90*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ControlCast(ctx, "shield", domain.CastPlayPause); err != nil {
+		t.Fatal(err)
+	}
+	state = "paused"
+	if err := c.ControlCast(ctx, "shield", domain.CastPlayPause); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/player/playback/playMedia",
+		"/player/timeline/poll", "/player/playback/pause",
+		"/player/timeline/poll", "/player/playback/play"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("player requests = %q, want %q", requests, want)
+	}
+}
+
+// Library changes pushed on the notification socket report their section
+// once each; playback and unfinished items are left out, and the channel
+// closes when the server hangs up
+func TestWatchUpdates(t *testing.T) {
+	messages := []string{
+		`{"NotificationContainer":{"type":"playing","size":1}}`,
+		`{"NotificationContainer":{"type":"timeline","size":3,"TimelineEntry":[
+			{"identifier":"com.plexapp.plugins.library","sectionID":"2","itemID":"10","type":1,"state":5},
+			{"identifier":"com.plexapp.plugins.library","sectionID":2,"itemID":"11","type":1,"state":9},
+			{"identifier":"com.plexapp.plugins.library","sectionID":"3","itemID":"12","type":4,"state":1}]}}`,
+		`{"NotificationContainer":{"type":"activity","size":1,"ActivityNotification":[
+			{"event":"ended","Activity":{"type":"library.update.section","Context":{"librarySectionID":"4"}}}]}}`,
+	}
+	var token string
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.URL.Query().Get("X-Plex-Token")
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		sum := sha1.Sum([]byte(r.Header.Get("Sec-WebSocket-Key") + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+			"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n")
+		for _, msg := range messages {
+			rw.Write([]byte{0x81, 126, byte(len(msg) >> 8), byte(len(msg))})
+			rw.WriteString(msg)
+		}
+		rw.Flush()
+	}))
+
+	updates, err := c.WatchUpdates(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for update := range updates {
+		got = append(got, update.LibraryID)
+	}
+	if strings.Join(got, ",") != "2,4" || token != "tok" {
+		t.Fatalf("updates = %v, token %q", got, token)
+	}
+}
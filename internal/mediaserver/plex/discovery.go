@@ -0,0 +1,188 @@
+package plex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+const resourcesEndpoint = "/api/v2/resources"
+
+// connectTimeout bounds each connection probe during discovery
+const connectTimeout = 5 * time.Second
+
+// ErrNoConnection indicates none of a server's advertised addresses answered
+var ErrNoConnection = errors.New("no reachable connection")
+
+// Server is a Plex Media Server the account can access, as advertised by
+// plex.tv
+type Server struct {
+	Name        string
+	Owned       bool   // false for servers shared with the account
+	Online      bool   // plex.tv has heard from it recently
+	AccessToken string // Token for this server (differs from the account token on shared servers)
+	Connections []Connection
+}
+
+// Connection is one advertised address of a server
+type Connection struct {
+	URI   string
+	Local bool // On the server's LAN
+	Relay bool // Bandwidth-limited plex.tv relay
+}
+
+// GetServers lists the servers available to an account token, including
+// shared ones, with every advertised connection (LAN, remote, relay)
+func (a *AuthClient) GetServers(ctx context.Context, token string) ([]Server, error) {
+	reqURL := a.baseURL + resourcesEndpoint + "?includeHttps=1&includeRelay=1"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Plex-Client-Identifier", a.clientID)
+	req.Header.Set("X-Plex-Product", "Kino")
+	req.Header.Set("X-Plex-Version", "1.0")
+	req.Header.Set("X-Plex-Token", token)
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrServerOffline, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, domain.ErrAuthFailed
+	}
+	if resp.StatusCode != http.StatusOK {
+		a.logger.Error("resources request error", "status", resp.StatusCode, "body", string(body))
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var resources []ResourceDTO
+	if err := json.Unmarshal(body, &resources); err != nil {
+		return nil, fmt.Errorf("failed to parse resources: %w", err)
+	}
+
+	var servers []Server
+	for _, r := range resources {
+		if !strings.Contains(r.Provides, "server") {
+			continue // players, controllers
+		}
+		s := Server{Name: r.Name, Owned: r.Owned, Online: r.Presence, AccessToken: r.AccessToken}
+		if s.AccessToken == "" {
+			s.AccessToken = token
+		}
+		for _, c := range r.Connections {
+			s.Connections = append(s.Connections, Connection{URI: c.URI, Local: c.Local, Relay: c.Relay})
+		}
+		servers = append(servers, s)
+	}
+
+	// Owned servers first, then by name
+	sort.SliceStable(servers, func(i, j int) bool {
+		if servers[i].Owned != servers[j].Owned {
+			return servers[i].Owned
+		}
+		return strings.ToLower(servers[i].Name) < strings.ToLower(servers[j].Name)
+	})
+	a.logger.Info("discovered servers", "count", len(servers))
+	return servers, nil
+}
+
+// connectionRank orders connections by preference: LAN, then direct
+// remote, then relay
+func connectionRank(c Connection) int {
+	switch {
+	case c.Relay:
+		return 2
+	case c.Local:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// PickConnection probes a server's connections in parallel and returns the
+// most preferred one that answers: a LAN address when at home, the direct
+// remote address when away, the relay as a last resort.
+func (a *AuthClient) PickConnection(ctx context.Context, s Server) (Connection, error) {
+	if len(s.Connections) == 0 {
+		return Connection{}, ErrNoConnection
+	}
+
+	conns := append([]Connection(nil), s.Connections...)
+	sort.SliceStable(conns, func(i, j int) bool { return connectionRank(conns[i]) < connectionRank(conns[j]) })
+
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	ok := make([]chan bool, len(conns))
+	for i, c := range conns {
+		ok[i] = make(chan bool, 1)
+		go func(c Connection, result chan<- bool) {
+			result <- a.probe(ctx, c, s.AccessToken)
+		}(c, ok[i])
+	}
+
+	// Wait in preference order, so a slow LAN answer still beats a fast relay
+	for i, c := range conns {
+		if <-ok[i] {
+			a.logger.Info("selected server connection", "server", s.Name, "uri", c.URI, "local", c.Local, "relay", c.Relay)
+			return c, nil
+		}
+	}
+	return Connection{}, ErrNoConnection
+}
+
+// probe checks that a connection reaches the server
+func (a *AuthClient) probe(ctx context.Context, c Connection, token string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(c.URI, "/")+"/identity", nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Plex-Token", token)
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		a.logger.Debug("connection probe failed", "uri", c.URI, "error", err)
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// Discover signs in through plex.tv (PIN flow) and returns the account's
+// servers, for setup without a known server URL
+func (f *AuthFlow) Discover(ctx context.Context) (token string, servers []Server, err error) {
+	result, err := f.Run(ctx, "")
+	if err != nil {
+		return "", nil, err
+	}
+	servers, err = f.client.GetServers(ctx, result.Token)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+	return result.Token, servers, nil
+}
+
+// PickConnection chooses a reachable address for a discovered server
+func (f *AuthFlow) PickConnection(ctx context.Context, s Server) (Connection, error) {
+	return f.client.PickConnection(ctx, s)
+}
@@ -1,5 +1,7 @@
 package plex
 
+import "encoding/json"
+
 // MediaContainer is the root container for Plex API responses
 type MediaContainer struct {
 	Size                int         `json:"size"`
@@ -14,6 +16,59 @@ type MediaContainer struct {
 	MediaTagVersion     int         `json:"mediaTagVersion,omitempty"`
 	Directory           []Directory `json:"Directory,omitempty"`
 	Metadata            []Metadata  `json:"Metadata,omitempty"`
+	Activity            []Activity  `json:"Activity,omitempty"`
+	Server              []Player    `json:"Server,omitempty"`
+}
+
+// Player is a Plex app on the network that takes remote control (/clients)
+type Player struct {
+	Name                 string `json:"name"`
+	Address              string `json:"address"`
+	Port                 int    `json:"port"`
+	MachineIdentifier    string `json:"machineIdentifier"`
+	Product              string `json:"product"`
+	ProtocolCapabilities string `json:"protocolCapabilities"` // e.g. "timeline,playback,navigation"
+}
+
+// Activity is a task running on the server (/activities)
+type Activity struct {
+	UUID     string  `json:"uuid"`
+	Type     string  `json:"type"` // e.g. "library.update.section", "library.refresh.items"
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle,omitempty"`
+	Progress float64 `json:"progress"` // Percent; -1 when indeterminate
+}
+
+// Notification is one message on the notification WebSocket
+// (/:/websockets/notifications)
+type Notification struct {
+	NotificationContainer struct {
+		Type                 string                 `json:"type"` // e.g. "timeline", "activity", "playing"
+		TimelineEntry        []TimelineEntry        `json:"TimelineEntry,omitempty"`
+		ActivityNotification []ActivityNotification `json:"ActivityNotification,omitempty"`
+	} `json:"NotificationContainer"`
+}
+
+// TimelineEntry reports a library item changing state. Plex sends IDs as
+// numbers or strings depending on version.
+type TimelineEntry struct {
+	Identifier string      `json:"identifier"` // "com.plexapp.plugins.library" for library items
+	SectionID  json.Number `json:"sectionID"`  // -1 outside a library
+	ItemID     json.Number `json:"itemID"`
+	Type       int         `json:"type"`
+	State      int         `json:"state"` // 5 processed, 9 deleted
+}
+
+// ActivityNotification reports a server activity starting, progressing or
+// ending
+type ActivityNotification struct {
+	Event    string `json:"event"` // "started", "updated", "ended"
+	Activity struct {
+		Type    string `json:"type"`
+		Context struct {
+			LibrarySectionID json.Number `json:"librarySectionID"`
+		} `json:"Context"`
+	} `json:"Activity"`
 }
 
 // Guid represents an external identifier (IMDB, TMDB, TVDB, etc.)
@@ -51,6 +106,7 @@ type Metadata struct {
 	Guids                 []Guid   `json:"Guid,omitempty"` // External IDs (IMDB, TMDB, TVDB)
 	Studio                string   `json:"studio,omitempty"`
 	Type                  string   `json:"type"`
+	Subtype               string   `json:"subtype,omitempty"` // Kind of extra for clips, e.g. "deletedScene"
 	Title                 string   `json:"title"`
 	GrandparentKey        string   `json:"grandparentKey,omitempty"`
 	ParentKey             string   `json:"parentKey,omitempty"`
@@ -63,6 +119,7 @@ type Metadata struct {
 	Rating                float64  `json:"rating,omitempty"`         // Critic rating
 	Ratings               []Rating `json:"Rating,omitempty"`         // External ratings
 	AudienceRating        float64  `json:"audienceRating,omitempty"` // Audience rating
+	UserRating            float64  `json:"userRating,omitempty"`     // The user's own rating
 	ViewOffset            int      `json:"viewOffset,omitempty"`
 	LastViewedAt          int64    `json:"lastViewedAt,omitempty"`
 	Year                  int      `json:"year,omitempty"`
@@ -86,6 +143,35 @@ type Metadata struct {
 	LibrarySectionTitle   string   `json:"librarySectionTitle,omitempty"`
 	PlaylistItemID        int      `json:"playlistItemID,omitempty"`
 	Media                 []Media  `json:"Media,omitempty"`
+
+	// Credits and chapters, only on a single item's full metadata
+	Genre    []Tag     `json:"Genre,omitempty"`
+	Director []Tag     `json:"Director,omitempty"`
+	Writer   []Tag     `json:"Writer,omitempty"`
+	Role     []Tag     `json:"Role,omitempty"`
+	Chapter  []Chapter `json:"Chapter,omitempty"`
+	Marker   []Marker  `json:"Marker,omitempty"`
+}
+
+// Tag is a genre or person credited on an item
+type Tag struct {
+	ID   int    `json:"id,omitempty"` // Person ID, for cast and directors
+	Tag  string `json:"tag"`
+	Role string `json:"role,omitempty"` // Character played, for cast
+}
+
+// Marker is an intro or credits stretch of a video, detected by the server
+type Marker struct {
+	Type            string `json:"type"`            // "intro", "credits", "commercial"
+	StartTimeOffset int64  `json:"startTimeOffset"` // Milliseconds
+	EndTimeOffset   int64  `json:"endTimeOffset"`
+}
+
+// Chapter is a chapter marker of a video
+type Chapter struct {
+	Tag             string `json:"tag,omitempty"`
+	Index           int    `json:"index,omitempty"`
+	StartTimeOffset int64  `json:"startTimeOffset"` // Milliseconds
 }
 
 // Media represents media information (video streams, codecs, etc.)
@@ -156,3 +242,40 @@ type PlaylistMetadata struct {
 	AddedAt      int64  `json:"addedAt,omitempty"`
 	UpdatedAt    int64  `json:"updatedAt,omitempty"`
 }
+
+// ResourceDTO is a device from plex.tv's /api/v2/resources
+type ResourceDTO struct {
+	Name             string          `json:"name"`
+	ClientIdentifier string          `json:"clientIdentifier"`
+	Provides         string          `json:"provides"` // comma-separated, e.g. "server"
+	Owned            bool            `json:"owned"`
+	AccessToken      string          `json:"accessToken"`
+	Presence         bool            `json:"presence"`
+	Connections      []ConnectionDTO `json:"connections"`
+}
+
+// ConnectionDTO is one way to reach a resource
+type ConnectionDTO struct {
+	Protocol string `json:"protocol"`
+	Address  string `json:"address"`
+	Port     int    `json:"port"`
+	URI      string `json:"uri"`
+	Local    bool   `json:"local"`
+	Relay    bool   `json:"relay"`
+}
+
+// HomeUsersResponse is plex.tv's /api/v2/home/users
+type HomeUsersResponse struct {
+	Users []HomeUserDTO `json:"users"`
+}
+
+// HomeUserDTO is a member of a Plex Home
+type HomeUserDTO struct {
+	ID        int64  `json:"id"`
+	UUID      string `json:"uuid"`
+	Title     string `json:"title"`
+	Username  string `json:"username"`
+	Admin     bool   `json:"admin"`
+	Protected bool   `json:"protected"` // Switching asks for the user's PIN
+	AuthToken string `json:"authToken"` // Only in the switch response
+}
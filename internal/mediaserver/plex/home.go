@@ -0,0 +1,113 @@
+package plex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+const homeUsersEndpoint = "/api/v2/home/users"
+
+// HomeUser is a member of the account's Plex Home
+type HomeUser struct {
+	UUID      string
+	Name      string
+	Admin     bool
+	Protected bool // Switching needs the user's PIN
+}
+
+// HomeUsers lists the members of the Plex Home the token belongs to. Any
+// member's token works, so a switched-to user can switch back.
+func (a *AuthClient) HomeUsers(ctx context.Context, token string) ([]HomeUser, error) {
+	body, err := a.homeRequest(ctx, http.MethodGet, a.baseURL+homeUsersEndpoint, token)
+	if err != nil {
+		return nil, err
+	}
+
+	var home HomeUsersResponse
+	if err := json.Unmarshal(body, &home); err != nil {
+		return nil, fmt.Errorf("failed to parse home users: %w", err)
+	}
+	users := make([]HomeUser, 0, len(home.Users))
+	for _, u := range home.Users {
+		name := u.Title
+		if name == "" {
+			name = u.Username
+		}
+		users = append(users, HomeUser{UUID: u.UUID, Name: name, Admin: u.Admin, Protected: u.Protected})
+	}
+	return users, nil
+}
+
+// SwitchHomeUser signs in as another Home member, returning that user's
+// account token. pin is required for protected users and ignored otherwise.
+func (a *AuthClient) SwitchHomeUser(ctx context.Context, token, uuid, pin string) (string, error) {
+	reqURL := fmt.Sprintf("%s%s/%s/switch", a.baseURL, homeUsersEndpoint, url.PathEscape(uuid))
+	if pin != "" {
+		reqURL += "?" + url.Values{"pin": {pin}}.Encode()
+	}
+	body, err := a.homeRequest(ctx, http.MethodPost, reqURL, token)
+	if err != nil {
+		return "", err
+	}
+
+	var user HomeUserDTO
+	if err := json.Unmarshal(body, &user); err != nil {
+		return "", fmt.Errorf("failed to parse switch response: %w", err)
+	}
+	if user.AuthToken == "" {
+		return "", fmt.Errorf("switch response has no token")
+	}
+	a.logger.Info("switched home user", "user", user.Title)
+	return user.AuthToken, nil
+}
+
+// homeRequest sends an authenticated plex.tv request and returns the body.
+// A wrong PIN comes back as 401 and maps to ErrAuthFailed.
+func (a *AuthClient) homeRequest(ctx context.Context, method, reqURL, token string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Plex-Client-Identifier", a.clientID)
+	req.Header.Set("X-Plex-Product", "Kino")
+	req.Header.Set("X-Plex-Version", "1.0")
+	req.Header.Set("X-Plex-Token", token)
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrServerOffline, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, domain.ErrAuthFailed
+	case resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated:
+		a.logger.Error("home users request error", "status", resp.StatusCode, "body", string(body))
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return body, nil
+}
+
+// HomeUsers lists the members of the token's Plex Home
+func (f *AuthFlow) HomeUsers(ctx context.Context, token string) ([]HomeUser, error) {
+	return f.client.HomeUsers(ctx, token)
+}
+
+// SwitchHomeUser signs in as another Home member
+func (f *AuthFlow) SwitchHomeUser(ctx context.Context, token, uuid, pin string) (string, error) {
+	return f.client.SwitchHomeUser(ctx, token, uuid, pin)
+}
@@ -1,6 +1,7 @@
 package plex
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -54,6 +55,9 @@ func mapMovie(m Metadata, serverURL string) domain.MediaItem {
 		ViewOffset: time.Duration(m.ViewOffset) * time.Millisecond,
 		IsPlayed:   m.ViewCount > 0,
 		Type:       domain.MediaTypeMovie,
+		External:   mapGuids(m.Guids),
+
+		LastViewedAt: m.LastViewedAt,
 	}
 
 	if item.SortTitle == "" {
@@ -65,6 +69,7 @@ func mapMovie(m Metadata, serverURL string) domain.MediaItem {
 	} else if m.Rating > 0 {
 		item.Rating = m.Rating
 	}
+	item.UserRating = m.UserRating
 
 	if m.Thumb != "" {
 		item.ThumbURL = serverURL + m.Thumb
@@ -87,6 +92,7 @@ func mapMovie(m Metadata, serverURL string) domain.MediaItem {
 			item.FileSize = media.Part[0].Size
 		}
 	}
+	item.Versions = mapVersions(m.Media)
 
 	return item
 }
@@ -118,6 +124,8 @@ func mapShow(m Metadata, serverURL string) domain.Show {
 		SeasonCount:    m.ChildCount,
 		EpisodeCount:   m.LeafCount,
 		UnwatchedCount: m.LeafCount - m.ViewedLeafCount,
+		LastAddedAt:    m.AddedAt, // Plex moves a show's addedAt up when an episode arrives
+		External:       mapGuids(m.Guids),
 	}
 
 	if show.SortTitle == "" {
@@ -129,6 +137,7 @@ func mapShow(m Metadata, serverURL string) domain.Show {
 	} else if m.Rating > 0 {
 		show.Rating = m.Rating
 	}
+	show.UserRating = m.UserRating
 
 	if m.Thumb != "" {
 		show.ThumbURL = serverURL + m.Thumb
@@ -165,6 +174,7 @@ func mapSeason(m Metadata, serverURL string) domain.Season {
 		Title:          m.Title,
 		EpisodeCount:   m.LeafCount,
 		UnwatchedCount: m.LeafCount - m.ViewedLeafCount,
+		LastAddedAt:    m.AddedAt, // Moved up with each new episode, as for shows
 	}
 
 	if m.Thumb != "" {
@@ -187,6 +197,187 @@ func MapEpisodes(metadata []Metadata, serverURL string) []*domain.MediaItem {
 	return items
 }
 
+// plexExtraTypes names Plex's extra subtypes for display
+var plexExtraTypes = map[string]string{
+	"trailer":         "Trailer",
+	"deletedScene":    "Deleted Scene",
+	"interview":       "Interview",
+	"musicVideo":      "Music Video",
+	"behindTheScenes": "Behind the Scenes",
+	"sceneOrSample":   "Scene",
+	"featurette":      "Featurette",
+	"short":           "Short",
+}
+
+// MapExtras converts a show's extras (clips) to domain media items
+func MapExtras(metadata []Metadata, serverURL string) []*domain.MediaItem {
+	items := make([]*domain.MediaItem, 0, len(metadata))
+	for _, m := range metadata {
+		if m.Type != "clip" {
+			continue
+		}
+		item := domain.MediaItem{
+			ID:        m.RatingKey,
+			Title:     m.Title,
+			SortTitle: m.Title,
+			Summary:   m.Summary,
+			Year:      m.Year,
+			AddedAt:   m.AddedAt,
+			Duration:  time.Duration(m.Duration) * time.Millisecond,
+			IsPlayed:  m.ViewCount > 0,
+			Type:      domain.MediaTypeMovie, // A standalone clip, not part of a season
+			ExtraType: plexExtraTypes[m.Subtype],
+		}
+		if item.ExtraType == "" {
+			item.ExtraType = "Extra"
+		}
+		if m.Thumb != "" {
+			item.ThumbURL = serverURL + m.Thumb
+		}
+		items = append(items, &item)
+	}
+	return items
+}
+
+// MapDetails converts an item's full metadata to its domain details
+func MapDetails(m Metadata) *domain.ItemDetails {
+	d := &domain.ItemDetails{
+		Tagline:   m.Tagline,
+		Studio:    m.Studio,
+		Genres:    tagNames(m.Genre),
+		Directors: mapCredits(m.Director),
+		Writers:   tagNames(m.Writer),
+		Cast:      mapCredits(m.Role),
+	}
+	d.Chapters = mapChapters(m.Chapter)
+	d.Files = mapFiles(m.Media)
+	return d
+}
+
+// MapMarkers converts an item's chapters and intro/credits markers
+func MapMarkers(m Metadata) *domain.Markers {
+	markers := &domain.Markers{Chapters: mapChapters(m.Chapter)}
+	for _, mk := range m.Marker {
+		kind, ok := plexSegmentKinds[mk.Type]
+		if !ok {
+			continue
+		}
+		markers.Segments = append(markers.Segments, domain.Segment{
+			Kind:  kind,
+			Start: time.Duration(mk.StartTimeOffset) * time.Millisecond,
+			End:   time.Duration(mk.EndTimeOffset) * time.Millisecond,
+		})
+	}
+	return markers
+}
+
+// plexSegmentKinds maps Plex marker types to segment kinds
+var plexSegmentKinds = map[string]domain.SegmentKind{
+	"intro":      domain.SegmentIntro,
+	"credits":    domain.SegmentCredits,
+	"commercial": domain.SegmentCommercial,
+}
+
+// mapChapters converts chapter markers, naming untitled ones by index
+func mapChapters(chapters []Chapter) []domain.Chapter {
+	var out []domain.Chapter
+	for _, c := range chapters {
+		title := c.Tag
+		if title == "" {
+			title = fmt.Sprintf("Chapter %d", c.Index)
+		}
+		out = append(out, domain.Chapter{
+			Title: title,
+			Start: time.Duration(c.StartTimeOffset) * time.Millisecond,
+		})
+	}
+	return out
+}
+
+// tagNames lists the names of genre or credit tags
+func tagNames(tags []Tag) []string {
+	var names []string
+	for _, t := range tags {
+		names = append(names, t.Tag)
+	}
+	return names
+}
+
+// mapCredits converts person tags (cast, directors) to credits
+func mapCredits(tags []Tag) []domain.Credit {
+	var credits []domain.Credit
+	for _, t := range tags {
+		id := ""
+		if t.ID != 0 {
+			id = strconv.Itoa(t.ID)
+		}
+		credits = append(credits, domain.Credit{ID: id, Name: t.Tag, Role: t.Role})
+	}
+	return credits
+}
+
+// mapVersions lists an item's files when it has several. A version is
+// identified by its Media ID.
+func mapVersions(media []Media) []domain.MediaVersion {
+	if len(media) < 2 {
+		return nil
+	}
+	return mapFiles(media)
+}
+
+// mapFiles lists an item's files, however many
+func mapFiles(media []Media) []domain.MediaVersion {
+	if len(media) == 0 {
+		return nil
+	}
+	versions := make([]domain.MediaVersion, 0, len(media))
+	for _, md := range media {
+		v := domain.MediaVersion{
+			ID:            strconv.Itoa(md.ID),
+			Bitrate:       md.Bitrate,
+			Width:         md.Width,
+			Height:        md.Height,
+			VideoCodec:    normalizeCodec(md.VideoCodec),
+			AudioCodec:    normalizeAudioCodec(md.AudioCodec),
+			AudioChannels: md.AudioChannels,
+			Container:     normalizeContainer(md.Container),
+		}
+		if len(md.Part) > 0 {
+			v.FileSize = md.Part[0].Size
+			v.Name = fileName(md.Part[0].File)
+		}
+		versions = append(versions, v)
+	}
+	return versions
+}
+
+// fileName returns the last element of a server path, which may use
+// Windows separators
+func fileName(path string) string {
+	return path[strings.LastIndexAny(path, `/\`)+1:]
+}
+
+// mapGuids picks the provider IDs out of Plex's external GUIDs
+// ("imdb://tt0113277", "tmdb://949", "tvdb://73244")
+func mapGuids(guids []Guid) domain.ExternalIDs {
+	var ids domain.ExternalIDs
+	for _, g := range guids {
+		provider, id, ok := strings.Cut(g.ID, "://")
+		if !ok {
+			continue
+		}
+		switch provider {
+		case "imdb":
+			ids.IMDb = id
+		case "tmdb":
+			ids.TMDB = id
+		case "tvdb":
+			ids.TVDB = id
+		}
+	}
+	return ids
+}
+
 // mapEpisode converts a single episode metadata to domain media item
 func mapEpisode(m Metadata, serverURL string) domain.MediaItem {
 	item := domain.MediaItem{
@@ -207,17 +398,28 @@ func mapEpisode(m Metadata, serverURL string) domain.MediaItem {
 		SeasonNum:  m.ParentIndex,
 		EpisodeNum: m.Index,
 		ParentID:   m.ParentRatingKey,
+		External:   mapGuids(m.Guids),
+
+		LastViewedAt: m.LastViewedAt,
 	}
 
 	if item.SortTitle == "" {
 		item.SortTitle = item.Title
 	}
 
+	// originallyAvailableAt is a bare date in the server's local calendar
+	if m.OriginallyAvailableAt != "" {
+		if t, err := time.ParseInLocation("2006-01-02", m.OriginallyAvailableAt, time.Local); err == nil {
+			item.AiredAt = t.Unix()
+		}
+	}
+
 	if m.AudienceRating > 0 {
 		item.Rating = m.AudienceRating
 	} else if m.Rating > 0 {
 		item.Rating = m.Rating
 	}
+	item.UserRating = m.UserRating
 
 	if m.Thumb != "" {
 		item.ThumbURL = serverURL + m.Thumb
@@ -240,6 +442,7 @@ func mapEpisode(m Metadata, serverURL string) domain.MediaItem {
 			item.FileSize = media.Part[0].Size
 		}
 	}
+	item.Versions = mapVersions(m.Media)
 
 	return item
 }
@@ -392,6 +595,7 @@ func mapPlaylist(m Metadata, serverURL string) domain.Playlist {
 	return domain.Playlist{
 		ID:           m.RatingKey,
 		Title:        m.Title,
+		Description:  m.Summary,
 		PlaylistType: "video",
 		Smart:        false,
 		ItemCount:    m.LeafCount,
@@ -417,3 +621,31 @@ func MapLibraryContent(metadata []Metadata, serverURL string) []domain.ListItem
 	}
 	return result
 }
+
+// MapActivities converts Plex activities to domain activities
+func MapActivities(activities []Activity) []domain.Activity {
+	out := make([]domain.Activity, 0, len(activities))
+	for _, a := range activities {
+		kind := domain.ActivityOther
+		switch {
+		case strings.HasPrefix(a.Type, "library.update.section"):
+			kind = domain.ActivityScan
+		case strings.HasPrefix(a.Type, "library.refresh"), strings.HasPrefix(a.Type, "library.update.item"):
+			kind = domain.ActivityRefresh
+		case strings.Contains(a.Type, "transcode"), strings.Contains(a.Type, "optimize"):
+			kind = domain.ActivityTranscode
+		}
+		progress := -1
+		if a.Progress >= 0 {
+			progress = int(a.Progress)
+		}
+		out = append(out, domain.Activity{
+			ID:       a.UUID,
+			Kind:     kind,
+			Title:    a.Title,
+			Subtitle: a.Subtitle,
+			Progress: progress,
+		})
+	}
+	return out
+}
@@ -0,0 +1,102 @@
+package plex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/mediaserver/httpclient"
+)
+
+// Timeline entry states that change what a library lists
+const (
+	timelineProcessed = 5 // Item added or updated and done processing
+	timelineDeleted   = 9
+)
+
+// SetSocketTransport sets the bare transport the notification WebSocket
+// dials through (see httpclient.DialWebSocket). Without one the stdlib
+// defaults apply, which ignore the configured TLS settings.
+func (c *Client) SetSocketTransport(t *http.Transport) {
+	c.socketTransport = t
+}
+
+// WatchUpdates opens the server's notification WebSocket and reports the
+// libraries whose content changes: timeline entries for items added,
+// updated or removed, and finished library scans
+func (c *Client) WatchUpdates(ctx context.Context) (<-chan domain.LiveUpdate, error) {
+	query := url.Values{}
+	query.Set("X-Plex-Token", c.authToken())
+	socketURL := "ws" + strings.TrimPrefix(c.baseURL, "http") + "/:/websockets/notifications?" + query.Encode()
+	ws, err := httpclient.DialWebSocket(ctx, c.socketTransport, socketURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrServerOffline, err)
+	}
+
+	updates := make(chan domain.LiveUpdate, 16)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			ws.Close() // Unblocks the read loop
+		case <-done:
+		}
+	}()
+	go func() {
+		defer close(updates)
+		defer ws.Close()
+		defer close(done)
+		for {
+			data, err := ws.ReadMessage()
+			if err != nil {
+				c.logger.Debug("notification socket closed", "error", err)
+				return
+			}
+			for _, libID := range changedLibraries(data) {
+				select {
+				case updates <- domain.LiveUpdate{LibraryID: libID}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return updates, nil
+}
+
+// changedLibraries reads the library sections a notification touches,
+// each once; messages that change no listing give none
+func changedLibraries(data []byte) []string {
+	var msg Notification
+	if json.Unmarshal(data, &msg) != nil {
+		return nil
+	}
+	n := msg.NotificationContainer
+	seen := make(map[string]bool)
+	var libIDs []string
+	add := func(id json.Number) {
+		if s := id.String(); s != "" && !strings.HasPrefix(s, "-") && !seen[s] {
+			seen[s] = true
+			libIDs = append(libIDs, s)
+		}
+	}
+	switch n.Type {
+	case "timeline":
+		for _, e := range n.TimelineEntry {
+			if e.Identifier == "com.plexapp.plugins.library" && (e.State == timelineProcessed || e.State == timelineDeleted) {
+				add(e.SectionID)
+			}
+		}
+	case "activity":
+		for _, a := range n.ActivityNotification {
+			if a.Event == "ended" && strings.HasPrefix(a.Activity.Type, "library.") {
+				add(a.Activity.Context.LibrarySectionID)
+			}
+		}
+	}
+	return libIDs
+}
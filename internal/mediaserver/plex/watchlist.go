@@ -0,0 +1,126 @@
+package plex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+const (
+	discoverBaseURL   = "https://discover.provider.plex.tv"
+	watchlistEndpoint = "/library/sections/watchlist/all"
+	watchlistPageSize = 100
+)
+
+// GetWatchlist lists the account's Discover watchlist. Each title is
+// looked up on the server by its Plex GUID (plex://movie/…), which the
+// server's Plex agent shares with Discover.
+func (c *Client) GetWatchlist(ctx context.Context) ([]*domain.WatchlistItem, error) {
+	var items []*domain.WatchlistItem
+	for start := 0; ; start += watchlistPageSize {
+		query := url.Values{}
+		query.Set("sort", "watchlistedAt:desc")
+		query.Set("X-Plex-Container-Start", strconv.Itoa(start))
+		query.Set("X-Plex-Container-Size", strconv.Itoa(watchlistPageSize))
+		body, err := c.doAt(ctx, c.discoverURL, http.MethodGet, watchlistEndpoint, query, true)
+		if err != nil {
+			return nil, err
+		}
+		container, err := c.parseResponse(body)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range container.Metadata {
+			item, err := c.matchWatchlisted(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		if len(container.Metadata) < watchlistPageSize || start+len(container.Metadata) >= container.TotalSize {
+			return items, nil
+		}
+	}
+}
+
+// matchWatchlisted maps a Discover entry and finds the server's copy
+func (c *Client) matchWatchlisted(ctx context.Context, m Metadata) (*domain.WatchlistItem, error) {
+	item := &domain.WatchlistItem{
+		ID:      m.RatingKey,
+		Title:   m.Title,
+		Year:    m.Year,
+		Type:    domain.MediaTypeMovie,
+		Summary: m.Summary,
+		AddedAt: m.AddedAt,
+	}
+	if m.Type == "show" {
+		item.Type = domain.MediaTypeShow
+	}
+	if m.GUID == "" {
+		return item, nil
+	}
+
+	query := url.Values{}
+	query.Set("guid", m.GUID)
+	body, err := c.doRequest(ctx, http.MethodGet, "/library/all", query)
+	if err != nil {
+		return nil, err
+	}
+	container, err := c.parseResponse(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(container.Metadata) > 0 {
+		local := container.Metadata[0]
+		item.LocalID = local.RatingKey
+		item.LibraryID = strconv.Itoa(local.LibrarySectionID)
+	}
+	return item, nil
+}
+
+// AddToWatchlist watchlists a server movie or show through Discover, which
+// knows it by the last element of its Plex GUID
+func (c *Client) AddToWatchlist(ctx context.Context, itemID string) (string, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/library/metadata/"+itemID, nil)
+	if err != nil {
+		return "", err
+	}
+	container, err := c.parseResponse(body)
+	if err != nil {
+		return "", err
+	}
+	if len(container.Metadata) == 0 {
+		return "", domain.ErrItemNotFound
+	}
+	guid := container.Metadata[0].GUID
+	if !strings.HasPrefix(guid, "plex://") {
+		// Items matched by a legacy agent have no Discover counterpart
+		return "", fmt.Errorf("%s is not matched with the Plex agent, so it cannot be watchlisted", container.Metadata[0].Title)
+	}
+	watchlistID := guid[strings.LastIndex(guid, "/")+1:]
+	if err := c.setWatchlisted(ctx, watchlistID, true); err != nil {
+		return "", err
+	}
+	return watchlistID, nil
+}
+
+// RemoveFromWatchlist drops a title from the Discover watchlist
+func (c *Client) RemoveFromWatchlist(ctx context.Context, watchlistID string) error {
+	return c.setWatchlisted(ctx, watchlistID, false)
+}
+
+func (c *Client) setWatchlisted(ctx context.Context, watchlistID string, add bool) error {
+	action := "/actions/removeFromWatchlist"
+	if add {
+		action = "/actions/addToWatchlist"
+	}
+	query := url.Values{}
+	query.Set("ratingKey", watchlistID)
+	_, err := c.doAt(ctx, c.discoverURL, http.MethodPut, action, query, false)
+	return err
+}
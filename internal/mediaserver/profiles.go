@@ -0,0 +1,132 @@
+package mediaserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/mediaserver/jellyfin"
+	"github.com/mmcdole/kino/internal/mediaserver/plex"
+)
+
+// ErrProfilesUnsupported indicates the configured server type has no user
+// switching
+var ErrProfilesUnsupported = errors.New("this server type does not support switching users")
+
+// Profile is a user of the configured server that kino can sign in as:
+// a Plex Home member or a Jellyfin user
+type Profile struct {
+	ID      string // Becomes server.user_id, which also namespaces the cache
+	Name    string
+	Secret  string // What switching asks for ("PIN", "password"), or "" when open
+	Current bool   // The user kino is signed in as
+}
+
+// ProfileSwitcher lists a server's users and signs in as one of them
+type ProfileSwitcher interface {
+	Profiles(ctx context.Context) ([]Profile, error)
+	// Switch signs in as p; secret is the PIN or password when p needs one
+	Switch(ctx context.Context, p Profile, secret string) (*AuthResult, error)
+}
+
+// NewProfileSwitcher returns the user switcher of the configured server's
+// source, or ErrProfilesUnsupported
+func NewProfileSwitcher(cfg *config.Config, logger *slog.Logger) (ProfileSwitcher, error) {
+	source, ok := Lookup(cfg.Server.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown server type: %s", cfg.Server.Type)
+	}
+	if source.NewProfileSwitcher == nil {
+		return nil, ErrProfilesUnsupported
+	}
+	transport, err := NewTransport(cfg.Server)
+	if err != nil {
+		return nil, err
+	}
+	return source.NewProfileSwitcher(cfg, transport, logger), nil
+}
+
+// plexProfiles switches between the members of a Plex Home. Switching
+// returns the member's account token, which the owner's server accepts and
+// which can list the Home again to switch back.
+type plexProfiles struct {
+	flow   *plex.AuthFlow
+	token  string
+	userID string
+}
+
+func newPlexProfiles(cfg *config.Config, transport http.RoundTripper, logger *slog.Logger) ProfileSwitcher {
+	flow := plex.NewAuthFlow(cfg.Server.DeviceID, logger)
+	flow.SetTransport(transport)
+	return &plexProfiles{flow: flow, token: cfg.Server.Token, userID: cfg.Server.UserID}
+}
+
+func (p *plexProfiles) Profiles(ctx context.Context) ([]Profile, error) {
+	users, err := p.flow.HomeUsers(ctx, p.token)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]Profile, 0, len(users))
+	for _, u := range users {
+		profile := Profile{
+			ID:   u.UUID,
+			Name: u.Name,
+			// Before any switch kino is signed in as the account owner
+			Current: u.UUID == p.userID || (p.userID == "" && u.Admin),
+		}
+		if u.Protected {
+			profile.Secret = "PIN"
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+func (p *plexProfiles) Switch(ctx context.Context, profile Profile, secret string) (*AuthResult, error) {
+	token, err := p.flow.SwitchHomeUser(ctx, p.token, profile.ID, secret)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthResult{Token: token, UserID: profile.ID, Username: profile.Name}, nil
+}
+
+// jellyfinProfiles signs in as another of the server's users with their
+// password
+type jellyfinProfiles struct {
+	flow      *jellyfin.AuthFlow
+	serverURL string
+	userID    string
+}
+
+func newJellyfinProfiles(cfg *config.Config, transport http.RoundTripper, logger *slog.Logger) ProfileSwitcher {
+	flow := jellyfin.NewAuthFlow(cfg.Server.DeviceID, logger)
+	flow.SetTransport(transport)
+	return &jellyfinProfiles{flow: flow, serverURL: cfg.Server.URL, userID: cfg.Server.UserID}
+}
+
+func (j *jellyfinProfiles) Profiles(ctx context.Context) ([]Profile, error) {
+	users, err := j.flow.PublicUsers(ctx, j.serverURL)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]Profile, 0, len(users))
+	for _, u := range users {
+		profile := Profile{ID: u.ID, Name: u.Name, Current: u.ID == j.userID}
+		if u.HasPassword {
+			profile.Secret = "password"
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+func (j *jellyfinProfiles) Switch(ctx context.Context, profile Profile, secret string) (*AuthResult, error) {
+	result, err := j.flow.Authenticate(ctx, j.serverURL, profile.Name, secret)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthResult{Token: result.Token, UserID: result.UserID, Username: result.Username}, nil
+}
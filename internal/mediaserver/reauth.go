@@ -0,0 +1,150 @@
+package mediaserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// LinkCode is a code the user enters on a web page to sign in, e.g. a Plex
+// PIN at plex.tv/link
+type LinkCode struct {
+	Code string
+	URL  string
+	id   int // Source-specific handle for WaitLink
+}
+
+// LinkAuth is an AuthFlow that signs in with a code entered on a web page.
+// Unlike Run, it leaves showing the code to the caller.
+type LinkAuth interface {
+	RequestLink(ctx context.Context) (LinkCode, error)
+	// WaitLink blocks until the code is claimed or expires
+	WaitLink(ctx context.Context, code LinkCode) (*AuthResult, error)
+}
+
+// PasswordAuth is an AuthFlow that signs in with a username and password
+// without prompting on the terminal
+type PasswordAuth interface {
+	Login(ctx context.Context, serverURL, username, password string) (*AuthResult, error)
+}
+
+// userSwitcher is an AuthFlow whose login yields the account token, which
+// must then be switched to the user kino runs as (a Plex Home member)
+type userSwitcher interface {
+	SwitchUser(ctx context.Context, token, userID, pin string) (string, error)
+}
+
+// TokenSetter is a client whose token can be replaced while it runs
+type TokenSetter interface {
+	SetToken(token string)
+}
+
+// Reauth signs the configured user in again after the server rejects the
+// token mid-session, and hands the new token to the running client so
+// nothing has to restart. Persisting the token is left to the caller.
+type Reauth struct {
+	flow   AuthFlow
+	client TokenSetter
+	server config.ServerConfig
+}
+
+// NewReauth returns a Reauth for client, or an error when the configured
+// source can't sign in again without the terminal
+func NewReauth(cfg *config.Config, client MediaSource, logger *slog.Logger) (*Reauth, error) {
+	setter, ok := client.(TokenSetter)
+	if !ok {
+		return nil, errors.New("client does not support replacing its token")
+	}
+	transport, err := NewTransport(cfg.Server)
+	if err != nil {
+		return nil, err
+	}
+	flow, err := NewAuthFlow(cfg.Server.Type, cfg.Server.DeviceID, transport, logger)
+	if err != nil {
+		return nil, err
+	}
+	_, link := flow.(LinkAuth)
+	_, password := flow.(PasswordAuth)
+	if !link && !password {
+		return nil, fmt.Errorf("%s sign-in needs the terminal", cfg.Server.Type)
+	}
+	return &Reauth{flow: flow, client: setter, server: cfg.Server}, nil
+}
+
+// UsesPassword reports whether signing in asks for a username and
+// password; otherwise it shows a link code
+func (r *Reauth) UsesPassword() bool {
+	_, ok := r.flow.(PasswordAuth)
+	return ok
+}
+
+// Username is the user currently signed in, to prefill the login form
+func (r *Reauth) Username() string {
+	return r.server.Username
+}
+
+// RequestLink starts a link-code sign-in
+func (r *Reauth) RequestLink(ctx context.Context) (LinkCode, error) {
+	flow, ok := r.flow.(LinkAuth)
+	if !ok {
+		return LinkCode{}, errors.New("this server signs in with a password")
+	}
+	return flow.RequestLink(ctx)
+}
+
+// WaitLink waits for code to be claimed, then switches the client to the
+// new token. Returns the server settings to save.
+func (r *Reauth) WaitLink(ctx context.Context, code LinkCode) (config.ServerConfig, error) {
+	flow, ok := r.flow.(LinkAuth)
+	if !ok {
+		return config.ServerConfig{}, errors.New("this server signs in with a password")
+	}
+	result, err := flow.WaitLink(ctx, code)
+	if err != nil {
+		return config.ServerConfig{}, err
+	}
+	if switcher, ok := r.flow.(userSwitcher); ok && result.UserID == "" && r.server.UserID != "" {
+		token, err := switcher.SwitchUser(ctx, result.Token, r.server.UserID, "")
+		if errors.Is(err, domain.ErrAuthFailed) {
+			return config.ServerConfig{}, fmt.Errorf("%s's profile needs its PIN: run kino --switch-user", r.server.Username)
+		}
+		if err != nil {
+			return config.ServerConfig{}, err
+		}
+		result = &AuthResult{Token: token, UserID: r.server.UserID}
+	}
+	return r.apply(result)
+}
+
+// Login signs in with a username and password, then switches the client
+// to the new token. Returns the server settings to save.
+func (r *Reauth) Login(ctx context.Context, username, password string) (config.ServerConfig, error) {
+	flow, ok := r.flow.(PasswordAuth)
+	if !ok {
+		return config.ServerConfig{}, errors.New("this server signs in with a link code")
+	}
+	result, err := flow.Login(ctx, r.server.URL, username, password)
+	if err != nil {
+		return config.ServerConfig{}, err
+	}
+	return r.apply(result)
+}
+
+// apply installs a fresh token. Signing in as someone else is refused: the
+// cache and session belong to the user kino started as.
+func (r *Reauth) apply(result *AuthResult) (config.ServerConfig, error) {
+	if result.UserID != "" && r.server.UserID != "" && result.UserID != r.server.UserID {
+		return config.ServerConfig{}, fmt.Errorf("signed in as %s, not %s: use kino --switch-user to change users",
+			result.Username, r.server.Username)
+	}
+	r.server.Token = result.Token
+	if result.Username != "" {
+		r.server.Username = result.Username
+	}
+	r.client.SetToken(result.Token)
+	return r.server, nil
+}
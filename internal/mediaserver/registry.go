@@ -0,0 +1,100 @@
+// Package mediaserver connects kino to media server backends. Each backend
+// is a Source in a small registry: setup detects the server type by asking
+// every registered source in turn, and NewClient/NewAuthFlow build the
+// client and login flow of the configured type.
+//
+// Adding a source: implement MediaSource (plus any optional capability
+// interfaces from the domain package) in its own package, then call
+// Register from an init function in this package's sources.go or in the
+// adapter package itself, blank-imported from cmd/kino. Nothing else in the
+// core needs to change.
+package mediaserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/mmcdole/kino/internal/config"
+)
+
+// Capabilities describes setup-time behavior of a source. Runtime features
+// (live TV, calendars, smart playlists...) are discovered by type assertion
+// on the client instead.
+type Capabilities struct {
+	// RequiresUserID: the client needs server.user_id from its auth flow
+	RequiresUserID bool
+	// Discovery: servers can be found through an online account, so setup
+	// may proceed without a URL
+	Discovery bool
+	// Anonymous: there is no sign-in, so no token is needed (a local
+	// folder, a DLNA server)
+	Anonymous bool
+}
+
+// Source is one registered media server backend
+type Source struct {
+	Type config.SourceType
+	Name string // Display name, e.g. "Plex Media Server"
+
+	// Detect reports whether the server at serverURL is this type, returning
+	// an error describing why not. client carries the TLS settings and a
+	// timeout. Detect must use unauthenticated endpoints.
+	Detect func(ctx context.Context, client *http.Client, serverURL string) error
+
+	// NewClient builds an API client for the configured server. transport
+	// is shared with every other request to the server (pooling, limits).
+	NewClient func(cfg *config.Config, transport http.RoundTripper, logger *slog.Logger) (MediaSource, error)
+
+	// NewAuthFlow builds the interactive login flow
+	NewAuthFlow func(deviceID string, transport http.RoundTripper, logger *slog.Logger) AuthFlow
+
+	// NewProfileSwitcher builds the user switcher for servers shared by
+	// several users. Optional: nil means the source has no user switching.
+	NewProfileSwitcher func(cfg *config.Config, transport http.RoundTripper, logger *slog.Logger) ProfileSwitcher
+
+	Capabilities Capabilities
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []Source
+)
+
+// Register adds a source. Detection tries sources in registration order.
+// Registering the same type twice, or a source missing a required
+// function, panics: both are programming errors caught at startup.
+func Register(s Source) {
+	if s.Type == "" || s.Detect == nil || s.NewClient == nil || s.NewAuthFlow == nil {
+		panic(fmt.Sprintf("mediaserver: incomplete source registration %q", s.Type))
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, existing := range registry {
+		if existing.Type == s.Type {
+			panic(fmt.Sprintf("mediaserver: source %q registered twice", s.Type))
+		}
+	}
+	registry = append(registry, s)
+}
+
+// Sources returns the registered sources in detection order
+func Sources() []Source {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return append([]Source(nil), registry...)
+}
+
+// Lookup returns the source registered for a server type
+func Lookup(t config.SourceType) (Source, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, s := range registry {
+		if s.Type == t {
+			return s, true
+		}
+	}
+	return Source{}, false
+}
@@ -0,0 +1,123 @@
+package mediaserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// Detection walks the registry, so a newly registered source is found and
+// built without touching DetectServerType or NewClient
+func TestRegisteredSourceIsDetectedAndBuilt(t *testing.T) {
+	const kodi config.SourceType = "kodi"
+	saved := Sources()
+	t.Cleanup(func() {
+		registryMu.Lock()
+		registry = saved
+		registryMu.Unlock()
+	})
+
+	built := false
+	Register(Source{
+		Type: kodi,
+		Name: "Kodi",
+		Detect: func(ctx context.Context, client *http.Client, serverURL string) error {
+			resp, err := client.Get(serverURL + "/jsonrpc")
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return errors.New("no jsonrpc endpoint")
+			}
+			return nil
+		},
+		NewClient: func(cfg *config.Config, transport http.RoundTripper, logger *slog.Logger) (MediaSource, error) {
+			built = true
+			return nil, nil
+		},
+		NewAuthFlow: func(deviceID string, transport http.RoundTripper, logger *slog.Logger) AuthFlow { return nil },
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jsonrpc" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	got, err := DetectServerType(context.Background(), srv.URL, nil)
+	if err != nil || got != kodi {
+		t.Fatalf("DetectServerType = %q, %v; want kodi", got, err)
+	}
+
+	cfg := &config.Config{Server: config.ServerConfig{Type: kodi, URL: srv.URL, Token: "t"}}
+	if _, err := NewClient(cfg, slog.Default()); err != nil || !built {
+		t.Fatalf("NewClient did not use the registered constructor (err %v)", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("duplicate registration did not panic")
+		}
+	}()
+	Register(Sources()[0])
+}
+
+// Signing in again hands the new token to the running client, and refuses
+// a login as another user
+func TestReauthReplacesClientToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/Users/AuthenticateByName" {
+			var body struct{ Username string }
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			fmt.Fprintf(w, `{"AccessToken":"fresh-%s","User":{"Id":"id-%s","Name":%q}}`, body.Username, body.Username, body.Username)
+			return
+		}
+		if !strings.Contains(r.Header.Get("X-Emby-Authorization"), `Token="fresh-ann"`) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"Items":[]}`)
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{Server: config.ServerConfig{
+		Type: config.SourceTypeJellyfin, URL: srv.URL, Token: "expired", UserID: "id-ann", Username: "ann",
+	}}
+	client, err := NewClient(cfg, slog.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reauth, err := NewReauth(cfg, client, slog.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reauth.UsesPassword() {
+		t.Fatal("Jellyfin should sign in with a password")
+	}
+
+	ctx := context.Background()
+	if _, err := client.GetLibraries(ctx); !errors.Is(err, domain.ErrAuthFailed) {
+		t.Fatalf("expired token: err = %v, want ErrAuthFailed", err)
+	}
+	if _, err := reauth.Login(ctx, "bob", "pw"); err == nil {
+		t.Fatal("signing in as another user was accepted")
+	}
+	server, err := reauth.Login(ctx, "ann", "pw")
+	if err != nil || server.Token != "fresh-ann" {
+		t.Fatalf("Login = %+v, %v", server, err)
+	}
+	if _, err := client.GetLibraries(ctx); err != nil {
+		t.Fatalf("client still rejected after signing in: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package mediaserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/mediaserver/dlna"
+	"github.com/mmcdole/kino/internal/mediaserver/jellyfin"
+	"github.com/mmcdole/kino/internal/mediaserver/local"
+	"github.com/mmcdole/kino/internal/mediaserver/plex"
+)
+
+// The built-in sources, in detection order: local folders (no network
+// needed to rule one out), Jellyfin, Plex, then DLNA servers (whose
+// description URL neither of the others answers)
+func init() {
+	Register(Source{
+		Type:         config.SourceTypeLocal,
+		Name:         "Local folder",
+		Detect:       detectLocal,
+		NewClient:    newLocalClient,
+		Capabilities: Capabilities{Anonymous: true},
+		NewAuthFlow: func(deviceID string, transport http.RoundTripper, logger *slog.Logger) AuthFlow {
+			return anonymousAuth{}
+		},
+	})
+	Register(Source{
+		Type:         config.SourceTypeJellyfin,
+		Name:         "Jellyfin",
+		Detect:       detectJellyfin,
+		NewClient:    newJellyfinClient,
+		Capabilities: Capabilities{RequiresUserID: true},
+		NewAuthFlow: func(deviceID string, transport http.RoundTripper, logger *slog.Logger) AuthFlow {
+			inner := jellyfin.NewAuthFlow(deviceID, logger)
+			inner.SetTransport(transport)
+			return &jellyfinAuthAdapter{inner: inner}
+		},
+		NewProfileSwitcher: newJellyfinProfiles,
+	})
+	Register(Source{
+		Type:         config.SourceTypePlex,
+		Name:         "Plex Media Server",
+		Detect:       detectPlex,
+		NewClient:    newPlexClient,
+		Capabilities: Capabilities{Discovery: true},
+		NewAuthFlow: func(deviceID string, transport http.RoundTripper, logger *slog.Logger) AuthFlow {
+			inner := plex.NewAuthFlow(deviceID, logger)
+			inner.SetTransport(transport)
+			return &plexAuthAdapter{inner: inner}
+		},
+		NewProfileSwitcher: newPlexProfiles,
+	})
+	Register(Source{
+		Type:         config.SourceTypeDLNA,
+		Name:         "DLNA media server",
+		Detect:       dlna.Detect,
+		NewClient:    newDLNAClient,
+		Capabilities: Capabilities{Anonymous: true},
+		NewAuthFlow: func(deviceID string, transport http.RoundTripper, logger *slog.Logger) AuthFlow {
+			return anonymousAuth{}
+		},
+	})
+}
+
+// newPlexClient builds a Plex client and fetches the server identity
+func newPlexClient(cfg *config.Config, transport http.RoundTripper, logger *slog.Logger) (MediaSource, error) {
+	client := plex.NewClient(cfg.Server.URL, cfg.Server.Token, cfg.Server.DeviceID, logger)
+	client.SetTransport(transport)
+	applySyncProfile(client, cfg.Server.SyncProfile)
+	// The notification socket needs the bare transport, as for Jellyfin's
+	// SyncPlay socket below
+	if socket, err := NewTransport(cfg.Server); err == nil {
+		client.SetSocketTransport(socket)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := client.FetchIdentity(ctx); err != nil {
+		logger.Warn("failed to fetch plex identity", "error", err)
+		// Non-fatal: playlist creation will fail but browsing works
+	}
+
+	return client, nil
+}
+
+// newJellyfinClient builds a Jellyfin client for the authenticated user
+func newJellyfinClient(cfg *config.Config, transport http.RoundTripper, logger *slog.Logger) (MediaSource, error) {
+	if cfg.Server.UserID == "" {
+		return nil, fmt.Errorf("Jellyfin requires user ID")
+	}
+	client := jellyfin.NewClient(cfg.Server.URL, cfg.Server.Token, cfg.Server.UserID, cfg.Server.DeviceID, logger)
+	client.SetTransport(transport)
+	applySyncProfile(client, cfg.Server.SyncProfile)
+	// The SyncPlay socket needs the bare transport: the shared one's limiter
+	// would hold a request slot for the life of the connection
+	if socket, err := NewTransport(cfg.Server); err == nil {
+		client.SetSocketTransport(socket)
+	}
+	return client, nil
+}
+
+// newLocalClient builds a client for a folder of video files
+func newLocalClient(cfg *config.Config, transport http.RoundTripper, logger *slog.Logger) (MediaSource, error) {
+	return local.NewClient(local.Path(cfg.Server.URL), filepath.Dir(config.DefaultCachePath()), logger), nil
+}
+
+// detectLocal recognizes a folder path or file:// URL
+func detectLocal(ctx context.Context, client *http.Client, serverURL string) error {
+	return local.Detect(serverURL)
+}
+
+// newDLNAClient builds a client for a DLNA server
+func newDLNAClient(cfg *config.Config, transport http.RoundTripper, logger *slog.Logger) (MediaSource, error) {
+	client := dlna.NewClient(cfg.Server.URL, filepath.Dir(config.DefaultCachePath()), logger)
+	client.SetTransport(transport)
+	return client, nil
+}
+
+// anonymousAuth signs in to nothing, for sources without accounts
+type anonymousAuth struct{}
+
+func (anonymousAuth) Run(ctx context.Context, serverURL string) (*AuthResult, error) {
+	return &AuthResult{}, nil
+}
+
+// syncTunable is a server client whose listings the sync profile shapes
+type syncTunable interface {
+	SetFastSync(fast bool)
+	SetPageSize(n int)
+}
+
+// applySyncProfile sets up a server client for the resolved sync profile
+func applySyncProfile(client syncTunable, profile string) {
+	client.SetFastSync(profile == config.SyncProfileFast || profile == config.SyncProfileRemote)
+	if profile == config.SyncProfileRemote {
+		client.SetPageSize(remotePageSize)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build torrent
+
+package mediaserver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/mediaserver/torrserver"
+)
+
+// The experimental TorrServer source, built with -tags torrent. It is never
+// detected: setup can't tell a torrent engine from any other web server,
+// and it stays opt-in by naming it as server.type.
+func init() {
+	Register(Source{
+		Type: config.SourceTypeTorrServer,
+		Name: "TorrServer (experimental)",
+		Detect: func(ctx context.Context, client *http.Client, serverURL string) error {
+			return errors.New("not detected; set server.type to torrserver")
+		},
+		NewClient:    newTorrServerClient,
+		Capabilities: Capabilities{Anonymous: true},
+		NewAuthFlow: func(deviceID string, transport http.RoundTripper, logger *slog.Logger) AuthFlow {
+			return anonymousAuth{}
+		},
+	})
+}
+
+// newTorrServerClient builds a client for a TorrServer engine
+func newTorrServerClient(cfg *config.Config, transport http.RoundTripper, logger *slog.Logger) (MediaSource, error) {
+	client := torrserver.NewClient(cfg.Server.URL, filepath.Dir(config.DefaultCachePath()), logger)
+	client.SetTransport(transport)
+	return client, nil
+}
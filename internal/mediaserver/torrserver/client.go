@@ -0,0 +1,184 @@
+// Package torrserver is an experimental media source for a TorrServer
+// torrent-streaming engine running alongside kino. The engine's torrents
+// are listed as libraries (one per category, or a single "Torrents"), their
+// video files named and grouped like a local folder's, and each file plays
+// through the engine's stream endpoint, which fetches pieces as mpv reads.
+//
+// The source is only compiled in with the "torrent" build tag and never
+// auto-detected: set server.type to "torrserver" and server.url to the
+// engine (http://localhost:8090; credentials go in the URL).
+package torrserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/kino/internal/mediaserver/catalog"
+	"github.com/mmcdole/kino/internal/mediaserver/httpclient"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Client serves a TorrServer's torrents as libraries. It implements
+// mediaserver.MediaSource.
+type Client struct {
+	*catalog.Browser
+
+	baseURL    string
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewClient returns a client for the engine at baseURL. Watch state is
+// kept in stateDir.
+func NewClient(baseURL, stateDir string, logger *slog.Logger) *Client {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpclient.New(defaultTimeout, httpclient.Options{}),
+		logger:     logger,
+	}
+	sum := sha1.Sum([]byte(c.baseURL))
+	statePath := filepath.Join(stateDir, "torrserver-"+hex.EncodeToString(sum[:8])+".json")
+	c.Browser = catalog.NewBrowser(c.scan, statePath, logger)
+	return c
+}
+
+// SetTransport replaces the client's HTTP transport, keeping its timeout.
+// Used to share one tuned connection pool across the app.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// torrent is a TorrServer torrent status, as its /torrents API lists it
+type torrent struct {
+	Hash      string `json:"hash"`
+	Title     string `json:"title"`
+	Category  string `json:"category"`
+	Timestamp int64  `json:"timestamp"`
+	Files     []file `json:"file_stats"`
+}
+
+type file struct {
+	ID     int    `json:"id"`
+	Path   string `json:"path"`
+	Length int64  `json:"length"`
+}
+
+// torrents calls the /torrents endpoint with an action
+func (c *Client) torrents(ctx context.Context, body map[string]string, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/torrents", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("torrserver %s: %d %s", body["action"], resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// scan lists the engine's torrents. A torrent listed without its files
+// (not loaded since the engine started) is fetched on its own, which
+// loads its metadata.
+func (c *Client) scan(ctx context.Context) ([]catalog.Library, error) {
+	var list []torrent
+	if err := c.torrents(ctx, map[string]string{"action": "list"}, &list); err != nil {
+		return nil, err
+	}
+
+	var libraries []catalog.Library
+	index := make(map[string]int) // By category
+	for _, t := range list {
+		if len(t.Files) == 0 {
+			var full torrent
+			if err := c.torrents(ctx, map[string]string{"action": "get", "hash": t.Hash}, &full); err != nil {
+				c.logger.Warn("skipping torrent without file list", "title", t.Title, "error", err)
+				continue
+			}
+			t.Files = full.Files
+		}
+
+		category := t.Category
+		if category == "" {
+			category = "Torrents"
+		}
+		i, ok := index[category]
+		if !ok {
+			i = len(libraries)
+			index[category] = i
+			libraries = append(libraries, catalog.Library{Key: category, Name: libraryName(category)})
+		}
+		libraries[i].Videos = append(libraries[i].Videos, c.videos(t)...)
+	}
+	return libraries, nil
+}
+
+// videos lists a torrent's video files, each with the folders of its path
+// in the torrent
+func (c *Client) videos(t torrent) []catalog.Video {
+	var videos []catalog.Video
+	for _, f := range t.Files {
+		name := path.Base(f.Path)
+		if !catalog.IsVideo(name) {
+			continue
+		}
+		ext := path.Ext(name)
+		folders := strings.Split(path.Dir(f.Path), "/")
+		if folders[0] == "." {
+			// A single-file torrent: its title is the only folder there is
+			folders = []string{t.Title}
+		}
+		videos = append(videos, catalog.Video{
+			Locator:   c.streamURL(t.Hash, f.ID, name),
+			Folders:   folders,
+			Name:      strings.TrimSuffix(name, ext),
+			ModTime:   time.Unix(t.Timestamp, 0),
+			Size:      f.Length,
+			Container: strings.TrimPrefix(strings.ToLower(ext), "."),
+		})
+	}
+	return videos
+}
+
+// streamURL is where the engine streams one file of a torrent
+func (c *Client) streamURL(hash string, index int, name string) string {
+	q := url.Values{"link": {hash}, "index": {strconv.Itoa(index)}, "play": {""}}
+	return c.baseURL + "/stream/" + url.PathEscape(name) + "?" + q.Encode()
+}
+
+// libraryName titles a TorrServer category ("movie", "tv")
+func libraryName(category string) string {
+	switch category {
+	case "movie":
+		return "Movies"
+	case "tv":
+		return "TV"
+	}
+	return strings.ToUpper(category[:1]) + category[1:]
+}
@@ -0,0 +1,63 @@
+package torrserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientListsTorrents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if r.URL.Path != "/torrents" || json.NewDecoder(r.Body).Decode(&body) != nil {
+			http.NotFound(w, r)
+			return
+		}
+		switch body["action"] {
+		case "list":
+			w.Write([]byte(`[
+				{"hash":"aaa","title":"Heat.1995.1080p","category":"movie","timestamp":1700000000,
+				 "file_stats":[{"id":1,"path":"Heat.1995.1080p/Heat.1995.1080p.mkv","length":5000},
+				               {"id":2,"path":"Heat.1995.1080p/sample.txt","length":10}]},
+				{"hash":"bbb","title":"The.Wire.S01.720p","category":"tv","timestamp":1700000000}
+			]`))
+		case "get":
+			if body["hash"] != "bbb" {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write([]byte(`{"hash":"bbb","file_stats":[
+				{"id":1,"path":"The.Wire.S01.720p/The.Wire.S01E01.720p.mkv","length":100},
+				{"id":2,"path":"The.Wire.S01.720p/The.Wire.S01E02.720p.mkv","length":100}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(srv.URL, t.TempDir(), nil)
+	libs, err := c.GetLibraries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(libs) != 2 || libs[0].Name != "Movies" || libs[0].Type != "movie" || libs[1].Name != "TV" || libs[1].Type != "show" {
+		t.Fatalf("libraries = %+v", libs)
+	}
+
+	movies, _, _ := c.GetMovies(ctx, libs[0].ID, 0, 0)
+	if len(movies) != 1 || movies[0].Title != "Heat" || movies[0].Year != 1995 {
+		t.Fatalf("movies = %+v", movies)
+	}
+	stream, err := c.ResolvePlayableURL(ctx, movies[0].ID)
+	if err != nil || !strings.HasPrefix(stream, srv.URL+"/stream/Heat.1995.1080p.mkv?") || !strings.Contains(stream, "link=aaa") || !strings.Contains(stream, "index=1") {
+		t.Errorf("stream URL = %q, %v", stream, err)
+	}
+
+	// The second torrent's files came from a get
+	shows, _, _ := c.GetShows(ctx, libs[1].ID, 0, 0)
+	if len(shows) != 1 || shows[0].Title != "The Wire" || shows[0].EpisodeCount != 2 {
+		t.Errorf("shows = %+v", shows)
+	}
+}
@@ -0,0 +1,191 @@
+// Package metrics keeps kino's internal counters and serves them, with the
+// Go runtime's memory stats and pprof, on an optional local listener in the
+// Prometheus text format. Recording is a few atomic adds and costs nothing
+// when no listener is configured.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Counter is a monotonically increasing count
+type Counter struct {
+	name, help string
+	v          atomic.Int64
+}
+
+// Inc adds one
+func (c *Counter) Inc() {
+	c.v.Add(1)
+}
+
+// Value returns the current count
+func (c *Counter) Value() int64 {
+	return c.v.Load()
+}
+
+// Summary tracks the count and total of observed durations
+type Summary struct {
+	name, help string
+	mu         sync.Mutex
+	count      int64
+	sum        float64 // Seconds
+}
+
+// Observe records one duration
+func (s *Summary) Observe(d time.Duration) {
+	s.mu.Lock()
+	s.count++
+	s.sum += d.Seconds()
+	s.mu.Unlock()
+}
+
+// registry is every metric in exposition order
+var registry struct {
+	mu        sync.Mutex
+	counters  []*Counter
+	summaries []*Summary
+}
+
+// NewCounter registers a counter. name may carry labels, e.g.
+// `kino_cache_reads_total{result="miss"}`; counters sharing a family name
+// share its help text.
+func NewCounter(name, help string) *Counter {
+	c := &Counter{name: name, help: help}
+	registry.mu.Lock()
+	registry.counters = append(registry.counters, c)
+	registry.mu.Unlock()
+	return c
+}
+
+// NewSummary registers a duration summary, exposed as name_count and
+// name_sum in seconds
+func NewSummary(name, help string) *Summary {
+	s := &Summary{name: name, help: help}
+	registry.mu.Lock()
+	registry.summaries = append(registry.summaries, s)
+	registry.mu.Unlock()
+	return s
+}
+
+// kino's metrics
+var (
+	APIRequests = NewCounter("kino_api_requests_total", "Requests sent to the media server.")
+	APIErrors   = NewCounter("kino_api_errors_total", "Media server requests that failed or answered 4xx/5xx.")
+
+	CacheMemoryHits = NewCounter(`kino_cache_reads_total{result="memory"}`, "Cache reads by where they were served from.")
+	CacheDiskHits   = NewCounter(`kino_cache_reads_total{result="disk"}`, "")
+	CacheMisses     = NewCounter(`kino_cache_reads_total{result="miss"}`, "")
+
+	SyncsFromCache = NewCounter(`kino_syncs_total{result="cached"}`, "Library syncs by outcome.")
+	SyncsFetched   = NewCounter(`kino_syncs_total{result="fetched"}`, "")
+	SyncsFailed    = NewCounter(`kino_syncs_total{result="failed"}`, "")
+	SyncDuration   = NewSummary("kino_sync_duration_seconds", "Time spent syncing libraries.")
+)
+
+// family is a metric name without its labels
+func family(name string) string {
+	if i := strings.IndexByte(name, '{'); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// Write renders every metric and the runtime's memory stats in the
+// Prometheus text format
+func Write(w io.Writer) {
+	registry.mu.Lock()
+	counters := append([]*Counter(nil), registry.counters...)
+	summaries := append([]*Summary(nil), registry.summaries...)
+	registry.mu.Unlock()
+
+	seen := make(map[string]bool)
+	header := func(name, help, kind string) {
+		if f := family(name); !seen[f] {
+			seen[f] = true
+			if help != "" {
+				fmt.Fprintf(w, "# HELP %s %s\n", f, help)
+			}
+			fmt.Fprintf(w, "# TYPE %s %s\n", f, kind)
+		}
+	}
+	for _, c := range counters {
+		header(c.name, c.help, "counter")
+		fmt.Fprintf(w, "%s %d\n", c.name, c.Value())
+	}
+	for _, s := range summaries {
+		header(s.name, s.help, "summary")
+		s.mu.Lock()
+		count, sum := s.count, s.sum
+		s.mu.Unlock()
+		fmt.Fprintf(w, "%s_count %d\n%s_sum %s\n", s.name, count, s.name, formatFloat(sum))
+	}
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	gauges := []struct {
+		name, help string
+		value      uint64
+	}{
+		{"kino_memory_heap_bytes", "Bytes of allocated heap objects.", mem.HeapAlloc},
+		{"kino_memory_sys_bytes", "Bytes of memory obtained from the OS.", mem.Sys},
+		{"kino_gc_cycles_total", "Completed GC cycles.", uint64(mem.NumGC)},
+		{"kino_goroutines", "Goroutines that currently exist.", uint64(runtime.NumGoroutine())},
+	}
+	for _, g := range gauges {
+		kind := "gauge"
+		if strings.HasSuffix(g.name, "_total") {
+			kind = "counter"
+		}
+		header(g.name, g.help, kind)
+		fmt.Fprintf(w, "%s %d\n", g.name, g.value)
+	}
+}
+
+// formatFloat renders a sample value the way Prometheus expects
+func formatFloat(v float64) string {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return "0"
+	}
+	return fmt.Sprintf("%g", v)
+}
+
+// Handler serves the metrics page
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		Write(w)
+	})
+}
+
+// Transport wraps rt so every request counts toward APIRequests, and
+// failures and 4xx/5xx answers toward APIErrors
+func Transport(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		APIRequests.Inc()
+		resp, err := rt.RoundTrip(req)
+		if err != nil || resp.StatusCode >= 400 {
+			APIErrors.Inc()
+		}
+		return resp, err
+	})
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Requests through Transport are counted, failures as errors too, and the
+// page renders each family once with its labelled series
+func TestTransportCountsAndWrite(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	requests, errs := APIRequests.Value(), APIErrors.Value()
+	client := &http.Client{Transport: Transport(nil)}
+	for _, path := range []string{"/ok", "/missing"} {
+		resp, err := client.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	if got := APIRequests.Value() - requests; got != 2 {
+		t.Errorf("requests counted = %d, want 2", got)
+	}
+	if got := APIErrors.Value() - errs; got != 1 {
+		t.Errorf("errors counted = %d, want 1", got)
+	}
+
+	SyncDuration.Observe(1500 * time.Millisecond)
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	page := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE kino_api_requests_total counter",
+		`kino_cache_reads_total{result="miss"} `,
+		"# TYPE kino_sync_duration_seconds summary",
+		"kino_sync_duration_seconds_count ",
+		"# TYPE kino_goroutines gauge",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("metrics page missing %q", want)
+		}
+	}
+	if n := strings.Count(page, "# TYPE kino_cache_reads_total "); n != 1 {
+		t.Errorf("cache family declared %d times, want 1", n)
+	}
+}
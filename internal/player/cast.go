@@ -0,0 +1,102 @@
+package player
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/mmcdole/kino/internal/chromecast"
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// chromecastWait is how long discovery listens for Chromecasts to answer
+const chromecastWait = 2 * time.Second
+
+// errCastStream refuses a Chromecast a stream it can't fetch, such as a
+// local file
+var errCastStream = errors.New("a Chromecast can only play streams served over HTTP")
+
+// errCastPrivate refuses casting to the server's devices in a private
+// session: the target reports its own playback to the server, which kino
+// cannot hold back
+var errCastPrivate = errors.New("casting is unavailable in a private session: the device would report watch state")
+
+// CanCast reports whether there may be devices to play on: Chromecasts
+// are looked for with any backend
+func (s *Service) CanCast() bool {
+	return true
+}
+
+// CastTargets lists the devices playback can be sent to: the backend's,
+// then the Chromecasts that answer on the LAN
+func (s *Service) CastTargets(ctx context.Context) ([]domain.CastTarget, error) {
+	var targets []domain.CastTarget
+	var backendErr error
+	if cc, ok := s.playback.(domain.CastClient); ok {
+		targets, backendErr = cc.CastTargets(ctx)
+	}
+
+	devices, err := chromecast.Discover(ctx, chromecastWait)
+	if err != nil {
+		s.logger.Warn("chromecast discovery failed", "error", err)
+	}
+	for _, d := range devices {
+		product := d.Model
+		if product == "" {
+			product = "Chromecast"
+		}
+		targets = append(targets, domain.CastTarget{ID: d.ID, Name: d.Name, Product: product, Addr: d.Addr})
+	}
+	if backendErr != nil && len(devices) == 0 {
+		return nil, backendErr
+	}
+	if backendErr != nil {
+		s.logger.Warn("listing the server's devices failed", "error", backendErr)
+	}
+	return targets, nil
+}
+
+// Cast plays an item on a target instead of a local player, from the
+// resume position when resume is set. A Chromecast streams what a local
+// player would and, like one, reports nothing to the server, so it is
+// allowed in a private session.
+func (s *Service) Cast(ctx context.Context, target domain.CastTarget, item domain.MediaItem, resume bool) error {
+	var offset time.Duration
+	if resume {
+		offset = item.ViewOffset
+	}
+	if target.Addr != "" {
+		stream, err := s.streamURL(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+		if u, err := url.Parse(stream); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return errCastStream
+		}
+		s.logger.Info("casting playback", "title", item.Title, "itemID", item.ID, "target", target.Name, "chromecast", target.Addr, "offset", offset)
+		return chromecast.Play(ctx, target.Addr, chromecast.Media{URL: stream, Title: item.Title, Offset: offset})
+	}
+
+	cc, ok := s.playback.(domain.CastClient)
+	if !ok {
+		return domain.ErrForbidden
+	}
+	if s.Private() {
+		return errCastPrivate
+	}
+	s.logger.Info("casting playback", "title", item.Title, "itemID", item.ID, "target", target.Name, "offset", offset)
+	return cc.Cast(ctx, target.ID, item.ID, offset)
+}
+
+// ControlCast sends a remote-control command to a target
+func (s *Service) ControlCast(ctx context.Context, target domain.CastTarget, cmd domain.CastCommand) error {
+	if target.Addr != "" {
+		return chromecast.Control(ctx, target.Addr, cmd)
+	}
+	cc, ok := s.playback.(domain.CastClient)
+	if !ok {
+		return domain.ErrForbidden
+	}
+	return cc.ControlCast(ctx, target.ID, cmd)
+}
@@ -0,0 +1,134 @@
+package player
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// hookTimeout bounds a hook's run. The pre-play hook holds the launch
+// back until it finishes, so a hung one must not hold it forever.
+const hookTimeout = 30 * time.Second
+
+// Hook events, passed as KINO_EVENT
+const (
+	hookPrePlay  = "pre_play"
+	hookPostPlay = "post_play"
+)
+
+// hookInfo is what a hook learns about the item, as JSON on stdin and as
+// KINO_* variables
+type hookInfo struct {
+	Event    string `json:"event"`
+	ItemID   string `json:"item_id"`
+	Title    string `json:"title"`
+	Type     string `json:"type"` // "movie", "episode" or "channel"
+	Show     string `json:"show,omitempty"`
+	Season   int    `json:"season,omitempty"`
+	Episode  int    `json:"episode,omitempty"`
+	Year     int    `json:"year,omitempty"`
+	Duration int    `json:"duration"` // Seconds
+	Offset   int    `json:"offset"`   // Seconds the playback started at
+	// PlayedToEnd is set after playback when the player reported how it
+	// ended (mpv)
+	PlayedToEnd *bool `json:"played_to_end,omitempty"`
+}
+
+func newHookInfo(event string, item domain.MediaItem, offset time.Duration) hookInfo {
+	info := hookInfo{
+		Event:    event,
+		ItemID:   item.ID,
+		Title:    item.Title,
+		Type:     "movie",
+		Year:     item.Year,
+		Duration: int(item.Duration.Seconds()),
+		Offset:   int(offset.Seconds()),
+	}
+	switch item.Type {
+	case domain.MediaTypeEpisode:
+		info.Type = "episode"
+		info.Show, info.Season, info.Episode = item.ShowTitle, item.SeasonNum, item.EpisodeNum
+	case domain.MediaTypeChannel:
+		info.Type = "channel"
+	}
+	return info
+}
+
+// env is the hook's KINO_* variables
+func (h hookInfo) env() []string {
+	vars := []string{
+		"KINO_EVENT=" + h.Event,
+		"KINO_ITEM_ID=" + h.ItemID,
+		"KINO_TITLE=" + h.Title,
+		"KINO_TYPE=" + h.Type,
+		"KINO_SHOW=" + h.Show,
+		"KINO_SEASON=" + strconv.Itoa(h.Season),
+		"KINO_EPISODE=" + strconv.Itoa(h.Episode),
+		"KINO_YEAR=" + strconv.Itoa(h.Year),
+		"KINO_DURATION=" + strconv.Itoa(h.Duration),
+		"KINO_OFFSET=" + strconv.Itoa(h.Offset),
+	}
+	if h.PlayedToEnd != nil {
+		ended := "0"
+		if *h.PlayedToEnd {
+			ended = "1"
+		}
+		vars = append(vars, "KINO_PLAYED_TO_END="+ended)
+	}
+	return vars
+}
+
+// SetHooks sets the shell commands run before a launch and after the
+// player exits; empty runs nothing
+func (s *Service) SetHooks(pre, post string) {
+	s.preHook, s.postHook = pre, post
+}
+
+// runHook runs a hook command through the shell and waits for it. A
+// failure is logged, never returned: hooks can't stop playback.
+func (s *Service) runHook(command string, info hookInfo) {
+	if command == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	stdin, err := json.Marshal(info)
+	if err != nil {
+		return
+	}
+	cmd := shellCommand(ctx, command)
+	cmd.Env = append(os.Environ(), info.env()...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var output bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &output, &output
+
+	start := time.Now()
+	if err := cmd.Run(); err != nil {
+		s.logger.Warn("playback hook failed", "event", info.Event, "error", err,
+			"output", truncate(output.String(), 500))
+		return
+	}
+	s.logger.Debug("playback hook ran", "event", info.Event, "took", time.Since(start))
+}
+
+// windowsShellLine is the cmd.exe command line that runs command as
+// written. cmd doesn't understand the backslash escaping Go gives each
+// argument, so the line is built by hand: with /S, cmd strips just the
+// outer quotes and leaves the user's own quoting alone.
+func windowsShellLine(command string) string {
+	return `cmd /S /C "` + command + `"`
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return fmt.Sprintf("%s… (%d bytes)", s[:n], len(s))
+}
@@ -6,9 +6,12 @@ import (
 	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/mmcdole/kino/internal/domain"
@@ -16,41 +19,89 @@ import (
 
 // Launcher launches media URLs in an external player
 type Launcher struct {
-	command  string   // configured player command, empty for system default
-	args     []string // additional arguments for the player
-	seekFlag string   // user-configured seek flag (e.g., "--start=%d"), overrides table lookup
-	logger   *slog.Logger
+	command    string   // configured player command, empty for system default
+	args       []string // additional arguments for the player
+	seekFlag   string   // user-configured seek flag (e.g., "--start=%d"), overrides table lookup
+	preference []string // player names auto-detection tries first (see SetPreference)
+	logger     *slog.Logger
 }
 
 // PlayerDef defines a player binary and its seek flag format
 type PlayerDef struct {
+	Name     string // What player.preference calls it; packaged builds add "-flatpak", "-snap"
 	Binary   string
 	SeekFlag string // Use %d for seconds placeholder, e.g., "--start=%d" or "-ss %d"
+
+	// Locations are where the player installs when its binary is not on
+	// PATH; $VARS are expanded
+	Locations []string
+
+	// MPV marks an mpv that kino can drive over its IPC socket. Sandboxed
+	// builds (flatpak, snap) get a private /tmp, so the socket is out of
+	// reach.
+	MPV bool
 }
 
-// Platform-specific player lists, ordered by priority (first match wins)
+// Flatpak exports a launcher for each installed app here, system-wide and
+// per user
+const (
+	flatpakSystemBin = "/var/lib/flatpak/exports/bin/"
+	flatpakUserBin   = "$HOME/.local/share/flatpak/exports/bin/"
+)
+
+// Platform-specific player lists, ordered by priority (first match wins).
+// A native build outranks the flatpak and snap of the same player, which
+// outrank the next player.
 var linuxPlayers = []PlayerDef{
-	{Binary: "mpv", SeekFlag: "--start=%d"},
-	{Binary: "vlc", SeekFlag: "--start-time=%d"},
-	{Binary: "celluloid", SeekFlag: "--mpv-start=%d"},
-	{Binary: "haruna", SeekFlag: "--start=%d"},
-	{Binary: "smplayer", SeekFlag: "-ss %d"},
-	{Binary: "mplayer", SeekFlag: "-ss %d"},
+	{Name: "mpv", Binary: "mpv", SeekFlag: "--start=%d", MPV: true},
+	{Name: "mpv-flatpak", Binary: "io.mpv.Mpv", SeekFlag: "--start=%d",
+		Locations: []string{flatpakSystemBin + "io.mpv.Mpv", flatpakUserBin + "io.mpv.Mpv"}},
+	{Name: "mpv-snap", Binary: "/snap/bin/mpv", SeekFlag: "--start=%d"},
+	{Name: "vlc", Binary: "vlc", SeekFlag: "--start-time=%d"},
+	{Name: "vlc-flatpak", Binary: "org.videolan.VLC", SeekFlag: "--start-time=%d",
+		Locations: []string{flatpakSystemBin + "org.videolan.VLC", flatpakUserBin + "org.videolan.VLC"}},
+	{Name: "vlc-snap", Binary: "/snap/bin/vlc", SeekFlag: "--start-time=%d"},
+	{Name: "celluloid", Binary: "celluloid", SeekFlag: "--mpv-start=%d"},
+	{Name: "celluloid-flatpak", Binary: "io.github.celluloid_player.Celluloid", SeekFlag: "--mpv-start=%d",
+		Locations: []string{
+			flatpakSystemBin + "io.github.celluloid_player.Celluloid",
+			flatpakUserBin + "io.github.celluloid_player.Celluloid",
+		}},
+	{Name: "haruna", Binary: "haruna", SeekFlag: "--start=%d"},
+	{Name: "smplayer", Binary: "smplayer", SeekFlag: "-ss %d"},
+	{Name: "mplayer", Binary: "mplayer", SeekFlag: "-ss %d"},
 }
 
 var darwinPlayers = []PlayerDef{
-	{Binary: "iina", SeekFlag: "--mpv-start=%d"},
-	{Binary: "mpv", SeekFlag: "--start=%d"},
-	{Binary: "vlc", SeekFlag: "--start-time=%d"},
+	{Name: "iina", Binary: "iina", SeekFlag: "--mpv-start=%d",
+		Locations: []string{"/Applications/IINA.app/Contents/MacOS/iina-cli"}},
+	{Name: "mpv", Binary: "mpv", SeekFlag: "--start=%d", MPV: true,
+		Locations: []string{"/opt/homebrew/bin/mpv", "/usr/local/bin/mpv"}},
+	{Name: "vlc", Binary: "vlc", SeekFlag: "--start-time=%d",
+		Locations: []string{"/Applications/VLC.app/Contents/MacOS/VLC"}},
+}
+
+// Windows players, found on PATH or in their default install folders.
+// MPC-HC's /start takes milliseconds.
+var windowsPlayers = []PlayerDef{
+	{Name: "mpv", Binary: "mpv.exe", SeekFlag: "--start=%d"},
+	{Name: "mpc-hc", Binary: "mpc-hc64.exe", SeekFlag: "/start %d000",
+		Locations: []string{`$ProgramFiles\MPC-HC\mpc-hc64.exe`}},
+	{Name: "mpc-hc", Binary: "mpc-hc.exe", SeekFlag: "/start %d000",
+		Locations: []string{`${ProgramFiles(x86)}\MPC-HC\mpc-hc.exe`}},
+	{Name: "potplayer", Binary: "PotPlayerMini64.exe", SeekFlag: "/seek=%d",
+		Locations: []string{`$ProgramFiles\DAUM\PotPlayer\PotPlayerMini64.exe`}},
+	{Name: "vlc", Binary: "vlc.exe", SeekFlag: "--start-time=%d",
+		Locations: []string{`$ProgramFiles\VideoLAN\VLC\vlc.exe`, `${ProgramFiles(x86)}\VideoLAN\VLC\vlc.exe`}},
 }
 
 // Windows-side players reachable from WSL via interop. Probed after the
 // native Linux list so a Linux install (e.g. via WSLg) still wins.
 var wslPlayers = []PlayerDef{
-	{Binary: "PotPlayerMini64.exe", SeekFlag: "/seek=%d"},
-	{Binary: "PotPlayerMini.exe", SeekFlag: "/seek=%d"},
-	{Binary: "mpv.exe", SeekFlag: "--start=%d"},
-	{Binary: "vlc.exe", SeekFlag: "--start-time=%d"},
+	{Name: "potplayer", Binary: "PotPlayerMini64.exe", SeekFlag: "/seek=%d"},
+	{Name: "potplayer", Binary: "PotPlayerMini.exe", SeekFlag: "/seek=%d"},
+	{Name: "mpv", Binary: "mpv.exe", SeekFlag: "--start=%d"},
+	{Name: "vlc", Binary: "vlc.exe", SeekFlag: "--start-time=%d"},
 }
 
 func lookPathOK(binary string) bool {
@@ -97,18 +148,25 @@ func NewLauncher(command string, args []string, seekFlag string, logger *slog.Lo
 
 // Launch opens a media URL in the configured player or auto-detected player
 func (l *Launcher) Launch(url string, startOffset time.Duration) error {
+	return l.launch(url, startOffset, nil, nil)
+}
+
+// launch is Launch with extra mpv arguments (IPC socket, chapters file),
+// and exited, if not nil, called once the player process exits. Only pass
+// mpvArgs when the player is mpv (see usesMPV).
+func (l *Launcher) launch(url string, startOffset time.Duration, mpvArgs []string, exited func()) error {
 	offsetSecs := int(startOffset.Seconds())
 
 	// Tier 1: User configured a specific player
 	if l.command != "" {
 		l.logger.Info("using configured player", "command", l.command)
-		return l.launchConfigured(url, offsetSecs)
+		return l.launchConfigured(url, offsetSecs, mpvArgs, exited)
 	}
 
 	// Tier 2: Auto-detect known players
 	if player, found := l.detectPlayer(); found {
-		l.logger.Info("auto-detected player", "binary", player.Binary)
-		return l.execPlayer(player, url, offsetSecs)
+		l.logger.Info("auto-detected player", "player", player.Name, "binary", player.Binary)
+		return l.execPlayer(player, url, offsetSecs, mpvArgs, exited)
 	}
 
 	// Tier 3: System default fallback (xdg-open/open)
@@ -116,29 +174,120 @@ func (l *Launcher) Launch(url string, startOffset time.Duration) error {
 	if offsetSecs > 0 {
 		l.logger.Warn("resume not supported with system default player - starting from beginning")
 	}
-	return l.launchDefault(url)
+	return l.launchDefault(url, exited)
 }
 
-// detectPlayer returns the first available player from the platform-specific list
-func (l *Launcher) detectPlayer() (PlayerDef, bool) {
-	var candidates []PlayerDef
+// LaunchQueue opens several media URLs as one playlist. Configured and
+// auto-detected players take every URL as a positional argument (mpv, vlc,
+// and friends queue them in order); the system default handler can only
+// open one, so the rest are dropped with a warning.
+func (l *Launcher) LaunchQueue(urls []string) error {
+	return l.launchQueue(urls, nil)
+}
+
+// launchQueue is LaunchQueue calling exited, if not nil, once the player
+// exits
+func (l *Launcher) launchQueue(urls []string, exited func()) error {
+	if len(urls) == 0 {
+		return nil
+	}
+	if len(urls) == 1 {
+		return l.launch(urls[0], 0, nil, exited)
+	}
+
+	if l.command != "" {
+		l.logger.Info("queueing on configured player", "command", l.command, "count", len(urls))
+		command := resolveCommand(l.command)
+		args := append(append([]string{}, l.args...), urls...)
+		l.logger.Debug("launching configured player", "command", command, "args", redactTokens(args))
+		if runtime.GOOS == "darwin" {
+			if _, err := exec.LookPath(command); err != nil {
+				return l.launchMacOSApp(command, args, exited)
+			}
+		}
+		return start(exec.Command(command, args...), exited)
+	}
 
+	if player, found := l.detectPlayer(); found {
+		l.logger.Info("queueing on auto-detected player", "binary", player.Binary, "count", len(urls))
+		l.logger.Debug("executing player", "binary", player.Binary, "args", redactTokens(urls))
+		return start(exec.Command(player.Binary, urls...), exited)
+	}
+
+	l.logger.Warn("system default player cannot queue - playing first item only", "count", len(urls))
+	return l.launchDefault(urls[0], exited)
+}
+
+// SetPreference sets the players auto-detection tries first, in order, by
+// name (PlayerDef.Name). A plain name also covers that player's flatpak and
+// snap. Players not listed follow in the platform's usual order.
+func (l *Launcher) SetPreference(names []string) {
+	l.preference = names
+}
+
+// platformPlayers returns the detection candidates for this system, best
+// first
+func platformPlayers() []PlayerDef {
 	switch runtime.GOOS {
 	case "darwin":
-		candidates = darwinPlayers
+		return darwinPlayers
+	case "windows":
+		return windowsPlayers
 	case "linux":
-		candidates = linuxPlayers
 		if isWSL() {
 			// WSL can execute Windows binaries via interop; a Windows-side
 			// mpv/vlc on PATH is a perfectly good player
-			candidates = append(append([]PlayerDef{}, linuxPlayers...), wslPlayers...)
+			return append(append([]PlayerDef{}, linuxPlayers...), wslPlayers...)
 		}
+		return linuxPlayers
 	default:
-		return PlayerDef{}, false
+		return nil
+	}
+}
+
+// rankPlayers moves the preferred players to the front, in preference
+// order, keeping the platform order among the rest
+func rankPlayers(candidates []PlayerDef, preference []string) []PlayerDef {
+	ranked := make([]PlayerDef, 0, len(candidates))
+	taken := make([]bool, len(candidates))
+	for _, name := range preference {
+		name = strings.ToLower(name)
+		for i, p := range candidates {
+			if !taken[i] && (p.Name == name || strings.HasPrefix(p.Name, name+"-")) {
+				ranked = append(ranked, p)
+				taken[i] = true
+			}
+		}
 	}
+	for i, p := range candidates {
+		if !taken[i] {
+			ranked = append(ranked, p)
+		}
+	}
+	return ranked
+}
 
-	for _, p := range candidates {
-		if path, err := exec.LookPath(p.Binary); err == nil && path != "" {
+// locatePlayer finds a player's executable: its binary on PATH, else the
+// first of its install locations that exists
+func locatePlayer(p PlayerDef) (string, bool) {
+	if path, err := exec.LookPath(p.Binary); err == nil && path != "" {
+		return p.Binary, true
+	}
+	for _, loc := range p.Locations {
+		if path, err := exec.LookPath(os.ExpandEnv(loc)); err == nil && path != "" {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// detectPlayer returns the best available player, preferred ones first.
+// A player found in one of its install locations comes back with Binary
+// set to that path.
+func (l *Launcher) detectPlayer() (PlayerDef, bool) {
+	for _, p := range rankPlayers(platformPlayers(), l.preference) {
+		if binary, ok := locatePlayer(p); ok {
+			p.Binary = binary
 			return p, true
 		}
 	}
@@ -146,7 +295,7 @@ func (l *Launcher) detectPlayer() (PlayerDef, bool) {
 }
 
 // execPlayer launches the detected player with optional seek offset
-func (l *Launcher) execPlayer(player PlayerDef, url string, offsetSecs int) error {
+func (l *Launcher) execPlayer(player PlayerDef, url string, offsetSecs int, mpvArgs []string, exited func()) error {
 	args := []string{}
 
 	// Add seek flag if we have an offset and the player supports it
@@ -155,16 +304,37 @@ func (l *Launcher) execPlayer(player PlayerDef, url string, offsetSecs int) erro
 		// Split flags like "-ss 10" into separate args
 		args = append(args, strings.Fields(formattedFlag)...)
 	}
+	args = append(args, mpvArgs...)
 
 	args = append(args, url)
 
 	l.logger.Debug("executing player", "binary", player.Binary, "args", redactTokens(args))
-	cmd := exec.Command(player.Binary, args...)
-	return cmd.Start()
+	return start(exec.Command(player.Binary, args...), exited)
+}
+
+// resolveCommand finds a configured player given by name but not on PATH
+// in that player's install locations, so "vlc" or "mpc-hc" works on
+// Windows, whose installers rarely add players to PATH. Anything else comes
+// back unchanged.
+func resolveCommand(command string) string {
+	if strings.ContainsAny(command, `/\`) || lookPathOK(command) {
+		return command
+	}
+	for _, p := range platformPlayers() {
+		if p.Name != strings.ToLower(command) && !strings.EqualFold(p.Binary, command) &&
+			!strings.EqualFold(p.Binary, command+".exe") {
+			continue
+		}
+		if binary, ok := locatePlayer(p); ok {
+			return binary
+		}
+	}
+	return command
 }
 
 // launchConfigured launches the media using the user-configured player
-func (l *Launcher) launchConfigured(url string, offsetSecs int) error {
+func (l *Launcher) launchConfigured(url string, offsetSecs int, mpvArgs []string, exited func()) error {
+	command := resolveCommand(l.command)
 	args := append([]string{}, l.args...)
 
 	// Add seek offset: user-configured flag takes precedence, then table lookup
@@ -172,7 +342,7 @@ func (l *Launcher) launchConfigured(url string, offsetSecs int) error {
 		seekFlag := l.seekFlag
 		if seekFlag == "" {
 			// Fall back to table lookup for known players
-			seekFlag = l.lookupSeekFlag(l.command)
+			seekFlag = l.lookupSeekFlag(command)
 		}
 
 		if seekFlag != "" {
@@ -183,27 +353,29 @@ func (l *Launcher) launchConfigured(url string, offsetSecs int) error {
 				"command", l.command, "offset", offsetSecs)
 		}
 	}
+	args = append(args, mpvArgs...)
 
 	args = append(args, url)
 
-	l.logger.Debug("launching configured player", "command", l.command, "args", redactTokens(args))
+	l.logger.Debug("launching configured player", "command", command, "args", redactTokens(args))
 
 	// On macOS, try 'open -a' if command not in PATH (for GUI apps)
 	if runtime.GOOS == "darwin" {
-		if _, err := exec.LookPath(l.command); err != nil {
-			return l.launchMacOSApp(l.command, args)
+		if _, err := exec.LookPath(command); err != nil {
+			return l.launchMacOSApp(command, args, exited)
 		}
 	}
 
-	cmd := exec.Command(l.command, args...)
-	return cmd.Start()
+	return start(exec.Command(command, args...), exited)
 }
 
-// lookupSeekFlag finds the seek flag for a known player binary
+// lookupSeekFlag finds the seek flag for a known player binary, given by
+// name or path
 func (l *Launcher) lookupSeekFlag(binary string) string {
-	for _, table := range [][]PlayerDef{linuxPlayers, darwinPlayers, wslPlayers} {
+	base := filepath.Base(binary)
+	for _, table := range [][]PlayerDef{linuxPlayers, darwinPlayers, windowsPlayers, wslPlayers} {
 		for _, p := range table {
-			if p.Binary == binary {
+			if p.Binary == binary || p.Name == binary || filepath.Base(p.Binary) == base {
 				return p.SeekFlag
 			}
 		}
@@ -212,7 +384,7 @@ func (l *Launcher) lookupSeekFlag(binary string) string {
 }
 
 // launchMacOSApp launches a macOS GUI app using 'open -a'
-func (l *Launcher) launchMacOSApp(appName string, playerArgs []string) error {
+func (l *Launcher) launchMacOSApp(appName string, playerArgs []string, exited func()) error {
 	cmdArgs := []string{"-a", appName}
 	if len(playerArgs) > 0 {
 		cmdArgs = append(cmdArgs, "--args")
@@ -220,44 +392,76 @@ func (l *Launcher) launchMacOSApp(appName string, playerArgs []string) error {
 	}
 
 	l.logger.Debug("using macOS 'open -a'", "app", appName, "args", redactTokens(cmdArgs))
-	cmd := exec.Command("open", cmdArgs...)
-	return cmd.Start()
+	return start(exec.Command("open", cmdArgs...), exited)
 }
 
 // launchDefault opens the URL using the system default handler
-func (l *Launcher) launchDefault(url string) error {
-	var cmd *exec.Cmd
+func (l *Launcher) launchDefault(url string, exited func()) error {
+	cmd := systemOpener(url)
+	if cmd == nil {
+		return fmt.Errorf("no media player found — install mpv (or vlc), or set player.command in config.yaml")
+	}
+	l.logger.Debug("launching with system default", "os", runtime.GOOS, "command", cmd.Path)
+	return start(cmd, exited)
+}
 
+// start starts a player process. With exited set, it waits for the process
+// in the background and calls exited when it ends. Handing off through
+// open or xdg-open ends as soon as the player is started, not when it
+// closes.
+func start(cmd *exec.Cmd, exited func()) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if exited != nil {
+		go func() {
+			cmd.Wait()
+			exited()
+		}()
+	}
+	return nil
+}
+
+// OpenURL opens a web page in the system browser
+func (l *Launcher) OpenURL(url string) error {
+	cmd := systemOpener(url)
+	if cmd == nil {
+		return fmt.Errorf("no browser opener found — install xdg-utils")
+	}
+	l.logger.Debug("opening in browser", "os", runtime.GOOS, "command", cmd.Path)
+	return cmd.Start()
+}
+
+// systemOpener returns the command that hands a URL to the system default
+// handler, nil when there is none
+func systemOpener(url string) *exec.Cmd {
 	switch runtime.GOOS {
 	case "darwin":
-		cmd = exec.Command("open", url)
-	default:
-		// Linux and other Unix-like systems
-		if isWSL() {
-			// WSL distros usually have no xdg-open; hand the URL to Windows.
-			// wslview (from wslu) is purpose-built for this. rundll32's
-			// FileProtocolHandler is the reliable built-in: explorer.exe
-			// chokes on URLs with query strings (?a=1&b=2) and silently
-			// opens the Documents folder instead.
-			switch {
-			case lookPathOK("wslview"):
-				cmd = exec.Command("wslview", url)
-			case lookPathOK("rundll32.exe"):
-				cmd = exec.Command("rundll32.exe", "url.dll,FileProtocolHandler", url)
-			case lookPathOK("explorer.exe"):
-				cmd = exec.Command("explorer.exe", url)
-			}
-		}
-		if cmd == nil {
-			if _, err := exec.LookPath("xdg-open"); err != nil {
-				return fmt.Errorf("no media player found — install mpv (or vlc), or set player.command in config.yaml")
-			}
-			cmd = exec.Command("xdg-open", url)
+		return exec.Command("open", url)
+	case "windows":
+		// Not start through cmd, which would split the URL at its &s
+		return exec.Command("rundll32.exe", "url.dll,FileProtocolHandler", url)
+	}
+	// Linux and other Unix-like systems
+	if isWSL() {
+		// WSL distros usually have no xdg-open; hand the URL to Windows.
+		// wslview (from wslu) is purpose-built for this. rundll32's
+		// FileProtocolHandler is the reliable built-in: explorer.exe
+		// chokes on URLs with query strings (?a=1&b=2) and silently
+		// opens the Documents folder instead.
+		switch {
+		case lookPathOK("wslview"):
+			return exec.Command("wslview", url)
+		case lookPathOK("rundll32.exe"):
+			return exec.Command("rundll32.exe", "url.dll,FileProtocolHandler", url)
+		case lookPathOK("explorer.exe"):
+			return exec.Command("explorer.exe", url)
 		}
 	}
-
-	l.logger.Debug("launching with system default", "os", runtime.GOOS, "command", cmd.Path)
-	return cmd.Start()
+	if !lookPathOK("xdg-open") {
+		return nil
+	}
+	return exec.Command("xdg-open", url)
 }
 
 // Service orchestrates playback operations
@@ -265,6 +469,37 @@ type Service struct {
 	launcher *Launcher
 	playback domain.PlaybackClient
 	logger   *slog.Logger
+
+	// private suppresses every watch-state write to the server for this
+	// session. Never persisted: a private session ends when kino exits.
+	private atomic.Bool
+
+	// maxKbps caps the stream bitrate through server transcoding; 0 plays
+	// the original file
+	maxKbps atomic.Int64
+
+	// skipKinds are the segments mpv jumps over (see SetAutoSkip). Set once
+	// at startup.
+	skipKinds []domain.SegmentKind
+
+	// preHook and postHook are the user's commands around playback (see
+	// SetHooks). Set once at startup.
+	preHook, postHook string
+
+	// nowPlaying is the watched mpv playback running, if any (see Attach)
+	nowPlaying nowPlaying
+}
+
+// Qualities are the stream bitrate caps offered, in kbps. 0 is the
+// original file.
+var Qualities = []int{0, 20000, 8000, 4000, 2000}
+
+// QualityLabel names a bitrate cap, e.g. "Original" or "8 Mbps"
+func QualityLabel(kbps int) string {
+	if kbps <= 0 {
+		return "Original"
+	}
+	return fmt.Sprintf("%d Mbps", kbps/1000)
 }
 
 // NewService creates a new playback service
@@ -279,35 +514,236 @@ func NewService(launcher *Launcher, playback domain.PlaybackClient, logger *slog
 	}
 }
 
+// SetPrivate turns private browsing on or off for the rest of the session
+func (s *Service) SetPrivate(on bool) {
+	s.private.Store(on)
+	s.logger.Info("private session", "enabled", on)
+}
+
+// SetMaxBitrate caps stream quality at kbps (0 for the original file).
+// Backends that can't transcode keep playing the original.
+func (s *Service) SetMaxBitrate(kbps int) {
+	s.maxKbps.Store(int64(max(kbps, 0)))
+	s.logger.Info("stream quality", "quality", QualityLabel(kbps))
+}
+
+// SetAutoSkip makes mpv jump over these kinds of segments (intros,
+// credits) the first time playback reaches them
+func (s *Service) SetAutoSkip(kinds ...domain.SegmentKind) {
+	s.skipKinds = kinds
+}
+
+// MaxBitrate returns the stream bitrate cap in kbps, 0 for the original
+func (s *Service) MaxBitrate() int {
+	return int(s.maxKbps.Load())
+}
+
+// Private reports whether the session is in private mode
+func (s *Service) Private() bool {
+	return s.private.Load()
+}
+
 // Play starts playback of a media item from the beginning
 func (s *Service) Play(ctx context.Context, item domain.MediaItem) error {
-	return s.playItem(ctx, item, 0)
+	_, err := s.playItem(ctx, item, "", 0, false)
+	return err
 }
 
 // Resume starts playback from the saved position
 func (s *Service) Resume(ctx context.Context, item domain.MediaItem) error {
-	return s.playItem(ctx, item, item.ViewOffset)
+	_, err := s.playItem(ctx, item, "", item.ViewOffset, false)
+	return err
+}
+
+// PlayWatched is Play (or Resume) that also returns a channel reporting
+// whether the item played to its end; nil when the player can't be
+// observed (see Launcher.LaunchWatched)
+func (s *Service) PlayWatched(ctx context.Context, item domain.MediaItem, resume bool) (<-chan bool, error) {
+	return s.PlayVersionWatched(ctx, item, "", resume)
+}
+
+// PlayVersionWatched is PlayWatched for one of the item's versions
+// (MediaItem.Versions); an empty versionID plays the default one
+func (s *Service) PlayVersionWatched(ctx context.Context, item domain.MediaItem, versionID string, resume bool) (<-chan bool, error) {
+	var offset time.Duration
+	if resume {
+		offset = item.ViewOffset
+	}
+	return s.playItem(ctx, item, versionID, offset, true)
+}
+
+// CanPickVersion reports whether a version other than the default can be
+// played. A quality cap transcodes whichever file the server picks, so
+// the choice only applies to direct play.
+func (s *Service) CanPickVersion() bool {
+	_, ok := s.playback.(domain.VersionClient)
+	return ok && s.MaxBitrate() == 0
 }
 
 // playItem resolves URL and launches player
-func (s *Service) playItem(ctx context.Context, item domain.MediaItem, offset time.Duration) error {
-	url, err := s.playback.ResolvePlayableURL(ctx, item.ID)
+func (s *Service) playItem(ctx context.Context, item domain.MediaItem, versionID string, offset time.Duration, watch bool) (<-chan bool, error) {
+	url, err := s.resolveURL(ctx, item, versionID)
 	if err != nil {
 		s.logger.Error("failed to resolve playable URL", "error", err, "itemID", item.ID)
-		return err
+		return nil, err
+	}
+
+	// Launches hand the player a direct stream URL and never report
+	// playing/progress themselves, so private mode needs no special case
+	// here; any future progress reporting must check Private()
+	s.logger.Info("launching playback", "title", item.Title, "itemID", item.ID, "version", versionID, "offset", offset, "private", s.Private())
+
+	s.runHook(s.preHook, newHookInfo(hookPrePlay, item, offset))
+	if watch && s.launcher.usesMPV() {
+		socket, ended, err := s.launcher.launchWatched(url, offset, s.marks(ctx, item))
+		if err != nil {
+			return nil, err
+		}
+		s.nowPlaying.set(Session{Socket: socket, Item: item})
+		return s.afterPlayback(ended, socket, item, offset), nil
+	}
+	return nil, s.launcher.launch(url, offset, nil, s.exitHook(item, offset))
+}
+
+// afterPlayback passes on how mpv playback ended, then ends its session
+// and runs the post-play hook
+func (s *Service) afterPlayback(ended <-chan bool, socket string, item domain.MediaItem, offset time.Duration) <-chan bool {
+	out := make(chan bool, 1)
+	go func() {
+		eof := <-ended
+		out <- eof
+		s.nowPlaying.clear(socket)
+		if s.postHook == "" {
+			return
+		}
+		info := newHookInfo(hookPostPlay, item, offset)
+		info.PlayedToEnd = &eof
+		s.runHook(s.postHook, info)
+	}()
+	return out
+}
+
+// exitHook is the launcher callback running the post-play hook when the
+// player exits, nil without one
+func (s *Service) exitHook(item domain.MediaItem, offset time.Duration) func() {
+	if s.postHook == "" {
+		return nil
+	}
+	return func() { s.runHook(s.postHook, newHookInfo(hookPostPlay, item, offset)) }
+}
+
+// marks fetches an item's chapters and intro/credits markers for mpv, the
+// one player that takes them. A failure costs only the chapters and the
+// skipping, never the playback.
+func (s *Service) marks(ctx context.Context, item domain.MediaItem) Marks {
+	mc, ok := s.playback.(domain.MarkerClient)
+	if !ok || item.Type == domain.MediaTypeChannel || !s.launcher.usesMPV() {
+		return Marks{}
+	}
+	markers, err := mc.GetMarkers(ctx, item.ID)
+	if err != nil {
+		s.logger.Warn("failed to fetch markers", "error", err, "itemID", item.ID)
+		return Marks{}
+	}
+	marks := Marks{Chapters: markerChapters(markers), Duration: item.Duration}
+	for _, seg := range markers.Segments {
+		if slices.Contains(s.skipKinds, seg.Kind) {
+			marks.Skip = append(marks.Skip, seg)
+		}
 	}
+	return marks
+}
 
-	s.logger.Info("launching playback", "title", item.Title, "itemID", item.ID, "offset", offset)
+// resolveURL resolves a playable URL; Live TV channels tune a live stream
+// through the backend's LiveTVClient instead of streaming a file. A chosen
+// version plays directly when the backend supports picking one.
+func (s *Service) resolveURL(ctx context.Context, item domain.MediaItem, versionID string) (string, error) {
+	if item.Type == domain.MediaTypeChannel {
+		lt, ok := s.playback.(domain.LiveTVClient)
+		if !ok {
+			return "", domain.ErrItemNotFound
+		}
+		return lt.ResolveChannelURL(ctx, item.ID)
+	}
+	if versionID != "" {
+		if vc, ok := s.playback.(domain.VersionClient); ok {
+			return vc.ResolveVersionURL(ctx, item.ID, versionID)
+		}
+		s.logger.Warn("backend cannot pick versions, playing default", "itemID", item.ID)
+	}
+	return s.streamURL(ctx, item.ID)
+}
 
-	return s.launcher.Launch(url, offset)
+// streamURL resolves a file's stream, transcoded when a quality cap is set
+// and the backend can transcode
+func (s *Service) streamURL(ctx context.Context, itemID string) (string, error) {
+	if kbps := s.MaxBitrate(); kbps > 0 {
+		if tc, ok := s.playback.(domain.TranscodeClient); ok {
+			return tc.ResolveTranscodedURL(ctx, itemID, kbps)
+		}
+		s.logger.Warn("backend cannot transcode, playing original", "itemID", itemID)
+	}
+	return s.playback.ResolvePlayableURL(ctx, itemID)
+}
+
+// StreamURL resolves an item's stream the way Play would: transcoded when
+// a quality cap is set. For players kino drives itself (see LaunchIdle).
+func (s *Service) StreamURL(ctx context.Context, itemID string) (string, error) {
+	return s.streamURL(ctx, itemID)
+}
+
+// LaunchIdle starts an mpv for kino to drive over IPC (see Launcher.LaunchIdle)
+func (s *Service) LaunchIdle() (*MPV, error) {
+	return s.launcher.LaunchIdle()
+}
+
+// PlayableURL resolves an item's original stream, ignoring the quality cap:
+// for handing to other players, e.g. in an exported playlist
+func (s *Service) PlayableURL(ctx context.Context, itemID string) (string, error) {
+	return s.playback.ResolvePlayableURL(ctx, itemID)
+}
+
+// PlayQueue resolves every item and hands them to the player as one queue,
+// in order. Queued items always start from the beginning. The playback
+// hooks run once around the whole queue, told about its first item.
+func (s *Service) PlayQueue(ctx context.Context, items []domain.MediaItem) error {
+	urls := make([]string, 0, len(items))
+	for _, item := range items {
+		url, err := s.streamURL(ctx, item.ID)
+		if err != nil {
+			s.logger.Error("failed to resolve playable URL", "error", err, "itemID", item.ID)
+			return err
+		}
+		urls = append(urls, url)
+	}
+
+	s.logger.Info("launching playback queue", "count", len(urls))
+
+	if len(items) == 0 {
+		return nil
+	}
+	s.runHook(s.preHook, newHookInfo(hookPrePlay, items[0], 0))
+	return s.launcher.launchQueue(urls, s.exitHook(items[0], 0))
+}
+
+// OpenURL opens an item's web page (IMDb, TMDB) in the browser
+func (s *Service) OpenURL(url string) error {
+	s.logger.Info("opening in browser", "url", url)
+	return s.launcher.OpenURL(url)
 }
 
 // MarkWatched marks an item as fully watched
 func (s *Service) MarkWatched(ctx context.Context, itemID string) error {
+	if s.Private() {
+		return domain.ErrPrivateSession
+	}
 	return s.playback.MarkPlayed(ctx, itemID)
 }
 
 // MarkUnwatched marks an item as unwatched
 func (s *Service) MarkUnwatched(ctx context.Context, itemID string) error {
+	if s.Private() {
+		return domain.ErrPrivateSession
+	}
 	return s.playback.MarkUnplayed(ctx, itemID)
 }
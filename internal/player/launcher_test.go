@@ -1,12 +1,20 @@
 package player
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
 )
 
 func fakeBinary(t *testing.T, dir, name string) {
@@ -85,6 +93,105 @@ func TestDetectPlayerWSLPrefersLinuxPlayer(t *testing.T) {
 	}
 }
 
+// A flatpak mpv is found through its export even when the exports dir is
+// not on PATH, but kino can't reach a sandboxed mpv's IPC socket.
+func TestDetectPlayerFlatpakExport(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("linux-only")
+	}
+	home := t.TempDir()
+	exports := filepath.Join(home, ".local/share/flatpak/exports/bin")
+	if err := os.MkdirAll(exports, 0755); err != nil {
+		t.Fatal(err)
+	}
+	fakeBinary(t, exports, "io.mpv.Mpv")
+	t.Setenv("HOME", home)
+	t.Setenv("PATH", t.TempDir())
+
+	l := NewLauncher("", nil, "", nil)
+	p, found := l.detectPlayer()
+	if !found || p.Name != "mpv-flatpak" {
+		t.Fatalf("expected the mpv flatpak, got %q (found=%v)", p.Name, found)
+	}
+	if p.Binary != filepath.Join(exports, "io.mpv.Mpv") {
+		t.Fatalf("binary = %q, want the export's path", p.Binary)
+	}
+	if l.usesMPV() {
+		t.Fatal("a flatpak mpv must not be driven over IPC")
+	}
+}
+
+// player.preference reorders detection; a plain name covers its packaged
+// builds and unlisted players keep their usual order.
+func TestDetectPlayerPreference(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("linux-only")
+	}
+	dir := t.TempDir()
+	fakeBinary(t, dir, "mpv")
+	fakeBinary(t, dir, "org.videolan.VLC")
+	fakeBinary(t, dir, "haruna")
+	t.Setenv("PATH", dir)
+	t.Setenv("HOME", t.TempDir())
+
+	l := NewLauncher("", nil, "", nil)
+	for _, tc := range []struct {
+		preference []string
+		want       string
+	}{
+		{nil, "mpv"},
+		{[]string{"vlc"}, "vlc-flatpak"},
+		{[]string{"IINA", "haruna", "vlc"}, "haruna"},
+		{[]string{"celluloid"}, "mpv"},
+	} {
+		l.SetPreference(tc.preference)
+		p, found := l.detectPlayer()
+		if !found || p.Name != tc.want {
+			t.Errorf("preference %v: detected %q (found=%v), want %q", tc.preference, p.Name, found, tc.want)
+		}
+	}
+}
+
+// A configured player given by name resolves to its install location when
+// it is not on PATH; paths and unknown names pass through.
+func TestResolveCommand(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("linux-only")
+	}
+	home := t.TempDir()
+	exports := filepath.Join(home, ".local/share/flatpak/exports/bin")
+	if err := os.MkdirAll(exports, 0755); err != nil {
+		t.Fatal(err)
+	}
+	fakeBinary(t, exports, "org.videolan.VLC")
+	dir := t.TempDir()
+	fakeBinary(t, dir, "mpv")
+	t.Setenv("HOME", home)
+	t.Setenv("PATH", dir)
+
+	for command, want := range map[string]string{
+		"mpv":              "mpv",
+		"vlc-flatpak":      filepath.Join(exports, "org.videolan.VLC"),
+		"org.videolan.VLC": filepath.Join(exports, "org.videolan.VLC"),
+		"vlc":              "vlc",
+		"/opt/vlc":         "/opt/vlc",
+	} {
+		if got := resolveCommand(command); got != want {
+			t.Errorf("resolveCommand(%q) = %q, want %q", command, got, want)
+		}
+	}
+}
+
+// Hooks reach cmd.exe verbatim: quotes and &s in the command stay as the
+// user wrote them.
+func TestWindowsShellLine(t *testing.T) {
+	got := windowsShellLine(`"C:\Program Files\lights.exe" dim & echo done`)
+	want := `cmd /S /C ""C:\Program Files\lights.exe" dim & echo done"`
+	if got != want {
+		t.Fatalf("shell line = %s, want %s", got, want)
+	}
+}
+
 // The system-default fallback on WSL uses a Windows opener instead of the
 // usually-absent xdg-open, and prefers rundll32 over explorer.exe (which
 // mangles URLs containing query strings and opens Documents instead).
@@ -104,7 +211,7 @@ func TestLaunchDefaultWSLPrefersRundll32(t *testing.T) {
 
 	l := NewLauncher("", nil, "", nil)
 	url := "http://server:8096/stream.mkv?Static=true&api_key=x"
-	if err := l.launchDefault(url); err != nil {
+	if err := l.launchDefault(url, nil); err != nil {
 		t.Fatalf("launchDefault failed: %v", err)
 	}
 
@@ -133,7 +240,7 @@ func TestLaunchDefaultWSLExplorerLastResort(t *testing.T) {
 	forceWSL(t)
 
 	l := NewLauncher("", nil, "", nil)
-	if err := l.launchDefault("http://example.invalid/stream"); err != nil {
+	if err := l.launchDefault("http://example.invalid/stream", nil); err != nil {
 		t.Fatalf("launchDefault failed: %v", err)
 	}
 }
@@ -171,7 +278,7 @@ func TestLaunchDefaultNoOpenerActionableError(t *testing.T) {
 	forceWSL(t)
 
 	l := NewLauncher("", nil, "", nil)
-	err := l.launchDefault("http://example.invalid/stream")
+	err := l.launchDefault("http://example.invalid/stream", nil)
 	if err == nil {
 		t.Fatal("expected error with no opener available")
 	}
@@ -179,3 +286,257 @@ func TestLaunchDefaultNoOpenerActionableError(t *testing.T) {
 		t.Fatalf("error not actionable: %v", err)
 	}
 }
+
+// countingPlayback records watch-state writes
+type countingPlayback struct {
+	writes int
+}
+
+func (c *countingPlayback) ResolvePlayableURL(ctx context.Context, itemID string) (string, error) {
+	return "", nil
+}
+func (c *countingPlayback) MarkPlayed(ctx context.Context, itemID string) error {
+	c.writes++
+	return nil
+}
+func (c *countingPlayback) MarkUnplayed(ctx context.Context, itemID string) error {
+	c.writes++
+	return nil
+}
+
+func TestPrivateSessionSuppressesWatchState(t *testing.T) {
+	pb := &countingPlayback{}
+	svc := NewService(NewLauncher("", nil, "", nil), pb, nil)
+
+	svc.SetPrivate(true)
+	if err := svc.MarkWatched(context.Background(), "1"); !errors.Is(err, domain.ErrPrivateSession) {
+		t.Fatalf("MarkWatched err = %v, want ErrPrivateSession", err)
+	}
+	if err := svc.MarkUnwatched(context.Background(), "1"); !errors.Is(err, domain.ErrPrivateSession) {
+		t.Fatalf("MarkUnwatched err = %v, want ErrPrivateSession", err)
+	}
+	if pb.writes != 0 {
+		t.Fatalf("private session wrote %d times", pb.writes)
+	}
+
+	svc.SetPrivate(false)
+	if err := svc.MarkWatched(context.Background(), "1"); err != nil || pb.writes != 1 {
+		t.Fatalf("after ending private session: err=%v writes=%d", err, pb.writes)
+	}
+}
+
+// Only an end-file with reason eof counts as playing to the end
+func TestPlayedToEnd(t *testing.T) {
+	cases := map[string]bool{
+		`{"event":"start-file"}` + "\n" + `{"event":"end-file","reason":"eof"}` + "\n": true,
+		`{"event":"end-file","reason":"quit"}` + "\n":                                  false,
+		`{"event":"end-file","reason":"eof"}` + "\n" + `{"event":"shutdown"}` + "\n":   true,
+		`not json` + "\n": false,
+	}
+	for stream, want := range cases {
+		if got := playedToEnd(strings.NewReader(stream)); got != want {
+			t.Errorf("playedToEnd(%q) = %v, want %v", stream, got, want)
+		}
+	}
+}
+
+// Entering a segment seeks past it once; seeking back into it plays it
+func TestFollowPlaybackSkipsSegmentsOnce(t *testing.T) {
+	skip := []domain.Segment{{Kind: domain.SegmentIntro, Start: 30 * time.Second, End: 90 * time.Second}}
+	stream := strings.Join([]string{
+		`{"event":"property-change","name":"time-pos","data":10.0}`,
+		`{"event":"property-change","name":"time-pos","data":31.5}`,
+		`{"event":"property-change","name":"time-pos","data":35.0}`, // Back in after skipping
+		`{"event":"property-change","name":"time-pos","data":null}`,
+		`{"event":"end-file","reason":"eof"}`,
+	}, "\n") + "\n"
+
+	var sent strings.Builder
+	if !followPlayback(strings.NewReader(stream), &sent, skip) {
+		t.Error("eof not reported")
+	}
+	want := `{"command":["observe_property",1,"time-pos"]}` + "\n" +
+		`{"command":["set_property","time-pos",90]}` + "\n" +
+		`{"command":["show-text","Skipped intro",2000]}` + "\n"
+	if sent.String() != want {
+		t.Errorf("sent:\n%s\nwant:\n%s", sent.String(), want)
+	}
+}
+
+// Segments become chapters named for their kind; the stretch after one
+// resumes the server chapter it interrupted
+func TestMarkerChapters(t *testing.T) {
+	markers := &domain.Markers{
+		Chapters: []domain.Chapter{{Title: "Cold Open"}, {Title: "Act One", Start: 60 * time.Second}, {Title: "Act Two", Start: 20 * time.Minute}},
+		Segments: []domain.Segment{{Kind: domain.SegmentIntro, Start: 60500 * time.Millisecond, End: 2 * time.Minute}},
+	}
+	var got []string
+	for _, c := range markerChapters(markers) {
+		got = append(got, fmt.Sprintf("%s@%s", c.Title, c.Start))
+	}
+	if want := "Cold Open@0s Intro@1m0.5s Act One@2m0s Act Two@20m0s"; strings.Join(got, " ") != want {
+		t.Errorf("chapters = %s, want %s", strings.Join(got, " "), want)
+	}
+	if markerChapters(&domain.Markers{Chapters: markers.Chapters}) != nil {
+		t.Error("chapters without segments should keep the file's own")
+	}
+
+	meta := ffmetadata([]domain.Chapter{{Title: "A=B"}, {Title: "Credits", Start: time.Minute}}, 90*time.Second)
+	if !strings.Contains(meta, "START=0\nEND=60000\ntitle=A\\=B\n") || !strings.Contains(meta, "START=60000\nEND=90000\ntitle=Credits\n") {
+		t.Errorf("ffmetadata:\n%s", meta)
+	}
+}
+
+// transcodingPlayback records which stream a play resolved
+type transcodingPlayback struct {
+	countingPlayback
+	transcodedKbps int
+}
+
+func (t *transcodingPlayback) ResolvePlayableURL(ctx context.Context, itemID string) (string, error) {
+	return "original", nil
+}
+
+func (t *transcodingPlayback) ResolveTranscodedURL(ctx context.Context, itemID string, maxKbps int) (string, error) {
+	t.transcodedKbps = maxKbps
+	return "transcoded", nil
+}
+
+// A quality cap resolves through the backend's transcoder; Original
+// direct-plays
+func TestQualityCapTranscodes(t *testing.T) {
+	pb := &transcodingPlayback{}
+	svc := NewService(NewLauncher("", nil, "", nil), pb, nil)
+	ctx := context.Background()
+
+	if url, _ := svc.streamURL(ctx, "1"); url != "original" {
+		t.Fatalf("uncapped stream = %q, want original", url)
+	}
+	svc.SetMaxBitrate(4000)
+	if url, _ := svc.streamURL(ctx, "1"); url != "transcoded" || pb.transcodedKbps != 4000 {
+		t.Fatalf("capped stream = %q at %d kbps", url, pb.transcodedKbps)
+	}
+	if got := QualityLabel(svc.MaxBitrate()); got != "4 Mbps" {
+		t.Fatalf("label = %q", got)
+	}
+}
+
+// The pre-play hook runs before the player starts and the post-play hook
+// after it exits, each told about the item
+func TestPlaybackHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	dir := t.TempDir()
+	log := filepath.Join(dir, "log")
+	player := filepath.Join(dir, "player")
+	if err := os.WriteFile(player, []byte("#!/bin/sh\necho player >> "+log+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewService(NewLauncher(player, nil, "", nil), &countingPlayback{}, nil)
+	svc.SetHooks(
+		`echo "$KINO_EVENT $KINO_SHOW S${KINO_SEASON}E${KINO_EPISODE}" >> `+log,
+		`echo "$KINO_EVENT $(cat)" >> `+log,
+	)
+
+	item := domain.MediaItem{ID: "7", Title: "Pilot", Type: domain.MediaTypeEpisode, ShowTitle: "Fargo", SeasonNum: 1, EpisodeNum: 1}
+	if err := svc.Play(context.Background(), item); err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for deadline := time.Now().Add(5 * time.Second); len(lines) < 3 && time.Now().Before(deadline); {
+		time.Sleep(20 * time.Millisecond)
+		data, _ := os.ReadFile(log)
+		lines = strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+	if len(lines) != 3 || lines[0] != "pre_play Fargo S1E1" || lines[1] != "player" ||
+		!strings.HasPrefix(lines[2], `post_play {"event":"post_play","item_id":"7","title":"Pilot","type":"episode"`) {
+		t.Fatalf("hook log = %q", lines)
+	}
+}
+
+// fakeMPV answers mpv IPC requests on a unix socket from props, replying
+// null to commands it doesn't know
+func fakeMPV(t *testing.T, socket string, props map[string]any) {
+	t.Helper()
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skip("unix sockets unavailable:", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					var req struct {
+						Command   []any `json:"command"`
+						RequestID int64 `json:"request_id"`
+					}
+					if json.Unmarshal(scanner.Bytes(), &req) != nil {
+						continue
+					}
+					reply := map[string]any{"request_id": req.RequestID, "error": "success", "data": nil}
+					if len(req.Command) == 2 && req.Command[0] == "get_property" {
+						if v, ok := props[req.Command[1].(string)]; ok {
+							reply["data"] = v
+						} else {
+							reply["error"] = "property unavailable"
+						}
+					}
+					data, _ := json.Marshal(reply)
+					conn.Write(append(data, '\n'))
+				}
+			}()
+		}
+	}()
+}
+
+// A session saved by an earlier kino is reattached while its mpv is still
+// listening, and forgotten once it isn't
+func TestAttachSavedSession(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses unix sockets")
+	}
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "mpv.sock")
+	file := filepath.Join(dir, "nowplaying.json")
+	fakeMPV(t, socket, map[string]any{"pause": true, "time-pos": 90.5, "duration": 3600.0})
+
+	launched := NewService(NewLauncher("", nil, "", nil), &countingPlayback{}, nil)
+	launched.SetSessionFile(file)
+	launched.nowPlaying.set(Session{Socket: socket, Item: domain.MediaItem{ID: "7", Title: "Pilot"}})
+
+	svc := NewService(NewLauncher("", nil, "", nil), &countingPlayback{}, nil)
+	svc.SetSessionFile(file)
+	session, mpv := svc.Attach()
+	if session == nil {
+		t.Fatal("Attach() found no session")
+	}
+	defer mpv.Detach()
+	if session.Item.Title != "Pilot" {
+		t.Errorf("item = %q, want Pilot", session.Item.Title)
+	}
+	st, err := mpv.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Status{Position: 90500 * time.Millisecond, Duration: time.Hour, Paused: true}); st != want {
+		t.Errorf("Status() = %+v, want %+v", st, want)
+	}
+
+	launched.nowPlaying.set(Session{Socket: filepath.Join(dir, "gone.sock")})
+	stale := NewService(NewLauncher("", nil, "", nil), &countingPlayback{}, nil)
+	stale.SetSessionFile(file)
+	if session, _ := stale.Attach(); session != nil {
+		t.Fatalf("attached to a stale session: %+v", session)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("stale session file kept: %v", err)
+	}
+}
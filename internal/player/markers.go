@@ -0,0 +1,106 @@
+package player
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// chaptersFlag makes mpv read chapters from a file instead of the stream
+const chaptersFlag = "--chapters-file="
+
+// Marks are what an mpv launch gets besides the stream: chapters for its
+// timeline, and segments to skip as playback reaches them
+type Marks struct {
+	Chapters []domain.Chapter
+	Skip     []domain.Segment
+	Duration time.Duration // Ends the last chapter
+}
+
+// markerChapters merges an item's chapters with its segments: each segment
+// becomes a chapter named for its kind, and the stretch after it resumes
+// the chapter it interrupted. A server chapter starting within a second of
+// a segment boundary gives way to it.
+func markerChapters(m *domain.Markers) []domain.Chapter {
+	if m == nil || len(m.Segments) == 0 {
+		return nil // Nothing to add to the file's own chapters
+	}
+
+	// titleAt is the server chapter playing at pos
+	titleAt := func(pos time.Duration) string {
+		title := ""
+		for _, c := range m.Chapters {
+			if c.Start <= pos {
+				title = c.Title
+			}
+		}
+		return title
+	}
+
+	var chapters []domain.Chapter
+	var bounds []time.Duration
+	for _, seg := range m.Segments {
+		chapters = append(chapters, domain.Chapter{Title: seg.Kind.Label(), Start: seg.Start})
+		chapters = append(chapters, domain.Chapter{Title: titleAt(seg.End), Start: seg.End})
+		bounds = append(bounds, seg.Start, seg.End)
+	}
+	for _, c := range m.Chapters {
+		near := false
+		for _, b := range bounds {
+			if (c.Start - b).Abs() < time.Second {
+				near = true
+				break
+			}
+		}
+		if !near {
+			chapters = append(chapters, c)
+		}
+	}
+	sort.SliceStable(chapters, func(i, j int) bool { return chapters[i].Start < chapters[j].Start })
+	return chapters
+}
+
+// writeChaptersFile writes chapters in FFmpeg's metadata format, which mpv
+// reads with --chapters-file, and returns the file's path. The caller
+// removes it.
+func writeChaptersFile(chapters []domain.Chapter, duration time.Duration) (string, error) {
+	f, err := os.CreateTemp("", "kino-chapters-*.txt")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(ffmetadata(chapters, duration)); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// ffmetadata renders chapters as an FFmpeg metadata file. Each chapter ends
+// where the next starts; the last at the item's end.
+func ffmetadata(chapters []domain.Chapter, duration time.Duration) string {
+	var b strings.Builder
+	b.WriteString(";FFMETADATA1\n")
+	for i, c := range chapters {
+		end := max(duration, c.Start)
+		if i+1 < len(chapters) {
+			end = chapters[i+1].Start
+		}
+		fmt.Fprintf(&b, "[CHAPTER]\nTIMEBASE=1/1000\nSTART=%d\nEND=%d\ntitle=%s\n",
+			c.Start.Milliseconds(), end.Milliseconds(), ffmetadataEscaper.Replace(c.Title))
+	}
+	return b.String()
+}
+
+// ffmetadataEscaper escapes the characters special in metadata values
+var ffmetadataEscaper = strings.NewReplacer(
+	`\`, `\\`, "=", `\=`, ";", `\;`, "#", `\#`, "\n", `\`+"\n",
+)
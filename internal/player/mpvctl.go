@@ -0,0 +1,359 @@
+package player
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Timeouts for driving mpv: a command's reply, and a file opening (which
+// waits on the server's stream)
+const (
+	mpvReplyTimeout = 5 * time.Second
+	mpvLoadTimeout  = time.Minute
+)
+
+// pauseObserveID tags the pause observation behind MPV.Pauses
+const pauseObserveID = 2
+
+// ErrNotMPV means the configured or detected player is not an mpv kino can
+// drive over IPC
+var ErrNotMPV = errors.New("player is not mpv")
+
+// MPV is an mpv that kino steers over its IPC socket instead of handing a
+// URL off to: files are loaded, paused and seeked on command (sync groups)
+type MPV struct {
+	conn   net.Conn
+	socket string
+	wmu    sync.Mutex
+
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]chan mpvReply
+
+	loaded chan struct{} // A file finished opening
+	pauses chan bool     // The pause property changed
+	done   chan struct{} // mpv exited
+
+	attached bool // Connected to an mpv someone else launched (AttachMPV)
+}
+
+// mpvReply is mpv's answer to one command
+type mpvReply struct {
+	Error string          `json:"error"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// LaunchIdle starts mpv with no file and a window, waiting for MPV.Load.
+// The configured command and arguments apply; any player but mpv gets
+// ErrNotMPV.
+func (l *Launcher) LaunchIdle() (*MPV, error) {
+	if !l.usesMPV() {
+		return nil, ErrNotMPV
+	}
+	binary, args := "mpv", []string{}
+	if l.command != "" {
+		binary, args = resolveCommand(l.command), append(args, l.args...)
+	}
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("kino-mpv-%d-%d.sock", os.Getpid(), ipcSeq.Add(1)))
+	args = append(args, "--idle=yes", "--force-window=yes", "--keep-open=yes", ipcFlag+path)
+
+	l.logger.Debug("launching idle mpv", "binary", binary, "args", args)
+	cmd := exec.Command(binary, args...)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	go cmd.Wait()
+
+	conn, err := dialIPC(path)
+	if err != nil {
+		cmd.Process.Kill()
+		os.Remove(path)
+		return nil, fmt.Errorf("mpv IPC socket unavailable: %w", err)
+	}
+	m := newMPV(conn, path)
+	go m.readLoop()
+	if _, err := m.command("observe_property", pauseObserveID, "pause"); err != nil {
+		m.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+// AttachMPV connects to an mpv already running with its IPC socket at
+// path, launched by LaunchWatched in this kino or an earlier one. Detach
+// lets go of it again; Close quits it.
+func AttachMPV(path string) (*MPV, error) {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return attachConn(conn, path), nil
+}
+
+// awaitMPV is AttachMPV for an mpv just launched, giving it time to
+// create its socket
+func awaitMPV(path string) (*MPV, error) {
+	conn, err := dialIPC(path)
+	if err != nil {
+		return nil, err
+	}
+	return attachConn(conn, path), nil
+}
+
+func attachConn(conn net.Conn, path string) *MPV {
+	m := newMPV(conn, path)
+	m.attached = true
+	go m.readLoop()
+	return m
+}
+
+func newMPV(conn net.Conn, socket string) *MPV {
+	return &MPV{
+		conn:    conn,
+		socket:  socket,
+		pending: make(map[int64]chan mpvReply),
+		loaded:  make(chan struct{}, 1),
+		pauses:  make(chan bool, 4),
+		done:    make(chan struct{}),
+	}
+}
+
+// dialIPC connects to mpv's socket, giving mpv ipcDialTimeout to create it
+func dialIPC(path string) (net.Conn, error) {
+	deadline := time.Now().Add(ipcDialTimeout)
+	for {
+		conn, err := net.Dial("unix", path)
+		if err == nil || time.Now().After(deadline) {
+			return conn, err
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// readLoop routes replies to their commands and events to their channels
+// until mpv closes the socket
+func (m *MPV) readLoop() {
+	defer func() {
+		m.mu.Lock()
+		close(m.done)
+		m.mu.Unlock()
+		if !m.attached {
+			os.Remove(m.socket)
+		}
+	}()
+
+	scanner := bufio.NewScanner(m.conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var line struct {
+			mpvReply
+			RequestID int64  `json:"request_id"`
+			Event     string `json:"event"`
+			ID        int    `json:"id"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &line) != nil {
+			continue
+		}
+		switch {
+		case line.Event == "file-loaded":
+			select {
+			case m.loaded <- struct{}{}:
+			default:
+			}
+		case line.Event == "property-change" && line.ID == pauseObserveID:
+			var paused bool
+			if json.Unmarshal(line.Data, &paused) == nil {
+				select {
+				case m.pauses <- paused:
+				default: // Nobody listening; the latest state is re-read anyway
+				}
+			}
+		case line.Event == "" && line.RequestID > 0:
+			m.mu.Lock()
+			reply, ok := m.pending[line.RequestID]
+			delete(m.pending, line.RequestID)
+			m.mu.Unlock()
+			if ok {
+				reply <- line.mpvReply
+			}
+		}
+	}
+}
+
+// command sends one IPC command and waits for its reply
+func (m *MPV) command(args ...any) (json.RawMessage, error) {
+	m.mu.Lock()
+	select {
+	case <-m.done:
+		m.mu.Unlock()
+		return nil, errors.New("mpv exited")
+	default:
+	}
+	m.nextID++
+	id := m.nextID
+	reply := make(chan mpvReply, 1)
+	m.pending[id] = reply
+	m.mu.Unlock()
+
+	data, err := json.Marshal(map[string]any{"command": args, "request_id": id})
+	if err != nil {
+		return nil, err
+	}
+	m.wmu.Lock()
+	_, err = m.conn.Write(append(data, '\n'))
+	m.wmu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case r := <-reply:
+		if r.Error != "success" {
+			return nil, fmt.Errorf("mpv %v: %s", args[0], r.Error)
+		}
+		return r.Data, nil
+	case <-m.done:
+		return nil, errors.New("mpv exited")
+	case <-time.After(mpvReplyTimeout):
+		m.mu.Lock()
+		delete(m.pending, id)
+		m.mu.Unlock()
+		return nil, fmt.Errorf("mpv %v: no reply", args[0])
+	}
+}
+
+// Load opens url in place of the current file and seeks to start once it
+// has opened
+func (m *MPV) Load(url string, start time.Duration) error {
+	select {
+	case <-m.loaded: // Drop a stale signal
+	default:
+	}
+	if _, err := m.command("loadfile", url, "replace"); err != nil {
+		return err
+	}
+	select {
+	case <-m.loaded:
+	case <-m.done:
+		return errors.New("mpv exited")
+	case <-time.After(mpvLoadTimeout):
+		return errors.New("mpv: file did not open")
+	}
+	if start > 0 {
+		return m.Seek(start)
+	}
+	return nil
+}
+
+// SetPaused pauses or resumes playback
+func (m *MPV) SetPaused(paused bool) error {
+	_, err := m.command("set_property", "pause", paused)
+	return err
+}
+
+// Seek jumps to an absolute position
+func (m *MPV) Seek(pos time.Duration) error {
+	_, err := m.command("seek", pos.Seconds(), "absolute")
+	return err
+}
+
+// SetSpeed sets the playback speed, 1 being normal
+func (m *MPV) SetSpeed(speed float64) error {
+	_, err := m.command("set_property", "speed", speed)
+	return err
+}
+
+// Position returns the current playback position
+func (m *MPV) Position() (time.Duration, error) {
+	return m.seconds("time-pos")
+}
+
+// Stop unloads the file, leaving mpv open and idle
+func (m *MPV) Stop() error {
+	_, err := m.command("stop")
+	return err
+}
+
+// ShowText puts a message on mpv's on-screen display
+func (m *MPV) ShowText(text string) error {
+	_, err := m.command("show-text", text, 3000)
+	return err
+}
+
+// Pauses reports each change of the pause state, whether the user or a
+// command caused it
+func (m *MPV) Pauses() <-chan bool {
+	return m.pauses
+}
+
+// Done is closed when mpv exits
+func (m *MPV) Done() <-chan struct{} {
+	return m.done
+}
+
+// Status is a snapshot of mpv's playback
+type Status struct {
+	Position time.Duration
+	Duration time.Duration // 0 while unknown (a live stream, still opening)
+	Paused   bool
+}
+
+// Status reads the position, duration and pause state
+func (m *MPV) Status() (Status, error) {
+	var st Status
+	data, err := m.command("get_property", "pause")
+	if err != nil {
+		return st, err
+	}
+	if err := json.Unmarshal(data, &st.Paused); err != nil {
+		return st, err
+	}
+	// Position and duration are unavailable while a file opens
+	st.Position, _ = m.seconds("time-pos")
+	st.Duration, _ = m.seconds("duration")
+	return st, nil
+}
+
+// seconds reads a property holding seconds
+func (m *MPV) seconds(property string) (time.Duration, error) {
+	data, err := m.command("get_property", property)
+	if err != nil {
+		return 0, err
+	}
+	var secs float64
+	if err := json.Unmarshal(data, &secs); err != nil {
+		return 0, err
+	}
+	return time.Duration(secs * float64(time.Second)), nil
+}
+
+// TogglePause pauses or resumes, whichever playback isn't
+func (m *MPV) TogglePause() error {
+	_, err := m.command("cycle", "pause")
+	return err
+}
+
+// SeekBy jumps forward, or back when d is negative
+func (m *MPV) SeekBy(d time.Duration) error {
+	_, err := m.command("seek", d.Seconds(), "relative")
+	return err
+}
+
+// Detach lets go of an mpv without quitting it
+func (m *MPV) Detach() error {
+	return m.conn.Close()
+}
+
+// Close quits mpv
+func (m *MPV) Close() error {
+	m.command("quit")
+	return m.conn.Close()
+}
@@ -0,0 +1,161 @@
+package player
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// ipcFlag makes mpv listen for JSON IPC on a unix socket
+const ipcFlag = "--input-ipc-server="
+
+// ipcDialTimeout is how long mpv gets to create its socket after launch
+const ipcDialTimeout = 10 * time.Second
+
+// ipcSeq keeps socket paths unique within one kino process
+var ipcSeq atomic.Int64
+
+// LaunchWatched is Launch that also reports how playback ended when the
+// player is mpv: ended receives true once the file plays to its end and
+// false when the user quits first (or the socket never comes up). For any
+// other player ended is nil, since there is nothing to observe. mpv also
+// gets the marks: their chapters replace the file's own, and their skip
+// segments are jumped over as playback reaches them.
+func (l *Launcher) LaunchWatched(url string, startOffset time.Duration, marks Marks) (<-chan bool, error) {
+	if !l.usesMPV() {
+		return nil, l.Launch(url, startOffset)
+	}
+	_, ended, err := l.launchWatched(url, startOffset, marks)
+	return ended, err
+}
+
+// launchWatched is LaunchWatched for mpv, also returning the path of its
+// IPC socket
+func (l *Launcher) launchWatched(url string, startOffset time.Duration, marks Marks) (string, <-chan bool, error) {
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("kino-mpv-%d-%d.sock", os.Getpid(), ipcSeq.Add(1)))
+	args := []string{ipcFlag + path}
+	chapters := ""
+	if len(marks.Chapters) > 0 {
+		var err error
+		if chapters, err = writeChaptersFile(marks.Chapters, marks.Duration); err != nil {
+			l.logger.Warn("failed to write chapters file", "error", err)
+		} else {
+			args = append(args, chaptersFlag+chapters)
+		}
+	}
+	removeChapters := func() {
+		if chapters != "" {
+			os.Remove(chapters)
+		}
+	}
+	if err := l.launch(url, startOffset, args, nil); err != nil {
+		removeChapters()
+		return "", nil, err
+	}
+	ended := make(chan bool, 1)
+	go func() {
+		defer os.Remove(path)
+		defer removeChapters()
+		ended <- l.watchIPC(path, marks.Skip)
+	}()
+	return path, ended, nil
+}
+
+// usesMPV reports whether Launch would start a native mpv, the one player
+// with an IPC socket kino understands. Windows-side mpv.exe under WSL
+// speaks named pipes instead, so it is not watched.
+func (l *Launcher) usesMPV() bool {
+	if runtime.GOOS == "windows" {
+		return false
+	}
+	if l.command != "" {
+		return filepath.Base(l.command) == "mpv"
+	}
+	player, found := l.detectPlayer()
+	return found && player.MPV
+}
+
+// watchIPC connects to mpv's socket and blocks until mpv exits, skipping
+// the given segments along the way
+func (l *Launcher) watchIPC(path string, skip []domain.Segment) bool {
+	conn, err := dialIPC(path)
+	if err != nil {
+		l.logger.Debug("mpv IPC socket unavailable", "error", err)
+		return false
+	}
+	defer conn.Close()
+	eof := followPlayback(conn, conn, skip)
+	l.logger.Debug("mpv exited", "playedToEnd", eof)
+	return eof
+}
+
+// playedToEnd reads mpv's event stream until it closes and reports whether
+// the last file ended by reaching its end rather than a quit or error
+func playedToEnd(r io.Reader) bool {
+	return followPlayback(r, io.Discard, nil)
+}
+
+// skipObserveID tags the time-pos observation that drives skipping
+const skipObserveID = 1
+
+// followPlayback is playedToEnd that also skips segments: with any to
+// skip it observes the playback position on w, and seeks past a segment
+// the first time playback enters it. Seeking back into a skipped segment
+// plays it.
+func followPlayback(r io.Reader, w io.Writer, skip []domain.Segment) bool {
+	if len(skip) > 0 {
+		sendIPC(w, "observe_property", skipObserveID, "time-pos")
+	}
+	skipped := make([]bool, len(skip))
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	eof := false
+	for scanner.Scan() {
+		var event struct {
+			Event  string   `json:"event"`
+			Reason string   `json:"reason"`
+			Name   string   `json:"name"`
+			Data   *float64 `json:"data"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &event) != nil {
+			continue
+		}
+		switch event.Event {
+		case "end-file":
+			eof = event.Reason == "eof"
+		case "property-change":
+			if event.Name != "time-pos" || event.Data == nil {
+				continue
+			}
+			pos := time.Duration(*event.Data * float64(time.Second))
+			for i, seg := range skip {
+				if skipped[i] || pos < seg.Start || pos >= seg.End-time.Second {
+					continue
+				}
+				skipped[i] = true
+				sendIPC(w, "set_property", "time-pos", seg.End.Seconds())
+				sendIPC(w, "show-text", "Skipped "+strings.ToLower(seg.Kind.Label()), 2000)
+			}
+		}
+	}
+	return eof
+}
+
+// sendIPC writes one mpv JSON IPC command
+func sendIPC(w io.Writer, args ...any) {
+	data, err := json.Marshal(map[string]any{"command": args})
+	if err != nil {
+		return
+	}
+	w.Write(append(data, '\n'))
+}
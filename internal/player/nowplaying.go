@@ -0,0 +1,110 @@
+package player
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// Session is a watched mpv playback: the item and the IPC socket to
+// control it through. It is saved to the session file (see SetSessionFile)
+// so a restarted kino can attach to an mpv that outlived the last one.
+type Session struct {
+	Socket string           `json:"socket"`
+	Item   domain.MediaItem `json:"item"`
+}
+
+// nowPlaying is the Service's record of the running session
+type nowPlaying struct {
+	mu       sync.Mutex
+	path     string // Session file; empty keeps the session in memory only
+	current  *Session
+	launched bool // current was launched by this kino, not read from the file
+}
+
+// SetSessionFile sets where the running session is saved. Set once at
+// startup.
+func (s *Service) SetSessionFile(path string) {
+	s.nowPlaying.path = path
+}
+
+// Attach connects to the mpv of the running session: the one this kino
+// launched, or one an earlier kino left playing. Without a session, or
+// once its mpv is gone, it returns nil.
+func (s *Service) Attach() (*Session, *MPV) {
+	session, launched := s.nowPlaying.load()
+	if session == nil {
+		return nil, nil
+	}
+	if launched {
+		// Ours, maybe still starting up; if it never comes up, its
+		// afterPlayback ends the session
+		mpv, err := awaitMPV(session.Socket)
+		if err != nil {
+			return nil, nil
+		}
+		return session, mpv
+	}
+	mpv, err := AttachMPV(session.Socket)
+	if err != nil {
+		s.logger.Debug("playback session is gone", "socket", session.Socket, "error", err)
+		s.nowPlaying.clear(session.Socket)
+		return nil, nil
+	}
+	return session, mpv
+}
+
+// set records a newly launched session
+func (n *nowPlaying) set(session Session) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.current, n.launched = &session, true
+	if n.path == "" {
+		return
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(n.path), 0o755); err != nil {
+		return
+	}
+	os.WriteFile(n.path, data, 0o600)
+}
+
+// load returns the running session, reading the file when this kino has
+// launched none, and whether this kino launched it
+func (n *nowPlaying) load() (*Session, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.current != nil || n.path == "" {
+		return n.current, n.launched
+	}
+	data, err := os.ReadFile(n.path)
+	if err != nil {
+		return nil, false
+	}
+	var session Session
+	if json.Unmarshal(data, &session) != nil || session.Socket == "" {
+		return nil, false
+	}
+	n.current = &session
+	return n.current, false
+}
+
+// clear forgets the session on socket once its playback ends. A newer
+// session, launched before the old mpv exited, is kept.
+func (n *nowPlaying) clear(socket string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.current != nil && n.current.Socket != socket {
+		return
+	}
+	n.current, n.launched = nil, false
+	if n.path != "" {
+		os.Remove(n.path)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package player
+
+import (
+	"context"
+	"os/exec"
+)
+
+// shellCommand runs command through the platform's shell
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
@@ -0,0 +1,15 @@
+package player
+
+import (
+	"context"
+	"os/exec"
+	"syscall"
+)
+
+// shellCommand runs command through cmd.exe, passing its command line
+// verbatim (see windowsShellLine)
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "cmd")
+	cmd.SysProcAttr = &syscall.SysProcAttr{CmdLine: windowsShellLine(command)}
+	return cmd
+}
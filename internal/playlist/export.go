@@ -0,0 +1,229 @@
+package playlist
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/library"
+)
+
+// Format is a playlist file format
+type Format string
+
+const (
+	FormatM3U  Format = "m3u"
+	FormatJSON Format = "json"
+)
+
+// FormatForPath picks the format from a file name's extension
+func FormatForPath(name string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".m3u", ".m3u8":
+		return FormatM3U, nil
+	case ".json":
+		return FormatJSON, nil
+	}
+	return "", fmt.Errorf("unknown playlist format %q: use .m3u or .json", filepath.Ext(name))
+}
+
+// Document is a playlist as written to or read from a file
+type Document struct {
+	Title string  `json:"title"`
+	Items []Entry `json:"items"`
+}
+
+// Entry is one item of a playlist file. Imports match on Link, a deep-link
+// reference ("Heat (1995)", "The Wire/S01E02").
+type Entry struct {
+	Link            string `json:"link"`
+	Title           string `json:"title,omitempty"`
+	DurationSeconds int    `json:"duration_seconds,omitempty"`
+	URL             string `json:"url,omitempty"` // Stream URL; carries the server token
+}
+
+// URLResolver returns an item's stream URL
+type URLResolver func(ctx context.Context, itemID string) (string, error)
+
+// Export builds the file document of a playlist. With resolve set, each
+// entry gets its stream URL; an item whose URL can't be resolved is kept
+// without one.
+func (s *Service) Export(ctx context.Context, playlistID string, resolve URLResolver) (*Document, error) {
+	playlists, ok := s.store.GetPlaylists()
+	if !ok {
+		var err error
+		if playlists, err = s.FetchPlaylists(ctx); err != nil {
+			return nil, err
+		}
+	}
+	doc := &Document{Items: []Entry{}}
+	for _, p := range playlists {
+		if p.ID == playlistID {
+			doc.Title = p.Title
+		}
+	}
+
+	items, ok := s.store.GetPlaylistItems(playlistID)
+	if !ok {
+		var err error
+		if items, err = s.FetchPlaylistItems(ctx, playlistID); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range items {
+		entry := Entry{
+			Link:            library.ItemLink(item),
+			Title:           item.Title,
+			DurationSeconds: int(item.Duration.Seconds()),
+		}
+		if resolve != nil {
+			url, err := resolve(ctx, item.ID)
+			if err != nil {
+				s.logger.Warn("playlist export: failed to resolve stream URL", "error", err, "itemID", item.ID)
+			}
+			entry.URL = url
+		}
+		doc.Items = append(doc.Items, entry)
+	}
+	return doc, nil
+}
+
+// Write encodes doc in the given format. M3U entries without a URL are
+// written as their #EXTINF line alone.
+func Write(w io.Writer, doc *Document, format Format) error {
+	if format == FormatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "#EXTM3U")
+	if doc.Title != "" {
+		fmt.Fprintf(bw, "#PLAYLIST:%s\n", doc.Title)
+	}
+	for _, e := range doc.Items {
+		duration := e.DurationSeconds
+		if duration == 0 {
+			duration = -1 // Unknown
+		}
+		fmt.Fprintf(bw, "#EXTINF:%d,%s\n", duration, e.Link)
+		if e.URL != "" {
+			fmt.Fprintln(bw, e.URL)
+		}
+	}
+	return bw.Flush()
+}
+
+// Read decodes a playlist file. M3U entries are named by their #EXTINF
+// title, or by the file name when a location has none.
+func Read(r io.Reader, format Format) (*Document, error) {
+	doc := &Document{}
+	if format == FormatJSON {
+		if err := json.NewDecoder(r).Decode(doc); err != nil {
+			return nil, fmt.Errorf("invalid playlist JSON: %w", err)
+		}
+		return doc, nil
+	}
+
+	var pending *Entry // #EXTINF awaiting its location
+	flush := func() {
+		if pending != nil {
+			doc.Items = append(doc.Items, *pending)
+			pending = nil
+		}
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#PLAYLIST:"):
+			doc.Title = strings.TrimSpace(strings.TrimPrefix(line, "#PLAYLIST:"))
+		case strings.HasPrefix(line, "#EXTINF:"):
+			flush()
+			info := strings.TrimPrefix(line, "#EXTINF:")
+			entry := Entry{}
+			if i := strings.IndexByte(info, ','); i >= 0 {
+				entry.Link = strings.TrimSpace(info[i+1:])
+				info = info[:i]
+			}
+			// The duration may be followed by attributes: #EXTINF:120 tvg-id="x",Title
+			if fields := strings.Fields(info); len(fields) > 0 {
+				if secs, err := strconv.Atoi(fields[0]); err == nil && secs > 0 {
+					entry.DurationSeconds = secs
+				}
+			}
+			pending = &entry
+		case strings.HasPrefix(line, "#"):
+		default:
+			if pending == nil {
+				pending = &Entry{}
+			}
+			pending.URL = line
+			if pending.Link == "" {
+				base := path.Base(strings.ReplaceAll(line, `\`, "/")) // Windows paths too
+				pending.Link = strings.TrimSuffix(base, path.Ext(base))
+			}
+			flush()
+		}
+	}
+	flush()
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// ItemResolver finds the library item a playlist entry's link names;
+// nil means no match
+type ItemResolver func(ctx context.Context, link string) (*domain.MediaItem, error)
+
+// ImportResult reports a playlist import
+type ImportResult struct {
+	Playlist  *domain.Playlist
+	Matched   int
+	Unmatched []string // Links that matched nothing
+}
+
+// Import creates a server playlist named title from doc's entries, in
+// order, matching each through resolve. An entry listed twice is added
+// once.
+func (s *Service) Import(ctx context.Context, doc *Document, title string, resolve ItemResolver) (*ImportResult, error) {
+	result := &ImportResult{}
+	var ids []string
+	seen := make(map[string]bool)
+	for _, e := range doc.Items {
+		item, err := resolve(ctx, e.Link)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			result.Unmatched = append(result.Unmatched, e.Link)
+			continue
+		}
+		result.Matched++
+		if !seen[item.ID] {
+			seen[item.ID] = true
+			ids = append(ids, item.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return result, errors.New("no playlist entries matched the library")
+	}
+
+	playlist, err := s.CreatePlaylist(ctx, title, ids)
+	if err != nil {
+		return nil, err
+	}
+	result.Playlist = playlist
+	return result, nil
+}
@@ -0,0 +1,50 @@
+package playlist
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestM3URoundTrip(t *testing.T) {
+	doc := &Document{
+		Title: "Road Trip",
+		Items: []Entry{
+			{Link: "Heat (1995)", DurationSeconds: 10200, URL: "http://srv/heat.mkv"},
+			{Link: "The Wire/S01E02", URL: "http://srv/wire.mkv"},
+			{Link: "Alien (1979)"}, // No URL: #EXTINF alone
+		},
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, doc, FormatM3U); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	got, err := Read(&buf, FormatM3U)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if !reflect.DeepEqual(got, doc) {
+		t.Errorf("round trip = %+v, want %+v", got, doc)
+	}
+}
+
+func TestReadForeignM3U(t *testing.T) {
+	in := "\ufeff#EXTM3U\n" +
+		"#EXTINF:120 tvg-id=\"x\",Ronin (1998)\n" +
+		"/media/movies/Ronin (1998)/Ronin (1998).mkv\n" +
+		"\n" +
+		"# a comment\n" +
+		"C:\\Movies\\Heat (1995).mp4\n"
+	doc, err := Read(strings.NewReader(in), FormatM3U)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	want := []Entry{
+		{Link: "Ronin (1998)", DurationSeconds: 120, URL: "/media/movies/Ronin (1998)/Ronin (1998).mkv"},
+		{Link: "Heat (1995)", URL: `C:\Movies\Heat (1995).mp4`},
+	}
+	if !reflect.DeepEqual(doc.Items, want) {
+		t.Errorf("items = %+v, want %+v", doc.Items, want)
+	}
+}
@@ -75,6 +75,13 @@ func (s *Service) SyncPlaylists(ctx context.Context) ([]*domain.Playlist, error)
 	return playlists, nil
 }
 
+// CanCreateEmpty reports whether the server accepts a playlist with no
+// items (domain.EmptyPlaylistClient)
+func (s *Service) CanCreateEmpty() bool {
+	ec, ok := s.client.(domain.EmptyPlaylistClient)
+	return ok && ec.CreatesEmptyPlaylists()
+}
+
 func (s *Service) CreatePlaylist(ctx context.Context, title string, itemIDs []string) (*domain.Playlist, error) {
 	playlist, err := s.client.CreatePlaylist(ctx, title, itemIDs)
 	if err != nil {
@@ -108,6 +115,16 @@ func (s *Service) RemoveFromPlaylist(ctx context.Context, playlistID, itemID str
 	return nil
 }
 
+func (s *Service) UpdatePlaylist(ctx context.Context, playlistID, title, description string) error {
+	if err := s.client.UpdatePlaylist(ctx, playlistID, title, description); err != nil {
+		s.logger.Error("failed to update playlist", "error", err, "playlistID", playlistID)
+		return err
+	}
+	s.InvalidatePlaylists()
+	s.logger.Info("updated playlist", "playlistID", playlistID, "title", title)
+	return nil
+}
+
 func (s *Service) DeletePlaylist(ctx context.Context, playlistID string) error {
 	if err := s.client.DeletePlaylist(ctx, playlistID); err != nil {
 		s.logger.Error("failed to delete playlist", "error", err, "playlistID", playlistID)
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"sort"
 	"strings"
 	"unicode"
 )
@@ -340,13 +341,9 @@ func dedupeAndSort(indexes []int) []int {
 
 // sortMatches sorts by score (lower = better), then by title length
 func sortMatches(matches []FuzzyMatch, titles []string) {
-	for i := 1; i < len(matches); i++ {
-		j := i
-		for j > 0 && compareFuzzyMatches(matches[j], matches[j-1], titles) {
-			matches[j], matches[j-1] = matches[j-1], matches[j]
-			j--
-		}
-	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return compareFuzzyMatches(matches[i], matches[j], titles)
+	})
 }
 
 func compareFuzzyMatches(a, b FuzzyMatch, titles []string) bool {
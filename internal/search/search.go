@@ -2,13 +2,14 @@ package search
 
 import (
 	"strings"
+	"time"
 
 	"github.com/mmcdole/kino/internal/domain"
 )
 
 // FilterItem represents a searchable item
 type FilterItem struct {
-	Item      domain.ListItem // *MediaItem or *Show
+	Item      domain.ListItem // *MediaItem (movie or episode) or *Show
 	Title     string
 	Type      domain.MediaType
 	LibraryID string
@@ -21,51 +22,136 @@ type FilterResult struct {
 	Score          int
 }
 
+// Ranking adjusts fuzzy scores (lower = better) by more than how well the
+// title matched. Boosts are subtracted and the penalty added, in fuzzy score
+// points: an exact word scores 0, a word prefix 10, a typo 120.
+type Ranking struct {
+	PrefixBoost     int // Title starts with the whole query
+	RecentBoost     int // Added within RecentDays
+	RecentDays      int
+	InProgressBoost int // Started but not finished
+	WatchedPenalty  int // Fully watched
+}
+
+// DefaultRanking is the ranking used unless configured otherwise
+var DefaultRanking = Ranking{
+	PrefixBoost:     15,
+	RecentBoost:     5,
+	RecentDays:      30,
+	InProgressBoost: 10,
+	WatchedPenalty:  5,
+}
+
 // Service handles fuzzy search across libraries
 type Service struct {
-	store domain.Store
+	store   domain.Store
+	ranking Ranking
 }
 
 // NewService creates a new search service
 func NewService(store domain.Store) *Service {
 	return &Service{
-		store: store,
+		store:   store,
+		ranking: DefaultRanking,
 	}
 }
 
+// SetRanking replaces the ranking weights
+func (s *Service) SetRanking(r Ranking) {
+	s.ranking = r
+}
+
+// adjust returns the ranking adjustment for an item matched by query
+func (r Ranking) adjust(item domain.ListItem, lowerTitle, lowerQuery string, now time.Time) int {
+	adj := 0
+	if strings.HasPrefix(lowerTitle, lowerQuery) {
+		adj -= r.PrefixBoost
+	}
+	if added := item.GetAddedAt(); added > 0 && r.RecentDays > 0 &&
+		now.Sub(time.Unix(added, 0)) <= time.Duration(r.RecentDays)*24*time.Hour {
+		adj -= r.RecentBoost
+	}
+	switch item.GetWatchStatus() {
+	case domain.WatchStatusInProgress:
+		adj -= r.InProgressBoost
+	case domain.WatchStatusWatched:
+		adj += r.WatchedPenalty
+	}
+	return adj
+}
+
 // FilterLocal searches cached data directly
 func (s *Service) FilterLocal(query string, libraries []domain.Library) []FilterResult {
-	if query == "" {
-		return nil
-	}
+	m := s.NewMatcher(query, libraries)
+	results, _ := m.Step(len(m.items))
+	return results
+}
 
-	var items []FilterItem
+// Matcher runs one query over a snapshot of the cached libraries a chunk at
+// a time, so a large index can be matched in the background with partial
+// results shown as they come and a stale query dropped between chunks
+type Matcher struct {
+	query   string // Lowercase, trimmed
+	ranking Ranking
+	now     time.Time
+	tokens  []Token
+	items   []FilterItem
+	titles  []string // Lowercase, parallel to items
+	next    int      // First item not yet matched
+	matches []FuzzyMatch
+}
+
+// NewMatcher snapshots the libraries' cached items for query
+func (s *Service) NewMatcher(query string, libraries []domain.Library) *Matcher {
+	query = strings.ToLower(strings.TrimSpace(query))
+	m := &Matcher{query: query, ranking: s.ranking, now: time.Now(), tokens: tokenize(query)}
+	if len(m.tokens) == 0 {
+		return m
+	}
 	for _, lib := range libraries {
-		items = append(items, s.gatherLibraryItems(lib)...)
+		m.items = append(m.items, s.gatherLibraryItems(lib)...)
 	}
-
-	if len(items) == 0 {
-		return nil
+	m.titles = make([]string, len(m.items))
+	for i, item := range m.items {
+		m.titles[i] = strings.ToLower(item.Title)
 	}
+	return m
+}
 
-	// Build lowercase titles for fuzzy search
-	titles := make([]string, len(items))
-	for i, item := range items {
-		titles[i] = strings.ToLower(item.Title)
+// Step matches up to n more items and returns every result so far, best
+// ranked first, and whether the whole snapshot has been matched
+func (m *Matcher) Step(n int) ([]FilterResult, bool) {
+	end := min(m.next+n, len(m.items))
+	found := false
+	for i := m.next; i < end; i++ {
+		if match, ok := matchTitle(m.titles[i], m.tokens, i); ok {
+			match.Score += m.ranking.adjust(m.items[i].Item, m.titles[i], m.query, m.now)
+			m.matches = append(m.matches, match)
+			found = true
+		}
+	}
+	m.next = end
+	if found {
+		sortMatches(m.matches, m.titles)
 	}
 
-	matches := FuzzySearch(query, titles)
-
-	results := make([]FilterResult, len(matches))
-	for i, match := range matches {
+	if len(m.matches) == 0 {
+		return nil, m.Done()
+	}
+	results := make([]FilterResult, len(m.matches))
+	for i, match := range m.matches {
 		results[i] = FilterResult{
-			FilterItem:     items[match.Index],
+			FilterItem:     m.items[match.Index],
 			MatchedIndexes: match.MatchedIndexes,
 			Score:          match.Score,
 		}
 	}
+	return results, m.Done()
+}
 
-	return results
+// Done reports whether every item has been matched
+func (m *Matcher) Done() bool {
+	return m.next >= len(m.items)
 }
 
 func (s *Service) gatherLibraryItems(lib domain.Library) []FilterItem {
@@ -116,6 +202,34 @@ func (s *Service) gatherLibraryItems(lib domain.Library) []FilterItem {
 			}
 		}
 	}
+	if lib.Type != "movie" {
+		items = append(items, s.gatherEpisodes(lib)...)
+	}
 
 	return items
 }
+
+// gatherEpisodes returns a library's indexed episodes, when deep indexing
+// keeps an index (see library.Service.SetDeepIndex). Their title is the
+// one the omnibar shows, "Show - S01E02 Title", so an episode code matches.
+func (s *Service) gatherEpisodes(lib domain.Library) []FilterItem {
+	episodes, ok := s.store.GetStaleLibraryEpisodes(lib.ID)
+	if !ok {
+		return nil
+	}
+	items := make([]FilterItem, 0, len(episodes))
+	for _, ep := range episodes {
+		items = append(items, EpisodeItem(ep, lib.ID))
+	}
+	return items
+}
+
+// EpisodeItem is how search lists an episode: titled "Show - S01E02 Title"
+func EpisodeItem(ep *domain.MediaItem, libID string) FilterItem {
+	return FilterItem{
+		Item:      ep,
+		Title:     ep.ShowTitle + " - " + ep.EpisodeCode() + " " + ep.Title,
+		Type:      domain.MediaTypeEpisode,
+		LibraryID: libID,
+	}
+}
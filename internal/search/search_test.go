@@ -0,0 +1,106 @@
+package search
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/store"
+)
+
+func TestMatcherChunksMatchFilterLocal(t *testing.T) {
+	cache, _ := store.NewLibraryStore("", "", "")
+	var movies []*domain.MediaItem
+	for i := 0; i < 250; i++ {
+		movies = append(movies, &domain.MediaItem{ID: fmt.Sprint(i), Title: fmt.Sprintf("Star Film %d", i), Type: domain.MediaTypeMovie})
+	}
+	movies = append(movies, &domain.MediaItem{ID: "wars", Title: "Star Wars", Type: domain.MediaTypeMovie})
+	cache.SaveMovies("m", movies, 0)
+	libs := []domain.Library{{ID: "m", Type: "movie"}}
+	svc := NewService(cache)
+
+	want := svc.FilterLocal("star", libs)
+	if len(want) != len(movies) || want[0].Item.GetID() != "wars" {
+		t.Fatalf("FilterLocal: %d results, first %v", len(want), want[0].Item.GetID())
+	}
+
+	m := svc.NewMatcher("star", libs)
+	var got []FilterResult
+	steps := 0
+	for done := false; !done; steps++ {
+		got, done = m.Step(40)
+	}
+	if steps != 7 {
+		t.Errorf("took %d steps of 40 for %d items, want 7", steps, len(movies))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Error("chunked results differ from FilterLocal")
+	}
+
+	if results, done := svc.NewMatcher("  ", libs).Step(40); results != nil || !done {
+		t.Errorf("blank query = %d results, done %v", len(results), done)
+	}
+}
+
+func TestRankingBoostsAndPenalties(t *testing.T) {
+	cache, _ := store.NewLibraryStore("", "", "")
+	now := time.Now().Unix()
+	cache.SaveMovies("m", []*domain.MediaItem{
+		{ID: "watched", Title: "Alien", IsPlayed: true, Type: domain.MediaTypeMovie},
+		{ID: "plain", Title: "Aliens", Type: domain.MediaTypeMovie},
+		{ID: "started", Title: "Alien Nation", ViewOffset: time.Minute, Duration: time.Hour, Type: domain.MediaTypeMovie},
+		{ID: "new", Title: "Alien Romulus", AddedAt: now, Type: domain.MediaTypeMovie},
+		{ID: "inside", Title: "The Alien", Type: domain.MediaTypeMovie},
+	}, 0)
+	libs := []domain.Library{{ID: "m", Type: "movie"}}
+	svc := NewService(cache)
+
+	order := func() string {
+		var ids []string
+		for _, r := range svc.FilterLocal("alien", libs) {
+			ids = append(ids, r.Item.GetID())
+		}
+		return strings.Join(ids, ",")
+	}
+
+	// Alien Nation 5-15-10, Alien Romulus 5-15-5, Alien 0-15+5, Aliens 10-15,
+	// The Alien 5 (not a prefix)
+	if got, want := order(), "started,new,watched,plain,inside"; got != want {
+		t.Errorf("ranked = %s, want %s", got, want)
+	}
+
+	svc.SetRanking(Ranking{})
+	// Fuzzy score alone; ties go to the shorter title
+	if got, want := order(), "watched,inside,started,new,plain"; got != want {
+		t.Errorf("unweighted = %s, want %s", got, want)
+	}
+}
+
+func TestEpisodeIndexIsSearchable(t *testing.T) {
+	cache, _ := store.NewLibraryStore("", "", "")
+	cache.SaveShows("tv", []*domain.Show{{ID: "bb", Title: "Breaking Bad"}}, 0)
+	libs := []domain.Library{{ID: "tv", Type: "show"}}
+	svc := NewService(cache)
+
+	if got := svc.FilterLocal("breaking bad s02e08", libs); len(got) != 0 {
+		t.Fatalf("matched %d items without an episode index", len(got))
+	}
+
+	cache.SaveLibraryEpisodes("tv", []*domain.MediaItem{
+		{ID: "e7", Title: "Negro y Azul", ShowTitle: "Breaking Bad", SeasonNum: 2, EpisodeNum: 7, Type: domain.MediaTypeEpisode},
+		{ID: "e8", Title: "Better Call Saul", ShowTitle: "Breaking Bad", SeasonNum: 2, EpisodeNum: 8, Type: domain.MediaTypeEpisode},
+	})
+	got := svc.FilterLocal("breaking bad s02e08", libs)
+	if len(got) == 0 || got[0].Item.GetID() != "e8" || got[0].Type != domain.MediaTypeEpisode {
+		t.Fatalf("results = %+v, want episode e8 first", got)
+	}
+	if got[0].Title != "Breaking Bad - S02E08 Better Call Saul" {
+		t.Errorf("title = %q", got[0].Title)
+	}
+	if got := svc.FilterLocal("better call", libs); len(got) != 1 || got[0].Item.GetID() != "e8" {
+		t.Errorf("episode title search = %+v", got)
+	}
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// checkpointTTL bounds how old a sync checkpoint may be to resume from. The
+// library timestamp alone can't tell (Jellyfin's never moves), and items
+// fetched long ago carry stale watch state.
+const checkpointTTL = 24 * time.Hour
+
+// checkpointMeta is a checkpoint without its items, which are stored as an
+// item list like library content
+type checkpointMeta struct {
+	ServerTS int64 `json:"serverTS"`
+	Offset   int   `json:"offset"`
+	Skipped  int   `json:"skipped"`
+	Total    int   `json:"total,omitempty"`
+	SavedAt  int64 `json:"savedAt"`
+}
+
+func checkpointKey(libID string) string {
+	return "lib:" + libID + ":checkpoint"
+}
+
+func (s *LibraryStore) GetSyncCheckpoint(libID string, serverTS int64) (domain.SyncCheckpoint, bool) {
+	key := checkpointKey(libID)
+	var meta checkpointMeta
+	if !s.get(bucketContent, key+":meta", &meta) {
+		return domain.SyncCheckpoint{}, false
+	}
+	if meta.ServerTS != serverTS || time.Now().Unix()-meta.SavedAt > int64(checkpointTTL.Seconds()) {
+		return domain.SyncCheckpoint{}, false
+	}
+	var wrappers []listItemWrapper
+	if !s.getContent(key, &wrappers) {
+		return domain.SyncCheckpoint{}, false
+	}
+	return domain.SyncCheckpoint{
+		ServerTS: meta.ServerTS,
+		Offset:   meta.Offset,
+		Skipped:  meta.Skipped,
+		Total:    meta.Total,
+		Items:    unwrapListItems(wrappers),
+	}, true
+}
+
+// SaveSyncCheckpoint writes a checkpoint over the library's last one. As
+// with library content only new items are written, so checkpointing a
+// growing sync doesn't rewrite what it already saved.
+func (s *LibraryStore) SaveSyncCheckpoint(libID string, cp domain.SyncCheckpoint) error {
+	entries, err := itemEntries(cp.Items, func(item domain.ListItem) any {
+		return wrapListItems([]domain.ListItem{item})[0]
+	})
+	if err != nil {
+		return err
+	}
+	key := checkpointKey(libID)
+	return s.setContentPair(key, entries, key+":meta", checkpointMeta{
+		ServerTS: cp.ServerTS,
+		Offset:   cp.Offset,
+		Skipped:  cp.Skipped,
+		Total:    cp.Total,
+		SavedAt:  time.Now().Unix(),
+	})
+}
+
+func (s *LibraryStore) ClearSyncCheckpoint(libID string) {
+	key := checkpointKey(libID)
+	// The prefix covers the meta key and the list's memory cache entry
+	s.deletePrefix(bucketContent, key)
+	s.deleteItemLists(key)
+}
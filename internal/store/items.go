@@ -0,0 +1,395 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mmcdole/kino/internal/domain"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Library content (movies, shows, mixed) is stored one entry per item rather
+// than one blob per library: bucketItems holds a nested bucket per list
+// ("lib:{libID}:movies" etc.) keyed by item ID, plus the list's order. A
+// sync rewrites only the items that changed, a watch-state patch touches a
+// single entry, and a page of a huge library can be read without decoding
+// the rest. Full reads assemble the stored entries into a JSON array without
+// decoding them, so the memory cache still holds one blob per list.
+var bucketItems = []byte("items")
+
+// orderKey holds a list's item IDs in server order. The NUL prefix keeps it
+// clear of any real item ID.
+var orderKey = []byte("\x00order")
+
+// contentKinds are the list suffixes a library's content may be stored under
+var contentKinds = []string{"movies", "shows", "mixed"}
+
+// itemEntry is one encoded list item and the key it is stored under
+type itemEntry struct {
+	id   string
+	data []byte
+}
+
+// itemEntries encodes a list for storage. value maps an item to what gets
+// serialized (mixed content stores its type wrapper).
+func itemEntries[T domain.ListItem](items []T, value func(T) any) ([]itemEntry, error) {
+	entries := make([]itemEntry, len(items))
+	for i, item := range items {
+		data, err := json.Marshal(value(item))
+		if err != nil {
+			return nil, err
+		}
+		id := item.GetID()
+		if id == "" {
+			// bbolt rejects empty keys; an ID-less item keeps its position
+			id = fmt.Sprintf("\x00%d", i)
+		}
+		entries[i] = itemEntry{id: id, data: data}
+	}
+	return entries, nil
+}
+
+// joinEntries assembles encoded items into a JSON array
+func joinEntries(entries [][]byte) []byte {
+	return append(append([]byte("["), bytes.Join(entries, []byte(","))...), ']')
+}
+
+// setContentPair writes a list and its freshness timestamp (or other
+// stamp) in a single transaction, so readers can never observe new data
+// with an old timestamp or vice versa. Only entries whose encoding changed
+// are rewritten; items gone from the list are deleted.
+func (s *LibraryStore) setContentPair(dataKey string, entries []itemEntry, tsKey string, ts any) error {
+	tsData, err := json.Marshal(ts)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, len(entries))
+	encoded := make([][]byte, len(entries))
+	for i, e := range entries {
+		ids[i] = e.id
+		encoded[i] = e.data
+	}
+	order, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+
+	if s.db != nil {
+		if err := s.db.Update(func(tx *bolt.Tx) error {
+			list, err := tx.Bucket(bucketItems).CreateBucketIfNotExists([]byte(dataKey))
+			if err != nil {
+				return err
+			}
+			keep := make(map[string]bool, len(entries))
+			for _, e := range entries {
+				keep[e.id] = true
+				if !bytes.Equal(list.Get([]byte(e.id)), e.data) {
+					if err := list.Put([]byte(e.id), e.data); err != nil {
+						return err
+					}
+				}
+			}
+			var stale [][]byte
+			list.ForEach(func(k, _ []byte) error {
+				if !bytes.Equal(k, orderKey) && !keep[string(k)] {
+					stale = append(stale, append([]byte(nil), k...))
+				}
+				return nil
+			})
+			for _, k := range stale {
+				if err := list.Delete(k); err != nil {
+					return err
+				}
+			}
+			if err := list.Put(orderKey, order); err != nil {
+				return err
+			}
+			return tx.Bucket(bucketContent).Put([]byte(tsKey), tsData)
+		}); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	s.cache[string(bucketContent)+":"+dataKey] = joinEntries(encoded)
+	s.cache[string(bucketContent)+":"+tsKey] = tsData
+	s.mu.Unlock()
+	return nil
+}
+
+// getContent reads a whole list, assembling it from its item entries on a
+// memory cache miss
+func (s *LibraryStore) getContent(dataKey string, dest interface{}) bool {
+	return s.cached(string(bucketContent)+":"+dataKey, dest, func(tx *bolt.Tx) []byte {
+		list := tx.Bucket(bucketItems).Bucket([]byte(dataKey))
+		if list == nil {
+			return nil
+		}
+		ids, ok := listOrder(list)
+		if !ok {
+			return nil
+		}
+		entries := make([][]byte, 0, len(ids))
+		for _, id := range ids {
+			if v := list.Get([]byte(id)); v != nil {
+				entries = append(entries, v)
+			}
+		}
+		// joinEntries copies, so the result outlives the transaction
+		return joinEntries(entries)
+	})
+}
+
+// listOrder returns a stored list's item IDs in order
+func listOrder(list *bolt.Bucket) ([]string, bool) {
+	data := list.Get(orderKey)
+	if data == nil {
+		return nil, false
+	}
+	var ids []string
+	if json.Unmarshal(data, &ids) != nil {
+		return nil, false
+	}
+	return ids, true
+}
+
+// GetContentPage returns items [offset, offset+limit) of a library's content
+// and the total item count, decoding only that page. limit <= 0 reads to the
+// end.
+func (s *LibraryStore) GetContentPage(libID string, offset, limit int) ([]domain.ListItem, int, bool) {
+	for _, kind := range contentKinds {
+		raw, total, ok := s.readPage("lib:"+libID+":"+kind, offset, limit)
+		if !ok {
+			continue
+		}
+		items := make([]domain.ListItem, 0, len(raw))
+		for _, data := range raw {
+			if item := decodeContentItem(kind, data); item != nil {
+				items = append(items, item)
+			}
+		}
+		return items, total, true
+	}
+	return nil, 0, false
+}
+
+// readPage returns the encoded entries of one page of a list. A list already
+// in the memory cache is sliced from there; otherwise only the page's
+// entries are read from disk, and nothing is promoted.
+func (s *LibraryStore) readPage(dataKey string, offset, limit int) ([][]byte, int, bool) {
+	s.mu.RLock()
+	data, ok := s.cache[string(bucketContent)+":"+dataKey]
+	s.mu.RUnlock()
+	if ok {
+		var all []json.RawMessage
+		if json.Unmarshal(data, &all) != nil {
+			return nil, 0, false
+		}
+		lo, hi := pageBounds(len(all), offset, limit)
+		page := make([][]byte, 0, hi-lo)
+		for _, v := range all[lo:hi] {
+			page = append(page, v)
+		}
+		return page, len(all), true
+	}
+
+	if s.db == nil {
+		return nil, 0, false
+	}
+	var page [][]byte
+	var total int
+	var found bool
+	s.db.View(func(tx *bolt.Tx) error {
+		list := tx.Bucket(bucketItems).Bucket([]byte(dataKey))
+		if list == nil {
+			return nil
+		}
+		ids, ok := listOrder(list)
+		if !ok {
+			return nil
+		}
+		found = true
+		total = len(ids)
+		lo, hi := pageBounds(total, offset, limit)
+		for _, id := range ids[lo:hi] {
+			if v := list.Get([]byte(id)); v != nil {
+				page = append(page, append([]byte(nil), v...))
+			}
+		}
+		return nil
+	})
+	return page, total, found
+}
+
+// pageBounds clamps a page request to a list of n items
+func pageBounds(n, offset, limit int) (int, int) {
+	lo := min(max(offset, 0), n)
+	hi := n
+	if limit > 0 && lo+limit < n {
+		hi = lo + limit
+	}
+	return lo, hi
+}
+
+// decodeContentItem decodes one stored entry of a list of the given kind
+func decodeContentItem(kind string, data []byte) domain.ListItem {
+	switch kind {
+	case "movies":
+		var m domain.MediaItem
+		if json.Unmarshal(data, &m) == nil {
+			return &m
+		}
+	case "shows":
+		var show domain.Show
+		if json.Unmarshal(data, &show) == nil {
+			return &show
+		}
+	case "mixed":
+		var w listItemWrapper
+		if json.Unmarshal(data, &w) == nil {
+			if items := unwrapListItems([]listItemWrapper{w}); len(items) == 1 {
+				return items[0]
+			}
+		}
+	}
+	return nil
+}
+
+// updateItem applies transform to the entry for itemID in every library
+// list whose key ends in suffix; a non-nil result is written back. On disk
+// that is one lookup per list. Lists in the memory cache are patched in
+// place so they stay warm.
+func (s *LibraryStore) updateItem(suffix, itemID string, transform func(data []byte) []byte) {
+	patchBlob := func(key string, data []byte) []byte {
+		return patchElements(data, transform)
+	}
+	if s.db == nil {
+		// Memory-only mode: lists exist only as cached blobs
+		s.updateEach(bucketContent, keySuffix(suffix), patchBlob)
+		return
+	}
+
+	var changed []string
+	s.db.Update(func(tx *bolt.Tx) error {
+		items := tx.Bucket(bucketItems)
+		var names []string
+		items.ForEach(func(k, v []byte) error {
+			if v == nil && strings.HasSuffix(string(k), suffix) {
+				names = append(names, string(k))
+			}
+			return nil
+		})
+		for _, name := range names {
+			list := items.Bucket([]byte(name))
+			data := list.Get([]byte(itemID))
+			if data == nil {
+				continue
+			}
+			out := transform(data)
+			if out == nil {
+				continue
+			}
+			if err := list.Put([]byte(itemID), out); err != nil {
+				return err
+			}
+			changed = append(changed, name)
+		}
+		return nil
+	})
+
+	for _, name := range changed {
+		cacheKey := string(bucketContent) + ":" + name
+		s.mu.RLock()
+		data, ok := s.cache[cacheKey]
+		genBefore := s.gen
+		s.mu.RUnlock()
+		if !ok {
+			continue
+		}
+		out := patchBlob(name, data)
+		s.mu.Lock()
+		if out != nil && s.gen == genBefore {
+			s.cache[cacheKey] = out
+		}
+		s.mu.Unlock()
+	}
+}
+
+// patchElements applies transform to each element of a JSON array,
+// returning the re-encoded array or nil when nothing changed
+func patchElements(data []byte, transform func(data []byte) []byte) []byte {
+	var elements []json.RawMessage
+	if json.Unmarshal(data, &elements) != nil {
+		return nil
+	}
+	changed := false
+	for i, el := range elements {
+		if out := transform(el); out != nil {
+			elements[i] = out
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	out, err := json.Marshal(elements)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// deleteItemLists drops every stored list whose key starts with prefix
+func (s *LibraryStore) deleteItemLists(prefix string) {
+	if s.db == nil {
+		return
+	}
+	s.db.Update(func(tx *bolt.Tx) error {
+		items := tx.Bucket(bucketItems)
+		var names [][]byte
+		items.ForEach(func(k, v []byte) error {
+			if v == nil && strings.HasPrefix(string(k), prefix) {
+				names = append(names, append([]byte(nil), k...))
+			}
+			return nil
+		})
+		for _, name := range names {
+			if err := items.DeleteBucket(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// dropLegacyContent removes library content written as one blob per list
+// by earlier versions, along with its timestamps, so those libraries read
+// as stale and resync into the per-item layout
+func dropLegacyContent(tx *bolt.Tx) error {
+	b := tx.Bucket(bucketContent)
+	legacy := false
+	b.ForEach(func(k, _ []byte) error {
+		for _, kind := range contentKinds {
+			if strings.HasSuffix(string(k), ":"+kind) {
+				legacy = true
+			}
+		}
+		return nil
+	})
+	if !legacy {
+		return nil
+	}
+	var keys [][]byte
+	b.ForEach(func(k, _ []byte) error {
+		keys = append(keys, append([]byte(nil), k...))
+		return nil
+	})
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/metrics"
 	bolt "go.etcd.io/bbolt"
 )
 
@@ -24,6 +25,9 @@ var (
 	bucketPlaylists = []byte("playlists")
 )
 
+// allBuckets lists every top-level bucket
+var allBuckets = [][]byte{bucketLibraries, bucketContent, bucketItems, bucketSeasons, bucketEpisodes, bucketPlaylists}
+
 // listItemWrapper wraps ListItem for JSON serialization
 type listItemWrapper struct {
 	Type     string            `json:"type"`
@@ -75,12 +79,12 @@ func NewLibraryStore(baseCacheDir, serverURL, userID string) (*LibraryStore, err
 
 	// Create buckets
 	err = db.Update(func(tx *bolt.Tx) error {
-		for _, bucket := range [][]byte{bucketLibraries, bucketContent, bucketSeasons, bucketEpisodes, bucketPlaylists} {
+		for _, bucket := range allBuckets {
 			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
 				return err
 			}
 		}
-		return nil
+		return dropLegacyContent(tx)
 	})
 	if err != nil {
 		db.Close()
@@ -120,38 +124,50 @@ func (s *LibraryStore) Close() error {
 // === Generic helpers ===
 
 func (s *LibraryStore) get(bucket []byte, key string, dest interface{}) bool {
-	cacheKey := string(bucket) + ":" + key
+	return s.cached(string(bucket)+":"+key, dest, func(tx *bolt.Tx) []byte {
+		b := tx.Bucket(bucket)
+		if b == nil {
+			return nil
+		}
+		if v := b.Get([]byte(key)); v != nil {
+			data := make([]byte, len(v))
+			copy(data, v)
+			return data
+		}
+		return nil
+	})
+}
 
+// cached serves cacheKey from the memory cache, falling back to load (which
+// must return data that outlives the transaction) and promoting the result
+func (s *LibraryStore) cached(cacheKey string, dest interface{}, load func(tx *bolt.Tx) []byte) bool {
 	// Check memory cache first
 	s.mu.RLock()
 	if data, ok := s.cache[cacheKey]; ok {
 		s.mu.RUnlock()
+		metrics.CacheMemoryHits.Inc()
 		return json.Unmarshal(data, dest) == nil
 	}
 	genBefore := s.gen
 	s.mu.RUnlock()
 
 	if s.db == nil {
+		metrics.CacheMisses.Inc()
 		return false
 	}
 
 	// Read from BoltDB
 	var data []byte
 	s.db.View(func(tx *bolt.Tx) error {
-		b := tx.Bucket(bucket)
-		if b == nil {
-			return nil
-		}
-		if v := b.Get([]byte(key)); v != nil {
-			data = make([]byte, len(v))
-			copy(data, v)
-		}
+		data = load(tx)
 		return nil
 	})
 
 	if data == nil {
+		metrics.CacheMisses.Inc()
 		return false
 	}
+	metrics.CacheDiskHits.Inc()
 
 	// Promote to memory cache — unless an invalidation ran while we were
 	// reading, in which case this data may already be deleted
@@ -186,38 +202,6 @@ func (s *LibraryStore) set(bucket []byte, key string, value interface{}) error {
 	return nil
 }
 
-// setContentPair writes a content payload and its freshness timestamp in a
-// single transaction, so readers can never observe new data with an old
-// timestamp or vice versa.
-func (s *LibraryStore) setContentPair(dataKey string, value interface{}, tsKey string, serverTS int64) error {
-	data, err := json.Marshal(value)
-	if err != nil {
-		return err
-	}
-	tsData, err := json.Marshal(serverTS)
-	if err != nil {
-		return err
-	}
-
-	if s.db != nil {
-		if err := s.db.Update(func(tx *bolt.Tx) error {
-			b := tx.Bucket(bucketContent)
-			if err := b.Put([]byte(dataKey), data); err != nil {
-				return err
-			}
-			return b.Put([]byte(tsKey), tsData)
-		}); err != nil {
-			return err
-		}
-	}
-
-	s.mu.Lock()
-	s.cache[string(bucketContent)+":"+dataKey] = data
-	s.cache[string(bucketContent)+":"+tsKey] = tsData
-	s.mu.Unlock()
-	return nil
-}
-
 func (s *LibraryStore) delete(bucket []byte, key string) {
 	cacheKey := string(bucket) + ":" + key
 
@@ -290,86 +274,128 @@ func (s *LibraryStore) SaveLibraries(libs []domain.Library) error {
 
 func (s *LibraryStore) GetMovies(libID string) ([]*domain.MediaItem, bool) {
 	var movies []*domain.MediaItem
-	ok := s.get(bucketContent, "lib:"+libID+":movies", &movies)
+	ok := s.getContent("lib:"+libID+":movies", &movies)
 	return movies, ok
 }
 
 func (s *LibraryStore) SaveMovies(libID string, movies []*domain.MediaItem, serverTS int64) error {
-	return s.setContentPair("lib:"+libID+":movies", movies, "lib:"+libID+":ts", serverTS)
+	entries, err := itemEntries(movies, func(m *domain.MediaItem) any { return m })
+	if err != nil {
+		return err
+	}
+	return s.setContentPair("lib:"+libID+":movies", entries, "lib:"+libID+":ts", serverTS)
 }
 
 // === Shows ===
 
 func (s *LibraryStore) GetShows(libID string) ([]*domain.Show, bool) {
 	var shows []*domain.Show
-	ok := s.get(bucketContent, "lib:"+libID+":shows", &shows)
+	ok := s.getContent("lib:"+libID+":shows", &shows)
 	return shows, ok
 }
 
 func (s *LibraryStore) SaveShows(libID string, shows []*domain.Show, serverTS int64) error {
-	return s.setContentPair("lib:"+libID+":shows", shows, "lib:"+libID+":ts", serverTS)
+	entries, err := itemEntries(shows, func(show *domain.Show) any { return show })
+	if err != nil {
+		return err
+	}
+	return s.setContentPair("lib:"+libID+":shows", entries, "lib:"+libID+":ts", serverTS)
 }
 
 // === Mixed Content ===
 
 func (s *LibraryStore) GetMixedContent(libID string) ([]domain.ListItem, bool) {
 	var wrappers []listItemWrapper
-	if !s.get(bucketContent, "lib:"+libID+":mixed", &wrappers) {
+	if !s.getContent("lib:"+libID+":mixed", &wrappers) {
 		return nil, false
 	}
 	return unwrapListItems(wrappers), true
 }
 
 func (s *LibraryStore) SaveMixedContent(libID string, items []domain.ListItem, serverTS int64) error {
-	return s.setContentPair("lib:"+libID+":mixed", wrapListItems(items), "lib:"+libID+":ts", serverTS)
+	entries, err := itemEntries(items, func(item domain.ListItem) any {
+		return wrapListItems([]domain.ListItem{item})[0]
+	})
+	if err != nil {
+		return err
+	}
+	return s.setContentPair("lib:"+libID+":mixed", entries, "lib:"+libID+":ts", serverTS)
 }
 
 // === Seasons (hierarchical key: lib:{libID}:show:{showID}) ===
 
-// tvCacheTTL bounds staleness of the TV hierarchy caches. Unlike libraries
-// (which have a server timestamp plus an item-count check), seasons and
-// episodes expose no server-side freshness signal at all, so without a TTL
-// they would be served stale forever — new episodes never appearing until a
-// manual refresh.
+// tvCacheTTL bounds staleness of the TV hierarchy caches. Seasons and
+// episodes expose no freshness signal of their own, and the parent
+// library's timestamp is not reliably bumped when episodes are added
+// (Jellyfin's never moves), so without a TTL they could be served stale
+// forever — new episodes never appearing until a manual refresh.
 const tvCacheTTL = 6 * time.Hour
 
-// timestamped wraps hierarchical cache payloads with their fetch time.
-// Pre-TTL cache entries fail to decode into this wrapper and simply read as
-// cache misses.
+// timestamped wraps hierarchical cache payloads with their fetch time and
+// the parent library's server timestamp at that moment. Pre-TTL cache
+// entries fail to decode into this wrapper and simply read as cache misses.
 type timestamped struct {
 	FetchedAt int64           `json:"fetched_at"`
+	LibraryTS int64           `json:"library_ts,omitempty"`
 	Data      json.RawMessage `json:"data"`
 }
 
-func (s *LibraryStore) getWithTTL(bucket []byte, key string, dest interface{}) bool {
+// getTV reads a season or episode list. It is fresh while within the TTL
+// and the parent library hasn't changed on the server since it was fetched;
+// anyAge serves it regardless, for browsing while the server is offline.
+func (s *LibraryStore) getTV(bucket []byte, libID, key string, dest interface{}, anyAge bool) bool {
 	var wrapper timestamped
 	if !s.get(bucket, key, &wrapper) {
 		return false
 	}
-	if time.Now().Unix()-wrapper.FetchedAt > int64(tvCacheTTL.Seconds()) {
-		return false
+	if !anyAge {
+		if time.Now().Unix()-wrapper.FetchedAt > int64(tvCacheTTL.Seconds()) {
+			return false
+		}
+		if s.libraryTS(libID) > wrapper.LibraryTS {
+			return false
+		}
 	}
 	return json.Unmarshal(wrapper.Data, dest) == nil
 }
 
-func (s *LibraryStore) setWithTTL(bucket []byte, key string, value interface{}) error {
+func (s *LibraryStore) setTV(bucket []byte, libID, key string, value interface{}) error {
 	data, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
-	return s.set(bucket, key, timestamped{FetchedAt: time.Now().Unix(), Data: data})
+	return s.set(bucket, key, timestamped{
+		FetchedAt: time.Now().Unix(),
+		LibraryTS: s.libraryTS(libID),
+		Data:      data,
+	})
+}
+
+// libraryTS is a library's cached server timestamp, 0 if never synced
+func (s *LibraryStore) libraryTS(libID string) int64 {
+	var ts int64
+	s.get(bucketContent, "lib:"+libID+":ts", &ts)
+	return ts
 }
 
 func (s *LibraryStore) GetSeasons(libID, showID string) ([]*domain.Season, bool) {
 	var seasons []*domain.Season
 	key := fmt.Sprintf("lib:%s:show:%s", libID, showID)
-	ok := s.getWithTTL(bucketSeasons, key, &seasons)
+	ok := s.getTV(bucketSeasons, libID, key, &seasons, false)
+	return seasons, ok
+}
+
+// GetStaleSeasons returns cached seasons however old they are
+func (s *LibraryStore) GetStaleSeasons(libID, showID string) ([]*domain.Season, bool) {
+	var seasons []*domain.Season
+	key := fmt.Sprintf("lib:%s:show:%s", libID, showID)
+	ok := s.getTV(bucketSeasons, libID, key, &seasons, true)
 	return seasons, ok
 }
 
 func (s *LibraryStore) SaveSeasons(libID, showID string, seasons []*domain.Season) error {
 	key := fmt.Sprintf("lib:%s:show:%s", libID, showID)
-	return s.setWithTTL(bucketSeasons, key, seasons)
+	return s.setTV(bucketSeasons, libID, key, seasons)
 }
 
 // === Episodes (hierarchical key: lib:{libID}:show:{showID}:season:{seasonID}) ===
@@ -377,13 +403,43 @@ func (s *LibraryStore) SaveSeasons(libID, showID string, seasons []*domain.Seaso
 func (s *LibraryStore) GetEpisodes(libID, showID, seasonID string) ([]*domain.MediaItem, bool) {
 	var episodes []*domain.MediaItem
 	key := fmt.Sprintf("lib:%s:show:%s:season:%s", libID, showID, seasonID)
-	ok := s.getWithTTL(bucketEpisodes, key, &episodes)
+	ok := s.getTV(bucketEpisodes, libID, key, &episodes, false)
+	return episodes, ok
+}
+
+// GetStaleEpisodes returns cached episodes however old they are
+func (s *LibraryStore) GetStaleEpisodes(libID, showID, seasonID string) ([]*domain.MediaItem, bool) {
+	var episodes []*domain.MediaItem
+	key := fmt.Sprintf("lib:%s:show:%s:season:%s", libID, showID, seasonID)
+	ok := s.getTV(bucketEpisodes, libID, key, &episodes, true)
 	return episodes, ok
 }
 
 func (s *LibraryStore) SaveEpisodes(libID, showID, seasonID string, episodes []*domain.MediaItem) error {
 	key := fmt.Sprintf("lib:%s:show:%s:season:%s", libID, showID, seasonID)
-	return s.setWithTTL(bucketEpisodes, key, episodes)
+	return s.setTV(bucketEpisodes, libID, key, episodes)
+}
+
+// === Episode index (key: lib:{libID}:episodes) ===
+
+// The index lives in the episodes bucket so watch state and rating patches
+// and library invalidation reach it like any episode list
+
+func (s *LibraryStore) GetLibraryEpisodes(libID string) ([]*domain.MediaItem, bool) {
+	var episodes []*domain.MediaItem
+	ok := s.getTV(bucketEpisodes, libID, "lib:"+libID+":episodes", &episodes, false)
+	return episodes, ok
+}
+
+// GetStaleLibraryEpisodes returns the episode index however old it is
+func (s *LibraryStore) GetStaleLibraryEpisodes(libID string) ([]*domain.MediaItem, bool) {
+	var episodes []*domain.MediaItem
+	ok := s.getTV(bucketEpisodes, libID, "lib:"+libID+":episodes", &episodes, true)
+	return episodes, ok
+}
+
+func (s *LibraryStore) SaveLibraryEpisodes(libID string, episodes []*domain.MediaItem) error {
+	return s.setTV(bucketEpisodes, libID, "lib:"+libID+":episodes", episodes)
 }
 
 // === Validation ===
@@ -396,6 +452,25 @@ func (s *LibraryStore) IsValid(libID string, serverTS int64) bool {
 	return storedTS >= serverTS
 }
 
+func (s *LibraryStore) GetLibraryValidator(libID string) (domain.Validator, bool) {
+	var v domain.Validator
+	ok := s.get(bucketContent, "lib:"+libID+":validator", &v)
+	return v, ok && !v.IsZero()
+}
+
+func (s *LibraryStore) SaveLibraryValidator(libID string, v domain.Validator) error {
+	return s.set(bucketContent, "lib:"+libID+":validator", v)
+}
+
+// TouchLibrary fails for a library with no cached content: there is
+// nothing for the timestamp to vouch for
+func (s *LibraryStore) TouchLibrary(libID string, serverTS int64) error {
+	if _, _, ok := s.GetContentPage(libID, 0, 1); !ok {
+		return fmt.Errorf("library %s has no cached content", libID)
+	}
+	return s.set(bucketContent, "lib:"+libID+":ts", serverTS)
+}
+
 // === In-place watch state updates ===
 
 // SetWatchState patches a media item's watch state in place everywhere it is
@@ -446,23 +521,24 @@ func (s *LibraryStore) SetWatchState(itemID string, played bool) {
 	}
 
 	s.updateEach(bucketEpisodes, nil, wrapped(patchItemList))
-	s.updateEach(bucketContent, keySuffix(":movies"), patchItemList)
 	s.updateEach(bucketPlaylists, keyPrefix("items:"), patchItemList)
-	s.updateEach(bucketContent, keySuffix(":mixed"), func(key string, data []byte) []byte {
-		var wrappers []listItemWrapper
-		if json.Unmarshal(data, &wrappers) != nil {
+	s.updateItem(":movies", itemID, func(data []byte) []byte {
+		var m domain.MediaItem
+		if json.Unmarshal(data, &m) != nil || !patch(&m) {
 			return nil
 		}
-		changed := false
-		for i := range wrappers {
-			if patch(wrappers[i].Movie) {
-				changed = true
-			}
+		out, err := json.Marshal(&m)
+		if err != nil {
+			return nil
 		}
-		if !changed {
+		return out
+	})
+	s.updateItem(":mixed", itemID, func(data []byte) []byte {
+		var w listItemWrapper
+		if json.Unmarshal(data, &w) != nil || !patch(w.Movie) {
 			return nil
 		}
-		out, err := json.Marshal(wrappers)
+		out, err := json.Marshal(w)
 		if err != nil {
 			return nil
 		}
@@ -507,46 +583,138 @@ func (s *LibraryStore) SetWatchState(itemID string, played bool) {
 		show.UnwatchedCount = clampCount(show.UnwatchedCount+delta, show.EpisodeCount)
 		return true
 	}
-	s.updateEach(bucketContent, keySuffix(":shows"), func(key string, data []byte) []byte {
-		var shows []*domain.Show
-		if json.Unmarshal(data, &shows) != nil {
+	s.updateItem(":shows", showID, func(data []byte) []byte {
+		var show domain.Show
+		if json.Unmarshal(data, &show) != nil || !adjustShow(&show) {
+			return nil
+		}
+		out, err := json.Marshal(&show)
+		if err != nil {
+			return nil
+		}
+		return out
+	})
+	s.updateItem(":mixed", showID, func(data []byte) []byte {
+		var w listItemWrapper
+		if json.Unmarshal(data, &w) != nil || !adjustShow(w.Show) {
+			return nil
+		}
+		out, err := json.Marshal(w)
+		if err != nil {
+			return nil
+		}
+		return out
+	})
+}
+
+// SetUserRating patches an item's user rating wherever it is cached: movie
+// and episode lists, playlist items, shows and mixed content.
+func (s *LibraryStore) SetUserRating(itemID string, rating float64) {
+	patchItemList := func(key string, data []byte) []byte {
+		var items []*domain.MediaItem
+		if json.Unmarshal(data, &items) != nil {
 			return nil
 		}
 		changed := false
-		for _, show := range shows {
-			if adjustShow(show) {
+		for _, m := range items {
+			if m != nil && m.ID == itemID {
+				m.UserRating = rating
 				changed = true
 			}
 		}
 		if !changed {
 			return nil
 		}
-		out, err := json.Marshal(shows)
+		out, err := json.Marshal(items)
+		if err != nil {
+			return nil
+		}
+		return out
+	}
+	s.updateEach(bucketEpisodes, nil, wrapped(patchItemList))
+	s.updateEach(bucketPlaylists, keyPrefix("items:"), patchItemList)
+
+	s.updateItem(":movies", itemID, func(data []byte) []byte {
+		var m domain.MediaItem
+		if json.Unmarshal(data, &m) != nil || m.ID != itemID {
+			return nil
+		}
+		m.UserRating = rating
+		out, err := json.Marshal(&m)
 		if err != nil {
 			return nil
 		}
 		return out
 	})
-	s.updateEach(bucketContent, keySuffix(":mixed"), func(key string, data []byte) []byte {
-		var wrappers []listItemWrapper
-		if json.Unmarshal(data, &wrappers) != nil {
+	s.updateItem(":shows", itemID, func(data []byte) []byte {
+		var show domain.Show
+		if json.Unmarshal(data, &show) != nil || show.ID != itemID {
 			return nil
 		}
-		changed := false
-		for i := range wrappers {
-			if adjustShow(wrappers[i].Show) {
-				changed = true
+		show.UserRating = rating
+		out, err := json.Marshal(&show)
+		if err != nil {
+			return nil
+		}
+		return out
+	})
+	s.updateItem(":mixed", itemID, func(data []byte) []byte {
+		var w listItemWrapper
+		if json.Unmarshal(data, &w) != nil {
+			return nil
+		}
+		switch {
+		case w.Movie != nil && w.Movie.ID == itemID:
+			w.Movie.UserRating = rating
+		case w.Show != nil && w.Show.ID == itemID:
+			w.Show.UserRating = rating
+		default:
+			return nil
+		}
+		out, err := json.Marshal(w)
+		if err != nil {
+			return nil
+		}
+		return out
+	})
+}
+
+// PruneItems drops cached data for items deleted on the server. Shows take
+// their seasons and episodes with them; any ID is stripped from cached
+// playlist item lists (the server drops deleted items from playlists too).
+func (s *LibraryStore) PruneItems(libID string, itemIDs []string) {
+	removed := make(map[string]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		removed[id] = true
+		s.InvalidateShow(libID, id) // no-op for movies
+	}
+
+	playlistsChanged := false
+	s.updateEach(bucketPlaylists, keyPrefix("items:"), func(key string, data []byte) []byte {
+		var items []*domain.MediaItem
+		if json.Unmarshal(data, &items) != nil {
+			return nil
+		}
+		kept := items[:0]
+		for _, m := range items {
+			if m != nil && (removed[m.ID] || removed[m.ShowID]) {
+				continue
 			}
+			kept = append(kept, m)
 		}
-		if !changed {
+		if len(kept) == len(items) {
 			return nil
 		}
-		out, err := json.Marshal(wrappers)
+		playlistsChanged = true
+		out, err := json.Marshal(kept)
 		if err != nil {
 			return nil
 		}
 		return out
 	})
+	if playlistsChanged {
+		s.InvalidatePlaylists() // item counts and durations are stale
+	}
 }
 
 func clampCount(n, max int) int {
@@ -654,6 +822,7 @@ func (s *LibraryStore) InvalidateLibrary(libID string) {
 	prefix := "lib:" + libID + ":"
 	// Delete movies/shows/mixed/ts for this library
 	s.deletePrefix(bucketContent, prefix)
+	s.deleteItemLists(prefix)
 	// Delete all seasons for all shows in this library
 	s.deletePrefix(bucketSeasons, prefix)
 	// Delete all episodes for all seasons in this library
@@ -687,16 +856,13 @@ func (s *LibraryStore) InvalidateAll() {
 
 	// Delete all data from all buckets
 	s.db.Update(func(tx *bolt.Tx) error {
-		for _, bucket := range [][]byte{bucketLibraries, bucketContent, bucketSeasons, bucketEpisodes, bucketPlaylists} {
-			b := tx.Bucket(bucket)
-			if b == nil {
-				continue
+		for _, bucket := range allBuckets {
+			// Recreating the bucket drops nested item lists along with keys
+			if err := tx.DeleteBucket(bucket); err != nil {
+				return err
 			}
-			c := b.Cursor()
-			for k, _ := c.First(); k != nil; k, _ = c.Next() {
-				if err := b.Delete(k); err != nil {
-					return err
-				}
+			if _, err := tx.CreateBucket(bucket); err != nil {
+				return err
 			}
 		}
 		return nil
@@ -104,6 +104,33 @@ func TestSetWatchStateBolt(t *testing.T) {
 	testWatchState(t, seedStore(t, t.TempDir()))
 }
 
+// Pruning a deleted show drops its seasons, episodes, and playlist entries
+func TestPruneItems(t *testing.T) {
+	s := seedStore(t, t.TempDir())
+	if err := s.SavePlaylistItems("pl2", []*domain.MediaItem{
+		{ID: "ep1", Type: domain.MediaTypeEpisode, ShowID: "show1"},
+		{ID: "mov1", Type: domain.MediaTypeMovie},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.PruneItems("lib2", []string{"show1"})
+
+	if _, ok := s.GetSeasons("lib2", "show1"); ok {
+		t.Fatal("seasons of a deleted show survived")
+	}
+	if _, ok := s.GetEpisodes("lib2", "show1", "season1"); ok {
+		t.Fatal("episodes of a deleted show survived")
+	}
+	items, _ := s.GetPlaylistItems("pl2")
+	if len(items) != 1 || items[0].ID != "mov1" {
+		t.Fatalf("playlist items = %+v, want only mov1", items)
+	}
+	if items, _ := s.GetPlaylistItems("pl1"); len(items) != 1 {
+		t.Fatal("unrelated playlist was modified")
+	}
+}
+
 // Seasons/episodes have no server-side freshness signal; entries past the
 // TTL must read as cache misses so drill-downs refetch.
 func TestTVCacheTTL(t *testing.T) {
@@ -143,3 +170,155 @@ func TestTVCacheTTL(t *testing.T) {
 func TestSetWatchStateMemoryOnly(t *testing.T) {
 	testWatchState(t, seedStore(t, ""))
 }
+
+// Library content is stored per item: a resync rewrites the list in place,
+// survives a reopen, and can be read a page at a time
+func TestPerItemContent(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLibraryStore(dir, "http://test", "user1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	movies := []*domain.MediaItem{
+		{ID: "a", Title: "A", Type: domain.MediaTypeMovie},
+		{ID: "b", Title: "B", Type: domain.MediaTypeMovie},
+		{ID: "c", Title: "C", Type: domain.MediaTypeMovie},
+	}
+	if err := s.SaveMovies("lib1", movies, 100); err != nil {
+		t.Fatal(err)
+	}
+	// Resync: b removed, d added, order changed
+	movies = []*domain.MediaItem{movies[2], movies[0], {ID: "d", Title: "D", Type: domain.MediaTypeMovie}}
+	if err := s.SaveMovies("lib1", movies, 200); err != nil {
+		t.Fatal(err)
+	}
+	s.SetWatchState("a", true)
+	s.Close()
+
+	s, err = NewLibraryStore(dir, "http://test", "user1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	// Page read straight from disk, before anything is in the memory cache
+	page, total, ok := s.GetContentPage("lib1", 1, 1)
+	if !ok || total != 3 || len(page) != 1 || page[0].GetID() != "a" {
+		t.Fatalf("page = %v total=%d ok=%v, want [a] of 3", page, total, ok)
+	}
+	if m := page[0].(*domain.MediaItem); !m.IsPlayed {
+		t.Fatal("watch-state patch not persisted")
+	}
+
+	got, ok := s.GetMovies("lib1")
+	if !ok || len(got) != 3 {
+		t.Fatalf("movies after reopen = %v", got)
+	}
+	for i, want := range []string{"c", "a", "d"} {
+		if got[i].ID != want {
+			t.Fatalf("movie %d = %s, want %s", i, got[i].ID, want)
+		}
+	}
+	if !s.IsValid("lib1", 200) {
+		t.Fatal("timestamp not persisted")
+	}
+
+	// Page read from the warm memory cache agrees
+	page, total, _ = s.GetContentPage("lib1", 2, 10)
+	if total != 3 || len(page) != 1 || page[0].GetID() != "d" {
+		t.Fatalf("cached page = %v total=%d, want [d] of 3", page, total)
+	}
+
+	s.InvalidateLibrary("lib1")
+	if _, _, ok := s.GetContentPage("lib1", 0, 0); ok {
+		t.Fatal("content survived invalidation")
+	}
+}
+
+// A sync checkpoint survives a reopen, keeps mixed item kinds apart, and is
+// only served for the library version it was fetching
+func TestSyncCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLibraryStore(dir, "http://test", "user1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := []domain.ListItem{
+		&domain.MediaItem{ID: "a", Title: "A", Type: domain.MediaTypeMovie},
+		&domain.Show{ID: "b", Title: "B"},
+	}
+	if err := s.SaveSyncCheckpoint("lib1", domain.SyncCheckpoint{ServerTS: 100, Offset: 50, Items: items[:1]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SaveSyncCheckpoint("lib1", domain.SyncCheckpoint{ServerTS: 100, Offset: 100, Skipped: 1, Total: 240, Items: items}); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s, err = NewLibraryStore(dir, "http://test", "user1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if _, ok := s.GetSyncCheckpoint("lib1", 200); ok {
+		t.Fatal("checkpoint served for another library version")
+	}
+	cp, ok := s.GetSyncCheckpoint("lib1", 100)
+	if !ok || cp.Offset != 100 || cp.Skipped != 1 || cp.Total != 240 || len(cp.Items) != 2 {
+		t.Fatalf("checkpoint = %+v ok=%v", cp, ok)
+	}
+	if _, isShow := cp.Items[1].(*domain.Show); !isShow {
+		t.Fatalf("item 1 = %T, want a show", cp.Items[1])
+	}
+
+	s.ClearSyncCheckpoint("lib1")
+	if _, ok := s.GetSyncCheckpoint("lib1", 100); ok {
+		t.Fatal("checkpoint survived clearing")
+	}
+}
+
+// Seasons and episodes persist across restarts, go stale when their library
+// changes on the server, and stay readable for offline browsing
+func TestTVCacheFollowsLibraryTimestamp(t *testing.T) {
+	dir := t.TempDir()
+	s := seedStore(t, dir)
+	s.Close()
+
+	s, err := NewLibraryStore(dir, "http://test", "user1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if _, ok := s.GetSeasons("lib2", "show1"); !ok {
+		t.Fatal("seasons not persisted across reopen")
+	}
+	if _, ok := s.GetEpisodes("lib2", "show1", "season1"); !ok {
+		t.Fatal("episodes not persisted across reopen")
+	}
+
+	// The library changed on the server: its TV hierarchy is stale
+	if err := s.SaveShows("lib2", []*domain.Show{{ID: "show1"}}, 200); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.GetSeasons("lib2", "show1"); ok {
+		t.Fatal("seasons served after the library timestamp moved")
+	}
+	if _, ok := s.GetEpisodes("lib2", "show1", "season1"); ok {
+		t.Fatal("episodes served after the library timestamp moved")
+	}
+	if seasons, ok := s.GetStaleSeasons("lib2", "show1"); !ok || len(seasons) != 1 {
+		t.Fatal("stale seasons not available for offline use")
+	}
+	if _, ok := s.GetStaleEpisodes("lib2", "show1", "season1"); !ok {
+		t.Fatal("stale episodes not available for offline use")
+	}
+
+	// Refetched under the new timestamp: fresh again
+	if err := s.SaveSeasons("lib2", "show1", []*domain.Season{{ID: "season1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.GetSeasons("lib2", "show1"); !ok {
+		t.Fatal("refetched seasons not served")
+	}
+}
@@ -0,0 +1,249 @@
+// Package syncplay keeps a local player in step with a server's sync group
+// (Jellyfin SyncPlay). The group says what to play and when to pause, seek
+// and resume; between commands the player's position is checked against
+// the group's clock and nudged back when it drifts.
+package syncplay
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// Drift correction. Within nudgeThreshold the player is left alone; up to
+// seekThreshold its speed is adjusted by at most maxNudge until it catches
+// up; beyond that it seeks.
+const (
+	checkInterval  = 2 * time.Second
+	nudgeThreshold = 100 * time.Millisecond
+	seekThreshold  = time.Second
+	maxNudge       = 0.05
+)
+
+// ownPauseWindow is how long after kino pauses or resumes the player a
+// pause change is taken as its echo rather than the user's doing
+const ownPauseWindow = time.Second
+
+// Player is a local player the group drives (player.MPV)
+type Player interface {
+	Load(url string, start time.Duration) error
+	SetPaused(paused bool) error
+	Seek(pos time.Duration) error
+	SetSpeed(speed float64) error
+	Position() (time.Duration, error)
+	Stop() error
+	ShowText(text string) error
+	Pauses() <-chan bool
+	Done() <-chan struct{}
+}
+
+// ResolveFunc returns the stream URL of an item
+type ResolveFunc func(ctx context.Context, itemID string) (string, error)
+
+// Follower applies a sync session's commands to a player
+type Follower struct {
+	session domain.SyncSession
+	player  Player
+	resolve ResolveFunc
+	logger  *slog.Logger
+	now     func() time.Time
+
+	itemID       string
+	groupPlaying bool          // The group's intent, reported with Ready
+	playing      bool          // The player is running in step with the anchor
+	anchorPos    time.Duration // Group position at anchorAt
+	anchorAt     time.Time
+	paused       bool // Pause state kino last set
+	pausedAt     time.Time
+	speed        float64
+	startAt      *time.Timer // Pending Unpause
+}
+
+// NewFollower creates a follower steering player by session's commands
+func NewFollower(session domain.SyncSession, player Player, resolve ResolveFunc, logger *slog.Logger) *Follower {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Follower{
+		session: session,
+		player:  player,
+		resolve: resolve,
+		logger:  logger,
+		now:     time.Now,
+		speed:   1,
+	}
+}
+
+// Run follows the group until ctx ends, the player exits or the session
+// closes. A local pause or unpause is asked of the whole group.
+func (f *Follower) Run(ctx context.Context) error {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	defer f.cancelStart()
+
+	for {
+		var start <-chan time.Time
+		if f.startAt != nil {
+			start = f.startAt.C
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-f.player.Done():
+			return nil
+		case event, ok := <-f.session.Events():
+			if !ok || event.Kind == domain.SyncLeft {
+				f.player.ShowText("Left the sync group")
+				return nil
+			}
+			if err := f.apply(ctx, event); err != nil {
+				f.logger.Warn("failed to apply sync command", "error", err, "kind", event.Kind)
+			}
+		case <-start:
+			f.startAt = nil
+			f.start()
+		case paused := <-f.player.Pauses():
+			f.localPause(ctx, paused)
+		case <-ticker.C:
+			f.correct()
+		}
+	}
+}
+
+// apply carries out one group command
+func (f *Follower) apply(ctx context.Context, event domain.SyncEvent) error {
+	switch event.Kind {
+	case domain.SyncQueue:
+		f.cancelStart()
+		f.playing = false
+		f.groupPlaying = event.Playing
+		// mpv keeps the pause across files, so the new one opens paused
+		if err := f.setPaused(true); err != nil {
+			return err
+		}
+		if event.ItemID != f.itemID {
+			f.session.Buffering(ctx, event.Position, event.Playing)
+			url, err := f.resolve(ctx, event.ItemID)
+			if err != nil {
+				return err
+			}
+			if err := f.player.Load(url, event.Position); err != nil {
+				return err
+			}
+			f.itemID = event.ItemID
+		} else if err := f.player.Seek(event.Position); err != nil {
+			return err
+		}
+		return f.session.Ready(ctx, event.Position, event.Playing)
+
+	case domain.SyncUnpause:
+		f.cancelStart()
+		f.groupPlaying = true
+		f.anchorPos, f.anchorAt = event.Position, event.When
+		if wait := event.When.Sub(f.now()); wait > 0 {
+			// Line up at the start position while the others do
+			f.startAt = time.NewTimer(wait)
+			return f.player.Seek(event.Position)
+		}
+		f.start()
+
+	case domain.SyncPause, domain.SyncSeek:
+		f.cancelStart()
+		f.playing = false
+		if event.Kind == domain.SyncPause {
+			f.groupPlaying = false
+		}
+		if err := f.setPaused(true); err != nil {
+			return err
+		}
+		if err := f.player.Seek(event.Position); err != nil {
+			return err
+		}
+		if event.Kind == domain.SyncSeek {
+			return f.session.Ready(ctx, event.Position, f.groupPlaying)
+		}
+
+	case domain.SyncStop:
+		f.cancelStart()
+		f.playing, f.groupPlaying = false, false
+		f.itemID = ""
+		return f.player.Stop()
+	}
+	return nil
+}
+
+// start resumes playback at the anchor, which may already lie in the past
+func (f *Follower) start() {
+	f.playing = true
+	if late := f.now().Sub(f.anchorAt); late > nudgeThreshold {
+		f.player.Seek(f.anchorPos + late)
+	}
+	if err := f.setPaused(false); err != nil {
+		f.logger.Warn("failed to resume player", "error", err)
+	}
+}
+
+// correct compares the player with the group clock and closes any gap:
+// small ones by playing slightly faster or slower, large ones by seeking
+func (f *Follower) correct() {
+	if !f.playing {
+		return
+	}
+	actual, err := f.player.Position()
+	if err != nil {
+		return
+	}
+	expected := f.anchorPos + f.now().Sub(f.anchorAt)
+	drift := actual - expected
+
+	speed := 1.0
+	switch {
+	case drift.Abs() > seekThreshold:
+		f.logger.Debug("sync drift, seeking", "drift", drift)
+		if err := f.player.Seek(expected); err != nil {
+			return
+		}
+	case drift.Abs() > nudgeThreshold:
+		// Close the gap over the next check: ahead plays slower
+		speed = 1 - max(-maxNudge, min(maxNudge, drift.Seconds()/checkInterval.Seconds()))
+	}
+	if speed != f.speed {
+		if f.player.SetSpeed(speed) == nil {
+			f.speed = speed
+		}
+	}
+}
+
+// localPause passes a pause or unpause the user made in the player on to
+// the group; the group's answering command then moves everyone
+func (f *Follower) localPause(ctx context.Context, paused bool) {
+	if f.itemID == "" || paused == f.paused || f.now().Sub(f.pausedAt) < ownPauseWindow {
+		return
+	}
+	f.paused = paused
+	var err error
+	if paused {
+		err = f.session.RequestPause(ctx)
+	} else {
+		err = f.session.RequestUnpause(ctx)
+	}
+	if err != nil {
+		f.logger.Warn("failed to send sync request", "error", err, "pause", paused)
+	}
+}
+
+// setPaused pauses or resumes the player, remembering that kino did it
+func (f *Follower) setPaused(paused bool) error {
+	f.paused = paused
+	f.pausedAt = f.now()
+	return f.player.SetPaused(paused)
+}
+
+func (f *Follower) cancelStart() {
+	if f.startAt != nil {
+		f.startAt.Stop()
+		f.startAt = nil
+	}
+}
@@ -0,0 +1,100 @@
+package syncplay
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// fakePlayer records every command as a string
+type fakePlayer struct {
+	calls    []string
+	position time.Duration
+}
+
+func (p *fakePlayer) record(format string, args ...any) error {
+	p.calls = append(p.calls, fmt.Sprintf(format, args...))
+	return nil
+}
+
+func (p *fakePlayer) Load(url string, start time.Duration) error {
+	return p.record("load %s %s", url, start)
+}
+func (p *fakePlayer) SetPaused(paused bool) error      { return p.record("pause %v", paused) }
+func (p *fakePlayer) Seek(pos time.Duration) error     { return p.record("seek %s", pos) }
+func (p *fakePlayer) SetSpeed(speed float64) error     { return p.record("speed %.2f", speed) }
+func (p *fakePlayer) Position() (time.Duration, error) { return p.position, nil }
+func (p *fakePlayer) Stop() error                      { return p.record("stop") }
+func (p *fakePlayer) ShowText(text string) error       { return nil }
+func (p *fakePlayer) Pauses() <-chan bool              { return nil }
+func (p *fakePlayer) Done() <-chan struct{}            { return nil }
+
+// fakeSession records the state reports sent to the group
+type fakeSession struct {
+	reports []string
+}
+
+func (s *fakeSession) Group() domain.SyncGroup         { return domain.SyncGroup{} }
+func (s *fakeSession) Events() <-chan domain.SyncEvent { return nil }
+func (s *fakeSession) Ready(ctx context.Context, position time.Duration, playing bool) error {
+	s.reports = append(s.reports, fmt.Sprintf("ready %s %v", position, playing))
+	return nil
+}
+func (s *fakeSession) Buffering(ctx context.Context, position time.Duration, playing bool) error {
+	s.reports = append(s.reports, fmt.Sprintf("buffering %s %v", position, playing))
+	return nil
+}
+func (s *fakeSession) RequestPause(ctx context.Context) error   { return nil }
+func (s *fakeSession) RequestUnpause(ctx context.Context) error { return nil }
+func (s *fakeSession) Leave(ctx context.Context) error          { return nil }
+
+// A queued item loads paused and reports ready; an unpause already due
+// starts at once, and drift is nudged when small and seeked when large
+func TestFollowerAppliesCommandsAndCorrectsDrift(t *testing.T) {
+	ctx := context.Background()
+	player := &fakePlayer{}
+	session := &fakeSession{}
+	resolve := func(ctx context.Context, itemID string) (string, error) { return "http://stream/" + itemID, nil }
+	f := NewFollower(session, player, resolve, nil)
+	clock := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	f.now = func() time.Time { return clock }
+
+	step := func(name string, run func(), want []string) {
+		t.Helper()
+		player.calls = nil
+		run()
+		if !reflect.DeepEqual(player.calls, want) {
+			t.Errorf("%s: player got %q, want %q", name, player.calls, want)
+		}
+	}
+
+	step("queue", func() {
+		f.apply(ctx, domain.SyncEvent{Kind: domain.SyncQueue, ItemID: "42", Position: time.Minute, Playing: true})
+	}, []string{"pause true", "load http://stream/42 1m0s"})
+	if want := []string{"buffering 1m0s true", "ready 1m0s true"}; !reflect.DeepEqual(session.reports, want) {
+		t.Errorf("reports = %q, want %q", session.reports, want)
+	}
+
+	step("unpause", func() {
+		f.apply(ctx, domain.SyncEvent{Kind: domain.SyncUnpause, Position: time.Minute, When: clock})
+	}, []string{"pause false"})
+
+	clock = clock.Add(10 * time.Second)
+	player.position = time.Minute + 10*time.Second
+	step("in step", f.correct, nil)
+
+	player.position = time.Minute + 10*time.Second + 200*time.Millisecond
+	step("slightly ahead", f.correct, []string{"speed 0.95"})
+
+	player.position = time.Minute + 5*time.Second
+	step("far behind", f.correct, []string{"seek 1m10s", "speed 1.00"})
+
+	step("pause", func() {
+		f.apply(ctx, domain.SyncEvent{Kind: domain.SyncPause, Position: 70 * time.Second})
+	}, []string{"pause true", "seek 1m10s"})
+	step("paused", f.correct, nil)
+}
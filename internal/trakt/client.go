@@ -0,0 +1,203 @@
+// Package trakt talks to the Trakt.tv API to import watch history into the
+// connected media server.
+package trakt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+const (
+	baseURL        = "https://api.trakt.tv"
+	apiVersion     = "2"
+	defaultTimeout = 30 * time.Second
+	userAgent      = "Kino/1.0"
+)
+
+// ErrDeviceCodeExpired is returned when the user didn't approve the device
+// code before it expired
+var ErrDeviceCodeExpired = errors.New("trakt device code expired")
+
+// errAuthPending means the user hasn't approved the device code yet
+var errAuthPending = errors.New("authorization pending")
+
+// Client is a minimal Trakt API client: device authentication and the
+// watched-history endpoints the importer needs
+type Client struct {
+	baseURL      string
+	clientID     string
+	clientSecret string
+	accessToken  string
+	httpClient   *http.Client
+	logger       *slog.Logger
+}
+
+// NewClient creates a Trakt client. clientID and clientSecret come from a
+// Trakt API application; accessToken may be empty until Authenticate runs.
+func NewClient(clientID, clientSecret, accessToken string, logger *slog.Logger) *Client {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Client{
+		baseURL:      baseURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		accessToken:  accessToken,
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		logger: logger,
+	}
+}
+
+// AccessToken returns the current access token (set by Authenticate)
+func (c *Client) AccessToken() string {
+	return c.accessToken
+}
+
+// do performs a Trakt API request. 401 → domain.ErrAuthFailed, transport
+// failures → domain.ErrServerOffline, any 2xx → success. Device-token
+// polling statuses are mapped by the caller via the returned status code.
+func (c *Client) do(ctx context.Context, method, path string, jsonBody interface{}) ([]byte, int, error) {
+	var reqBody io.Reader
+	if jsonBody != nil {
+		b, err := json.Marshal(jsonBody)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("trakt-api-version", apiVersion)
+	req.Header.Set("trakt-api-key", c.clientID)
+	req.Header.Set("User-Agent", userAgent)
+	if c.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	}
+
+	c.logger.Debug("trakt request", "method", method, "path", path)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.Warn("trakt request failed", "error", err, "path", path)
+		return nil, 0, fmt.Errorf("%w: %v", domain.ErrServerOffline, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return nil, resp.StatusCode, domain.ErrAuthFailed
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return body, resp.StatusCode, nil
+	default:
+		return nil, resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
+
+// RequestDeviceCode starts the device authentication flow
+func (c *Client) RequestDeviceCode(ctx context.Context) (*DeviceCodeResponse, error) {
+	body, _, err := c.do(ctx, http.MethodPost, "/oauth/device/code", map[string]string{
+		"client_id": c.clientID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var resp DeviceCodeResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse device code response: %w", err)
+	}
+	return &resp, nil
+}
+
+// pollDeviceToken checks once whether the user approved the device code
+func (c *Client) pollDeviceToken(ctx context.Context, deviceCode string) (*TokenResponse, error) {
+	body, status, err := c.do(ctx, http.MethodPost, "/oauth/device/token", map[string]string{
+		"code":          deviceCode,
+		"client_id":     c.clientID,
+		"client_secret": c.clientSecret,
+	})
+	switch status {
+	case http.StatusBadRequest, http.StatusTooManyRequests:
+		return nil, errAuthPending // pending, or polling too fast
+	case http.StatusNotFound, http.StatusConflict, http.StatusGone, 418:
+		return nil, ErrDeviceCodeExpired // invalid, already used, expired, or denied
+	}
+	if err != nil {
+		return nil, err
+	}
+	var resp TokenResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	return &resp, nil
+}
+
+// WaitForDeviceToken polls until the user approves the device code, then
+// stores and returns the access token
+func (c *Client) WaitForDeviceToken(ctx context.Context, code *DeviceCodeResponse) (string, error) {
+	interval := time.Duration(max(code.Interval, 1)) * time.Second
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(interval):
+			tok, err := c.pollDeviceToken(ctx, code.DeviceCode)
+			if errors.Is(err, errAuthPending) {
+				continue
+			}
+			if err != nil {
+				return "", err
+			}
+			c.accessToken = tok.AccessToken
+			return tok.AccessToken, nil
+		}
+	}
+	return "", ErrDeviceCodeExpired
+}
+
+// WatchedMovies returns every movie in the user's watched history
+func (c *Client) WatchedMovies(ctx context.Context) ([]WatchedMovie, error) {
+	body, _, err := c.do(ctx, http.MethodGet, "/sync/watched/movies", nil)
+	if err != nil {
+		return nil, err
+	}
+	var movies []WatchedMovie
+	if err := json.Unmarshal(body, &movies); err != nil {
+		return nil, fmt.Errorf("failed to parse watched movies: %w", err)
+	}
+	return movies, nil
+}
+
+// WatchedShows returns every show with at least one watched episode
+func (c *Client) WatchedShows(ctx context.Context) ([]WatchedShow, error) {
+	body, _, err := c.do(ctx, http.MethodGet, "/sync/watched/shows", nil)
+	if err != nil {
+		return nil, err
+	}
+	var shows []WatchedShow
+	if err := json.Unmarshal(body, &shows); err != nil {
+		return nil, fmt.Errorf("failed to parse watched shows: %w", err)
+	}
+	return shows, nil
+}
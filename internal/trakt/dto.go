@@ -0,0 +1,60 @@
+package trakt
+
+// DeviceCodeResponse is returned by /oauth/device/code
+type DeviceCodeResponse struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURL string `json:"verification_url"`
+	ExpiresIn       int    `json:"expires_in"` // seconds
+	Interval        int    `json:"interval"`   // poll interval, seconds
+}
+
+// TokenResponse is returned by /oauth/device/token once the user approves
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// IDs holds the external identifiers Trakt attaches to every title
+type IDs struct {
+	Trakt int    `json:"trakt"`
+	Slug  string `json:"slug"`
+	IMDB  string `json:"imdb"`
+	TMDB  int    `json:"tmdb"`
+	TVDB  int    `json:"tvdb"`
+}
+
+// Title is the movie/show summary embedded in watched entries
+type Title struct {
+	Title string `json:"title"`
+	Year  int    `json:"year"`
+	IDs   IDs    `json:"ids"`
+}
+
+// WatchedMovie is one entry of /sync/watched/movies
+type WatchedMovie struct {
+	Plays         int    `json:"plays"`
+	LastWatchedAt string `json:"last_watched_at"`
+	Movie         Title  `json:"movie"`
+}
+
+// WatchedEpisode is an episode inside a WatchedSeason
+type WatchedEpisode struct {
+	Number        int    `json:"number"`
+	Plays         int    `json:"plays"`
+	LastWatchedAt string `json:"last_watched_at"`
+}
+
+// WatchedSeason is a season inside a WatchedShow
+type WatchedSeason struct {
+	Number   int              `json:"number"`
+	Episodes []WatchedEpisode `json:"episodes"`
+}
+
+// WatchedShow is one entry of /sync/watched/shows
+type WatchedShow struct {
+	Plays   int             `json:"plays"`
+	Show    Title           `json:"show"`
+	Seasons []WatchedSeason `json:"seasons"`
+}
@@ -0,0 +1,341 @@
+package trakt
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// Library is the subset of library.Service the importer reads the server's
+// content through
+type Library interface {
+	FetchLibraries(ctx context.Context) ([]domain.Library, error)
+	FetchMovies(ctx context.Context, libID string, serverTS int64, onProgress domain.ProgressFunc) ([]*domain.MediaItem, error)
+	FetchShows(ctx context.Context, libID string, serverTS int64, onProgress domain.ProgressFunc) ([]*domain.Show, error)
+	FetchMixedContent(ctx context.Context, libID string, serverTS int64, onProgress domain.ProgressFunc) ([]domain.ListItem, error)
+	FetchSeasons(ctx context.Context, libID, showID string) ([]*domain.Season, error)
+	FetchEpisodes(ctx context.Context, libID, showID, seasonID string) ([]*domain.MediaItem, error)
+	SetWatchState(itemID string, played bool)
+}
+
+// History is the Trakt side of an import
+type History interface {
+	WatchedMovies(ctx context.Context) ([]WatchedMovie, error)
+	WatchedShows(ctx context.Context) ([]WatchedShow, error)
+}
+
+// ImportEntry is one Trakt watched entry matched to a server item
+type ImportEntry struct {
+	ItemID string // Server item ID (empty when unmatched)
+	Label  string // "Title (Year)" or "Show S01E02"
+	Plays  int    // Trakt play count
+}
+
+// Report summarizes an import. Trakt play counts are written back where the
+// server can take them (domain.PlayCountClient); elsewhere items are only
+// marked watched.
+type Report struct {
+	DryRun         bool
+	ToMark         []ImportEntry // Watched on Trakt, unwatched on the server
+	AlreadyWatched []ImportEntry // Watched on both
+	Unmatched      []ImportEntry // No matching server item
+	Failed         []ImportEntry // Server rejected the mark (not set in dry runs)
+}
+
+// Importer applies Trakt watched history to the connected media server
+type Importer struct {
+	library  Library
+	playback domain.PlaybackClient
+	history  History
+	logger   *slog.Logger
+}
+
+// NewImporter creates an importer
+func NewImporter(lib Library, playback domain.PlaybackClient, history History, logger *slog.Logger) *Importer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Importer{
+		library:  lib,
+		playback: playback,
+		history:  history,
+		logger:   logger,
+	}
+}
+
+// showRef locates a server show for episode lookups
+type showRef struct {
+	libID string
+	show  *domain.Show
+}
+
+// titleKey normalizes a title for matching: case, punctuation and
+// whitespace differences between Trakt and server metadata are common
+func titleKey(title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// index finds server content for Trakt titles: by IMDb, TMDB or TVDB ID,
+// else by title and year
+type index[T any] struct {
+	byID    map[string]T
+	byTitle map[string]map[int]T
+}
+
+func newIndex[T any]() *index[T] {
+	return &index[T]{byID: make(map[string]T), byTitle: make(map[string]map[int]T)}
+}
+
+// idKeys are an item's external IDs as index keys
+func idKeys(ids domain.ExternalIDs) []string {
+	var keys []string
+	if ids.IMDb != "" {
+		keys = append(keys, "imdb:"+ids.IMDb)
+	}
+	if ids.TMDB != "" {
+		keys = append(keys, "tmdb:"+ids.TMDB)
+	}
+	if ids.TVDB != "" {
+		keys = append(keys, "tvdb:"+ids.TVDB)
+	}
+	return keys
+}
+
+// traktKeys are a Trakt title's external IDs as index keys
+func traktKeys(ids IDs) []string {
+	external := domain.ExternalIDs{IMDb: ids.IMDB}
+	if ids.TMDB > 0 {
+		external.TMDB = strconv.Itoa(ids.TMDB)
+	}
+	if ids.TVDB > 0 {
+		external.TVDB = strconv.Itoa(ids.TVDB)
+	}
+	return idKeys(external)
+}
+
+func (ix *index[T]) add(title string, year int, ids domain.ExternalIDs, v T) {
+	for _, key := range idKeys(ids) {
+		ix.byID[key] = v
+	}
+	key := titleKey(title)
+	if ix.byTitle[key] == nil {
+		ix.byTitle[key] = make(map[int]T)
+	}
+	ix.byTitle[key][year] = v
+}
+
+// lookup finds a Trakt title by ID, else by title and year, tolerating a
+// one-year mismatch (release vs. premiere dates differ between metadata
+// providers). A title alone never matches: remakes share it.
+func (ix *index[T]) lookup(t Title) (T, bool) {
+	for _, key := range traktKeys(t.IDs) {
+		if v, ok := ix.byID[key]; ok {
+			return v, true
+		}
+	}
+	var zero T
+	byYear, ok := ix.byTitle[titleKey(t.Title)]
+	if !ok || t.Year == 0 {
+		return zero, false
+	}
+	for _, y := range []int{t.Year, t.Year - 1, t.Year + 1} {
+		if v, ok := byYear[y]; ok {
+			return v, true
+		}
+	}
+	return zero, false
+}
+
+// Run fetches Trakt history, matches it against the server, and marks
+// matched items watched. With dryRun nothing is written; the report shows
+// what would change.
+func (imp *Importer) Run(ctx context.Context, dryRun bool) (*Report, error) {
+	movies, shows, err := imp.indexServer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	watchedMovies, err := imp.history.WatchedMovies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	watchedShows, err := imp.history.WatchedShows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{DryRun: dryRun}
+	var toMark []*domain.MediaItem // In step with report.ToMark
+
+	for _, wm := range watchedMovies {
+		entry := ImportEntry{Label: formatLabel(wm.Movie.Title, wm.Movie.Year), Plays: wm.Plays}
+		item, ok := movies.lookup(wm.Movie)
+		if !ok {
+			report.Unmatched = append(report.Unmatched, entry)
+			continue
+		}
+		entry.ItemID = item.ID
+		if item.IsPlayed {
+			report.AlreadyWatched = append(report.AlreadyWatched, entry)
+			continue
+		}
+		report.ToMark = append(report.ToMark, entry)
+		toMark = append(toMark, item)
+	}
+
+	for _, ws := range watchedShows {
+		ref, ok := shows.lookup(ws.Show)
+		if !ok {
+			for _, season := range ws.Seasons {
+				for _, ep := range season.Episodes {
+					report.Unmatched = append(report.Unmatched, ImportEntry{
+						Label: episodeLabel(ws.Show.Title, season.Number, ep.Number),
+						Plays: ep.Plays,
+					})
+				}
+			}
+			continue
+		}
+
+		episodes, err := imp.indexEpisodes(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		for _, season := range ws.Seasons {
+			for _, ep := range season.Episodes {
+				entry := ImportEntry{
+					Label: episodeLabel(ref.show.Title, season.Number, ep.Number),
+					Plays: ep.Plays,
+				}
+				item, ok := episodes[[2]int{season.Number, ep.Number}]
+				if !ok {
+					report.Unmatched = append(report.Unmatched, entry)
+					continue
+				}
+				entry.ItemID = item.ID
+				if item.IsPlayed {
+					report.AlreadyWatched = append(report.AlreadyWatched, entry)
+					continue
+				}
+				report.ToMark = append(report.ToMark, entry)
+				toMark = append(toMark, item)
+			}
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	counter, _ := imp.playback.(domain.PlayCountClient)
+	for i, item := range toMark {
+		var err error
+		if plays := report.ToMark[i].Plays; counter != nil && plays > 1 {
+			err = counter.SetPlayCount(ctx, item.ID, plays)
+		} else {
+			err = imp.playback.MarkPlayed(ctx, item.ID)
+		}
+		if err != nil {
+			imp.logger.Warn("trakt import: failed to mark watched", "error", err, "itemID", item.ID)
+			report.Failed = append(report.Failed, report.ToMark[i])
+			continue
+		}
+		imp.library.SetWatchState(item.ID, true)
+	}
+	imp.logger.Info("trakt import complete",
+		"marked", len(toMark)-len(report.Failed),
+		"failed", len(report.Failed),
+		"unmatched", len(report.Unmatched))
+
+	return report, nil
+}
+
+func formatLabel(title string, year int) string {
+	if year == 0 {
+		return title
+	}
+	return fmt.Sprintf("%s (%d)", title, year)
+}
+
+func episodeLabel(show string, season, episode int) string {
+	return fmt.Sprintf("%s S%02dE%02d", show, season, episode)
+}
+
+// indexServer fetches every movie and show library and indexes the content
+// by external ID and by normalized title and year
+func (imp *Importer) indexServer(ctx context.Context) (*index[*domain.MediaItem], *index[showRef], error) {
+	movies := newIndex[*domain.MediaItem]()
+	shows := newIndex[showRef]()
+
+	libs, err := imp.library.FetchLibraries(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, lib := range libs {
+		switch lib.Type {
+		case "movie":
+			items, err := imp.library.FetchMovies(ctx, lib.ID, lib.UpdatedAt, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, m := range items {
+				movies.add(m.Title, m.Year, m.External, m)
+			}
+		case "show":
+			items, err := imp.library.FetchShows(ctx, lib.ID, lib.UpdatedAt, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, s := range items {
+				shows.add(s.Title, s.Year, s.External, showRef{libID: lib.ID, show: s})
+			}
+		case "mixed":
+			items, err := imp.library.FetchMixedContent(ctx, lib.ID, lib.UpdatedAt, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, it := range items {
+				switch v := it.(type) {
+				case *domain.MediaItem:
+					if v.Type == domain.MediaTypeMovie {
+						movies.add(v.Title, v.Year, v.External, v)
+					}
+				case *domain.Show:
+					shows.add(v.Title, v.Year, v.External, showRef{libID: lib.ID, show: v})
+				}
+			}
+		}
+	}
+	return movies, shows, nil
+}
+
+// indexEpisodes fetches all episodes of a show keyed by season/episode number
+func (imp *Importer) indexEpisodes(ctx context.Context, ref showRef) (map[[2]int]*domain.MediaItem, error) {
+	seasons, err := imp.library.FetchSeasons(ctx, ref.libID, ref.show.ID)
+	if err != nil {
+		return nil, err
+	}
+	episodes := make(map[[2]int]*domain.MediaItem)
+	for _, season := range seasons {
+		items, err := imp.library.FetchEpisodes(ctx, ref.libID, ref.show.ID, season.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, ep := range items {
+			episodes[[2]int{ep.SeasonNum, ep.EpisodeNum}] = ep
+		}
+	}
+	return episodes, nil
+}
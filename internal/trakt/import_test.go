@@ -0,0 +1,185 @@
+package trakt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// fakeLibrary serves one movie library and one show library
+type fakeLibrary struct {
+	movies   []*domain.MediaItem
+	shows    []*domain.Show
+	episodes []*domain.MediaItem
+	watched  map[string]bool
+}
+
+func (f *fakeLibrary) FetchLibraries(ctx context.Context) ([]domain.Library, error) {
+	return []domain.Library{{ID: "m", Type: "movie"}, {ID: "s", Type: "show"}}, nil
+}
+
+func (f *fakeLibrary) FetchMovies(ctx context.Context, libID string, serverTS int64, onProgress domain.ProgressFunc) ([]*domain.MediaItem, error) {
+	return f.movies, nil
+}
+
+func (f *fakeLibrary) FetchShows(ctx context.Context, libID string, serverTS int64, onProgress domain.ProgressFunc) ([]*domain.Show, error) {
+	return f.shows, nil
+}
+
+func (f *fakeLibrary) FetchMixedContent(ctx context.Context, libID string, serverTS int64, onProgress domain.ProgressFunc) ([]domain.ListItem, error) {
+	return nil, nil
+}
+
+func (f *fakeLibrary) FetchSeasons(ctx context.Context, libID, showID string) ([]*domain.Season, error) {
+	return []*domain.Season{{ID: "season1", ShowID: showID, SeasonNum: 1}}, nil
+}
+
+func (f *fakeLibrary) FetchEpisodes(ctx context.Context, libID, showID, seasonID string) ([]*domain.MediaItem, error) {
+	return f.episodes, nil
+}
+
+func (f *fakeLibrary) SetWatchState(itemID string, played bool) {
+	f.watched[itemID] = played
+}
+
+type fakePlayback struct {
+	marked []string
+	plays  map[string]int
+}
+
+func (f *fakePlayback) ResolvePlayableURL(ctx context.Context, itemID string) (string, error) {
+	return "", nil
+}
+
+func (f *fakePlayback) MarkPlayed(ctx context.Context, itemID string) error {
+	f.marked = append(f.marked, itemID)
+	return nil
+}
+
+func (f *fakePlayback) SetPlayCount(ctx context.Context, itemID string, plays int) error {
+	f.marked = append(f.marked, itemID)
+	f.plays[itemID] = plays
+	return nil
+}
+
+func (f *fakePlayback) MarkUnplayed(ctx context.Context, itemID string) error { return nil }
+
+type fakeHistory struct {
+	movies []WatchedMovie
+	shows  []WatchedShow
+}
+
+func (f *fakeHistory) WatchedMovies(ctx context.Context) ([]WatchedMovie, error) {
+	return f.movies, nil
+}
+func (f *fakeHistory) WatchedShows(ctx context.Context) ([]WatchedShow, error) { return f.shows, nil }
+
+func newFixture() (*fakeLibrary, *fakePlayback, *fakeHistory) {
+	lib := &fakeLibrary{
+		movies: []*domain.MediaItem{
+			{ID: "alien", Title: "Alien", Year: 1979, Type: domain.MediaTypeMovie},
+			{ID: "heat", Title: "Heat", Year: 1995, Type: domain.MediaTypeMovie, IsPlayed: true},
+		},
+		shows: []*domain.Show{{ID: "office", Title: "The Office (US)", Year: 2005}},
+		episodes: []*domain.MediaItem{
+			{ID: "s1e1", SeasonNum: 1, EpisodeNum: 1, Type: domain.MediaTypeEpisode},
+			{ID: "s1e2", SeasonNum: 1, EpisodeNum: 2, Type: domain.MediaTypeEpisode},
+		},
+		watched: make(map[string]bool),
+	}
+	history := &fakeHistory{
+		movies: []WatchedMovie{
+			{Plays: 2, Movie: Title{Title: "Alien", Year: 1979}},
+			{Plays: 1, Movie: Title{Title: "Heat", Year: 1995}},
+			{Plays: 1, Movie: Title{Title: "Arrival", Year: 2016}},
+		},
+		shows: []WatchedShow{{
+			Show: Title{Title: "The Office (US)", Year: 2005},
+			Seasons: []WatchedSeason{{Number: 1, Episodes: []WatchedEpisode{
+				{Number: 2, Plays: 3},
+				{Number: 9, Plays: 1},
+			}}},
+		}},
+	}
+	return lib, &fakePlayback{plays: make(map[string]int)}, history
+}
+
+// TestImportDryRunWritesNothing covers the report classification and that a
+// dry run leaves both the server and the cache untouched
+func TestImportDryRunWritesNothing(t *testing.T) {
+	lib, pb, history := newFixture()
+	report, err := NewImporter(lib, pb, history, nil).Run(context.Background(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.ToMark) != 2 || len(report.AlreadyWatched) != 1 || len(report.Unmatched) != 2 {
+		t.Fatalf("got toMark=%d already=%d unmatched=%d, want 2/1/2",
+			len(report.ToMark), len(report.AlreadyWatched), len(report.Unmatched))
+	}
+	if len(pb.marked) != 0 || len(lib.watched) != 0 {
+		t.Fatalf("dry run wrote: marked=%v watched=%v", pb.marked, lib.watched)
+	}
+}
+
+func TestImportMarksMatchedItems(t *testing.T) {
+	lib, pb, history := newFixture()
+	report, err := NewImporter(lib, pb, history, nil).Run(context.Background(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pb.marked) != 2 || pb.marked[0] != "alien" || pb.marked[1] != "s1e2" {
+		t.Fatalf("marked %v, want [alien s1e2]", pb.marked)
+	}
+	if !lib.watched["alien"] || !lib.watched["s1e2"] {
+		t.Fatalf("cache not updated: %v", lib.watched)
+	}
+	if pb.plays["alien"] != 2 || pb.plays["s1e2"] != 3 {
+		t.Fatalf("play counts = %v, want Trakt's", pb.plays)
+	}
+	if len(report.Failed) != 0 {
+		t.Fatalf("unexpected failures: %v", report.Failed)
+	}
+}
+
+// TestLookupToleratesYearDrift covers metadata providers disagreeing on
+// release year by one
+func TestLookupToleratesYearDrift(t *testing.T) {
+	ix := newIndex[string]()
+	ix.add("Blade Runner 2049", 2017, domain.ExternalIDs{}, "br")
+	ix.add("Dune", 1984, domain.ExternalIDs{}, "dune84")
+	ix.add("Dune", 2021, domain.ExternalIDs{}, "dune21")
+
+	if v, ok := ix.lookup(Title{Title: "blade runner 2049", Year: 2018}); !ok || v != "br" {
+		t.Fatalf("year drift: got %q, %v", v, ok)
+	}
+	if v, ok := ix.lookup(Title{Title: "Dune", Year: 2021}); !ok || v != "dune21" {
+		t.Fatalf("exact year: got %q, %v", v, ok)
+	}
+	if _, ok := ix.lookup(Title{Title: "Dune", Year: 2000}); ok {
+		t.Fatal("ambiguous title with distant year should not match")
+	}
+}
+
+// External IDs match whatever the titles say; without them a title only
+// matches in its year, so a remake the server lacks stays unmatched
+func TestLookupPrefersExternalIDs(t *testing.T) {
+	ix := newIndex[string]()
+	ix.add("Solaris", 2002, domain.ExternalIDs{IMDb: "tt0307479", TMDB: "2103"}, "solaris02")
+	ix.add("Sleuth", 2007, domain.ExternalIDs{}, "sleuth07")
+
+	if v, ok := ix.lookup(Title{Title: "Solyaris", Year: 1972, IDs: IDs{TMDB: 2103}}); !ok || v != "solaris02" {
+		t.Fatalf("TMDB ID: got %q, %v", v, ok)
+	}
+	if v, ok := ix.lookup(Title{Title: "Solaris", IDs: IDs{IMDB: "tt0307479"}}); !ok || v != "solaris02" {
+		t.Fatalf("IMDb ID: got %q, %v", v, ok)
+	}
+	if _, ok := ix.lookup(Title{Title: "Sleuth", Year: 1972, IDs: IDs{IMDB: "tt0069281"}}); ok {
+		t.Fatal("the 1972 original matched its 2007 remake by title")
+	}
+	if _, ok := ix.lookup(Title{Title: "Sleuth"}); ok {
+		t.Fatal("a title without a year matched")
+	}
+}
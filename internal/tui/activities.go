@@ -0,0 +1,205 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// Server activity polling. The server's own tasks (scans, refreshes,
+// conversions) are asked for every activityInterval while it is reachable.
+const (
+	activityInterval = 10 * time.Second
+	activityTimeout  = 10 * time.Second
+)
+
+// ActivityTickMsg fires when the next activity poll is due. Gen matches
+// Model.activityGen unless a poll was rescheduled since.
+type ActivityTickMsg struct {
+	Gen int
+}
+
+// ActivitiesLoadedMsg carries the server's running tasks
+type ActivitiesLoadedMsg struct {
+	Gen        int
+	Activities []domain.Activity
+	Err        error
+}
+
+// FetchActivitiesCmd asks the server what it is running
+func FetchActivitiesCmd(svc *library.Service, gen int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), activityTimeout)
+		defer cancel()
+
+		activities, err := svc.FetchActivities(ctx)
+		return ActivitiesLoadedMsg{Gen: gen, Activities: activities, Err: err}
+	}
+}
+
+// initActivitiesCmd starts polling on backends that report activities
+func (m Model) initActivitiesCmd() tea.Cmd {
+	if m.LibraryService == nil || !m.LibraryService.HasActivities() {
+		return nil
+	}
+	return FetchActivitiesCmd(m.LibraryService, m.activityGen)
+}
+
+// scheduleActivities queues the next poll after delay, superseding any
+// already queued
+func (m *Model) scheduleActivities(delay time.Duration) tea.Cmd {
+	if m.LibraryService == nil || !m.LibraryService.HasActivities() {
+		return nil
+	}
+	m.activityGen++
+	gen := m.activityGen
+	if delay == 0 {
+		return FetchActivitiesCmd(m.LibraryService, gen)
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return ActivityTickMsg{Gen: gen}
+	})
+}
+
+// handleActivityTick polls unless the server is known to be offline, in
+// which case the health check's reconnects are left to find it
+func (m Model) handleActivityTick(msg ActivityTickMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.activityGen {
+		return m, nil
+	}
+	if m.health.status == HealthOffline {
+		return m, m.scheduleActivities(activityInterval)
+	}
+	return m, FetchActivitiesCmd(m.LibraryService, msg.Gen)
+}
+
+// handleActivitiesLoaded replaces the running tasks and says when a library
+// scan finishes, since that is when new files become browsable. A failed
+// poll keeps the last list; the health check reports the server's state.
+func (m Model) handleActivitiesLoaded(msg ActivitiesLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.activityGen {
+		return m, nil
+	}
+	cmds := []tea.Cmd{m.scheduleActivities(activityInterval)}
+	if msg.Err != nil {
+		return m, tea.Batch(cmds...)
+	}
+
+	running := make(map[string]bool, len(msg.Activities))
+	for _, a := range msg.Activities {
+		running[a.ID] = true
+	}
+	for _, a := range m.activities {
+		if a.Kind == domain.ActivityScan && !running[a.ID] {
+			cmds = append(cmds, m.notifyHint(NoticeSuccess, i18n.T("status.scan_finished", a.Title), i18n.T("hint.refresh_all")))
+		}
+	}
+	m.activities = msg.Activities
+	return m, tea.Batch(cmds...)
+}
+
+// ActivitiesKeyMap defines the activity panel key bindings
+type ActivitiesKeyMap struct {
+	Close key.Binding
+}
+
+// ActivitiesKeys is the activity panel key bindings instance
+var ActivitiesKeys = ActivitiesKeyMap{
+	Close: key.NewBinding(
+		key.WithKeys("esc", "A"),
+		key.WithHelp("esc", "close"),
+	),
+}
+
+// handleActivitiesPanel opens the server activity panel and polls at once
+func (m Model) handleActivitiesPanel() (tea.Model, tea.Cmd) {
+	if m.LibraryService == nil || !m.LibraryService.HasActivities() {
+		return m, m.notify(NoticeInfo, i18n.T("status.no_activities"))
+	}
+	m.activitiesOpen = true
+	return m, m.scheduleActivities(0)
+}
+
+// handleActivitiesPanelInput closes the panel; other keys are swallowed
+// while it is open
+func (m Model) handleActivitiesPanelInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
+	if key.Matches(msg, ActivitiesKeys.Close) {
+		m.activitiesOpen = false
+	}
+	return true, m, nil
+}
+
+// renderActivitiesPanel lists the server's running tasks with progress
+func (m Model) renderActivitiesPanel() string {
+	var b strings.Builder
+	b.WriteString(styles.ModalTitleStyle.Render("Server Activity"))
+	b.WriteString("\n\n")
+
+	if len(m.activities) == 0 {
+		b.WriteString(styles.DimStyle.Render("  The server is idle"))
+		b.WriteString("\n")
+	}
+	for _, a := range m.activities {
+		line := fmt.Sprintf("%s %-34s  %s", activityGlyph(a.Kind), styles.Truncate(a.Title, 34), m.activityProgress(a))
+		b.WriteString("  " + line + "\n")
+		if a.Subtitle != "" {
+			b.WriteString("    " + styles.DimStyle.Render(styles.Truncate(a.Subtitle, 40)) + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render(fmt.Sprintf("updates every %s · esc close", activityInterval)))
+
+	return lipgloss.Place(m.Width, m.Height,
+		lipgloss.Center, lipgloss.Center,
+		styles.ModalStyle.Render(b.String()))
+}
+
+// activityGlyph marks an activity by kind
+func activityGlyph(kind domain.ActivityKind) string {
+	switch kind {
+	case domain.ActivityScan:
+		return "⟳"
+	case domain.ActivityRefresh:
+		return "↻"
+	case domain.ActivityTranscode:
+		return "⇄"
+	default:
+		return "•"
+	}
+}
+
+// activityProgress is an activity's percent done, or the spinner when the
+// server does not say
+func (m Model) activityProgress(a domain.Activity) string {
+	if a.Progress < 0 {
+		return RenderSpinner(m.SpinnerFrame)
+	}
+	return fmt.Sprintf("%3d%%", a.Progress)
+}
+
+// renderActivitySegment is the footer's summary of server activity: the
+// first task and how many more; empty while the server is idle
+func (m Model) renderActivitySegment() string {
+	if len(m.activities) == 0 {
+		return ""
+	}
+	a := m.activities[0]
+	text := activityGlyph(a.Kind) + " " + styles.Truncate(a.Title, 24)
+	if a.Progress >= 0 {
+		text += fmt.Sprintf(" %d%%", a.Progress)
+	}
+	if n := len(m.activities) - 1; n > 0 {
+		text += fmt.Sprintf(" +%d", n)
+	}
+	return styles.DimStyle.Render(text + " · A")
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/library"
+)
+
+// Running server tasks show in the footer; a scan that drops out of the
+// list is announced as finished
+func TestServerActivity(t *testing.T) {
+	server := &fakeServer{activities: []domain.Activity{
+		{ID: "a1", Kind: domain.ActivityScan, Title: "Scanning Movies", Progress: 45},
+		{ID: "a2", Kind: domain.ActivityRefresh, Title: "Refreshing metadata", Progress: -1},
+	}}
+	m := Model{ColumnStack: NewColumnStack(), LibraryService: library.NewService(server, nil, nil), jobs: NewJobs()}
+	m.Width = 160
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	m = updated.(Model)
+	if !m.activitiesOpen || cmd == nil {
+		t.Fatal("A did not open the panel and poll")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(m.activities) != 2 {
+		t.Fatalf("activities = %+v", m.activities)
+	}
+	if footer := m.renderFooter(); !strings.Contains(footer, "Scanning Movies 45% +1") {
+		t.Errorf("footer lacks the scan: %q", footer)
+	}
+
+	server.activities = server.activities[1:]
+	updated, _ = m.Update(FetchActivitiesCmd(m.LibraryService, m.activityGen)())
+	m = updated.(Model)
+	if len(m.activities) != 1 || m.notice.Text != i18n.T("status.scan_finished", "Scanning Movies") {
+		t.Fatalf("after the scan: %d running, notice %q", len(m.activities), m.notice.Text)
+	}
+
+	updated, _ = m.Update(ActivitiesLoadedMsg{Gen: m.activityGen - 1})
+	m = updated.(Model)
+	if len(m.activities) != 1 {
+		t.Fatal("a superseded poll replaced the list")
+	}
+}
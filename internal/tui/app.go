@@ -4,22 +4,23 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/arr"
 	"github.com/mmcdole/kino/internal/config"
 	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
 	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/mediaserver"
+	"github.com/mmcdole/kino/internal/mediaserver/httpclient"
 	"github.com/mmcdole/kino/internal/player"
 	"github.com/mmcdole/kino/internal/playlist"
 	"github.com/mmcdole/kino/internal/search"
 	"github.com/mmcdole/kino/internal/tui/components"
 )
 
-// authFailedStatusMsg tells the user how to recover from a revoked/expired
-// token. Shown persistently (not auto-cleared) since action is required.
-const authFailedStatusMsg = "Session expired or revoked — press L to log out, then run kino to sign in again"
-
 // ApplicationState represents the current state of the application
 type ApplicationState int
 
@@ -28,6 +29,11 @@ const (
 	StateHelp
 	StateConfirmLogout
 	StateConfirmDeletePlaylist
+	StateConfirmDeleteItem // Typed confirmation before deleting media (see delete.go)
+	StateConfirmNextEpisode
+	StateKeyConflicts // Startup report of ambiguous key bindings
+	StateLocked       // Inactivity lock; PIN required to resume
+	StateAuthRequired // Token rejected; signing in again (see reauth.go)
 )
 
 // Layout proportions for Miller Columns
@@ -53,6 +59,11 @@ const (
 
 	// Synthetic library entry for playlists
 	playlistsLibraryID = "__playlists__"
+	liveTVLibraryID    = "__livetv__"
+	mergedLibraryID    = "__merged_movies__"
+	calendarLibraryID  = "__calendar__"
+	watchlistLibraryID = "__watchlist__"
+	continueLibraryID  = "__continue__"
 )
 
 // playlistsLibraryEntry returns the synthetic library entry for playlists
@@ -64,9 +75,101 @@ func playlistsLibraryEntry() domain.Library {
 	}
 }
 
-// allLibraryEntries returns libraries plus the synthetic Playlists entry
+// liveTVLibraryEntry returns the synthetic library entry for Live TV
+func liveTVLibraryEntry() domain.Library {
+	return domain.Library{
+		ID:   liveTVLibraryID,
+		Name: "Live TV",
+		Type: "livetv",
+	}
+}
+
+// isSyntheticLibrary reports whether a library ID is one of kino's virtual
+// entries rather than a server library
+func isSyntheticLibrary(id string) bool {
+	return id == playlistsLibraryID || id == liveTVLibraryID || id == mergedLibraryID ||
+		id == calendarLibraryID || id == watchlistLibraryID || id == continueLibraryID
+}
+
+// continueLibraryEntry returns the synthetic library entry for Continue
+// Watching
+func continueLibraryEntry() domain.Library {
+	return domain.Library{
+		ID:   continueLibraryID,
+		Name: "Continue Watching",
+		Type: "continue",
+	}
+}
+
+// calendarLibraryEntry returns the synthetic library entry for the calendar
+func calendarLibraryEntry() domain.Library {
+	return domain.Library{
+		ID:   calendarLibraryID,
+		Name: "Calendar",
+		Type: "calendar",
+	}
+}
+
+// watchlistLibraryEntry returns the synthetic library entry for the
+// account's watchlist
+func watchlistLibraryEntry() domain.Library {
+	return domain.Library{
+		ID:   watchlistLibraryID,
+		Name: "Watchlist",
+		Type: "watchlist",
+	}
+}
+
+// mergedLibraryEntry returns the synthetic merged-movies library entry
+func (m *Model) mergedLibraryEntry() domain.Library {
+	name := m.UIConfig.MergedMovies.Name
+	if name == "" {
+		name = "All Movies"
+	}
+	return domain.Library{
+		ID:   mergedLibraryID,
+		Name: name,
+		Type: "movie",
+	}
+}
+
+// mergedMembers resolves the configured merged-movies libraries (by name or
+// ID) against the server's movie libraries, keeping the configured order
+func (m *Model) mergedMembers() []domain.Library {
+	var members []domain.Library
+	for _, want := range m.UIConfig.MergedMovies.Libraries {
+		for _, lib := range m.Libraries {
+			if lib.Type == "movie" && (lib.ID == want || strings.EqualFold(lib.Name, want)) {
+				members = append(members, lib)
+				break
+			}
+		}
+	}
+	return members
+}
+
+// allLibraryEntries returns libraries plus the synthetic entries: the
+// merged movie library (when configured), Continue Watching, the calendar,
+// the watchlist (Plex), Live TV (only when the server has tuners) and
+// Playlists
 func (m *Model) allLibraryEntries() []domain.Library {
-	return append(m.Libraries, playlistsLibraryEntry())
+	entries := append([]domain.Library{}, m.Libraries...)
+	if len(m.mergedMembers()) > 0 {
+		entries = append(entries, m.mergedLibraryEntry())
+	}
+	if m.LibraryService != nil && m.LibraryService.HasContinueWatching() {
+		entries = append(entries, continueLibraryEntry())
+	}
+	if m.LibraryService != nil && m.LibraryService.HasCalendar() {
+		entries = append(entries, calendarLibraryEntry())
+	}
+	if m.LibraryService != nil && m.LibraryService.HasWatchlist() {
+		entries = append(entries, watchlistLibraryEntry())
+	}
+	if m.liveTVAvailable {
+		entries = append(entries, liveTVLibraryEntry())
+	}
+	return append(entries, playlistsLibraryEntry())
 }
 
 // Model is the main Bubble Tea model for the application
@@ -85,17 +188,31 @@ type Model struct {
 	// Other services
 	SearchSvc   *search.Service
 	PlaybackSvc *player.Service
+	ArrSvc      *arr.Service // Optional Sonarr/Radarr; nil when not configured
 
 	// UI Components - Miller Columns
-	ColumnStack   *ColumnStack             // Stack of navigable list columns
-	Inspector     components.Inspector     // View projection (always shows details for middle column selection)
-	GlobalSearch  components.GlobalSearch  // Search modal
-	SortModal     components.SortModal     // Sort field selector
-	PlaylistModal components.PlaylistModal // Playlist management modal
-	InputModal    components.InputModal    // Simple text input modal
+	ColumnStack       *ColumnStack             // Stack of navigable list columns
+	Inspector         components.Inspector     // View projection (always shows details for middle column selection)
+	GlobalSearch      components.GlobalSearch  // Search modal
+	SortModal         components.SortModal     // Sort field selector
+	ResumeModal       components.ResumeModal   // Resume / start over prompt
+	VersionModal      components.VersionModal  // Which file to play, for items with several
+	RatingModal       components.RatingModal   // The user's 1-10 rating of an item
+	PlaylistModal     components.PlaylistModal // Playlist management modal
+	InputModal        components.InputModal    // Simple text input modal
+	newPlaylistIDs    []string                 // Items the playlist named in InputModal starts with
+	PlaylistEditModal components.PlaylistEditModal
+	LibraryModal      components.LibraryModal // Show/hide libraries
 
 	// Data
-	Libraries []domain.Library
+	Libraries       []domain.Library // Libraries shown: the server's minus the hidden ones
+	serverLibraries []domain.Library // Every library on the server
+
+	// Library sync scheduling (see syncqueue.go)
+	syncLimit   int            // Library syncs run at once; 0 = no limit
+	syncQueue   []queuedSync   // Waiting syncs, next first
+	syncRunning map[int]string // Running library syncs: job ID -> library ID
+	playlistJob int            // Running playlist sync job ID; 0 when none
 
 	// Dimensions
 	Width  int
@@ -105,17 +222,127 @@ type Model struct {
 	SpinnerFrame  int
 	ShowInspector bool // Toggle inspector visibility (default true)
 
+	// Peek mode: the inspector lists the selection's children (see peek.go)
+	peeking             bool
+	peekOpenedInspector bool // Inspector was hidden before the peek
+
+	// Inspector details (see details.go): the item whose full metadata is
+	// wanted, and a generation bumped on every selection change so a dwell
+	// timer for an item the user moved past is dropped
+	detailsItemID string
+	detailsGen    int
+
 	// Footer notification (single slot; see notice.go for the rules)
 	notice    Notice
 	noticeSeq int
 
+	// Items the latest sync added (see newitems.go)
+	newItems newItems
+
+	// Server reachability for the footer and reconnects (see health.go)
+	health serverHealth
+
+	// The server's change notifications (see liveupdates.go)
+	live liveUpdates
+
+	// The mpv playing in the footer strip (see nowplaying.go); nil = none
+	nowPlaying *nowPlaying
+
+	// The device playback was sent to (see cast.go); nil = none
+	casting *casting
+
 	// Sync state
 	LibraryStates map[string]components.LibrarySyncState // Tracks progress per library
 	SyncGen       int                                    // Current sync generation; messages from older generations are dropped
 
+	// Background jobs (see jobs.go)
+	jobs          *Jobs
+	jobsPanelOpen bool
+	jobsCursor    int
+	titleJobCount int // Running-job count last written to the window title
+
+	// Tasks running on the server itself, polled (see activities.go)
+	activities     []domain.Activity
+	activityGen    int
+	activitiesOpen bool
+
+	// The command line (:) while open, and what has been typed
+	cmdOpen bool
+	cmdLine string
+
+	// Remote mode's context menu, nil while closed (see remote.go)
+	menu       []menuEntry
+	menuCursor int
+
+	// Global search generation: bumped on every keystroke so matching for
+	// an older query stops at its next chunk
+	searchGen int
+
+	// The episode an episode-code query ("wire s03e05") resolved to, pinned
+	// above the matches for the generation it was resolved in
+	episodeHit    *search.FilterResult
+	episodeHitGen int
+
+	// API request log and whether its overlay is open (D)
+	requestTrace *httpclient.Tracer
+	debugOpen    bool
+
 	// Navigation plan for deep linking
 	navPlan *NavPlan
 
+	// Deep-link target to open once libraries load (kino --goto)
+	startAt *library.Resolved
+
+	// session is a saved session to restore once libraries load
+	session *config.Session
+
+	// Split view (see split.go); nil = one pane
+	split *splitView
+
+	// Home screen (see home.go); nil = closed. homeGen drops rows loaded
+	// for an earlier opening.
+	home    *homeView
+	homeGen int
+
+	// Full-screen detail view (see detailview.go); nil = closed
+	detail *detailView
+
+	// peopleFor is the item whose cast & crew menu (see people.go) opens
+	// once its details load
+	peopleFor string
+
+	// Locations saved to the number keys (see bookmarks.go); pending is
+	// set between m and the number
+	bookmarks        map[int]config.Bookmark
+	bookmarksChanged bool
+	bookmarkPending  bool
+
+	// Ambiguous key bindings found (and disabled) at startup
+	keyConflicts []KeyConflict
+
+	// Inactivity lock (see lock.go); lockTimeout 0 = disabled
+	lockTimeout  time.Duration
+	lockPINHash  string
+	lastActivity time.Time
+	lockedFrom   ApplicationState // State to return to on unlock
+	pinEntry     string
+	pinRejected  bool
+
+	// Signing in again after the server rejects the token (see reauth.go);
+	// reauth nil = tell the user to log out instead
+	reauth *mediaserver.Reauth
+	auth   authPrompt
+
+	// Previous left click, for double-click detection (see mouse.go)
+	click lastClick
+
+	// Set once the server reports Live TV with a configured tuner
+	liveTVAvailable bool
+
+	// The watchlist as last loaded, for toggling titles outside its column
+	// (see watchlist.go)
+	watchlist []*domain.WatchlistItem
+
 	// Playlist navigation context (when viewing playlist items)
 	currentPlaylistID string
 
@@ -123,6 +350,33 @@ type Model struct {
 	pendingDeletePlaylistID   string
 	pendingDeletePlaylistName string
 
+	// Item awaiting deletion from the server, and the confirmation typed
+	// so far
+	pendingDeleteItem domain.ListItem
+	deleteEntry       string
+
+	// Next episode awaiting confirmation, and what to do when an episode
+	// plays to its end (config.NextEpisode*)
+	pendingNextEpisode *domain.MediaItem
+	nextEpisodeMode    string
+
+	// Startup sync mode (config.StartupSync*, see startup.go). quiet holds
+	// cache-only's network silence until the first refresh-all; lazySynced
+	// are the libraries lazy mode synced on opening.
+	startupSync string
+	quiet       bool
+	lazySynced  map[string]bool
+
+	// Background re-sync intervals (see autorefresh.go); autoRefreshBy
+	// overrides autoRefresh per library name or ID
+	autoRefresh    time.Duration
+	autoRefreshBy  map[string]time.Duration
+	autoRefreshGen int
+
+	// loggedOut is set once logout cleared the server config; the caller
+	// clears the cache after the store closes (see LoggedOut)
+	loggedOut bool
+
 	// Navigation context for hierarchical cache keys (cascade invalidation)
 	currentLibID  string // Set when entering a library
 	currentShowID string // Set when entering a show
@@ -138,31 +392,60 @@ func NewModel(
 	playlistSvc *playlist.Service,
 	searchSvc *search.Service,
 	playbackSvc *player.Service,
+	arrSvc *arr.Service,
 	uiConfig config.UIConfig,
 ) Model {
-	return Model{
-		State:           StateBrowsing,
-		Store:           store,
-		LibraryService:  librarySvc,
-		PlaylistService: playlistSvc,
-		SearchSvc:       searchSvc,
-		PlaybackSvc:     playbackSvc,
-		ColumnStack:     NewColumnStack(),
-		Inspector:       components.NewInspector(),
-		GlobalSearch:    components.NewGlobalSearch(),
-		PlaylistModal:   components.NewPlaylistModal(),
-		InputModal:      components.NewInputModal(),
-		LibraryStates:   make(map[string]components.LibrarySyncState),
-		ShowInspector:   false, // Inspector hidden by default - show 3 nav columns
-		UIConfig:        uiConfig,
+	m := Model{
+		State:             StateBrowsing,
+		Store:             store,
+		LibraryService:    librarySvc,
+		PlaylistService:   playlistSvc,
+		SearchSvc:         searchSvc,
+		PlaybackSvc:       playbackSvc,
+		ArrSvc:            arrSvc,
+		ColumnStack:       NewColumnStack(),
+		Inspector:         components.NewInspector(),
+		GlobalSearch:      components.NewGlobalSearch(),
+		PlaylistModal:     components.NewPlaylistModal(),
+		LibraryModal:      components.NewLibraryModal(),
+		InputModal:        components.NewInputModal(),
+		PlaylistEditModal: components.NewPlaylistEditModal(),
+		LibraryStates:     make(map[string]components.LibrarySyncState),
+		jobs:              NewJobs(),
+		ShowInspector:     false, // Inspector hidden by default - show 3 nav columns
+		UIConfig:          uiConfig,
 	}
+
+	// Refuse ambiguous bindings up front rather than letting dispatch order
+	// decide which action a key runs
+	if m.keyConflicts = validateKeyBindings(); len(m.keyConflicts) > 0 {
+		m.State = StateKeyConflicts
+	}
+	return m
+}
+
+// SetStartAt opens the TUI navigated to a resolved deep link
+func (m *Model) SetStartAt(res *library.Resolved) {
+	m.startAt = res
 }
 
 // Init initializes the application
 func (m Model) Init() tea.Cmd {
+	if m.quiet {
+		// Cache-only startup: nothing goes to the server until a refresh
+		return tea.Batch(
+			LoadCachedLibrariesCmd(m.Store, m.LibraryService),
+			TickCmd(100*time.Millisecond),
+			AttachNowPlayingCmd(m.PlaybackSvc, true),
+			WatchConfigCmd(),
+		)
+	}
 	return tea.Batch(
 		LoadLibrariesCmd(m.LibraryService),
 		TickCmd(100*time.Millisecond),
+		m.serverWatchCmds(),
+		AttachNowPlayingCmd(m.PlaybackSvc, true),
+		WatchConfigCmd(),
 	)
 }
 
@@ -178,35 +461,128 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateLayout()
 		return m, nil
 
-	case tea.KeyMsg:
-		return m.handleKeyMsg(msg)
+	case tea.KeyMsg, tea.MouseMsg:
+		var next tea.Model
+		var cmd tea.Cmd
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			next, cmd = m.handleKeyMsg(keyMsg)
+		} else {
+			next, cmd = m.handleMouseMsg(msg.(tea.MouseMsg))
+		}
+		nm, ok := next.(Model)
+		if !ok {
+			return next, cmd
+		}
+		// Prefetch, peek, the forward column and the sync queue follow the
+		// selection as the user moves around
+		nm.prefetchSelection()
+		nm.prioritizeSelectedSync()
+		forwardCmd := nm.syncForward()
+		detailsCmd := nm.scheduleDetails()
+		if nm.peeking {
+			peekCmd := nm.refreshPeek()
+			return nm, tea.Batch(cmd, forwardCmd, detailsCmd, peekCmd)
+		}
+		return nm, tea.Batch(cmd, forwardCmd, detailsCmd)
+
+	case PeekLoadedMsg:
+		return m.handlePeekLoaded(msg)
+
+	case DetailsDwellMsg:
+		if msg.Gen != m.detailsGen {
+			return m, nil
+		}
+		return m, DetailsCmd(m.LibraryService, msg.ItemID)
+
+	case DetailsLoadedMsg:
+		if msg.Err == nil && msg.ItemID == m.detailsItemID {
+			m.Inspector.SetDetails(msg.ItemID, msg.Details)
+		}
+		if msg.Err == nil && m.detail != nil && msg.ItemID == m.detail.item.GetID() {
+			m.detail.details = msg.Details
+		}
+		if msg.ItemID != "" && msg.ItemID == m.peopleFor {
+			m.peopleFor = ""
+			if msg.Err != nil {
+				return m, m.notify(NoticeError, i18n.T("status.people_failed", msg.Err))
+			}
+			return m.openPeopleMenu(msg.Details)
+		}
+		return m, nil
+
+	case PersonItemsLoadedMsg:
+		return m.handlePersonItemsLoaded(msg)
+
+	case SimilarLoadedMsg:
+		return m.handleSimilarLoaded(msg)
+
+	case HealthTickMsg:
+		if msg.Gen != m.health.gen {
+			return m, nil
+		}
+		return m, HealthCheckCmd(m.LibraryService, msg.Gen)
+
+	case HealthCheckedMsg:
+		return m.handleHealthChecked(msg)
+
+	case ActivityTickMsg:
+		return m.handleActivityTick(msg)
+
+	case LiveUpdatesOpenedMsg:
+		return m.handleLiveUpdatesOpened(msg)
+
+	case LiveUpdateMsg:
+		return m.handleLiveUpdate(msg)
+
+	case LiveSettledMsg:
+		return m.handleLiveSettled(msg)
+
+	case LiveRetryMsg:
+		return m.handleLiveRetry(msg)
+
+	case ConfigCheckedMsg:
+		return m.handleConfigChecked(msg)
+
+	case ActivitiesLoadedMsg:
+		return m.handleActivitiesLoaded(msg)
+
+	case HomeRowLoadedMsg:
+		return m.handleHomeRowLoaded(msg)
 
 	case TickMsg:
 		m.SpinnerFrame++
 		// Always propagate spinner frame - columns render spinner only when their loading flag is true
 		m.ColumnStack.UpdateSpinnerFrame(m.SpinnerFrame)
-		return m, TickCmd(100 * time.Millisecond)
+		if m.split != nil {
+			m.split.other.stack.UpdateSpinnerFrame(m.SpinnerFrame)
+		}
+		m.checkIdleLock(time.Now())
+		return m, tea.Batch(TickCmd(100*time.Millisecond), m.syncWindowTitle())
 
 	case LibrariesLoadedMsg:
-		m.Libraries = msg.Libraries
+		m.serverLibraries = msg.Libraries
+		m.Libraries = m.visibleLibraries(msg.Libraries)
 
 		// New sync generation: any still-running chains from before this
-		// reload are stale and their messages will be dropped
+		// reload are stale and their messages will be dropped, so stop them
 		m.SyncGen++
+		m.jobs.CancelKind(JobSync)
+		m.resetSyncQueue()
 
-		// Initialize all states to Syncing (including playlists)
+		// Fast path: libraries whose cache timestamp still matches show as
+		// synced from disk right away; only the others queue a full sync,
+		// the selected library first. Hidden libraries aren't synced at all.
 		m.LibraryStates = make(map[string]components.LibrarySyncState)
-		for _, lib := range msg.Libraries {
-			m.LibraryStates[lib.ID] = components.LibrarySyncState{Status: components.StatusSyncing}
+		var openLibID string
+		if m.startAt != nil {
+			openLibID = m.startAt.Library.ID
+		} else if m.session != nil {
+			openLibID = m.session.LibraryID
 		}
-		m.LibraryStates[playlistsLibraryID] = components.LibrarySyncState{Status: components.StatusSyncing}
+		syncCmds := m.beginStartupSyncs(libraryFirst(m.Libraries, openLibID), msg.Refresh)
+		syncCmds = append(syncCmds, m.scheduleAutoRefresh(m.Libraries)...)
 		m.Inspector.SetLibraryStates(m.LibraryStates)
 
-		syncCmds := []tea.Cmd{
-			SyncAllLibrariesCmd(m.LibraryService, msg.Libraries, m.SyncGen),
-			SyncPlaylistsCmd(m.PlaylistService, playlistsLibraryID, m.SyncGen),
-		}
-
 		// Refresh-all with the user somewhere deeper: keep their position.
 		// Update the root column in place and reload the top column's
 		// content in the background instead of resetting to the root.
@@ -224,10 +600,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// The library the user is inside may have been removed
 			// server-side — that's the one case where resetting is the
 			// only sane answer
-			if drilledID != "" && drilledID != playlistsLibraryID && m.findLibrary(drilledID) == nil {
+			if drilledID != "" && !isSyntheticLibrary(drilledID) && m.findLibrary(drilledID) == nil {
 				// Alert: it explains why navigation just reset; stays until
 				// the user dismisses it with Esc
-				m.notify(NoticeAlert, "Library no longer exists on server — navigation reset")
+				m.notify(NoticeAlert, i18n.T("status.library_gone"))
 			} else {
 				if reload := m.reloadTopColumnCmd(); reload != nil {
 					syncCmds = append(syncCmds, reload)
@@ -236,13 +612,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// Initial load (or unrecoverable refresh): build the root column
+		// Initial load (or unrecoverable refresh): build the root column.
+		// A refresh keeps the selected library, or the row it was on if
+		// the library is gone.
+		var selectedID string
+		var selectedIdx int
+		if root := m.libraryColumn(); msg.Refresh && root != nil {
+			if sel := root.SelectedLibrary(); sel != nil {
+				selectedID = sel.ID
+			}
+			selectedIdx = root.SelectedIndex()
+		}
 		libCol := components.NewLibraryColumn(m.allLibraryEntries())
 		libCol.SetLibraryStates(m.LibraryStates)
 		libCol.SetShowWatchStatus(m.UIConfig.ShowWatchStatus)
 		libCol.SetShowLibraryCounts(m.UIConfig.ShowLibraryCounts)
+		if !libCol.SetSelectedByID(selectedID) {
+			libCol.SetSelectedIndex(selectedIdx)
+		}
 		m.ColumnStack.Reset(libCol)
 
+		if m.UIConfig.Home && !msg.Refresh && m.startAt == nil {
+			syncCmds = append(syncCmds, m.openHome())
+		}
+		if m.startAt != nil {
+			syncCmds = append(syncCmds, m.navigateToResolved(m.startAt))
+			m.startAt = nil
+			m.session = nil
+		} else if m.session != nil {
+			syncCmds = append(syncCmds, m.restoreSession(m.session))
+			m.session = nil
+		}
+
 		return m, tea.Batch(syncCmds...)
 
 	case MoviesLoadedMsg:
@@ -256,14 +657,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Validate content ID to prevent race condition
-		if !m.validateContentID(msg.LibraryID) {
+		col := m.loadTarget(msg.LibraryID)
+		if col == nil {
 			return m, nil
 		}
 
-		// Update top column with movies
-		if top := m.ColumnStack.Top(); top != nil {
-			top.ReplaceItems(msg.Movies)
-		}
+		// Update the column with movies
+		col.ReplaceItems(msg.Movies)
 
 		m.updateInspector()
 
@@ -284,14 +684,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Validate content ID to prevent race condition
-		if !m.validateContentID(msg.LibraryID) {
+		col := m.loadTarget(msg.LibraryID)
+		if col == nil {
 			return m, nil
 		}
 
-		// Update top column with shows
-		if top := m.ColumnStack.Top(); top != nil {
-			top.ReplaceItems(msg.Shows)
-		}
+		// Update the column with shows
+		col.ReplaceItems(msg.Shows)
 
 		m.updateInspector()
 
@@ -312,14 +711,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Validate content ID to prevent race condition
-		if !m.validateContentID(msg.LibraryID) {
+		col := m.loadTarget(msg.LibraryID)
+		if col == nil {
 			return m, nil
 		}
 
-		// Update top column with mixed content
-		if top := m.ColumnStack.Top(); top != nil {
-			top.ReplaceItems(msg.Items)
-		}
+		// Update the column with mixed content
+		col.ReplaceItems(msg.Items)
 
 		m.updateInspector()
 
@@ -332,14 +730,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case SeasonsLoadedMsg:
 
 		// Validate content ID to prevent race condition
-		if !m.validateContentID(msg.ShowID) {
+		col := m.loadTarget(msg.ShowID)
+		if col == nil {
 			return m, nil
 		}
 
-		// Update top column with seasons
-		if top := m.ColumnStack.Top(); top != nil {
-			top.ReplaceItems(msg.Seasons)
-		}
+		// Update the column with seasons
+		col.ReplaceItems(m.arrangeShowSeasons(msg.ShowID, msg.Seasons))
 
 		m.updateInspector()
 
@@ -352,14 +749,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case EpisodesLoadedMsg:
 
 		// Validate content ID to prevent race condition
-		if !m.validateContentID(msg.SeasonID) {
+		col := m.loadTarget(msg.SeasonID)
+		if col == nil {
 			return m, nil
 		}
 
-		// Update top column with episodes
-		if top := m.ColumnStack.Top(); top != nil {
-			top.ReplaceItems(msg.Episodes)
-		}
+		// Update the column with episodes
+		col.ReplaceItems(msg.Episodes)
 
 		m.updateInspector()
 
@@ -370,32 +766,171 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case PlaybackStartedMsg:
-		return m, m.notify(NoticeSuccess, "Launched: "+msg.Item.Title)
+		var attach tea.Cmd
+		if msg.Ended != nil {
+			attach = AttachNowPlayingCmd(m.PlaybackSvc, false)
+		}
+		return m, tea.Batch(
+			m.notify(NoticeSuccess, i18n.T("status.launched", msg.Item.Title)),
+			m.watchPlayback(msg),
+			attach,
+		)
+
+	case NowPlayingAttachedMsg:
+		return m.handleNowPlayingAttached(msg)
+
+	case NowPlayingStatusMsg:
+		return m.handleNowPlayingStatus(msg)
+
+	case CastTargetsLoadedMsg:
+		return m.handleCastTargetsLoaded(msg)
+
+	case CastStartedMsg:
+		return m.handleCastStarted(msg)
+
+	case PlaybackEndedMsg:
+		return m.handlePlaybackEnded(msg)
+
+	case NextEpisodeMsg:
+		return m.handleNextEpisode(msg)
+
+	case AuthLinkMsg:
+		return m.handleAuthLink(msg)
+
+	case ReauthDoneMsg:
+		return m.handleReauthDone(msg)
 
 	case MarkWatchedMsg:
 		m.applyWatchState(msg.ItemID, true)
-		return m, m.notify(NoticeSuccess, "Marked watched: "+msg.Title)
+		return m, m.notify(NoticeSuccess, i18n.T("status.marked_watched", msg.Title))
+
+	case LinkResolvedMsg:
+		return m, m.navigateToResolved(msg.Res)
+
+	case ItemDeletedMsg:
+		return m.handleItemDeleted(msg)
+
+	case ItemRatedMsg:
+		m.applyUserRating(msg.ItemID, msg.Rating)
+		if msg.Rating == 0 {
+			return m, m.notify(NoticeSuccess, i18n.T("status.rating_cleared", msg.Title))
+		}
+		return m, m.notify(NoticeSuccess, i18n.T("status.rated", msg.Title, msg.Rating))
 
 	case MarkUnwatchedMsg:
 		m.applyWatchState(msg.ItemID, false)
-		return m, m.notify(NoticeSuccess, "Marked unwatched: "+msg.Title)
+		return m, m.notify(NoticeSuccess, i18n.T("status.marked_unwatched", msg.Title))
+
+	case BatchWatchStateMsg:
+		for _, id := range msg.ItemIDs {
+			m.applyWatchState(id, msg.Played)
+		}
+		state := "watched"
+		if !msg.Played {
+			state = "unwatched"
+		}
+		if m.jobs.Cancelled(msg.JobID) {
+			return m, m.notify(NoticeInfo, i18n.T("status.batch_cancelled_"+state, len(msg.ItemIDs)))
+		}
+		m.jobs.Finish(msg.JobID, msg.Err)
+		if msg.Failed > 0 {
+			if errors.Is(msg.Err, domain.ErrAuthFailed) {
+				return m, m.authFailed(nil)
+			}
+			return m, m.notify(NoticeError, i18n.T("status.batch_failed_"+state, len(msg.ItemIDs), msg.Failed, msg.Err))
+		}
+		return m, m.notify(NoticeSuccess, i18n.T("status.batch_marked_"+state, len(msg.ItemIDs)))
+
+	case QueueStartedMsg:
+		return m, m.notify(NoticeSuccess, i18n.T("status.queue_launched", msg.Count))
 
 	case ErrMsg:
 		m.clearNavPlan()
 		// A failed refresh must not leave the column spinner running, and a
-		// failed initial load must show a retry hint, not spin forever
-		if top := m.ColumnStack.Top(); top != nil {
+		// failed initial load must show a retry hint, not spin forever. Load
+		// errors carry their content ID so the error lands on the column it
+		// belongs to, even if the user has drilled elsewhere since.
+		if msg.ContentID != "" {
+			if col := m.ColumnStack.FindByContentID(msg.ContentID); col != nil {
+				col.SetRefreshing(false)
+				if col.IsLoading() || col.ItemCount() == 0 {
+					col.SetLoadFailed(msg.What, describeError(msg.Err).Summary)
+				}
+			}
+		} else if top := m.ColumnStack.Top(); top != nil {
 			top.SetRefreshing(false)
 			if top.IsLoading() {
-				top.SetLoadFailed()
+				top.SetLoadFailed("", describeError(msg.Err).Summary)
 			}
 		}
 		if errors.Is(msg.Err, domain.ErrAuthFailed) {
 			// The token was revoked/expired and the user must re-authenticate
-			m.notify(NoticeAlert, authFailedStatusMsg)
+			return m, m.authFailed(msg.Retry)
+		}
+		if errors.Is(msg.Err, domain.ErrPrivateSession) {
+			return m, m.notify(NoticeInfo, i18n.T("status.private_unreported"))
+		}
+		if errors.Is(msg.Err, domain.ErrServerOffline) {
+			if msg.Retry == nil {
+				return m, tea.Batch(m.markOffline(), m.notifyError(msg.Context, msg.Err))
+			}
+			// Rerun by the reconnect (see health.go)
+			m.queueOfflineRetry(msg.Retry)
+			notice := m.notifyHint(NoticeError, msg.Context+": "+describeError(msg.Err).Summary, i18n.T("hint.retrying"))
+			return m, tea.Batch(m.markOffline(), notice)
+		}
+		return m, m.notifyError(msg.Context, msg.Err)
+
+	case SearchDebounceMsg:
+		if msg.Gen != m.searchGen || !m.GlobalSearch.IsVisible() {
+			return m, nil
+		}
+		cmds := []tea.Cmd{SearchCmd(m.SearchSvc, m.GlobalSearch.Query(), m.Libraries, msg.Gen)}
+		if link, ok := library.ParseEpisodeQuery(m.GlobalSearch.Query()); ok {
+			cmds = append(cmds, EpisodeQueryCmd(m.LibraryService, m.Libraries, link, msg.Gen))
+		}
+		return m, tea.Batch(cmds...)
+
+	case EpisodeQueryMsg:
+		if msg.Gen != m.searchGen || !m.GlobalSearch.IsVisible() {
+			return m, nil
+		}
+		m.episodeHit, m.episodeHitGen = &msg.Result, msg.Gen
+		m.GlobalSearch.UpdateResults(m.withEpisodeHit(m.GlobalSearch.Results(), msg.Gen))
+		return m, nil
+
+	case SearchResultsMsg:
+		if msg.Gen != m.searchGen || !m.GlobalSearch.IsVisible() {
+			return m, nil // Superseded: matching stops here
+		}
+		if msg.First {
+			m.GlobalSearch.SetResults(m.withEpisodeHit(msg.Results, msg.Gen))
+		} else {
+			m.GlobalSearch.UpdateResults(m.withEpisodeHit(msg.Results, msg.Gen))
+		}
+		m.GlobalSearch.SetSearching(!msg.Done)
+		if msg.Done {
 			return m, nil
 		}
-		return m, m.notify(NoticeError, msg.Error())
+		return m, SearchStepCmd(msg.Matcher, msg.Gen)
+
+	case ArrLookupMsg:
+		if !m.GlobalSearch.IsVisible() || msg.Query != m.GlobalSearch.Query() {
+			return m, nil // the user moved on; results are for a stale query
+		}
+		if msg.Err != nil {
+			m.GlobalSearch.SetExternal(nil)
+			return m, m.notify(NoticeError, i18n.T("status.arr_lookup_failed", msg.Err))
+		}
+		m.GlobalSearch.SetExternal(m.notInLibrary(msg.Results))
+		return m, nil
+
+	case ArrAddedMsg:
+		if msg.Err != nil {
+			return m, m.notify(NoticeError, i18n.T("status.arr_add_failed", msg.Result.Title, msg.Err))
+		}
+		m.GlobalSearch.UpdateExternal(msg.Result)
+		return m, m.notify(NoticeSuccess, i18n.T("status.arr_requested", msg.Result.Title))
 
 	case ClearNoticeMsg:
 		m.expireNotice(msg.Seq)
@@ -411,12 +946,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		state := m.LibraryStates[msg.LibraryID]
 
+		// Cancelled from the jobs panel: settle the row quietly and stop
+		// reading the chain
+		if m.jobs.Cancelled(msg.JobID) {
+			cmd := m.finishSync(msg.JobID)
+			// A restarted sync of the same library owns the row now
+			if !m.syncPending(msg.LibraryID) {
+				state.Status = components.StatusIdle
+				m.LibraryStates[msg.LibraryID] = state
+				m.updateLibraryStates()
+			}
+			return m, cmd
+		}
+
 		if msg.Error != nil {
+			m.jobs.Finish(msg.JobID, msg.Error)
+			cmds = append(cmds, m.finishSync(msg.JobID))
 			state.Status = components.StatusError
 			state.Error = msg.Error
 			slog.Error("library sync failed", "libraryID", msg.LibraryID, "error", msg.Error)
 			if errors.Is(msg.Error, domain.ErrAuthFailed) {
-				m.notify(NoticeAlert, authFailedStatusMsg)
+				cmds = append(cmds, m.authFailed(nil)) // Retried with the other failed syncs
 			} else {
 				// The row's ✗ glyph may be off-screen; name the scope so the
 				// failure is visible wherever the user is
@@ -426,18 +976,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else if msg.LibraryID == playlistsLibraryID {
 					name = "Playlists"
 				}
-				cmds = append(cmds, m.notify(NoticeError, fmt.Sprintf("Sync failed: %s — r to retry", name)))
+				text := fmt.Sprintf("Sync failed: %s — %s", name, describeError(msg.Error).Summary)
+				if errors.Is(msg.Error, domain.ErrServerOffline) {
+					// Restarted by the reconnect (see health.go)
+					cmds = append(cmds, m.markOffline(), m.notifyHint(NoticeError, text, i18n.T("hint.resumes")))
+				} else {
+					cmds = append(cmds, m.notifyHint(NoticeError, text, i18n.T("hint.retry")))
+				}
 			}
 		} else {
 			state.Loaded = msg.Loaded
 			state.Total = msg.Total
 			state.FromCache = msg.FromCache
+			m.jobs.Progress(msg.JobID, msg.Loaded, msg.Total)
 
 			if msg.Done {
 				state.Status = components.StatusSynced
+				if msg.LibraryID != playlistsLibraryID {
+					state.Unwatched, state.HasUnwatched = msg.Unwatched, true
+				}
+				m.jobs.Finish(msg.JobID, nil)
+				cmds = append(cmds, m.finishSync(msg.JobID))
 
 				// Trigger delayed cleanup
 				cmds = append(cmds, ClearLibraryStatusCmd(msg.LibraryID, 2*time.Second))
+				cmds = append(cmds, m.noteNewItems(msg.LibraryID, msg.NewIDs))
+				if !msg.FromCache {
+					m.showSynced(msg.LibraryID)
+				}
 			}
 		}
 
@@ -451,6 +1017,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, tea.Batch(cmds...)
 
+	case LibrariesStaleMsg:
+		if msg.Generation != m.SyncGen {
+			return m, nil
+		}
+		for _, lib := range msg.Libraries {
+			cmds = append(cmds, m.queueSync(lib, true))
+		}
+		if len(cmds) > 0 {
+			m.updateLibraryStates()
+		}
+		return m, tea.Batch(cmds...)
+
+	case AutoRefreshTickMsg:
+		return m.handleAutoRefreshTick(msg)
+
 	case ClearLibraryStatusMsg:
 		if state, ok := m.LibraryStates[msg.LibraryID]; ok {
 			if state.Status == components.StatusSynced {
@@ -461,39 +1042,116 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case LogLevelSavedMsg:
+		return m.handleLogLevelSaved(msg)
+
+	case SortSavedMsg:
+		if msg.Err != nil {
+			return m, m.notify(NoticeError, i18n.T("status.sort_save_failed", msg.Err))
+		}
+		return m, nil
+
+	case SpecialsSavedMsg:
+		if msg.Err != nil {
+			return m, m.notify(NoticeError, i18n.T("status.specials_save_failed", msg.Err))
+		}
+		return m, nil
+
+	case HiddenLibrariesSavedMsg:
+		if msg.Err != nil {
+			return m, m.notify(NoticeError, i18n.T("status.hidden_save_failed", msg.Err))
+		}
+		return m, nil
+
 	case LogoutCompleteMsg:
 		if msg.Error != nil {
 			m.State = StateBrowsing
-			return m, m.notify(NoticeError, fmt.Sprintf("Logout failed: %v", msg.Error))
+			return m, m.notify(NoticeError, i18n.T("status.logout_failed", msg.Error))
 		}
 		// Logout successful - quit the application
-		return m, tea.Quit
+		m.loggedOut = true
+		return m.handleQuit()
+
+	case LiveTVAvailableMsg:
+		if !msg.Available || m.liveTVAvailable {
+			return m, nil
+		}
+		m.liveTVAvailable = true
+		// The capability check races the library load: if the root column
+		// already exists, slot the entry in without disturbing the cursor
+		if libCol := m.libraryColumn(); libCol != nil {
+			libCol.ReplaceItems(m.allLibraryEntries())
+		}
+		return m, nil
+
+	case ChannelsLoadedMsg:
+		col := m.loadTarget(liveTVLibraryID)
+		if col == nil {
+			return m, nil
+		}
+		col.ReplaceItems(msg.Channels)
+		m.updateInspector()
+		return m, nil
+
+	case CalendarLoadedMsg:
+		col := m.loadTarget(calendarLibraryID)
+		if col == nil {
+			return m, nil
+		}
+		firstLoad := col.ItemCount() == 0
+		col.ReplaceItems(msg.Episodes)
+		if firstLoad {
+			col.SetSelectedByID(col.FirstUpcomingID())
+		}
+		m.updateInspector()
+		return m, nil
+
+	case WatchlistLoadedMsg:
+		m.watchlist = msg.Items
+		if col := m.loadTarget(watchlistLibraryID); col != nil {
+			col.ReplaceItems(msg.Items)
+			m.updateInspector()
+		}
+		return m, nil
+
+	case WatchlistChangedMsg:
+		return m.handleWatchlistChanged(msg)
+
+	case ContinueWatchingLoadedMsg:
+		if col := m.loadTarget(continueLibraryID); col != nil {
+			col.ReplaceItems(msg.Items)
+			m.updateInspector()
+		}
+		return m, nil
+
+	case ContinueDismissedMsg:
+		return m.handleContinueDismissed(msg)
 
 	case PlaylistsLoadedMsg:
 
 		// Validate content ID like every other load handler: a slow playlist
 		// fetch must not clobber whatever column the user navigated to since
-		if !m.validateContentID(playlistsLibraryID) {
+		col := m.loadTarget(playlistsLibraryID)
+		if col == nil {
 			return m, nil
 		}
 
-		if top := m.ColumnStack.Top(); top != nil {
-			top.ReplaceItems(msg.Playlists)
-		}
+		col.ReplaceItems(msg.Playlists)
 		m.updateInspector()
 		return m, nil
 
 	case PlaylistItemsLoadedMsg:
 
 		// Validate content ID to prevent race condition
-		if !m.validateContentID(msg.PlaylistID) {
+		col := m.loadTarget(msg.PlaylistID)
+		if col == nil {
 			return m, nil
 		}
 
-		m.currentPlaylistID = msg.PlaylistID
-		if top := m.ColumnStack.Top(); top != nil {
-			top.ReplaceItems(msg.Items)
+		if col == m.ColumnStack.Top() {
+			m.currentPlaylistID = msg.PlaylistID
 		}
+		col.ReplaceItems(msg.Items)
 		m.updateInspector()
 		return m, nil
 
@@ -502,15 +1160,35 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.notice.Kind == NoticeInfo {
 			m.clearNotice()
 		}
-		m.PlaylistModal.Show(msg.Playlists, msg.Membership, msg.Item)
+		if len(msg.Items) > 0 {
+			m.PlaylistModal.ShowBatch(msg.Playlists, msg.Items)
+		} else {
+			m.PlaylistModal.Show(msg.Playlists, msg.Membership, msg.Item)
+		}
 		m.PlaylistModal.SetSize(m.Width, m.Height)
 		return m, nil
 
 	case PlaylistUpdatedMsg:
+		// A batch edit reports once, after its last change lands
+		if msg.JobID != 0 {
+			if !m.jobs.Step(msg.JobID, msg.Error) {
+				return m, nil
+			}
+			if job := m.jobs.Get(msg.JobID); job != nil {
+				if job.Status == JobCancelled {
+					cmds = append(cmds, m.notify(NoticeInfo, i18n.T("status.playlist_edit_cancelled")))
+					if m.currentPlaylistID != "" {
+						cmds = append(cmds, LoadPlaylistItemsCmd(m.PlaylistService, m.currentPlaylistID))
+					}
+					return m, tea.Batch(cmds...)
+				}
+				msg.Error = job.Err
+			}
+		}
 		if msg.Error != nil {
-			return m, m.notify(NoticeError, fmt.Sprintf("Playlist update failed: %v", msg.Error))
+			return m, m.notify(NoticeError, i18n.T("status.playlist_update_failed", msg.Error))
 		}
-		cmds = append(cmds, m.notify(NoticeSuccess, "Playlist updated"))
+		cmds = append(cmds, m.notify(NoticeSuccess, i18n.T("status.playlist_updated")))
 		// Refresh playlist items if viewing a playlist
 		if m.currentPlaylistID != "" {
 			cmds = append(cmds, LoadPlaylistItemsCmd(m.PlaylistService, m.currentPlaylistID))
@@ -519,20 +1197,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case PlaylistCreatedMsg:
 		if msg.Error != nil {
-			return m, m.notify(NoticeError, fmt.Sprintf("Failed to create playlist: %v", msg.Error))
+			return m, m.notify(NoticeError, i18n.T("status.playlist_create_failed", msg.Error))
 		}
-		cmds = append(cmds, m.notify(NoticeSuccess, fmt.Sprintf("Created playlist: %s", msg.Playlist.Title)))
+		cmds = append(cmds, m.notify(NoticeSuccess, i18n.T("status.playlist_created", msg.Playlist.Title)))
 		// Refresh playlists if viewing playlists
 		if top := m.ColumnStack.Top(); top != nil && top.ColumnType() == components.ColumnTypePlaylists {
 			cmds = append(cmds, LoadPlaylistsCmd(m.PlaylistService))
 		}
 		return m, tea.Batch(cmds...)
 
+	case PlaylistEditedMsg:
+		if msg.Error != nil {
+			return m, m.notify(NoticeError, i18n.T("status.playlist_update_failed", msg.Error))
+		}
+		cmds = append(cmds, m.notify(NoticeSuccess, i18n.T("status.playlist_title_updated", msg.Title)))
+		if top := m.ColumnStack.Top(); top != nil && top.ColumnType() == components.ColumnTypePlaylists {
+			cmds = append(cmds, LoadPlaylistsCmd(m.PlaylistService))
+		}
+		return m, tea.Batch(cmds...)
+
+	case PlaylistExportedMsg:
+		if msg.Error != nil {
+			return m, m.notify(NoticeError, i18n.T("status.playlist_export_failed", msg.Error))
+		}
+		return m, m.notify(NoticeSuccess, i18n.T("status.playlist_exported", msg.Count, msg.Path))
+
 	case PlaylistDeletedMsg:
 		if msg.Error != nil {
-			return m, m.notify(NoticeError, fmt.Sprintf("Failed to delete playlist: %v", msg.Error))
+			return m, m.notify(NoticeError, i18n.T("status.playlist_delete_failed", msg.Error))
 		}
-		cmds = append(cmds, m.notify(NoticeSuccess, "Playlist deleted"))
+		cmds = append(cmds, m.notify(NoticeSuccess, i18n.T("status.playlist_deleted")))
 		// Clear current playlist ID and refresh the playlists
 		m.currentPlaylistID = ""
 		cmds = append(cmds, LoadPlaylistsCmd(m.PlaylistService))
@@ -555,28 +1249,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-// activeSyncCount returns how many libraries are currently syncing, for the
-// footer's compact background-activity segment
-func (m Model) activeSyncCount() int {
-	n := 0
-	for _, state := range m.LibraryStates {
-		if state.Status == components.StatusSyncing {
-			n++
-		}
-	}
-	return n
-}
-
 // libraryColumn returns the library column (index 0) or nil if not available
 func (m *Model) libraryColumn() *components.ListColumn {
 	return m.ColumnStack.Get(0)
 }
 
-// validateContentID checks if the top column has the expected content ID.
-// Returns false if the column doesn't match (user navigated away before async load completed).
-func (m *Model) validateContentID(expectedID string) bool {
-	top := m.ColumnStack.Top()
-	return top != nil && top.ContentID() == expectedID
+// loadTarget returns the column an async load for expectedID lands on: the
+// top column, or the forward column while it is shown beside it, in either
+// pane when split. Returns nil if none matches (user navigated away before
+// the load completed).
+func (m *Model) loadTarget(expectedID string) *components.ListColumn {
+	stacks := []*ColumnStack{m.ColumnStack}
+	if m.split != nil {
+		stacks = append(stacks, m.split.other.stack)
+	}
+	for _, cs := range stacks {
+		if top := cs.Top(); top != nil && top.ContentID() == expectedID {
+			return top
+		}
+		if fwd := cs.Forward(); fwd != nil && fwd.ContentID() == expectedID {
+			return fwd
+		}
+	}
+	return nil
 }
 
 // updateLibraryStates updates the library states in the library column and inspector
@@ -584,6 +1279,11 @@ func (m *Model) updateLibraryStates() {
 	if libCol := m.libraryColumn(); libCol != nil {
 		libCol.SetLibraryStates(m.LibraryStates)
 	}
+	if m.split != nil {
+		if libCol := m.split.other.stack.Get(0); libCol != nil {
+			libCol.SetLibraryStates(m.LibraryStates)
+		}
+	}
 	m.Inspector.SetLibraryStates(m.LibraryStates)
 }
 
@@ -601,6 +1301,10 @@ func (m *Model) reloadTopColumnCmd() tea.Cmd {
 
 	switch top.ColumnType() {
 	case components.ColumnTypeMovies:
+		if m.currentLibID == mergedLibraryID {
+			top.SetRefreshing(true)
+			return LoadMergedMoviesCmd(m.LibraryService, m.mergedMembers())
+		}
 		if lib != nil {
 			top.SetRefreshing(true)
 			return LoadMoviesCmd(m.LibraryService, *lib)
@@ -630,6 +1334,18 @@ func (m *Model) reloadTopColumnCmd() tea.Cmd {
 	case components.ColumnTypePlaylists:
 		top.SetRefreshing(true)
 		return LoadPlaylistsCmd(m.PlaylistService)
+	case components.ColumnTypeChannels:
+		top.SetRefreshing(true)
+		return LoadChannelsCmd(m.LibraryService)
+	case components.ColumnTypeCalendar:
+		top.SetRefreshing(true)
+		return LoadCalendarCmd(m.LibraryService)
+	case components.ColumnTypeWatchlist:
+		top.SetRefreshing(true)
+		return LoadWatchlistCmd(m.LibraryService)
+	case components.ColumnTypeContinue:
+		top.SetRefreshing(true)
+		return LoadContinueWatchingCmd(m.LibraryService)
 	case components.ColumnTypePlaylistItems:
 		if m.currentPlaylistID != "" {
 			top.SetRefreshing(true)
@@ -639,6 +1355,16 @@ func (m *Model) reloadTopColumnCmd() tea.Cmd {
 	return nil
 }
 
+// applyUserRating patches an item's rating in the cache and in every
+// visible column, like applyWatchState
+func (m *Model) applyUserRating(itemID string, rating float64) {
+	m.LibraryService.SetUserRating(itemID, rating)
+	for _, col := range m.allColumns() {
+		col.ApplyUserRating(itemID, rating)
+	}
+	m.updateInspector()
+}
+
 // applyWatchState patches an item's watch state in the cache and in every
 // visible column. This replaces the old invalidate-everything-and-refetch
 // approach: the UI updates instantly and no network requests are issued.
@@ -649,12 +1375,10 @@ func (m *Model) applyWatchState(itemID string, played bool) {
 	// counters on visible show/season rows if an episode flipped state.
 	var patched *domain.MediaItem
 	flipped := false
-	for i := 0; i < m.ColumnStack.Len(); i++ {
-		if col := m.ColumnStack.Get(i); col != nil {
-			if item, f := col.ApplyWatchState(itemID, played); item != nil {
-				patched = item
-				flipped = flipped || f
-			}
+	for _, col := range m.allColumns() {
+		if item, f := col.ApplyWatchState(itemID, played); item != nil {
+			patched = item
+			flipped = flipped || f
 		}
 	}
 
@@ -663,10 +1387,8 @@ func (m *Model) applyWatchState(itemID string, played bool) {
 		if played {
 			delta = -1
 		}
-		for i := 0; i < m.ColumnStack.Len(); i++ {
-			if col := m.ColumnStack.Get(i); col != nil {
-				col.AdjustUnwatchedCounts(patched.ShowID, patched.ParentID, delta)
-			}
+		for _, col := range m.allColumns() {
+			col.AdjustUnwatchedCounts(patched.ShowID, patched.ParentID, delta)
 		}
 	}
 
@@ -683,6 +1405,35 @@ func (m Model) findLibrary(id string) *domain.Library {
 	return nil
 }
 
+// notInLibrary drops Sonarr/Radarr results the media server already has,
+// matched on case-insensitive title and year against the cached libraries
+func (m Model) notInLibrary(results []arr.Result) []arr.Result {
+	key := func(title string, year int) string {
+		return strings.ToLower(title) + "|" + fmt.Sprint(year)
+	}
+	have := make(map[string]bool)
+	for _, lib := range m.Libraries {
+		if movies, ok := m.Store.GetMovies(lib.ID); ok {
+			for _, mv := range movies {
+				have[key(mv.Title, mv.Year)] = true
+			}
+		}
+		if shows, ok := m.Store.GetShows(lib.ID); ok {
+			for _, sh := range shows {
+				have[key(sh.Title, sh.Year)] = true
+			}
+		}
+	}
+
+	missing := make([]arr.Result, 0, len(results))
+	for _, r := range results {
+		if !have[key(r.Title, r.Year)] {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
 // updateInspector updates the inspector with the selected item from middle column
 func (m *Model) updateInspector() {
 	if top := m.ColumnStack.Top(); top != nil {
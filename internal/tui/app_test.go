@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// A load error must land on the column it was for: if the user backed out
+// of show A and opened show B, A's late failure must not mark B as failed.
+func TestErrMsgTargetsColumnByContentID(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack()}
+	m.ColumnStack.Push(components.NewListColumn(components.ColumnTypeShows, "TV"), 0)
+
+	showB := components.NewListColumn(components.ColumnTypeSeasons, "Show B")
+	showB.SetContentID("show-b")
+	showB.SetLoading(true)
+	m.ColumnStack.Push(showB, 0)
+
+	updated, _ := m.Update(ErrMsg{Err: errors.New("boom"), Context: "loading seasons", ContentID: "show-a", What: "seasons"})
+	m = updated.(Model)
+	if showB.IsLoadFailed() || !showB.IsLoading() {
+		t.Fatal("stale error for another show marked the current column failed")
+	}
+
+	updated, _ = m.Update(ErrMsg{Err: errors.New("boom"), Context: "loading seasons", ContentID: "show-b", What: "seasons"})
+	m = updated.(Model)
+	if !showB.IsLoadFailed() {
+		t.Fatal("error for the current show did not mark its column failed")
+	}
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// Auto-refresh: each library with an interval re-syncs on its own timer
+// while kino runs. The sync is the usual one, a cheap freshness check that
+// fetches only a library that changed, and it is silent unless it finds
+// new items. Timers restart with every library reload and config reload.
+
+// AutoRefreshTickMsg fires when a library is due a background sync.
+// Generation matches Model.autoRefreshGen unless the timers were restarted
+// since.
+type AutoRefreshTickMsg struct {
+	LibraryID  string
+	Generation int
+}
+
+// SetAutoRefresh sets how often libraries re-sync in the background; 0
+// turns it off. byLibrary overrides it per library name or ID.
+func (m *Model) SetAutoRefresh(every time.Duration, byLibrary map[string]time.Duration) {
+	m.autoRefresh = every
+	m.autoRefreshBy = byLibrary
+}
+
+// autoRefreshInterval is how often a library re-syncs, 0 for never
+func (m *Model) autoRefreshInterval(lib domain.Library) time.Duration {
+	for ref, every := range m.autoRefreshBy {
+		if lib.ID == ref || strings.EqualFold(lib.Name, ref) {
+			return every
+		}
+	}
+	return m.autoRefresh
+}
+
+// scheduleAutoRefresh starts the libraries' timers, superseding any running
+func (m *Model) scheduleAutoRefresh(libs []domain.Library) []tea.Cmd {
+	m.autoRefreshGen++
+	var cmds []tea.Cmd
+	for _, lib := range libs {
+		if cmd := m.autoRefreshTick(lib); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return cmds
+}
+
+// autoRefreshTick queues a library's next background sync
+func (m *Model) autoRefreshTick(lib domain.Library) tea.Cmd {
+	every := m.autoRefreshInterval(lib)
+	if every <= 0 {
+		return nil
+	}
+	id, gen := lib.ID, m.autoRefreshGen
+	return tea.Tick(every, func(time.Time) tea.Msg {
+		return AutoRefreshTickMsg{LibraryID: id, Generation: gen}
+	})
+}
+
+// handleAutoRefreshTick syncs a library that is due, unless it is syncing
+// already, the server is away, or a cache-only start hasn't been refreshed
+func (m Model) handleAutoRefreshTick(msg AutoRefreshTickMsg) (tea.Model, tea.Cmd) {
+	lib := m.findLibrary(msg.LibraryID)
+	if msg.Generation != m.autoRefreshGen || lib == nil {
+		return m, nil // Restarted since, or gone
+	}
+	cmds := []tea.Cmd{m.autoRefreshTick(*lib)}
+	if !m.quiet && m.health.status != HealthOffline && !m.syncPending(lib.ID) {
+		cmds = append(cmds, m.queueSync(*lib, false))
+		m.updateLibraryStates()
+	}
+	return m, tea.Batch(cmds...)
+}
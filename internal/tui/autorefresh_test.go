@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+func TestAutoRefresh(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	libs := []domain.Library{{ID: "1", Name: "Movies", Type: "movie"}, {ID: "2", Name: "TV Shows", Type: "show"}}
+	m := Model{ColumnStack: NewColumnStack(), LibraryStates: map[string]components.LibrarySyncState{}, jobs: NewJobs(),
+		Store: st, LibraryService: library.NewService(nil, st, nil), Libraries: libs}
+	m.resetSyncQueue()
+	m.SetAutoRefresh(30*time.Minute, map[string]time.Duration{"tv shows": 0})
+
+	if got := m.autoRefreshInterval(libs[0]); got != 30*time.Minute {
+		t.Errorf("Movies interval = %v", got)
+	}
+	if got := m.autoRefreshInterval(libs[1]); got != 0 {
+		t.Errorf("TV Shows interval = %v, want the override", got)
+	}
+	if cmds := m.scheduleAutoRefresh(libs); len(cmds) != 1 {
+		t.Fatalf("scheduled %d timers, want 1", len(cmds))
+	}
+
+	gen := m.autoRefreshGen
+	m.scheduleAutoRefresh(libs)
+	updated, cmd := m.handleAutoRefreshTick(AutoRefreshTickMsg{LibraryID: "1", Generation: gen})
+	if cmd != nil || updated.(Model).jobs.Active() != 0 {
+		t.Fatal("a tick from before a restart should be dropped")
+	}
+	gen = m.autoRefreshGen
+	m.quiet = true
+	updated, cmd = m.handleAutoRefreshTick(AutoRefreshTickMsg{LibraryID: "1", Generation: gen})
+	if m = updated.(Model); cmd == nil || m.syncPending("1") {
+		t.Error("cache-only quiet should keep the timer without syncing")
+	}
+	m.quiet = false
+	updated, cmd = m.handleAutoRefreshTick(AutoRefreshTickMsg{LibraryID: "1", Generation: gen})
+	if m = updated.(Model); cmd == nil || !m.syncPending("1") {
+		t.Error("a due library should sync and keep its timer")
+	}
+}
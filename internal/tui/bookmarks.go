@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/i18n"
+)
+
+// Bookmarks save the current location to a number key: m then 1-9 saves,
+// the number alone jumps back. A bookmark is a session in miniature and is
+// restored through the same navigation plan.
+
+// SetBookmarks installs the bookmarks loaded at startup
+func (m *Model) SetBookmarks(slots map[int]config.Bookmark) {
+	m.bookmarks = slots
+}
+
+// Bookmarks returns the bookmarks to save on exit and whether any changed
+// since SetBookmarks
+func (m Model) Bookmarks() (map[int]config.Bookmark, bool) {
+	return m.bookmarks, m.bookmarksChanged
+}
+
+// bookmarkSlot returns the slot a key names, or 0 when it is not 1-9
+func bookmarkSlot(msg tea.KeyMsg) int {
+	if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 {
+		return 0
+	}
+	n, err := strconv.Atoi(string(msg.Runes))
+	if err != nil || n < 1 || n > 9 {
+		return 0
+	}
+	return n
+}
+
+// handleBookmark waits for the number key to save the location to (m)
+func (m Model) handleBookmark() (tea.Model, tea.Cmd) {
+	if _, ok := m.currentBookmark(); !ok {
+		return m.notAvailableHere("Bookmark (m)")
+	}
+	m.bookmarkPending = true
+	return m, m.notify(NoticeInfo, i18n.T("status.bookmark_which"))
+}
+
+// handleBookmarkSlot saves the location to the number pressed after m; any
+// other key cancels
+func (m Model) handleBookmarkSlot(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.bookmarkPending = false
+	slot := bookmarkSlot(msg)
+	if slot == 0 {
+		return m, nil
+	}
+	b, ok := m.currentBookmark()
+	if !ok {
+		return m.notAvailableHere("Bookmark (m)")
+	}
+	if m.bookmarks == nil {
+		m.bookmarks = make(map[int]config.Bookmark)
+	}
+	m.bookmarks[slot] = b
+	m.bookmarksChanged = true
+	return m, m.notify(NoticeSuccess, i18n.T("status.bookmarked", slot, b.Label))
+}
+
+// handleJumpBookmark returns to the location saved on a number key
+func (m Model) handleJumpBookmark(slot int) (tea.Model, tea.Cmd) {
+	b, ok := m.bookmarks[slot]
+	if !ok {
+		return m, m.notify(NoticeInfo, i18n.T("status.no_bookmark", slot))
+	}
+	if m.findLibrary(b.LibraryID) == nil {
+		return m, m.notify(NoticeError, i18n.T("status.bookmark_gone", slot, b.Label))
+	}
+	cmd := m.restoreSession(&config.Session{LibraryID: b.LibraryID, Columns: b.Columns})
+	return m, tea.Batch(cmd, m.notify(NoticeInfo, i18n.T("status.jumped", b.Label)))
+}
+
+// currentBookmark captures the location as a bookmark, labelled by the last
+// two open columns. Only server libraries can be bookmarked; virtual ones
+// are rebuilt on every visit.
+func (m Model) currentBookmark() (config.Bookmark, bool) {
+	s := m.Session("")
+	lib := m.findLibrary(s.LibraryID)
+	if lib == nil {
+		return config.Bookmark{}, false
+	}
+	var titles []string
+	for i := max(1, m.ColumnStack.Len()-2); i < m.ColumnStack.Len(); i++ {
+		titles = append(titles, m.ColumnStack.Get(i).Title())
+	}
+	label := lib.Name
+	if len(titles) > 0 {
+		label = strings.Join(titles, " › ")
+	}
+	return config.Bookmark{Label: label, LibraryID: s.LibraryID, Columns: s.Columns}, true
+}
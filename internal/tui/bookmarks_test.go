@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/store"
+)
+
+// m then a number saves the location; the number alone returns to it from
+// elsewhere, and an empty slot says how to fill it
+func TestBookmarks(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := st.SaveShows("tv", []*domain.Show{{ID: "show1", Title: "Alpha"}}, 1); err != nil {
+		t.Fatal(err)
+	}
+	seasons := []*domain.Season{{ID: "s1", ShowID: "show1", SeasonNum: 1}, {ID: "s2", ShowID: "show1", SeasonNum: 2}}
+	if err := st.SaveSeasons("tv", "show1", seasons); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{
+		ColumnStack: NewColumnStack(),
+		Store:       st,
+		State:       StateBrowsing,
+		Libraries:   []domain.Library{{ID: "movies", Name: "Movies", Type: "movie"}, {ID: "tv", Name: "TV", Type: "show"}},
+	}
+	m.restoreSession(&config.Session{LibraryID: "tv", Columns: []config.ColumnSession{{SelectedID: "show1"}, {SelectedID: "s2"}}})
+
+	press := func(r string) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(r)})
+		m = updated.(Model)
+	}
+	press("m")
+	press("3")
+	slots, changed := m.Bookmarks()
+	if !changed || slots[3].LibraryID != "tv" || len(slots[3].Columns) != 2 || slots[3].Columns[1].SelectedID != "s2" {
+		t.Fatalf("bookmark 3 = %+v (changed %v)", slots[3], changed)
+	}
+
+	m.resetToLibrary("movies")
+	press("3")
+	if m.ColumnStack.Len() != 3 {
+		t.Fatalf("stack has %d columns after the jump, want libraries/shows/seasons", m.ColumnStack.Len())
+	}
+	if item, _ := m.ColumnStack.Top().SelectedItem().(*domain.Season); item == nil || item.ID != "s2" {
+		t.Fatalf("selected after the jump = %v, want s2", m.ColumnStack.Top().SelectedItem())
+	}
+
+	press("5")
+	if !strings.Contains(m.notice.Text, "m5") {
+		t.Fatalf("empty slot notice = %+v", m.notice)
+	}
+}
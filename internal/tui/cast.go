@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/player"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// Play on (C): pick another device from a menu, a Plex player on the
+// network, another app signed in to Jellyfin or a Chromecast on the LAN,
+// and it plays the selected item itself, from the resume position when there is one. Until it is
+// stopped, the player keys (. < > ctrl+x) control it instead of mpv.
+
+// CastTargetsLoadedMsg carries the devices an item can be played on
+type CastTargetsLoadedMsg struct {
+	Item    domain.MediaItem
+	Targets []domain.CastTarget
+}
+
+// CastStartedMsg signals that a device took the item
+type CastStartedMsg struct {
+	Target domain.CastTarget
+	Item   domain.MediaItem
+}
+
+// casting is the device last sent playback, which the player keys control
+type casting struct {
+	target domain.CastTarget
+	item   domain.MediaItem
+}
+
+// LoadCastTargetsCmd lists the devices to offer for an item
+func LoadCastTargetsCmd(svc *player.Service, item domain.MediaItem) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		targets, err := svc.CastTargets(ctx)
+		if err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.finding_devices")}
+		}
+		return CastTargetsLoadedMsg{Item: item, Targets: targets}
+	})
+}
+
+// CastCmd plays an item on a device
+func CastCmd(svc *player.Service, target domain.CastTarget, item domain.MediaItem) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := svc.Cast(ctx, target, item, item.ShouldResume()); err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.playing_on", target.Name)}
+		}
+		return CastStartedMsg{Target: target, Item: item}
+	})
+}
+
+// ControlCastCmd sends a remote-control command to a device, reporting
+// only failures
+func ControlCastCmd(svc *player.Service, target domain.CastTarget, cmd domain.CastCommand) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := svc.ControlCast(ctx, target, cmd); err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.controlling", target.Name)}
+		}
+		return nil
+	}
+}
+
+// handleCast looks up the devices the selected item can be played on
+func (m Model) handleCast() (tea.Model, tea.Cmd) {
+	top := m.ColumnStack.Top()
+	if top == nil {
+		return m, nil
+	}
+	item := top.SelectedMediaItem()
+	if item == nil || item.Type == domain.MediaTypeChannel || m.PlaybackSvc == nil || !m.PlaybackSvc.CanCast() {
+		return m.notAvailableHere(i18n.T("action.play_on"))
+	}
+	return m, tea.Batch(
+		m.notify(NoticeInfo, i18n.T("status.finding_devices")),
+		LoadCastTargetsCmd(m.PlaybackSvc, *item),
+	)
+}
+
+// handleCastTargetsLoaded lists the devices in the context menu
+func (m Model) handleCastTargetsLoaded(msg CastTargetsLoadedMsg) (tea.Model, tea.Cmd) {
+	if len(msg.Targets) == 0 {
+		return m, m.notify(NoticeInfo, i18n.T("status.no_devices"))
+	}
+	entries := make([]menuEntry, 0, len(msg.Targets))
+	for _, target := range msg.Targets {
+		label := target.Name
+		if target.Product != "" {
+			label += " · " + target.Product
+		}
+		entries = append(entries, menuEntry{label: label, run: func(m Model) (tea.Model, tea.Cmd) {
+			return m, tea.Batch(
+				m.notify(NoticeInfo, i18n.T("status.casting", msg.Item.Title, target.Name)),
+				CastCmd(m.PlaybackSvc, target, msg.Item),
+			)
+		}})
+	}
+	m.menu = entries
+	m.menuCursor = 0
+	return m, nil
+}
+
+// handleCastStarted hands the player keys to the device
+func (m Model) handleCastStarted(msg CastStartedMsg) (tea.Model, tea.Cmd) {
+	m.casting = &casting{target: msg.Target, item: msg.Item}
+	return m, m.notify(NoticeSuccess, i18n.T("status.cast_started", msg.Item.Title, msg.Target.Name))
+}
+
+// controlCast sends a player key to the device; stopping ends the control
+func (m Model) controlCast(cmd domain.CastCommand) (tea.Model, tea.Cmd) {
+	c := m.casting
+	if cmd == domain.CastStop {
+		m.casting = nil
+	}
+	return m, ControlCastCmd(m.PlaybackSvc, c.target, cmd)
+}
+
+// renderCasting renders the footer strip for the device being controlled
+func (m Model) renderCasting() string {
+	c := m.casting
+	if c == nil {
+		return ""
+	}
+	title := c.item.Title
+	if code := c.item.EpisodeCode(); code != "" {
+		title = c.item.ShowTitle + " " + code
+	}
+	return styles.AccentStyle.Render("⇢") + " " + title + styles.DimStyle.Render("  "+i18n.T("footer.on_device", c.target.Name))
+}
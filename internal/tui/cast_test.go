@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// Picking a device from the play-on menu starts playback there, after
+// which the footer names the device until the stop key
+func TestCastPicker(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack(), jobs: NewJobs(), Width: 120}
+	item := domain.MediaItem{ID: "42", Title: "Heat", Type: domain.MediaTypeMovie}
+	target := domain.CastTarget{ID: "s1", Name: "Living Room", Product: "Jellyfin Android TV"}
+
+	updated, _ := m.Update(CastTargetsLoadedMsg{Item: item, Targets: []domain.CastTarget{target}})
+	m = updated.(Model)
+	if len(m.menu) != 1 || m.menu[0].label != "Living Room · Jellyfin Android TV" {
+		t.Fatalf("menu = %+v", m.menu)
+	}
+
+	m.menu = nil
+	updated, _ = m.Update(CastStartedMsg{Target: target, Item: item})
+	m = updated.(Model)
+	m.notice = Notice{}
+	if footer := m.renderFooter(); !strings.Contains(footer, "⇢ Heat  on Living Room") {
+		t.Errorf("footer = %q", footer)
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	m = updated.(Model)
+	if m.casting != nil || cmd == nil {
+		t.Errorf("stop key left casting = %+v, cmd = %v", m.casting, cmd)
+	}
+}
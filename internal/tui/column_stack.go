@@ -17,9 +17,19 @@ import (
 // The "middle" column (top of stack) is always focused.
 // The "left" column shows parent context.
 // The "right" column (Inspector) shows details for the selection in middle column.
+//
+// Moving focus left with Retreat keeps the popped column as the forward
+// column: still drawn to the right of the focused one (when the inspector
+// is hidden) and pushed back as-is by Advance.
 type ColumnStack struct {
 	columns     []*components.ListColumn
 	cursorStack []int // Saved cursor positions for back navigation
+
+	forward *components.ListColumn // Child of the top column's selection, or nil
+
+	// views remembers the filter and selection of dropped columns by
+	// content ID, so a column rebuilt for the same content can get them back
+	views map[string]components.ViewState
 }
 
 // NewColumnStack creates a new empty column stack
@@ -51,6 +61,20 @@ func (cs *ColumnStack) Top() *components.ListColumn {
 	return cs.columns[len(cs.columns)-1]
 }
 
+// FindByContentID returns the topmost column showing the given content ID
+// (the forward column included), or nil if no column in the stack does
+func (cs *ColumnStack) FindByContentID(id string) *components.ListColumn {
+	if cs.forward != nil && cs.forward.ContentID() == id {
+		return cs.forward
+	}
+	for i := len(cs.columns) - 1; i >= 0; i-- {
+		if cs.columns[i].ContentID() == id {
+			return cs.columns[i]
+		}
+	}
+	return nil
+}
+
 // Push adds a new column to the stack, saving the current cursor position
 func (cs *ColumnStack) Push(col *components.ListColumn, saveCursor int) {
 	// Save current cursor position for back navigation
@@ -64,6 +88,10 @@ func (cs *ColumnStack) Push(col *components.ListColumn, saveCursor int) {
 	// Add new column and focus it
 	col.SetFocused(true)
 	cs.columns = append(cs.columns, col)
+	if cs.forward != col {
+		cs.remember(cs.forward)
+	}
+	cs.forward = nil
 }
 
 // Pop removes and returns the top column, along with the saved cursor position.
@@ -77,6 +105,7 @@ func (cs *ColumnStack) Pop() (*components.ListColumn, int) {
 	// Remove top column
 	popped := cs.columns[len(cs.columns)-1]
 	popped.SetFocused(false)
+	cs.remember(popped)
 	cs.columns = cs.columns[:len(cs.columns)-1]
 
 	// Restore saved cursor position
@@ -91,20 +120,72 @@ func (cs *ColumnStack) Pop() (*components.ListColumn, int) {
 		top.SetFocused(true)
 	}
 
+	cs.forward = nil
+	return popped, savedCursor
+}
+
+// Retreat pops the top column like Pop but keeps it as the forward column
+func (cs *ColumnStack) Retreat() (*components.ListColumn, int) {
+	popped, savedCursor := cs.Pop()
+	if popped != nil {
+		cs.forward = popped
+	}
 	return popped, savedCursor
 }
 
+// Forward returns the column kept by Retreat, or nil
+func (cs *ColumnStack) Forward() *components.ListColumn {
+	return cs.forward
+}
+
+// ClearForward drops the forward column
+func (cs *ColumnStack) ClearForward() {
+	cs.forward = nil
+}
+
+// Advance pushes the forward column back on top with its cursor intact.
+// Returns false when there is no forward column.
+func (cs *ColumnStack) Advance(saveCursor int) bool {
+	col := cs.forward
+	if col == nil {
+		return false
+	}
+	cs.Push(col, saveCursor)
+	return true
+}
+
 // Reset resets the stack to a single column (used when switching libraries)
 func (cs *ColumnStack) Reset(col *components.ListColumn) {
 	for _, c := range cs.columns {
 		c.SetFocused(false)
+		cs.remember(c)
 	}
+	cs.remember(cs.forward)
 	cs.columns = nil
 	cs.cursorStack = nil
+	cs.forward = nil
 	col.SetFocused(true)
 	cs.columns = append(cs.columns, col)
 }
 
+// SavedView returns the view state of the last dropped column that showed
+// contentID
+func (cs *ColumnStack) SavedView(contentID string) (components.ViewState, bool) {
+	view, ok := cs.views[contentID]
+	return view, ok
+}
+
+// remember keeps a column's view state for SavedView
+func (cs *ColumnStack) remember(col *components.ListColumn) {
+	if col == nil || col.ContentID() == "" || col.IsLoading() || col.IsLoadFailed() {
+		return
+	}
+	if cs.views == nil {
+		cs.views = make(map[string]components.ViewState)
+	}
+	cs.views[col.ContentID()] = col.ViewState()
+}
+
 // CanGoBack returns true if we can navigate back (not at root)
 func (cs *ColumnStack) CanGoBack() bool {
 	return len(cs.columns) > 1
@@ -123,4 +204,7 @@ func (cs *ColumnStack) UpdateSpinnerFrame(frame int) {
 	for _, col := range cs.columns {
 		col.SetSpinnerFrame(frame)
 	}
+	if cs.forward != nil {
+		cs.forward.SetSpinnerFrame(frame)
+	}
 }
@@ -0,0 +1,285 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/playlist"
+	"github.com/mmcdole/kino/internal/tui/components"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// commandUsage lists the commands for the error hint
+const commandUsage = "sort, filter, goto, playlist add, refresh, loglevel, quit"
+
+// command is a parsed command line: ":sort added desc" is
+// {name: "sort", args: ["added", "desc"]}
+type command struct {
+	name string
+	args []string
+}
+
+// parseCommand splits a command line into words. Double quotes group words
+// ("The Wire"); the leading ":" is optional.
+func parseCommand(line string) (command, error) {
+	var words []string
+	var word strings.Builder
+	inQuotes, inWord := false, false
+	for _, r := range strings.TrimPrefix(strings.TrimSpace(line), ":") {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			inWord = true
+		case r == ' ' && !inQuotes:
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inQuotes {
+		return command{}, errors.New("unterminated quote")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	if len(words) == 0 {
+		return command{}, errors.New("empty command")
+	}
+	return command{name: strings.ToLower(words[0]), args: words[1:]}, nil
+}
+
+// LinkResolvedMsg carries the target of a :goto
+type LinkResolvedMsg struct {
+	Res *library.Resolved
+}
+
+// ResolveLinkCmd finds a :goto target, from the cache or the server
+func ResolveLinkCmd(svc *library.Service, libs []domain.Library, link library.Link) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		res, err := svc.Resolve(ctx, libs, link)
+		if err != nil {
+			return ErrMsg{Err: err, Context: "goto"}
+		}
+		return LinkResolvedMsg{Res: res}
+	})
+}
+
+// AddToNamedPlaylistCmd adds items to the playlist titled name
+func AddToNamedPlaylistCmd(svc *playlist.Service, name string, itemIDs []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		playlists, err := svc.FetchPlaylists(ctx)
+		if err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_playlists")}
+		}
+		for _, p := range playlists {
+			if strings.EqualFold(p.Title, name) {
+				err := svc.AddToPlaylist(ctx, p.ID, itemIDs)
+				return PlaylistUpdatedMsg{PlaylistID: p.ID, Error: err}
+			}
+		}
+		return ErrMsg{Err: fmt.Errorf("no playlist named %q", name), Context: "playlist add"}
+	}
+}
+
+// handleCommandLine opens the command line (:)
+func (m Model) handleCommandLine() (tea.Model, tea.Cmd) {
+	m.cmdOpen = true
+	m.cmdLine = ""
+	return m, nil
+}
+
+// handleCommandInput edits the command line; Enter runs it, Esc (or
+// backspace on an empty line) closes it. Every key is consumed.
+func (m Model) handleCommandInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.cmdOpen = false
+		cmd, err := parseCommand(m.cmdLine)
+		if err != nil {
+			if m.cmdLine == "" {
+				return true, m, nil
+			}
+			return true, m, m.notifyHint(NoticeError, i18n.T("status.command_invalid", m.cmdLine, err), commandUsage)
+		}
+		updated, c := m.runCommand(cmd)
+		return true, updated.(Model), c
+	case tea.KeyEsc:
+		m.cmdOpen = false
+	case tea.KeyBackspace:
+		if m.cmdLine == "" {
+			m.cmdOpen = false
+		} else {
+			runes := []rune(m.cmdLine)
+			m.cmdLine = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		m.cmdLine += " "
+	case tea.KeyRunes:
+		m.cmdLine += string(msg.Runes)
+	}
+	return true, m, nil
+}
+
+// runCommand dispatches a parsed command to the handler its key would run
+func (m Model) runCommand(cmd command) (tea.Model, tea.Cmd) {
+	switch cmd.name {
+	case "sort":
+		return m.commandSort(cmd.args)
+	case "filter":
+		return m.commandFilter(cmd.args)
+	case "goto":
+		return m.commandGoto(cmd.args)
+	case "playlist":
+		return m.commandPlaylist(cmd.args)
+	case "refresh":
+		return m.handleRefresh()
+	case "loglevel":
+		return m.commandLogLevel(cmd.args)
+	case "q", "quit":
+		return m.handleQuit()
+	case "help":
+		return m.handleHelp()
+	}
+	return m, m.notifyHint(NoticeError, i18n.T("status.unknown_command", cmd.name), commandUsage)
+}
+
+// commandSort sorts the column: ":sort added desc". The direction defaults
+// to the field's natural one.
+func (m Model) commandSort(args []string) (tea.Model, tea.Cmd) {
+	top := m.ColumnStack.Top()
+	if top == nil || sortOptions(top) == nil {
+		return m.notAvailableHere(":sort")
+	}
+	if len(args) == 0 || len(args) > 2 {
+		return m, m.notifyHint(NoticeError, i18n.T("status.sort_needs_field"), i18n.T("hint.sort_example"))
+	}
+	field := components.ParseSortField(strings.ToLower(args[0]))
+	if !slices.Contains(sortOptions(top), field) {
+		keys := make([]string, 0, len(sortOptions(top)))
+		for _, f := range sortOptions(top) {
+			keys = append(keys, f.Key())
+		}
+		return m, m.notifyHint(NoticeError, i18n.T("status.cannot_sort", args[0]), strings.Join(keys, ", "))
+	}
+	dir := components.DefaultDirection(field)
+	if len(args) == 2 {
+		switch strings.ToLower(args[1]) {
+		case "asc":
+			dir = components.SortAsc
+		case "desc":
+			dir = components.SortDesc
+		default:
+			return m, m.notifyHint(NoticeError, i18n.T("status.unknown_direction", args[1]), i18n.T("hint.sort_directions"))
+		}
+	}
+	top.ApplySort(field, dir)
+	m.updateInspector()
+	return m, m.rememberSort(top, field, dir)
+}
+
+// commandFilter sets the column's watch filter: ":filter unwatched"
+func (m Model) commandFilter(args []string) (tea.Model, tea.Cmd) {
+	top := m.ColumnStack.Top()
+	if top == nil {
+		return m, nil
+	}
+	if len(args) != 1 {
+		return m, m.notifyHint(NoticeError, i18n.T("status.filter_needs_state"), i18n.T("hint.watch_states"))
+	}
+	var want components.WatchFilter
+	switch strings.ToLower(args[0]) {
+	case "all":
+		want = components.WatchFilterAll
+	case "unwatched":
+		want = components.WatchFilterUnwatched
+	case "inprogress", "in-progress", "progress":
+		want = components.WatchFilterInProgress
+	default:
+		return m, m.notifyHint(NoticeError, i18n.T("status.unknown_watch_state", args[0]), i18n.T("hint.watch_states"))
+	}
+	for top.WatchFilter() != want {
+		if _, ok := top.CycleWatchFilter(); !ok {
+			return m.notAvailableHere(":filter")
+		}
+	}
+	m.updateInspector()
+	return m, nil
+}
+
+// commandGoto opens a movie, show, season or episode by title:
+// ':goto show "The Wire" s3e5', ':goto "Heat (1995)"'
+func (m Model) commandGoto(args []string) (tea.Model, tea.Cmd) {
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "show", "movie":
+			args = args[1:]
+		}
+	}
+	if len(args) == 0 || m.LibraryService == nil {
+		return m, m.notifyHint(NoticeError, i18n.T("status.goto_needs_title"), i18n.T("hint.goto_example"))
+	}
+	// A trailing s3e5 or s3 is the episode or season, as in "The Wire/S03E05"
+	link, err := library.ParseLink(strings.Join(args, " "))
+	if n := len(args); n > 1 {
+		if episode, epErr := library.ParseLink(strings.Join(args[:n-1], " ") + "/" + args[n-1]); epErr == nil && episode.IsEpisode() {
+			link, err = episode, nil
+		}
+	}
+	if err != nil {
+		return m, m.notify(NoticeError, i18n.T("status.goto_failed", err))
+	}
+	return m, tea.Batch(
+		m.notify(NoticeInfo, i18n.T("status.finding", link.Title)),
+		ResolveLinkCmd(m.LibraryService, m.Libraries, link),
+	)
+}
+
+// commandPlaylist adds the marked items, or the selection, to a playlist
+// by name: ":playlist add Favorites"
+func (m Model) commandPlaylist(args []string) (tea.Model, tea.Cmd) {
+	if len(args) < 2 || strings.ToLower(args[0]) != "add" {
+		return m, m.notifyHint(NoticeError, i18n.T("status.playlist_needs_name"), i18n.T("hint.playlist_example"))
+	}
+	top := m.ColumnStack.Top()
+	if top == nil || m.PlaylistService == nil {
+		return m.notAvailableHere(":playlist add")
+	}
+	items := top.MarkedMediaItems()
+	if len(items) > 0 {
+		top.ClearMarks()
+	} else if item := top.SelectedMediaItem(); item != nil && item.Type != domain.MediaTypeChannel {
+		items = []*domain.MediaItem{item}
+	}
+	if len(items) == 0 {
+		return m.notAvailableHere(":playlist add")
+	}
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return m, AddToNamedPlaylistCmd(m.PlaylistService, strings.Join(args[1:], " "), ids)
+}
+
+// renderCommandLine renders the command line in place of the footer
+func (m Model) renderCommandLine() string {
+	return styles.AccentStyle.Render(":") + m.cmdLine + styles.DimStyle.Render("█")
+}
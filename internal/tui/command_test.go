@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// Quotes group words in a command line
+func TestParseCommand(t *testing.T) {
+	cmd, err := parseCommand(`:goto show "The Wire" s3e5`)
+	if err != nil || cmd.name != "goto" || !slices.Equal(cmd.args, []string{"show", "The Wire", "s3e5"}) {
+		t.Fatalf("parseCommand = %+v, %v", cmd, err)
+	}
+	if _, err := parseCommand(`goto "The Wire`); err == nil {
+		t.Error("unterminated quote parsed")
+	}
+}
+
+// Typed commands run what their keys would: :sort sorts and remembers the
+// sort, :filter sets the watch filter
+func TestCommandLine(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack(), jobs: NewJobs()}
+	col := components.NewListColumn(components.ColumnTypeMovies, "Movies")
+	col.SetItems([]*domain.MediaItem{
+		{ID: "m1", Title: "Alien", Type: domain.MediaTypeMovie, AddedAt: 1, IsPlayed: true},
+		{ID: "m2", Title: "Heat", Type: domain.MediaTypeMovie, AddedAt: 2},
+	})
+	col.SetContentID("lib")
+	m.ColumnStack.Push(col, 0)
+	run := func(line string) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(":")})
+		m = updated.(Model)
+		for _, r := range line {
+			k := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+			if r == ' ' {
+				k = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}}
+			}
+			updated, _ = m.Update(k)
+			m = updated.(Model)
+		}
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(Model)
+	}
+
+	run("sort added desc")
+	if field, dir := col.SortState(); field != components.SortDateAdded || dir != components.SortDesc {
+		t.Fatalf("sort = %v %v", field, dir)
+	}
+	if got := col.SelectedMediaItem(); got == nil || got.ID != "m2" {
+		t.Fatalf("first item = %+v, want the newest", got)
+	}
+
+	run("filter unwatched")
+	if col.WatchFilter() != components.WatchFilterUnwatched || col.ItemCount() != 1 {
+		t.Fatalf("filter = %v with %d items", col.WatchFilter(), col.ItemCount())
+	}
+
+	run("frobnicate")
+	if m.cmdOpen || m.notice.Text != i18n.T("status.unknown_command", "frobnicate") {
+		t.Fatalf("notice = %q", m.notice.Text)
+	}
+}
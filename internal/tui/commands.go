@@ -2,15 +2,22 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/arr"
 	"github.com/mmcdole/kino/internal/config"
 	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
 	"github.com/mmcdole/kino/internal/library"
 	"github.com/mmcdole/kino/internal/player"
 	"github.com/mmcdole/kino/internal/playlist"
+	"github.com/mmcdole/kino/internal/search"
 )
 
 // syncChannelSize is the buffer size for sync progress channels
@@ -18,6 +25,20 @@ const syncChannelSize = 100
 
 // Command factories for async operations
 
+// retryable lets cmd run again once the user signs in again or the server
+// comes back: an auth or offline failure it returns carries the command as
+// its Retry
+func retryable(cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		msg := cmd()
+		if e, ok := msg.(ErrMsg); ok && (errors.Is(e.Err, domain.ErrAuthFailed) || errors.Is(e.Err, domain.ErrServerOffline)) {
+			e.Retry = retryable(cmd)
+			return e
+		}
+		return msg
+	}
+}
+
 // LoadLibrariesCmd loads all available libraries
 func LoadLibrariesCmd(svc *library.Service) tea.Cmd {
 	return loadLibrariesCmd(svc, false)
@@ -30,137 +51,236 @@ func RefreshLibrariesCmd(svc *library.Service) tea.Cmd {
 }
 
 func loadLibrariesCmd(svc *library.Service, refresh bool) tea.Cmd {
-	return func() tea.Msg {
+	return retryable(func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
 		libraries, err := svc.FetchLibraries(ctx)
 		if err != nil {
 			slog.Error("failed to load libraries", "error", err)
-			return ErrMsg{Err: err, Context: "loading libraries"}
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_libraries")}
 		}
 		return LibrariesLoadedMsg{Libraries: libraries, Refresh: refresh}
-	}
+	})
 }
 
 // LoadMoviesCmd loads movies from a library
 func LoadMoviesCmd(svc *library.Service, lib domain.Library) tea.Cmd {
-	return func() tea.Msg {
+	return retryable(func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 		defer cancel()
 
 		movies, err := svc.FetchMovies(ctx, lib.ID, lib.UpdatedAt, nil)
 		if err != nil {
-			return ErrMsg{Err: err, Context: "loading movies"}
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_movies"), ContentID: lib.ID, What: "movies"}
 		}
 		return MoviesLoadedMsg{Movies: movies, LibraryID: lib.ID}
-	}
+	})
+}
+
+// LoadMergedMoviesCmd loads the merged movie library from its member
+// libraries. It lands as a regular MoviesLoadedMsg for the merged ID.
+func LoadMergedMoviesCmd(svc *library.Service, members []domain.Library) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		movies, err := svc.FetchMergedMovies(ctx, members)
+		if err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_movies"), ContentID: mergedLibraryID, What: "movies"}
+		}
+		return MoviesLoadedMsg{Movies: movies, LibraryID: mergedLibraryID}
+	})
 }
 
 // LoadShowsCmd loads TV shows from a library
 func LoadShowsCmd(svc *library.Service, lib domain.Library) tea.Cmd {
-	return func() tea.Msg {
+	return retryable(func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 		defer cancel()
 
 		shows, err := svc.FetchShows(ctx, lib.ID, lib.UpdatedAt, nil)
 		if err != nil {
-			return ErrMsg{Err: err, Context: "loading shows"}
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_shows"), ContentID: lib.ID, What: "shows"}
 		}
 		return ShowsLoadedMsg{Shows: shows, LibraryID: lib.ID}
-	}
+	})
 }
 
 // LoadMixedLibraryCmd loads content (movies AND shows) from a mixed library
 func LoadMixedLibraryCmd(svc *library.Service, lib domain.Library) tea.Cmd {
-	return func() tea.Msg {
+	return retryable(func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 		defer cancel()
 
 		items, err := svc.FetchMixedContent(ctx, lib.ID, lib.UpdatedAt, nil)
 		if err != nil {
-			return ErrMsg{Err: err, Context: "loading library content"}
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_library"), ContentID: lib.ID, What: "library"}
 		}
 		return MixedLibraryLoadedMsg{Items: items, LibraryID: lib.ID}
-	}
+	})
 }
 
 // LoadSeasonsCmd loads seasons for a show
 func LoadSeasonsCmd(svc *library.Service, libID, showID string) tea.Cmd {
-	return func() tea.Msg {
+	return retryable(func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
 		seasons, err := svc.FetchSeasons(ctx, libID, showID)
 		if err != nil {
-			return ErrMsg{Err: err, Context: "loading seasons"}
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_seasons"), ContentID: showID, What: "seasons"}
 		}
 		return SeasonsLoadedMsg{Seasons: seasons, ShowID: showID}
-	}
+	})
 }
 
 // LoadEpisodesCmd loads episodes for a season
 func LoadEpisodesCmd(svc *library.Service, libID, showID, seasonID string) tea.Cmd {
-	return func() tea.Msg {
+	return retryable(func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
 		episodes, err := svc.FetchEpisodes(ctx, libID, showID, seasonID)
 		if err != nil {
-			return ErrMsg{Err: err, Context: "loading episodes"}
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_episodes"), ContentID: seasonID, What: "episodes"}
 		}
 		return EpisodesLoadedMsg{Episodes: episodes, SeasonID: seasonID}
-	}
+	})
 }
 
 // PlayItemCmd starts playback of an item
 func PlayItemCmd(svc *player.Service, item domain.MediaItem, resume bool) tea.Cmd {
-	return func() tea.Msg {
+	return PlayVersionCmd(svc, item, "", resume)
+}
+
+// PlayVersionCmd starts playback of one of an item's versions; an empty
+// versionID plays the default one
+func PlayVersionCmd(svc *player.Service, item domain.MediaItem, versionID string, resume bool) tea.Cmd {
+	return retryable(func() tea.Msg {
 		// URL resolution is a network round-trip; a hung server must not
 		// wedge the command goroutine forever
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
 
-		var err error
-		if resume {
-			err = svc.Resume(ctx, item)
-		} else {
-			err = svc.Play(ctx, item)
+		ended, err := svc.PlayVersionWatched(ctx, item, versionID, resume)
+		if err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.starting_playback")}
 		}
+		return PlaybackStartedMsg{Item: item, Ended: ended}
+	})
+}
 
-		if err != nil {
-			return ErrMsg{Err: err, Context: "starting playback"}
+// OpenURLCmd opens a web page in the browser, reporting only failures
+func OpenURLCmd(svc *player.Service, url string) tea.Cmd {
+	return func() tea.Msg {
+		if err := svc.OpenURL(url); err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.opening_browser")}
 		}
-		return PlaybackStartedMsg{Item: item}
+		return nil
 	}
 }
 
 // MarkWatchedCmd marks an item as watched
 func MarkWatchedCmd(svc *player.Service, itemID, title string) tea.Cmd {
-	return func() tea.Msg {
+	return retryable(func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
 		if err := svc.MarkWatched(ctx, itemID); err != nil {
-			return ErrMsg{Err: err, Context: "marking as watched"}
+			return ErrMsg{Err: err, Context: i18n.T("context.marking_watched")}
 		}
 		return MarkWatchedMsg{ItemID: itemID, Title: title}
-	}
+	})
+}
+
+// RateItemCmd sets the user's rating of an item; 0 clears it
+func RateItemCmd(svc *library.Service, itemID, title string, rating float64) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := svc.RateItem(ctx, itemID, rating); err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.rating", title)}
+		}
+		return ItemRatedMsg{ItemID: itemID, Title: title, Rating: rating}
+	})
+}
+
+// DeleteItemCmd deletes a movie, show or episode from the server, browsed
+// in library libID
+func DeleteItemCmd(svc *library.Service, libID string, item domain.ListItem) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := svc.DeleteItem(ctx, libID, item); err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.deleting", item.GetTitle())}
+		}
+		return ItemDeletedMsg{Item: item}
+	})
 }
 
 // MarkUnwatchedCmd marks an item as unwatched
 func MarkUnwatchedCmd(svc *player.Service, itemID, title string) tea.Cmd {
-	return func() tea.Msg {
+	return retryable(func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
 		if err := svc.MarkUnwatched(ctx, itemID); err != nil {
-			return ErrMsg{Err: err, Context: "marking as unwatched"}
+			return ErrMsg{Err: err, Context: i18n.T("context.marking_unwatched")}
 		}
 		return MarkUnwatchedMsg{ItemID: itemID, Title: title}
+	})
+}
+
+// BatchMarkWatchedCmd marks several items watched or unwatched. Failures
+// don't abort the batch; the message reports which items succeeded.
+// Cancelling the job's context stops before the next item.
+func BatchMarkWatchedCmd(parent context.Context, jobID int, svc *player.Service, items []*domain.MediaItem, played bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(parent, 60*time.Second)
+		defer cancel()
+
+		msg := BatchWatchStateMsg{Played: played, JobID: jobID}
+		for _, item := range items {
+			if parent.Err() != nil {
+				break
+			}
+			var err error
+			if played {
+				err = svc.MarkWatched(ctx, item.ID)
+			} else {
+				err = svc.MarkUnwatched(ctx, item.ID)
+			}
+			if err != nil {
+				msg.Failed++
+				msg.Err = err
+				continue
+			}
+			msg.ItemIDs = append(msg.ItemIDs, item.ID)
+		}
+		return msg
 	}
 }
 
+// PlayQueueCmd starts playback of several items as one player queue
+func PlayQueueCmd(svc *player.Service, items []*domain.MediaItem) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		queue := make([]domain.MediaItem, len(items))
+		for i, item := range items {
+			queue[i] = *item
+		}
+		if err := svc.PlayQueue(ctx, queue); err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.starting_queue")}
+		}
+		return QueueStartedMsg{Count: len(items)}
+	})
+}
+
 // TickCmd returns a command that sends a tick after a delay
 func TickCmd(delay time.Duration) tea.Cmd {
 	return tea.Tick(delay, func(t time.Time) tea.Msg {
@@ -168,6 +288,58 @@ func TickCmd(delay time.Duration) tea.Cmd {
 	})
 }
 
+// searchDebounce is how long typing must pause before global search
+// matches; searchChunk is how many titles one background step matches
+const (
+	searchDebounce = 80 * time.Millisecond
+	searchChunk    = 4000
+)
+
+// SearchDebounceCmd waits out typing before matching the query
+func SearchDebounceCmd(gen int) tea.Cmd {
+	return tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+		return SearchDebounceMsg{Gen: gen}
+	})
+}
+
+// SearchCmd snapshots the cached libraries for query and matches the first
+// chunk off the UI goroutine
+func SearchCmd(svc *search.Service, query string, libs []domain.Library, gen int) tea.Cmd {
+	return func() tea.Msg {
+		msg := searchStep(svc.NewMatcher(query, libs), gen)
+		msg.First = true
+		return msg
+	}
+}
+
+// SearchStepCmd matches the next chunk of a running search
+func SearchStepCmd(matcher *search.Matcher, gen int) tea.Cmd {
+	return func() tea.Msg {
+		return searchStep(matcher, gen)
+	}
+}
+
+func searchStep(matcher *search.Matcher, gen int) SearchResultsMsg {
+	results, done := matcher.Step(searchChunk)
+	return SearchResultsMsg{Gen: gen, Results: results, Done: done, Matcher: matcher}
+}
+
+// EpisodeQueryCmd resolves a query ending in an episode code against the
+// show index, or the server's search, so the episode is found even when
+// episodes aren't indexed. A miss is silent: the matches still show.
+func EpisodeQueryCmd(svc *library.Service, libs []domain.Library, link library.Link, gen int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		res, err := svc.ResolveEpisodeQuery(ctx, libs, link)
+		if err != nil || res.Episode == nil {
+			return nil
+		}
+		return EpisodeQueryMsg{Gen: gen, Result: search.FilterResult{FilterItem: search.EpisodeItem(res.Episode, res.Library.ID)}}
+	}
+}
+
 // ClearLibraryStatusCmd returns a command that clears library status after delay
 func ClearLibraryStatusCmd(libID string, delay time.Duration) tea.Cmd {
 	return tea.Tick(delay, func(t time.Time) tea.Msg {
@@ -177,10 +349,39 @@ func ClearLibraryStatusCmd(libID string, delay time.Duration) tea.Cmd {
 
 // SyncLibraryCmd performs smart sync with streaming progress updates.
 // The generation tags every message so the model can drop chains superseded
-// by a newer library reload (refresh-all during a running sync).
-func SyncLibraryCmd(svc *library.Service, lib domain.Library, generation int) tea.Cmd {
+// by a newer library reload (refresh-all during a running sync); the job ID
+// ties the messages to the sync's entry in the jobs registry.
+func SyncLibraryCmd(parent context.Context, jobID int, svc *library.Service, lib domain.Library, generation int) tea.Cmd {
+	return syncLibraryCmd(parent, jobID, lib, generation, svc.SyncLibrary)
+}
+
+// RefetchLibraryCmd fully syncs a library already known to be stale,
+// skipping the freshness check
+func RefetchLibraryCmd(parent context.Context, jobID int, svc *library.Service, lib domain.Library, generation int) tea.Cmd {
+	return syncLibraryCmd(parent, jobID, lib, generation, svc.RefetchLibrary)
+}
+
+// CheckFreshnessCmd verifies the item counts of libraries whose cache
+// timestamps are current, reporting those that turned out stale
+func CheckFreshnessCmd(svc *library.Service, libraries []domain.Library, generation int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		return LibrariesStaleMsg{
+			Libraries:  svc.StaleLibraries(ctx, libraries),
+			Generation: generation,
+		}
+	}
+}
+
+// syncFunc is SyncLibrary or RefetchLibrary
+type syncFunc func(ctx context.Context, lib domain.Library, onProgress domain.ProgressFunc) (domain.SyncResult, error)
+
+// syncLibraryCmd runs a sync, streaming progress messages
+func syncLibraryCmd(parent context.Context, jobID int, lib domain.Library, generation int, sync syncFunc) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		ctx, cancel := context.WithTimeout(parent, 10*time.Minute)
 
 		progressCh := make(chan syncProgress, syncChannelSize)
 		// Dedicated 1-slot channel for the terminal message: progress updates
@@ -199,18 +400,20 @@ func SyncLibraryCmd(svc *library.Service, lib domain.Library, generation int) te
 				}
 			}
 
-			result, err := svc.SyncLibrary(ctx, lib, onProgress)
+			result, err := sync(ctx, lib, onProgress)
 
 			doneCh <- syncProgress{
 				loaded:    result.Count,
 				total:     result.Count,
 				done:      true,
 				fromCache: result.FromCache,
+				unwatched: result.Unwatched,
+				newIDs:    result.NewIDs,
 				err:       err,
 			}
 		}()
 
-		return readSyncProgress(lib, generation, progressCh, doneCh)
+		return readSyncProgress(lib, generation, jobID, progressCh, doneCh)
 	}
 }
 
@@ -220,22 +423,27 @@ type syncProgress struct {
 	total     int
 	done      bool
 	fromCache bool
+	unwatched int
+	newIDs    []string
 	err       error
 }
 
 // readSyncProgress reads the next progress or terminal message and converts
 // it to a LibrarySyncProgressMsg. The terminal message comes from the
 // dedicated done channel, which is buffered and therefore never lost.
-func readSyncProgress(lib domain.Library, generation int, progressCh <-chan syncProgress, doneCh <-chan syncProgress) tea.Msg {
+func readSyncProgress(lib domain.Library, generation, jobID int, progressCh <-chan syncProgress, doneCh <-chan syncProgress) tea.Msg {
 	makeMsg := func(p syncProgress) LibrarySyncProgressMsg {
 		return LibrarySyncProgressMsg{
 			LibraryID:   lib.ID,
 			LibraryType: lib.Type,
 			Generation:  generation,
+			JobID:       jobID,
 			Loaded:      p.loaded,
 			Total:       p.total,
 			Done:        p.done,
 			FromCache:   p.fromCache,
+			Unwatched:   p.unwatched,
+			NewIDs:      p.newIDs,
 			Error:       p.err,
 		}
 	}
@@ -251,32 +459,23 @@ func readSyncProgress(lib domain.Library, generation int, progressCh <-chan sync
 		}
 		msg := makeMsg(p)
 		if !p.done && p.err == nil {
-			msg.NextCmd = listenToSyncCmd(lib, generation, progressCh, doneCh)
+			msg.NextCmd = listenToSyncCmd(lib, generation, jobID, progressCh, doneCh)
 		}
 		return msg
 	}
 }
 
 // listenToSyncCmd returns a command that reads the next message from the progress channel
-func listenToSyncCmd(lib domain.Library, generation int, progressCh <-chan syncProgress, doneCh <-chan syncProgress) tea.Cmd {
+func listenToSyncCmd(lib domain.Library, generation, jobID int, progressCh <-chan syncProgress, doneCh <-chan syncProgress) tea.Cmd {
 	return func() tea.Msg {
-		return readSyncProgress(lib, generation, progressCh, doneCh)
+		return readSyncProgress(lib, generation, jobID, progressCh, doneCh)
 	}
 }
 
-// SyncAllLibrariesCmd syncs all libraries in parallel
-func SyncAllLibrariesCmd(svc *library.Service, libraries []domain.Library, generation int) tea.Cmd {
-	teaCmds := make([]tea.Cmd, len(libraries))
-	for i, lib := range libraries {
-		teaCmds[i] = SyncLibraryCmd(svc, lib, generation)
-	}
-	return tea.Batch(teaCmds...)
-}
-
 // SyncPlaylistsCmd syncs playlists and their items (two levels deep, like library sync).
-func SyncPlaylistsCmd(svc *playlist.Service, playlistsID string, generation int) tea.Cmd {
+func SyncPlaylistsCmd(parent context.Context, jobID int, svc *playlist.Service, playlistsID string, generation int) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		ctx, cancel := context.WithTimeout(parent, 2*time.Minute)
 		defer cancel()
 
 		// SyncPlaylists fetches playlists AND items for each
@@ -286,6 +485,7 @@ func SyncPlaylistsCmd(svc *playlist.Service, playlistsID string, generation int)
 			LibraryID:   playlistsID,
 			LibraryType: "playlist",
 			Generation:  generation,
+			JobID:       jobID,
 			Loaded:      len(playlists),
 			Total:       len(playlists),
 			Done:        true,
@@ -294,45 +494,180 @@ func SyncPlaylistsCmd(svc *playlist.Service, playlistsID string, generation int)
 	}
 }
 
-// LogoutCmd clears server config and cache, then signals completion
+// LogoutCmd clears the server config, then signals completion. The cache
+// is cleared on exit, once its database is closed: Windows can't delete an
+// open file.
 func LogoutCmd() tea.Cmd {
 	return func() tea.Msg {
 		if err := config.ClearServerConfig(); err != nil {
 			return LogoutCompleteMsg{Error: err}
 		}
-		if err := config.ClearCache(); err != nil {
-			return LogoutCompleteMsg{Error: err}
-		}
 		return LogoutCompleteMsg{Error: nil}
 	}
 }
 
+// LoggedOut reports whether the session ended in a logout, leaving the
+// cache to clear
+func (m Model) LoggedOut() bool {
+	return m.loggedOut
+}
+
+// DetectLiveTVCmd checks whether the server offers Live TV
+func DetectLiveTVCmd(svc *library.Service) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return LiveTVAvailableMsg{Available: svc.LiveTVAvailable(ctx)}
+	}
+}
+
+// LoadChannelsCmd loads Live TV channels with their current programs
+func LoadChannelsCmd(svc *library.Service) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		channels, err := svc.FetchChannels(ctx)
+		if err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_channels"), ContentID: liveTVLibraryID, What: "channels"}
+		}
+		return ChannelsLoadedMsg{Channels: channels}
+	})
+}
+
+// LoadCalendarCmd loads recently aired and upcoming episodes
+func LoadCalendarCmd(svc *library.Service) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		episodes, err := svc.FetchCalendar(ctx)
+		if err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_calendar"), ContentID: calendarLibraryID, What: "calendar"}
+		}
+		return CalendarLoadedMsg{Episodes: episodes}
+	})
+}
+
+// LoadContinueWatchingCmd loads the in-progress items of every library
+func LoadContinueWatchingCmd(svc *library.Service) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		items, err := svc.FetchContinueWatching(ctx)
+		if err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_continue"), ContentID: continueLibraryID, What: "continue watching"}
+		}
+		return ContinueWatchingLoadedMsg{Items: items}
+	})
+}
+
+// DismissContinueCmd removes an item from Continue Watching
+func DismissContinueCmd(svc *library.Service, item domain.MediaItem) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := svc.RemoveFromContinueWatching(ctx, item.ID); err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.removing_continue")}
+		}
+		return ContinueDismissedMsg{Item: item}
+	}
+}
+
+// LoadWatchlistCmd loads the account's watchlist
+func LoadWatchlistCmd(svc *library.Service) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		items, err := svc.FetchWatchlist(ctx)
+		if err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_watchlist"), ContentID: watchlistLibraryID, What: "watchlist"}
+		}
+		return WatchlistLoadedMsg{Items: items}
+	})
+}
+
+// AddToWatchlistCmd watchlists a server movie or show; entry describes it
+// and gets its watchlist ID
+func AddToWatchlistCmd(svc *library.Service, entry domain.WatchlistItem) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		watchlistID, err := svc.AddToWatchlist(ctx, entry.LocalID)
+		if err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.adding_watchlist")}
+		}
+		entry.ID = watchlistID
+		return WatchlistChangedMsg{Item: entry, Added: true}
+	})
+}
+
+// RemoveFromWatchlistCmd drops a title from the watchlist
+func RemoveFromWatchlistCmd(svc *library.Service, entry domain.WatchlistItem) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := svc.RemoveFromWatchlist(ctx, entry.ID); err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.removing_watchlist")}
+		}
+		return WatchlistChangedMsg{Item: entry}
+	})
+}
+
+// ArrLookupCmd looks a search query up on Sonarr/Radarr
+func ArrLookupCmd(svc *arr.Service, query string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+
+		results, err := svc.Search(ctx, query)
+		return ArrLookupMsg{Query: query, Results: results, Err: err}
+	}
+}
+
+// ArrAddCmd adds a lookup result to Sonarr/Radarr and starts its search
+func ArrAddCmd(svc *arr.Service, r arr.Result) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+
+		added, err := svc.Add(ctx, r)
+		return ArrAddedMsg{Result: added, Err: err}
+	}
+}
+
 // LoadPlaylistsCmd loads all playlists
 func LoadPlaylistsCmd(svc *playlist.Service) tea.Cmd {
-	return func() tea.Msg {
+	return retryable(func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
 		playlists, err := svc.FetchPlaylists(ctx)
 		if err != nil {
-			return ErrMsg{Err: err, Context: "loading playlists"}
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_playlists"), ContentID: playlistsLibraryID, What: "playlists"}
 		}
 		return PlaylistsLoadedMsg{Playlists: playlists}
-	}
+	})
 }
 
 // LoadPlaylistItemsCmd loads items from a playlist
 func LoadPlaylistItemsCmd(svc *playlist.Service, playlistID string) tea.Cmd {
-	return func() tea.Msg {
+	return retryable(func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
 		items, err := svc.FetchPlaylistItems(ctx, playlistID)
 		if err != nil {
-			return ErrMsg{Err: err, Context: "loading playlist items"}
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_playlist"), ContentID: playlistID, What: "playlist"}
 		}
 		return PlaylistItemsLoadedMsg{Items: items, PlaylistID: playlistID}
-	}
+	})
 }
 
 // CreatePlaylistCmd creates a new playlist
@@ -350,30 +685,24 @@ func CreatePlaylistCmd(svc *playlist.Service, title string, itemIDs []string) te
 }
 
 // AddToPlaylistCmd adds items to a playlist
-func AddToPlaylistCmd(svc *playlist.Service, playlistID string, itemIDs []string) tea.Cmd {
+func AddToPlaylistCmd(parent context.Context, jobID int, svc *playlist.Service, playlistID string, itemIDs []string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(parent, 30*time.Second)
 		defer cancel()
 
 		err := svc.AddToPlaylist(ctx, playlistID, itemIDs)
-		if err != nil {
-			return PlaylistUpdatedMsg{PlaylistID: playlistID, Error: err}
-		}
-		return PlaylistUpdatedMsg{PlaylistID: playlistID}
+		return PlaylistUpdatedMsg{PlaylistID: playlistID, JobID: jobID, Error: err}
 	}
 }
 
 // RemoveFromPlaylistCmd removes an item from a playlist
-func RemoveFromPlaylistCmd(svc *playlist.Service, playlistID, itemID string) tea.Cmd {
+func RemoveFromPlaylistCmd(parent context.Context, jobID int, svc *playlist.Service, playlistID, itemID string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(parent, 30*time.Second)
 		defer cancel()
 
 		err := svc.RemoveFromPlaylist(ctx, playlistID, itemID)
-		if err != nil {
-			return PlaylistUpdatedMsg{PlaylistID: playlistID, Error: err}
-		}
-		return PlaylistUpdatedMsg{PlaylistID: playlistID}
+		return PlaylistUpdatedMsg{PlaylistID: playlistID, JobID: jobID, Error: err}
 	}
 }
 
@@ -391,6 +720,58 @@ func DeletePlaylistCmd(svc *playlist.Service, playlistID string) tea.Cmd {
 	}
 }
 
+// UpdatePlaylistCmd sets a playlist's title and description
+func UpdatePlaylistCmd(svc *playlist.Service, playlistID, title, description string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := svc.UpdatePlaylist(ctx, playlistID, title, description)
+		return PlaylistEditedMsg{PlaylistID: playlistID, Title: title, Error: err}
+	}
+}
+
+// ExportPlaylistCmd writes a playlist, with stream URLs, to an M3U file
+// named after it in the working directory
+func ExportPlaylistCmd(svc *playlist.Service, playback *player.Service, playlistID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		doc, err := svc.Export(ctx, playlistID, playback.PlayableURL)
+		if err != nil {
+			return PlaylistExportedMsg{Error: err}
+		}
+		path, err := filepath.Abs(exportFileName(doc.Title, playlistID) + ".m3u")
+		if err != nil {
+			return PlaylistExportedMsg{Error: err}
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return PlaylistExportedMsg{Error: err}
+		}
+		err = playlist.Write(f, doc, playlist.FormatM3U)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return PlaylistExportedMsg{Path: path, Count: len(doc.Items), Error: err}
+	}
+}
+
+// exportFileName makes a playlist title safe to use as a file name
+func exportFileName(title, fallback string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if name == "" || strings.Trim(name, ".") == "" {
+		return fallback
+	}
+	return name
+}
+
 // LoadPlaylistModalDataCmd loads data for the playlist management modal
 func LoadPlaylistModalDataCmd(svc *playlist.Service, item *domain.MediaItem) tea.Cmd {
 	return func() tea.Msg {
@@ -399,12 +780,12 @@ func LoadPlaylistModalDataCmd(svc *playlist.Service, item *domain.MediaItem) tea
 
 		playlists, err := svc.FetchPlaylists(ctx)
 		if err != nil {
-			return ErrMsg{Err: err, Context: "loading playlists for modal"}
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_playlists")}
 		}
 
 		membership, err := svc.GetPlaylistMembership(ctx, item.ID)
 		if err != nil {
-			return ErrMsg{Err: err, Context: "checking playlist membership"}
+			return ErrMsg{Err: err, Context: i18n.T("context.checking_playlists")}
 		}
 
 		return PlaylistModalDataMsg{
@@ -414,3 +795,23 @@ func LoadPlaylistModalDataCmd(svc *playlist.Service, item *domain.MediaItem) tea
 		}
 	}
 }
+
+// LoadBatchPlaylistModalDataCmd loads playlists for adding several marked
+// items at once. Membership isn't checked for batches.
+func LoadBatchPlaylistModalDataCmd(svc *playlist.Service, items []*domain.MediaItem) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		playlists, err := svc.FetchPlaylists(ctx)
+		if err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.loading_playlists")}
+		}
+
+		return PlaylistModalDataMsg{
+			Playlists:  playlists,
+			Membership: map[string]bool{},
+			Items:      items,
+		}
+	}
+}
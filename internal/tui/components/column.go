@@ -12,4 +12,8 @@ const (
 	ColumnTypeEpisodes
 	ColumnTypePlaylists
 	ColumnTypePlaylistItems
+	ColumnTypeChannels  // Live TV channels
+	ColumnTypeCalendar  // Episodes by air date
+	ColumnTypeWatchlist // Watchlisted titles, on the server or not
+	ColumnTypeContinue  // In-progress movies and episodes of every library
 )
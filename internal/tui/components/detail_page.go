@@ -0,0 +1,53 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// maxSimilar caps the similar titles listed on the detail page
+const maxSimilar = 10
+
+// RenderDetailPage renders everything known about a movie, episode or show
+// for the full-screen detail view: the inspector's header, the summary with
+// the whole cast, the file details and similar titles
+func RenderDetailPage(item domain.ListItem, d *domain.ItemDetails, similar []domain.ListItem, width int) string {
+	var sections []string
+	switch v := item.(type) {
+	case *domain.MediaItem:
+		sections = append(sections, renderMediaHeader(*v, width), withDetails(renderMediaBody(*v, width), d, width, 0))
+		if footer := renderMediaFooter(*v, width); footer != "" {
+			sections = append(sections, footer)
+		}
+	case *domain.Show:
+		sections = append(sections, renderShowHeader(*v, width), withDetails(renderShowBody(*v, width), d, width, 0))
+	default:
+		return styles.DimStyle.Render("No details for this item")
+	}
+
+	if len(similar) > 0 {
+		lines := []string{styles.DimStyle.Render("More like this:")}
+		for n, s := range similar {
+			if n == maxSimilar {
+				break
+			}
+			line := s.GetTitle()
+			if year := s.GetYear(); year > 0 {
+				line += fmt.Sprintf(" (%d)", year)
+			}
+			lines = append(lines, styles.SubtitleStyle.Render(styles.Truncate("  "+line, width)))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	var nonEmpty []string
+	for _, s := range sections {
+		if s != "" {
+			nonEmpty = append(nonEmpty, s)
+		}
+	}
+	return strings.Join(nonEmpty, "\n\n")
+}
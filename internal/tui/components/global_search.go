@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/arr"
 	"github.com/mmcdole/kino/internal/domain"
 	"github.com/mmcdole/kino/internal/search"
 	"github.com/mmcdole/kino/internal/tui/styles"
@@ -23,6 +24,13 @@ type GlobalSearch struct {
 	width     int
 	height    int
 	prevQuery string
+	searching bool // Results are still streaming in
+
+	// Sonarr/Radarr lookup results for titles not in the library. The
+	// cursor runs past the local results into these.
+	lookupEnabled bool
+	lookingUp     bool
+	external      []arr.Result
 }
 
 // NewGlobalSearch creates a new global search component
@@ -52,6 +60,9 @@ func (o *GlobalSearch) Show() {
 	o.cursor = 0
 	o.offset = 0
 	o.prevQuery = ""
+	o.searching = false
+	o.external = nil
+	o.lookingUp = false
 }
 
 // Hide hides the global search
@@ -65,11 +76,72 @@ func (o GlobalSearch) IsVisible() bool {
 	return o.visible
 }
 
-// SetResults sets the search results with match highlighting data
+// SetResults sets the search results with match highlighting data. External
+// lookup results belong to the previous query and are dropped.
 func (o *GlobalSearch) SetResults(results []search.FilterResult) {
 	o.results = results
 	o.cursor = 0
 	o.offset = 0
+	o.external = nil
+	o.lookingUp = false
+}
+
+// UpdateResults replaces the results with a fuller set for the same
+// query, keeping the cursor where it is
+func (o *GlobalSearch) UpdateResults(results []search.FilterResult) {
+	o.results = results
+	if o.cursor >= len(o.results)+len(o.external) {
+		o.cursor = max(0, len(o.results)+len(o.external)-1)
+	}
+	if o.offset > o.cursor {
+		o.offset = o.cursor
+	}
+}
+
+// Results returns the local results shown
+func (o GlobalSearch) Results() []search.FilterResult {
+	return o.results
+}
+
+// SetSearching marks matching as still in progress
+func (o *GlobalSearch) SetSearching(searching bool) {
+	o.searching = searching
+}
+
+// SetLookupEnabled shows the Sonarr/Radarr lookup hint
+func (o *GlobalSearch) SetLookupEnabled(enabled bool) {
+	o.lookupEnabled = enabled
+}
+
+// SetLookingUp marks a Sonarr/Radarr lookup as in flight
+func (o *GlobalSearch) SetLookingUp(lookingUp bool) {
+	o.lookingUp = lookingUp
+}
+
+// SetExternal sets the Sonarr/Radarr results shown below the local ones
+func (o *GlobalSearch) SetExternal(results []arr.Result) {
+	o.external = results
+	o.lookingUp = false
+}
+
+// UpdateExternal replaces an external result in place (after adding it)
+func (o *GlobalSearch) UpdateExternal(r arr.Result) {
+	for i := range o.external {
+		if o.external[i].Kind == r.Kind && o.external[i].ExternalID == r.ExternalID {
+			o.external[i] = r
+		}
+	}
+}
+
+// SelectedExternal returns the selected Sonarr/Radarr result, if the cursor
+// is on one
+func (o GlobalSearch) SelectedExternal() *arr.Result {
+	i := o.cursor - len(o.results)
+	if i < 0 || i >= len(o.external) {
+		return nil
+	}
+	r := o.external[i]
+	return &r
 }
 
 // SetSize updates the component dimensions
@@ -97,7 +169,7 @@ func (o *GlobalSearch) QueryChanged() bool {
 // Selected returns the selected result's FilterItem
 func (o GlobalSearch) Selected() *search.FilterItem {
 	if len(o.results) == 0 || o.cursor >= len(o.results) {
-		return nil
+		return nil // empty, or the cursor is on an external result
 	}
 	return &o.results[o.cursor].FilterItem
 }
@@ -119,7 +191,7 @@ func (o GlobalSearch) Update(msg tea.Msg) (GlobalSearch, tea.Cmd, bool) {
 	}
 
 	var cmd tea.Cmd
-	resultCount := o.ResultCount()
+	resultCount := o.ResultCount() + len(o.external)
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -137,14 +209,18 @@ func (o GlobalSearch) Update(msg tea.Msg) (GlobalSearch, tea.Cmd, bool) {
 		case key.Matches(msg, GlobalSearchKeys.Down):
 			if o.cursor < resultCount-1 {
 				o.cursor++
-				o.ensureVisible(10)
+				if o.cursor < len(o.results) {
+					o.ensureVisible(10)
+				}
 			}
 			return o, nil, false
 
 		case key.Matches(msg, GlobalSearchKeys.Up):
 			if o.cursor > 0 {
 				o.cursor--
-				o.ensureVisible(10)
+				if o.cursor < len(o.results) {
+					o.ensureVisible(10)
+				}
 			}
 			return o, nil, false
 
@@ -197,6 +273,7 @@ func (o GlobalSearch) View() string {
 
 	// Results
 	o.renderResults(&b, modalWidth, maxResults)
+	o.renderExternal(&b, modalWidth)
 
 	// Center the modal
 	content := lipgloss.NewStyle().
@@ -291,7 +368,12 @@ func highlightMatches(text string, matchedIndexes []int, selected bool) string {
 // renderResults renders the search results
 func (o GlobalSearch) renderResults(b *strings.Builder, modalWidth, maxResults int) {
 	if len(o.results) == 0 && o.input.Value() != "" {
-		b.WriteString(styles.DimStyle.Render("No matches found"))
+		if o.searching {
+			b.WriteString(styles.DimStyle.Render("Searching..."))
+		} else {
+			b.WriteString(styles.DimStyle.Render("No matches found"))
+		}
+		b.WriteString("\n")
 		return
 	}
 	if len(o.results) == 0 {
@@ -325,14 +407,8 @@ func (o GlobalSearch) renderResults(b *strings.Builder, modalWidth, maxResults i
 		title := result.Title
 		matchedIndexes := result.MatchedIndexes
 		maxTitleWidth := modalWidth - 25
-		if result.Type == domain.MediaTypeEpisode {
-			// For episodes, show: ShowTitle - S01E01 Title
-			if item, ok := result.Item.(*domain.MediaItem); ok {
-				title = fmt.Sprintf("%s - %s %s", item.ShowTitle, item.EpisodeCode(), item.Title)
-				// Reset matched indexes since the title format changed
-				matchedIndexes = nil
-			}
-		} else if result.Type == domain.MediaTypeMovie {
+		// Episodes are matched as "ShowTitle - S01E01 Title" already
+		if result.Type == domain.MediaTypeMovie {
 			// For movies, show: Title (Year)
 			if item, ok := result.Item.(*domain.MediaItem); ok && item.Year > 0 {
 				title = fmt.Sprintf("%s (%d)", item.Title, item.Year)
@@ -349,6 +425,57 @@ func (o GlobalSearch) renderResults(b *strings.Builder, modalWidth, maxResults i
 	}
 
 	if remaining := len(o.results) - (o.offset + displayCount); remaining > 0 {
-		b.WriteString(styles.DimStyle.Render(fmt.Sprintf("... and %d more", remaining)))
+		more := fmt.Sprintf("... and %d more", remaining)
+		if o.searching {
+			more += " (searching)"
+		}
+		b.WriteString(styles.DimStyle.Render(more))
+	}
+}
+
+// renderExternal renders the Sonarr/Radarr section below the local results
+func (o GlobalSearch) renderExternal(b *strings.Builder, modalWidth int) {
+	if !o.lookupEnabled || o.input.Value() == "" {
+		return
+	}
+	b.WriteString("\n")
+	switch {
+	case o.lookingUp:
+		b.WriteString(styles.DimStyle.Render("Searching Sonarr/Radarr..."))
+		return
+	case o.external == nil:
+		b.WriteString(styles.DimStyle.Render("tab: look up on Sonarr/Radarr"))
+		return
+	case len(o.external) == 0:
+		b.WriteString(styles.DimStyle.Render("Not found on Sonarr/Radarr"))
+		return
+	}
+
+	b.WriteString(styles.DimStyle.Render("Not in library"))
+	b.WriteString("\n")
+	for i, r := range o.external {
+		selected := len(o.results)+i == o.cursor
+
+		badge := "MOV"
+		if r.Kind == arr.KindSonarr {
+			badge = "SHOW"
+		}
+		status := r.StatusLabel()
+		if status == "" {
+			status = "enter: add"
+		}
+
+		title := r.Title
+		if r.Year > 0 {
+			title = fmt.Sprintf("%s (%d)", r.Title, r.Year)
+		}
+		title = styles.Truncate(title, modalWidth-25-len(status))
+
+		b.WriteString(styles.DimBadgeStyle.Render(badge))
+		b.WriteString(" ")
+		b.WriteString(highlightMatches(title, nil, selected))
+		b.WriteString(" ")
+		b.WriteString(styles.DimStyle.Render(status))
+		b.WriteString("\n")
 	}
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/format"
 	"github.com/mmcdole/kino/internal/tui/styles"
 )
 
@@ -31,6 +32,23 @@ type Inspector struct {
 	offset        int // scroll offset
 	maxVisible    int // max visible lines
 	libraryStates map[string]LibrarySyncState
+
+	// Peek: the selected show's seasons or season's episodes, shown in
+	// place of its metadata while peek mode is on
+	peek *peekContent
+
+	// Full metadata fetched for the item detailsID once it was selected
+	// long enough, shown below its summary
+	detailsID string
+	details   *domain.ItemDetails
+}
+
+// peekContent is the child list of the item being peeked at
+type peekContent struct {
+	parentID string
+	items    []domain.ListItem
+	loading  bool
+	failed   bool
 }
 
 // NewInspector creates a new inspector component
@@ -42,8 +60,85 @@ func NewInspector() Inspector {
 
 // SetItem sets the item to display
 func (i *Inspector) SetItem(item interface{}) {
+	if itemKey(item) != itemKey(i.item) {
+		i.offset = 0 // Reset scroll on item change
+	}
 	i.item = item
-	i.offset = 0 // Reset scroll on item change
+}
+
+// itemKey identifies an inspected item, so re-setting the same one keeps
+// its scroll position
+func itemKey(item interface{}) string {
+	switch v := item.(type) {
+	case domain.Library:
+		return "library:" + v.ID
+	case domain.ListItem:
+		return v.GetItemType() + ":" + v.GetID()
+	default:
+		return ""
+	}
+}
+
+// SetDetails attaches an item's full metadata. It is shown while that item
+// is the inspected one.
+func (i *Inspector) SetDetails(itemID string, details *domain.ItemDetails) {
+	i.detailsID, i.details = itemID, details
+}
+
+// itemDetails returns the full metadata of the item with this ID, if set
+func (i Inspector) itemDetails(itemID string) *domain.ItemDetails {
+	if i.detailsID != itemID {
+		return nil
+	}
+	return i.details
+}
+
+// Scroll moves the body delta lines, clamped to its length (mouse wheel)
+func (i *Inspector) Scroll(delta int) {
+	i.offset = min(max(i.offset+delta, 0), i.maxOffset())
+}
+
+// maxOffset is the furthest the body can scroll at the current size
+func (i *Inspector) maxOffset() int {
+	content := i.renderInspector(max(i.width-3, 10))
+	available := max(i.maxVisible-len(splitLines(content.header))-len(splitLines(content.footer)), 1)
+	return max(len(splitLines(content.body))-available, 0)
+}
+
+// SetPeek shows a parent's children in place of its metadata while it is
+// the selected item. loading shows a spinner line until the items arrive.
+func (i *Inspector) SetPeek(parentID string, items []domain.ListItem, loading bool) {
+	i.peek = &peekContent{parentID: parentID, items: items, loading: loading}
+}
+
+// SetPeekFailed marks the pending peek load as failed
+func (i *Inspector) SetPeekFailed(parentID string) {
+	if i.peek != nil && i.peek.parentID == parentID {
+		i.peek.loading = false
+		i.peek.failed = true
+	}
+}
+
+// ClearPeek returns the inspector to metadata
+func (i *Inspector) ClearPeek() {
+	i.peek = nil
+}
+
+// PeekParentID returns the ID of the peeked item ("" when not peeking)
+func (i Inspector) PeekParentID() string {
+	if i.peek == nil {
+		return ""
+	}
+	return i.peek.parentID
+}
+
+// peeking reports whether the selected item is the peeked one
+func (i Inspector) peeking() bool {
+	if i.peek == nil {
+		return false
+	}
+	item, ok := i.item.(domain.ListItem)
+	return ok && item.GetID() == i.peek.parentID
 }
 
 // SetLibraryStates sets the library sync states for displaying item counts
@@ -74,7 +169,11 @@ func (i Inspector) View() string {
 	content := i.renderInspector(contentWidth)
 
 	// Title line (styled, matching other columns)
-	titleLine := styles.AccentStyle.Render(styles.Truncate("Info", contentWidth))
+	title := "Info"
+	if i.peeking() {
+		title = "Peek"
+	}
+	titleLine := styles.AccentStyle.Render(styles.Truncate(title, contentWidth))
 
 	// Three-zone layout: header is fixed, body scrolls, footer is fixed
 	headerLines := splitLines(content.header)
@@ -163,6 +262,9 @@ func (i Inspector) View() string {
 
 // renderInspector renders the inspector panel content as three zones
 func (i Inspector) renderInspector(width int) inspectorContent {
+	if i.peeking() {
+		return i.renderPeek(width)
+	}
 	switch v := i.item.(type) {
 	case *domain.MediaItem:
 		return i.renderMediaItemInspector(*v, width)
@@ -176,15 +278,18 @@ func (i Inspector) renderInspector(width int) inspectorContent {
 		return inspectorContent{body: i.renderLibraryInspector(&v, width)}
 	case *domain.Playlist:
 		return inspectorContent{body: i.renderPlaylistInspector(*v, width)}
+	case *domain.WatchlistItem:
+		return inspectorContent{body: i.renderWatchlistInspector(*v, width)}
 	default:
 		return inspectorContent{body: styles.DimStyle.Render("No item selected")}
 	}
 }
 
 func (i Inspector) renderMediaItemInspector(item domain.MediaItem, width int) inspectorContent {
+	details := i.itemDetails(item.ID)
 	headerStr := renderMediaHeader(item, width)
-	bodyStr := renderMediaBody(item, width)
-	footerStr := renderMediaFooter(item, width)
+	bodyStr := withDetails(renderMediaBody(item, width), details, width, maxCast)
+	footerStr := renderMediaFooter(withFiles(item, details), width)
 	return inspectorContent{
 		header: headerStr,
 		body:   bodyStr,
@@ -214,12 +319,16 @@ func renderMediaHeader(item domain.MediaItem, width int) string {
 	if item.Year > 0 {
 		metaParts = append(metaParts, fmt.Sprintf("%d", item.Year))
 	}
-	metaParts = append(metaParts, item.FormattedDuration())
+	metaParts = append(metaParts, format.Duration(item.Duration))
 	if item.ContentRating != "" {
 		metaParts = append(metaParts, item.ContentRating)
 	}
 	b.WriteString(styles.DimStyle.Render(strings.Join(metaParts, " · ")))
 	b.WriteString("\n")
+	if ids := renderExternalIDs(item.External, width); ids != "" {
+		b.WriteString(ids)
+		b.WriteString("\n")
+	}
 
 	// Rating and watch status grouped left
 	var statusParts []string
@@ -236,6 +345,9 @@ func renderMediaHeader(item domain.MediaItem, width int) string {
 		}
 		statusParts = append(statusParts, ratingStyle.Render(ratingText))
 	}
+	if item.UserRating > 0 {
+		statusParts = append(statusParts, renderUserRating(item.UserRating))
+	}
 
 	switch item.WatchStatus() {
 	case domain.WatchStatusWatched:
@@ -248,11 +360,46 @@ func renderMediaHeader(item domain.MediaItem, width int) string {
 
 	if len(statusParts) > 0 {
 		b.WriteString(strings.Join(statusParts, "   "))
+		b.WriteString("\n")
+	}
+
+	// When it aired, arrived and was last watched
+	var dateParts []string
+	if item.AiredAt > 0 && item.Type == domain.MediaTypeEpisode {
+		dateParts = append(dateParts, "Aired "+format.Date(time.Unix(item.AiredAt, 0)))
+	}
+	if item.AddedAt > 0 {
+		dateParts = append(dateParts, "Added "+format.Date(time.Unix(item.AddedAt, 0)))
+	}
+	if item.LastViewedAt > 0 {
+		dateParts = append(dateParts, "Watched "+format.DateTime(time.Unix(item.LastViewedAt, 0)))
+	}
+	if len(dateParts) > 0 {
+		b.WriteString(styles.DimStyle.Render(wordWrap(strings.Join(dateParts, " · "), width)))
 	}
 
 	return strings.TrimRight(b.String(), "\n")
 }
 
+// renderExternalIDs lists the known IMDb/TMDB/TVDB IDs on one line, empty
+// when there are none
+func renderExternalIDs(ids domain.ExternalIDs, width int) string {
+	var parts []string
+	if ids.IMDb != "" {
+		parts = append(parts, "IMDb "+ids.IMDb)
+	}
+	if ids.TMDB != "" {
+		parts = append(parts, "TMDB "+ids.TMDB)
+	}
+	if ids.TVDB != "" {
+		parts = append(parts, "TVDB "+ids.TVDB)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return styles.DimStyle.Render(styles.Truncate(strings.Join(parts, " · "), width))
+}
+
 func renderMediaBody(item domain.MediaItem, width int) string {
 	if item.Summary == "" {
 		return ""
@@ -265,6 +412,96 @@ func renderMediaBody(item domain.MediaItem, width int) string {
 	return styles.SubtitleStyle.Render(summary)
 }
 
+// maxCast caps the cast listed in the inspector
+const maxCast = 10
+
+// withDetails adds an item's full metadata to its summary: the tagline
+// above it, then credits and chapters below, with at most castLimit of the
+// cast (0 = all). Without details the summary is returned as is.
+func withDetails(summary string, d *domain.ItemDetails, width, castLimit int) string {
+	if d == nil {
+		return summary
+	}
+	bodyWidth := min(width-2, 80)
+
+	var sections []string
+	if d.Tagline != "" {
+		sections = append(sections, styles.AccentStyle.Render(wordWrap(d.Tagline, bodyWidth)))
+	}
+	if summary != "" {
+		sections = append(sections, summary)
+	}
+
+	var credits []string
+	addCredit := func(label string, names []string) {
+		if len(names) > 0 {
+			credits = append(credits, styles.DimStyle.Render(wordWrap(label+": "+strings.Join(names, ", "), bodyWidth)))
+		}
+	}
+	addCredit("Genres", d.Genres)
+	addCredit("Director", creditNames(d.Directors))
+	addCredit("Writers", d.Writers)
+	if d.Studio != "" {
+		addCredit("Studio", []string{d.Studio})
+	}
+	if len(credits) > 0 {
+		sections = append(sections, strings.Join(credits, "\n"))
+	}
+
+	if len(d.Cast) > 0 {
+		lines := []string{styles.DimStyle.Render("Cast:")}
+		for n, c := range d.Cast {
+			if n == castLimit {
+				lines = append(lines, styles.DimStyle.Render(fmt.Sprintf("  +%d more", len(d.Cast)-castLimit)))
+				break
+			}
+			line := c.Name
+			if c.Role != "" {
+				line += " as " + c.Role
+			}
+			lines = append(lines, styles.SubtitleStyle.Render(styles.Truncate("  "+line, width)))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	if len(d.Chapters) > 0 {
+		lines := []string{styles.DimStyle.Render("Chapters:")}
+		for _, c := range d.Chapters {
+			line := fmt.Sprintf("  %s  %s", formatDuration(c.Start), c.Title)
+			lines = append(lines, styles.SubtitleStyle.Render(styles.Truncate(line, width)))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// creditNames returns the names of credited people
+func creditNames(credits []domain.Credit) []string {
+	names := make([]string, len(credits))
+	for i, c := range credits {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// withFiles fills in the media details a fast listing left out of an item
+// from its full metadata, once that has loaded
+func withFiles(item domain.MediaItem, details *domain.ItemDetails) domain.MediaItem {
+	if details == nil || len(details.Files) == 0 || item.VideoCodec != "" || item.FileSize > 0 {
+		return item
+	}
+	f := details.Files[0]
+	item.FileSize, item.Bitrate = f.FileSize, f.Bitrate
+	item.Width, item.Height = f.Width, f.Height
+	item.VideoCodec, item.AudioCodec, item.AudioChannels = f.VideoCodec, f.AudioCodec, f.AudioChannels
+	item.Container = f.Container
+	if len(details.Files) > 1 {
+		item.Versions = details.Files
+	}
+	return item
+}
+
 func renderMediaFooter(item domain.MediaItem, width int) string {
 	hasTech := item.VideoCodec != "" || item.AudioCodec != "" ||
 		item.Container != "" || item.FileSize > 0
@@ -333,10 +570,27 @@ func renderMediaFooter(item domain.MediaItem, width int) string {
 	b.WriteString("\n")
 	b.WriteString(styles.DimStyle.Render(padTo(row2c1, col1W) + spacer + padTo(row2c2, col2W) + spacer + row2c3))
 
+	// Every file when there are several; the rows above describe the first
+	if len(item.Versions) > 1 {
+		b.WriteString("\n")
+		b.WriteString(styles.DimStyle.Render(fmt.Sprintf("%d versions:", len(item.Versions))))
+		for n, v := range item.Versions {
+			b.WriteString("\n")
+			b.WriteString(styles.SubtitleStyle.Render(styles.Truncate(fmt.Sprintf("  %d. %s", n+1, v.Label()), width)))
+		}
+	}
+
 	return strings.TrimRight(b.String(), "\n")
 }
 
 func (i Inspector) renderShowInspector(show domain.Show, width int) inspectorContent {
+	return inspectorContent{
+		header: renderShowHeader(show, width),
+		body:   withDetails(renderShowBody(show, width), i.itemDetails(show.ID), width, maxCast),
+	}
+}
+
+func renderShowHeader(show domain.Show, width int) string {
 	var header strings.Builder
 
 	// Title
@@ -355,6 +609,10 @@ func (i Inspector) renderShowInspector(show domain.Show, width int) inspectorCon
 		header.WriteString(styles.DimStyle.Render(strings.Join(metaParts, " · ")))
 		header.WriteString("\n")
 	}
+	if ids := renderExternalIDs(show.External, width); ids != "" {
+		header.WriteString(ids)
+		header.WriteString("\n")
+	}
 
 	// Rating
 	if show.Rating > 0 {
@@ -371,6 +629,10 @@ func (i Inspector) renderShowInspector(show domain.Show, width int) inspectorCon
 		header.WriteString(ratingStyle.Render(ratingText))
 		header.WriteString("\n")
 	}
+	if show.UserRating > 0 {
+		header.WriteString(renderUserRating(show.UserRating))
+		header.WriteString("\n")
+	}
 
 	// Season/Episode counts and progress
 	header.WriteString(styles.DimStyle.Render(fmt.Sprintf("Seasons: %d", show.SeasonCount)))
@@ -385,19 +647,61 @@ func (i Inspector) renderShowInspector(show domain.Show, width int) inspectorCon
 	}
 	header.WriteString(styles.DimStyle.Render(fmt.Sprintf("Progress: %.0f%% (%d/%d)", progress, watched, show.EpisodeCount)))
 
-	// Body: summary
-	bodyStr := ""
-	if show.Summary != "" {
-		bodyWidth := width - 2
-		if bodyWidth > 80 {
-			bodyWidth = 80
+	return strings.TrimRight(header.String(), "\n")
+}
+
+func renderShowBody(show domain.Show, width int) string {
+	if show.Summary == "" {
+		return ""
+	}
+	bodyWidth := width - 2
+	if bodyWidth > 80 {
+		bodyWidth = 80
+	}
+	return styles.SubtitleStyle.Render(wordWrap(show.Summary, bodyWidth))
+}
+
+// renderPeek lists the peeked item's children: seasons with watch progress,
+// or episodes with their watch state
+func (i Inspector) renderPeek(width int) inspectorContent {
+	parent := i.item.(domain.ListItem)
+	header := styles.TitleStyle.Render(styles.Truncate(parent.GetTitle(), width))
+
+	switch {
+	case i.peek.loading:
+		return inspectorContent{header: header, body: styles.DimStyle.Render("Loading...")}
+	case i.peek.failed:
+		return inspectorContent{header: header, body: styles.ErrorStyle.Render("Failed to load")}
+	case len(i.peek.items) == 0:
+		return inspectorContent{header: header, body: styles.DimStyle.Render("Nothing here")}
+	}
+
+	var b strings.Builder
+	for _, child := range i.peek.items {
+		var line string
+		switch v := child.(type) {
+		case *domain.Season:
+			watched := v.EpisodeCount - v.UnwatchedCount
+			line = fmt.Sprintf("%s  %d/%d", v.DisplayTitle(), watched, v.EpisodeCount)
+		case *domain.MediaItem:
+			line = fmt.Sprintf("%2d. %s", v.EpisodeNum, v.Title)
+		default:
+			line = child.GetTitle()
 		}
-		bodyStr = styles.SubtitleStyle.Render(wordWrap(show.Summary, bodyWidth))
+		line = styles.Truncate(line, width)
+		switch child.GetWatchStatus() {
+		case domain.WatchStatusWatched:
+			line = styles.PlayedStyle.Render(line)
+		case domain.WatchStatusInProgress:
+			line = styles.InProgressStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
 	}
 
 	return inspectorContent{
-		header: strings.TrimRight(header.String(), "\n"),
-		body:   bodyStr,
+		header: header,
+		body:   strings.TrimRight(b.String(), "\n"),
 	}
 }
 
@@ -464,6 +768,43 @@ func (i Inspector) renderLibraryInspector(lib *domain.Library, width int) string
 	return b.String()
 }
 
+// renderUserRating renders the user's own rating, set with Keys.Rate
+func renderUserRating(rating float64) string {
+	return lipgloss.NewStyle().Foreground(styles.PlexOrange).Render(fmt.Sprintf("Your rating %g/10", rating))
+}
+
+// renderWatchlistInspector shows a watchlisted title and whether the server
+// has it
+func (i Inspector) renderWatchlistInspector(item domain.WatchlistItem, width int) string {
+	var b strings.Builder
+
+	title := item.Title
+	if item.Year > 0 {
+		title = fmt.Sprintf("%s (%d)", item.Title, item.Year)
+	}
+	b.WriteString(styles.TitleStyle.Render(styles.Truncate(title, width)))
+	b.WriteString("\n\n")
+
+	kind := "Movie"
+	if item.Type == domain.MediaTypeShow {
+		kind = "Show"
+	}
+	b.WriteString(styles.DimStyle.Render(kind))
+	b.WriteString("\n")
+	if item.Available() {
+		b.WriteString(styles.PlayedStyle.Render("On this server"))
+	} else {
+		b.WriteString(styles.DimStyle.Render("Not on this server"))
+	}
+	b.WriteString("\n")
+
+	if item.Summary != "" {
+		b.WriteString("\n")
+		b.WriteString(wordWrap(item.Summary, width))
+	}
+	return b.String()
+}
+
 func (i Inspector) renderPlaylistInspector(playlist domain.Playlist, width int) string {
 	var b strings.Builder
 
@@ -471,6 +812,11 @@ func (i Inspector) renderPlaylistInspector(playlist domain.Playlist, width int)
 	b.WriteString(styles.TitleStyle.Render(styles.Truncate(playlist.Title, width)))
 	b.WriteString("\n\n")
 
+	if playlist.Description != "" {
+		b.WriteString(styles.SubtitleStyle.Render(wordWrap(playlist.Description, width)))
+		b.WriteString("\n\n")
+	}
+
 	// Playlist type
 	typeLabel := "Video"
 	if playlist.PlaylistType == "audio" {
@@ -493,13 +839,7 @@ func (i Inspector) renderPlaylistInspector(playlist domain.Playlist, width int)
 
 	// Duration
 	if playlist.Duration > 0 {
-		hours := int(playlist.Duration.Hours())
-		minutes := int(playlist.Duration.Minutes()) % 60
-		if hours > 0 {
-			b.WriteString(styles.DimStyle.Render(fmt.Sprintf("Duration: %dh %dm", hours, minutes)))
-		} else {
-			b.WriteString(styles.DimStyle.Render(fmt.Sprintf("Duration: %dm", minutes)))
-		}
+		b.WriteString(styles.DimStyle.Render("Duration: " + format.Duration(playlist.Duration)))
 		b.WriteString("\n")
 	}
 
@@ -508,6 +848,8 @@ func (i Inspector) renderPlaylistInspector(playlist domain.Playlist, width int)
 	b.WriteString("\n")
 	b.WriteString(styles.DimStyle.Render("n: New Playlist"))
 	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("e: Edit Playlist"))
+	b.WriteString("\n")
 	b.WriteString(styles.DimStyle.Render("x: Delete Playlist"))
 
 	return b.String()
@@ -73,6 +73,7 @@ type GlobalSearchKeyMap struct {
 	Enter  key.Binding
 	Up     key.Binding
 	Down   key.Binding
+	Lookup key.Binding
 }
 
 // DefaultGlobalSearchKeyMap returns the default global search key bindings
@@ -94,6 +95,10 @@ func DefaultGlobalSearchKeyMap() GlobalSearchKeyMap {
 			key.WithKeys("down", "ctrl+n"),
 			key.WithHelp("↓/C-n", "next"),
 		),
+		Lookup: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "look up on Sonarr/Radarr"),
+		),
 	}
 }
 
@@ -185,10 +190,150 @@ func DefaultSortModalKeyMap() SortModalKeyMap {
 	}
 }
 
+// ResumeModalKeyMap defines key bindings for the resume prompt
+type ResumeModalKeyMap struct {
+	Up        key.Binding
+	Down      key.Binding
+	Resume    key.Binding
+	StartOver key.Binding
+	Enter     key.Binding
+	Escape    key.Binding
+}
+
+// DefaultResumeModalKeyMap returns the default resume prompt key bindings
+func DefaultResumeModalKeyMap() ResumeModalKeyMap {
+	return ResumeModalKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/↑", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/↓", "down"),
+		),
+		Resume: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "resume"),
+		),
+		StartOver: key.NewBinding(
+			key.WithKeys("s", "p"),
+			key.WithHelp("s", "start over"),
+		),
+		Enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "select"),
+		),
+		Escape: key.NewBinding(
+			key.WithKeys("esc", "q", "h"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+}
+
+// VersionModalKeyMap defines key bindings for the version picker
+type VersionModalKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Enter  key.Binding
+	Escape key.Binding
+}
+
+// DefaultVersionModalKeyMap returns the default version picker key bindings
+func DefaultVersionModalKeyMap() VersionModalKeyMap {
+	return VersionModalKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/↑", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/↓", "down"),
+		),
+		Enter: key.NewBinding(
+			key.WithKeys("enter", "l"),
+			key.WithHelp("enter", "play"),
+		),
+		Escape: key.NewBinding(
+			key.WithKeys("esc", "q", "h"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+}
+
+// RatingModalKeyMap defines key bindings for the rating picker. The digits
+// 1-9 and 0 (for 10) pick a rating directly.
+type RatingModalKeyMap struct {
+	Lower  key.Binding
+	Raise  key.Binding
+	Clear  key.Binding
+	Enter  key.Binding
+	Escape key.Binding
+}
+
+// DefaultRatingModalKeyMap returns the default rating picker key bindings
+func DefaultRatingModalKeyMap() RatingModalKeyMap {
+	return RatingModalKeyMap{
+		Lower: key.NewBinding(
+			key.WithKeys("h", "left", "j", "down"),
+			key.WithHelp("h/←", "lower"),
+		),
+		Raise: key.NewBinding(
+			key.WithKeys("l", "right", "k", "up"),
+			key.WithHelp("l/→", "raise"),
+		),
+		Clear: key.NewBinding(
+			key.WithKeys("x", "backspace"),
+			key.WithHelp("x", "clear rating"),
+		),
+		Enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "rate"),
+		),
+		Escape: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+}
+
+// LibraryModalKeyMap defines key bindings for the library visibility modal
+type LibraryModalKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Toggle key.Binding
+	Close  key.Binding
+}
+
+// DefaultLibraryModalKeyMap returns the default library modal key bindings
+func DefaultLibraryModalKeyMap() LibraryModalKeyMap {
+	return LibraryModalKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/↑", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/↓", "down"),
+		),
+		Toggle: key.NewBinding(
+			key.WithKeys(" ", "enter"),
+			key.WithHelp("space", "show/hide"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("esc", "q", "H"),
+			key.WithHelp("esc", "done"),
+		),
+	}
+}
+
 // Package-level key map instances
 var (
 	ListColumnKeys    = DefaultListColumnKeyMap()
 	GlobalSearchKeys  = DefaultGlobalSearchKeyMap()
 	PlaylistModalKeys = DefaultPlaylistModalKeyMap()
 	SortModalKeys     = DefaultSortModalKeyMap()
+	ResumeModalKeys   = DefaultResumeModalKeyMap()
+	VersionModalKeys  = DefaultVersionModalKeyMap()
+	RatingModalKeys   = DefaultRatingModalKeyMap()
+	LibraryModalKeys  = DefaultLibraryModalKeyMap()
 )
@@ -0,0 +1,139 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// LibraryModal is a checkbox list of the server's libraries: unchecked
+// ones are hidden from the library column, sync and search
+type LibraryModal struct {
+	visible   bool
+	libraries []domain.Library
+	shown     map[string]bool // Library ID -> checked
+	cursor    int
+
+	width  int
+	height int
+}
+
+// NewLibraryModal creates a new library modal
+func NewLibraryModal() LibraryModal {
+	return LibraryModal{shown: make(map[string]bool)}
+}
+
+// Show displays the modal with every library, checking the visible ones
+func (m *LibraryModal) Show(libraries []domain.Library, hidden func(domain.Library) bool) {
+	m.visible = true
+	m.libraries = libraries
+	m.cursor = 0
+	m.shown = make(map[string]bool, len(libraries))
+	for _, lib := range libraries {
+		m.shown[lib.ID] = !hidden(lib)
+	}
+}
+
+// Hide dismisses the modal
+func (m *LibraryModal) Hide() {
+	m.visible = false
+}
+
+// IsVisible returns whether the modal is shown
+func (m *LibraryModal) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize sets the modal dimensions
+func (m *LibraryModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// HiddenIDs returns the IDs of the unchecked libraries, in server order
+func (m *LibraryModal) HiddenIDs() []string {
+	var ids []string
+	for _, lib := range m.libraries {
+		if !m.shown[lib.ID] {
+			ids = append(ids, lib.ID)
+		}
+	}
+	return ids
+}
+
+// HandleKeyMsg processes a key message, returns (handled, done). done
+// means the modal closed and HiddenIDs holds the choice.
+func (m *LibraryModal) HandleKeyMsg(msg tea.KeyMsg) (handled bool, done bool) {
+	if !m.visible {
+		return false, false
+	}
+
+	switch {
+	case key.Matches(msg, LibraryModalKeys.Down):
+		if m.cursor < len(m.libraries)-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, LibraryModalKeys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, LibraryModalKeys.Toggle):
+		if m.cursor < len(m.libraries) {
+			id := m.libraries[m.cursor].ID
+			m.shown[id] = !m.shown[id]
+		}
+	case key.Matches(msg, LibraryModalKeys.Close):
+		m.visible = false
+		return true, true
+	}
+	return true, false // Consume all keys when visible
+}
+
+// View renders the library modal
+func (m *LibraryModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	modalWidth := 40
+	if m.width > 0 && m.width < 60 {
+		modalWidth = m.width - 10
+	}
+
+	var lines []string
+	lines = append(lines, styles.ModalTitleStyle.Render("Libraries"))
+	lines = append(lines, "")
+
+	for i, lib := range m.libraries {
+		checkbox := "[ ]"
+		if m.shown[lib.ID] {
+			checkbox = "[x]"
+		}
+		line := styles.Pad(checkbox+" "+styles.Truncate(lib.Name, modalWidth-10), modalWidth-4)
+
+		switch {
+		case i == m.cursor:
+			line = lipgloss.NewStyle().Foreground(styles.White).Background(styles.SlateLight).Render(line)
+		case m.shown[lib.ID]:
+			line = lipgloss.NewStyle().Foreground(styles.PlexOrange).Render(line)
+		default:
+			line = lipgloss.NewStyle().Foreground(styles.DimGray).Render(line)
+		}
+		lines = append(lines, "  "+line)
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, styles.DimStyle.Render("Space: Show/hide  Esc: Done"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.PlexOrange).
+		Background(styles.SlateDark).
+		Padding(1, 2).
+		Width(modalWidth).
+		Render(strings.Join(lines, "\n"))
+}
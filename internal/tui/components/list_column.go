@@ -1,6 +1,7 @@
 package components
 
 import (
+	"cmp"
 	"fmt"
 	"sort"
 	"strings"
@@ -11,6 +12,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/format"
+	"github.com/mmcdole/kino/internal/i18n"
 	"github.com/mmcdole/kino/internal/tui/styles"
 	"github.com/sahilm/fuzzy"
 )
@@ -23,6 +26,10 @@ const (
 
 	// Scroll indicators ("↑ more" and "↓ more") each take 1 line
 	ScrollIndicatorLines = 2
+
+	// firstItemRow is the row of the first visible item: below the top
+	// border, the title and the "↑ more" indicator
+	firstItemRow = 3
 )
 
 // ListColumn is a scrollable list column that can display various content types.
@@ -48,8 +55,10 @@ type ListColumn struct {
 
 	// Loading state
 	loading      bool
-	refreshing   bool // background refresh in progress; items stay visible
-	loadFailed   bool // last load errored; renders a retry hint instead of a spinner
+	refreshing   bool   // background refresh in progress; items stay visible
+	loadFailed   bool   // last load errored; renders a retry hint instead of a spinner
+	loadErrWhat  string // what failed to load ("seasons"), shown in the inline error
+	loadErrWhy   string // why, in the user's terms ("server unreachable")
 	spinnerFrame int
 
 	// Library sync states (for library column)
@@ -60,15 +69,30 @@ type ListColumn struct {
 	sortDir   SortDirection
 	sortedIdx []int // sorted position → raw index (nil = default order)
 
+	// preferredSort replaces the column type's built-in order each time
+	// items are set (SortDefault = built-in)
+	preferredSort    SortField
+	preferredSortDir SortDirection
+
 	// Filter state
 	filterActive bool
 	filterInput  textinput.Model
 	filterQuery  string
 	filteredIdx  []int // indices into sorted slice (or raw if no sort)
 
+	// watchFilter narrows the list by watch status, on top of the query
+	watchFilter WatchFilter
+
+	// pendingView is a view state to restore once items arrive
+	pendingView *ViewState
+
+	// Multi-select marks (item IDs) for batch operations
+	marked map[string]bool
+
 	// Display settings
-	showWatchStatus   bool // Whether to show watch status indicators
-	showLibraryCounts bool // Whether to keep library item counts visible after sync
+	showWatchStatus   bool          // Whether to show watch status indicators
+	showLibraryCounts bool          // Whether to keep library item counts visible after sync
+	newWindow         time.Duration // Shows/seasons with an episode added this recently get a NEW badge (0 = off)
 
 	// Content identity for race condition prevention
 	contentID string
@@ -203,6 +227,30 @@ func (c *ListColumn) Update(msg tea.Msg) (*ListColumn, tea.Cmd) {
 	return c, nil
 }
 
+// ItemAtRow returns the list position drawn at row y of the column (0 is
+// the top border), or -1 when y is not on an item
+func (c *ListColumn) ItemAtRow(y int) int {
+	if c.loading {
+		return -1
+	}
+	row := y - firstItemRow
+	if row < 0 || row >= c.maxVisible {
+		return -1
+	}
+	if pos := c.offset + row; pos < c.ItemCount() {
+		return pos
+	}
+	return -1
+}
+
+// ScrollBy moves the cursor delta rows, clamped to the list (mouse wheel)
+func (c *ListColumn) ScrollBy(delta int) {
+	if c.ItemCount() == 0 {
+		return
+	}
+	c.SetSelectedIndex(c.cursor + delta)
+}
+
 func (c *ListColumn) View() string {
 	style := styles.InactiveBorder
 	if c.focused {
@@ -310,10 +358,32 @@ func (c *ListColumn) SetRefreshing(refreshing bool) {
 }
 
 // SetLoadFailed marks the column's load as failed, replacing the infinite
-// spinner with an actionable retry hint.
-func (c *ListColumn) SetLoadFailed() {
+// spinner with an actionable retry hint. what names the content for the
+// inline message ("seasons"); empty falls back to a generic message. why,
+// if set, says what went wrong.
+func (c *ListColumn) SetLoadFailed(what, why string) {
 	c.loading = false
+	c.refreshing = false
 	c.loadFailed = true
+	c.loadErrWhat = what
+	c.loadErrWhy = why
+}
+
+// IsLoadFailed returns true if the last load errored with nothing to show
+func (c *ListColumn) IsLoadFailed() bool {
+	return c.loadFailed && len(c.items) == 0
+}
+
+// BeginReload starts a reload: a column with content keeps it visible
+// behind a title spinner, while an empty or failed column goes back to the
+// full loading state so the inline error is replaced by a spinner.
+func (c *ListColumn) BeginReload() {
+	if len(c.items) == 0 {
+		c.loading = true
+		c.loadFailed = false
+		return
+	}
+	c.refreshing = true
 }
 
 func (c *ListColumn) IsRefreshing() bool {
@@ -327,6 +397,7 @@ func (c *ListColumn) SetItems(rawItems interface{}) {
 	c.offset = 0
 	c.clearFilter()
 	c.sortedIdx = nil
+	c.marked = nil
 
 	if rawItems == nil {
 		c.items = nil
@@ -335,6 +406,83 @@ func (c *ListColumn) SetItems(rawItems interface{}) {
 		return
 	}
 
+	c.setContent(rawItems)
+
+	// Apply default sort for sortable column types
+	if c.columnSortable() {
+		switch {
+		case c.preferredSort != SortDefault:
+			c.sortField = c.preferredSort
+			c.sortDir = c.preferredSortDir
+		case c.columnType == ColumnTypeEpisodes:
+			c.sortField = SortEpisodeNum
+			c.sortDir = SortAsc
+		default:
+			c.sortField = SortTitle
+			c.sortDir = SortAsc
+		}
+		c.buildSortedIdx()
+	} else {
+		c.sortField = SortDefault
+		c.sortDir = SortAsc
+		c.sortedIdx = nil
+	}
+	c.filteredIdx = c.visibleIndices()
+
+	if c.pendingView != nil {
+		view := *c.pendingView
+		c.pendingView = nil
+		c.RestoreViewState(view)
+	}
+}
+
+// ViewState is what the user set up in a column that rebuilding it from
+// the same content would lose: the filter query and the selected item
+type ViewState struct {
+	FilterQuery string
+	SelectedID  string
+}
+
+// ViewState captures the column's filter query and selection
+func (c *ListColumn) ViewState() ViewState {
+	var view ViewState
+	if c.filterActive {
+		view.FilterQuery = c.filterQuery
+	}
+	if idx := c.mapIndex(c.cursor); c.cursor < c.ItemCount() && idx < len(c.items) {
+		view.SelectedID = c.items[idx].GetID()
+	}
+	return view
+}
+
+// RestoreViewState re-applies a filter query and selection captured by
+// ViewState. On a column still loading it waits for SetItems. A selected
+// item the filter no longer matches leaves the cursor on the first match.
+func (c *ListColumn) RestoreViewState(view ViewState) {
+	if len(c.items) == 0 {
+		c.pendingView = &view
+		return
+	}
+	if view.FilterQuery != "" {
+		c.filterActive = true
+		c.filterInput.SetValue(view.FilterQuery)
+		c.filterInput.Blur()
+		c.filterQuery = view.FilterQuery
+		c.filteredIdx = c.visibleIndices()
+		c.recalcMaxVisible()
+		c.cursor, c.offset = 0, 0
+	}
+	if view.SelectedID != "" {
+		if i := c.indexOfID(view.SelectedID); i >= 0 {
+			c.cursor = i
+		}
+	}
+	c.ensureVisible()
+}
+
+// setContent wraps a typed domain slice into c.items and settles the column
+// type. It touches no view state (cursor, sort, filter).
+func (c *ListColumn) setContent(rawItems interface{}) {
 	switch v := rawItems.(type) {
 	case []domain.Library:
 		c.items = WrapLibraries(v)
@@ -348,6 +496,12 @@ func (c *ListColumn) SetItems(rawItems interface{}) {
 			c.items = WrapPlaylistItems(v)
 		case c.columnType == ColumnTypeEpisodes:
 			c.items = WrapEpisodes(v)
+		case c.columnType == ColumnTypeChannels:
+			c.items = WrapChannels(v)
+		case c.columnType == ColumnTypeCalendar:
+			c.items = WrapEpisodes(v)
+		case c.columnType == ColumnTypeContinue:
+			c.items = WrapPlaylistItems(v)
 		case len(v) > 0 && v[0].Type == domain.MediaTypeEpisode:
 			c.items = WrapEpisodes(v)
 			c.columnType = ColumnTypeEpisodes
@@ -364,6 +518,9 @@ func (c *ListColumn) SetItems(rawItems interface{}) {
 	case []*domain.Playlist:
 		c.items = WrapPlaylists(v)
 		c.columnType = ColumnTypePlaylists
+	case []*domain.WatchlistItem:
+		c.items = WrapWatchlist(v)
+		c.columnType = ColumnTypeWatchlist
 	case []domain.ListItem:
 		c.items = v
 		// columnType should already be set, default to mixed if not
@@ -371,35 +528,23 @@ func (c *ListColumn) SetItems(rawItems interface{}) {
 			c.columnType = ColumnTypeMixed
 		}
 	}
-
-	// Apply default sort for sortable column types
-	if c.columnSortable() {
-		if c.columnType == ColumnTypeEpisodes {
-			c.sortField = SortEpisodeNum
-			c.sortDir = SortAsc
-		} else {
-			c.sortField = SortTitle
-			c.sortDir = SortAsc
-		}
-		c.buildSortedIdx()
-	} else {
-		c.sortField = SortDefault
-		c.sortDir = SortAsc
-		c.sortedIdx = nil
-	}
 }
 
-// ReplaceItems swaps the column's content while preserving the user's view
-// state: cursor (matched by item ID), sort, and filter all survive the swap.
-// Background refreshes use this so the list doesn't jump; on a column with no
-// prior content it behaves exactly like SetItems.
+// ReplaceItems merges refreshed content into the column without resetting
+// the user's view state: the active sort is re-applied to the new items, an
+// active filter is re-run, and the cursor follows the selected item by ID,
+// keeping it on the same screen row where possible. Items added "between"
+// existing ones just slot into place. On a column with no prior content it
+// behaves exactly like SetItems.
 func (c *ListColumn) ReplaceItems(rawItems interface{}) {
 	c.refreshing = false
 
-	if len(c.items) == 0 {
+	if len(c.items) == 0 || rawItems == nil {
 		c.SetItems(rawItems)
 		return
 	}
+	c.loading = false
+	c.loadFailed = false
 
 	// Capture view state
 	var selectedID string
@@ -407,34 +552,111 @@ func (c *ListColumn) ReplaceItems(rawItems interface{}) {
 		selectedID = c.items[idx].GetID()
 	}
 	prevCursor := c.cursor
-	sortField, sortDir := c.sortField, c.sortDir
-	filterActive := c.filterActive
-	filterQuery := c.filterInput.Value()
-	filterTyping := c.filterInput.Focused()
+	screenRow := c.cursor - c.offset
 
-	c.SetItems(rawItems)
+	c.setContent(rawItems)
+	c.pruneMarks()
 
-	// Restore sort
-	if c.columnSortable() && sortField != SortDefault {
-		c.sortField = sortField
-		c.sortDir = sortDir
+	// Re-sort in place; non-sortable columns keep server order
+	if c.columnSortable() && c.sortField != SortDefault {
 		c.buildSortedIdx()
+	} else {
+		c.sortedIdx = nil
 	}
 
-	// Restore filter
-	if filterActive {
-		c.filterActive = true
-		c.filterInput.SetValue(filterQuery)
-		if filterTyping {
-			c.filterInput.Focus()
+	// Re-run the filter against the new content
+	c.filteredIdx = c.visibleIndices()
+
+	// Restore cursor: by ID first, clamped index as fallback
+	c.cursor = prevCursor
+	if selectedID != "" {
+		if i := c.indexOfID(selectedID); i >= 0 {
+			c.cursor = i
 		}
-		c.recalcMaxVisible()
-		c.applyFilter()
+	}
+	if max := c.ItemCount() - 1; c.cursor > max {
+		c.cursor = max
+	}
+	if c.cursor < 0 {
+		c.cursor = 0
 	}
 
-	// Restore cursor: by ID first, clamped index as fallback
-	if selectedID == "" || !c.SetSelectedByID(selectedID) {
-		c.SetSelectedIndex(prevCursor)
+	// Keep the selected row where the user was looking at it
+	c.offset = c.cursor - screenRow
+	if c.offset < 0 {
+		c.offset = 0
+	}
+	c.ensureVisible()
+}
+
+// ToggleMark flips the multi-select mark on the selected media item and
+// advances the cursor, so consecutive presses mark a run of items. Returns
+// false when the selection can't be marked (shows, seasons, libraries).
+func (c *ListColumn) ToggleMark() bool {
+	item := c.SelectedMediaItem()
+	if item == nil || item.Type == domain.MediaTypeChannel {
+		return false
+	}
+	if c.marked[item.ID] {
+		delete(c.marked, item.ID)
+	} else {
+		if c.marked == nil {
+			c.marked = make(map[string]bool)
+		}
+		c.marked[item.ID] = true
+	}
+	if c.cursor < c.ItemCount()-1 {
+		c.cursor++
+		c.ensureVisible()
+	}
+	return true
+}
+
+// ClearMarks drops all multi-select marks
+func (c *ListColumn) ClearMarks() {
+	c.marked = nil
+}
+
+// MarkedCount returns the number of marked items
+func (c *ListColumn) MarkedCount() int {
+	return len(c.marked)
+}
+
+// MarkedMediaItems returns the marked items in display (sorted) order.
+// Marks hidden by an active filter are still included.
+func (c *ListColumn) MarkedMediaItems() []*domain.MediaItem {
+	if len(c.marked) == 0 {
+		return nil
+	}
+	var out []*domain.MediaItem
+	for i := 0; i < c.sortedCount(); i++ {
+		rawIdx := i
+		if c.sortedIdx != nil {
+			rawIdx = c.sortedIdx[i]
+		}
+		if rawIdx >= len(c.items) {
+			continue
+		}
+		if item, ok := c.items[rawIdx].(*domain.MediaItem); ok && c.marked[item.ID] {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// pruneMarks drops marks for items no longer present after a content swap
+func (c *ListColumn) pruneMarks() {
+	if len(c.marked) == 0 {
+		return
+	}
+	present := make(map[string]bool, len(c.items))
+	for _, item := range c.items {
+		present[item.GetID()] = true
+	}
+	for id := range c.marked {
+		if !present[id] {
+			delete(c.marked, id)
+		}
 	}
 }
 
@@ -453,6 +675,39 @@ func (c *ListColumn) ApplyWatchState(itemID string, played bool) (*domain.MediaI
 	return nil, false
 }
 
+// ApplyUserRating patches the user's rating of a movie, episode or show in
+// this column's items
+func (c *ListColumn) ApplyUserRating(itemID string, rating float64) {
+	for _, item := range c.items {
+		switch v := item.(type) {
+		case *domain.MediaItem:
+			if v.ID == itemID {
+				v.UserRating = rating
+			}
+		case *domain.Show:
+			if v.ID == itemID {
+				v.UserRating = rating
+			}
+		}
+	}
+}
+
+// RemoveItem drops the item with the given ID (deleted on the server),
+// keeping the view state as ReplaceItems does. Reports whether it was there.
+func (c *ListColumn) RemoveItem(itemID string) bool {
+	kept := make([]domain.ListItem, 0, len(c.items))
+	for _, item := range c.items {
+		if item.GetID() != itemID {
+			kept = append(kept, item)
+		}
+	}
+	if len(kept) == len(c.items) {
+		return false
+	}
+	c.ReplaceItems(kept)
+	return true
+}
+
 // AdjustUnwatchedCounts shifts the unwatched counter on matching show and
 // season rows (used when an episode's watch state is toggled in place).
 func (c *ListColumn) AdjustUnwatchedCounts(showID, seasonID string, delta int) {
@@ -507,6 +762,12 @@ func (c *ListColumn) SetShowLibraryCounts(show bool) {
 	c.showLibraryCounts = show
 }
 
+// SetNewWindow sets how recently a show or season's latest episode must
+// have been added for its NEW badge (0 disables the badge)
+func (c *ListColumn) SetNewWindow(window time.Duration) {
+	c.newWindow = window
+}
+
 // SetContentID sets the content identity for race condition prevention
 func (c *ListColumn) SetContentID(id string) {
 	c.contentID = id
@@ -557,7 +818,8 @@ func (c *ListColumn) SelectedSeason() *domain.Season {
 // SelectedMediaItem returns the selected media item (if in movies/episodes/playlist items/mixed column)
 func (c *ListColumn) SelectedMediaItem() *domain.MediaItem {
 	switch c.columnType {
-	case ColumnTypeMovies, ColumnTypeEpisodes, ColumnTypePlaylistItems:
+	case ColumnTypeMovies, ColumnTypeEpisodes, ColumnTypePlaylistItems, ColumnTypeChannels, ColumnTypeCalendar,
+		ColumnTypeContinue:
 		item := c.SelectedItem()
 		if item == nil {
 			return nil
@@ -591,20 +853,41 @@ func (c *ListColumn) SelectedPlaylist() *domain.Playlist {
 	return item.(*domain.Playlist)
 }
 
+// SelectedWatchlistItem returns the selected title (if in the watchlist column)
+func (c *ListColumn) SelectedWatchlistItem() *domain.WatchlistItem {
+	if c.columnType != ColumnTypeWatchlist {
+		return nil
+	}
+	item := c.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	return item.(*domain.WatchlistItem)
+}
+
 // SetSelectedByID finds an item by ID and selects it. Returns true on success.
 func (c *ListColumn) SetSelectedByID(id string) bool {
 	if id == "" {
 		return true
 	}
+	if i := c.indexOfID(id); i >= 0 {
+		c.SetSelectedIndex(i)
+		return true
+	}
+	return false
+}
+
+// indexOfID returns the visible (sorted and filtered) position of the item
+// with the given ID, or -1 if it isn't visible
+func (c *ListColumn) indexOfID(id string) int {
 	count := c.filteredCount()
 	for i := 0; i < count; i++ {
 		rawIdx := c.mapIndex(i)
 		if rawIdx < len(c.items) && c.items[rawIdx].GetID() == id {
-			c.SetSelectedIndex(i)
-			return true
+			return i
 		}
 	}
-	return false
+	return -1
 }
 
 // ToggleFilter activates the filter input
@@ -624,6 +907,83 @@ func (c *ListColumn) IsFilterTyping() bool {
 	return c.filterActive && c.filterInput.Focused()
 }
 
+// WatchFilter narrows a column to items in a given watch state
+type WatchFilter int
+
+const (
+	WatchFilterAll        WatchFilter = iota
+	WatchFilterUnwatched              // hides watched items; in-progress stays
+	WatchFilterInProgress             // only partially watched items
+)
+
+// String returns the filter's display name
+func (f WatchFilter) String() string {
+	switch f {
+	case WatchFilterUnwatched:
+		return "Unwatched"
+	case WatchFilterInProgress:
+		return "In Progress"
+	default:
+		return "All"
+	}
+}
+
+// next returns the filter after f in the All → Unwatched → In Progress cycle
+func (f WatchFilter) next() WatchFilter {
+	return (f + 1) % 3
+}
+
+// matches reports whether an item in status passes the filter
+func (f WatchFilter) matches(status domain.WatchStatus) bool {
+	switch f {
+	case WatchFilterUnwatched:
+		return status != domain.WatchStatusWatched
+	case WatchFilterInProgress:
+		return status == domain.WatchStatusInProgress
+	default:
+		return true
+	}
+}
+
+// watchFilterable reports whether the column's items carry a watch status
+func (c *ListColumn) watchFilterable() bool {
+	switch c.columnType {
+	case ColumnTypeMovies, ColumnTypeShows, ColumnTypeMixed, ColumnTypeSeasons,
+		ColumnTypeEpisodes, ColumnTypePlaylistItems, ColumnTypeCalendar:
+		return true
+	default:
+		return false
+	}
+}
+
+// CycleWatchFilter advances the watch filter and re-applies it, keeping the
+// selection when it is still visible. Returns false on columns without
+// watch status.
+func (c *ListColumn) CycleWatchFilter() (WatchFilter, bool) {
+	if !c.watchFilterable() {
+		return c.watchFilter, false
+	}
+	var selectedID string
+	if idx := c.mapIndex(c.cursor); c.cursor < c.ItemCount() && idx < len(c.items) {
+		selectedID = c.items[idx].GetID()
+	}
+	c.watchFilter = c.watchFilter.next()
+	c.filteredIdx = c.visibleIndices()
+	c.cursor, c.offset = 0, 0
+	if selectedID != "" {
+		if i := c.indexOfID(selectedID); i >= 0 {
+			c.cursor = i
+		}
+	}
+	c.ensureVisible()
+	return c.watchFilter, true
+}
+
+// WatchFilter returns the column's current watch filter
+func (c *ListColumn) WatchFilter() WatchFilter {
+	return c.watchFilter
+}
+
 // ClearFilter deactivates the filter and shows all items
 func (c *ListColumn) ClearFilter() {
 	c.clearFilter()
@@ -661,7 +1021,7 @@ func (c *ListColumn) ensureVisible() {
 func (c *ListColumn) clearFilter() {
 	c.filterActive = false
 	c.filterQuery = ""
-	c.filteredIdx = nil
+	c.filteredIdx = c.visibleIndices()
 	c.filterInput.SetValue("")
 	c.filterInput.Blur()
 	c.recalcMaxVisible()
@@ -670,13 +1030,48 @@ func (c *ListColumn) clearFilter() {
 func (c *ListColumn) applyFilter() {
 	query := c.filterInput.Value()
 	c.filterQuery = query
-
+	c.filteredIdx = c.visibleIndices()
 	if query == "" {
-		c.filteredIdx = nil
 		return
 	}
 
-	// Get filter values from items
+	// Reset cursor to first match
+	c.cursor = 0
+	c.offset = 0
+}
+
+// visibleIndices combines the fuzzy query and the watch filter into a
+// filteredIdx value: nil when neither narrows the list, so an empty result
+// is non-nil
+func (c *ListColumn) visibleIndices() []int {
+	var idx []int
+	if c.filterActive && c.filterQuery != "" {
+		idx = c.filterMatches(c.filterQuery)
+	}
+	if c.watchFilter == WatchFilterAll || !c.watchFilterable() {
+		return idx
+	}
+	if idx == nil {
+		idx = make([]int, c.sortedCount())
+		for i := range idx {
+			idx[i] = i
+		}
+	}
+	kept := make([]int, 0, len(idx))
+	for _, i := range idx {
+		rawIdx := i
+		if c.sortedIdx != nil && i < len(c.sortedIdx) {
+			rawIdx = c.sortedIdx[i]
+		}
+		if rawIdx < len(c.items) && c.watchFilter.matches(c.items[rawIdx].GetWatchStatus()) {
+			kept = append(kept, i)
+		}
+	}
+	return kept
+}
+
+// filterMatches returns the sorted-slice indices of items matching query
+func (c *ListColumn) filterMatches(query string) []int {
 	titles := c.getFilterValues()
 	lowerTitles := make([]string, len(titles))
 	for i, t := range titles {
@@ -685,14 +1080,11 @@ func (c *ListColumn) applyFilter() {
 
 	matches := fuzzy.Find(strings.ToLower(query), lowerTitles)
 
-	c.filteredIdx = make([]int, len(matches))
+	idx := make([]int, len(matches))
 	for i, match := range matches {
-		c.filteredIdx[i] = match.Index
+		idx[i] = match.Index
 	}
-
-	// Reset cursor to first match
-	c.cursor = 0
-	c.offset = 0
+	return idx
 }
 
 func (c *ListColumn) getFilterValues() []string {
@@ -747,8 +1139,11 @@ func (c *ListColumn) renderContent() string {
 	// Title line (styled, truncated to fit column width); background
 	// refreshes show a spinner next to the title while items stay visible
 	title := c.title
+	if c.watchFilter != WatchFilterAll && c.watchFilterable() {
+		title += " · " + c.watchFilter.String()
+	}
 	if c.refreshing {
-		title = c.title + " " + styles.SpinnerFrames[c.spinnerFrame%len(styles.SpinnerFrames)]
+		title += " " + styles.SpinnerFrames[c.spinnerFrame%len(styles.SpinnerFrames)]
 	}
 	titleLine := styles.AccentStyle.Render(styles.Truncate(title, itemWidth))
 
@@ -761,15 +1156,22 @@ func (c *ListColumn) renderContent() string {
 
 	// Failed load: actionable dead-end instead of an infinite spinner
 	if c.loadFailed && len(c.items) == 0 {
-		failedLine := styles.ErrorStyle.Render("✗ Failed to load")
+		failedText := "✗ Failed to load"
+		if c.loadErrWhat != "" {
+			failedText += " " + c.loadErrWhat
+		}
+		failedLine := styles.ErrorStyle.Render(styles.Truncate(failedText, itemWidth))
 		retryLine := styles.DimStyle.Render("press r to retry")
+		if c.loadErrWhy != "" {
+			failedLine += "\n" + styles.DimStyle.Render(styles.Truncate(c.loadErrWhy, itemWidth))
+		}
 		return titleLine + "\n" + " " + "\n" + failedLine + "\n" + retryLine
 	}
 
 	count := c.ItemCount()
 	if count == 0 {
 		emptyMsg := styles.DimStyle.Render("No items")
-		if c.filterActive && c.filterQuery != "" {
+		if c.filterActive && c.filterQuery != "" || len(c.items) > 0 {
 			emptyMsg = styles.DimStyle.Render("No matches")
 		}
 		content := titleLine + "\n" + " " + "\n" + emptyMsg + "\n" + " "
@@ -790,7 +1192,12 @@ func (c *ListColumn) renderContent() string {
 	for i := c.offset; i < end; i++ {
 		selected := i == c.cursor
 		idx := c.mapIndex(i)
-		line := c.renderItem(idx, selected, itemWidth)
+		var line string
+		if idx < len(c.items) && c.marked[c.items[idx].GetID()] {
+			line = c.renderMarkedItem(idx, selected, itemWidth)
+		} else {
+			line = c.renderItem(idx, selected, itemWidth)
+		}
 		lines = append(lines, line)
 	}
 
@@ -844,11 +1251,29 @@ func (c *ListColumn) renderItem(idx int, selected bool, width int) string {
 		return c.renderPlaylistMediaItem(*item.(*domain.MediaItem), selected, width)
 	case ColumnTypeMixed:
 		return c.renderMixedItem(item, selected, width)
+	case ColumnTypeChannels:
+		return c.renderChannelItem(*item.(*domain.MediaItem), selected, width)
+	case ColumnTypeCalendar:
+		return c.renderCalendarItem(*item.(*domain.MediaItem), selected, width)
+	case ColumnTypeContinue:
+		return c.renderContinueItem(*item.(*domain.MediaItem), selected, width)
+	case ColumnTypeWatchlist:
+		return c.renderWatchlistItem(*item.(*domain.WatchlistItem), selected, width)
 	default:
 		return ""
 	}
 }
 
+// renderMarkedItem renders a row with a multi-select marker in front. The
+// row itself is rendered one cell narrower so the total width is unchanged.
+func (c *ListColumn) renderMarkedItem(idx int, selected bool, width int) string {
+	markStyle := lipgloss.NewStyle().Foreground(styles.PlexOrange).Bold(true)
+	if selected {
+		markStyle = markStyle.Background(styles.SlateLight)
+	}
+	return markStyle.Render(styles.MarkChar) + c.renderItem(idx, selected, width-1)
+}
+
 func (c *ListColumn) renderLibraryItem(lib domain.Library, selected bool, width int) string {
 	// Get sync state for this library (works for playlists too via playlistsLibraryID)
 	state := c.libraryStates[lib.ID]
@@ -867,6 +1292,9 @@ func (c *ListColumn) renderLibraryItem(lib domain.Library, selected bool, width
 	case StatusError:
 		prefix = "✗ "
 		prefixFg = styles.Red
+	case StatusQueued:
+		prefix = "· "
+		prefixFg = styles.DimGray
 	default:
 		prefix = "  "
 		prefixFg = styles.DimGray
@@ -881,12 +1309,24 @@ func (c *ListColumn) renderLibraryItem(lib domain.Library, selected bool, width
 	} else if (state.Status == StatusSynced || c.showLibraryCounts) && state.Loaded > 0 {
 		title = fmt.Sprintf("%s (%d)", lib.Name, state.Loaded)
 	}
-	title = styles.Truncate(title, width-4)
 
-	parts := []styles.RowPart{
+	var badge string
+	if state.Status != StatusSyncing && state.HasUnwatched && state.Unwatched > 0 {
+		badge = fmt.Sprintf("%d unwatched", state.Unwatched)
+	}
+	availableForTitle := width - 4
+	if badge != "" {
+		availableForTitle -= len(badge) + 1
+	}
+	if availableForTitle < 5 {
+		availableForTitle = 5
+	}
+	title = styles.Truncate(title, availableForTitle)
+
+	parts := appendSortTag([]styles.RowPart{
 		{Text: prefix, Foreground: &prefixFg},
 		{Text: title, Foreground: nil},
-	}
+	}, badge, width)
 
 	return styles.RenderListRow(parts, selected, width)
 }
@@ -941,18 +1381,25 @@ func (c *ListColumn) renderShowItem(show domain.Show, selected bool, width int)
 	// Available space: width - indicator(1) - space(1) - margins(2)
 	availableForTitle := width - 4
 	tag := c.sortTag(&show)
+	if tag == "" {
+		tag = c.showBadge(show)
+	}
 	if tag != "" {
 		availableForTitle -= len(tag) + 1
 	}
+	isNew := c.isNew(show.LastAddedAt)
+	if isNew {
+		availableForTitle -= len(newBadge)
+	}
 	if availableForTitle < 5 {
 		availableForTitle = 5
 	}
 	title = styles.Truncate(title, availableForTitle)
 
-	parts := appendSortTag([]styles.RowPart{
+	parts := appendSortTag(appendNewBadge([]styles.RowPart{
 		{Text: indicatorChar, Foreground: &indicatorFg},
 		{Text: " " + title, Foreground: nil},
-	}, tag, width)
+	}, isNew), tag, width)
 
 	return styles.RenderListRow(parts, selected, width)
 }
@@ -970,15 +1417,19 @@ func (c *ListColumn) renderSeasonItem(season domain.Season, selected bool, width
 
 	// Available space: width - indicator(1) - space(1) - margins(2)
 	availableForTitle := width - 4
+	isNew := c.isNew(season.LastAddedAt)
+	if isNew {
+		availableForTitle -= len(newBadge)
+	}
 	if availableForTitle < 5 {
 		availableForTitle = 5
 	}
 	title = styles.Truncate(title, availableForTitle)
 
-	parts := []styles.RowPart{
+	parts := appendNewBadge([]styles.RowPart{
 		{Text: indicatorChar, Foreground: &indicatorFg},
 		{Text: " " + title, Foreground: nil},
-	}
+	}, isNew)
 
 	return styles.RenderListRow(parts, selected, width)
 }
@@ -998,8 +1449,13 @@ func (c *ListColumn) renderEpisodeItem(item domain.MediaItem, selected bool, wid
 	// Available space: width - indicator(1) - space(1) - code - space(1) - margins(2)
 	availableForTitle := width - 4 - len(code) - 1
 	tag := c.sortTag(&item)
+	if tag == "" && item.ExtraType != "" {
+		tag = item.ExtraType
+	} else if tag == "" && item.AiredAt > 0 {
+		tag = format.Date(time.Unix(item.AiredAt, 0))
+	}
 	if tag != "" {
-		availableForTitle -= len(tag) + 1
+		availableForTitle -= lipgloss.Width(tag) + 1
 	}
 	if availableForTitle < 5 {
 		availableForTitle = 5
@@ -1015,6 +1471,133 @@ func (c *ListColumn) renderEpisodeItem(item domain.MediaItem, selected bool, wid
 	return styles.RenderListRow(parts, selected, width)
 }
 
+func (c *ListColumn) renderChannelItem(item domain.MediaItem, selected bool, width int) string {
+	plexOrange := styles.PlexOrange
+	dimGray := styles.DimGray
+
+	number := item.ChannelNumber
+	if number == "" {
+		number = "-"
+	}
+
+	// Available space: width - number - space(1) - margins(2)
+	available := width - len(number) - 3
+	if available < 5 {
+		available = 5
+	}
+	title := styles.Truncate(item.Title, available)
+
+	parts := []styles.RowPart{
+		{Text: " " + number, Foreground: &plexOrange},
+		{Text: " " + title, Foreground: nil},
+	}
+
+	// Current program fills whatever room the channel name leaves
+	if item.NowPlaying != "" {
+		if room := available - lipgloss.Width(title) - 3; room >= 5 {
+			parts = append(parts, styles.RowPart{
+				Text:       " · " + styles.Truncate(item.NowPlaying, room),
+				Foreground: &dimGray,
+			})
+		}
+	}
+
+	return styles.RenderListRow(parts, selected, width)
+}
+
+// calendarDateWidth fits "Wed Jan 12"
+const calendarDateWidth = 10
+
+func (c *ListColumn) renderCalendarItem(item domain.MediaItem, selected bool, width int) string {
+	var indicatorChar string
+	var indicatorFg lipgloss.Color
+	if c.showWatchStatus {
+		indicatorChar, indicatorFg = mediaItemWatchIndicator(item)
+	} else {
+		indicatorChar = " "
+	}
+
+	// Today stands out; episodes that haven't aired yet are dimmed
+	aired := time.Unix(item.AiredAt, 0)
+	now := time.Now()
+	dateFg := styles.DimGray
+	var titleFg *lipgloss.Color
+	switch {
+	case aired.Year() == now.Year() && aired.YearDay() == now.YearDay():
+		dateFg = styles.PlexOrange
+	case aired.After(now):
+		dimGray := styles.DimGray
+		titleFg = &dimGray
+		indicatorChar = " "
+	}
+	date := i18n.DayDate(aired)
+	date += strings.Repeat(" ", max(calendarDateWidth-lipgloss.Width(date), 0))
+
+	// Available space: width - indicator(1) - space(1) - date - space(1) - margins(2)
+	available := width - 5 - calendarDateWidth
+	if available < 5 {
+		available = 5
+	}
+	title := styles.Truncate(fmt.Sprintf("%s %s %s", item.ShowTitle, item.EpisodeCode(), item.Title), available)
+
+	parts := []styles.RowPart{
+		{Text: indicatorChar, Foreground: &indicatorFg},
+		{Text: " " + date, Foreground: &dateFg},
+		{Text: " " + title, Foreground: titleFg},
+	}
+
+	return styles.RenderListRow(parts, selected, width)
+}
+
+// FirstUpcomingID returns the ID of the first item airing today or later,
+// so the calendar opens on "now" rather than a week ago
+func (c *ListColumn) FirstUpcomingID() string {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).Unix()
+	for _, it := range c.items {
+		if m, ok := it.(*domain.MediaItem); ok && m.AiredAt >= today {
+			return m.ID
+		}
+	}
+	return ""
+}
+
+// renderWatchlistItem renders a watchlisted title; those the server lacks
+// are dimmed and tagged
+func (c *ListColumn) renderWatchlistItem(item domain.WatchlistItem, selected bool, width int) string {
+	title := item.Title
+	if item.Year > 0 {
+		title = fmt.Sprintf("%s (%d)", item.Title, item.Year)
+	}
+
+	marker, markerFg := "●", styles.Green
+	var titleFg *lipgloss.Color
+	tag := ""
+	if !item.Available() {
+		marker, markerFg = "○", styles.DimGray
+		dimGray := styles.DimGray
+		titleFg = &dimGray
+		tag = "not on server"
+	}
+
+	// Available space: width - marker(1) - space(1) - margins(2)
+	available := width - 4
+	if tag != "" {
+		available -= len(tag) + 1
+	}
+	if available < 5 {
+		available = 5
+	}
+	title = styles.Truncate(title, available)
+
+	parts := appendSortTag([]styles.RowPart{
+		{Text: marker, Foreground: &markerFg},
+		{Text: " " + title, Foreground: titleFg},
+	}, tag, width)
+
+	return styles.RenderListRow(parts, selected, width)
+}
+
 func (c *ListColumn) renderFilterBar(_ int) string {
 	input := c.filterInput.View()
 	count := c.ItemCount()
@@ -1077,6 +1660,33 @@ func (c *ListColumn) renderPlaylistItem(playlist domain.Playlist, selected bool,
 	return styles.RenderListRow(parts, selected, width)
 }
 
+// renderContinueItem renders an in-progress movie or episode with how far
+// into it the user is
+func (c *ListColumn) renderContinueItem(item domain.MediaItem, selected bool, width int) string {
+	indicatorChar, indicatorFg := mediaItemWatchIndicator(item)
+
+	title := item.Title
+	if item.Type == domain.MediaTypeEpisode && item.ShowTitle != "" {
+		title = fmt.Sprintf("%s - %s %s", item.ShowTitle, item.EpisodeCode(), item.Title)
+	} else if item.Year > 0 {
+		title = fmt.Sprintf("%s (%d)", item.Title, item.Year)
+	}
+	tag := fmt.Sprintf("%d%%", item.PercentWatched())
+
+	// Available space: width - indicator(1) - space(1) - tag - space(1) - margins(2)
+	available := width - 5 - len(tag)
+	if available < 5 {
+		available = 5
+	}
+
+	parts := appendSortTag([]styles.RowPart{
+		{Text: indicatorChar, Foreground: &indicatorFg},
+		{Text: " " + styles.Truncate(title, available), Foreground: nil},
+	}, tag, width)
+
+	return styles.RenderListRow(parts, selected, width)
+}
+
 func (c *ListColumn) renderPlaylistMediaItem(item domain.MediaItem, selected bool, width int) string {
 	var indicatorChar string
 	var indicatorFg lipgloss.Color
@@ -1127,22 +1737,63 @@ func (c *ListColumn) renderMixedItem(item domain.ListItem, selected bool, width
 	// Available space: width - indicator(1) - space(1) - margins(2)
 	availableForTitle := width - 4
 	tag := c.sortTag(item)
+	var isNew bool
+	if show, ok := item.(*domain.Show); ok {
+		if tag == "" {
+			tag = c.showBadge(*show)
+		}
+		isNew = c.isNew(show.LastAddedAt)
+	}
 	if tag != "" {
 		availableForTitle -= len(tag) + 1
 	}
+	if isNew {
+		availableForTitle -= len(newBadge)
+	}
 	if availableForTitle < 5 {
 		availableForTitle = 5
 	}
 	title = styles.Truncate(title, availableForTitle)
 
-	parts := appendSortTag([]styles.RowPart{
+	parts := appendSortTag(appendNewBadge([]styles.RowPart{
 		{Text: indicatorChar, Foreground: &indicatorFg},
 		{Text: " " + title, Foreground: nil},
-	}, tag, width)
+	}, isNew), tag, width)
 
 	return styles.RenderListRow(parts, selected, width)
 }
 
+// newBadge follows the title of a show or season with a recently added
+// episode
+const newBadge = " NEW"
+
+// isNew reports whether an episode added at addedAt is inside the NEW window
+func (c *ListColumn) isNew(addedAt int64) bool {
+	return c.newWindow > 0 && addedAt > 0 && time.Since(time.Unix(addedAt, 0)) < c.newWindow
+}
+
+// appendNewBadge adds the NEW badge after the title when isNew
+func appendNewBadge(parts []styles.RowPart, isNew bool) []styles.RowPart {
+	if !isNew {
+		return parts
+	}
+	plexOrange := styles.PlexOrange
+	return append(parts, styles.RowPart{Text: newBadge, Foreground: &plexOrange})
+}
+
+// showBadge is a show row's episode tally when no sort tag takes its place:
+// unwatched out of total while anything is left, else just the total
+func (c *ListColumn) showBadge(show domain.Show) string {
+	switch {
+	case show.EpisodeCount == 0:
+		return ""
+	case c.showWatchStatus && show.UnwatchedCount > 0:
+		return fmt.Sprintf("%d/%d unwatched", show.UnwatchedCount, show.EpisodeCount)
+	default:
+		return fmt.Sprintf("%d ep", show.EpisodeCount)
+	}
+}
+
 // sortTag returns a right-aligned tag string for the current sort field, or "" if
 // sorting by the default field or the value is zero/empty.
 func (c *ListColumn) sortTag(item domain.ListItem) string {
@@ -1156,12 +1807,7 @@ func (c *ListColumn) sortTag(item domain.ListItem) string {
 		if d <= 0 {
 			return ""
 		}
-		h := int(d.Hours())
-		m := int(d.Minutes()) % 60
-		if h > 0 {
-			return fmt.Sprintf("%dh %dm", h, m)
-		}
-		return fmt.Sprintf("%dm", m)
+		return format.Duration(d)
 	case SortRating:
 		r := item.GetRating()
 		if r == 0 {
@@ -1178,7 +1824,7 @@ func (c *ListColumn) sortTag(item domain.ListItem) string {
 		if ts == 0 {
 			return ""
 		}
-		return formatMonthYear(ts)
+		return format.MonthYear(time.Unix(ts, 0))
 	case SortReleased:
 		// Skip for movies/shows since year is already in the title
 		switch c.columnType {
@@ -1212,16 +1858,11 @@ func appendSortTag(parts []styles.RowPart, tag string, width int) []styles.RowPa
 	return append(parts, styles.RowPart{Text: strings.Repeat(" ", gap) + tag, Foreground: &dimGray})
 }
 
-// formatMonthYear formats a unix timestamp as "Jan 2006"
-func formatMonthYear(ts int64) string {
-	return time.Unix(ts, 0).Format("Jan 2006")
-}
-
 // columnSortable returns true if this column type supports user-facing sorting.
 // Episodes, seasons, libraries, playlists, and playlist items keep their natural order.
 func (c *ListColumn) columnSortable() bool {
 	switch c.columnType {
-	case ColumnTypeMovies, ColumnTypeShows, ColumnTypeMixed, ColumnTypeEpisodes:
+	case ColumnTypeMovies, ColumnTypeShows, ColumnTypeMixed, ColumnTypeEpisodes, ColumnTypeContinue:
 		return true
 	default:
 		return false
@@ -1240,11 +1881,16 @@ func (c *ListColumn) ApplySort(field SortField, dir SortDirection) {
 	c.offset = 0
 
 	c.buildSortedIdx()
+	c.filteredIdx = c.visibleIndices()
+}
 
-	if c.filterActive && c.filterQuery != "" {
-		c.applyFilter()
-	} else {
-		c.filteredIdx = nil
+// SetPreferredSort makes field/dir the order the column takes whenever its
+// items are (re)set, and applies it now if items are already loaded
+func (c *ListColumn) SetPreferredSort(field SortField, dir SortDirection) {
+	c.preferredSort = field
+	c.preferredSortDir = dir
+	if field != SortDefault && c.columnSortable() && len(c.items) > 0 {
+		c.ApplySort(field, dir)
 	}
 }
 
@@ -1347,6 +1993,18 @@ func (c *ListColumn) compareBySortField(i, j int) int {
 			return 1
 		}
 		return 0
+	case SortUnwatched:
+		return watchRank(itemI.GetWatchStatus()) - watchRank(itemJ.GetWatchStatus())
+	case SortLastWatched, SortProgress:
+		miI, okI := itemI.(*domain.MediaItem)
+		miJ, okJ := itemJ.(*domain.MediaItem)
+		if !okI || !okJ {
+			return 0
+		}
+		if c.sortField == SortLastWatched {
+			return cmp.Compare(miI.LastViewedAt, miJ.LastViewedAt)
+		}
+		return cmp.Compare(miI.PercentWatched(), miJ.PercentWatched())
 	case SortEpisodeNum:
 		// Compare by season number first, then episode number
 		miI, okI := itemI.(*domain.MediaItem)
@@ -1370,3 +2028,15 @@ func (c *ListColumn) compareBySortField(i, j int) int {
 		return 0
 	}
 }
+
+// watchRank orders watch states for SortUnwatched
+func watchRank(status domain.WatchStatus) int {
+	switch status {
+	case domain.WatchStatusUnwatched:
+		return 0
+	case domain.WatchStatusInProgress:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -1,8 +1,11 @@
 package components
 
 import (
+	"strings"
 	"testing"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/mmcdole/kino/internal/domain"
 )
 
@@ -96,3 +99,312 @@ func TestReplaceItemsOnEmptyColumn(t *testing.T) {
 		t.Fatalf("expected fresh load semantics, cursor=%d count=%d", c.SelectedIndex(), c.ItemCount())
 	}
 }
+
+// An active filter is re-run against refreshed content instead of cleared,
+// and the cursor stays on the selected match.
+func TestReplaceItemsPreservesFilter(t *testing.T) {
+	c := NewListColumn(ColumnTypeMovies, "Movies")
+	c.SetSize(40, 20)
+	c.SetItems(testMovies("Alpha", "Bravo", "Brave"))
+
+	c.ToggleFilter()
+	c.filterInput.SetValue("brav")
+	c.applyFilter()
+	c.SetSelectedByID("id-Bravo")
+
+	c.ReplaceItems(testMovies("Alpha", "Bravado", "Bravo", "Brave", "Charlie"))
+
+	if !c.IsFiltering() || c.filterQuery != "brav" {
+		t.Fatalf("filter lost: active=%v query=%q", c.IsFiltering(), c.filterQuery)
+	}
+	if c.ItemCount() != 3 {
+		t.Fatalf("expected 3 filtered matches, got %d", c.ItemCount())
+	}
+	if got := selectedID(t, c); got != "id-Bravo" {
+		t.Fatalf("cursor moved off Bravo: selected %q", got)
+	}
+}
+
+// Items inserted above the selection must not scroll the selected row to a
+// different place on screen.
+func TestReplaceItemsKeepsScreenRow(t *testing.T) {
+	c := NewListColumn(ColumnTypeMovies, "Movies")
+	c.SetSize(40, 10) // 5 visible rows
+	c.SetItems(testMovies("B", "C", "D", "E", "F", "G", "H", "I"))
+	c.SetSelectedIndex(6) // H, scrolled
+	row := c.cursor - c.offset
+
+	c.ReplaceItems(testMovies("A", "B", "C", "D", "E", "F", "G", "H", "I"))
+
+	if got := selectedID(t, c); got != "id-H" {
+		t.Fatalf("selected %q, want id-H", got)
+	}
+	if c.cursor-c.offset != row {
+		t.Fatalf("screen row changed: was %d, now %d", row, c.cursor-c.offset)
+	}
+}
+
+// Marks advance the cursor, come back in display order, and survive a
+// refresh only for items that still exist.
+func TestToggleMarkAndRefresh(t *testing.T) {
+	c := NewListColumn(ColumnTypeMovies, "Movies")
+	c.SetSize(40, 20)
+	c.SetItems(testMovies("Alpha", "Bravo", "Charlie"))
+
+	c.ToggleMark() // Alpha, cursor -> Bravo
+	c.SetSelectedIndex(2)
+	c.ToggleMark() // Charlie
+
+	marked := c.MarkedMediaItems()
+	if len(marked) != 2 || marked[0].ID != "id-Alpha" || marked[1].ID != "id-Charlie" {
+		t.Fatalf("unexpected marked items: %v", marked)
+	}
+
+	c.ReplaceItems(testMovies("Bravo", "Charlie"))
+	if c.MarkedCount() != 1 {
+		t.Fatalf("expected mark on removed item to be pruned, have %d", c.MarkedCount())
+	}
+
+	c.SetItems(testMovies("Alpha"))
+	if c.MarkedCount() != 0 {
+		t.Fatal("SetItems must drop marks")
+	}
+}
+
+// A failed load renders inline and a retry puts the column back into the
+// loading state rather than leaving the error on screen.
+func TestLoadFailedAndReload(t *testing.T) {
+	c := NewListColumn(ColumnTypeSeasons, "Show")
+	c.SetSize(40, 20)
+	c.SetLoading(true)
+
+	c.SetLoadFailed("seasons", "server unreachable")
+	if !c.IsLoadFailed() || c.IsLoading() {
+		t.Fatal("expected failed, not loading")
+	}
+	if view := c.View(); !strings.Contains(view, "Failed to load seasons") || !strings.Contains(view, "server unreachable") {
+		t.Fatalf("inline error missing from view:\n%s", view)
+	}
+
+	c.BeginReload()
+	if c.IsLoadFailed() || !c.IsLoading() {
+		t.Fatal("retry must return an empty column to loading")
+	}
+}
+
+// The calendar opens on today: the first episode airing today or later,
+// not the oldest entry of the past week
+func TestCalendarFirstUpcoming(t *testing.T) {
+	now := time.Now()
+	day := func(offset int) int64 { return now.AddDate(0, 0, offset).Unix() }
+
+	c := NewListColumn(ColumnTypeCalendar, "Calendar")
+	c.SetSize(60, 20)
+	c.SetItems([]*domain.MediaItem{
+		{ID: "old", Title: "Old", Type: domain.MediaTypeEpisode, AiredAt: day(-3)},
+		{ID: "soon", Title: "Soon", Type: domain.MediaTypeEpisode, AiredAt: day(2)},
+	})
+
+	if c.ColumnType() != ColumnTypeCalendar {
+		t.Fatalf("column type changed to %v", c.ColumnType())
+	}
+	if got := c.FirstUpcomingID(); got != "soon" {
+		t.Fatalf("FirstUpcomingID = %q, want soon", got)
+	}
+	if !strings.Contains(c.View(), "Soon") {
+		t.Fatal("calendar row not rendered")
+	}
+}
+
+// The resume prompt previews the saved position and maps each row to a
+// choice; cancelling yields no choice
+func TestResumeModalChoices(t *testing.T) {
+	item := domain.MediaItem{ID: "m", Title: "Movie", ViewOffset: 47*time.Minute + 15*time.Second, Duration: 2 * time.Hour}
+	keys := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	var m ResumeModal
+	m.Show(item)
+	if !strings.Contains(m.View(), "Resume from 47:15") {
+		t.Fatalf("missing position preview:\n%s", m.View())
+	}
+	if _, choice := m.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter}); choice != ResumeChoiceResume || m.IsVisible() {
+		t.Fatalf("enter on first row = %v, visible %v", choice, m.IsVisible())
+	}
+
+	m.Show(item)
+	m.HandleKeyMsg(keys("j"))
+	if _, choice := m.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter}); choice != ResumeChoiceStartOver {
+		t.Fatalf("enter on second row = %v, want start over", choice)
+	}
+
+	m.Show(item)
+	if handled, choice := m.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc}); !handled || choice != ResumeChoiceNone || m.IsVisible() {
+		t.Fatalf("esc = (%v, %v), visible %v", handled, choice, m.IsVisible())
+	}
+}
+
+// A preferred sort survives SetItems; unwatched-first ranks unwatched, then
+// in progress, then watched
+func TestPreferredSortUnwatchedFirst(t *testing.T) {
+	movies := testMovies("Alpha", "Bravo", "Charlie")
+	movies[0].IsPlayed = true
+	movies[1].ViewOffset = 5 * time.Minute
+	movies[1].Duration = time.Hour
+
+	c := NewListColumn(ColumnTypeMovies, "Movies")
+	c.SetSize(40, 20)
+	c.SetPreferredSort(SortUnwatched, SortAsc)
+	c.SetItems(movies)
+
+	if field, _ := c.SortState(); field != SortUnwatched {
+		t.Fatalf("sort after SetItems = %v, want unwatched first", field)
+	}
+	for i, want := range []string{"id-Charlie", "id-Bravo", "id-Alpha"} {
+		c.SetSelectedIndex(i)
+		if got := selectedID(t, c); got != want {
+			t.Fatalf("row %d = %s, want %s", i, got, want)
+		}
+	}
+
+	if ParseSortField(SortUnwatched.Key()) != SortUnwatched || ParseSortField("bogus") != SortDefault {
+		t.Fatal("sort field keys do not round-trip")
+	}
+}
+
+func TestWatchFilterCycle(t *testing.T) {
+	movies := testMovies("Alpha", "Bravo", "Charlie")
+	movies[0].IsPlayed = true
+	movies[1].ViewOffset = 5 * time.Minute
+	movies[1].Duration = time.Hour
+
+	c := NewListColumn(ColumnTypeMovies, "Movies")
+	c.SetSize(40, 20)
+	c.SetItems(movies)
+	c.SetSelectedIndex(1) // Bravo
+
+	counts := map[WatchFilter]int{WatchFilterUnwatched: 2, WatchFilterInProgress: 1, WatchFilterAll: 3}
+	for _, want := range []WatchFilter{WatchFilterUnwatched, WatchFilterInProgress, WatchFilterAll} {
+		got, ok := c.CycleWatchFilter()
+		if !ok || got != want {
+			t.Fatalf("CycleWatchFilter = %v, %v; want %v", got, ok, want)
+		}
+		if c.ItemCount() != counts[want] {
+			t.Fatalf("%v: %d items, want %d", want, c.ItemCount(), counts[want])
+		}
+		if id := selectedID(t, c); id != "id-Bravo" {
+			t.Fatalf("%v: selection moved to %s", want, id)
+		}
+	}
+
+	// The watch filter stacks with the fuzzy filter and survives its clearing
+	c.CycleWatchFilter()
+	c.ToggleFilter()
+	c.filterInput.SetValue("a")
+	c.applyFilter()
+	if c.ItemCount() != 2 {
+		t.Fatalf("query + unwatched: %d items, want 2", c.ItemCount())
+	}
+	c.clearFilter()
+	if c.ItemCount() != 2 {
+		t.Fatalf("after clearing query: %d items, want 2", c.ItemCount())
+	}
+
+	// Nothing in progress still reads as filtered, not unfiltered
+	movies[1].ViewOffset = 0
+	c.CycleWatchFilter()
+	c.ReplaceItems(movies)
+	if c.ItemCount() != 0 {
+		t.Fatalf("in progress with none: %d items, want 0", c.ItemCount())
+	}
+
+	libs := NewListColumn(ColumnTypeLibraries, "Libraries")
+	if _, ok := libs.CycleWatchFilter(); ok {
+		t.Fatal("libraries column accepted a watch filter")
+	}
+}
+
+func TestUnwatchedBadges(t *testing.T) {
+	libs := NewListColumn(ColumnTypeLibraries, "Libraries")
+	libs.SetSize(50, 20)
+	libs.SetItems([]domain.Library{{ID: "tv", Name: "TV"}, {ID: "films", Name: "Films"}})
+	libs.SetLibraryStates(map[string]LibrarySyncState{
+		"tv":    {Status: StatusIdle, Unwatched: 142, HasUnwatched: true},
+		"films": {Status: StatusSyncing, Loaded: 5, Total: 10, Unwatched: 7, HasUnwatched: true},
+	})
+	view := libs.View()
+	if !strings.Contains(view, "142 unwatched") {
+		t.Fatalf("missing library badge:\n%s", view)
+	}
+	if strings.Contains(view, "7 unwatched") {
+		t.Fatalf("badge shown while syncing:\n%s", view)
+	}
+
+	shows := NewListColumn(ColumnTypeShows, "Shows")
+	shows.SetSize(50, 20)
+	shows.SetShowWatchStatus(true)
+	shows.SetItems([]*domain.Show{
+		{ID: "a", Title: "Alpha", EpisodeCount: 10, UnwatchedCount: 3},
+		{ID: "b", Title: "Bravo", EpisodeCount: 8},
+	})
+	view = shows.View()
+	if !strings.Contains(view, "3/10 unwatched") || !strings.Contains(view, "8 ep") {
+		t.Fatalf("missing show badges:\n%s", view)
+	}
+}
+
+// Rows map to list positions below the title and scroll indicator, offset
+// by the scroll position
+func TestItemAtRow(t *testing.T) {
+	c := NewListColumn(ColumnTypeMovies, "Movies")
+	c.SetItems(testMovies("a", "b", "c", "d", "e", "f", "g", "h"))
+	c.SetSize(30, 8) // 3 visible rows
+
+	if got := c.ItemAtRow(firstItemRow); got != 0 {
+		t.Fatalf("first row = %d, want 0", got)
+	}
+	if got := c.ItemAtRow(firstItemRow - 1); got != -1 {
+		t.Fatalf("indicator row = %d, want -1", got)
+	}
+	if got := c.ItemAtRow(firstItemRow + 3); got != -1 {
+		t.Fatalf("row past the list = %d, want -1", got)
+	}
+
+	c.ScrollBy(5)
+	if got := c.ItemAtRow(firstItemRow); got != 3 {
+		t.Fatalf("first row after scroll = %d, want 3", got)
+	}
+	c.ScrollBy(100)
+	if got := c.SelectedIndex(); got != 7 {
+		t.Fatalf("cursor = %d, want clamped to 7", got)
+	}
+}
+
+// Shows and seasons with a recently added episode get a NEW badge, and
+// episode rows carry their air date
+func TestNewBadgeAndAirDates(t *testing.T) {
+	now := time.Now()
+	shows := NewListColumn(ColumnTypeShows, "Shows")
+	shows.SetSize(60, 20)
+	shows.SetNewWindow(7 * 24 * time.Hour)
+	shows.SetItems([]*domain.Show{
+		{ID: "a", Title: "Fresh", LastAddedAt: now.Add(-24 * time.Hour).Unix()},
+		{ID: "b", Title: "Stale", LastAddedAt: now.Add(-30 * 24 * time.Hour).Unix()},
+	})
+	view := shows.View()
+	if strings.Count(view, "NEW") != 1 || !strings.Contains(view, "Fresh NEW") {
+		t.Fatalf("want exactly the fresh show badged:\n%s", view)
+	}
+
+	shows.SetNewWindow(0)
+	if view := shows.View(); strings.Contains(view, "NEW") {
+		t.Fatalf("badge shown with the window disabled:\n%s", view)
+	}
+
+	aired := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.Local)
+	episodes := NewListColumn(ColumnTypeEpisodes, "S01")
+	episodes.SetSize(60, 20)
+	episodes.SetItems([]*domain.MediaItem{{ID: "e1", Title: "Pilot", Type: domain.MediaTypeEpisode, SeasonNum: 1, EpisodeNum: 1, AiredAt: aired.Unix()}})
+	if view := episodes.View(); !strings.Contains(view, "Mar 5, 2024") {
+		t.Fatalf("missing air date:\n%s", view)
+	}
+}
@@ -68,3 +68,21 @@ func WrapPlaylistItems(items []*domain.MediaItem) []domain.ListItem {
 	}
 	return result
 }
+
+// WrapChannels converts a slice of Live TV channels to []domain.ListItem
+func WrapChannels(channels []*domain.MediaItem) []domain.ListItem {
+	items := make([]domain.ListItem, len(channels))
+	for i, ch := range channels {
+		items[i] = ch
+	}
+	return items
+}
+
+// WrapWatchlist converts a slice of *domain.WatchlistItem to []domain.ListItem
+func WrapWatchlist(watchlist []*domain.WatchlistItem) []domain.ListItem {
+	items := make([]domain.ListItem, len(watchlist))
+	for i, w := range watchlist {
+		items[i] = w
+	}
+	return items
+}
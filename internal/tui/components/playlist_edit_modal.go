@@ -0,0 +1,149 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// PlaylistEditModal edits a playlist's title and description
+type PlaylistEditModal struct {
+	visible     bool
+	playlistID  string
+	title       textinput.Model
+	description textinput.Model
+	focus       int // 0 = title, 1 = description
+}
+
+// NewPlaylistEditModal creates a new playlist edit modal
+func NewPlaylistEditModal() PlaylistEditModal {
+	newInput := func(placeholder string, limit int) textinput.Model {
+		ti := textinput.New()
+		ti.Placeholder = placeholder
+		ti.CharLimit = limit
+		ti.Width = 40
+		ti.Prompt = ""
+		ti.TextStyle = lipgloss.NewStyle().Foreground(styles.White)
+		ti.PlaceholderStyle = styles.DimStyle
+		return ti
+	}
+	return PlaylistEditModal{
+		title:       newInput("Title", 50),
+		description: newInput("Description", 500),
+	}
+}
+
+// Show displays the modal prefilled with the playlist's current values
+func (m *PlaylistEditModal) Show(p domain.Playlist) {
+	m.visible = true
+	m.playlistID = p.ID
+	m.title.SetValue(p.Title)
+	m.title.CursorEnd()
+	m.description.SetValue(p.Description)
+	m.description.CursorEnd()
+	m.setFocus(0)
+}
+
+// Hide dismisses the modal
+func (m *PlaylistEditModal) Hide() {
+	m.visible = false
+	m.title.Blur()
+	m.description.Blur()
+}
+
+// IsVisible returns whether the modal is shown
+func (m PlaylistEditModal) IsVisible() bool {
+	return m.visible
+}
+
+// PlaylistID returns the playlist being edited
+func (m PlaylistEditModal) PlaylistID() string {
+	return m.playlistID
+}
+
+// Values returns the entered title and description
+func (m PlaylistEditModal) Values() (title, description string) {
+	return strings.TrimSpace(m.title.Value()), strings.TrimSpace(m.description.Value())
+}
+
+func (m *PlaylistEditModal) setFocus(field int) {
+	m.focus = field
+	if field == 0 {
+		m.title.Focus()
+		m.description.Blur()
+	} else {
+		m.description.Focus()
+		m.title.Blur()
+	}
+}
+
+// Update handles input events, returns (modal, cmd, submitted)
+func (m PlaylistEditModal) Update(msg tea.Msg) (PlaylistEditModal, tea.Cmd, bool) {
+	if !m.visible {
+		return m, nil, false
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "enter":
+			return m, nil, true
+		case "esc":
+			m.Hide()
+			return m, nil, false
+		case "tab", "shift+tab", "up", "down":
+			m.setFocus(1 - m.focus)
+			return m, nil, false
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.focus == 0 {
+		m.title, cmd = m.title.Update(msg)
+	} else {
+		m.description, cmd = m.description.Update(msg)
+	}
+	return m, cmd, false
+}
+
+// View renders the edit modal
+func (m PlaylistEditModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	const modalWidth = 46
+
+	line := lipgloss.NewStyle().
+		Width(modalWidth).
+		Background(styles.SlateDark)
+
+	label := func(text string, field int) string {
+		if m.focus == field {
+			return line.Foreground(styles.PlexOrange).Render(text)
+		}
+		return line.Foreground(styles.LightGray).Render(text)
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		line.Foreground(styles.White).Bold(true).Render("Edit Playlist"),
+		line.Render(""),
+		label("Title", 0),
+		line.Render(m.title.View()),
+		line.Render(""),
+		label("Description", 1),
+		line.Render(m.description.View()),
+		line.Render(""),
+		line.Inherit(styles.DimStyle).Render("tab: switch field  enter: save  esc: cancel"),
+	)
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.PlexOrange).
+		Background(styles.SlateDark).
+		Padding(1, 2).
+		Render(content)
+}
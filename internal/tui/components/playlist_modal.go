@@ -1,6 +1,7 @@
 package components
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -21,6 +22,7 @@ type PlaylistChange struct {
 type PlaylistModal struct {
 	visible    bool
 	item       *domain.MediaItem
+	items      []*domain.MediaItem // batch mode: every marked item (item is the first)
 	playlists  []*domain.Playlist
 	membership map[string]bool // Current membership: playlist ID -> is member
 	pending    map[string]bool // Toggled state: playlist ID -> should be member
@@ -52,6 +54,7 @@ func (m *PlaylistModal) Show(playlists []*domain.Playlist, membership map[string
 	m.visible = true
 	m.playlists = playlists
 	m.item = item
+	m.items = nil
 	m.membership = membership
 	m.cursor = 0
 	m.createMode = false
@@ -65,6 +68,17 @@ func (m *PlaylistModal) Show(playlists []*domain.Playlist, membership map[string
 	}
 }
 
+// ShowBatch displays the modal for several items at once. Membership is not
+// tracked across a batch, so every box starts unchecked and checking one adds
+// all items to that playlist.
+func (m *PlaylistModal) ShowBatch(playlists []*domain.Playlist, items []*domain.MediaItem) {
+	if len(items) == 0 {
+		return
+	}
+	m.Show(playlists, map[string]bool{}, items[0])
+	m.items = items
+}
+
 // Hide dismisses the modal
 func (m *PlaylistModal) Hide() {
 	m.visible = false
@@ -87,6 +101,21 @@ func (m *PlaylistModal) Item() *domain.MediaItem {
 	return m.item
 }
 
+// ItemIDs returns the IDs of every item the modal's changes apply to
+func (m *PlaylistModal) ItemIDs() []string {
+	if len(m.items) > 0 {
+		ids := make([]string, len(m.items))
+		for i, item := range m.items {
+			ids[i] = item.ID
+		}
+		return ids
+	}
+	if m.item != nil {
+		return []string{m.item.ID}
+	}
+	return nil
+}
+
 // NewPlaylistTitle returns the title entered for new playlist creation
 func (m *PlaylistModal) NewPlaylistTitle() string {
 	return m.newTitle.Value()
@@ -207,7 +236,9 @@ func (m *PlaylistModal) View() string {
 	// Title: show which item the checkboxes affect — the modal opens async,
 	// so the cursor may have moved since it was requested
 	title := "Manage Playlists"
-	if m.item != nil {
+	if len(m.items) > 1 {
+		title = fmt.Sprintf("Add %d items to Playlist", len(m.items))
+	} else if m.item != nil {
 		title = "Add to Playlist: " + styles.Truncate(m.item.Title, 25)
 	}
 	titleLine := styles.ModalTitleStyle.Render(title)
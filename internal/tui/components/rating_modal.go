@@ -0,0 +1,118 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// ratingModalWidth is the inner width of the rating picker
+const ratingModalWidth = 36
+
+// RatingModal picks the user's 1-10 rating of an item
+type RatingModal struct {
+	visible bool
+	itemID  string
+	title   string
+	rating  int // 0 = unrated
+}
+
+// Show opens the picker for an item at its current rating
+func (m *RatingModal) Show(itemID, title string, rating float64) {
+	m.visible = true
+	m.itemID = itemID
+	m.title = title
+	m.rating = int(rating + 0.5)
+}
+
+// Hide dismisses the modal
+func (m *RatingModal) Hide() {
+	m.visible = false
+}
+
+// IsVisible returns whether the modal is shown
+func (m RatingModal) IsVisible() bool {
+	return m.visible
+}
+
+// ItemID returns the item the picker was opened for
+func (m RatingModal) ItemID() string {
+	return m.itemID
+}
+
+// Title returns the title of the item being rated
+func (m RatingModal) Title() string {
+	return m.title
+}
+
+// HandleKeyMsg processes a key press, returns (handled, chosen, rating).
+// chosen is true once a rating is confirmed or cleared (rating 0); the
+// modal closes then or on cancel.
+func (m *RatingModal) HandleKeyMsg(msg tea.KeyMsg) (handled, chosen bool, rating int) {
+	if !m.visible {
+		return false, false, 0
+	}
+
+	switch {
+	case key.Matches(msg, RatingModalKeys.Lower):
+		if m.rating > 1 {
+			m.rating--
+		}
+	case key.Matches(msg, RatingModalKeys.Raise):
+		if m.rating < 10 {
+			m.rating++
+		}
+	case key.Matches(msg, RatingModalKeys.Clear):
+		m.visible = false
+		return true, true, 0
+	case key.Matches(msg, RatingModalKeys.Enter):
+		if m.rating == 0 {
+			return true, false, 0 // Nothing picked yet
+		}
+		m.visible = false
+		return true, true, m.rating
+	case key.Matches(msg, RatingModalKeys.Escape):
+		m.visible = false
+	default:
+		if s := msg.String(); len(s) == 1 && s[0] >= '0' && s[0] <= '9' {
+			m.rating = int(s[0] - '0')
+			if m.rating == 0 {
+				m.rating = 10
+			}
+			m.visible = false
+			return true, true, m.rating
+		}
+	}
+
+	return true, false, 0 // consume all keys when visible
+}
+
+// View renders the rating picker: ten stars, the picked ones lit
+func (m RatingModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	lit := lipgloss.NewStyle().Foreground(styles.PlexOrange)
+	stars := lit.Render(strings.Repeat("★ ", m.rating)) +
+		styles.DimStyle.Render(strings.Repeat("☆ ", 10-m.rating))
+	score := "unrated"
+	if m.rating > 0 {
+		score = fmt.Sprintf("%d/10", m.rating)
+	}
+
+	content := styles.DimStyle.Render(styles.Truncate(m.title, ratingModalWidth)) + "\n\n" +
+		stars + " " + score + "\n\n" +
+		styles.DimStyle.Render("←/→ adjust · 1-9, 0 = 10 · x clear")
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.PlexOrange).
+		Background(styles.SlateDark).
+		Padding(0, 1).
+		Render(styles.ModalTitleStyle.Render("Your rating") + "\n" + content)
+}
@@ -0,0 +1,133 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/format"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// ResumeChoice is the user's answer to the resume prompt
+type ResumeChoice int
+
+const (
+	ResumeChoiceNone      ResumeChoice = iota // still open, or cancelled
+	ResumeChoiceResume                        // play from the saved position
+	ResumeChoiceStartOver                     // play from the beginning
+)
+
+// resumeModalWidth is the inner width of each option row
+const resumeModalWidth = 28
+
+// ResumeModal asks whether to resume an in-progress item or start over
+type ResumeModal struct {
+	visible bool
+	item    domain.MediaItem
+	cursor  int
+}
+
+// Show displays the prompt for an item with a saved position
+func (m *ResumeModal) Show(item domain.MediaItem) {
+	m.visible = true
+	m.item = item
+	m.cursor = 0
+}
+
+// Hide dismisses the modal
+func (m *ResumeModal) Hide() {
+	m.visible = false
+}
+
+// IsVisible returns whether the modal is shown
+func (m ResumeModal) IsVisible() bool {
+	return m.visible
+}
+
+// Item returns the item the prompt was opened for
+func (m ResumeModal) Item() domain.MediaItem {
+	return m.item
+}
+
+// options in display order; the last row is Cancel
+func (m ResumeModal) options() []string {
+	return []string{
+		"Resume from " + m.item.FormattedOffset(),
+		"Start over",
+		"Cancel",
+	}
+}
+
+// HandleKeyMsg processes a key press, returns (handled, choice). The modal
+// closes on any choice, including cancel (ResumeChoiceNone).
+func (m *ResumeModal) HandleKeyMsg(msg tea.KeyMsg) (handled bool, choice ResumeChoice) {
+	if !m.visible {
+		return false, ResumeChoiceNone
+	}
+
+	switch {
+	case key.Matches(msg, ResumeModalKeys.Down):
+		if m.cursor < len(m.options())-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, ResumeModalKeys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, ResumeModalKeys.Resume):
+		m.visible = false
+		return true, ResumeChoiceResume
+	case key.Matches(msg, ResumeModalKeys.StartOver):
+		m.visible = false
+		return true, ResumeChoiceStartOver
+	case key.Matches(msg, ResumeModalKeys.Enter):
+		m.visible = false
+		switch m.cursor {
+		case 0:
+			return true, ResumeChoiceResume
+		case 1:
+			return true, ResumeChoiceStartOver
+		}
+	case key.Matches(msg, ResumeModalKeys.Escape):
+		m.visible = false
+	}
+
+	return true, ResumeChoiceNone // consume all keys when visible
+}
+
+// View renders the resume prompt
+func (m ResumeModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	var lines []string
+	for i, opt := range m.options() {
+		style := lipgloss.NewStyle().Foreground(styles.LightGray)
+		if i == m.cursor {
+			style = lipgloss.NewStyle().Foreground(styles.White).Background(styles.SlateLight)
+		}
+		lines = append(lines, style.Render(styles.Pad("  "+opt, resumeModalWidth)))
+	}
+
+	// Position preview: how far in, out of how long
+	preview := m.item.FormattedOffset()
+	if m.item.Duration > 0 {
+		preview += " / " + format.Duration(m.item.Duration)
+	}
+	title := styles.Truncate(m.item.Title, resumeModalWidth)
+
+	content := styles.DimStyle.Render(title) + "\n" +
+		styles.DimStyle.Render(preview) + "\n\n" +
+		strings.Join(lines, "\n")
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.PlexOrange).
+		Background(styles.SlateDark).
+		Padding(0, 1).
+		Render(styles.ModalTitleStyle.Render("Resume playback?") + "\n" + content)
+}
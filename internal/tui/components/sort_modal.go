@@ -21,6 +21,9 @@ const (
 	SortDuration
 	SortRating
 	SortEpisodeNum
+	SortUnwatched   // unwatched, then in progress, then watched
+	SortLastWatched // Continue Watching only
+	SortProgress    // Continue Watching only: percent watched
 )
 
 // String returns the display name for the sort field
@@ -40,11 +43,46 @@ func (f SortField) String() string {
 		return "Rating"
 	case SortEpisodeNum:
 		return "Episode #"
+	case SortUnwatched:
+		return "Unwatched First"
+	case SortLastWatched:
+		return "Last Watched"
+	case SortProgress:
+		return "Progress"
 	default:
 		return "Unknown"
 	}
 }
 
+// sortFieldKeys are the stable names sort fields are saved under
+var sortFieldKeys = map[SortField]string{
+	SortTitle:       "title",
+	SortDateAdded:   "added",
+	SortLastUpdated: "updated",
+	SortReleased:    "released",
+	SortDuration:    "duration",
+	SortRating:      "rating",
+	SortEpisodeNum:  "episode",
+	SortUnwatched:   "unwatched",
+	SortLastWatched: "watched",
+	SortProgress:    "progress",
+}
+
+// Key returns the field's saved name; empty for SortDefault
+func (f SortField) Key() string {
+	return sortFieldKeys[f]
+}
+
+// ParseSortField returns the field saved under key, or SortDefault
+func ParseSortField(key string) SortField {
+	for f, k := range sortFieldKeys {
+		if k == key {
+			return f
+		}
+	}
+	return SortDefault
+}
+
 // SortDirection represents sort direction
 type SortDirection int
 
@@ -60,6 +98,8 @@ func DefaultDirection(field SortField) SortDirection {
 		return SortAsc // A-Z
 	case SortEpisodeNum:
 		return SortAsc // natural order
+	case SortUnwatched:
+		return SortAsc // unwatched first
 	default:
 		return SortDesc // newest/highest/longest first
 	}
@@ -67,22 +107,28 @@ func DefaultDirection(field SortField) SortDirection {
 
 // MovieSortOptions returns the available sort options for movies
 func MovieSortOptions() []SortField {
-	return []SortField{SortTitle, SortDateAdded, SortReleased, SortDuration, SortRating}
+	return []SortField{SortTitle, SortDateAdded, SortReleased, SortDuration, SortRating, SortUnwatched}
 }
 
 // ShowSortOptions returns the available sort options for shows
 func ShowSortOptions() []SortField {
-	return []SortField{SortTitle, SortDateAdded, SortLastUpdated, SortReleased, SortRating}
+	return []SortField{SortTitle, SortDateAdded, SortLastUpdated, SortReleased, SortRating, SortUnwatched}
 }
 
 // EpisodeSortOptions returns the available sort options for episodes
 func EpisodeSortOptions() []SortField {
-	return []SortField{SortEpisodeNum, SortTitle, SortDuration, SortDateAdded, SortRating}
+	return []SortField{SortEpisodeNum, SortTitle, SortDuration, SortDateAdded, SortRating, SortUnwatched}
 }
 
 // MixedSortOptions returns the available sort options for mixed content
 func MixedSortOptions() []SortField {
-	return []SortField{SortTitle, SortDateAdded, SortReleased, SortDuration, SortRating}
+	return []SortField{SortTitle, SortDateAdded, SortReleased, SortDuration, SortRating, SortUnwatched}
+}
+
+// ContinueSortOptions returns the available sort options for Continue
+// Watching
+func ContinueSortOptions() []SortField {
+	return []SortField{SortLastWatched, SortProgress, SortTitle}
 }
 
 // SortSelection represents the user's sort choice
@@ -8,6 +8,7 @@ const (
 	StatusSyncing
 	StatusSynced
 	StatusError
+	StatusQueued // Waiting for a free sync slot
 )
 
 // LibrarySyncState tracks sync progress for a single library
@@ -17,4 +18,9 @@ type LibrarySyncState struct {
 	Total     int   // Total items expected
 	FromCache bool  // Whether loaded from cache
 	Error     error // Error if any
+
+	// Unwatched is what is left to watch (episodes, for show libraries),
+	// counted from the cache once a sync settles; valid when HasUnwatched
+	Unwatched    int
+	HasUnwatched bool
 }
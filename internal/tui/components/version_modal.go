@@ -0,0 +1,111 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// versionModalWidth is the inner width of each version row
+const versionModalWidth = 52
+
+// VersionModal asks which file to play for an item with several versions
+type VersionModal struct {
+	visible bool
+	item    domain.MediaItem
+	resume  bool
+	cursor  int
+}
+
+// Show displays the picker for an item. resume is carried through to the
+// launch once a version is chosen.
+func (m *VersionModal) Show(item domain.MediaItem, resume bool) {
+	m.visible = true
+	m.item = item
+	m.resume = resume
+	m.cursor = 0
+}
+
+// Hide dismisses the modal
+func (m *VersionModal) Hide() {
+	m.visible = false
+}
+
+// IsVisible returns whether the modal is shown
+func (m VersionModal) IsVisible() bool {
+	return m.visible
+}
+
+// Item returns the item the picker was opened for
+func (m VersionModal) Item() domain.MediaItem {
+	return m.item
+}
+
+// Resume reports whether the chosen version plays from the saved position
+func (m VersionModal) Resume() bool {
+	return m.resume
+}
+
+// HandleKeyMsg processes a key press, returns (handled, chosen version).
+// The modal closes on a choice or on cancel (nil version).
+func (m *VersionModal) HandleKeyMsg(msg tea.KeyMsg) (handled bool, version *domain.MediaVersion) {
+	if !m.visible {
+		return false, nil
+	}
+
+	switch {
+	case key.Matches(msg, VersionModalKeys.Down):
+		if m.cursor < len(m.item.Versions)-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, VersionModalKeys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, VersionModalKeys.Enter):
+		m.visible = false
+		if m.cursor < len(m.item.Versions) {
+			v := m.item.Versions[m.cursor]
+			return true, &v
+		}
+	case key.Matches(msg, VersionModalKeys.Escape):
+		m.visible = false
+	}
+
+	return true, nil // consume all keys when visible
+}
+
+// View renders the version picker
+func (m VersionModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	var lines []string
+	for i, v := range m.item.Versions {
+		style := lipgloss.NewStyle().Foreground(styles.LightGray)
+		if i == m.cursor {
+			style = lipgloss.NewStyle().Foreground(styles.White).Background(styles.SlateLight)
+		}
+		row := fmt.Sprintf("  %d. %s", i+1, v.Label())
+		lines = append(lines, style.Render(styles.Pad(styles.Truncate(row, versionModalWidth), versionModalWidth)))
+		if v.Name != "" && v.Name != v.Label() {
+			lines = append(lines, styles.DimStyle.Render(styles.Truncate("     "+v.Name, versionModalWidth)))
+		}
+	}
+
+	title := styles.Truncate(m.item.Title, versionModalWidth)
+	content := styles.DimStyle.Render(title) + "\n\n" + strings.Join(lines, "\n")
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.PlexOrange).
+		Background(styles.SlateDark).
+		Padding(0, 1).
+		Render(styles.ModalTitleStyle.Render("Play which version?") + "\n" + content)
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/log"
+)
+
+// Config reloads. The config file is checked every configPollInterval and
+// read again once it was edited outside kino: display preferences, hidden
+// libraries, auto-refresh, next-episode behaviour and the log level apply at
+// once; server, player, security and other logging settings on the next
+// start.
+const configPollInterval = 5 * time.Second
+
+// ConfigCheckedMsg carries the config read again after an outside edit;
+// Config is nil when the file had not changed
+type ConfigCheckedMsg struct {
+	Config *config.Config
+	Err    error
+}
+
+// WatchConfigCmd checks the config file for outside edits after
+// configPollInterval
+func WatchConfigCmd() tea.Cmd {
+	return tea.Tick(configPollInterval, func(time.Time) tea.Msg {
+		if !config.FileChanged() {
+			return ConfigCheckedMsg{}
+		}
+		cfg, err := config.ReloadConfig()
+		return ConfigCheckedMsg{Config: cfg, Err: err}
+	})
+}
+
+// handleConfigChecked applies a reloaded config and keeps watching. A file
+// that fails to parse is reported once, until it is edited again.
+func (m Model) handleConfigChecked(msg ConfigCheckedMsg) (tea.Model, tea.Cmd) {
+	cmds := []tea.Cmd{WatchConfigCmd()}
+	if msg.Err != nil {
+		slog.Warn("failed to reload config", "error", msg.Err)
+		return m, tea.Batch(append(cmds, m.notify(NoticeError, i18n.T("status.config_reload_failed", msg.Err)))...)
+	}
+	if msg.Config == nil {
+		return m, tea.Batch(cmds...)
+	}
+
+	cfg := msg.Config
+	slog.Info("config reloaded")
+	m.UIConfig = cfg.UI
+	m.SetNextEpisode(cfg.Player.NextEpisode)
+	log.SetLevel(cfg.Logging.Level)
+	m.SetAutoRefresh(cfg.Server.AutoRefreshIntervals())
+	visibility, _ := m.applyLibraryVisibility()
+	cmds = append(cmds, visibility...)
+	cmds = append(cmds, m.scheduleAutoRefresh(m.Libraries)...)
+	cmds = append(cmds, m.notify(NoticeInfo, i18n.T("status.config_reloaded")))
+	return m, tea.Batch(cmds...)
+}
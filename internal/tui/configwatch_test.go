@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/log"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+func TestConfigReloadApplies(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	libs := []domain.Library{{ID: "1", Name: "Movies", Type: "movie"}, {ID: "2", Name: "Photos", Type: "photo"}}
+	m := Model{ColumnStack: NewColumnStack(), LibraryStates: map[string]components.LibrarySyncState{}, jobs: NewJobs(),
+		Store: st, LibraryService: library.NewService(nil, st, nil), serverLibraries: libs, Libraries: libs}
+	m.resetSyncQueue()
+
+	cfg := config.DefaultConfig()
+	cfg.UI.HiddenLibraries = []string{"photos"}
+	cfg.Server.AutoRefresh = 15
+	cfg.Logging.Level = "debug"
+	t.Cleanup(func() { log.SetLevel("INFO") })
+	updated, cmd := m.handleConfigChecked(ConfigCheckedMsg{Config: cfg})
+	m = updated.(Model)
+	if cmd == nil || len(m.Libraries) != 1 || m.autoRefreshInterval(libs[0]) != 15*time.Minute || m.autoRefreshGen != 1 {
+		t.Fatalf("reload: libraries %v, interval %v, timer generation %d",
+			m.Libraries, m.autoRefreshInterval(libs[0]), m.autoRefreshGen)
+	}
+	if log.Level() != slog.LevelDebug {
+		t.Errorf("log level = %v, want DEBUG", log.Level())
+	}
+
+	// An unchanged file only keeps watching
+	updated, _ = m.handleConfigChecked(ConfigCheckedMsg{})
+	if updated.(Model).autoRefreshGen != 1 {
+		t.Error("an unchanged config should not restart the timers")
+	}
+}
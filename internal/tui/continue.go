@@ -0,0 +1,26 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// handleDismissContinue removes the selected item from Continue Watching
+// (x). Its progress stays: playing it resumes, and brings it back.
+func (m Model) handleDismissContinue(top *components.ListColumn) (tea.Model, tea.Cmd) {
+	item := top.SelectedMediaItem()
+	if item == nil {
+		return m, nil
+	}
+	return m, DismissContinueCmd(m.LibraryService, *item)
+}
+
+// handleContinueDismissed drops the item from the Continue Watching column
+func (m Model) handleContinueDismissed(msg ContinueDismissedMsg) (tea.Model, tea.Cmd) {
+	if col := m.loadTarget(continueLibraryID); col != nil {
+		col.RemoveItem(msg.Item.ID)
+		m.updateInspector()
+	}
+	return m, m.notify(NoticeSuccess, i18n.T("status.continue_removed", msg.Item.Title))
+}
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// Continue Watching sorts by progress and drops a dismissed item, keeping
+// the cursor on its neighbour
+func TestContinueWatching(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack(), State: StateBrowsing}
+	col := components.NewListColumn(components.ColumnTypeContinue, "Continue Watching")
+	col.SetContentID(continueLibraryID)
+	col.SetItems([]*domain.MediaItem{
+		{ID: "e1", Title: "Pilot", Type: domain.MediaTypeEpisode, ShowTitle: "Lost", Duration: 100, ViewOffset: 20, LastViewedAt: 3},
+		{ID: "m1", Title: "Heat", Type: domain.MediaTypeMovie, Duration: 100, ViewOffset: 80, LastViewedAt: 2},
+		{ID: "m2", Title: "Ronin", Type: domain.MediaTypeMovie, Duration: 100, ViewOffset: 50, LastViewedAt: 1},
+	})
+	m.ColumnStack.Push(col, 0)
+
+	col.ApplySort(components.SortProgress, components.DefaultDirection(components.SortProgress))
+	if got := col.SelectedMediaItem(); got == nil || got.ID != "m1" {
+		t.Fatalf("first by progress = %+v, want the furthest along", got)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("x did not dismiss the item")
+	}
+	updated, _ = m.Update(ContinueDismissedMsg{Item: domain.MediaItem{ID: "m1", Title: "Heat"}})
+	m = updated.(Model)
+	if col.ItemCount() != 2 || col.SelectedMediaItem().ID != "m2" {
+		t.Fatalf("after dismiss: %d items, selected %+v", col.ItemCount(), col.SelectedMediaItem())
+	}
+	if !strings.Contains(m.notice.Text, "Heat") {
+		t.Fatalf("notice = %q", m.notice.Text)
+	}
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/format"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/mediaserver/httpclient"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// SetRequestTrace supplies the API request log shown by the debug overlay
+func (m *Model) SetRequestTrace(t *httpclient.Tracer) {
+	m.requestTrace = t
+}
+
+// handleDebugOverlay opens the API request overlay (D)
+func (m Model) handleDebugOverlay() (tea.Model, tea.Cmd) {
+	if m.requestTrace == nil {
+		return m.notAvailableHere(i18n.T("action.debug"))
+	}
+	m.debugOpen = true
+	return m, nil
+}
+
+// handleDebugOverlayInput closes the overlay on D or Esc; other keys are
+// swallowed while it is open
+func (m Model) handleDebugOverlayInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
+	if key.Matches(msg, Keys.Debug) || key.Matches(msg, Keys.Escape) {
+		m.debugOpen = false
+	}
+	return true, m, nil
+}
+
+// renderDebugOverlay lists the latest API requests, newest first. The
+// spinner tick redraws it, so it updates live.
+func (m Model) renderDebugOverlay() string {
+	var b strings.Builder
+	b.WriteString(styles.ModalTitleStyle.Render("API Requests"))
+	b.WriteString("\n\n")
+
+	requests := m.requestTrace.Recent()
+	rows := max(m.Height-10, 1)
+	if len(requests) == 0 {
+		b.WriteString(styles.DimStyle.Render("  No requests yet"))
+		b.WriteString("\n")
+	}
+	pathWidth := max(m.Width-50, 20)
+	for i, r := range requests {
+		if i == rows {
+			break
+		}
+		status := fmt.Sprintf("%3d", r.Status)
+		if r.Err != "" {
+			status = "ERR"
+		}
+		line := fmt.Sprintf("%s  %-6s %s  %7s  %8s  %s",
+			format.TimeSeconds(r.Start), r.Method, status,
+			r.Duration.Round(time.Millisecond), formatBytes(r.Bytes),
+			styles.Truncate(r.Path, pathWidth))
+		switch {
+		case r.Err != "" || r.Status >= 400:
+			line = styles.ErrorStyle.Render(line)
+		case r.Duration >= time.Second:
+			line = lipgloss.NewStyle().Foreground(styles.PlexOrange).Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("D/esc close"))
+
+	return lipgloss.Place(m.Width, m.Height,
+		lipgloss.Center, lipgloss.Center,
+		styles.ModalStyle.Render(b.String()))
+}
+
+// formatBytes renders a payload size compactly, e.g. "812 B" or "4.2 KB"
+func formatBytes(n int64) string {
+	const (
+		kb = 1024
+		mb = 1024 * kb
+	)
+	switch {
+	case n >= mb:
+		return fmt.Sprintf("%.1f MB", float64(n)/mb)
+	case n >= kb:
+		return fmt.Sprintf("%.1f KB", float64(n)/kb)
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
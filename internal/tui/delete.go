@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/tui/components"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// deleteConfirmWord must be typed to delete media from the server. A y/n
+// prompt is too easy to answer by reflex for something that takes the
+// files with it.
+const deleteConfirmWord = "delete"
+
+// handleDeleteItem asks to delete the selected movie, show or episode from
+// the server. Off unless ui.allow_delete is set.
+func (m Model) handleDeleteItem(top *components.ListColumn) (tea.Model, tea.Cmd) {
+	if !m.UIConfig.AllowDelete {
+		return m, m.notifyHint(NoticeInfo, i18n.T("status.remove_playlists_only"), i18n.T("hint.allow_delete"))
+	}
+	if m.LibraryService == nil || !m.LibraryService.CanDelete() {
+		return m, m.notify(NoticeInfo, i18n.T("status.no_delete"))
+	}
+	item := deletableItem(top.SelectedItem())
+	if item == nil {
+		return m.notAvailableHere(i18n.T("action.delete"))
+	}
+	m.State = StateConfirmDeleteItem
+	m.pendingDeleteItem = item
+	m.deleteEntry = ""
+	return m, nil
+}
+
+// deletableItem returns the item when it is a movie, show or episode
+func deletableItem(item interface{}) domain.ListItem {
+	switch v := item.(type) {
+	case *domain.MediaItem:
+		if v.Type == domain.MediaTypeMovie || v.Type == domain.MediaTypeEpisode {
+			return v
+		}
+	case *domain.Show:
+		return v
+	}
+	return nil
+}
+
+// handleDeleteItemInput collects the confirmation word. Enter deletes once
+// it is typed out; Esc cancels. Every key is consumed.
+func (m Model) handleDeleteItemInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		if !strings.EqualFold(m.deleteEntry, deleteConfirmWord) {
+			return m, nil
+		}
+		item := m.pendingDeleteItem
+		m.State = StateBrowsing
+		m.pendingDeleteItem = nil
+		m.deleteEntry = ""
+		libID := m.currentLibID
+		if isSyntheticLibrary(libID) {
+			libID = "" // Cross-library views: fall back to the item's own
+		}
+		return m, DeleteItemCmd(m.LibraryService, libID, item)
+	case tea.KeyEsc:
+		m.State = StateBrowsing
+		m.pendingDeleteItem = nil
+		m.deleteEntry = ""
+	case tea.KeyBackspace:
+		if n := len(m.deleteEntry); n > 0 {
+			m.deleteEntry = m.deleteEntry[:n-1]
+		}
+	case tea.KeyRunes:
+		if len(m.deleteEntry)+len(string(msg.Runes)) <= len(deleteConfirmWord) {
+			m.deleteEntry += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+// handleItemDeleted drops the deleted item from every open column
+func (m Model) handleItemDeleted(msg ItemDeletedMsg) (tea.Model, tea.Cmd) {
+	for _, col := range m.allColumns() {
+		col.RemoveItem(msg.Item.GetID())
+	}
+	m.updateInspector()
+	return m, m.notify(NoticeSuccess, i18n.T("status.deleted", msg.Item.GetTitle()))
+}
+
+// renderDeleteItemConfirmation renders the typed delete confirmation
+func (m Model) renderDeleteItemConfirmation() string {
+	var title, kind string
+	if item := m.pendingDeleteItem; item != nil {
+		title, kind = styles.Truncate(item.GetTitle(), 30), item.GetItemType()
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.ModalTitleStyle.Render(fmt.Sprintf("Delete %s from server?", kind)))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("  %q\n", title))
+	b.WriteString("  and its media files will be permanently\n")
+	b.WriteString("  deleted. This cannot be undone.\n\n")
+	b.WriteString("  Type " + deleteConfirmWord + ": ")
+	b.WriteString(styles.ErrorStyle.Render(m.deleteEntry))
+	b.WriteString("\n\n")
+	b.WriteString(styles.DimStyle.Render("  Enter to delete · Esc to cancel"))
+
+	return lipgloss.Place(m.Width, m.Height,
+		lipgloss.Center, lipgloss.Center,
+		styles.ModalStyle.Render(b.String()))
+}
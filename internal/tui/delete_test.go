@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// x deletes from the server only with ui.allow_delete set and "delete"
+// typed out; the movie then leaves the column
+func TestDeleteItem(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &fakeServer{}
+	m := Model{ColumnStack: NewColumnStack(), LibraryService: library.NewService(server, st, nil)}
+	col := components.NewListColumn(components.ColumnTypeMovies, "Movies")
+	col.SetItems([]*domain.MediaItem{
+		{ID: "m1", Title: "Heat", Type: domain.MediaTypeMovie, LibraryID: "1"},
+		{ID: "m2", Title: "Ronin", Type: domain.MediaTypeMovie, LibraryID: "1"},
+	})
+	m.ColumnStack.Push(col, 0)
+	press := func(k tea.KeyMsg) tea.Cmd {
+		updated, cmd := m.Update(k)
+		m = updated.(Model)
+		return cmd
+	}
+	x := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+
+	press(x)
+	if m.State != StateBrowsing {
+		t.Fatal("x asked to delete with ui.allow_delete off")
+	}
+
+	m.UIConfig.AllowDelete = true
+	press(x)
+	if m.State != StateConfirmDeleteItem {
+		t.Fatalf("state = %v, want the delete confirmation", m.State)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("del")})
+	if cmd := press(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || m.State != StateConfirmDeleteItem {
+		t.Fatal("Enter deleted before the word was typed out")
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ete")})
+	cmd := press(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.State != StateBrowsing {
+		t.Fatal("typing delete and Enter did not delete")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+
+	if !slices.Equal(server.deleted, []string{"m1"}) {
+		t.Fatalf("server deleted %v", server.deleted)
+	}
+	if col.ItemCount() != 1 || col.SelectedMediaItem().ID != "m2" {
+		t.Fatalf("column still lists the deleted movie (%d items)", col.ItemCount())
+	}
+}
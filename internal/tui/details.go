@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/library"
+)
+
+// detailsDwell is how long an item must stay selected before its full
+// metadata is fetched, so scrolling through a list doesn't fire requests
+// per row
+const detailsDwell = 300 * time.Millisecond
+
+// DetailsDwellMsg fires when the selection has rested on an item for
+// detailsDwell. Gen matches Model.detailsGen unless the selection moved.
+type DetailsDwellMsg struct {
+	ItemID string
+	Gen    int
+}
+
+// DetailsLoadedMsg carries an item's full metadata for the inspector
+type DetailsLoadedMsg struct {
+	ItemID  string
+	Details *domain.ItemDetails
+	Err     error
+}
+
+// DetailsCmd fetches an item's full metadata. The library service caches
+// it, so returning to the item later shows it without a request.
+func DetailsCmd(svc *library.Service, itemID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		details, err := svc.FetchDetails(ctx, itemID)
+		return DetailsLoadedMsg{ItemID: itemID, Details: details, Err: err}
+	}
+}
+
+// scheduleDetails follows the selection with the inspector's full metadata:
+// cached details are shown at once, others are fetched once the selection
+// rests on the item. Nothing is fetched while the inspector is hidden.
+func (m *Model) scheduleDetails() tea.Cmd {
+	itemID := ""
+	if top := m.ColumnStack.Top(); top != nil && m.ShowInspector {
+		itemID = detailsTarget(top.SelectedItem())
+	}
+	if itemID == m.detailsItemID {
+		return nil
+	}
+	m.detailsItemID = itemID
+	m.detailsGen++
+	if itemID == "" || m.LibraryService == nil || !m.LibraryService.HasDetails() {
+		return nil
+	}
+	if details, ok := m.LibraryService.CachedDetails(itemID); ok {
+		m.Inspector.SetDetails(itemID, details)
+		return nil
+	}
+	gen := m.detailsGen
+	return tea.Tick(detailsDwell, func(time.Time) tea.Msg {
+		return DetailsDwellMsg{ItemID: itemID, Gen: gen}
+	})
+}
+
+// detailsTarget returns the ID of an item with full metadata worth
+// fetching: movies, episodes and shows. Live TV channels have none.
+func detailsTarget(item interface{}) string {
+	switch v := item.(type) {
+	case *domain.MediaItem:
+		if v.Type == domain.MediaTypeChannel {
+			return ""
+		}
+		return v.ID
+	case *domain.Show:
+		return v.ID
+	default:
+		return ""
+	}
+}
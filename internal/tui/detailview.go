@@ -0,0 +1,204 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/tui/components"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// The detail view (I) gives the selected movie, episode or show the whole
+// screen: everything the inspector shows, uncut, plus similar titles and
+// the item's actions as buttons.
+
+// detailView is the open detail view
+type detailView struct {
+	item    domain.ListItem
+	details *domain.ItemDetails
+	similar []domain.ListItem
+	offset  int // Scroll offset of the page
+	action  int // Selected button
+}
+
+// SimilarLoadedMsg carries the titles similar to an item
+type SimilarLoadedMsg struct {
+	ItemID string
+	Items  []domain.ListItem
+	Err    error
+}
+
+// SimilarCmd fetches the titles similar to an item
+func SimilarCmd(svc *library.Service, itemID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		items, err := svc.FetchSimilar(ctx, itemID)
+		return SimilarLoadedMsg{ItemID: itemID, Items: items, Err: err}
+	}
+}
+
+// DetailViewKeyMap defines the detail view key bindings
+type DetailViewKeyMap struct {
+	Up         key.Binding
+	Down       key.Binding
+	PrevAction key.Binding
+	NextAction key.Binding
+	Choose     key.Binding
+	Close      key.Binding
+}
+
+// DetailViewKeys is the detail view key bindings instance
+var DetailViewKeys = DetailViewKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("k", "up"),
+		key.WithHelp("↑", "scroll up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("↓", "scroll down"),
+	),
+	PrevAction: key.NewBinding(
+		key.WithKeys("h", "left", "shift+tab"),
+		key.WithHelp("←", "previous action"),
+	),
+	NextAction: key.NewBinding(
+		key.WithKeys("l", "right", "tab"),
+		key.WithHelp("→", "next action"),
+	),
+	Choose: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "run action"),
+	),
+	Close: key.NewBinding(
+		key.WithKeys("esc", "backspace", "I"),
+		key.WithHelp("esc", "close"),
+	),
+}
+
+// handleDetailView opens the detail view on the selected movie, episode or
+// show, fetching its full metadata and similar titles
+func (m Model) handleDetailView() (tea.Model, tea.Cmd) {
+	top := m.ColumnStack.Top()
+	if top == nil {
+		return m, nil
+	}
+	itemID := detailsTarget(top.SelectedItem())
+	if itemID == "" {
+		return m.notAvailableHere(i18n.T("action.details"))
+	}
+	m.detail = &detailView{item: top.SelectedItem().(domain.ListItem)}
+
+	svc := m.LibraryService
+	if svc == nil {
+		return m, nil
+	}
+	var cmds []tea.Cmd
+	if svc.HasDetails() {
+		if details, ok := svc.CachedDetails(itemID); ok {
+			m.detail.details = details
+		} else {
+			cmds = append(cmds, DetailsCmd(svc, itemID))
+		}
+	}
+	if media, ok := m.detail.item.(*domain.MediaItem); svc.HasSimilar() && (!ok || media.Type == domain.MediaTypeMovie) {
+		cmds = append(cmds, SimilarCmd(svc, itemID))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleSimilarLoaded shows the similar titles if the view is still open
+// on the item; a failure just leaves the section out
+func (m Model) handleSimilarLoaded(msg SimilarLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.detail != nil && msg.Err == nil && m.detail.item.GetID() == msg.ItemID {
+		m.detail.similar = msg.Items
+	}
+	return m, nil
+}
+
+// handleDetailViewInput scrolls the page and runs the selected action.
+// Quit and help still work; other keys are swallowed.
+func (m Model) handleDetailViewInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
+	if key.Matches(msg, Keys.Quit, Keys.Help) {
+		return false, m, nil
+	}
+	d := m.detail
+	actions := m.itemMenuEntries()
+	switch {
+	case key.Matches(msg, DetailViewKeys.Close):
+		m.detail = nil
+	case key.Matches(msg, DetailViewKeys.Up):
+		if d.offset > 0 {
+			d.offset--
+		}
+	case key.Matches(msg, DetailViewKeys.Down):
+		if lines, visible := m.detailPage(); d.offset < len(lines)-visible {
+			d.offset++
+		}
+	case key.Matches(msg, DetailViewKeys.PrevAction):
+		if d.action > 0 {
+			d.action--
+		}
+	case key.Matches(msg, DetailViewKeys.NextAction):
+		if d.action < len(actions)-1 {
+			d.action++
+		}
+	case key.Matches(msg, DetailViewKeys.Choose):
+		if len(actions) == 0 {
+			break
+		}
+		updated, cmd := actions[min(d.action, len(actions)-1)].run(m)
+		m = updated.(Model)
+		// Actions that move the selection (Open) leave the view
+		if top := m.ColumnStack.Top(); top == nil || detailsTarget(top.SelectedItem()) != d.item.GetID() {
+			m.detail = nil
+		}
+		return true, m, cmd
+	}
+	return true, m, nil
+}
+
+// detailPage returns the page's lines and how many fit above the buttons
+func (m Model) detailPage() (lines []string, visible int) {
+	d := m.detail
+	page := components.RenderDetailPage(d.item, d.details, d.similar, min(m.Width-4, 100))
+	// The buttons, the blank line above them and the top and bottom margins
+	return strings.Split(page, "\n"), max(m.Height-ChromeHeight-4, 1)
+}
+
+// renderDetailView renders the page, scrolled, above the action buttons
+func (m Model) renderDetailView() string {
+	height := m.Height - ChromeHeight
+	d := m.detail
+
+	lines, visible := m.detailPage()
+	offset := min(d.offset, max(len(lines)-visible, 0))
+	end := min(offset+visible, len(lines))
+	body := strings.Join(lines[offset:end], "\n")
+
+	var buttons []string
+	actions := m.itemMenuEntries()
+	for i, a := range actions {
+		if i == min(d.action, len(actions)-1) {
+			buttons = append(buttons, styles.SelectedItemStyle.Render(a.label))
+		} else {
+			buttons = append(buttons, styles.DimBadgeStyle.Render(a.label))
+		}
+	}
+	more := ""
+	if end < len(lines) {
+		more = styles.DimStyle.Render("  ↓ more")
+	}
+	bar := strings.Join(buttons, " ") + more
+
+	content := lipgloss.NewStyle().Height(visible).Render(body) + "\n\n" + bar
+	return lipgloss.NewStyle().Width(m.Width).Height(height).MaxHeight(height).Padding(1, 2).Render(content)
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+func TestDetailView(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack(), State: StateBrowsing, Width: 100, Height: 30}
+	col := components.NewListColumn(components.ColumnTypeMovies, "Movies")
+	col.SetItems([]*domain.MediaItem{
+		{ID: "m1", Title: "Heat", Type: domain.MediaTypeMovie, Year: 1995, Summary: "A thief and a detective."},
+	})
+	m.ColumnStack.Push(col, 0)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("I")})
+	m = updated.(Model)
+	if m.detail == nil || m.detail.item.GetID() != "m1" {
+		t.Fatalf("detail = %+v, want Heat", m.detail)
+	}
+	updated, _ = m.Update(SimilarLoadedMsg{ItemID: "m1", Items: []domain.ListItem{
+		&domain.MediaItem{ID: "m2", Title: "Ronin", Type: domain.MediaTypeMovie, Year: 1998},
+	}})
+	m = updated.(Model)
+	updated, _ = m.Update(SimilarLoadedMsg{ItemID: "other", Items: []domain.ListItem{&domain.MediaItem{ID: "x", Title: "Stale"}}})
+	m = updated.(Model)
+
+	view := m.renderDetailView()
+	for _, want := range []string{"Heat", "A thief and a detective.", "Ronin (1998)", "Play", "Mark watched"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail view lacks %q", want)
+		}
+	}
+	if strings.Contains(view, "Stale") {
+		t.Error("similar titles of another item were shown")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.detail != nil {
+		t.Fatal("esc should close the detail view")
+	}
+}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+)
+
+// errorHelp is a failure told plainly, with what the user can do about it
+type errorHelp struct {
+	Summary string // What went wrong ("server unreachable")
+	Action  string // What to do; empty when there is nothing to suggest
+}
+
+// describeError puts err in the user's terms by its domain.ErrorKind.
+// Unclassified errors keep their own text.
+func describeError(err error) errorHelp {
+	switch domain.KindOf(err) {
+	case domain.ErrorNetwork:
+		return errorHelp{i18n.T("error.network"), i18n.T("error.network_action")}
+	case domain.ErrorAuth:
+		return errorHelp{i18n.T("error.auth"), i18n.T("error.auth_action")}
+	case domain.ErrorNotFound:
+		return errorHelp{i18n.T("error.not_found"), i18n.T("error.not_found_action")}
+	case domain.ErrorServer:
+		return errorHelp{i18n.T("error.server"), i18n.T("error.server_action")}
+	case domain.ErrorForbidden:
+		return errorHelp{i18n.T("error.forbidden"), i18n.T("error.forbidden_action")}
+	case domain.ErrorParse:
+		return errorHelp{i18n.T("error.parse"), i18n.T("error.parse_action")}
+	default:
+		return errorHelp{Summary: err.Error()}
+	}
+}
+
+// notifyError posts a failure in the footer as what went wrong and what to
+// do about it. context names the failed action ("loading seasons").
+func (m *Model) notifyError(context string, err error) tea.Cmd {
+	help := describeError(err)
+	text := help.Summary
+	if context != "" {
+		text = context + ": " + text
+	}
+	return m.notifyHint(NoticeError, text, help.Action)
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// Failures reach the footer and the failed column in plain words, with what
+// to do about them; unclassified errors keep their own text
+func TestErrMsgDescribesFailure(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack()}
+	col := components.NewListColumn(components.ColumnTypeSeasons, "Show")
+	col.SetContentID("show-1")
+	col.SetLoading(true)
+	m.ColumnStack.Push(col, 0)
+
+	serverErr := fmt.Errorf("%w: 500 - boom", domain.ErrServerError)
+	updated, _ := m.Update(ErrMsg{Err: serverErr, Context: "loading seasons", ContentID: "show-1", What: "seasons"})
+	m = updated.(Model)
+	if m.notice.Text != "loading seasons: "+i18n.T("error.server") || m.notice.Hint == "" {
+		t.Fatalf("notice = %+v", m.notice)
+	}
+	col.SetSize(40, 20)
+	if view := col.View(); !strings.Contains(view, "the server failed the request") {
+		t.Errorf("column lacks the reason:\n%s", view)
+	}
+
+	for err, want := range map[error]domain.ErrorKind{
+		fmt.Errorf("%w: /library/metadata/9", domain.ErrItemNotFound):   domain.ErrorNotFound,
+		fmt.Errorf("failed to parse response: %w", &json.SyntaxError{}): domain.ErrorParse,
+		fmt.Errorf("%w: DELETE /Items/9", domain.ErrForbidden):          domain.ErrorForbidden,
+		context.DeadlineExceeded:                                        domain.ErrorNetwork,
+		errors.New("disk full"):                                         domain.ErrorUnknown,
+	} {
+		if got := domain.KindOf(err); got != want {
+			t.Errorf("KindOf(%v) = %d, want %d", err, got, want)
+		}
+	}
+
+	updated, _ = m.Update(ErrMsg{Err: errors.New("disk full"), Context: "saving"})
+	m = updated.(Model)
+	if m.notice.Text != "saving: disk full" || m.notice.Hint != "" {
+		t.Errorf("unclassified notice = %+v", m.notice)
+	}
+}
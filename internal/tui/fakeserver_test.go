@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"context"
+	"slices"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// fakeServer is a media server for feature tests: it records what it is
+// asked to change and answers from its fields. Methods a test doesn't set
+// up fall through to the nil embedded clients and panic.
+type fakeServer struct {
+	domain.LibraryClient
+	domain.PlaylistClient
+
+	emptyPlaylists bool               // Accepts playlists with no items
+	created        [][]string         // Item IDs of each created playlist
+	watchlist      []string           // Server item IDs on the watchlist
+	rated          map[string]float64 // Ratings by item ID
+	deleted        []string           // Deleted item IDs
+	activities     []domain.Activity  // Running tasks
+}
+
+func (s *fakeServer) CreatesEmptyPlaylists() bool { return s.emptyPlaylists }
+func (s *fakeServer) CreatePlaylist(ctx context.Context, title string, ids []string) (*domain.Playlist, error) {
+	s.created = append(s.created, ids)
+	return &domain.Playlist{ID: "new", Title: title}, nil
+}
+
+// GetWatchlist lists a title the server doesn't have, then the listed items
+func (s *fakeServer) GetWatchlist(ctx context.Context) ([]*domain.WatchlistItem, error) {
+	items := []*domain.WatchlistItem{{ID: "w-gone", Title: "Ran"}}
+	for _, id := range s.watchlist {
+		items = append(items, &domain.WatchlistItem{ID: "w-" + id, Title: id, LocalID: id})
+	}
+	return items, nil
+}
+func (s *fakeServer) AddToWatchlist(ctx context.Context, itemID string) (string, error) {
+	s.watchlist = append(s.watchlist, itemID)
+	return "w-" + itemID, nil
+}
+func (s *fakeServer) RemoveFromWatchlist(ctx context.Context, watchlistID string) error {
+	s.watchlist = slices.DeleteFunc(s.watchlist, func(id string) bool { return "w-"+id == watchlistID })
+	return nil
+}
+
+func (s *fakeServer) SetUserRating(ctx context.Context, itemID string, rating float64) error {
+	if s.rated == nil {
+		s.rated = make(map[string]float64)
+	}
+	s.rated[itemID] = rating
+	return nil
+}
+
+func (s *fakeServer) DeleteItem(ctx context.Context, itemID string) error {
+	s.deleted = append(s.deleted, itemID)
+	return nil
+}
+
+func (s *fakeServer) GetActivities(ctx context.Context) ([]domain.Activity, error) {
+	return s.activities, nil
+}
@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// Server health checks. While the server answers, it is pinged every
+// healthInterval; a ping slower than degradedLatency, or answered with an
+// error, marks it degraded.
+// Once it stops answering, it is pinged again after reconnectMin, doubling
+// up to reconnectMax until it is back.
+const (
+	healthInterval  = 30 * time.Second
+	healthTimeout   = 10 * time.Second
+	degradedLatency = 2 * time.Second
+	reconnectMin    = 2 * time.Second
+	reconnectMax    = time.Minute
+)
+
+// ServerHealth is the footer's view of the server
+type ServerHealth int
+
+const (
+	HealthUnknown ServerHealth = iota // Not checked yet
+	HealthOnline
+	HealthDegraded // Answering, but slowly
+	HealthOffline
+)
+
+// serverHealth tracks the server's reachability and what to rerun once it
+// is reachable again
+type serverHealth struct {
+	status  ServerHealth
+	gen     int           // Bumped when a check is scheduled; older ones are dropped
+	backoff time.Duration // Wait before the next reconnect attempt
+	retries []tea.Cmd     // Commands that failed while offline
+}
+
+// HealthTickMsg fires when the next health check is due. Gen matches
+// serverHealth.gen unless a check was rescheduled since.
+type HealthTickMsg struct {
+	Gen int
+}
+
+// HealthCheckedMsg carries the result of one ping
+type HealthCheckedMsg struct {
+	Gen     int
+	Latency time.Duration
+	Err     error
+}
+
+// HealthCheckCmd pings the server
+func HealthCheckCmd(svc *library.Service, gen int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), healthTimeout)
+		defer cancel()
+
+		latency, err := svc.Ping(ctx)
+		return HealthCheckedMsg{Gen: gen, Latency: latency, Err: err}
+	}
+}
+
+// scheduleHealthCheck queues the next ping after delay, superseding any
+// already queued
+func (m *Model) scheduleHealthCheck(delay time.Duration) tea.Cmd {
+	if m.LibraryService == nil {
+		return nil
+	}
+	m.health.gen++
+	gen := m.health.gen
+	if delay == 0 {
+		return HealthCheckCmd(m.LibraryService, gen)
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return HealthTickMsg{Gen: gen}
+	})
+}
+
+// handleHealthChecked updates the server's status from a ping. Coming back
+// online reruns what failed while it was away.
+func (m Model) handleHealthChecked(msg HealthCheckedMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.health.gen {
+		return m, nil
+	}
+	if errors.Is(msg.Err, domain.ErrServerOffline) {
+		if m.health.status == HealthOffline {
+			m.health.backoff = min(m.health.backoff*2, reconnectMax)
+			return m, m.scheduleHealthCheck(m.health.backoff)
+		}
+		return m, tea.Batch(m.markOffline(), m.notify(NoticeError, i18n.T("status.server_unreachable")))
+	}
+
+	wasOffline := m.health.status == HealthOffline
+	// The server answered. A rejected token is reauth's business; any
+	// other error means it is up but struggling.
+	m.health.status = HealthOnline
+	if msg.Latency > degradedLatency || (msg.Err != nil && !errors.Is(msg.Err, domain.ErrAuthFailed)) {
+		m.health.status = HealthDegraded
+	}
+	m.health.backoff = 0
+	cmds := []tea.Cmd{m.scheduleHealthCheck(healthInterval)}
+	if wasOffline {
+		cmds = append(cmds, m.health.retries...)
+		m.health.retries = nil
+		cmds = append(cmds, m.retryFailedSyncs(domain.ErrServerOffline))
+		cmds = append(cmds, m.notify(NoticeSuccess, i18n.T("status.server_online")))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// markOffline records that the server stopped answering and starts the
+// reconnect attempts. While already offline the attempts are under way.
+func (m *Model) markOffline() tea.Cmd {
+	if m.health.status == HealthOffline {
+		return nil
+	}
+	m.health.status = HealthOffline
+	m.health.backoff = reconnectMin
+	return m.scheduleHealthCheck(m.health.backoff)
+}
+
+// queueOfflineRetry keeps a command that failed while the server was
+// offline, to rerun once it is back
+func (m *Model) queueOfflineRetry(retry tea.Cmd) {
+	if retry != nil {
+		m.health.retries = append(m.health.retries, retry)
+	}
+}
+
+// renderHealth renders the footer's server status segment; empty until the
+// first check
+func (m Model) renderHealth() string {
+	switch m.health.status {
+	case HealthOnline:
+		return styles.SuccessStyle.Render("●") + styles.DimStyle.Render(" online")
+	case HealthDegraded:
+		return styles.AlertStyle.Render("● degraded")
+	case HealthOffline:
+		return styles.ErrorStyle.Render("● offline")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// An offline failure marks the server offline and keeps the command; failed
+// pings back off, and the first answer reruns the command and the library
+// syncs that failed while it was away
+func TestServerHealthReconnect(t *testing.T) {
+	m := Model{
+		ColumnStack:    NewColumnStack(),
+		LibraryService: library.NewService(nil, nil, nil),
+		Libraries:      []domain.Library{{ID: "lib", Name: "Movies", Type: "movie"}},
+		LibraryStates:  map[string]components.LibrarySyncState{},
+		jobs:           NewJobs(),
+	}
+	m.resetSyncQueue()
+	offline := fmt.Errorf("%w: connection refused", domain.ErrServerOffline)
+	m.LibraryStates["lib"] = components.LibrarySyncState{Status: components.StatusError, Error: offline}
+
+	retry := func() tea.Msg { return nil }
+	updated, _ := m.Update(ErrMsg{Err: offline, Context: "marking watched", Retry: retry})
+	m = updated.(Model)
+	if m.health.status != HealthOffline || m.health.backoff != reconnectMin || len(m.health.retries) != 1 {
+		t.Fatalf("after failure: %+v", m.health)
+	}
+	if !strings.Contains(m.renderFooter(), "● offline") {
+		t.Errorf("footer lacks the offline indicator: %q", m.renderFooter())
+	}
+
+	updated, _ = m.Update(HealthCheckedMsg{Gen: m.health.gen, Err: offline})
+	m = updated.(Model)
+	if m.health.backoff != 2*reconnectMin {
+		t.Fatalf("backoff = %s, want %s", m.health.backoff, 2*reconnectMin)
+	}
+
+	updated, _ = m.Update(HealthCheckedMsg{Gen: m.health.gen - 1, Latency: time.Millisecond})
+	m = updated.(Model)
+	if m.health.status != HealthOffline {
+		t.Fatal("a superseded check changed the status")
+	}
+
+	updated, cmd := m.Update(HealthCheckedMsg{Gen: m.health.gen, Latency: 3 * time.Second})
+	m = updated.(Model)
+	if m.health.status != HealthDegraded || len(m.health.retries) != 0 || cmd == nil {
+		t.Fatalf("after reconnect: %+v", m.health)
+	}
+	if m.LibraryStates["lib"].Status != components.StatusSyncing {
+		t.Errorf("failed sync not restarted: %+v", m.LibraryStates["lib"])
+	}
+	if m.notice.Text != i18n.T("status.server_online") {
+		t.Errorf("notice = %q", m.notice.Text)
+	}
+}
@@ -0,0 +1,401 @@
+package tui
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/format"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/tui/components"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// The home screen (ui.home, ~) covers the columns with rows of cards, one
+// row per entry of ui.home_rows, each scrolled sideways like the official
+// clients' carousels.
+const (
+	homeRowSize   = 20 // Items per row
+	homeCardWidth = 26 // Card width, border included
+	homeRowHeight = 7  // Row title, card with border and the gap below
+)
+
+// homeRow is one carousel
+type homeRow struct {
+	title   string
+	items   []domain.ListItem
+	loading bool
+	err     error
+	cursor  int
+}
+
+// homeView is the open home screen
+type homeView struct {
+	rows []homeRow
+	row  int
+}
+
+// HomeRowLoadedMsg carries a home row fetched from the server. Gen matches
+// Model.homeGen unless the home screen was reopened since.
+type HomeRowLoadedMsg struct {
+	Gen   int
+	Row   int
+	Items []domain.ListItem
+	Err   error
+}
+
+// LoadHomeRowCmd fetches one home row
+func LoadHomeRowCmd(gen, row int, fetch func(context.Context) ([]domain.ListItem, error)) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		items, err := fetch(ctx)
+		return HomeRowLoadedMsg{Gen: gen, Row: row, Items: items, Err: err}
+	}
+}
+
+// HomeKeyMap defines the home screen key bindings
+type HomeKeyMap struct {
+	Left    key.Binding
+	Right   key.Binding
+	Up      key.Binding
+	Down    key.Binding
+	Open    key.Binding
+	Refresh key.Binding
+	Close   key.Binding
+}
+
+// HomeKeys is the home screen key bindings instance
+var HomeKeys = HomeKeyMap{
+	Left: key.NewBinding(
+		key.WithKeys("h", "left"),
+		key.WithHelp("←", "previous"),
+	),
+	Right: key.NewBinding(
+		key.WithKeys("l", "right"),
+		key.WithHelp("→", "next"),
+	),
+	Up: key.NewBinding(
+		key.WithKeys("k", "up"),
+		key.WithHelp("↑", "row up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("↓", "row down"),
+	),
+	Open: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "play/open"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "refresh"),
+	),
+	Close: key.NewBinding(
+		key.WithKeys("esc", "backspace", "~"),
+		key.WithHelp("~", "libraries"),
+	),
+}
+
+// handleHome opens the home screen (~)
+func (m Model) handleHome() (tea.Model, tea.Cmd) {
+	return m, m.openHome()
+}
+
+// openHome builds the rows of ui.home_rows and starts fetching the ones
+// that come from the server
+func (m *Model) openHome() tea.Cmd {
+	m.homeGen++
+	rows, cmds := m.buildHomeRows(m.homeGen)
+	m.home = &homeView{rows: rows}
+	return tea.Batch(cmds...)
+}
+
+// buildHomeRows turns ui.home_rows into rows. Recently added rows come from
+// the cache; rows the backend cannot fill are left out.
+func (m Model) buildHomeRows(gen int) ([]homeRow, []tea.Cmd) {
+	var rows []homeRow
+	var cmds []tea.Cmd
+	fetchRow := func(title string, fetch func(context.Context) ([]domain.ListItem, error)) {
+		cmds = append(cmds, LoadHomeRowCmd(gen, len(rows), fetch))
+		rows = append(rows, homeRow{title: title, loading: true})
+	}
+
+	svc := m.LibraryService
+	for _, spec := range m.UIConfig.HomeRows {
+		kind, arg, _ := strings.Cut(spec, ":")
+		switch strings.ToLower(kind) {
+		case config.HomeRowContinue:
+			if svc != nil && svc.HasContinueWatching() {
+				fetchRow("Continue Watching", func(ctx context.Context) ([]domain.ListItem, error) {
+					items, err := svc.FetchContinueWatching(ctx)
+					return components.WrapPlaylistItems(items), err
+				})
+			}
+		case config.HomeRowNextUp:
+			if svc != nil && svc.HasNextUp() {
+				fetchRow("Next Up", func(ctx context.Context) ([]domain.ListItem, error) {
+					items, err := svc.FetchNextUp(ctx)
+					return components.WrapEpisodes(items), err
+				})
+			}
+		case config.HomeRowRecent:
+			for _, lib := range m.Libraries {
+				if arg != "" && lib.ID != arg && !strings.EqualFold(lib.Name, arg) {
+					continue
+				}
+				if items := m.recentlyAdded(lib); len(items) > 0 || arg != "" {
+					rows = append(rows, homeRow{title: "Recently Added in " + lib.Name, items: items})
+				}
+			}
+		case config.HomeRowCollection:
+			if svc != nil && svc.HasCollections() && arg != "" {
+				fetchRow(arg, func(ctx context.Context) ([]domain.ListItem, error) {
+					return svc.FetchCollectionItems(ctx, arg)
+				})
+			}
+		default:
+			rows = append(rows, homeRow{title: spec, err: errors.New("unknown row in ui.home_rows")})
+		}
+	}
+	return rows, cmds
+}
+
+// recentlyAdded is a library's newest items from the cache. A show counts
+// as added when its latest episode was.
+func (m Model) recentlyAdded(lib domain.Library) []domain.ListItem {
+	var items []domain.ListItem
+	switch lib.Type {
+	case "movie":
+		movies, _ := m.Store.GetMovies(lib.ID)
+		items = components.WrapMovies(movies)
+	case "show":
+		shows, _ := m.Store.GetShows(lib.ID)
+		items = components.WrapShows(shows)
+	case "mixed":
+		items, _ = m.Store.GetMixedContent(lib.ID)
+	default:
+		return nil
+	}
+	items = slices.Clone(items)
+	slices.SortStableFunc(items, func(a, b domain.ListItem) int {
+		return cmp.Compare(addedAt(b), addedAt(a))
+	})
+	return items[:min(len(items), homeRowSize)]
+}
+
+// addedAt is when an item last gained something new
+func addedAt(item domain.ListItem) int64 {
+	if show, ok := item.(*domain.Show); ok {
+		return max(show.AddedAt, show.LastAddedAt)
+	}
+	return item.GetAddedAt()
+}
+
+// handleHomeRowLoaded fills in a row fetched from the server
+func (m Model) handleHomeRowLoaded(msg HomeRowLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.home == nil || msg.Gen != m.homeGen || msg.Row >= len(m.home.rows) {
+		return m, nil
+	}
+	row := &m.home.rows[msg.Row]
+	row.loading = false
+	row.err = msg.Err
+	row.items = msg.Items[:min(len(msg.Items), homeRowSize)]
+	row.cursor = min(row.cursor, max(len(row.items)-1, 0))
+	return m, nil
+}
+
+// handleHomeInput moves through the rows and plays or opens the selected
+// card. Quit and help still work; other keys are swallowed.
+func (m Model) handleHomeInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
+	if key.Matches(msg, Keys.Quit, Keys.Help) {
+		return false, m, nil
+	}
+	h := m.home
+	switch {
+	case key.Matches(msg, HomeKeys.Close):
+		m.home = nil
+	case key.Matches(msg, HomeKeys.Up):
+		if h.row > 0 {
+			h.row--
+		}
+	case key.Matches(msg, HomeKeys.Down):
+		if h.row < len(h.rows)-1 {
+			h.row++
+		}
+	case len(h.rows) == 0:
+	case key.Matches(msg, HomeKeys.Left):
+		if row := &h.rows[h.row]; row.cursor > 0 {
+			row.cursor--
+		}
+	case key.Matches(msg, HomeKeys.Right):
+		if row := &h.rows[h.row]; row.cursor < len(row.items)-1 {
+			row.cursor++
+		}
+	case key.Matches(msg, HomeKeys.Open):
+		updated, cmd := m.openHomeItem()
+		return true, updated.(Model), cmd
+	case key.Matches(msg, HomeKeys.Refresh):
+		selected := h.row
+		cmd := m.openHome()
+		m.home.row = min(selected, max(len(m.home.rows)-1, 0))
+		return true, m, cmd
+	}
+	return true, m, nil
+}
+
+// openHomeItem plays the selected movie or episode, asking to resume like
+// Enter in the columns, or closes the home screen on the selected show
+func (m Model) openHomeItem() (tea.Model, tea.Cmd) {
+	row := m.home.rows[m.home.row]
+	if len(row.items) == 0 {
+		return m, nil
+	}
+	switch item := row.items[row.cursor].(type) {
+	case *domain.MediaItem:
+		if item.ShouldResume() && !m.UIConfig.AutoResume {
+			m.ResumeModal.Show(*item)
+			return m, nil
+		}
+		return m.launchItem(*item, item.ShouldResume())
+	case *domain.Show:
+		lib := m.libraryOfShow(item)
+		if lib == nil {
+			return m, m.notify(NoticeError, i18n.T("status.show_library_not_found", item.Title))
+		}
+		m.home = nil
+		return m, m.navigateToResolved(&library.Resolved{Library: *lib, Show: item})
+	}
+	return m, nil
+}
+
+// libraryOfShow finds the library holding a show: its own LibraryID, or
+// the cached library it is listed in (collections may not say)
+func (m Model) libraryOfShow(show *domain.Show) *domain.Library {
+	if lib := m.findLibrary(show.LibraryID); lib != nil {
+		return lib
+	}
+	for i, lib := range m.Libraries {
+		var items []domain.ListItem
+		switch lib.Type {
+		case "show":
+			shows, _ := m.Store.GetShows(lib.ID)
+			items = components.WrapShows(shows)
+		case "mixed":
+			items, _ = m.Store.GetMixedContent(lib.ID)
+		}
+		if slices.ContainsFunc(items, func(item domain.ListItem) bool { return item.GetID() == show.ID }) {
+			return &m.Libraries[i]
+		}
+	}
+	return nil
+}
+
+// renderHome renders the rows that fit, keeping the selected one in view
+func (m Model) renderHome() string {
+	height := m.Height - ChromeHeight
+	var b strings.Builder
+	b.WriteString(styles.AccentStyle.Render(" Home"))
+	b.WriteString(styles.DimStyle.Render("  ←/→ browse · ↑/↓ rows · enter play/open · r refresh · ~ libraries"))
+	b.WriteString("\n\n")
+
+	h := m.home
+	if len(h.rows) == 0 {
+		b.WriteString(styles.DimStyle.Render(" Nothing to show: set ui.home_rows"))
+	}
+	visible := max((height-2)/homeRowHeight, 1)
+	first := max(h.row-visible+1, 0)
+	for i := first; i < len(h.rows) && i < first+visible; i++ {
+		b.WriteString(m.renderHomeRow(h.rows[i], i == h.row))
+		b.WriteString("\n")
+	}
+
+	return lipgloss.NewStyle().Width(m.Width).Height(height).MaxHeight(height).Render(b.String())
+}
+
+// renderHomeRow renders a row's title and the cards that fit, keeping the
+// selected card in view
+func (m Model) renderHomeRow(row homeRow, focused bool) string {
+	title := " " + row.title
+	if len(row.items) > 0 {
+		title += styles.DimStyle.Render(fmt.Sprintf("  %d/%d", row.cursor+1, len(row.items)))
+	}
+	if focused {
+		title = styles.TitleStyle.Render(title)
+	} else {
+		title = styles.SubtitleStyle.Render(title)
+	}
+
+	var body string
+	switch {
+	case row.loading:
+		body = "\n " + RenderSpinner(m.SpinnerFrame) + " Loading...\n\n\n"
+	case row.err != nil:
+		body = "\n " + styles.ErrorStyle.Render(styles.Truncate(row.err.Error(), m.Width-2)) + "\n\n\n"
+	case len(row.items) == 0:
+		body = "\n " + styles.DimStyle.Render("Nothing here yet") + "\n\n\n"
+	default:
+		visible := max(m.Width/homeCardWidth, 1)
+		first := max(row.cursor-visible+1, 0)
+		var cards []string
+		for i := first; i < len(row.items) && i < first+visible; i++ {
+			cards = append(cards, renderHomeCard(row.items[i], focused && i == row.cursor))
+		}
+		body = lipgloss.JoinHorizontal(lipgloss.Top, cards...)
+	}
+	return title + "\n" + body
+}
+
+// renderHomeCard renders a card: title, what it belongs to, and progress
+// for items part way through
+func renderHomeCard(item domain.ListItem, selected bool) string {
+	inner := homeCardWidth - 4
+	subtitle := item.GetDescription()
+	footer := ""
+	if media, ok := item.(*domain.MediaItem); ok {
+		switch media.Type {
+		case domain.MediaTypeEpisode:
+			subtitle = media.ShowTitle + " · " + media.EpisodeCode()
+		case domain.MediaTypeMovie:
+			subtitle = ""
+			if media.Year > 0 {
+				subtitle = fmt.Sprint(media.Year)
+			}
+		}
+		if media.ViewOffset > 0 {
+			footer = progressBar(media.PercentWatched(), inner-5)
+		} else {
+			footer = styles.DimStyle.Render(format.Duration(media.Duration))
+		}
+	}
+
+	titleStyle := styles.SubtitleStyle
+	border := styles.InactiveBorder
+	if selected {
+		titleStyle = styles.TitleStyle
+		border = styles.ActiveBorder
+	}
+	content := titleStyle.Render(styles.Truncate(item.GetTitle(), inner)) + "\n" +
+		styles.DimStyle.Render(styles.Truncate(subtitle, inner)) + "\n" +
+		footer
+	return border.Width(homeCardWidth-2).Padding(0, 1).Render(content)
+}
+
+// progressBar draws pct as a bar of width cells followed by the number
+func progressBar(pct, width int) string {
+	filled := pct * width / 100
+	return styles.AccentStyle.Render(strings.Repeat("━", filled)) +
+		styles.DimStyle.Render(strings.Repeat("─", width-filled)) +
+		fmt.Sprintf(" %3d%%", pct)
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/store"
+)
+
+func TestHomeScreen(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	movies := []*domain.MediaItem{
+		{ID: "m1", Title: "Heat", Type: domain.MediaTypeMovie, AddedAt: 1},
+		{ID: "m2", Title: "Ronin", Type: domain.MediaTypeMovie, AddedAt: 3, Duration: 100, ViewOffset: 40},
+		{ID: "m3", Title: "Thief", Type: domain.MediaTypeMovie, AddedAt: 2},
+	}
+	if err := st.SaveMovies("1", movies, 0); err != nil {
+		t.Fatal(err)
+	}
+	m := Model{
+		ColumnStack: NewColumnStack(),
+		Store:       st,
+		State:       StateBrowsing,
+		Width:       100,
+		Height:      30,
+		Libraries:   []domain.Library{{ID: "1", Name: "Movies", Type: "movie"}, {ID: "2", Name: "TV", Type: "show"}},
+		UIConfig:    config.UIConfig{HomeRows: []string{"recent", "bogus"}},
+	}
+	key := func(k string) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = updated.(Model)
+	}
+
+	key("~")
+	if m.home == nil || len(m.home.rows) != 2 {
+		t.Fatalf("home = %+v, want the Movies row and the bad row", m.home)
+	}
+	recent := m.home.rows[0]
+	if recent.title != "Recently Added in Movies" || len(recent.items) != 3 || recent.items[0].GetID() != "m2" {
+		t.Fatalf("recent row = %+v, want newest first", recent)
+	}
+	if m.home.rows[1].err == nil {
+		t.Fatal("an unknown row kind should show an error")
+	}
+	if !strings.Contains(m.renderHome(), "Ronin") {
+		t.Fatal("the first card is not rendered")
+	}
+
+	key("l")
+	key("j")
+	if m.home.rows[0].cursor != 1 || m.home.row != 1 {
+		t.Fatalf("cursor %d row %d, want 1 and 1", m.home.rows[0].cursor, m.home.row)
+	}
+	updated, _ := m.Update(HomeRowLoadedMsg{Gen: m.homeGen - 1, Row: 1})
+	m = updated.(Model)
+	if m.home.rows[1].err == nil {
+		t.Fatal("a row from an earlier opening was applied")
+	}
+
+	// Enter on a movie part way through asks to resume
+	key("k")
+	key("h")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if !m.ResumeModal.IsVisible() {
+		t.Fatal("enter on an in-progress movie should ask to resume")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+
+	key("~")
+	if m.home != nil {
+		t.Fatal("~ should close the home screen")
+	}
+}
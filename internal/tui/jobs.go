@@ -0,0 +1,386 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// maxFinishedJobs bounds how many completed jobs the panel keeps around
+const maxFinishedJobs = 20
+
+// JobKind groups jobs by the operation they run
+type JobKind int
+
+const (
+	JobSync JobKind = iota
+	JobWatchState
+	JobPlaylistEdit
+)
+
+// JobStatus is the lifecycle state of a background job
+type JobStatus int
+
+const (
+	JobRunning JobStatus = iota
+	JobDone
+	JobFailed
+	JobCancelled
+)
+
+// String returns the panel label for the status
+func (s JobStatus) String() string {
+	switch s {
+	case JobRunning:
+		return "running"
+	case JobDone:
+		return "done"
+	case JobFailed:
+		return "failed"
+	case JobCancelled:
+		return "cancelled"
+	default:
+		return "unknown"
+	}
+}
+
+// Job is one background operation. Done/Total report progress when the
+// operation knows its size; Total 0 means indeterminate.
+type Job struct {
+	ID      int
+	Kind    JobKind
+	Label   string
+	Status  JobStatus
+	Done    int
+	Total   int
+	Err     error
+	Started time.Time
+
+	cancel context.CancelFunc
+}
+
+// Jobs is the registry of background operations. Every command that runs
+// longer than a single request registers here, so the footer, the window
+// title and the jobs panel all read one source of truth. Job ID 0 is never
+// issued; commands started outside the registry pass it and every method
+// ignores it.
+type Jobs struct {
+	list   []*Job
+	nextID int
+}
+
+// NewJobs returns an empty registry
+func NewJobs() *Jobs {
+	return &Jobs{}
+}
+
+// Start registers a running job and returns its ID and the context its
+// work must run under. Cancelling the job cancels that context.
+func (j *Jobs) Start(kind JobKind, label string, total int) (int, context.Context) {
+	ctx, cancel := context.WithCancel(context.Background())
+	j.nextID++
+	j.list = append(j.list, &Job{
+		ID:      j.nextID,
+		Kind:    kind,
+		Label:   label,
+		Total:   total,
+		Started: time.Now(),
+		cancel:  cancel,
+	})
+	j.prune()
+	return j.nextID, ctx
+}
+
+// Get returns the job with the given ID, or nil
+func (j *Jobs) Get(id int) *Job {
+	for _, job := range j.list {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+// Progress updates a running job's progress counters
+func (j *Jobs) Progress(id, done, total int) {
+	if job := j.Get(id); job != nil && job.Status == JobRunning {
+		job.Done = done
+		job.Total = total
+	}
+}
+
+// Step counts one finished unit of a multi-part job, reporting whether every
+// unit has now reported. The first error marks the job failed.
+func (j *Jobs) Step(id int, err error) bool {
+	job := j.Get(id)
+	if job == nil {
+		return true
+	}
+	job.Done++
+	if err != nil && job.Err == nil {
+		job.Err = err
+	}
+	if job.Done < job.Total {
+		return false
+	}
+	j.Finish(id, job.Err)
+	return true
+}
+
+// Finish marks a job done or failed. A cancelled job stays cancelled.
+func (j *Jobs) Finish(id int, err error) {
+	job := j.Get(id)
+	if job == nil || job.Status != JobRunning {
+		return
+	}
+	job.Err = err
+	if err != nil {
+		job.Status = JobFailed
+	} else {
+		job.Status = JobDone
+	}
+	job.cancel()
+}
+
+// Cancel stops a running job, reporting whether there was one to stop. The
+// work sees its context cancelled; its final message still arrives and is
+// recognised by Cancelled.
+func (j *Jobs) Cancel(id int) bool {
+	job := j.Get(id)
+	if job == nil || job.Status != JobRunning {
+		return false
+	}
+	job.Status = JobCancelled
+	job.cancel()
+	return true
+}
+
+// CancelKind cancels every running job of a kind
+func (j *Jobs) CancelKind(kind JobKind) {
+	for _, job := range j.list {
+		if job.Kind == kind {
+			j.Cancel(job.ID)
+		}
+	}
+}
+
+// CancelAll cancels every running job (quitting)
+func (j *Jobs) CancelAll() {
+	for _, job := range j.list {
+		j.Cancel(job.ID)
+	}
+}
+
+// Cancelled reports whether the user cancelled the job
+func (j *Jobs) Cancelled(id int) bool {
+	job := j.Get(id)
+	return job != nil && job.Status == JobCancelled
+}
+
+// Active returns how many jobs are still running
+func (j *Jobs) Active() int {
+	n := 0
+	for _, job := range j.list {
+		if job.Status == JobRunning {
+			n++
+		}
+	}
+	return n
+}
+
+// List returns the jobs, running ones first, each group oldest first
+func (j *Jobs) List() []*Job {
+	out := make([]*Job, 0, len(j.list))
+	for _, job := range j.list {
+		if job.Status == JobRunning {
+			out = append(out, job)
+		}
+	}
+	for _, job := range j.list {
+		if job.Status != JobRunning {
+			out = append(out, job)
+		}
+	}
+	return out
+}
+
+// prune drops the oldest finished jobs beyond maxFinishedJobs
+func (j *Jobs) prune() {
+	finished := len(j.list) - j.Active()
+	if finished <= maxFinishedJobs {
+		return
+	}
+	kept := j.list[:0]
+	for _, job := range j.list {
+		if job.Status != JobRunning && finished > maxFinishedJobs {
+			finished--
+			continue
+		}
+		kept = append(kept, job)
+	}
+	j.list = kept
+}
+
+// JobsKeyMap defines the jobs panel key bindings
+type JobsKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Cancel key.Binding
+	Close  key.Binding
+}
+
+// JobsKeys is the jobs panel key bindings instance
+var JobsKeys = JobsKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("k", "up"),
+		key.WithHelp("k/↑", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("j/↓", "down"),
+	),
+	Cancel: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "cancel job"),
+	),
+	Close: key.NewBinding(
+		key.WithKeys("esc", "ctrl+j"),
+		key.WithHelp("esc", "close"),
+	),
+}
+
+// startSyncJob registers a library sync and returns its command. refetch
+// skips the freshness check for a library already known to be stale.
+// Scheduling goes through queueSync, which holds the slot this takes.
+func (m *Model) startSyncJob(lib domain.Library, refetch bool) tea.Cmd {
+	id, ctx := m.jobs.Start(JobSync, "Sync "+lib.Name, 0)
+	if m.syncRunning == nil {
+		m.syncRunning = make(map[int]string)
+	}
+	m.syncRunning[id] = lib.ID
+	if refetch {
+		return RefetchLibraryCmd(ctx, id, m.LibraryService, lib, m.SyncGen)
+	}
+	return SyncLibraryCmd(ctx, id, m.LibraryService, lib, m.SyncGen)
+}
+
+// startPlaylistSyncJob registers the playlist sync and returns its command.
+// Playlists sync beside the library queue, not in one of its slots.
+func (m *Model) startPlaylistSyncJob() tea.Cmd {
+	id, ctx := m.jobs.Start(JobSync, "Sync Playlists", 0)
+	m.playlistJob = id
+	return SyncPlaylistsCmd(ctx, id, m.PlaylistService, playlistsLibraryID, m.SyncGen)
+}
+
+// jobsWindowTitle is the terminal title for n running jobs
+func jobsWindowTitle(n int) string {
+	if n == 0 {
+		return "kino"
+	}
+	return fmt.Sprintf("kino (%d)", n)
+}
+
+// syncWindowTitle sets the terminal title when the running-job count
+// changes. Called from the tick so every job start and finish is picked up
+// without each handler having to remember.
+func (m *Model) syncWindowTitle() tea.Cmd {
+	n := m.jobs.Active()
+	if n == m.titleJobCount {
+		return nil
+	}
+	m.titleJobCount = n
+	return tea.SetWindowTitle(jobsWindowTitle(n))
+}
+
+// handleJobsPanel opens the jobs panel
+func (m Model) handleJobsPanel() (tea.Model, tea.Cmd) {
+	m.jobsPanelOpen = true
+	m.jobsCursor = 0
+	return m, nil
+}
+
+// handleJobsPanelInput handles input while the jobs panel is open
+func (m Model) handleJobsPanelInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
+	list := m.jobs.List()
+	switch {
+	case key.Matches(msg, JobsKeys.Close):
+		m.jobsPanelOpen = false
+	case key.Matches(msg, JobsKeys.Down):
+		if m.jobsCursor < len(list)-1 {
+			m.jobsCursor++
+		}
+	case key.Matches(msg, JobsKeys.Up):
+		if m.jobsCursor > 0 {
+			m.jobsCursor--
+		}
+	case key.Matches(msg, JobsKeys.Cancel):
+		if m.jobsCursor < len(list) {
+			job := list[m.jobsCursor]
+			if m.jobs.Cancel(job.ID) {
+				return true, m, m.notify(NoticeInfo, i18n.T("status.job_cancelled", job.Label))
+			}
+		}
+	}
+	return true, m, nil
+}
+
+// renderJobsPanel renders the jobs list with progress and status
+func (m Model) renderJobsPanel() string {
+	var b strings.Builder
+	b.WriteString(styles.ModalTitleStyle.Render("Jobs"))
+	b.WriteString("\n\n")
+
+	list := m.jobs.List()
+	if len(list) == 0 {
+		b.WriteString(styles.DimStyle.Render("  No background jobs"))
+		b.WriteString("\n")
+	}
+	for i, job := range list {
+		cursor := "  "
+		if i == m.jobsCursor {
+			cursor = styles.AccentStyle.Render("> ")
+		}
+		label := styles.Truncate(job.Label, 32)
+		line := fmt.Sprintf("%-32s  %s", label, m.jobStatusText(job))
+		switch job.Status {
+		case JobRunning:
+		case JobFailed:
+			line = styles.ErrorStyle.Render(line)
+		default:
+			line = styles.DimStyle.Render(line)
+		}
+		b.WriteString(cursor + line + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("j/k move · x cancel · esc close"))
+
+	return lipgloss.Place(m.Width, m.Height,
+		lipgloss.Center, lipgloss.Center,
+		styles.ModalStyle.Render(b.String()))
+}
+
+// jobStatusText is a job's progress (while running) or outcome
+func (m Model) jobStatusText(job *Job) string {
+	switch job.Status {
+	case JobRunning:
+		text := RenderSpinner(m.SpinnerFrame)
+		if job.Total > 0 {
+			text += fmt.Sprintf(" %d/%d", job.Done, job.Total)
+		}
+		return text + " " + time.Since(job.Started).Truncate(time.Second).String()
+	case JobFailed:
+		if job.Err != nil {
+			return "failed: " + styles.Truncate(describeError(job.Err).Summary, 30)
+		}
+	}
+	return job.Status.String()
+}
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// Cancelling a sync from the jobs panel stops its context, settles the
+// library row and drops the chain's remaining messages
+func TestJobsPanelCancelsSync(t *testing.T) {
+	m := Model{
+		ColumnStack:   NewColumnStack(),
+		LibraryStates: map[string]components.LibrarySyncState{"lib": {Status: components.StatusSyncing}},
+		jobs:          NewJobs(),
+	}
+	id, ctx := m.jobs.Start(JobSync, "Sync Movies", 0)
+	if m.syncWindowTitle() == nil || m.titleJobCount != 1 {
+		t.Fatal("window title not updated for the running job")
+	}
+
+	updated, _ := m.handleJobsPanel()
+	m = updated.(Model)
+	_, m, _ = m.handleJobsPanelInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if ctx.Err() == nil || m.jobs.Active() != 0 {
+		t.Fatal("cancel did not stop the job")
+	}
+
+	updated, cmd := m.Update(LibrarySyncProgressMsg{LibraryID: "lib", JobID: id, Loaded: 10, Total: 100, NextCmd: func() tea.Msg { return nil }})
+	m = updated.(Model)
+	if cmd != nil {
+		t.Fatal("cancelled sync chain kept reading")
+	}
+	if got := m.LibraryStates["lib"].Status; got != components.StatusIdle {
+		t.Fatalf("library status = %v, want idle", got)
+	}
+	if got := m.jobs.Get(id).Status; got != JobCancelled {
+		t.Fatalf("job status = %v, want cancelled", got)
+	}
+}
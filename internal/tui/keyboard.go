@@ -1,8 +1,18 @@
 package tui
 
 import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/player"
+	"github.com/mmcdole/kino/internal/search"
 	"github.com/mmcdole/kino/internal/tui/components"
 )
 
@@ -12,19 +22,28 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Ctrl+C always quits, even inside modals and text inputs
 	if msg.String() == "ctrl+c" {
-		return m, tea.Quit
+		return m.handleQuit()
+	}
+
+	if m.State == StateLocked {
+		return m.handleLockedInput(msg)
+	}
+	m.lastActivity = time.Now()
+
+	if m.State == StateAuthRequired {
+		return m.handleAuthInput(msg)
 	}
 
 	// Handle state-specific keys
 	switch m.State {
-	case StateHelp:
-		// Any key returns to browsing, as the help screen promises
+	case StateHelp, StateKeyConflicts:
+		// Any key returns to browsing, as both screens promise
 		m.State = StateBrowsing
 		return m, nil
 
 	case StateConfirmLogout:
 		switch {
-		case key.Matches(msg, Keys.Confirm):
+		case m.isConfirm(msg):
 			// User confirmed logout
 			return m, LogoutCmd()
 		case key.Matches(msg, Keys.Deny):
@@ -35,7 +54,7 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case StateConfirmDeletePlaylist:
 		switch {
-		case key.Matches(msg, Keys.Confirm):
+		case m.isConfirm(msg):
 			m.State = StateBrowsing
 			if m.pendingDeletePlaylistID != "" {
 				id := m.pendingDeletePlaylistID
@@ -49,6 +68,18 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.pendingDeletePlaylistName = ""
 		}
 		return m, nil
+
+	case StateConfirmDeleteItem:
+		return m.handleDeleteItemInput(msg)
+
+	case StateConfirmNextEpisode:
+		switch {
+		case m.isConfirm(msg):
+			return m.handleNextEpisodeConfirm(true)
+		case key.Matches(msg, Keys.Deny):
+			return m.handleNextEpisodeConfirm(false)
+		}
+		return m, nil
 	}
 
 	// Route to active modal if any
@@ -56,10 +87,20 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return newModel, cmd
 	}
 
+	// The number key after m picks the bookmark slot
+	if m.bookmarkPending {
+		return m.handleBookmarkSlot(msg)
+	}
+
+	// In remote mode Enter opens the context menu in place of letter keys
+	if m.UIConfig.RemoteMode && key.Matches(msg, Keys.Enter) {
+		return m.handleContextMenu()
+	}
+
 	// Global keys
 	switch {
 	case key.Matches(msg, Keys.Quit):
-		return m, tea.Quit
+		return m.handleQuit()
 	case key.Matches(msg, Keys.Help):
 		return m.handleHelp()
 	case key.Matches(msg, Keys.Escape):
@@ -70,6 +111,12 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleGlobalSearch()
 	case key.Matches(msg, Keys.Sort):
 		return m.handleSort()
+	case key.Matches(msg, Keys.Specials):
+		return m.handleSpecials()
+	case key.Matches(msg, Keys.Libraries):
+		return m.handleLibraryVisibility()
+	case key.Matches(msg, Keys.Left):
+		return m.handleFocusParent()
 	case key.Matches(msg, Keys.Back):
 		return m.handleBack()
 	case key.Matches(msg, Keys.Right):
@@ -88,6 +135,8 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handlePlay()
 	case key.Matches(msg, Keys.ToggleInspector):
 		return m.handleToggleInspector()
+	case key.Matches(msg, Keys.Peek):
+		return m.handlePeek()
 	case key.Matches(msg, Keys.Logout):
 		return m.handleLogout()
 	case key.Matches(msg, Keys.PlaylistModal):
@@ -96,6 +145,77 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleDelete()
 	case key.Matches(msg, Keys.NewPlaylist):
 		return m.handleNewPlaylist()
+	case key.Matches(msg, Keys.EditPlaylist):
+		return m.handleEditPlaylist()
+	case key.Matches(msg, Keys.ExportPlaylist):
+		return m.handleExportPlaylist()
+	case key.Matches(msg, Keys.ToggleMark):
+		return m.handleToggleMark()
+	case key.Matches(msg, Keys.TogglePrivate):
+		return m.handleTogglePrivate()
+	case key.Matches(msg, Keys.Jobs):
+		return m.handleJobsPanel()
+	case key.Matches(msg, Keys.WatchFilter):
+		return m.handleWatchFilter()
+	case key.Matches(msg, Keys.OpenExternal):
+		return m.handleOpenExternal()
+	case key.Matches(msg, Keys.Quality):
+		return m.handleQuality()
+	case key.Matches(msg, Keys.Debug):
+		return m.handleDebugOverlay()
+	case key.Matches(msg, Keys.NewItems):
+		return m.handleNewItems()
+
+	case key.Matches(msg, Keys.Watchlist):
+		return m.handleWatchlistToggle()
+
+	case key.Matches(msg, Keys.Rate):
+		return m.handleRate()
+
+	case key.Matches(msg, Keys.Activities):
+		return m.handleActivitiesPanel()
+
+	case key.Matches(msg, Keys.Command):
+		return m.handleCommandLine()
+
+	case key.Matches(msg, Keys.Bookmark):
+		return m.handleBookmark()
+	case key.Matches(msg, Keys.JumpBookmark):
+		return m.handleJumpBookmark(bookmarkSlot(msg))
+
+	case key.Matches(msg, Keys.Split):
+		return m.handleSplit()
+	case key.Matches(msg, Keys.SwitchPane):
+		return m.handleSwitchPane()
+
+	case key.Matches(msg, Keys.Home):
+		return m.handleHome()
+	case key.Matches(msg, Keys.Details):
+		return m.handleDetailView()
+	case key.Matches(msg, Keys.People):
+		return m.handlePeople()
+
+	case key.Matches(msg, Keys.Cast):
+		return m.handleCast()
+
+	// The player keys only act while something plays: the local mpv, or
+	// else the device playback was sent to
+	case m.nowPlaying != nil && key.Matches(msg, Keys.PausePlayer):
+		return m.handlePausePlayer()
+	case m.nowPlaying != nil && key.Matches(msg, Keys.SeekBackPlayer):
+		return m.handleSeekPlayer(-seekBackStep)
+	case m.nowPlaying != nil && key.Matches(msg, Keys.SeekForwardPlayer):
+		return m.handleSeekPlayer(seekForwardStep)
+	case m.nowPlaying != nil && key.Matches(msg, Keys.StopPlayer):
+		return m.handleStopPlayer()
+	case m.casting != nil && key.Matches(msg, Keys.PausePlayer):
+		return m.controlCast(domain.CastPlayPause)
+	case m.casting != nil && key.Matches(msg, Keys.SeekBackPlayer):
+		return m.controlCast(domain.CastRewind)
+	case m.casting != nil && key.Matches(msg, Keys.SeekForwardPlayer):
+		return m.controlCast(domain.CastFastForward)
+	case m.casting != nil && key.Matches(msg, Keys.StopPlayer):
+		return m.controlCast(domain.CastStop)
 	}
 
 	// Let the focused column handle remaining keys (j/k/g/G navigation)
@@ -117,6 +237,21 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // routeToModal routes key input to active modals
 // Returns (handled, model, cmd) where handled is true if a modal consumed the input
 func (m Model) routeToModal(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
+	if m.debugOpen {
+		return m.handleDebugOverlayInput(msg)
+	}
+	if m.menu != nil {
+		return m.handleContextMenuInput(msg)
+	}
+	if m.cmdOpen {
+		return m.handleCommandInput(msg)
+	}
+	if m.jobsPanelOpen {
+		return m.handleJobsPanelInput(msg)
+	}
+	if m.activitiesOpen {
+		return m.handleActivitiesPanelInput(msg)
+	}
 	if m.GlobalSearch.IsVisible() {
 		newModel, cmd := m.handleGlobalSearchInput(msg)
 		return true, newModel, cmd
@@ -124,12 +259,33 @@ func (m Model) routeToModal(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
 	if m.SortModal.IsVisible() {
 		return m.handleSortModalInput(msg)
 	}
+	if m.ResumeModal.IsVisible() {
+		return m.handleResumeModalInput(msg)
+	}
+	if m.VersionModal.IsVisible() {
+		return m.handleVersionModalInput(msg)
+	}
+	if m.RatingModal.IsVisible() {
+		return m.handleRatingModalInput(msg)
+	}
 	if m.PlaylistModal.IsVisible() {
 		return m.handlePlaylistModalInput(msg)
 	}
 	if m.InputModal.IsVisible() {
 		return m.handleInputModalInput(msg)
 	}
+	if m.PlaylistEditModal.IsVisible() {
+		return m.handlePlaylistEditModalInput(msg)
+	}
+	if m.LibraryModal.IsVisible() {
+		return m.handleLibraryModalInput(msg)
+	}
+	if m.detail != nil {
+		return m.handleDetailViewInput(msg)
+	}
+	if m.home != nil {
+		return m.handleHomeInput(msg)
+	}
 	if top := m.ColumnStack.Top(); top != nil && top.IsFilterTyping() {
 		return m.handleFilterTypingInput(msg)
 	}
@@ -152,18 +308,47 @@ func (m Model) handleEscape() (tea.Model, tea.Cmd) {
 		top.ClearFilter()
 		return m, nil
 	}
+	if top := m.ColumnStack.Top(); top != nil && top.MarkedCount() > 0 {
+		top.ClearMarks()
+		return m, m.notify(NoticeInfo, i18n.T("status.marks_cleared"))
+	}
+	if m.peeking {
+		m.closePeek()
+		return m, nil
+	}
 	if m.navPlan != nil {
 		m.clearNavPlan()
-		return m, m.notify(NoticeInfo, "Navigation cancelled")
+		return m, m.notify(NoticeInfo, i18n.T("status.navigation_cancelled"))
 	}
 	// Esc dismisses a persistent alert once the user has read it
 	if m.notice.Kind == NoticeAlert && m.notice.Text != "" {
 		m.clearNotice()
 		return m, nil
 	}
+	// In the library list, Esc stops the selected library's sync
+	if m.ColumnStack.Len() == 1 {
+		if lib := m.ColumnStack.Top().SelectedLibrary(); lib != nil && m.syncPending(lib.ID) {
+			m.cancelLibrarySync(lib.ID)
+			cmd := m.drainSyncQueue()
+			m.updateLibraryStates()
+			return m, tea.Batch(cmd, m.notify(NoticeInfo, i18n.T("status.sync_cancelled", lib.Name)))
+		}
+	}
 	return m, nil
 }
 
+// handleQuit stops background work before quitting, so no sync or
+// prefetch is left writing to the cache while main shuts down
+func (m Model) handleQuit() (tea.Model, tea.Cmd) {
+	m.jobs.CancelAll()
+	m.resetSyncQueue()
+	m.stopLiveUpdates()
+	if m.LibraryService != nil {
+		m.LibraryService.CancelPrefetch()
+	}
+	return m, tea.Quit
+}
+
 // handleFilter toggles filter mode in the current column
 func (m Model) handleFilter() (tea.Model, tea.Cmd) {
 	if top := m.ColumnStack.Top(); top != nil {
@@ -175,7 +360,9 @@ func (m Model) handleFilter() (tea.Model, tea.Cmd) {
 // handleGlobalSearch opens the global search modal
 func (m Model) handleGlobalSearch() (tea.Model, tea.Cmd) {
 	m.GlobalSearch.Show()
+	m.searchGen++ // Drop matching left over from the last time it was open
 	m.GlobalSearch.SetSize(m.Width, m.Height)
+	m.GlobalSearch.SetLookupEnabled(m.ArrSvc.Enabled())
 	return m, m.GlobalSearch.Init()
 }
 
@@ -190,10 +377,7 @@ func (m Model) handleDrillIn() (tea.Model, tea.Cmd) {
 	}
 	if !top.CanDrillInto() {
 		if item := top.SelectedMediaItem(); item != nil {
-			return m, tea.Batch(
-				m.notify(NoticeInfo, "Launching: "+item.Title),
-				PlayItemCmd(m.PlaybackSvc, *item, item.ShouldResume()),
-			)
+			return m.launchItem(*item, item.ShouldResume())
 		}
 		return m, nil
 	}
@@ -211,10 +395,14 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 		return m.drillIntoSelection()
 	}
 	if item := top.SelectedMediaItem(); item != nil {
-		return m, tea.Batch(
-			m.notify(NoticeInfo, "Launching: "+item.Title),
-			PlayItemCmd(m.PlaybackSvc, *item, item.ShouldResume()),
-		)
+		if item.AiredAt > time.Now().Unix() {
+			return m, m.notify(NoticeInfo, i18n.T("status.not_aired", item.Title))
+		}
+		if item.ShouldResume() && !m.UIConfig.AutoResume {
+			m.ResumeModal.Show(*item)
+			return m, nil
+		}
+		return m.launchItem(*item, item.ShouldResume())
 	}
 	return m, nil
 }
@@ -225,25 +413,33 @@ func (m Model) handleSort() (tea.Model, tea.Cmd) {
 	if top == nil {
 		return m, nil
 	}
-	var opts []components.SortField
-	switch top.ColumnType() {
-	case components.ColumnTypeMovies:
-		opts = components.MovieSortOptions()
-	case components.ColumnTypeShows:
-		opts = components.ShowSortOptions()
-	case components.ColumnTypeEpisodes:
-		opts = components.EpisodeSortOptions()
-	case components.ColumnTypeMixed:
-		opts = components.MixedSortOptions()
-	}
+	opts := sortOptions(top)
 	if opts == nil {
-		return m.notAvailableHere("Sort (s)")
+		return m.notAvailableHere(i18n.T("action.sort"))
 	}
 	field, dir := top.SortState()
 	m.SortModal.Show(opts, field, dir)
 	return m, nil
 }
 
+// sortOptions returns the fields a column can be sorted by; nil when it
+// keeps the server's order
+func sortOptions(col *components.ListColumn) []components.SortField {
+	switch col.ColumnType() {
+	case components.ColumnTypeMovies:
+		return components.MovieSortOptions()
+	case components.ColumnTypeShows:
+		return components.ShowSortOptions()
+	case components.ColumnTypeEpisodes:
+		return components.EpisodeSortOptions()
+	case components.ColumnTypeMixed:
+		return components.MixedSortOptions()
+	case components.ColumnTypeContinue:
+		return components.ContinueSortOptions()
+	}
+	return nil
+}
+
 // handleRefresh performs context-sensitive refresh with cascade invalidation.
 // At library level: refresh selected library (cascade to seasons/episodes)
 // At show level: refresh selected show (cascade to seasons/episodes)
@@ -259,18 +455,21 @@ func (m Model) handleRefresh() (tea.Model, tea.Cmd) {
 	case components.ColumnTypeLibraries:
 		// Refresh selected library
 		lib := top.SelectedLibrary()
-		if lib == nil || lib.ID == playlistsLibraryID {
-			return m, nil
+		if lib != nil && lib.ID == playlistsLibraryID {
+			cmd := m.restartPlaylistSync()
+			m.updateLibraryStates()
+			return m, cmd
 		}
-		// Already syncing: don't start a second chain or double-count
-		if state, ok := m.LibraryStates[lib.ID]; ok && state.Status == components.StatusSyncing {
+		if lib == nil || isSyntheticLibrary(lib.ID) {
 			return m, nil
 		}
-		m.LibraryStates[lib.ID] = components.LibrarySyncState{Status: components.StatusSyncing}
-		m.updateLibraryStates()
-		// Invalidate then sync
+		// A sync already running or queued is superseded: cancel it so two
+		// chains never write the same library. Then invalidate and sync
+		// again, ahead of anything else waiting.
 		m.LibraryService.InvalidateLibrary(lib.ID)
-		return m, SyncLibraryCmd(m.LibraryService, *lib, m.SyncGen)
+		cmd := m.restartSync(*lib)
+		m.updateLibraryStates()
+		return m, cmd
 
 	case components.ColumnTypeMovies, components.ColumnTypeMixed, components.ColumnTypeShows:
 		return m.refreshLibraryContent(top)
@@ -278,7 +477,7 @@ func (m Model) handleRefresh() (tea.Model, tea.Cmd) {
 	case components.ColumnTypeSeasons:
 		// Refresh current show's seasons (invalidate seasons + episodes, re-fetch seasons)
 		m.LibraryService.InvalidateShow(m.currentLibID, m.currentShowID)
-		top.SetRefreshing(true)
+		top.BeginReload()
 		return m, LoadSeasonsCmd(m.LibraryService, m.currentLibID, m.currentShowID)
 
 	case components.ColumnTypeEpisodes:
@@ -292,20 +491,37 @@ func (m Model) handleRefresh() (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.LibraryService.InvalidateSeason(m.currentLibID, m.currentShowID, season.ID)
-		top.SetRefreshing(true)
+		top.BeginReload()
 		return m, LoadEpisodesCmd(m.LibraryService, m.currentLibID, m.currentShowID, season.ID)
 
 	case components.ColumnTypePlaylists:
 		// Refresh playlists
-		top.SetRefreshing(true)
+		top.BeginReload()
 		return m, LoadPlaylistsCmd(m.PlaylistService)
 
+	case components.ColumnTypeChannels:
+		// Refresh channels (and their current programs)
+		top.BeginReload()
+		return m, LoadChannelsCmd(m.LibraryService)
+
+	case components.ColumnTypeCalendar:
+		top.BeginReload()
+		return m, LoadCalendarCmd(m.LibraryService)
+
+	case components.ColumnTypeWatchlist:
+		top.BeginReload()
+		return m, LoadWatchlistCmd(m.LibraryService)
+
+	case components.ColumnTypeContinue:
+		top.BeginReload()
+		return m, LoadContinueWatchingCmd(m.LibraryService)
+
 	case components.ColumnTypePlaylistItems:
 		// Refresh playlist items
 		if m.currentPlaylistID == "" {
 			return m, nil
 		}
-		top.SetRefreshing(true)
+		top.BeginReload()
 		return m, LoadPlaylistItemsCmd(m.PlaylistService, m.currentPlaylistID)
 	}
 
@@ -322,8 +538,16 @@ func (m Model) refreshLibraryContent(top *components.ListColumn) (Model, tea.Cmd
 	if lib == nil {
 		return m, nil
 	}
+	if lib.ID == mergedLibraryID {
+		members := m.mergedMembers()
+		for _, member := range members {
+			m.LibraryService.InvalidateLibrary(member.ID)
+		}
+		top.BeginReload()
+		return m, LoadMergedMoviesCmd(m.LibraryService, members)
+	}
 	m.LibraryService.InvalidateLibrary(lib.ID)
-	top.SetRefreshing(true)
+	top.BeginReload()
 
 	switch lib.Type {
 	case "movie":
@@ -351,9 +575,17 @@ func (m Model) handleMarkWatched() (tea.Model, tea.Cmd) {
 	if top == nil {
 		return m, nil
 	}
+	if m.PlaybackSvc.Private() {
+		return m, m.notify(NoticeInfo, i18n.T("status.private_unreported_hint"))
+	}
+	if marked := top.MarkedMediaItems(); len(marked) > 0 {
+		top.ClearMarks()
+		id, ctx := m.jobs.Start(JobWatchState, fmt.Sprintf("Mark %d watched", len(marked)), 0)
+		return m, BatchMarkWatchedCmd(ctx, id, m.PlaybackSvc, marked, true)
+	}
 	item := top.SelectedMediaItem()
-	if item == nil {
-		return m.notAvailableHere("Mark watched (w)")
+	if item == nil || item.Type == domain.MediaTypeChannel {
+		return m.notAvailableHere(i18n.T("action.mark_watched"))
 	}
 	return m, MarkWatchedCmd(m.PlaybackSvc, item.ID, item.Title)
 }
@@ -364,37 +596,91 @@ func (m Model) handleMarkUnwatched() (tea.Model, tea.Cmd) {
 	if top == nil {
 		return m, nil
 	}
+	if m.PlaybackSvc.Private() {
+		return m, m.notify(NoticeInfo, i18n.T("status.private_unreported_hint"))
+	}
+	if marked := top.MarkedMediaItems(); len(marked) > 0 {
+		top.ClearMarks()
+		id, ctx := m.jobs.Start(JobWatchState, fmt.Sprintf("Mark %d unwatched", len(marked)), 0)
+		return m, BatchMarkWatchedCmd(ctx, id, m.PlaybackSvc, marked, false)
+	}
 	item := top.SelectedMediaItem()
-	if item == nil {
-		return m.notAvailableHere("Mark unwatched (u)")
+	if item == nil || item.Type == domain.MediaTypeChannel {
+		return m.notAvailableHere(i18n.T("action.mark_unwatched"))
 	}
 	return m, MarkUnwatchedCmd(m.PlaybackSvc, item.ID, item.Title)
 }
 
+// handleOpenExternal opens the selected movie, show or episode on IMDb
+// (or TMDB) in the browser
+func (m Model) handleOpenExternal() (tea.Model, tea.Cmd) {
+	top := m.ColumnStack.Top()
+	if top == nil {
+		return m, nil
+	}
+	var url string
+	switch item := top.SelectedItem().(type) {
+	case *domain.MediaItem:
+		url = item.ExternalURL()
+	case *domain.Show:
+		url = item.ExternalURL()
+	}
+	if url == "" {
+		return m.notAvailableHere(i18n.T("action.open_imdb"))
+	}
+	return m, tea.Batch(
+		m.notify(NoticeInfo, i18n.T("status.opening", url)),
+		OpenURLCmd(m.PlaybackSvc, url),
+	)
+}
+
 // handlePlay plays the selected item from the beginning
 func (m Model) handlePlay() (tea.Model, tea.Cmd) {
 	top := m.ColumnStack.Top()
 	if top == nil {
 		return m, nil
 	}
+	if marked := top.MarkedMediaItems(); len(marked) > 0 {
+		top.ClearMarks()
+		return m, tea.Batch(
+			m.notify(NoticeInfo, i18n.T("status.queueing", len(marked))),
+			PlayQueueCmd(m.PlaybackSvc, marked),
+		)
+	}
 	item := top.SelectedMediaItem()
 	if item == nil {
-		return m.notAvailableHere("Play (p)")
+		return m.notAvailableHere(i18n.T("action.play"))
+	}
+	if item.AiredAt > time.Now().Unix() {
+		return m, m.notify(NoticeInfo, i18n.T("status.not_aired", item.Title))
+	}
+	return m.launchItem(*item, false)
+}
+
+// launchItem plays an item, first asking which version to play when it has
+// several and the backend can play a chosen one
+func (m Model) launchItem(item domain.MediaItem, resume bool) (tea.Model, tea.Cmd) {
+	if len(item.Versions) > 1 && m.PlaybackSvc.CanPickVersion() {
+		m.VersionModal.Show(item, resume)
+		return m, nil
 	}
 	return m, tea.Batch(
-		m.notify(NoticeInfo, "Launching: "+item.Title),
-		PlayItemCmd(m.PlaybackSvc, *item, false),
+		m.notify(NoticeInfo, i18n.T("status.launching", item.Title)),
+		PlayItemCmd(m.PlaybackSvc, item, resume),
 	)
 }
 
 // notAvailableHere emits a short status explaining that a key does nothing
 // for the current selection, instead of silently ignoring it
 func (m Model) notAvailableHere(action string) (tea.Model, tea.Cmd) {
-	return m, m.notify(NoticeInfo, action+" is not available for this item")
+	return m, m.notify(NoticeInfo, i18n.T("status.not_available", action))
 }
 
 // handleToggleInspector toggles the inspector panel visibility
 func (m Model) handleToggleInspector() (tea.Model, tea.Cmd) {
+	if m.split != nil {
+		return m, m.notifyHint(NoticeInfo, i18n.T("status.no_inspector_split"), i18n.T("hint.close_split"))
+	}
 	m.ShowInspector = !m.ShowInspector
 	m.updateLayout()
 	return m, nil
@@ -412,12 +698,18 @@ func (m Model) handlePlaylistModal() (tea.Model, tea.Cmd) {
 	if top == nil {
 		return m, nil
 	}
+	if marked := top.MarkedMediaItems(); len(marked) > 0 && m.PlaylistService != nil {
+		return m, tea.Batch(
+			m.notify(NoticeInfo, i18n.T("status.loading_playlists")),
+			LoadBatchPlaylistModalDataCmd(m.PlaylistService, marked),
+		)
+	}
 	item := top.SelectedMediaItem()
-	if item == nil || m.PlaylistService == nil {
-		return m.notAvailableHere("Playlists (space)")
+	if item == nil || item.Type == domain.MediaTypeChannel || m.PlaylistService == nil {
+		return m.notAvailableHere(i18n.T("action.playlists"))
 	}
 	return m, tea.Batch(
-		m.notify(NoticeInfo, "Loading playlists..."),
+		m.notify(NoticeInfo, i18n.T("status.loading_playlists")),
 		LoadPlaylistModalDataCmd(m.PlaylistService, item),
 	)
 }
@@ -432,8 +724,10 @@ func (m Model) handleDelete() (tea.Model, tea.Cmd) {
 	case components.ColumnTypePlaylistItems:
 		item := top.SelectedMediaItem()
 		if item != nil && m.currentPlaylistID != "" {
-			return m, RemoveFromPlaylistCmd(m.PlaylistService, m.currentPlaylistID, item.ID)
+			return m, RemoveFromPlaylistCmd(context.Background(), 0, m.PlaylistService, m.currentPlaylistID, item.ID)
 		}
+	case components.ColumnTypeContinue:
+		return m.handleDismissContinue(top)
 	case components.ColumnTypePlaylists:
 		// Deleting a playlist is irreversible and server-side: confirm first
 		if playlist := top.SelectedPlaylist(); playlist != nil {
@@ -443,21 +737,130 @@ func (m Model) handleDelete() (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 	default:
-		return m, m.notify(NoticeInfo, "Remove (x) only works in playlists")
+		return m.handleDeleteItem(top)
 	}
 	return m, nil
 }
 
-// handleNewPlaylist opens the new-playlist name input (playlists column only)
+// handleToggleMark marks or unmarks the selected item for a batch operation
+// (w/u, p, and space then act on every marked item)
+func (m Model) handleToggleMark() (tea.Model, tea.Cmd) {
+	top := m.ColumnStack.Top()
+	if top == nil || !top.ToggleMark() {
+		return m.notAvailableHere(i18n.T("action.mark"))
+	}
+	m.updateInspector()
+	return m, nil
+}
+
+// handleWatchFilter cycles the top column between all, unwatched and
+// in-progress items
+func (m Model) handleWatchFilter() (tea.Model, tea.Cmd) {
+	top := m.ColumnStack.Top()
+	if top == nil {
+		return m, nil
+	}
+	filter, ok := top.CycleWatchFilter()
+	if !ok {
+		return m.notAvailableHere(i18n.T("action.watch_filter"))
+	}
+	m.updateInspector()
+	return m, m.notify(NoticeInfo, i18n.T("status.showing", filter.String()))
+}
+
+// handleTogglePrivate starts or ends a private session: nothing played or
+// marked until it ends is reported to the server
+func (m Model) handleTogglePrivate() (tea.Model, tea.Cmd) {
+	on := !m.PlaybackSvc.Private()
+	m.PlaybackSvc.SetPrivate(on)
+	if on {
+		return m, m.notify(NoticeInfo, i18n.T("status.private_on"))
+	}
+	return m, m.notify(NoticeInfo, i18n.T("status.private_off"))
+}
+
+// handleQuality cycles the stream quality cap for the rest of the session;
+// the default comes from player.max_bitrate_mbps
+func (m Model) handleQuality() (tea.Model, tea.Cmd) {
+	current := m.PlaybackSvc.MaxBitrate()
+	next := player.Qualities[0]
+	if i := slices.Index(player.Qualities, current); i >= 0 {
+		next = player.Qualities[(i+1)%len(player.Qualities)]
+	}
+	m.PlaybackSvc.SetMaxBitrate(next)
+	return m, m.notify(NoticeInfo, i18n.T("status.quality", player.QualityLabel(next)))
+}
+
+// handleNewPlaylist asks for the name of a new playlist: one holding the
+// marked items, or an empty one from the playlists column where the server
+// allows it
 func (m Model) handleNewPlaylist() (tea.Model, tea.Cmd) {
 	top := m.ColumnStack.Top()
-	if top == nil || top.ColumnType() != components.ColumnTypePlaylists {
+	if top == nil || m.PlaylistService == nil {
+		return m, nil
+	}
+	if marked := top.MarkedMediaItems(); len(marked) > 0 {
+		m.newPlaylistIDs = make([]string, len(marked))
+		for i, item := range marked {
+			m.newPlaylistIDs[i] = item.ID
+		}
+		m.InputModal.Show(fmt.Sprintf("New Playlist (%d items)", len(marked)))
 		return m, nil
 	}
+	if top.ColumnType() != components.ColumnTypePlaylists {
+		return m, m.notify(NoticeInfo, i18n.T("status.playlist_needs_marks"))
+	}
+	if !m.PlaylistService.CanCreateEmpty() {
+		return m, m.notify(NoticeInfo, i18n.T("status.playlist_needs_items"))
+	}
+	m.newPlaylistIDs = nil
 	m.InputModal.Show("New Playlist")
 	return m, nil
 }
 
+// handleEditPlaylist opens the title/description editor for the selected
+// playlist (playlists column only)
+func (m Model) handleEditPlaylist() (tea.Model, tea.Cmd) {
+	top := m.ColumnStack.Top()
+	if top == nil || top.ColumnType() != components.ColumnTypePlaylists {
+		return m, nil
+	}
+	playlist := top.SelectedPlaylist()
+	if playlist == nil {
+		return m, nil
+	}
+	if playlist.Smart {
+		return m, m.notify(NoticeInfo, i18n.T("status.smart_playlist"))
+	}
+	m.PlaylistEditModal.Show(*playlist)
+	return m, nil
+}
+
+// handleExportPlaylist writes the selected (or open) playlist to an M3U
+// file in the working directory
+func (m Model) handleExportPlaylist() (tea.Model, tea.Cmd) {
+	top := m.ColumnStack.Top()
+	if top == nil || m.PlaylistService == nil {
+		return m, nil
+	}
+	var id string
+	switch top.ColumnType() {
+	case components.ColumnTypePlaylists:
+		if playlist := top.SelectedPlaylist(); playlist != nil {
+			id = playlist.ID
+		}
+	case components.ColumnTypePlaylistItems:
+		id = m.currentPlaylistID
+	}
+	if id == "" {
+		return m.notAvailableHere(i18n.T("action.export"))
+	}
+	return m, tea.Batch(
+		m.notify(NoticeInfo, i18n.T("status.exporting_playlist")),
+		ExportPlaylistCmd(m.PlaylistService, m.PlaybackSvc, id),
+	)
+}
+
 // ----------------------------------------------------------------------------
 // Modal input handlers
 // ----------------------------------------------------------------------------
@@ -468,18 +871,41 @@ func (m Model) handleGlobalSearchInput(msg tea.KeyMsg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var selected bool
 
+	if key.Matches(msg, components.GlobalSearchKeys.Lookup) {
+		query := strings.TrimSpace(m.GlobalSearch.Query())
+		if !m.ArrSvc.Enabled() || query == "" {
+			return m, nil
+		}
+		m.GlobalSearch.SetLookingUp(true)
+		return m, ArrLookupCmd(m.ArrSvc, m.GlobalSearch.Query())
+	}
+
 	m.GlobalSearch, cmd, selected = m.GlobalSearch.Update(msg)
 	if cmd != nil {
 		cmds = append(cmds, cmd)
 	}
 
 	if m.GlobalSearch.QueryChanged() {
-		query := m.GlobalSearch.Query()
-		results := m.SearchSvc.FilterLocal(query, m.Libraries)
-		m.GlobalSearch.SetResults(results)
+		m.searchGen++
+		if strings.TrimSpace(m.GlobalSearch.Query()) == "" {
+			m.GlobalSearch.SetResults(nil)
+			m.GlobalSearch.SetSearching(false)
+		} else {
+			m.GlobalSearch.SetSearching(true)
+			cmds = append(cmds, SearchDebounceCmd(m.searchGen))
+		}
 	}
 
 	if selected {
+		if ext := m.GlobalSearch.SelectedExternal(); ext != nil {
+			if ext.Added() {
+				cmds = append(cmds, m.notify(NoticeInfo, i18n.T("status.arr_status", ext.Title, ext.StatusLabel())))
+			} else {
+				cmds = append(cmds, m.notify(NoticeInfo, i18n.T("status.arr_adding", ext.Title)),
+					ArrAddCmd(m.ArrSvc, *ext))
+			}
+			return m, tea.Batch(cmds...)
+		}
 		if result := m.GlobalSearch.Selected(); result != nil {
 			m.GlobalSearch.Hide()
 			if navCmd := m.navigateToSearchResult(*result); navCmd != nil {
@@ -490,6 +916,21 @@ func (m Model) handleGlobalSearchInput(msg tea.KeyMsg) (Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// withEpisodeHit puts the episode a code query resolved to first, dropping
+// its match further down when the episode index found it too
+func (m Model) withEpisodeHit(results []search.FilterResult, gen int) []search.FilterResult {
+	if m.episodeHit == nil || m.episodeHitGen != gen {
+		return results
+	}
+	out := []search.FilterResult{*m.episodeHit}
+	for _, r := range results {
+		if r.Item.GetID() != m.episodeHit.Item.GetID() {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
 // handleSortModalInput handles input when sort modal is visible
 func (m Model) handleSortModalInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
 	handled, selection := m.SortModal.HandleKeyMsg(msg)
@@ -498,6 +939,7 @@ func (m Model) handleSortModalInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
 			if top := m.ColumnStack.Top(); top != nil {
 				top.ApplySort(selection.Field, selection.Direction)
 				m.updateInspector()
+				return true, m, m.rememberSort(top, selection.Field, selection.Direction)
 			}
 		}
 		return true, m, nil
@@ -505,6 +947,69 @@ func (m Model) handleSortModalInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
 	return false, m, nil
 }
 
+// handleResumeModalInput handles input when the resume prompt is visible
+func (m Model) handleResumeModalInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
+	handled, choice := m.ResumeModal.HandleKeyMsg(msg)
+	if !handled {
+		return false, m, nil
+	}
+	if choice == components.ResumeChoiceNone {
+		return true, m, nil
+	}
+	next, cmd := m.launchItem(m.ResumeModal.Item(), choice == components.ResumeChoiceResume)
+	return true, next.(Model), cmd
+}
+
+// handleVersionModalInput handles input when the version picker is visible
+func (m Model) handleVersionModalInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
+	handled, version := m.VersionModal.HandleKeyMsg(msg)
+	if !handled {
+		return false, m, nil
+	}
+	if version == nil {
+		return true, m, nil
+	}
+	item := m.VersionModal.Item()
+	return true, m, tea.Batch(
+		m.notify(NoticeInfo, i18n.T("status.launching_version", item.Title, version.Label())),
+		PlayVersionCmd(m.PlaybackSvc, item, version.ID, m.VersionModal.Resume()),
+	)
+}
+
+// handleRate opens the rating picker for the selected movie, episode or show
+func (m Model) handleRate() (tea.Model, tea.Cmd) {
+	top := m.ColumnStack.Top()
+	if top == nil {
+		return m, nil
+	}
+	if m.LibraryService == nil || !m.LibraryService.CanRate() {
+		return m, m.notify(NoticeInfo, i18n.T("status.no_ratings"))
+	}
+	switch v := top.SelectedItem().(type) {
+	case *domain.MediaItem:
+		if v.Type != domain.MediaTypeChannel {
+			m.RatingModal.Show(v.ID, v.Title, v.UserRating)
+			return m, nil
+		}
+	case *domain.Show:
+		m.RatingModal.Show(v.ID, v.Title, v.UserRating)
+		return m, nil
+	}
+	return m.notAvailableHere(i18n.T("action.rate"))
+}
+
+// handleRatingModalInput handles input when the rating picker is visible
+func (m Model) handleRatingModalInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
+	handled, chosen, rating := m.RatingModal.HandleKeyMsg(msg)
+	if !handled {
+		return false, m, nil
+	}
+	if !chosen {
+		return true, m, nil
+	}
+	return true, m, RateItemCmd(m.LibraryService, m.RatingModal.ItemID(), m.RatingModal.Title(), float64(rating))
+}
+
 // handlePlaylistModalInput handles input when playlist modal is visible
 func (m Model) handlePlaylistModalInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
 	handled, shouldClose, shouldCreate := m.PlaylistModal.HandleKeyMsg(msg)
@@ -526,44 +1031,69 @@ func (m Model) handlePlaylistModalInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
 // applyPlaylistCreate creates a new playlist and applies checkbox changes
 func (m Model) applyPlaylistCreate() (Model, tea.Cmd) {
 	title := m.PlaylistModal.NewPlaylistTitle()
-	item := m.PlaylistModal.Item()
+	ids := m.PlaylistModal.ItemIDs()
 	changes := m.PlaylistModal.GetChanges()
 	m.PlaylistModal.Hide()
 
-	if title == "" || item == nil {
+	if title == "" || len(ids) == 0 {
 		return m, nil
 	}
 
-	cmds := []tea.Cmd{CreatePlaylistCmd(m.PlaylistService, title, []string{item.ID})}
-	for _, change := range changes {
-		if change.Add {
-			cmds = append(cmds, AddToPlaylistCmd(m.PlaylistService, change.PlaylistID, []string{item.ID}))
-		} else {
-			cmds = append(cmds, RemoveFromPlaylistCmd(m.PlaylistService, change.PlaylistID, item.ID))
-		}
-	}
+	cmds := []tea.Cmd{CreatePlaylistCmd(m.PlaylistService, title, ids)}
+	cmds = append(cmds, m.playlistChangeCmds(changes, ids)...)
+	m.clearMarksAfterBatch(len(ids))
 	return m, tea.Batch(cmds...)
 }
 
 // applyPlaylistChanges applies pending playlist checkbox changes
 func (m Model) applyPlaylistChanges() (Model, tea.Cmd) {
 	changes := m.PlaylistModal.GetChanges()
-	item := m.PlaylistModal.Item()
+	ids := m.PlaylistModal.ItemIDs()
 	m.PlaylistModal.Hide()
 
-	if len(changes) == 0 || item == nil {
+	if len(changes) == 0 || len(ids) == 0 {
 		return m, nil
 	}
 
+	m.clearMarksAfterBatch(len(ids))
+	return m, tea.Batch(m.playlistChangeCmds(changes, ids)...)
+}
+
+// playlistChangeCmds builds add/remove commands for checkbox changes. A
+// batch of marked items runs as one job, reported once its last change lands.
+func (m Model) playlistChangeCmds(changes []components.PlaylistChange, ids []string) []tea.Cmd {
+	units := 0
+	for _, change := range changes {
+		if change.Add {
+			units++
+		} else {
+			units += len(ids)
+		}
+	}
+	ctx, jobID := context.Background(), 0
+	if len(ids) > 1 && units > 0 {
+		jobID, ctx = m.jobs.Start(JobPlaylistEdit, fmt.Sprintf("Playlist edit (%d items)", len(ids)), units)
+	}
+
 	var cmds []tea.Cmd
 	for _, change := range changes {
 		if change.Add {
-			cmds = append(cmds, AddToPlaylistCmd(m.PlaylistService, change.PlaylistID, []string{item.ID}))
+			cmds = append(cmds, AddToPlaylistCmd(ctx, jobID, m.PlaylistService, change.PlaylistID, ids))
 		} else {
-			cmds = append(cmds, RemoveFromPlaylistCmd(m.PlaylistService, change.PlaylistID, item.ID))
+			for _, id := range ids {
+				cmds = append(cmds, RemoveFromPlaylistCmd(ctx, jobID, m.PlaylistService, change.PlaylistID, id))
+			}
 		}
 	}
-	return m, tea.Batch(cmds...)
+	return cmds
+}
+
+// clearMarksAfterBatch drops the top column's marks once a batch of more
+// than one item has been handed off
+func (m Model) clearMarksAfterBatch(n int) {
+	if top := m.ColumnStack.Top(); top != nil && n > 1 {
+		top.ClearMarks()
+	}
 }
 
 // handleInputModalInput handles input when input modal is visible
@@ -574,11 +1104,18 @@ func (m Model) handleInputModalInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
 	m.InputModal, cmd, submitted = m.InputModal.Update(msg)
 	if submitted {
 		title := m.InputModal.Value()
+		ids := m.newPlaylistIDs
 		m.InputModal.Hide()
-		if title != "" {
-			return true, m, CreatePlaylistCmd(m.PlaylistService, title, []string{})
+		m.newPlaylistIDs = nil
+		if title == "" {
+			return true, m, nil
 		}
-		return true, m, nil
+		if len(ids) > 0 {
+			if top := m.ColumnStack.Top(); top != nil {
+				top.ClearMarks()
+			}
+		}
+		return true, m, CreatePlaylistCmd(m.PlaylistService, title, ids)
 	}
 	if cmd != nil {
 		return true, m, cmd
@@ -586,6 +1123,23 @@ func (m Model) handleInputModalInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
 	return true, m, nil
 }
 
+// handlePlaylistEditModalInput handles input when the playlist editor is visible
+func (m Model) handlePlaylistEditModalInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var submitted bool
+
+	m.PlaylistEditModal, cmd, submitted = m.PlaylistEditModal.Update(msg)
+	if submitted {
+		title, description := m.PlaylistEditModal.Values()
+		if title == "" {
+			return true, m, m.notify(NoticeError, i18n.T("status.playlist_title_empty"))
+		}
+		m.PlaylistEditModal.Hide()
+		return true, m, UpdatePlaylistCmd(m.PlaylistService, m.PlaylistEditModal.PlaylistID(), title, description)
+	}
+	return true, m, cmd
+}
+
 // handleFilterTypingInput handles input when filter typing mode is active
 func (m Model) handleFilterTypingInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
 	top := m.ColumnStack.Top()
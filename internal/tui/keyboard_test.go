@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/playlist"
+	"github.com/mmcdole/kino/internal/search"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// n names a new playlist of the marked items on any server, and an empty
+// one in the playlists column only where the server allows it
+func TestNewPlaylist(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newModel := func(server *fakeServer, col *components.ListColumn) Model {
+		m := Model{ColumnStack: NewColumnStack(), PlaylistService: playlist.NewService(server, st, nil), InputModal: components.NewInputModal()}
+		m.ColumnStack.Push(col, 0)
+		return m
+	}
+	create := func(m Model, title string) Model {
+		t.Helper()
+		for _, k := range []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("n")}, {Type: tea.KeyRunes, Runes: []rune(title)}, {Type: tea.KeyEnter}} {
+			updated, cmd := m.Update(k)
+			m = updated.(Model)
+			if k.Type == tea.KeyEnter && cmd != nil {
+				cmd()
+			}
+		}
+		return m
+	}
+
+	plex := &fakeServer{}
+	m := create(newModel(plex, components.NewListColumn(components.ColumnTypePlaylists, "Playlists")), "Empty")
+	if len(plex.created) != 0 || m.notice.Text != i18n.T("status.playlist_needs_items") {
+		t.Fatalf("empty playlist on a server without them: created %v, notice %q", plex.created, m.notice.Text)
+	}
+
+	jellyfin := &fakeServer{emptyPlaylists: true}
+	create(newModel(jellyfin, components.NewListColumn(components.ColumnTypePlaylists, "Playlists")), "Empty")
+	if len(jellyfin.created) != 1 || len(jellyfin.created[0]) != 0 {
+		t.Fatalf("empty playlist not created: %v", jellyfin.created)
+	}
+
+	movies := components.NewListColumn(components.ColumnTypeMovies, "Movies")
+	movies.SetItems([]*domain.MediaItem{
+		{ID: "m1", Title: "Alien", Type: domain.MediaTypeMovie},
+		{ID: "m2", Title: "Heat", Type: domain.MediaTypeMovie},
+		{ID: "m3", Title: "Ran", Type: domain.MediaTypeMovie},
+	})
+	movies.ToggleMark()
+	movies.ToggleMark()
+	m = create(newModel(plex, movies), "Picks")
+	if len(plex.created) != 1 || strings.Join(plex.created[0], ",") != "m1,m2" || movies.MarkedCount() != 0 {
+		t.Fatalf("playlist of marked items: created %v, %d still marked", plex.created, movies.MarkedCount())
+	}
+}
+
+// * opens the picker on the selected movie; a digit rates it on the server,
+// in the column and in the cache
+func TestRateItem(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	movies := []*domain.MediaItem{{ID: "m1", Title: "Heat", Type: domain.MediaTypeMovie, LibraryID: "1"}}
+	if err := st.SaveMovies("1", movies, 1); err != nil {
+		t.Fatal(err)
+	}
+	server := &fakeServer{}
+	m := Model{ColumnStack: NewColumnStack(), LibraryService: library.NewService(server, st, nil)}
+	col := components.NewListColumn(components.ColumnTypeMovies, "Movies")
+	col.SetItems([]*domain.MediaItem{{ID: "m1", Title: "Heat", Type: domain.MediaTypeMovie}})
+	m.ColumnStack.Push(col, 0)
+
+	var cmd tea.Cmd
+	for _, k := range []string{"*", "8"} {
+		var updated tea.Model
+		updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = updated.(Model)
+	}
+	if m.RatingModal.IsVisible() || cmd == nil {
+		t.Fatal("picking 8 did not rate")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+
+	if server.rated["m1"] != 8 {
+		t.Fatalf("server got %v", server.rated)
+	}
+	if got := col.SelectedMediaItem().UserRating; got != 8 {
+		t.Fatalf("column rating = %v, want 8", got)
+	}
+	cached, _ := st.GetMovies("1")
+	if len(cached) != 1 || cached[0].UserRating != 8 {
+		t.Fatalf("cached = %+v", cached)
+	}
+}
+
+// An episode-code query pins the episode it resolved to above the matches,
+// whichever arrives first, without listing it twice
+func TestEpisodeQueryPinsEpisode(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack(), jobs: NewJobs(), GlobalSearch: components.NewGlobalSearch(), searchGen: 3}
+	m.GlobalSearch.Show()
+	ep := &domain.MediaItem{ID: "e5", Title: "Straight and True", ShowTitle: "The Wire", SeasonNum: 3, EpisodeNum: 5, Type: domain.MediaTypeEpisode}
+	show := &domain.Show{ID: "wire", Title: "The Wire"}
+	hit := search.FilterResult{FilterItem: search.EpisodeItem(ep, "tv")}
+
+	updated, _ := m.Update(EpisodeQueryMsg{Gen: 2, Result: hit})
+	m = updated.(Model)
+	if m.episodeHit != nil {
+		t.Fatal("kept a hit for a superseded query")
+	}
+	updated, _ = m.Update(EpisodeQueryMsg{Gen: 3, Result: hit})
+	m = updated.(Model)
+	updated, _ = m.Update(SearchResultsMsg{Gen: 3, First: true, Done: true, Results: []search.FilterResult{
+		{FilterItem: search.FilterItem{Item: show, Title: show.Title, Type: domain.MediaTypeShow, LibraryID: "tv"}},
+		hit,
+	}})
+	m = updated.(Model)
+
+	results := m.GlobalSearch.Results()
+	if len(results) != 2 || results[0].Item.GetID() != "e5" || results[1].Item.GetID() != "wire" {
+		t.Fatalf("results = %+v", results)
+	}
+	if sel := m.GlobalSearch.Selected(); sel == nil || sel.Title != "The Wire - S03E05 Straight and True" {
+		t.Errorf("selected = %+v", sel)
+	}
+}
@@ -0,0 +1,187 @@
+package tui
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// keyBindingType is used to find key.Binding fields in key map structs
+var keyBindingType = reflect.TypeOf(key.Binding{})
+
+// keyMapRef selects bindings from a key map struct. Fields lists the
+// bindings that are live in the context; empty means all of them.
+type keyMapRef struct {
+	keyMap interface{} // pointer to a key map struct
+	fields []string
+}
+
+// keyContext is a set of bindings that are matched against the same key
+// press. The same key in two contexts is fine (j is "down" everywhere);
+// the same key twice within one context is ambiguous.
+type keyContext struct {
+	name string
+	maps []keyMapRef
+}
+
+// KeyConflict is one key bound to several actions in the same context
+type KeyConflict struct {
+	Context string
+	Key     string
+	Actions []string
+}
+
+// String describes the conflict for the startup report
+func (c KeyConflict) String() string {
+	return fmt.Sprintf("%s: %q is bound to %s", c.Context, c.Key, strings.Join(c.Actions, ", "))
+}
+
+// keyContexts mirrors how handleKeyMsg dispatches: confirm dialogs, then
+// the visible modal, then filter typing, then the browsing keys followed by
+// column navigation
+func keyContexts() []keyContext {
+	return []keyContext{
+		{name: "browsing", maps: []keyMapRef{
+			{keyMap: &Keys, fields: []string{
+				"Right", "Enter", "Left", "Back", "Quit", "Help", "Escape", "Filter",
+				"GlobalSearch", "Sort", "Specials", "Libraries", "Refresh", "RefreshAll", "MarkWatched",
+				"MarkUnwatched", "Play", "ToggleInspector", "Peek", "Logout",
+				"PlaylistModal", "Delete", "NewPlaylist", "EditPlaylist", "ExportPlaylist", "ToggleMark",
+				"TogglePrivate", "Jobs", "WatchFilter", "OpenExternal", "Quality", "Debug", "NewItems",
+				"Watchlist", "Rate", "Activities", "Command",
+				"Bookmark", "JumpBookmark", "Split", "SwitchPane", "Home", "Details", "People",
+				"Cast", "PausePlayer", "SeekBackPlayer", "SeekForwardPlayer", "StopPlayer",
+			}},
+			{keyMap: &components.ListColumnKeys, fields: []string{
+				"Up", "Down", "Home", "End", "HalfUp", "HalfDown", "PageUp", "PageDown",
+			}},
+		}},
+		{name: "confirmation", maps: []keyMapRef{
+			{keyMap: &Keys, fields: []string{"Confirm", "Deny"}},
+		}},
+		{name: "filter typing", maps: []keyMapRef{
+			{keyMap: &components.ListColumnKeys, fields: []string{"Escape", "Enter"}},
+		}},
+		{name: "global search", maps: []keyMapRef{{keyMap: &components.GlobalSearchKeys}}},
+		{name: "sort", maps: []keyMapRef{{keyMap: &components.SortModalKeys}}},
+		{name: "resume prompt", maps: []keyMapRef{{keyMap: &components.ResumeModalKeys}}},
+		{name: "version picker", maps: []keyMapRef{{keyMap: &components.VersionModalKeys}}},
+		{name: "rating picker", maps: []keyMapRef{{keyMap: &components.RatingModalKeys}}},
+		{name: "playlists", maps: []keyMapRef{{keyMap: &components.PlaylistModalKeys}}},
+		{name: "libraries", maps: []keyMapRef{{keyMap: &components.LibraryModalKeys}}},
+		{name: "jobs", maps: []keyMapRef{{keyMap: &JobsKeys}}},
+		{name: "server activity", maps: []keyMapRef{{keyMap: &ActivitiesKeys}}},
+		{name: "context menu", maps: []keyMapRef{{keyMap: &ContextMenuKeys}}},
+		{name: "details", maps: []keyMapRef{
+			{keyMap: &DetailViewKeys},
+			{keyMap: &Keys, fields: []string{"Quit", "Help"}},
+		}},
+		{name: "home", maps: []keyMapRef{
+			{keyMap: &HomeKeys},
+			{keyMap: &Keys, fields: []string{"Quit", "Help"}},
+		}},
+	}
+}
+
+// contextBindings returns the named, addressable bindings of a context
+func contextBindings(ctx keyContext) (names []string, bindings []*key.Binding) {
+	for _, ref := range ctx.maps {
+		v := reflect.ValueOf(ref.keyMap).Elem()
+		t := v.Type()
+		wanted := make(map[string]bool, len(ref.fields))
+		for _, f := range ref.fields {
+			wanted[f] = true
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.Type != keyBindingType || (len(wanted) > 0 && !wanted[field.Name]) {
+				continue
+			}
+			names = append(names, field.Name)
+			bindings = append(bindings, v.Field(i).Addr().Interface().(*key.Binding))
+		}
+	}
+	return names, bindings
+}
+
+// findKeyConflicts reports every key bound to more than one action within
+// a context, in context then key order
+func findKeyConflicts(contexts []keyContext) []KeyConflict {
+	var conflicts []KeyConflict
+	for _, ctx := range contexts {
+		names, bindings := contextBindings(ctx)
+		byKey := make(map[string][]string)
+		for i, b := range bindings {
+			if !b.Enabled() {
+				continue
+			}
+			for _, k := range b.Keys() {
+				byKey[k] = append(byKey[k], names[i])
+			}
+		}
+
+		keys := make([]string, 0, len(byKey))
+		for k, actions := range byKey {
+			if len(actions) > 1 {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			conflicts = append(conflicts, KeyConflict{Context: ctx.name, Key: k, Actions: byKey[k]})
+		}
+	}
+	return conflicts
+}
+
+// disableConflictingKeys removes each ambiguous key from every binding that
+// claims it, so the key does nothing instead of whichever handler happens to
+// be checked first. The bindings' other keys keep working.
+func disableConflictingKeys(contexts []keyContext, conflicts []KeyConflict) {
+	ambiguous := make(map[string]map[string]bool)
+	for _, c := range conflicts {
+		if ambiguous[c.Context] == nil {
+			ambiguous[c.Context] = make(map[string]bool)
+		}
+		ambiguous[c.Context][c.Key] = true
+	}
+
+	for _, ctx := range contexts {
+		drop := ambiguous[ctx.name]
+		if len(drop) == 0 {
+			continue
+		}
+		_, bindings := contextBindings(ctx)
+		for _, b := range bindings {
+			var kept []string
+			for _, k := range b.Keys() {
+				if !drop[k] {
+					kept = append(kept, k)
+				}
+			}
+			if len(kept) == len(b.Keys()) {
+				continue
+			}
+			if len(kept) == 0 {
+				b.SetEnabled(false)
+				continue
+			}
+			b.SetKeys(kept...)
+		}
+	}
+}
+
+// validateKeyBindings checks the active key maps and disables ambiguous
+// keys. The returned conflicts are shown on the startup screen.
+func validateKeyBindings() []KeyConflict {
+	contexts := keyContexts()
+	conflicts := findKeyConflicts(contexts)
+	if len(conflicts) > 0 {
+		disableConflictingKeys(contexts, conflicts)
+	}
+	return conflicts
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// The shipped key maps must be unambiguous in every context
+func TestDefaultKeyBindingsHaveNoConflicts(t *testing.T) {
+	for _, c := range findKeyConflicts(keyContexts()) {
+		t.Errorf("conflict: %s", c)
+	}
+}
+
+// A key bound twice in one context is reported and removed from both
+// bindings; their other keys and other contexts are untouched
+func TestKeyConflictsAreDisabled(t *testing.T) {
+	type testKeys struct {
+		Delete  key.Binding
+		Descend key.Binding
+	}
+	km := testKeys{
+		Delete:  key.NewBinding(key.WithKeys("d", "x")),
+		Descend: key.NewBinding(key.WithKeys("d")),
+	}
+	other := testKeys{Delete: key.NewBinding(key.WithKeys("d"))}
+	contexts := []keyContext{
+		{name: "test", maps: []keyMapRef{{keyMap: &km}}},
+		{name: "other", maps: []keyMapRef{{keyMap: &other}}},
+	}
+
+	conflicts := findKeyConflicts(contexts)
+	if len(conflicts) != 1 || conflicts[0].Context != "test" || conflicts[0].Key != "d" {
+		t.Fatalf("conflicts = %v", conflicts)
+	}
+
+	disableConflictingKeys(contexts, conflicts)
+	if keys := km.Delete.Keys(); len(keys) != 1 || keys[0] != "x" {
+		t.Fatalf("Delete keys = %v, want [x]", keys)
+	}
+	if km.Descend.Enabled() {
+		t.Fatal("binding left with no keys must be disabled")
+	}
+	if !other.Delete.Enabled() {
+		t.Fatal("binding in another context was disabled")
+	}
+	if len(findKeyConflicts(contexts)) != 0 {
+		t.Fatal("conflicts remain after disabling")
+	}
+}
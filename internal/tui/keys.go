@@ -7,6 +7,7 @@ type KeyMap struct {
 	// Navigation
 	Right key.Binding
 	Enter key.Binding
+	Left  key.Binding
 	Back  key.Binding
 
 	// Actions
@@ -16,16 +17,47 @@ type KeyMap struct {
 	Filter          key.Binding
 	GlobalSearch    key.Binding
 	Sort            key.Binding
+	Specials        key.Binding
+	Libraries       key.Binding
 	Refresh         key.Binding
 	RefreshAll      key.Binding
 	MarkWatched     key.Binding
 	MarkUnwatched   key.Binding
 	Play            key.Binding
 	ToggleInspector key.Binding
+	Peek            key.Binding
 	Logout          key.Binding
 	PlaylistModal   key.Binding
 	Delete          key.Binding
 	NewPlaylist     key.Binding
+	EditPlaylist    key.Binding
+	ExportPlaylist  key.Binding
+	ToggleMark      key.Binding
+	TogglePrivate   key.Binding
+	Jobs            key.Binding
+	WatchFilter     key.Binding
+	OpenExternal    key.Binding
+	Quality         key.Binding
+	Debug           key.Binding
+	NewItems        key.Binding
+	Watchlist       key.Binding
+	Rate            key.Binding
+	Activities      key.Binding
+	Command         key.Binding
+	Bookmark        key.Binding
+	JumpBookmark    key.Binding
+	Split           key.Binding
+	SwitchPane      key.Binding
+	Home            key.Binding
+	Details         key.Binding
+	People          key.Binding
+
+	// The running player (see nowplaying.go) and other devices (cast.go)
+	Cast              key.Binding
+	PausePlayer       key.Binding
+	SeekBackPlayer    key.Binding
+	SeekForwardPlayer key.Binding
+	StopPlayer        key.Binding
 
 	// Confirmations
 	Confirm key.Binding
@@ -44,9 +76,13 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "select/play"),
 		),
+		Left: key.NewBinding(
+			key.WithKeys("h", "left"),
+			key.WithHelp("h/←", "parent"),
+		),
 		Back: key.NewBinding(
-			key.WithKeys("h", "left", "backspace"),
-			key.WithHelp("h/←", "back"),
+			key.WithKeys("backspace"),
+			key.WithHelp("backspace", "back"),
 		),
 
 		// Actions
@@ -74,6 +110,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("s"),
 			key.WithHelp("s", "sort"),
 		),
+		Specials: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "specials placement"),
+		),
+		Libraries: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "show/hide libraries"),
+		),
 		Refresh: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh view"),
@@ -98,6 +142,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("i"),
 			key.WithHelp("i", "toggle inspector"),
 		),
+		Peek: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "peek"),
+		),
 		Logout: key.NewBinding(
 			key.WithKeys("L"),
 			key.WithHelp("L", "logout"),
@@ -114,6 +162,112 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("n"),
 			key.WithHelp("n", "new"),
 		),
+		EditPlaylist: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit playlist"),
+		),
+		ExportPlaylist: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "export playlist"),
+		),
+		ToggleMark: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "mark for batch"),
+		),
+		TogglePrivate: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "private session"),
+		),
+		Jobs: key.NewBinding(
+			key.WithKeys("ctrl+j"),
+			key.WithHelp("ctrl+j", "jobs"),
+		),
+		WatchFilter: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "watch filter"),
+		),
+		OpenExternal: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open IMDb/TMDB"),
+		),
+		Quality: key.NewBinding(
+			key.WithKeys("Q"),
+			key.WithHelp("Q", "stream quality"),
+		),
+		Debug: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "API request log"),
+		),
+		NewItems: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "new items"),
+		),
+		Watchlist: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "watchlist add/remove"),
+		),
+		Rate: key.NewBinding(
+			key.WithKeys("*"),
+			key.WithHelp("*", "rate"),
+		),
+		Activities: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "server activity"),
+		),
+		Command: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "command"),
+		),
+		Bookmark: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m1-9", "bookmark"),
+		),
+		JumpBookmark: key.NewBinding(
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+			key.WithHelp("1-9", "jump to bookmark"),
+		),
+		Split: key.NewBinding(
+			key.WithKeys("|"),
+			key.WithHelp("|", "split view"),
+		),
+		SwitchPane: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "switch pane"),
+		),
+		Home: key.NewBinding(
+			key.WithKeys("~"),
+			key.WithHelp("~", "home"),
+		),
+		Details: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", "details"),
+		),
+		People: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "cast & crew"),
+		),
+
+		// The running player and other devices
+		Cast: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "play on..."),
+		),
+		PausePlayer: key.NewBinding(
+			key.WithKeys("."),
+			key.WithHelp(".", "pause/resume player"),
+		),
+		SeekBackPlayer: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "seek back"),
+		),
+		SeekForwardPlayer: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "seek forward"),
+		),
+		StopPlayer: key.NewBinding(
+			key.WithKeys("ctrl+x"),
+			key.WithHelp("ctrl+x", "stop player"),
+		),
 
 		// Confirmations
 		Confirm: key.NewBinding(
@@ -1,5 +1,7 @@
 package tui
 
+import "github.com/mmcdole/kino/internal/tui/components"
+
 // columnLayout holds calculated column widths for the View
 type columnLayout struct {
 	grandparentWidth int // 0 if not shown
@@ -8,9 +10,22 @@ type columnLayout struct {
 	inspectorWidth   int // 0 if not shown
 }
 
+// displayColumns returns the list columns laid out left to right: the
+// stack, followed by its forward column when the inspector is hidden
+func (m Model) displayColumns() []*components.ListColumn {
+	cols := make([]*components.ListColumn, 0, m.ColumnStack.Len()+1)
+	for i := 0; i < m.ColumnStack.Len(); i++ {
+		cols = append(cols, m.ColumnStack.Get(i))
+	}
+	if fwd := m.ColumnStack.Forward(); fwd != nil && !m.ShowInspector {
+		cols = append(cols, fwd)
+	}
+	return cols
+}
+
 // calculateColumnLayout computes column widths based on stack depth and inspector visibility
 func (m Model) calculateColumnLayout(availableWidth int) columnLayout {
-	stackLen := m.ColumnStack.Len()
+	stackLen := len(m.displayColumns())
 	layout := columnLayout{}
 
 	// Helper to apply minimum width
@@ -69,39 +84,53 @@ func (m *Model) updateLayout() {
 		return
 	}
 
-	contentHeight := m.Height - ChromeHeight
 	m.GlobalSearch.SetSize(m.Width, m.Height)
+	if m.split != nil {
+		_, focusedWidth := m.focusedPaneArea()
+		other := m.otherPaneModel()
+		other.layoutColumns(m.Width - focusedWidth)
+		m.layoutColumns(focusedWidth)
+		return
+	}
+	m.layoutColumns(m.Width)
+}
+
+// layoutColumns sizes the stack's columns, and the inspector when shown, to
+// fit width
+func (m *Model) layoutColumns(width int) {
+	contentHeight := m.Height - ChromeHeight
 
-	stackLen := m.ColumnStack.Len()
+	cols := m.displayColumns()
+	stackLen := len(cols)
 	if stackLen == 0 {
 		return
 	}
 
 	// Calculate layout using shared logic
-	layout := m.calculateColumnLayout(m.Width)
+	layout := m.calculateColumnLayout(width)
 	topIdx := stackLen - 1
 
 	// Apply calculated sizes to components
 	switch stackLen {
 	case 1:
-		m.ColumnStack.Get(0).SetSize(layout.activeWidth, contentHeight)
+		cols[0].SetSize(layout.activeWidth, contentHeight)
 		if m.ShowInspector {
 			m.Inspector.SetSize(layout.inspectorWidth, contentHeight)
 		}
 
 	case 2:
-		m.ColumnStack.Get(topIdx-1).SetSize(layout.parentWidth, contentHeight)
-		m.ColumnStack.Get(topIdx).SetSize(layout.activeWidth, contentHeight)
+		cols[topIdx-1].SetSize(layout.parentWidth, contentHeight)
+		cols[topIdx].SetSize(layout.activeWidth, contentHeight)
 		if m.ShowInspector {
 			m.Inspector.SetSize(layout.inspectorWidth, contentHeight)
 		}
 
 	default: // 3+ columns
 		if layout.grandparentWidth > 0 {
-			m.ColumnStack.Get(topIdx-2).SetSize(layout.grandparentWidth, contentHeight)
+			cols[topIdx-2].SetSize(layout.grandparentWidth, contentHeight)
 		}
-		m.ColumnStack.Get(topIdx-1).SetSize(layout.parentWidth, contentHeight)
-		m.ColumnStack.Get(topIdx).SetSize(layout.activeWidth, contentHeight)
+		cols[topIdx-1].SetSize(layout.parentWidth, contentHeight)
+		cols[topIdx].SetSize(layout.activeWidth, contentHeight)
 		if m.ShowInspector {
 			m.Inspector.SetSize(layout.inspectorWidth, contentHeight)
 		}
@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// HiddenLibrariesSavedMsg reports the result of persisting the hidden
+// libraries
+type HiddenLibrariesSavedMsg struct {
+	Err error
+}
+
+// SaveHiddenLibrariesCmd persists the hidden libraries to the config file
+func SaveHiddenLibrariesCmd(libraries []string) tea.Cmd {
+	return func() tea.Msg {
+		return HiddenLibrariesSavedMsg{Err: config.SaveHiddenLibraries(libraries)}
+	}
+}
+
+// libraryHidden reports whether the user hid a library, by ID or name
+func (m *Model) libraryHidden(lib domain.Library) bool {
+	for _, hidden := range m.UIConfig.HiddenLibraries {
+		if lib.ID == hidden || strings.EqualFold(lib.Name, hidden) {
+			return true
+		}
+	}
+	return false
+}
+
+// visibleLibraries drops the hidden libraries, keeping server order
+func (m *Model) visibleLibraries(libs []domain.Library) []domain.Library {
+	visible := make([]domain.Library, 0, len(libs))
+	for _, lib := range libs {
+		if !m.libraryHidden(lib) {
+			visible = append(visible, lib)
+		}
+	}
+	return visible
+}
+
+// beginLibrarySyncs shows libraries whose cache timestamp still matches as
+// synced from disk (one batched count check weeds out the stale ones, see
+// LibrariesStaleMsg) and queues a full sync for the rest
+func (m *Model) beginLibrarySyncs(libs []domain.Library) []tea.Cmd {
+	var toSync, toVerify []domain.Library
+	var cmds []tea.Cmd
+	for _, lib := range libs {
+		if count, unwatched, ok := m.LibraryService.CachedCount(lib); ok {
+			m.LibraryStates[lib.ID] = components.LibrarySyncState{
+				Status: components.StatusSynced, Loaded: count, Total: count, FromCache: true,
+				Unwatched: unwatched, HasUnwatched: true,
+			}
+			toVerify = append(toVerify, lib)
+			cmds = append(cmds, ClearLibraryStatusCmd(lib.ID, 2*time.Second))
+			continue
+		}
+		toSync = append(toSync, lib)
+	}
+	for _, lib := range toSync {
+		cmds = append(cmds, m.queueSync(lib, false))
+	}
+	if len(toVerify) > 0 {
+		cmds = append(cmds, CheckFreshnessCmd(m.LibraryService, toVerify, m.SyncGen))
+	}
+	return cmds
+}
+
+// handleLibraryVisibility opens the show/hide list of the server's
+// libraries (H in the library column)
+func (m Model) handleLibraryVisibility() (tea.Model, tea.Cmd) {
+	if m.ColumnStack.Len() != 1 || len(m.serverLibraries) == 0 {
+		return m.notAvailableHere(i18n.T("action.libraries"))
+	}
+	m.LibraryModal.Show(m.serverLibraries, m.libraryHidden)
+	m.LibraryModal.SetSize(m.Width, m.Height)
+	return m, nil
+}
+
+// applyLibraryVisibility filters the server's libraries again after the
+// hidden ones changed: newly shown libraries sync, hidden ones leave the
+// column and search. It reports whether any library came or went.
+func (m *Model) applyLibraryVisibility() ([]tea.Cmd, bool) {
+	before := m.Libraries
+	m.Libraries = m.visibleLibraries(m.serverLibraries)
+	if slices.Equal(before, m.Libraries) {
+		return nil, false
+	}
+
+	var shown []domain.Library
+	for _, lib := range m.Libraries {
+		if !slices.Contains(before, lib) {
+			shown = append(shown, lib)
+		}
+	}
+	// Syncs of libraries just hidden are no longer wanted
+	for _, lib := range before {
+		if !slices.Contains(m.Libraries, lib) {
+			m.cancelLibrarySync(lib.ID)
+		}
+	}
+	cmds := append(m.beginLibrarySyncs(shown), m.drainSyncQueue())
+	if libCol := m.libraryColumn(); libCol != nil {
+		libCol.ReplaceItems(m.allLibraryEntries())
+	}
+	m.updateLibraryStates()
+	m.updateInspector()
+	return cmds, true
+}
+
+// handleLibraryModalInput handles input when the library modal is visible.
+// Closing it applies the choice: newly shown libraries sync, hidden ones
+// leave the column and search.
+func (m Model) handleLibraryModalInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
+	handled, done := m.LibraryModal.HandleKeyMsg(msg)
+	if !done {
+		return handled, m, nil
+	}
+
+	hidden := m.LibraryModal.HiddenIDs()
+	m.UIConfig.HiddenLibraries = hidden
+	cmds, changed := m.applyLibraryVisibility()
+	if !changed {
+		return true, m, nil
+	}
+	cmds = append(cmds,
+		m.notify(NoticeInfo, i18n.T("status.libraries_shown", len(m.Libraries), len(m.serverLibraries))),
+		SaveHiddenLibrariesCmd(hidden),
+	)
+	return true, m, tea.Batch(cmds...)
+}
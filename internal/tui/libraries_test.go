@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// Hidden libraries (by name in config, or toggled in the H list) leave the
+// library column and are remembered by ID
+func TestHiddenLibraries(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack(), LibraryStates: map[string]components.LibrarySyncState{}}
+	m.UIConfig.HiddenLibraries = []string{"photos"}
+	m.serverLibraries = []domain.Library{
+		{ID: "1", Name: "Movies", Type: "movie"},
+		{ID: "2", Name: "Photos", Type: "photo"},
+		{ID: "3", Name: "TV", Type: "show"},
+	}
+	m.Libraries = m.visibleLibraries(m.serverLibraries)
+	if len(m.Libraries) != 2 || m.Libraries[1].ID != "3" {
+		t.Fatalf("visible = %+v", m.Libraries)
+	}
+	m.ColumnStack.Push(components.NewLibraryColumn(m.allLibraryEntries()), 0)
+
+	updated, _ := m.handleLibraryVisibility()
+	m = updated.(Model)
+	if !m.LibraryModal.IsVisible() {
+		t.Fatal("library list not opened")
+	}
+	// Uncheck TV, then close
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyDown}, {Type: tea.KeyDown}, {Type: tea.KeySpace, Runes: []rune{' '}}, {Type: tea.KeyEsc},
+	} {
+		_, m, _ = m.handleLibraryModalInput(k)
+	}
+
+	if got := strings.Join(m.UIConfig.HiddenLibraries, ","); got != "2,3" {
+		t.Fatalf("hidden = %s, want 2,3", got)
+	}
+	if len(m.Libraries) != 1 || m.Libraries[0].ID != "1" {
+		t.Fatalf("visible after toggle = %+v", m.Libraries)
+	}
+	if lib := m.libraryColumn().SelectedLibrary(); lib == nil || lib.ID != "1" {
+		t.Fatalf("library column not updated: %+v", lib)
+	}
+}
+
+// Refreshing at the root rebuilds the library column on the library that
+// was selected, or on the same row when that library is gone
+func TestRefreshKeepsSelectedLibrary(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := Model{
+		ColumnStack:    NewColumnStack(),
+		Store:          st,
+		LibraryService: library.NewService(nil, st, nil),
+		LibraryStates:  map[string]components.LibrarySyncState{},
+		jobs:           NewJobs(),
+	}
+	a, b, c := domain.Library{ID: "a", Name: "A"}, domain.Library{ID: "b", Name: "B"}, domain.Library{ID: "c", Name: "C"}
+	updated, _ := m.Update(LibrariesLoadedMsg{Libraries: []domain.Library{a, b, c}})
+	m = updated.(Model)
+	m.libraryColumn().SetSelectedByID("b")
+
+	updated, _ = m.Update(LibrariesLoadedMsg{Libraries: []domain.Library{{ID: "0", Name: "0"}, a, b, c}, Refresh: true})
+	m = updated.(Model)
+	if sel := m.libraryColumn().SelectedLibrary(); sel == nil || sel.ID != "b" {
+		t.Fatalf("selected after refresh = %+v, want b", sel)
+	}
+
+	row := m.libraryColumn().SelectedIndex()
+	updated, _ = m.Update(LibrariesLoadedMsg{Libraries: []domain.Library{{ID: "0", Name: "0"}, a, c}, Refresh: true})
+	m = updated.(Model)
+	if got := m.libraryColumn().SelectedIndex(); got != row {
+		t.Fatalf("row after the library vanished = %d, want %d", got, row)
+	}
+}
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/library"
+)
+
+// Live updates. On backends that push change notifications kino listens
+// while it runs, and once a library's changes have settled for liveSettle
+// it runs the same silent sync as auto-refresh (see autorefresh.go). A
+// dropped stream is reopened after liveRetry, once the server answers.
+const (
+	liveSettle = 5 * time.Second
+	liveRetry  = 30 * time.Second
+)
+
+// liveUpdates is the open notification stream and the libraries waiting
+// for their changes to settle
+type liveUpdates struct {
+	gen     int                      // Bumped when the stream is reopened; older messages are dropped
+	updates <-chan domain.LiveUpdate // The open stream; nil while closed
+	cancel  context.CancelFunc       // Closes it
+	pending map[string]int           // Library ID → settle count; only the latest settles
+}
+
+// LiveUpdatesOpenedMsg carries a freshly opened notification stream
+type LiveUpdatesOpenedMsg struct {
+	Gen     int
+	Updates <-chan domain.LiveUpdate
+	Cancel  context.CancelFunc
+	Err     error
+}
+
+// LiveUpdateMsg carries one change from the stream; Closed is set instead
+// once the stream ends
+type LiveUpdateMsg struct {
+	Gen    int
+	Update domain.LiveUpdate
+	Closed bool
+}
+
+// LiveRetryMsg fires when a dropped stream is due to be reopened
+type LiveRetryMsg struct {
+	Gen int
+}
+
+// LiveSettledMsg fires liveSettle after a library's latest change
+type LiveSettledMsg struct {
+	LibraryID string
+	Count     int
+}
+
+// WatchUpdatesCmd opens the server's notification stream
+func WatchUpdatesCmd(svc *library.Service, gen int) tea.Cmd {
+	if svc == nil || !svc.HasLiveUpdates() {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithCancel(context.Background())
+		updates, err := svc.WatchUpdates(ctx)
+		if err != nil {
+			cancel()
+		}
+		return LiveUpdatesOpenedMsg{Gen: gen, Updates: updates, Cancel: cancel, Err: err}
+	}
+}
+
+// nextLiveUpdateCmd waits for the stream's next change
+func nextLiveUpdateCmd(updates <-chan domain.LiveUpdate, gen int) tea.Cmd {
+	return func() tea.Msg {
+		update, ok := <-updates
+		return LiveUpdateMsg{Gen: gen, Update: update, Closed: !ok}
+	}
+}
+
+// handleLiveUpdatesOpened starts reading a stream, or retries later when
+// it failed to open
+func (m Model) handleLiveUpdatesOpened(msg LiveUpdatesOpenedMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.live.gen {
+		if msg.Cancel != nil {
+			msg.Cancel()
+		}
+		return m, nil
+	}
+	if msg.Err != nil {
+		return m, m.scheduleLiveRetry()
+	}
+	m.live.updates, m.live.cancel = msg.Updates, msg.Cancel
+	return m, nextLiveUpdateCmd(msg.Updates, msg.Gen)
+}
+
+// handleLiveUpdate (re)starts a library's settle timer, or schedules a
+// reconnect when the stream ended
+func (m Model) handleLiveUpdate(msg LiveUpdateMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.live.gen {
+		return m, nil
+	}
+	if msg.Closed {
+		m.live.updates, m.live.cancel = nil, nil
+		return m, m.scheduleLiveRetry()
+	}
+	cmds := []tea.Cmd{nextLiveUpdateCmd(m.live.updates, msg.Gen)}
+	if lib := m.findLibrary(msg.Update.LibraryID); lib != nil {
+		cmds = append(cmds, m.settleLive(lib.ID))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// settleLive restarts a library's settle timer
+func (m *Model) settleLive(libID string) tea.Cmd {
+	if m.live.pending == nil {
+		m.live.pending = make(map[string]int)
+	}
+	m.live.pending[libID]++
+	count := m.live.pending[libID]
+	return tea.Tick(liveSettle, func(time.Time) tea.Msg {
+		return LiveSettledMsg{LibraryID: libID, Count: count}
+	})
+}
+
+// handleLiveSettled syncs a library whose changes have settled. One still
+// syncing waits another round, so the changes that came in meanwhile are
+// not lost.
+func (m Model) handleLiveSettled(msg LiveSettledMsg) (tea.Model, tea.Cmd) {
+	if msg.Count != m.live.pending[msg.LibraryID] {
+		return m, nil // A later change restarted the timer
+	}
+	lib := m.findLibrary(msg.LibraryID)
+	if lib == nil || m.quiet || m.health.status == HealthOffline {
+		delete(m.live.pending, msg.LibraryID)
+		return m, nil
+	}
+	if m.syncPending(lib.ID) {
+		return m, m.settleLive(lib.ID)
+	}
+	delete(m.live.pending, msg.LibraryID)
+	cmd := m.queueSync(*lib, false)
+	m.updateLibraryStates()
+	return m, cmd
+}
+
+// scheduleLiveRetry queues reopening the stream, superseding the old one
+func (m *Model) scheduleLiveRetry() tea.Cmd {
+	m.live.gen++
+	gen := m.live.gen
+	return tea.Tick(liveRetry, func(time.Time) tea.Msg {
+		return LiveRetryMsg{Gen: gen}
+	})
+}
+
+// handleLiveRetry reopens the stream unless the server is known to be
+// offline, in which case it waits another round
+func (m Model) handleLiveRetry(msg LiveRetryMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.live.gen {
+		return m, nil
+	}
+	if m.health.status == HealthOffline {
+		return m, m.scheduleLiveRetry()
+	}
+	return m, WatchUpdatesCmd(m.LibraryService, m.live.gen)
+}
+
+// stopLiveUpdates closes the open stream
+func (m *Model) stopLiveUpdates() {
+	if m.live.cancel != nil {
+		m.live.cancel()
+	}
+	m.live.updates, m.live.cancel = nil, nil
+	m.live.gen++
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+func TestLiveUpdates(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	libs := []domain.Library{{ID: "1", Name: "Movies", Type: "movie"}}
+	m := Model{ColumnStack: NewColumnStack(), LibraryStates: map[string]components.LibrarySyncState{}, jobs: NewJobs(),
+		Store: st, LibraryService: library.NewService(nil, st, nil), Libraries: libs}
+	m.resetSyncQueue()
+
+	updates := make(chan domain.LiveUpdate, 2)
+	updated, _ := m.handleLiveUpdatesOpened(LiveUpdatesOpenedMsg{Gen: m.live.gen, Updates: updates, Cancel: func() {}})
+	m = updated.(Model)
+
+	// A burst of changes settles once, on the latest
+	updated, _ = m.handleLiveUpdate(LiveUpdateMsg{Gen: m.live.gen, Update: domain.LiveUpdate{LibraryID: "1"}})
+	m = updated.(Model)
+	updated, _ = m.handleLiveUpdate(LiveUpdateMsg{Gen: m.live.gen, Update: domain.LiveUpdate{LibraryID: "1"}})
+	m = updated.(Model)
+	if _, cmd := m.handleLiveSettled(LiveSettledMsg{LibraryID: "1", Count: 1}); cmd != nil {
+		t.Fatal("a change superseded by a later one should not sync")
+	}
+	updated, cmd := m.handleLiveSettled(LiveSettledMsg{LibraryID: "1", Count: 2})
+	if m = updated.(Model); cmd == nil || !m.syncPending("1") {
+		t.Fatal("settled changes should sync the library")
+	}
+
+	gen := m.live.gen
+	updated, cmd = m.handleLiveUpdate(LiveUpdateMsg{Gen: gen, Closed: true})
+	if m = updated.(Model); cmd == nil || m.live.gen == gen || m.live.cancel != nil {
+		t.Fatal("a dropped stream should schedule a reconnect")
+	}
+	if _, cmd := m.handleLiveUpdate(LiveUpdateMsg{Gen: gen, Update: domain.LiveUpdate{LibraryID: "1"}}); cmd != nil {
+		t.Error("updates from a closed stream should be dropped")
+	}
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// maxPINLength bounds the PIN entry buffer
+const maxPINLength = 32
+
+// SetLock enables the inactivity lock: after timeout without a key press
+// the session locks until the PIN behind pinHash (config.HashPIN) is
+// entered. Zero timeout or empty pinHash leaves the lock off.
+func (m *Model) SetLock(timeout time.Duration, pinHash string) {
+	if timeout <= 0 || pinHash == "" {
+		return
+	}
+	m.lockTimeout = timeout
+	m.lockPINHash = pinHash
+	m.lastActivity = time.Now()
+}
+
+// checkIdleLock locks the session once it has been idle past the timeout
+func (m *Model) checkIdleLock(now time.Time) {
+	if m.lockTimeout == 0 || m.State == StateLocked {
+		return
+	}
+	if now.Sub(m.lastActivity) >= m.lockTimeout {
+		m.lockedFrom = m.State
+		m.State = StateLocked
+		m.pinEntry = ""
+		m.pinRejected = false
+	}
+}
+
+// handleLockedInput collects the PIN. Every key is consumed: nothing behind
+// the lock screen may react until the session is unlocked.
+func (m Model) handleLockedInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		if subtle.ConstantTimeCompare([]byte(config.RehashPIN(m.lockPINHash, m.pinEntry)), []byte(m.lockPINHash)) == 1 {
+			m.State = m.lockedFrom
+			m.lastActivity = time.Now()
+		} else {
+			m.pinRejected = true
+		}
+		m.pinEntry = ""
+	case tea.KeyBackspace:
+		if n := len(m.pinEntry); n > 0 {
+			m.pinEntry = m.pinEntry[:n-1]
+		}
+	case tea.KeyEsc:
+		m.pinEntry = ""
+	case tea.KeyRunes:
+		if len(m.pinEntry)+len(string(msg.Runes)) <= maxPINLength {
+			m.pinEntry += string(msg.Runes)
+		}
+		m.pinRejected = false
+	}
+	return m, nil
+}
+
+// renderLockScreen renders the PIN prompt. Browsing content stays hidden
+// while locked.
+func (m Model) renderLockScreen() string {
+	var b strings.Builder
+	b.WriteString(styles.ModalTitleStyle.Render("Kino is locked"))
+	b.WriteString("\n\n")
+	b.WriteString("  PIN: ")
+	b.WriteString(styles.AccentStyle.Render(strings.Repeat("•", len(m.pinEntry))))
+	b.WriteString("\n\n")
+	if m.pinRejected {
+		b.WriteString(styles.ErrorStyle.Render("  Wrong PIN"))
+	} else {
+		b.WriteString(styles.DimStyle.Render("  Enter PIN and press Enter"))
+	}
+
+	return lipgloss.Place(m.Width, m.Height,
+		lipgloss.Center, lipgloss.Center,
+		styles.ModalStyle.Render(b.String()))
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+)
+
+// An idle session locks; only the right PIN unlocks it, and keys typed while
+// locked never reach the browsing handlers
+func TestInactivityLock(t *testing.T) {
+	hash, err := config.HashPIN("4711")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := Model{ColumnStack: NewColumnStack()}
+	m.SetLock(time.Minute, hash)
+
+	m.checkIdleLock(m.lastActivity.Add(30 * time.Second))
+	if m.State == StateLocked {
+		t.Fatal("locked before the timeout")
+	}
+	m.checkIdleLock(m.lastActivity.Add(time.Minute))
+	if m.State != StateLocked {
+		t.Fatal("not locked after the timeout")
+	}
+
+	typePIN := func(m Model, pin string) Model {
+		for _, r := range pin {
+			updated, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			m = updated.(Model)
+		}
+		updated, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+		return updated.(Model)
+	}
+
+	m = typePIN(m, "?") // the help key must not open help while locked
+	if m.State != StateLocked || !m.pinRejected {
+		t.Fatalf("wrong PIN: state %v, rejected %v", m.State, m.pinRejected)
+	}
+	m = typePIN(m, "4711")
+	if m.State != StateBrowsing {
+		t.Fatalf("right PIN left state %v", m.State)
+	}
+}
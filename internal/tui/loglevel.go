@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/log"
+)
+
+// LogLevelSavedMsg reports the result of persisting the log level
+type LogLevelSavedMsg struct {
+	Err error
+}
+
+// SaveLogLevelCmd persists the log level to the config file
+func SaveLogLevelCmd(level string) tea.Cmd {
+	return func() tea.Msg {
+		return LogLevelSavedMsg{Err: config.SaveLogLevel(level)}
+	}
+}
+
+// commandLogLevel changes the log level at once and for later runs:
+// ":loglevel debug". Without a level it shows the current one.
+func (m Model) commandLogLevel(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		return m, m.notify(NoticeInfo, i18n.T("status.log_level", log.Level()))
+	}
+	if len(args) > 1 || !log.SetLevel(args[0]) {
+		return m, m.notifyHint(NoticeError, i18n.T("status.log_level_unknown", strings.Join(args, " ")),
+			i18n.T("status.log_level_hint"))
+	}
+	level := log.Level().String()
+	slog.Info("log level changed", "level", level)
+	return m, tea.Batch(m.notify(NoticeInfo, i18n.T("status.log_level", level)), SaveLogLevelCmd(level))
+}
+
+// handleLogLevelSaved reports a log level that could not be saved; it
+// still applies until kino exits
+func (m Model) handleLogLevelSaved(msg LogLevelSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.notify(NoticeError, i18n.T("status.log_level_save_failed", msg.Err))
+	}
+	return m, nil
+}
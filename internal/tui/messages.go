@@ -2,7 +2,9 @@ package tui
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/arr"
 	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/search"
 )
 
 // Message types for the TUI
@@ -11,6 +13,15 @@ import (
 type ErrMsg struct {
 	Err     error
 	Context string
+	// ContentID is the column content the failed load was for (library,
+	// show, season, or playlist ID). Empty for errors not tied to a load.
+	ContentID string
+	// What names the failed content for the column's inline error
+	What string
+	// Retry reruns the failed command; set for auth failures that signing
+	// in again can recover and for failures while the server was offline
+	// (see retryable)
+	Retry tea.Cmd
 }
 
 // Error implements the error interface
@@ -59,7 +70,21 @@ type EpisodesLoadedMsg struct {
 
 // PlaybackStartedMsg signals that playback has started (player launched)
 type PlaybackStartedMsg struct {
-	Item domain.MediaItem
+	Item  domain.MediaItem
+	Ended <-chan bool // Reports whether it played to the end; nil when unobservable
+}
+
+// ItemRatedMsg signals that the user's rating of an item was saved
+type ItemRatedMsg struct {
+	ItemID string
+	Title  string
+	Rating float64 // 0 = cleared
+}
+
+// ItemDeletedMsg signals that a movie, show or episode was deleted from
+// the server
+type ItemDeletedMsg struct {
+	Item domain.ListItem
 }
 
 // MarkWatchedMsg signals a request to mark an item as watched
@@ -68,6 +93,21 @@ type MarkWatchedMsg struct {
 	Title  string
 }
 
+// BatchWatchStateMsg signals that a batch of items was marked (un)watched.
+// ItemIDs holds only the items the server accepted.
+type BatchWatchStateMsg struct {
+	ItemIDs []string
+	Played  bool
+	Failed  int
+	Err     error // last failure, if any
+	JobID   int
+}
+
+// QueueStartedMsg signals that a multi-item playback queue was launched
+type QueueStartedMsg struct {
+	Count int
+}
+
 // MarkUnwatchedMsg signals a request to mark an item as unwatched
 type MarkUnwatchedMsg struct {
 	ItemID string
@@ -82,10 +122,13 @@ type LibrarySyncProgressMsg struct {
 	LibraryID   string
 	LibraryType string
 	Generation  int // Sync generation; stale generations are dropped
+	JobID       int // Entry in the jobs registry
 	Loaded      int
 	Total       int
 	Done        bool
 	FromCache   bool
+	Unwatched   int      // Unwatched count, set on the Done message of a library sync
+	NewIDs      []string // Items the sync added to the cached listing, on the Done message
 	Error       error
 	NextCmd     tea.Cmd // Continuation command for streaming
 }
@@ -100,6 +143,66 @@ type LogoutCompleteMsg struct {
 	Error error
 }
 
+// LiveTVAvailableMsg reports the result of the Live TV capability check
+type LiveTVAvailableMsg struct {
+	Available bool
+}
+
+// ChannelsLoadedMsg signals that Live TV channels have been loaded
+type ChannelsLoadedMsg struct {
+	Channels []*domain.MediaItem
+}
+
+// CalendarLoadedMsg signals that the calendar's episodes have been loaded
+type CalendarLoadedMsg struct {
+	Episodes []*domain.MediaItem
+}
+
+// ContinueWatchingLoadedMsg signals that Continue Watching has been loaded
+type ContinueWatchingLoadedMsg struct {
+	Items []*domain.MediaItem
+}
+
+// ContinueDismissedMsg signals that an item was removed from Continue
+// Watching
+type ContinueDismissedMsg struct {
+	Item domain.MediaItem
+}
+
+// WatchlistLoadedMsg signals that the watchlist has been loaded
+type WatchlistLoadedMsg struct {
+	Items []*domain.WatchlistItem
+}
+
+// WatchlistChangedMsg signals that a title was added to or removed from the
+// watchlist
+type WatchlistChangedMsg struct {
+	Item  domain.WatchlistItem
+	Added bool
+}
+
+// LibrariesStaleMsg reports which cache-fresh libraries failed the item
+// count check at startup and need a full sync
+type LibrariesStaleMsg struct {
+	Libraries  []domain.Library
+	Generation int
+}
+
+// ArrLookupMsg carries Sonarr/Radarr lookup results for a search query.
+// Errors stay on this message: an *arr auth failure is not a media server
+// auth failure.
+type ArrLookupMsg struct {
+	Query   string
+	Results []arr.Result
+	Err     error
+}
+
+// ArrAddedMsg signals that a title was added to Sonarr/Radarr
+type ArrAddedMsg struct {
+	Result arr.Result
+	Err    error
+}
+
 // PlaylistsLoadedMsg signals that playlists have been loaded
 type PlaylistsLoadedMsg struct {
 	Playlists []*domain.Playlist
@@ -114,6 +217,7 @@ type PlaylistItemsLoadedMsg struct {
 // PlaylistUpdatedMsg signals that a playlist was updated (item added/removed)
 type PlaylistUpdatedMsg struct {
 	PlaylistID string
+	JobID      int // Batch edit this change belongs to; 0 for a single change
 	Error      error
 }
 
@@ -129,9 +233,45 @@ type PlaylistDeletedMsg struct {
 	Error      error
 }
 
+// PlaylistEditedMsg signals that a playlist's title/description was saved
+type PlaylistEditedMsg struct {
+	PlaylistID string
+	Title      string
+	Error      error
+}
+
+// SearchDebounceMsg fires once typing in global search has paused
+type SearchDebounceMsg struct {
+	Gen int
+}
+
+// SearchResultsMsg carries global search results so far. Until Done, the
+// handler runs Matcher's next chunk.
+type SearchResultsMsg struct {
+	Gen     int
+	Results []search.FilterResult
+	First   bool // First chunk of a new query
+	Done    bool
+	Matcher *search.Matcher
+}
+
+// EpisodeQueryMsg carries the episode an episode-code query names
+type EpisodeQueryMsg struct {
+	Gen    int
+	Result search.FilterResult
+}
+
+// PlaylistExportedMsg reports a playlist written to a file
+type PlaylistExportedMsg struct {
+	Path  string
+	Count int
+	Error error
+}
+
 // PlaylistModalDataMsg contains data for the playlist modal
 type PlaylistModalDataMsg struct {
 	Playlists  []*domain.Playlist
 	Membership map[string]bool
 	Item       *domain.MediaItem
+	Items      []*domain.MediaItem // set for batch (multi-select) mode
 }
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// doubleClickWindow is how close two clicks on the same row must be to
+// count as a double-click
+const doubleClickWindow = 400 * time.Millisecond
+
+// lastClick remembers the previous left click for double-click detection
+type lastClick struct {
+	at     time.Time
+	column int // Stack index of the clicked column
+	pos    int // List position of the clicked item
+}
+
+// columnSpan is the horizontal extent of one visible column on screen.
+// column is its stack index (the stack's length for the forward column),
+// or -1 for the inspector.
+type columnSpan struct {
+	column int
+	left   int
+	width  int
+}
+
+// visibleColumns maps the current layout to screen columns, left to right.
+// When split, only the focused pane's columns are mapped.
+func (m Model) visibleColumns() []columnSpan {
+	stackLen := len(m.displayColumns())
+	if stackLen == 0 {
+		return nil
+	}
+	x, width := 0, m.Width
+	if m.split != nil {
+		x, width = m.focusedPaneArea()
+	}
+	layout := m.calculateColumnLayout(width)
+	topIdx := stackLen - 1
+
+	var spans []columnSpan
+	add := func(column, width int) {
+		spans = append(spans, columnSpan{column: column, left: x, width: width})
+		x += width
+	}
+	if layout.grandparentWidth > 0 {
+		add(topIdx-2, layout.grandparentWidth)
+	}
+	if layout.parentWidth > 0 {
+		add(topIdx-1, layout.parentWidth)
+	}
+	add(topIdx, layout.activeWidth)
+	if layout.inspectorWidth > 0 {
+		add(-1, layout.inspectorWidth)
+	}
+	return spans
+}
+
+// columnAt returns the screen column under x
+func (m Model) columnAt(x int) (columnSpan, bool) {
+	for _, span := range m.visibleColumns() {
+		if x >= span.left && x < span.left+span.width {
+			return span, true
+		}
+	}
+	return columnSpan{}, false
+}
+
+// handleMouseMsg handles clicks and the wheel in the column view. Modals
+// and full-screen states stay keyboard-only.
+func (m Model) handleMouseMsg(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.State != StateBrowsing || m.hasOverlay() {
+		return m, nil
+	}
+	if msg.Y >= m.Height-ChromeHeight {
+		return m, nil // Footer
+	}
+	if m.split != nil {
+		// A click or scroll in the other pane focuses it first
+		if left, width := m.focusedPaneArea(); msg.X < left || msg.X >= left+width {
+			m.switchPane()
+		}
+	}
+	span, ok := m.columnAt(msg.X)
+	if !ok {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp, tea.MouseButtonWheelDown:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		delta := 1
+		if msg.Button == tea.MouseButtonWheelUp {
+			delta = -1
+		}
+		m.lastActivity = time.Now()
+		// The wheel over a parent column moves the focused column: scrolling
+		// a parent would change its selection out from under its child
+		if span.column < 0 {
+			m.Inspector.Scroll(delta)
+			return m, nil
+		}
+		if top := m.ColumnStack.Top(); top != nil {
+			top.ScrollBy(delta)
+			m.updateInspector()
+		}
+		return m, nil
+
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress || span.column < 0 {
+			return m, nil
+		}
+		m.lastActivity = time.Now()
+		return m.handleClick(span.column, msg.Y)
+	}
+	return m, nil
+}
+
+// handleClick selects the item under the pointer. Clicking a parent column
+// focuses it first and clicking the forward column re-enters it; a second
+// click on the same item drills in or plays.
+func (m Model) handleClick(column, y int) (tea.Model, tea.Cmd) {
+	col := m.ColumnStack.Get(column)
+	if column == m.ColumnStack.Len() {
+		col = m.ColumnStack.Forward()
+	}
+	if col == nil {
+		return m, nil
+	}
+	pos := col.ItemAtRow(y)
+
+	now := time.Now()
+	double := pos >= 0 && m.click.column == column && m.click.pos == pos &&
+		now.Sub(m.click.at) <= doubleClickWindow
+	m.click = lastClick{at: now, column: column, pos: pos}
+
+	// Focus the clicked column, the same as pressing h repeatedly (or l
+	// for the forward column)
+	for m.ColumnStack.Len()-1 > column {
+		next, _ := m.handleFocusParent()
+		m = next.(Model)
+	}
+	if column == m.ColumnStack.Len() {
+		m.clearNavPlan()
+		m.advanceToForward()
+	}
+	if pos < 0 {
+		return m, nil
+	}
+
+	top := m.ColumnStack.Top()
+	top.SetSelectedIndex(pos)
+	m.updateInspector()
+	if double {
+		m.click = lastClick{}
+		return m.handleEnter()
+	}
+	return m, nil
+}
+
+// hasOverlay reports whether a modal or panel is covering the columns
+func (m Model) hasOverlay() bool {
+	if m.home != nil || m.detail != nil || m.jobsPanelOpen || m.activitiesOpen || m.menu != nil || m.debugOpen || m.GlobalSearch.IsVisible() || m.SortModal.IsVisible() ||
+		m.ResumeModal.IsVisible() || m.VersionModal.IsVisible() || m.RatingModal.IsVisible() || m.PlaylistModal.IsVisible() ||
+		m.InputModal.IsVisible() || m.PlaylistEditModal.IsVisible() || m.LibraryModal.IsVisible() {
+		return true
+	}
+	top := m.ColumnStack.Top()
+	return top != nil && top.IsFilterTyping()
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// Clicking a parent column focuses it and selects the clicked row; the
+// wheel moves the focused column
+func TestMouseClickFocusesParent(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := st.SaveMovies("b", []*domain.MediaItem{{ID: "m3", Title: "Three"}}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{ColumnStack: NewColumnStack(), Store: st, State: StateBrowsing, Width: 100, Height: 20}
+	libs := components.NewLibraryColumn([]domain.Library{{ID: "a", Name: "A", Type: "movie"}, {ID: "b", Name: "B", Type: "movie"}})
+	m.ColumnStack.Push(libs, 0)
+	movies := components.NewListColumn(components.ColumnTypeMovies, "A")
+	movies.SetContentID("a")
+	movies.SetItems([]*domain.MediaItem{{ID: "m1", Title: "One"}, {ID: "m2", Title: "Two"}})
+	m.ColumnStack.Push(movies, 0)
+	m.updateLayout()
+
+	updated, _ := m.Update(tea.MouseMsg{X: 50, Y: 3, Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	m = updated.(Model)
+	if got := movies.SelectedIndex(); got != 1 {
+		t.Fatalf("wheel cursor = %d, want 1", got)
+	}
+
+	updated, _ = m.Update(tea.MouseMsg{X: 2, Y: 4, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	m = updated.(Model)
+	if m.ColumnStack.Len() != 1 {
+		t.Fatalf("stack has %d columns, want focus on the libraries", m.ColumnStack.Len())
+	}
+	if lib := m.ColumnStack.Top().SelectedLibrary(); lib == nil || lib.ID != "b" {
+		t.Fatalf("selected library = %v, want b", lib)
+	}
+	if fwd := m.ColumnStack.Forward(); fwd == nil || fwd.ContentID() != "b" {
+		t.Fatalf("forward column = %v, want library b's movies", fwd)
+	}
+}
@@ -2,9 +2,12 @@ package tui
 
 import (
 	"fmt"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/library"
 	"github.com/mmcdole/kino/internal/search"
 	"github.com/mmcdole/kino/internal/tui/components"
 )
@@ -24,6 +27,11 @@ const (
 // NavTarget represents a single navigation step
 type NavTarget struct {
 	ID string // item ID to select (empty = no-op, just land)
+
+	// Sort is applied to the column before selecting (SortDefault = leave
+	// the column's own order)
+	Sort    components.SortField
+	SortDir components.SortDirection
 }
 
 // NavPlan represents a multi-step navigation flow
@@ -68,12 +76,36 @@ type columnLoadSpec struct {
 	loadCmd   tea.Cmd
 }
 
+// prefetchSelection warms the cache for the selected show so drilling in is
+// instant. Selecting anything else stops a prefetch in flight.
+func (m *Model) prefetchSelection() {
+	if m.LibraryService == nil {
+		return
+	}
+	if top := m.ColumnStack.Top(); top != nil {
+		if show, ok := top.SelectedItem().(*domain.Show); ok {
+			m.LibraryService.PrefetchShow(m.currentLibID, show.ID)
+			return
+		}
+	}
+	m.LibraryService.CancelPrefetch()
+}
+
+// newEpisodeWindow is how long a show or season keeps its NEW badge after
+// an episode is added
+func (m *Model) newEpisodeWindow() time.Duration {
+	return time.Duration(m.UIConfig.NewEpisodeDays) * 24 * time.Hour
+}
+
 // pushAndLoadColumn pushes a column and either populates from cache or triggers async load.
 // This consolidates the repeated cache-check-and-load pattern used throughout navigation.
 func (m *Model) pushAndLoadColumn(spec columnLoadSpec, cursor int) *drillResult {
 	col := components.NewListColumn(spec.colType, spec.name)
 	col.SetShowWatchStatus(m.UIConfig.ShowWatchStatus)
+	col.SetNewWindow(m.newEpisodeWindow())
 	col.SetContentID(spec.awaitID)
+	m.applySortPreference(col)
+	m.restoreView(col)
 	m.ColumnStack.Push(col, cursor)
 	m.updateLayout()
 
@@ -81,9 +113,13 @@ func (m *Model) pushAndLoadColumn(spec columnLoadSpec, cursor int) *drillResult
 		col.SetItems(cached)
 		m.updateInspector()
 		if m.navPlan != nil {
+			// Served from cache: this column is the one the plan waits
+			// for, so advance now. AwaitNone tells the caller the plan's
+			// await state is already current.
+			m.navPlan.AwaitKind = spec.awaitKind
+			m.navPlan.AwaitID = spec.awaitID
 			return &drillResult{
-				AwaitKind: spec.awaitKind,
-				AwaitID:   spec.awaitID,
+				AwaitKind: AwaitNone,
 				Cmd:       m.advanceNavPlanAfterLoad(spec.awaitKind, spec.awaitID),
 			}
 		}
@@ -98,6 +134,18 @@ func (m *Model) pushAndLoadColumn(spec columnLoadSpec, cursor int) *drillResult
 	}
 }
 
+// restoreView gives a column rebuilt by drilling in the filter and
+// selection its content had when the user last left it. Navigation plans
+// pick their own selection, so they start clean.
+func (m *Model) restoreView(col *components.ListColumn) {
+	if m.navPlan != nil {
+		return
+	}
+	if view, ok := m.ColumnStack.SavedView(col.ContentID()); ok {
+		col.RestoreViewState(view)
+	}
+}
+
 // navigateToMixedLibraryItem navigates to an item in a mixed library using NavPlan.
 // This consolidates the 3 near-identical mixed library navigation blocks.
 func (m *Model) navigateToMixedLibraryItem(lib *domain.Library, targets []NavTarget) tea.Cmd {
@@ -110,7 +158,9 @@ func (m *Model) navigateToMixedLibraryItem(lib *domain.Library, targets []NavTar
 
 	mixedCol := components.NewListColumn(components.ColumnTypeMixed, lib.Name)
 	mixedCol.SetShowWatchStatus(m.UIConfig.ShowWatchStatus)
+	mixedCol.SetNewWindow(m.newEpisodeWindow())
 	mixedCol.SetContentID(lib.ID)
+	m.applySortPreference(mixedCol)
 
 	if cached, ok := m.Store.GetMixedContent(lib.ID); ok {
 		mixedCol.SetItems(cached)
@@ -160,6 +210,13 @@ func (m *Model) buildNavContext(item search.FilterItem) NavigationContext {
 		if show, ok := item.Item.(*domain.Show); ok {
 			ctx.ShowTitle = show.Title
 		}
+	case domain.MediaTypeEpisode:
+		if ep, ok := item.Item.(*domain.MediaItem); ok {
+			ctx.ShowID = ep.ShowID
+			ctx.ShowTitle = ep.ShowTitle
+			ctx.SeasonID = ep.ParentID
+			ctx.EpisodeID = ep.ID
+		}
 	}
 
 	return ctx
@@ -176,6 +233,9 @@ func (m *Model) drillSelected() *drillResult {
 	if top == nil || !top.CanDrillInto() {
 		return nil
 	}
+	if m.advanceToForward() {
+		return &drillResult{AwaitKind: AwaitNone}
+	}
 	item := top.SelectedItem()
 	if item == nil {
 		return nil
@@ -189,6 +249,7 @@ func (m *Model) drillSelected() *drillResult {
 			col := components.NewListColumn(components.ColumnTypePlaylists, "Playlists")
 			col.SetShowWatchStatus(m.UIConfig.ShowWatchStatus)
 			col.SetContentID(playlistsLibraryID)
+			m.restoreView(col)
 			m.ColumnStack.Push(col, cursor)
 			m.updateLayout()
 
@@ -206,14 +267,86 @@ func (m *Model) drillSelected() *drillResult {
 			}
 		}
 
+		// Synthetic "Live TV" entry: channels are always fetched fresh since
+		// the current program is only accurate for a few minutes
+		if v.ID == liveTVLibraryID {
+			col := components.NewListColumn(components.ColumnTypeChannels, "Live TV")
+			col.SetContentID(liveTVLibraryID)
+			m.ColumnStack.Push(col, cursor)
+			m.updateLayout()
+			col.SetLoading(true)
+			return &drillResult{
+				AwaitKind: AwaitNone,
+				Cmd:       LoadChannelsCmd(m.LibraryService),
+			}
+		}
+
+		// Synthetic "Continue Watching" entry: changes with every play, so
+		// always fetched fresh
+		if v.ID == continueLibraryID {
+			col := components.NewListColumn(components.ColumnTypeContinue, "Continue Watching")
+			col.SetContentID(continueLibraryID)
+			m.applySortPreference(col)
+			m.restoreView(col)
+			m.ColumnStack.Push(col, cursor)
+			m.updateLayout()
+			col.SetLoading(true)
+			return &drillResult{
+				AwaitKind: AwaitNone,
+				Cmd:       LoadContinueWatchingCmd(m.LibraryService),
+			}
+		}
+
+		// Synthetic "Calendar" entry: a date-window view, always fetched fresh
+		if v.ID == calendarLibraryID {
+			col := components.NewListColumn(components.ColumnTypeCalendar, "Calendar")
+			col.SetShowWatchStatus(m.UIConfig.ShowWatchStatus)
+			col.SetContentID(calendarLibraryID)
+			m.ColumnStack.Push(col, cursor)
+			m.updateLayout()
+			col.SetLoading(true)
+			return &drillResult{
+				AwaitKind: AwaitNone,
+				Cmd:       LoadCalendarCmd(m.LibraryService),
+			}
+		}
+
+		// Synthetic "Watchlist" entry: lives on plex.tv, always fetched fresh
+		if v.ID == watchlistLibraryID {
+			col := components.NewListColumn(components.ColumnTypeWatchlist, "Watchlist")
+			col.SetContentID(watchlistLibraryID)
+			m.ColumnStack.Push(col, cursor)
+			m.updateLayout()
+			col.SetLoading(true)
+			return &drillResult{
+				AwaitKind: AwaitNone,
+				Cmd:       LoadWatchlistCmd(m.LibraryService),
+			}
+		}
+
 		// Track library context for hierarchical caching
 		m.currentLibID = v.ID
 		m.currentShowID = "" // Reset show context when entering a library
 
 		// Build column spec based on library type
 		var spec columnLoadSpec
-		switch v.Type {
-		case "movie":
+		switch {
+		case v.ID == mergedLibraryID:
+			members := m.mergedMembers()
+			spec = columnLoadSpec{
+				colType:   components.ColumnTypeMovies,
+				name:      v.Name,
+				awaitKind: AwaitMovies,
+				awaitID:   v.ID,
+				getCached: func() interface{} {
+					if c, ok := m.LibraryService.CachedMergedMovies(members); ok {
+						return c
+					}
+					return nil
+				},
+				loadCmd: LoadMergedMoviesCmd(m.LibraryService, members),
+			}
+		case v.Type == "movie":
 			spec = columnLoadSpec{
 				colType:   components.ColumnTypeMovies,
 				name:      v.Name,
@@ -227,7 +360,7 @@ func (m *Model) drillSelected() *drillResult {
 				},
 				loadCmd: LoadMoviesCmd(m.LibraryService, v),
 			}
-		case "show":
+		case v.Type == "show":
 			spec = columnLoadSpec{
 				colType:   components.ColumnTypeShows,
 				name:      v.Name,
@@ -241,7 +374,7 @@ func (m *Model) drillSelected() *drillResult {
 				},
 				loadCmd: LoadShowsCmd(m.LibraryService, v),
 			}
-		case "mixed":
+		case v.Type == "mixed":
 			spec = columnLoadSpec{
 				colType:   components.ColumnTypeMixed,
 				name:      v.Name,
@@ -271,11 +404,20 @@ func (m *Model) drillSelected() *drillResult {
 				loadCmd: LoadMixedLibraryCmd(m.LibraryService, v),
 			}
 		}
-		return m.pushAndLoadColumn(spec, cursor)
+		result := m.pushAndLoadColumn(spec, cursor)
+		if v.ID != mergedLibraryID {
+			result.Cmd = tea.Batch(result.Cmd, m.syncOnOpen(v))
+		}
+		return result
 
 	case *domain.Show:
 		// Track show context for hierarchical caching (episodes need showID)
 		m.currentShowID = v.ID
+		// Columns gathered across libraries (cast & crew) carry shows from
+		// libraries other than the current one
+		if v.LibraryID != "" && v.LibraryID != m.currentLibID && m.findLibrary(v.LibraryID) != nil {
+			m.currentLibID = v.LibraryID
+		}
 
 		libID := m.currentLibID
 		showID := v.ID
@@ -286,7 +428,7 @@ func (m *Model) drillSelected() *drillResult {
 			awaitID:   v.ID,
 			getCached: func() interface{} {
 				if c, ok := m.Store.GetSeasons(libID, showID); ok {
-					return c
+					return m.arrangeShowSeasons(showID, c)
 				}
 				return nil
 			},
@@ -296,7 +438,9 @@ func (m *Model) drillSelected() *drillResult {
 
 	case *domain.Season:
 		title := v.ShowTitle
-		if v.SeasonNum == 0 {
+		if v.Extras {
+			title += " - Extras"
+		} else if v.SeasonNum == 0 {
 			title += " - Specials"
 		} else {
 			title += fmt.Sprintf(" - S%02d", v.SeasonNum)
@@ -324,6 +468,7 @@ func (m *Model) drillSelected() *drillResult {
 		col := components.NewListColumn(components.ColumnTypePlaylistItems, v.Title)
 		col.SetShowWatchStatus(m.UIConfig.ShowWatchStatus)
 		col.SetContentID(v.ID)
+		m.restoreView(col)
 		m.ColumnStack.Push(col, cursor)
 		m.currentPlaylistID = v.ID
 		m.updateLayout()
@@ -354,8 +499,20 @@ func (m Model) drillIntoSelection() (tea.Model, tea.Cmd) {
 	return m, result.Cmd
 }
 
-// handleBack handles navigation back (h/backspace)
+// handleBack handles navigation back (backspace), discarding the column
 func (m Model) handleBack() (tea.Model, tea.Cmd) {
+	return m.leaveTop(false)
+}
+
+// handleFocusParent moves focus to the parent column (h/←), keeping the
+// column left behind visible to its right so l returns to it as it was
+func (m Model) handleFocusParent() (tea.Model, tea.Cmd) {
+	return m.leaveTop(true)
+}
+
+// leaveTop pops the top column and clears the context it set. keep holds
+// on to it as the stack's forward column.
+func (m Model) leaveTop(keep bool) (tea.Model, tea.Cmd) {
 	// Manual navigation cancels any pending search-navigation plan
 	m.clearNavPlan()
 	if !m.ColumnStack.CanGoBack() {
@@ -382,7 +539,12 @@ func (m Model) handleBack() (tea.Model, tea.Cmd) {
 		}
 	}
 
-	_, savedCursor := m.ColumnStack.Pop()
+	var savedCursor int
+	if keep {
+		_, savedCursor = m.ColumnStack.Retreat()
+	} else {
+		_, savedCursor = m.ColumnStack.Pop()
+	}
 
 	// Restore cursor position on the new top
 	if top := m.ColumnStack.Top(); top != nil {
@@ -394,6 +556,82 @@ func (m Model) handleBack() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// selectionID returns the ID a drilled column for item would carry as its
+// content ID
+func selectionID(item interface{}) string {
+	switch v := item.(type) {
+	case domain.Library:
+		return v.ID
+	case domain.ListItem:
+		return v.GetID()
+	default:
+		return ""
+	}
+}
+
+// advanceToForward re-focuses the forward column when it belongs to the
+// current selection, restoring the context drilling in would have set.
+// Returns false when the selection needs a fresh drill.
+func (m *Model) advanceToForward() bool {
+	top := m.ColumnStack.Top()
+	fwd := m.ColumnStack.Forward()
+	if top == nil || fwd == nil || m.navPlan != nil {
+		return false
+	}
+	item := top.SelectedItem()
+	if selectionID(item) != fwd.ContentID() {
+		return false
+	}
+
+	switch v := item.(type) {
+	case domain.Library:
+		if !isSyntheticLibrary(v.ID) {
+			m.currentLibID = v.ID
+			m.currentShowID = ""
+		}
+	case *domain.Show:
+		m.currentShowID = v.ID
+	case *domain.Playlist:
+		m.currentPlaylistID = v.ID
+	}
+
+	m.ColumnStack.Advance(top.SelectedIndex())
+	m.updateLayout()
+	m.updateInspector()
+	return true
+}
+
+// syncForward keeps the forward column on the selection's children: when
+// the selection moves it is rebuilt in place (from cache or a load that
+// lands on it), and dropped for items without children
+func (m *Model) syncForward() tea.Cmd {
+	top := m.ColumnStack.Top()
+	fwd := m.ColumnStack.Forward()
+	if top == nil || fwd == nil || m.navPlan != nil {
+		return nil
+	}
+	if selectionID(top.SelectedItem()) == fwd.ContentID() {
+		return nil
+	}
+	if !top.CanDrillInto() {
+		m.ColumnStack.ClearForward()
+		m.updateLayout()
+		return nil
+	}
+
+	// Drill as usual, then step straight back out: the drilled column
+	// becomes the forward one and the top keeps focus
+	result := m.drillSelected()
+	if result == nil {
+		m.ColumnStack.ClearForward()
+		m.updateLayout()
+		return nil
+	}
+	next, _ := m.handleFocusParent()
+	*m = next.(Model)
+	return result.Cmd
+}
+
 // advanceNavPlanAfterLoad advances the navigation plan after an async load completes
 func (m *Model) advanceNavPlanAfterLoad(kind NavAwaitKind, id string) tea.Cmd {
 	p := m.navPlan
@@ -418,11 +656,15 @@ func (m *Model) advanceNavPlanAfterLoad(kind NavAwaitKind, id string) tea.Cmd {
 		return nil
 	}
 
+	if target.Sort != components.SortDefault {
+		top.ApplySort(target.Sort, target.SortDir)
+	}
+
 	// Apply ID selection if requested
 	if target.ID != "" {
 		if !top.SetSelectedByID(target.ID) {
 			m.clearNavPlan()
-			return m.notify(NoticeError, "Item not found (library may have changed)")
+			return m.notify(NoticeError, i18n.T("status.item_not_found"))
 		}
 	}
 
@@ -438,11 +680,13 @@ func (m *Model) advanceNavPlanAfterLoad(kind NavAwaitKind, id string) tea.Cmd {
 	result := m.drillSelected()
 	if result == nil {
 		m.clearNavPlan()
-		return m.notify(NoticeError, "Navigation failed")
+		return m.notify(NoticeError, i18n.T("status.navigation_failed"))
 	}
 	// Update navPlan with await info for next load
-	m.navPlan.AwaitKind = result.AwaitKind
-	m.navPlan.AwaitID = result.AwaitID
+	if result.AwaitKind != AwaitNone && m.navPlan != nil {
+		m.navPlan.AwaitKind = result.AwaitKind
+		m.navPlan.AwaitID = result.AwaitID
+	}
 	return result.Cmd
 }
 
@@ -451,23 +695,7 @@ func (m *Model) advanceNavPlanAfterLoad(kind NavAwaitKind, id string) tea.Cmd {
 func (m *Model) navigateToSearchResult(item search.FilterItem) tea.Cmd {
 	navCtx := m.buildNavContext(item)
 
-	// Reset stack to library level first
-	libCol := components.NewLibraryColumn(m.allLibraryEntries())
-	libCol.SetLibraryStates(m.LibraryStates)
-	libCol.SetShowWatchStatus(m.UIConfig.ShowWatchStatus)
-	libCol.SetShowLibraryCounts(m.UIConfig.ShowLibraryCounts)
-	m.Inspector.SetLibraryStates(m.LibraryStates)
-
-	// Find and select the library
-	for i, lib := range m.Libraries {
-		if lib.ID == navCtx.LibraryID {
-			libCol.SetSelectedIndex(i)
-			break
-		}
-	}
-	m.ColumnStack.Reset(libCol)
-
-	lib := m.findLibrary(navCtx.LibraryID)
+	lib := m.resetToLibrary(navCtx.LibraryID)
 	if lib == nil {
 		return nil
 	}
@@ -502,6 +730,55 @@ func (m *Model) navigateToSearchResult(item search.FilterItem) tea.Cmd {
 	return m.navigateToTypedLibraryItem(lib, navCtx, targets, item.Type)
 }
 
+// resetToLibrary resets the stack to the library column with the given
+// library selected. Returns nil if the library is unknown.
+func (m *Model) resetToLibrary(libID string) *domain.Library {
+	libCol := components.NewLibraryColumn(m.allLibraryEntries())
+	libCol.SetLibraryStates(m.LibraryStates)
+	libCol.SetShowWatchStatus(m.UIConfig.ShowWatchStatus)
+	libCol.SetShowLibraryCounts(m.UIConfig.ShowLibraryCounts)
+	m.Inspector.SetLibraryStates(m.LibraryStates)
+
+	// Find and select the library
+	for i, lib := range m.Libraries {
+		if lib.ID == libID {
+			libCol.SetSelectedIndex(i)
+			break
+		}
+	}
+	m.ColumnStack.Reset(libCol)
+
+	return m.findLibrary(libID)
+}
+
+// navigateToResolved navigates to a deep-link target (kino --goto): a movie,
+// a show (landing on its seasons), a season, or an episode
+func (m *Model) navigateToResolved(res *library.Resolved) tea.Cmd {
+	lib := m.resetToLibrary(res.Library.ID)
+	if lib == nil {
+		return m.notify(NoticeError, i18n.T("status.library_not_found", res.Library.Name))
+	}
+
+	var targets []NavTarget
+	mediaType := domain.MediaTypeShow
+	switch {
+	case res.Movie != nil:
+		targets = []NavTarget{{ID: res.Movie.ID}}
+		mediaType = domain.MediaTypeMovie
+	case res.Episode != nil:
+		targets = []NavTarget{{ID: res.Show.ID}, {ID: res.Season.ID}, {ID: res.Episode.ID}}
+	case res.Season != nil:
+		targets = []NavTarget{{ID: res.Show.ID}, {ID: res.Season.ID}}
+	default:
+		targets = []NavTarget{{ID: res.Show.ID}, {}}
+	}
+
+	if lib.Type == "mixed" {
+		return m.navigateToMixedLibraryItem(lib, targets)
+	}
+	return m.navigateToTypedLibraryItem(lib, NavigationContext{LibraryID: lib.ID, LibraryName: lib.Name}, targets, mediaType)
+}
+
 // navigateToTypedLibraryItem navigates to an item in a typed (movie/show) library.
 func (m *Model) navigateToTypedLibraryItem(lib *domain.Library, navCtx NavigationContext, targets []NavTarget, mediaType domain.MediaType) tea.Cmd {
 	// Track library context for hierarchical caching
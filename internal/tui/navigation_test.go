@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// h keeps the child column beside its parent: moving the parent's cursor
+// reloads it in place, and l returns to it with its cursor intact
+func TestFocusParentKeepsChildColumn(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shows := []*domain.Show{{ID: "show1", Title: "Alpha"}, {ID: "show2", Title: "Beta"}}
+	if err := st.SaveShows("tv", shows, 1); err != nil {
+		t.Fatal(err)
+	}
+	for _, show := range shows {
+		seasons := []*domain.Season{{ID: show.ID + "-s1", ShowID: show.ID, SeasonNum: 1}, {ID: show.ID + "-s2", ShowID: show.ID, SeasonNum: 2}}
+		if err := st.SaveSeasons("tv", show.ID, seasons); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := Model{ColumnStack: NewColumnStack(), Store: st, Libraries: []domain.Library{{ID: "tv", Name: "TV", Type: "show"}}}
+	m.restoreSession(&config.Session{LibraryID: "tv", Columns: []config.ColumnSession{{SelectedID: "show1"}, {SelectedID: "show1-s2"}}})
+	key := func(s string) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+		m = updated.(Model)
+	}
+
+	key("h")
+	if m.ColumnStack.Len() != 2 || m.ColumnStack.Forward() == nil || m.ColumnStack.Forward().ContentID() != "show1" {
+		t.Fatal("h did not keep the seasons column as the forward column")
+	}
+	key("l")
+	if m.ColumnStack.Len() != 3 || m.ColumnStack.Top().SelectedIndex() != 1 {
+		t.Fatal("l did not return to the kept seasons column with its cursor")
+	}
+
+	key("h")
+	key("j")
+	if fwd := m.ColumnStack.Forward(); fwd == nil || fwd.ContentID() != "show2" || fwd.ItemCount() != 2 {
+		t.Fatalf("forward column = %v, want show2's seasons", fwd)
+	}
+	if m.ColumnStack.Len() != 2 || !m.ColumnStack.Top().IsFocused() {
+		t.Fatal("reloading the forward column moved focus")
+	}
+}
+
+// Leaving a filtered column and drilling back into the same content brings
+// back the filter and the selected item, though the column is rebuilt
+func TestFilterSurvivesLeavingColumn(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	movies := []*domain.MediaItem{
+		{ID: "m1", Title: "Alien", Type: domain.MediaTypeMovie},
+		{ID: "m2", Title: "Aliens", Type: domain.MediaTypeMovie},
+		{ID: "m3", Title: "Heat", Type: domain.MediaTypeMovie},
+	}
+	if err := st.SaveMovies("lib", movies, 1); err != nil {
+		t.Fatal(err)
+	}
+	m := Model{
+		ColumnStack:   NewColumnStack(),
+		Store:         st,
+		Libraries:     []domain.Library{{ID: "lib", Name: "Movies", Type: "movie"}},
+		LibraryStates: map[string]components.LibrarySyncState{},
+		jobs:          NewJobs(),
+	}
+	m.ColumnStack.Reset(components.NewLibraryColumn(m.Libraries))
+	press := func(keys ...tea.KeyMsg) {
+		t.Helper()
+		for _, k := range keys {
+			updated, _ := m.Update(k)
+			m = updated.(Model)
+		}
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	press(tea.KeyMsg{Type: tea.KeyEnter}, runes("/"), runes("alien"), tea.KeyMsg{Type: tea.KeyEnter}, runes("j"))
+	want := components.ViewState{FilterQuery: "alien", SelectedID: "m2"}
+	if got := m.ColumnStack.Top().ViewState(); got != want {
+		t.Fatalf("before leaving: %+v, want %+v", got, want)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyEnter})
+	top := m.ColumnStack.Top()
+	if got := top.ViewState(); got != want || top.ItemCount() != 2 {
+		t.Fatalf("after returning: %+v with %d items, want %+v with 2", got, top.ItemCount(), want)
+	}
+}
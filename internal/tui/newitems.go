@@ -0,0 +1,102 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// newItems are the items added to a library, as found by the most recent
+// sync that found any; Keys.NewItems opens them
+type newItems struct {
+	libID string
+	ids   []string
+}
+
+// noteNewItems remembers what a finished sync found and announces it. A
+// library's first sync finds nothing new (see domain.SyncResult).
+func (m *Model) noteNewItems(libID string, ids []string) tea.Cmd {
+	lib := m.findLibrary(libID)
+	if lib == nil || len(ids) == 0 {
+		return nil
+	}
+	m.newItems = newItems{libID: libID, ids: ids}
+	if len(ids) == 1 {
+		return m.notify(NoticeInfo, i18n.T("status.new_items.one", lib.Name))
+	}
+	return m.notify(NoticeInfo, i18n.T("status.new_items", len(ids), lib.Name))
+}
+
+// handleNewItems opens the latest sync's new items in their library, as a
+// column of just those items, newest first
+func (m Model) handleNewItems() (tea.Model, tea.Cmd) {
+	if m.newItems.libID == "" {
+		return m, m.notify(NoticeInfo, i18n.T("status.no_new_items"))
+	}
+	libID := m.newItems.libID
+	lib := m.findLibrary(libID)
+	if lib == nil {
+		return m, nil
+	}
+	items := cachedNewItems(m.Store, *lib, m.newItems.ids)
+	if items == nil {
+		return m, m.notify(NoticeInfo, i18n.T("status.new_items_gone", lib.Name))
+	}
+
+	m.resetToLibrary(libID)
+	m.currentLibID = libID
+	m.currentShowID = ""
+	colType := components.ColumnTypeMixed
+	if lib.Type == "movie" || lib.Type == "show" {
+		colType = components.ColumnTypeMovies // Settled by SetItems
+	}
+	col := components.NewListColumn(colType, "New in "+lib.Name)
+	col.SetShowWatchStatus(m.UIConfig.ShowWatchStatus)
+	col.SetContentID(libID)
+	col.SetPreferredSort(components.SortDateAdded, components.SortDesc)
+	col.SetItems(items)
+	m.ColumnStack.Push(col, m.ColumnStack.Top().SelectedIndex())
+	m.updateLayout()
+	m.updateInspector()
+	return m, nil
+}
+
+// cachedNewItems returns the cached items of a library with the given IDs,
+// typed as the library's listing is (movies, shows or mixed); nil when none
+// are cached
+func cachedNewItems(store domain.Store, lib domain.Library, ids []string) interface{} {
+	switch lib.Type {
+	case "movie":
+		movies, _ := store.GetMovies(lib.ID)
+		if found := pickByID(movies, ids); len(found) > 0 {
+			return found
+		}
+	case "show":
+		shows, _ := store.GetShows(lib.ID)
+		if found := pickByID(shows, ids); len(found) > 0 {
+			return found
+		}
+	default:
+		items, _ := store.GetMixedContent(lib.ID)
+		if found := pickByID(items, ids); len(found) > 0 {
+			return found
+		}
+	}
+	return nil
+}
+
+// pickByID keeps the items whose ID is in ids
+func pickByID[T domain.ListItem](items []T, ids []string) []T {
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	var found []T
+	for _, item := range items {
+		if want[item.GetID()] {
+			found = append(found, item)
+		}
+	}
+	return found
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// A sync that adds items announces them, and the new-items key opens just
+// those items, newest first
+func TestNewItemsFromSync(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	movies := []*domain.MediaItem{
+		{ID: "m1", Title: "Old", Type: domain.MediaTypeMovie, AddedAt: 100},
+		{ID: "m2", Title: "Newer", Type: domain.MediaTypeMovie, AddedAt: 300},
+		{ID: "m3", Title: "New", Type: domain.MediaTypeMovie, AddedAt: 200},
+	}
+	if err := st.SaveMovies("lib", movies, 1); err != nil {
+		t.Fatal(err)
+	}
+	m := Model{
+		ColumnStack:   NewColumnStack(),
+		Store:         st,
+		Libraries:     []domain.Library{{ID: "lib", Name: "Movies", Type: "movie"}},
+		LibraryStates: map[string]components.LibrarySyncState{},
+		jobs:          NewJobs(),
+	}
+	id, _ := m.jobs.Start(JobSync, "Sync Movies", 0)
+
+	updated, _ := m.Update(LibrarySyncProgressMsg{LibraryID: "lib", JobID: id, Done: true, NewIDs: []string{"m3", "m2"}})
+	m = updated.(Model)
+	if m.notice.Text != "2 new items in Movies — a to view" {
+		t.Fatalf("notice = %q", m.notice.Text)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m = updated.(Model)
+	top := m.ColumnStack.Top()
+	if m.ColumnStack.Len() != 2 || top.Title() != "New in Movies" || top.ItemCount() != 2 {
+		t.Fatalf("stack = %d columns, top %q with %d items", m.ColumnStack.Len(), top.Title(), top.ItemCount())
+	}
+	if got := top.SelectedMediaItem(); got == nil || got.ID != "m2" {
+		t.Fatalf("first item = %+v, want the newest (m2)", got)
+	}
+}
@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/library"
+)
+
+// PlaybackEndedMsg reports that a watched player exited
+type PlaybackEndedMsg struct {
+	Item        domain.MediaItem
+	PlayedToEnd bool
+}
+
+// NextEpisodeMsg carries the episode after one that finished, nil at the
+// end of the show
+type NextEpisodeMsg struct {
+	After domain.MediaItem
+	Next  *domain.MediaItem
+	Err   error
+}
+
+// SetNextEpisode sets what happens when an episode plays to its end
+// (config.NextEpisodeOff, Ask or Auto); empty behaves as off
+func (m *Model) SetNextEpisode(mode string) {
+	m.nextEpisodeMode = mode
+}
+
+// WaitPlaybackCmd blocks until the player exits
+func WaitPlaybackCmd(item domain.MediaItem, ended <-chan bool) tea.Cmd {
+	return func() tea.Msg {
+		return PlaybackEndedMsg{Item: item, PlayedToEnd: <-ended}
+	}
+}
+
+// NextEpisodeCmd looks up the episode after item
+func NextEpisodeCmd(svc *library.Service, libID string, item domain.MediaItem) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		next, err := svc.NextEpisode(ctx, libID, item)
+		return NextEpisodeMsg{After: item, Next: next, Err: err}
+	}
+}
+
+// watchPlayback waits on an observable episode's player when next-episode
+// handling is on
+func (m Model) watchPlayback(msg PlaybackStartedMsg) tea.Cmd {
+	if msg.Ended == nil || msg.Item.Type != domain.MediaTypeEpisode {
+		return nil
+	}
+	if m.nextEpisodeMode != config.NextEpisodeAsk && m.nextEpisodeMode != config.NextEpisodeAuto {
+		return nil
+	}
+	return WaitPlaybackCmd(msg.Item, msg.Ended)
+}
+
+// handlePlaybackEnded looks up the next episode once one plays to its end;
+// quitting the player early means the user is done
+func (m Model) handlePlaybackEnded(msg PlaybackEndedMsg) (tea.Model, tea.Cmd) {
+	if !msg.PlayedToEnd {
+		return m, nil
+	}
+	libID := m.currentLibID
+	if libID == "" || isSyntheticLibrary(libID) {
+		libID = msg.Item.LibraryID
+	}
+	return m, NextEpisodeCmd(m.LibraryService, libID, msg.Item)
+}
+
+// handleNextEpisode starts or offers the next episode while browsing; over
+// a lock or modal it only announces it, in either mode
+func (m Model) handleNextEpisode(msg NextEpisodeMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.notify(NoticeError, i18n.T("status.next_episode_failed", msg.Err))
+	}
+	if msg.Next == nil {
+		return m, m.notify(NoticeInfo, i18n.T("status.show_finished", msg.After.ShowTitle))
+	}
+	next := *msg.Next
+	label := next.EpisodeCode() + " " + next.Title
+	if m.State != StateBrowsing || m.hasOverlay() {
+		return m, m.notify(NoticeInfo, i18n.T("status.up_next", label))
+	}
+	if m.nextEpisodeMode == config.NextEpisodeAuto {
+		return m, tea.Batch(
+			m.notify(NoticeInfo, i18n.T("status.up_next", label)),
+			PlayItemCmd(m.PlaybackSvc, next, next.ShouldResume()),
+		)
+	}
+	m.pendingNextEpisode = &next
+	m.State = StateConfirmNextEpisode
+	return m, nil
+}
+
+// handleNextEpisodeConfirm answers the next-episode dialog
+func (m Model) handleNextEpisodeConfirm(play bool) (tea.Model, tea.Cmd) {
+	next := m.pendingNextEpisode
+	m.pendingNextEpisode = nil
+	m.State = StateBrowsing
+	if !play || next == nil {
+		return m, nil
+	}
+	return m, PlayItemCmd(m.PlaybackSvc, *next, next.ShouldResume())
+}
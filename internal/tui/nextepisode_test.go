@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+)
+
+// Auto mode starts the next episode only while browsing: over the lock it
+// is announced, as ask mode does
+func TestNextEpisodeAutoRespectsLock(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack()}
+	m.SetNextEpisode(config.NextEpisodeAuto)
+	msg := NextEpisodeMsg{Next: &domain.MediaItem{ID: "e2", Type: domain.MediaTypeEpisode, EpisodeNum: 2}}
+	// plays reports whether cmd starts playback besides the notice: a batch,
+	// where the notice alone is its timer
+	plays := func(cmd tea.Cmd) bool {
+		got := make(chan tea.Msg, 1)
+		go func() { got <- cmd() }()
+		select {
+		case msg := <-got:
+			_, ok := msg.(tea.BatchMsg)
+			return ok
+		case <-time.After(time.Second):
+			return false
+		}
+	}
+
+	m.State = StateLocked
+	updated, cmd := m.handleNextEpisode(msg)
+	want := i18n.T("status.up_next", msg.Next.EpisodeCode()+" "+msg.Next.Title)
+	if plays(cmd) || updated.(Model).State != StateLocked || updated.(Model).notice.Text != want {
+		t.Fatal("auto mode played the next episode behind the lock")
+	}
+
+	m.State = StateBrowsing
+	if _, cmd := m.handleNextEpisode(msg); !plays(cmd) {
+		t.Fatal("auto mode did not play the next episode while browsing")
+	}
+}
@@ -20,6 +20,7 @@ const (
 // Notice is the single footer notification slot.
 type Notice struct {
 	Text string
+	Hint string // What to do about it, rendered dimmed after the text
 	Kind NoticeKind
 	Seq  int
 }
@@ -37,6 +38,11 @@ type ClearNoticeMsg struct{ Seq int }
 // notify posts a footer notification and returns its expiry timer command.
 // Alerts return nil (no timer) and cannot be displaced by non-alert notices.
 func (m *Model) notify(kind NoticeKind, text string) tea.Cmd {
+	return m.notifyHint(kind, text, "")
+}
+
+// notifyHint posts a notification with a suggested action (see notify)
+func (m *Model) notifyHint(kind NoticeKind, text, hint string) tea.Cmd {
 	if m.notice.Kind == NoticeAlert && m.notice.Text != "" && kind != NoticeAlert {
 		// An active alert outranks transient notices; drop them rather than
 		// hide an actionable message
@@ -44,7 +50,7 @@ func (m *Model) notify(kind NoticeKind, text string) tea.Cmd {
 	}
 
 	m.noticeSeq++
-	m.notice = Notice{Text: text, Kind: kind, Seq: m.noticeSeq}
+	m.notice = Notice{Text: text, Hint: hint, Kind: kind, Seq: m.noticeSeq}
 
 	if kind == NoticeAlert {
 		return nil
@@ -1,6 +1,14 @@
 package tui
 
-import "testing"
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode"
+)
 
 func TestNotifySetsNoticeAndTimer(t *testing.T) {
 	m := &Model{}
@@ -64,3 +72,59 @@ func TestAlertOutranksTransientNotices(t *testing.T) {
 		t.Fatal("clearNotice failed")
 	}
 }
+
+// Notice text must come from the message catalog: a string literal with
+// words in it, passed to notify or notifyHint outside i18n.T, would show
+// in English whatever the locale.
+func TestNoticesUseCatalog(t *testing.T) {
+	paths, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || (sel.Sel.Name != "notify" && sel.Sel.Name != "notifyHint") || len(call.Args) < 2 {
+				return true
+			}
+			for _, arg := range call.Args[1:] {
+				ast.Inspect(arg, func(n ast.Node) bool {
+					if isCatalogCall(n) {
+						return false
+					}
+					if lit, ok := n.(*ast.BasicLit); ok && lit.Kind == token.STRING && strings.IndexFunc(lit.Value, unicode.IsLetter) >= 0 {
+						t.Errorf("%s: %s passed %s; add it to the i18n catalogs", fset.Position(lit.Pos()), sel.Sel.Name, lit.Value)
+					}
+					return true
+				})
+			}
+			return true
+		})
+	}
+}
+
+// isCatalogCall reports whether n is an i18n.T call, whose literal is a key
+func isCatalogCall(n ast.Node) bool {
+	call, ok := n.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "i18n" && sel.Sel.Name == "T"
+}
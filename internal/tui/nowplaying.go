@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/format"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/player"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// Now playing: while a watched mpv runs, the footer shows what it plays
+// and where it is, polled over its IPC socket every nowPlayingInterval,
+// and the player keys control it from kino. mpv outlives kino, so on
+// startup kino attaches to one an earlier run left playing.
+const (
+	nowPlayingInterval = time.Second
+	seekBackStep       = 10 * time.Second
+	seekForwardStep    = 30 * time.Second
+)
+
+// nowPlaying is the running mpv the footer strip follows
+type nowPlaying struct {
+	item   domain.MediaItem
+	mpv    *player.MPV
+	status player.Status
+}
+
+// NowPlayingAttachedMsg signals a connection to a running mpv. Reattached
+// is set when an earlier kino launched it.
+type NowPlayingAttachedMsg struct {
+	Item       domain.MediaItem
+	MPV        *player.MPV
+	Reattached bool
+}
+
+// NowPlayingStatusMsg carries one poll of an mpv; Err once it is gone
+type NowPlayingStatusMsg struct {
+	MPV    *player.MPV
+	Status player.Status
+	Err    error
+}
+
+// AttachNowPlayingCmd connects to the running playback session, if any
+func AttachNowPlayingCmd(svc *player.Service, reattached bool) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		session, mpv := svc.Attach()
+		if session == nil {
+			return nil
+		}
+		return NowPlayingAttachedMsg{Item: session.Item, MPV: mpv, Reattached: reattached}
+	}
+}
+
+// nowPlayingStatusCmd polls an mpv after delay
+func nowPlayingStatusCmd(mpv *player.MPV, delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		st, err := mpv.Status()
+		return NowPlayingStatusMsg{MPV: mpv, Status: st, Err: err}
+	})
+}
+
+// handleNowPlayingAttached starts following an mpv, letting go of any
+// followed before
+func (m Model) handleNowPlayingAttached(msg NowPlayingAttachedMsg) (tea.Model, tea.Cmd) {
+	if m.nowPlaying != nil {
+		m.nowPlaying.mpv.Detach()
+	}
+	m.nowPlaying = &nowPlaying{item: msg.Item, mpv: msg.MPV}
+	cmds := []tea.Cmd{nowPlayingStatusCmd(msg.MPV, 0)}
+	if msg.Reattached {
+		cmds = append(cmds, m.notify(NoticeInfo, i18n.T("status.reattached", msg.Item.Title)))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleNowPlayingStatus updates the strip and schedules the next poll; a
+// failed poll means mpv exited
+func (m Model) handleNowPlayingStatus(msg NowPlayingStatusMsg) (tea.Model, tea.Cmd) {
+	if m.nowPlaying == nil || m.nowPlaying.mpv != msg.MPV {
+		return m, nil
+	}
+	if msg.Err != nil {
+		msg.MPV.Detach()
+		m.nowPlaying = nil
+		return m, nil
+	}
+	m.nowPlaying.status = msg.Status
+	return m, nowPlayingStatusCmd(msg.MPV, nowPlayingInterval)
+}
+
+// playerCmd runs a control command on mpv, reporting only failures
+func playerCmd(control func() error) tea.Cmd {
+	return func() tea.Msg {
+		if err := control(); err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.controlling_player")}
+		}
+		return nil
+	}
+}
+
+// handlePausePlayer pauses or resumes the player, flipping the strip ahead
+// of the next poll
+func (m Model) handlePausePlayer() (tea.Model, tea.Cmd) {
+	np := *m.nowPlaying
+	np.status.Paused = !np.status.Paused
+	m.nowPlaying = &np
+	return m, playerCmd(np.mpv.TogglePause)
+}
+
+// handleSeekPlayer jumps the player by d
+func (m Model) handleSeekPlayer(d time.Duration) (tea.Model, tea.Cmd) {
+	np := *m.nowPlaying
+	np.status.Position = max(0, np.status.Position+d)
+	if np.status.Duration > 0 {
+		np.status.Position = min(np.status.Position, np.status.Duration)
+	}
+	m.nowPlaying = &np
+	return m, playerCmd(func() error { return np.mpv.SeekBy(d) })
+}
+
+// handleStopPlayer quits the player
+func (m Model) handleStopPlayer() (tea.Model, tea.Cmd) {
+	np := m.nowPlaying
+	m.nowPlaying = nil
+	return m, tea.Batch(
+		playerCmd(np.mpv.Close),
+		m.notify(NoticeInfo, i18n.T("status.player_stopped", np.item.Title)),
+	)
+}
+
+// renderNowPlaying renders the footer strip: state, title and position
+func (m Model) renderNowPlaying() string {
+	np := m.nowPlaying
+	if np == nil {
+		return ""
+	}
+	icon := "▶"
+	if np.status.Paused {
+		icon = "⏸"
+	}
+	title := np.item.Title
+	if code := np.item.EpisodeCode(); code != "" {
+		title = np.item.ShowTitle + " " + code
+	}
+	clock := format.Position(np.status.Position)
+	if np.status.Duration > 0 {
+		clock += " / " + format.Position(np.status.Duration)
+	}
+	return styles.AccentStyle.Render(icon) + " " + title + styles.DimStyle.Render("  "+clock)
+}
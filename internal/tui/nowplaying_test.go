@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/player"
+)
+
+// The footer follows the attached player's polls, the pause key flips it
+// ahead of the next one, and polls of a player no longer followed are
+// dropped
+func TestNowPlayingStrip(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack(), jobs: NewJobs(), Width: 120}
+	item := domain.MediaItem{ID: "7", Title: "Pilot", Type: domain.MediaTypeEpisode, ShowTitle: "Fargo", SeasonNum: 1, EpisodeNum: 1}
+	updated, _ := m.Update(NowPlayingAttachedMsg{Item: item})
+	m = updated.(Model)
+	updated, cmd := m.Update(NowPlayingStatusMsg{Status: player.Status{Position: 90 * time.Second, Duration: 45 * time.Minute}})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Error("no next poll scheduled")
+	}
+	if footer := m.renderFooter(); !strings.Contains(footer, "▶ Fargo S01E01  1:30 / 45:00") {
+		t.Errorf("footer = %q", footer)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(".")})
+	m = updated.(Model)
+	if !m.nowPlaying.status.Paused || !strings.Contains(m.renderFooter(), "⏸ Fargo") {
+		t.Errorf("not paused: %q", m.renderFooter())
+	}
+
+	updated, cmd = m.Update(NowPlayingStatusMsg{MPV: &player.MPV{}})
+	m = updated.(Model)
+	if cmd != nil || m.nowPlaying.status.Position != 90*time.Second {
+		t.Errorf("poll of another player applied: %+v", m.nowPlaying.status)
+	}
+}
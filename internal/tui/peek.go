@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/library"
+)
+
+// PeekLoadedMsg carries the children of a peeked show or season
+type PeekLoadedMsg struct {
+	ParentID string
+	Items    []domain.ListItem
+	Err      error
+}
+
+// PeekCmd fetches a show's seasons (seasonID empty) or a season's episodes
+// for the inspector peek. Fetching caches them, so drilling in afterwards
+// is instant. Seasons are arranged by the show's specials placement.
+func PeekCmd(svc *library.Service, libID, showID, seasonID, specials string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if seasonID == "" {
+			seasons, err := svc.FetchSeasons(ctx, libID, showID)
+			return PeekLoadedMsg{ParentID: showID, Items: seasonItems(arrangeSeasons(seasons, specials)), Err: err}
+		}
+		episodes, err := svc.FetchEpisodes(ctx, libID, showID, seasonID)
+		return PeekLoadedMsg{ParentID: seasonID, Items: episodeItems(episodes), Err: err}
+	}
+}
+
+func seasonItems(seasons []*domain.Season) []domain.ListItem {
+	items := make([]domain.ListItem, len(seasons))
+	for i, s := range seasons {
+		items[i] = s
+	}
+	return items
+}
+
+func episodeItems(episodes []*domain.MediaItem) []domain.ListItem {
+	items := make([]domain.ListItem, len(episodes))
+	for i, e := range episodes {
+		items[i] = e
+	}
+	return items
+}
+
+// handlePeek toggles peek mode: the inspector lists the selected show's
+// seasons or season's episodes without pushing a column. The inspector is
+// opened for the peek and closed again afterwards if it was hidden.
+func (m Model) handlePeek() (tea.Model, tea.Cmd) {
+	if m.peeking {
+		m.closePeek()
+		return m, nil
+	}
+	if m.split != nil {
+		return m, m.notifyHint(NoticeInfo, i18n.T("status.no_peek_split"), i18n.T("hint.close_split"))
+	}
+	m.peeking = true
+	m.peekOpenedInspector = !m.ShowInspector
+	m.ShowInspector = true
+	m.updateLayout()
+	return m, m.refreshPeek()
+}
+
+// closePeek leaves peek mode and restores the inspector visibility
+func (m *Model) closePeek() {
+	m.peeking = false
+	m.Inspector.ClearPeek()
+	if m.peekOpenedInspector {
+		m.ShowInspector = false
+		m.peekOpenedInspector = false
+	}
+	m.updateLayout()
+}
+
+// refreshPeek points the peek at the current selection, reading the cache
+// first and fetching only on a miss. Items without children clear it.
+func (m *Model) refreshPeek() tea.Cmd {
+	if !m.peeking {
+		return nil
+	}
+	top := m.ColumnStack.Top()
+	if top == nil {
+		m.Inspector.ClearPeek()
+		return nil
+	}
+
+	switch v := top.SelectedItem().(type) {
+	case *domain.Show:
+		if m.Inspector.PeekParentID() == v.ID {
+			return nil
+		}
+		if seasons, ok := m.Store.GetSeasons(m.currentLibID, v.ID); ok {
+			m.Inspector.SetPeek(v.ID, seasonItems(m.arrangeShowSeasons(v.ID, seasons)), false)
+			return nil
+		}
+		m.Inspector.SetPeek(v.ID, nil, true)
+		return PeekCmd(m.LibraryService, m.currentLibID, v.ID, "", m.specialsMode(v.ID))
+	case *domain.Season:
+		if m.Inspector.PeekParentID() == v.ID {
+			return nil
+		}
+		if episodes, ok := m.Store.GetEpisodes(m.currentLibID, m.currentShowID, v.ID); ok {
+			m.Inspector.SetPeek(v.ID, episodeItems(episodes), false)
+			return nil
+		}
+		m.Inspector.SetPeek(v.ID, nil, true)
+		return PeekCmd(m.LibraryService, m.currentLibID, m.currentShowID, v.ID, "")
+	default:
+		m.Inspector.ClearPeek()
+		return nil
+	}
+}
+
+// handlePeekLoaded applies fetched children if the peek still targets them
+func (m Model) handlePeekLoaded(msg PeekLoadedMsg) (tea.Model, tea.Cmd) {
+	if !m.peeking || m.Inspector.PeekParentID() != msg.ParentID {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.Inspector.SetPeekFailed(msg.ParentID)
+		return m, nil
+	}
+	m.Inspector.SetPeek(msg.ParentID, msg.Items, false)
+	return m, nil
+}
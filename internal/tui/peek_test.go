@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// Peek shows cached children in the inspector without pushing a column,
+// and follows the selection
+func TestPeekShowsChildrenWithoutNavigating(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seasons := []*domain.Season{
+		{ID: "s1", ShowID: "show1", SeasonNum: 1, Title: "Season 1", EpisodeCount: 8, UnwatchedCount: 3},
+	}
+	if err := st.SaveSeasons("lib1", "show1", seasons); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{ColumnStack: NewColumnStack(), Store: st, currentLibID: "lib1"}
+	shows := components.NewListColumn(components.ColumnTypeShows, "TV")
+	shows.SetItems([]*domain.Show{{ID: "show1", Title: "Show One"}, {ID: "show2", Title: "Show Two"}})
+	m.ColumnStack.Push(shows, 0)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(Model)
+	if !m.peeking || !m.ShowInspector {
+		t.Fatal("tab did not open the peek")
+	}
+	if m.ColumnStack.Len() != 1 {
+		t.Fatalf("peek pushed a column: stack has %d", m.ColumnStack.Len())
+	}
+	m.updateInspector()
+	m.Inspector.SetSize(40, 20)
+	if view := m.Inspector.View(); !strings.Contains(view, "Season 1") || !strings.Contains(view, "5/8") {
+		t.Fatalf("peek did not list the cached seasons:\n%s", view)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.peeking || m.ShowInspector || m.Inspector.PeekParentID() != "" {
+		t.Fatal("esc did not close the peek and restore the hidden inspector")
+	}
+}
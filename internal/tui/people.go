@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// Cast & crew (c): pick a director or actor of the selected item from a
+// menu and get a column of every movie and show of theirs in the
+// libraries, newest first.
+
+// PersonItemsLoadedMsg carries the items crediting a person
+type PersonItemsLoadedMsg struct {
+	Person   domain.Credit
+	Director bool
+	Items    []domain.ListItem
+}
+
+// LoadPersonItemsCmd finds the items a person acted in or directed
+func LoadPersonItemsCmd(svc *library.Service, libs []domain.Library, person domain.Credit, director bool) tea.Cmd {
+	return retryable(func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		items, err := svc.FetchPersonItems(ctx, libs, person, director)
+		if err != nil {
+			return ErrMsg{Err: err, Context: i18n.T("context.finding_person", person.Name)}
+		}
+		return PersonItemsLoadedMsg{Person: person, Director: director, Items: items}
+	})
+}
+
+// handlePeople opens the cast & crew menu of the selected movie, episode or
+// show, fetching its credits first when they are not cached
+func (m Model) handlePeople() (tea.Model, tea.Cmd) {
+	top := m.ColumnStack.Top()
+	if top == nil {
+		return m, nil
+	}
+	itemID := detailsTarget(top.SelectedItem())
+	svc := m.LibraryService
+	if itemID == "" || svc == nil || !svc.HasDetails() || !svc.HasPersonItems() {
+		return m.notAvailableHere(i18n.T("action.people"))
+	}
+	if details, ok := svc.CachedDetails(itemID); ok {
+		return m.openPeopleMenu(details)
+	}
+	m.peopleFor = itemID
+	return m, DetailsCmd(svc, itemID)
+}
+
+// openPeopleMenu lists the directors, then the cast, in the context menu.
+// People the server gave no ID for cannot be looked up and are left out.
+func (m Model) openPeopleMenu(d *domain.ItemDetails) (tea.Model, tea.Cmd) {
+	var entries []menuEntry
+	add := func(label string, person domain.Credit, director bool) {
+		entries = append(entries, menuEntry{label: label, run: func(m Model) (tea.Model, tea.Cmd) {
+			return m, tea.Batch(
+				m.notify(NoticeInfo, i18n.T("status.finding_person", person.Name)),
+				LoadPersonItemsCmd(m.LibraryService, m.Libraries, person, director),
+			)
+		}})
+	}
+	for _, p := range d.Directors {
+		if p.ID != "" {
+			add("Directed by "+p.Name, p, true)
+		}
+	}
+	for _, p := range d.Cast {
+		if p.ID == "" {
+			continue
+		}
+		label := p.Name
+		if p.Role != "" {
+			label += " as " + p.Role
+		}
+		add(label, p, false)
+	}
+	if len(entries) == 0 {
+		return m, m.notify(NoticeInfo, i18n.T("status.no_people"))
+	}
+	m.menu = entries
+	m.menuCursor = 0
+	return m, nil
+}
+
+// handlePersonItemsLoaded opens the person's items in a column after the
+// current one, leaving the detail view if it was open
+func (m Model) handlePersonItemsLoaded(msg PersonItemsLoadedMsg) (tea.Model, tea.Cmd) {
+	if len(msg.Items) == 0 {
+		return m, m.notify(NoticeInfo, i18n.T("status.person_not_found", msg.Person.Name))
+	}
+	top := m.ColumnStack.Top()
+	if top == nil {
+		return m, nil
+	}
+	m.detail = nil
+
+	title := msg.Person.Name
+	if msg.Director {
+		title = "Directed by " + title
+	}
+	col := components.NewListColumn(components.ColumnTypeMixed, title)
+	col.SetShowWatchStatus(m.UIConfig.ShowWatchStatus)
+	col.SetPreferredSort(components.SortReleased, components.SortDesc)
+	col.SetItems(msg.Items)
+	m.ColumnStack.Push(col, top.SelectedIndex())
+	m.updateLayout()
+	m.updateInspector()
+	return m, nil
+}
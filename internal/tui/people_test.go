@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+func TestPeople(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack(), State: StateBrowsing, Width: 100, Height: 30}
+	col := components.NewListColumn(components.ColumnTypeMovies, "Movies")
+	col.SetItems([]*domain.MediaItem{{ID: "m1", Title: "Heat", Type: domain.MediaTypeMovie, Year: 1995}})
+	m.ColumnStack.Push(col, 0)
+	m.detail = &detailView{item: col.SelectedItem().(domain.ListItem)}
+
+	// The menu opens once the pending item's details arrive
+	m.peopleFor = "m1"
+	updated, _ := m.Update(DetailsLoadedMsg{ItemID: "m1", Details: &domain.ItemDetails{
+		Directors: []domain.Credit{{ID: "7", Name: "Michael Mann"}},
+		Cast:      []domain.Credit{{ID: "8", Name: "Al Pacino", Role: "Vincent Hanna"}, {Name: "No ID"}},
+	}})
+	m = updated.(Model)
+	var labels []string
+	for _, e := range m.menu {
+		labels = append(labels, e.label)
+	}
+	if got := strings.Join(labels, "|"); got != "Directed by Michael Mann|Al Pacino as Vincent Hanna" {
+		t.Fatalf("menu = %q", got)
+	}
+	m.menu = nil
+
+	updated, _ = m.Update(PersonItemsLoadedMsg{Person: domain.Credit{ID: "8", Name: "Al Pacino"}, Items: []domain.ListItem{
+		&domain.MediaItem{ID: "m1", Title: "Heat", Type: domain.MediaTypeMovie, Year: 1995},
+		&domain.Show{ID: "s1", Title: "Angels in America", Year: 2003},
+	}})
+	m = updated.(Model)
+	if m.detail != nil {
+		t.Error("the detail view should close for the results")
+	}
+	top := m.ColumnStack.Top()
+	if m.ColumnStack.Len() != 2 || top.Title() != "Al Pacino" || top.ItemCount() != 2 {
+		t.Fatalf("top column = %q with %d items, want Al Pacino's 2", top.Title(), top.ItemCount())
+	}
+	if first, ok := top.SelectedItem().(*domain.Show); !ok || first.ID != "s1" {
+		t.Errorf("first item = %+v, want the newest (Angels in America)", top.SelectedItem())
+	}
+}
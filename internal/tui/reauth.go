@@ -0,0 +1,311 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/mediaserver"
+	"github.com/mmcdole/kino/internal/tui/components"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// maxCredentialLength bounds the username and password entry buffers
+const maxCredentialLength = 256
+
+// authStep is where the sign-in dialog is
+type authStep int
+
+const (
+	authRequesting authStep = iota // Fetching a link code (or failed to)
+	authWaiting                    // Showing the link code until it is claimed
+	authPassword                   // Username/password form
+	authSigningIn                  // Password submitted
+)
+
+// authPrompt is the state of signing in again after the server rejected
+// the token
+type authPrompt struct {
+	step       authStep
+	code       mediaserver.LinkCode
+	username   string
+	password   string
+	onPassword bool // Password field has focus
+	err        string
+	ctx        context.Context
+	cancel     context.CancelFunc
+	gen        int       // Results of abandoned attempts carry an older gen
+	retries    []tea.Cmd // Failed commands to rerun once signed in
+}
+
+// AuthLinkMsg carries a link code to show, or why none could be had
+type AuthLinkMsg struct {
+	Gen  int
+	Code mediaserver.LinkCode
+	Err  error
+}
+
+// ReauthDoneMsg reports the end of a sign-in attempt. SaveErr is set when
+// the client got its new token but writing it to the config failed.
+type ReauthDoneMsg struct {
+	Gen     int
+	Err     error
+	SaveErr error
+}
+
+// SetReauth lets the session sign in again when the server rejects the
+// token, instead of asking the user to log out
+func (m *Model) SetReauth(r *mediaserver.Reauth) {
+	m.reauth = r
+}
+
+// RequestLinkCmd fetches a link code for the sign-in dialog
+func RequestLinkCmd(ctx context.Context, r *mediaserver.Reauth, gen int) tea.Cmd {
+	return func() tea.Msg {
+		code, err := r.RequestLink(ctx)
+		return AuthLinkMsg{Gen: gen, Code: code, Err: err}
+	}
+}
+
+// WaitLinkCmd waits for the link code to be claimed and saves the token
+func WaitLinkCmd(ctx context.Context, r *mediaserver.Reauth, code mediaserver.LinkCode, gen int) tea.Cmd {
+	return func() tea.Msg {
+		server, err := r.WaitLink(ctx, code)
+		return reauthDone(gen, server, err)
+	}
+}
+
+// LoginCmd signs in with a username and password and saves the token
+func LoginCmd(ctx context.Context, r *mediaserver.Reauth, username, password string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		server, err := r.Login(ctx, username, password)
+		return reauthDone(gen, server, err)
+	}
+}
+
+// reauthDone persists a successful sign-in
+func reauthDone(gen int, server config.ServerConfig, err error) ReauthDoneMsg {
+	if err != nil {
+		return ReauthDoneMsg{Gen: gen, Err: err}
+	}
+	return ReauthDoneMsg{Gen: gen, SaveErr: config.SaveServerToken(server)}
+}
+
+// authFailed handles a request the server rejected for its token. With
+// re-auth set up it opens the sign-in dialog (once, however many requests
+// fail) and keeps retry to rerun after signing in; otherwise it tells the
+// user to log out.
+func (m *Model) authFailed(retry tea.Cmd) tea.Cmd {
+	if m.reauth == nil {
+		// Shown persistently (not auto-cleared) since action is required
+		return m.notify(NoticeAlert, i18n.T("status.auth_failed"))
+	}
+	if retry != nil {
+		m.auth.retries = append(m.auth.retries, retry)
+	}
+	if m.State == StateAuthRequired || (m.State == StateLocked && m.lockedFrom == StateAuthRequired) {
+		return nil
+	}
+	if m.State == StateLocked {
+		m.lockedFrom = StateAuthRequired // Ask once the lock is lifted
+	} else {
+		m.State = StateAuthRequired
+	}
+	return m.startReauth()
+}
+
+// startReauth begins a fresh sign-in attempt, keeping the pending retries
+func (m *Model) startReauth() tea.Cmd {
+	if m.auth.cancel != nil {
+		m.auth.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.auth = authPrompt{ctx: ctx, cancel: cancel, gen: m.auth.gen + 1, retries: m.auth.retries}
+	if m.reauth.UsesPassword() {
+		m.auth.step = authPassword
+		m.auth.username = m.reauth.Username()
+		m.auth.onPassword = m.auth.username != ""
+		return nil
+	}
+	m.auth.step = authRequesting
+	return RequestLinkCmd(ctx, m.reauth, m.auth.gen)
+}
+
+// endReauth closes the dialog, dropping the attempt in progress
+func (m *Model) endReauth() {
+	if m.auth.cancel != nil {
+		m.auth.cancel()
+	}
+	m.auth = authPrompt{gen: m.auth.gen + 1}
+	if m.State == StateLocked {
+		m.lockedFrom = StateBrowsing
+	} else {
+		m.State = StateBrowsing
+	}
+}
+
+// handleAuthLink shows a fetched link code and waits for it to be claimed
+func (m Model) handleAuthLink(msg AuthLinkMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.auth.gen {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.auth.err = msg.Err.Error()
+		return m, nil
+	}
+	m.auth.step = authWaiting
+	m.auth.code = msg.Code
+	m.auth.err = ""
+	return m, WaitLinkCmd(m.auth.ctx, m.reauth, msg.Code, m.auth.gen)
+}
+
+// handleReauthDone resumes the session after signing in: the failed
+// requests and library syncs run again
+func (m Model) handleReauthDone(msg ReauthDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.Gen != m.auth.gen {
+		return m, nil
+	}
+	if msg.Err != nil {
+		m.auth.err = msg.Err.Error()
+		if m.auth.step == authSigningIn && errors.Is(msg.Err, domain.ErrAuthFailed) {
+			m.auth.err = "Wrong username or password"
+		}
+		if m.auth.step == authSigningIn {
+			m.auth.step = authPassword
+			m.auth.password = ""
+			m.auth.onPassword = true
+		} else {
+			m.auth.step = authRequesting // Enter fetches a new code
+		}
+		return m, nil
+	}
+
+	cmds := append([]tea.Cmd(nil), m.auth.retries...)
+	m.endReauth()
+	cmds = append(cmds, m.retryFailedSyncs(domain.ErrAuthFailed))
+	if msg.SaveErr != nil {
+		cmds = append(cmds, m.notify(NoticeError, i18n.T("status.token_save_failed", msg.SaveErr)))
+	} else {
+		cmds = append(cmds, m.notify(NoticeSuccess, i18n.T("status.signed_in")))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// retryFailedSyncs restarts the library syncs that failed with cause: a
+// rejected token or an unreachable server
+func (m *Model) retryFailedSyncs(cause error) tea.Cmd {
+	var cmds []tea.Cmd
+	for id, state := range m.LibraryStates {
+		if state.Status != components.StatusError || !errors.Is(state.Error, cause) {
+			continue
+		}
+		if id == playlistsLibraryID {
+			m.LibraryStates[id] = components.LibrarySyncState{Status: components.StatusSyncing}
+			cmds = append(cmds, m.startPlaylistSyncJob())
+		} else if lib := m.findLibrary(id); lib != nil {
+			cmds = append(cmds, m.queueSync(*lib, false))
+		}
+	}
+	if len(cmds) > 0 {
+		m.updateLibraryStates()
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleAuthInput drives the sign-in dialog. Esc gives up: the next
+// rejected request asks again.
+func (m Model) handleAuthInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyEsc {
+		m.endReauth()
+		return m, m.notify(NoticeInfo, i18n.T("status.not_signed_in"))
+	}
+
+	switch m.auth.step {
+	case authRequesting:
+		if msg.Type == tea.KeyEnter && m.auth.err != "" {
+			return m, m.startReauth()
+		}
+	case authPassword:
+		field := &m.auth.username
+		if m.auth.onPassword {
+			field = &m.auth.password
+		}
+		switch msg.Type {
+		case tea.KeyTab, tea.KeyShiftTab, tea.KeyUp, tea.KeyDown:
+			m.auth.onPassword = !m.auth.onPassword
+		case tea.KeyEnter:
+			if !m.auth.onPassword {
+				m.auth.onPassword = true
+				return m, nil
+			}
+			m.auth.step = authSigningIn
+			m.auth.err = ""
+			return m, LoginCmd(m.auth.ctx, m.reauth, m.auth.username, m.auth.password, m.auth.gen)
+		case tea.KeyBackspace:
+			if r := []rune(*field); len(r) > 0 {
+				*field = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			if len(*field)+len(string(msg.Runes)) <= maxCredentialLength {
+				*field += string(msg.Runes)
+			}
+		}
+	}
+	return m, nil
+}
+
+// renderAuthPrompt renders the sign-in dialog
+func (m Model) renderAuthPrompt() string {
+	var b strings.Builder
+	b.WriteString(styles.ModalTitleStyle.Render("Sign in again"))
+	b.WriteString("\n\n")
+	b.WriteString("  The server no longer accepts kino's token.\n\n")
+
+	hint := "esc cancel"
+	switch m.auth.step {
+	case authRequesting:
+		if m.auth.err == "" {
+			b.WriteString(styles.DimStyle.Render("  Getting a sign-in code..."))
+			b.WriteString("\n")
+		} else {
+			hint = "enter try again • esc cancel"
+		}
+	case authWaiting:
+		b.WriteString("  Go to:      " + styles.AccentStyle.Render(m.auth.code.URL) + "\n")
+		b.WriteString("  Enter code: " + styles.AccentStyle.Render(m.auth.code.Code) + "\n\n")
+		b.WriteString(styles.DimStyle.Render("  Waiting for the code to be entered..."))
+		b.WriteString("\n")
+	case authPassword, authSigningIn:
+		user, pass := "  Username: ", "  Password: "
+		if m.auth.onPassword {
+			pass = "> Password: "
+		} else {
+			user = "> Username: "
+		}
+		b.WriteString(user + styles.AccentStyle.Render(m.auth.username) + "\n")
+		b.WriteString(pass + styles.AccentStyle.Render(strings.Repeat("•", len([]rune(m.auth.password)))) + "\n")
+		if m.auth.step == authSigningIn {
+			b.WriteString("\n" + styles.DimStyle.Render("  Signing in...") + "\n")
+		} else {
+			hint = "tab switch field • enter sign in • esc cancel"
+		}
+	}
+	if m.auth.err != "" {
+		b.WriteString("\n" + styles.ErrorStyle.Render("  "+m.auth.err) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render(hint))
+
+	return lipgloss.Place(m.Width, m.Height,
+		lipgloss.Center, lipgloss.Center,
+		styles.ModalStyle.Render(b.String()))
+}
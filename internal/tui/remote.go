@@ -0,0 +1,249 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/tui/components"
+	"github.com/mmcdole/kino/internal/tui/styles"
+)
+
+// Remote mode (ui.remote_mode) is for keyboards with little more than
+// arrows, Enter and Back, like a TV box's remote. Right drills in or plays,
+// Left and Back go back, and Enter opens a context menu holding what the
+// letter keys would do for the selection.
+
+// menuEntry is one action of the context menu
+type menuEntry struct {
+	label string
+	run   func(Model) (tea.Model, tea.Cmd)
+}
+
+// ContextMenuKeyMap defines the context menu key bindings
+type ContextMenuKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Choose key.Binding
+	Close  key.Binding
+}
+
+// ContextMenuKeys is the context menu key bindings instance
+var ContextMenuKeys = ContextMenuKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("k", "up"),
+		key.WithHelp("↑", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("↓", "down"),
+	),
+	Choose: key.NewBinding(
+		key.WithKeys("enter", "l", "right"),
+		key.WithHelp("enter", "choose"),
+	),
+	Close: key.NewBinding(
+		key.WithKeys("esc", "backspace", "h", "left"),
+		key.WithHelp("back", "close"),
+	),
+}
+
+// isConfirm reports whether msg answers yes to a Y/N prompt. In remote mode
+// Enter does too, as there may be no Y key.
+func (m Model) isConfirm(msg tea.KeyMsg) bool {
+	return key.Matches(msg, Keys.Confirm) || (m.UIConfig.RemoteMode && key.Matches(msg, Keys.Enter))
+}
+
+// handleContextMenu opens the context menu for the selection
+func (m Model) handleContextMenu() (tea.Model, tea.Cmd) {
+	entries := m.contextMenuEntries()
+	if len(entries) == 0 {
+		return m, nil
+	}
+	m.menu = entries
+	m.menuCursor = 0
+	return m, nil
+}
+
+// contextMenuEntries lists the actions for the selected item, the default
+// one (open or play) first, then those for the column and the session
+func (m Model) contextMenuEntries() []menuEntry {
+	var entries []menuEntry
+	add := func(label string, run func(Model) (tea.Model, tea.Cmd)) {
+		entries = append(entries, menuEntry{label: label, run: run})
+	}
+
+	top := m.ColumnStack.Top()
+	if top == nil {
+		add("Quit", Model.handleQuit)
+		return entries
+	}
+	entries = m.itemMenuEntries()
+	if detailsTarget(top.SelectedItem()) != "" {
+		add("Details", Model.handleDetailView)
+	}
+
+	if sortOptions(top) != nil {
+		add("Sort…", Model.handleSort)
+		add("Watch filter", Model.handleWatchFilter)
+	}
+	add("Refresh", Model.handleRefresh)
+	switch {
+	case m.split != nil:
+		add("Switch pane", Model.handleSwitchPane)
+		add("Close split", Model.handleSplit)
+	case m.ShowInspector:
+		add("Hide inspector", Model.handleToggleInspector)
+		add("Split view", Model.handleSplit)
+	default:
+		add("Show inspector", Model.handleToggleInspector)
+		add("Split view", Model.handleSplit)
+	}
+	if m.LibraryService != nil && m.LibraryService.HasActivities() {
+		add("Server activity", Model.handleActivitiesPanel)
+	}
+	add("Home", Model.handleHome)
+	add("Background jobs", Model.handleJobsPanel)
+	add("Help", Model.handleHelp)
+	add("Quit", Model.handleQuit)
+	return entries
+}
+
+// itemMenuEntries lists the actions for the selected item alone, the
+// default one (open or play) first; the detail view shows them as buttons
+func (m Model) itemMenuEntries() []menuEntry {
+	var entries []menuEntry
+	add := func(label string, run func(Model) (tea.Model, tea.Cmd)) {
+		entries = append(entries, menuEntry{label: label, run: run})
+	}
+
+	top := m.ColumnStack.Top()
+	if top == nil {
+		return nil
+	}
+	selected := top.SelectedItem()
+	item := top.SelectedMediaItem()
+
+	switch {
+	case top.CanDrillInto():
+		add("Open", Model.handleDrillIn)
+	case item != nil && item.ShouldResume():
+		resume := *item
+		add("Resume", func(m Model) (tea.Model, tea.Cmd) { return m.launchItem(resume, true) })
+		add("Play from start", Model.handlePlay)
+	case item != nil:
+		add("Play", Model.handlePlay)
+	}
+	if item != nil && item.Type != domain.MediaTypeChannel && m.PlaybackSvc != nil && m.PlaybackSvc.CanCast() {
+		add("Play on…", Model.handleCast)
+	}
+
+	if status, ok := watchStateOf(selected); ok {
+		if status != domain.WatchStatusWatched {
+			add("Mark watched", Model.handleMarkWatched)
+		}
+		if status != domain.WatchStatusUnwatched {
+			add("Mark unwatched", Model.handleMarkUnwatched)
+		}
+	}
+	if item != nil && top.ColumnType() == components.ColumnTypeContinue {
+		add("Remove from Continue Watching", Model.handleDelete)
+	}
+	if item != nil && item.Type != domain.MediaTypeChannel && m.PlaylistService != nil {
+		if top.ColumnType() == components.ColumnTypePlaylistItems {
+			add("Remove from playlist", Model.handleDelete)
+		} else {
+			add("Add to playlist…", Model.handlePlaylistModal)
+		}
+	}
+	if m.LibraryService != nil && m.LibraryService.HasWatchlist() {
+		if top.SelectedWatchlistItem() != nil {
+			add("Remove from watchlist", Model.handleWatchlistToggle)
+		} else if entry, ok := watchlistEntryFor(selected); ok {
+			if m.watchlisted(entry.LocalID) != nil {
+				add("Remove from watchlist", Model.handleWatchlistToggle)
+			} else {
+				add("Add to watchlist", Model.handleWatchlistToggle)
+			}
+		}
+	}
+	if m.LibraryService != nil && m.LibraryService.CanRate() {
+		if _, ok := watchlistEntryFor(selected); ok || (item != nil && item.Type == domain.MediaTypeEpisode) {
+			add("Rate…", Model.handleRate)
+		}
+	}
+	if svc := m.LibraryService; svc != nil && svc.HasDetails() && svc.HasPersonItems() && detailsTarget(selected) != "" {
+		add("Cast & crew…", Model.handlePeople)
+	}
+	return entries
+}
+
+// watchStateOf returns the watch state of items that can be marked
+func watchStateOf(item interface{}) (domain.WatchStatus, bool) {
+	switch v := item.(type) {
+	case *domain.MediaItem:
+		if v.Type != domain.MediaTypeChannel {
+			return v.GetWatchStatus(), true
+		}
+	case *domain.Show:
+		return v.GetWatchStatus(), true
+	case *domain.Season:
+		return v.GetWatchStatus(), true
+	}
+	return 0, false
+}
+
+// handleContextMenuInput moves through the menu and runs the chosen
+// action; every key is consumed while it is open
+func (m Model) handleContextMenuInput(msg tea.KeyMsg) (bool, Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, ContextMenuKeys.Close):
+		m.menu = nil
+	case key.Matches(msg, ContextMenuKeys.Down):
+		if m.menuCursor < len(m.menu)-1 {
+			m.menuCursor++
+		}
+	case key.Matches(msg, ContextMenuKeys.Up):
+		if m.menuCursor > 0 {
+			m.menuCursor--
+		}
+	case key.Matches(msg, ContextMenuKeys.Choose):
+		entry := m.menu[m.menuCursor]
+		m.menu = nil
+		updated, cmd := entry.run(m)
+		return true, updated.(Model), cmd
+	}
+	return true, m, nil
+}
+
+// renderContextMenu renders the menu titled by the selection
+func (m Model) renderContextMenu() string {
+	title := "Menu"
+	if top := m.ColumnStack.Top(); top != nil {
+		if item, ok := top.SelectedItem().(domain.ListItem); ok {
+			title = item.GetTitle()
+		} else {
+			title = top.Title()
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.ModalTitleStyle.Render(styles.Truncate(title, 32)))
+	b.WriteString("\n\n")
+	for i, entry := range m.menu {
+		if i == m.menuCursor {
+			b.WriteString(styles.AccentStyle.Render("> " + entry.label))
+		} else {
+			b.WriteString("  " + entry.label)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("↑/↓ move · enter choose · back close"))
+
+	return lipgloss.Place(m.Width, m.Height,
+		lipgloss.Center, lipgloss.Center,
+		styles.ModalStyle.Render(b.String()))
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// In remote mode Enter opens the selection's menu, default action first;
+// choosing an entry runs the action the letter key would
+func TestRemoteModeContextMenu(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack(), jobs: NewJobs()}
+	m.UIConfig.RemoteMode = true
+	col := components.NewListColumn(components.ColumnTypeMovies, "Movies")
+	col.SetItems([]*domain.MediaItem{{ID: "m1", Title: "Heat", Type: domain.MediaTypeMovie}})
+	m.ColumnStack.Push(col, 0)
+	press := func(k tea.KeyType) {
+		updated, _ := m.Update(tea.KeyMsg{Type: k})
+		m = updated.(Model)
+	}
+
+	press(tea.KeyEnter)
+	if len(m.menu) == 0 || m.menu[0].label != "Play" {
+		t.Fatalf("menu = %+v, want Play first", m.menu)
+	}
+	target := slices.IndexFunc(m.menu, func(e menuEntry) bool { return e.label == "Show inspector" })
+	if target < 0 {
+		t.Fatal("no inspector entry")
+	}
+	for range target {
+		press(tea.KeyDown)
+	}
+	press(tea.KeyEnter)
+	if m.menu != nil || !m.ShowInspector {
+		t.Fatalf("choosing the entry did not run it (menu open: %v)", m.menu != nil)
+	}
+
+	press(tea.KeyEnter)
+	press(tea.KeyBackspace)
+	if m.menu != nil || m.ColumnStack.Len() != 1 {
+		t.Fatal("Back did not just close the menu")
+	}
+}
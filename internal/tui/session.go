@@ -0,0 +1,82 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// SetSession restores a saved session once libraries load. A deep link
+// (SetStartAt) takes precedence.
+func (m *Model) SetSession(s *config.Session) {
+	m.session = s
+	if s != nil {
+		m.ShowInspector = s.ShowInspector
+		m.updateLayout()
+	}
+}
+
+// Session captures where the user is for the next launch: the selected
+// library and, inside a server library, each open column's selection and
+// sort
+func (m Model) Session(serverURL string) config.Session {
+	s := config.Session{
+		Server:        serverURL,
+		ShowInspector: m.ShowInspector && !m.peekOpenedInspector,
+	}
+	if m.split != nil {
+		s.ShowInspector = m.split.inspector
+	}
+	libCol := m.ColumnStack.Get(0)
+	if libCol == nil {
+		return s
+	}
+	lib, ok := libCol.SelectedItem().(domain.Library)
+	if !ok {
+		return s
+	}
+	s.LibraryID = lib.ID
+	if m.findLibrary(lib.ID) == nil {
+		return s // virtual libraries reopen on the library list
+	}
+	for i := 1; i < m.ColumnStack.Len(); i++ {
+		col := m.ColumnStack.Get(i)
+		var cs config.ColumnSession
+		if item, ok := col.SelectedItem().(domain.ListItem); ok {
+			cs.SelectedID = item.GetID()
+		}
+		field, dir := col.SortState()
+		cs.Sort = field.Key()
+		cs.Desc = dir == components.SortDesc
+		s.Columns = append(s.Columns, cs)
+	}
+	return s
+}
+
+// restoreSession navigates back to a saved session, re-applying each
+// column's sort before selecting its saved item
+func (m *Model) restoreSession(s *config.Session) tea.Cmd {
+	lib := m.resetToLibrary(s.LibraryID)
+	if lib == nil || len(s.Columns) == 0 {
+		m.updateInspector()
+		return nil
+	}
+
+	targets := make([]NavTarget, len(s.Columns))
+	for i, cs := range s.Columns {
+		targets[i] = NavTarget{ID: cs.SelectedID, Sort: components.ParseSortField(cs.Sort)}
+		if cs.Desc {
+			targets[i].SortDir = components.SortDesc
+		}
+	}
+
+	switch lib.Type {
+	case "mixed":
+		return m.navigateToMixedLibraryItem(lib, targets)
+	case "movie":
+		return m.navigateToTypedLibraryItem(lib, NavigationContext{LibraryID: lib.ID, LibraryName: lib.Name}, targets, domain.MediaTypeMovie)
+	default:
+		return m.navigateToTypedLibraryItem(lib, NavigationContext{LibraryID: lib.ID, LibraryName: lib.Name}, targets, domain.MediaTypeShow)
+	}
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// A saved session reopens the same library, show and season with each
+// column's sort, and the restored state saves back unchanged
+func TestSessionRoundTrip(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shows := []*domain.Show{{ID: "show1", Title: "Alpha"}, {ID: "show2", Title: "Beta"}}
+	if err := st.SaveShows("tv", shows, 1); err != nil {
+		t.Fatal(err)
+	}
+	seasons := []*domain.Season{{ID: "s1", ShowID: "show2", SeasonNum: 1}, {ID: "s2", ShowID: "show2", SeasonNum: 2}}
+	if err := st.SaveSeasons("tv", "show2", seasons); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{
+		ColumnStack: NewColumnStack(),
+		Store:       st,
+		Libraries:   []domain.Library{{ID: "movies", Name: "Movies", Type: "movie"}, {ID: "tv", Name: "TV", Type: "show"}},
+	}
+	saved := &config.Session{
+		Server:    "http://server",
+		LibraryID: "tv",
+		Columns: []config.ColumnSession{
+			{SelectedID: "show2", Sort: "title", Desc: true},
+			{SelectedID: "s2"},
+		},
+		ShowInspector: true,
+	}
+	m.SetSession(saved)
+	m.restoreSession(saved)
+
+	if m.ColumnStack.Len() != 3 {
+		t.Fatalf("stack has %d columns, want libraries/shows/seasons", m.ColumnStack.Len())
+	}
+	if item, _ := m.ColumnStack.Top().SelectedItem().(*domain.Season); item == nil || item.ID != "s2" {
+		t.Fatalf("selected season = %v, want s2", m.ColumnStack.Top().SelectedItem())
+	}
+	if field, dir := m.ColumnStack.Get(1).SortState(); field != components.SortTitle || dir != components.SortDesc {
+		t.Fatalf("shows sort = %v/%v, want title desc", field, dir)
+	}
+
+	got := m.Session("http://server")
+	if got.LibraryID != saved.LibraryID || !got.ShowInspector || len(got.Columns) != 2 ||
+		got.Columns[0] != saved.Columns[0] || got.Columns[1].SelectedID != "s2" {
+		t.Fatalf("session = %+v, want %+v", got, *saved)
+	}
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// SortSavedMsg reports the result of persisting a sort preference
+type SortSavedMsg struct {
+	Err error
+}
+
+// SaveSortCmd persists a sort preference to the config file
+func SaveSortCmd(key, value string) tea.Cmd {
+	return func() tea.Msg {
+		return SortSavedMsg{Err: config.SaveSortPreference(key, value)}
+	}
+}
+
+// sortPreferenceKeys are the config keys a column's sort is saved under,
+// most specific first: library content by library ID, then by column type.
+// Episodes share one preference across shows.
+func sortPreferenceKeys(col *components.ListColumn) []string {
+	switch col.ColumnType() {
+	case components.ColumnTypeMovies:
+		return []string{col.ContentID(), "movies"}
+	case components.ColumnTypeShows:
+		return []string{col.ContentID(), "shows"}
+	case components.ColumnTypeMixed:
+		return []string{col.ContentID(), "mixed"}
+	case components.ColumnTypeEpisodes:
+		return []string{"episodes"}
+	case components.ColumnTypeContinue:
+		return []string{"continue"}
+	default:
+		return nil
+	}
+}
+
+// applySortPreference gives a new column its saved sort, if any
+func (m *Model) applySortPreference(col *components.ListColumn) {
+	for _, key := range sortPreferenceKeys(col) {
+		if value, ok := m.UIConfig.Sort[strings.ToLower(key)]; ok {
+			field, dir := parseSortPreference(value)
+			if field != components.SortDefault {
+				col.SetPreferredSort(field, dir)
+				return
+			}
+		}
+	}
+}
+
+// rememberSort records a column's sort as its preference: the column
+// keeps it across refreshes, new columns of its kind open with it, and it is
+// saved for future sessions
+func (m *Model) rememberSort(col *components.ListColumn, field components.SortField, dir components.SortDirection) tea.Cmd {
+	keys := sortPreferenceKeys(col)
+	if len(keys) == 0 {
+		return nil
+	}
+	col.SetPreferredSort(field, dir)
+	value := formatSortPreference(field, dir)
+	if m.UIConfig.Sort == nil {
+		m.UIConfig.Sort = make(map[string]string)
+	}
+	m.UIConfig.Sort[strings.ToLower(keys[0])] = value
+	return SaveSortCmd(keys[0], value)
+}
+
+// parseSortPreference parses "field" or "field:desc"
+func parseSortPreference(value string) (components.SortField, components.SortDirection) {
+	name, suffix, _ := strings.Cut(value, ":")
+	field := components.ParseSortField(strings.TrimSpace(name))
+	dir := components.SortAsc
+	if strings.TrimSpace(suffix) == "desc" {
+		dir = components.SortDesc
+	}
+	return field, dir
+}
+
+// formatSortPreference is the inverse of parseSortPreference
+func formatSortPreference(field components.SortField, dir components.SortDirection) string {
+	if dir == components.SortDesc {
+		return field.Key() + ":desc"
+	}
+	return field.Key()
+}
@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// Saved sorts apply to new columns, a library's own preference beating
+// its column type's
+func TestSortPreferenceAppliesToNewColumns(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack()}
+	m.UIConfig.Sort = map[string]string{"movies": "added:desc", "lib4k": "rating"}
+
+	col := components.NewListColumn(components.ColumnTypeMovies, "Movies")
+	col.SetContentID("lib1")
+	m.applySortPreference(col)
+	col.SetItems([]*domain.MediaItem{{ID: "a"}})
+	if field, dir := col.SortState(); field != components.SortDateAdded || dir != components.SortDesc {
+		t.Fatalf("type preference = %v/%v, want added desc", field, dir)
+	}
+
+	col = components.NewListColumn(components.ColumnTypeMovies, "4K")
+	col.SetContentID("LIB4K")
+	m.applySortPreference(col)
+	col.SetItems([]*domain.MediaItem{{ID: "a"}})
+	if field, dir := col.SortState(); field != components.SortRating || dir != components.SortAsc {
+		t.Fatalf("library preference = %v/%v, want rating asc", field, dir)
+	}
+
+	if cmd := m.rememberSort(col, components.SortTitle, components.SortDesc); cmd == nil {
+		t.Fatal("sort choice not persisted")
+	}
+	if m.UIConfig.Sort["lib4k"] != "title:desc" {
+		t.Fatalf("remembered = %q, want title:desc", m.UIConfig.Sort["lib4k"])
+	}
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// SpecialsSavedMsg reports the result of persisting a show's specials
+// placement
+type SpecialsSavedMsg struct {
+	Err error
+}
+
+// SaveSpecialsCmd persists a show's specials placement to the config file
+func SaveSpecialsCmd(showID, value string) tea.Cmd {
+	return func() tea.Msg {
+		return SpecialsSavedMsg{Err: config.SaveSpecialsPreference(showID, value)}
+	}
+}
+
+// specialsMode returns where a show's specials and extras go: its own
+// setting, else the global one
+func (m *Model) specialsMode(showID string) string {
+	if mode, ok := m.UIConfig.SpecialsByShow[strings.ToLower(showID)]; ok {
+		return mode
+	}
+	if m.UIConfig.Specials == "" {
+		return config.SpecialsShow
+	}
+	return m.UIConfig.Specials
+}
+
+// isSpecial reports whether a season is Specials or the Extras group
+func isSpecial(season *domain.Season) bool {
+	return season.Extras || season.SeasonNum == 0
+}
+
+// arrangeSeasons applies a specials placement to a show's seasons,
+// returning a new slice and leaving the cached one untouched
+func arrangeSeasons(seasons []*domain.Season, mode string) []*domain.Season {
+	if mode != config.SpecialsSink && mode != config.SpecialsHide {
+		return seasons
+	}
+	arranged := make([]*domain.Season, 0, len(seasons))
+	var specials []*domain.Season
+	for _, season := range seasons {
+		if isSpecial(season) {
+			specials = append(specials, season)
+		} else {
+			arranged = append(arranged, season)
+		}
+	}
+	if mode == config.SpecialsSink {
+		arranged = append(arranged, specials...)
+	}
+	return arranged
+}
+
+// arrangeShowSeasons applies the show's specials placement
+func (m *Model) arrangeShowSeasons(showID string, seasons []*domain.Season) []*domain.Season {
+	return arrangeSeasons(seasons, m.specialsMode(showID))
+}
+
+// nextSpecialsMode cycles show → sink → hide
+func nextSpecialsMode(mode string) string {
+	switch mode {
+	case config.SpecialsShow:
+		return config.SpecialsSink
+	case config.SpecialsSink:
+		return config.SpecialsHide
+	default:
+		return config.SpecialsShow
+	}
+}
+
+// specialsNotice describes a placement for the footer
+func specialsNotice(mode string) string {
+	switch mode {
+	case config.SpecialsSink:
+		return i18n.T("status.specials_sink")
+	case config.SpecialsHide:
+		return i18n.T("status.specials_hide")
+	default:
+		return i18n.T("status.specials_order")
+	}
+}
+
+// handleSpecials cycles where the current show's specials and extras go
+// (S in a seasons column) and remembers the choice for that show
+func (m Model) handleSpecials() (tea.Model, tea.Cmd) {
+	top := m.ColumnStack.Top()
+	if top == nil || top.ColumnType() != components.ColumnTypeSeasons || m.currentShowID == "" {
+		return m.notAvailableHere(i18n.T("action.specials"))
+	}
+	showID := m.currentShowID
+	mode := nextSpecialsMode(m.specialsMode(showID))
+	if m.UIConfig.SpecialsByShow == nil {
+		m.UIConfig.SpecialsByShow = make(map[string]string)
+	}
+	m.UIConfig.SpecialsByShow[strings.ToLower(showID)] = mode
+
+	if seasons, ok := m.Store.GetSeasons(m.currentLibID, showID); ok {
+		top.ReplaceItems(arrangeSeasons(seasons, mode))
+		m.updateInspector()
+	}
+	return m, tea.Batch(
+		m.notify(NoticeInfo, specialsNotice(mode)),
+		SaveSpecialsCmd(showID, mode),
+	)
+}
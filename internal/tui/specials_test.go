@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// Specials and extras sink or hide per show without touching the others
+func TestArrangeSeasons(t *testing.T) {
+	seasons := []*domain.Season{
+		{ID: "s0", SeasonNum: 0},
+		{ID: "s1", SeasonNum: 1},
+		{ID: "s2", SeasonNum: 2},
+		{ID: domain.ExtrasSeasonID("show"), SeasonNum: -1, Extras: true},
+	}
+	ids := func(list []*domain.Season) string {
+		var out []string
+		for _, s := range list {
+			out = append(out, s.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	m := Model{}
+	m.UIConfig.Specials = config.SpecialsSink
+	m.UIConfig.SpecialsByShow = map[string]string{"hidden": config.SpecialsHide}
+
+	if got := ids(m.arrangeShowSeasons("show", seasons)); got != "s1,s2,s0,show:extras" {
+		t.Fatalf("sink = %s", got)
+	}
+	if got := ids(m.arrangeShowSeasons("HIDDEN", seasons)); got != "s1,s2" {
+		t.Fatalf("hide = %s", got)
+	}
+	if got := ids(arrangeSeasons(seasons, config.SpecialsShow)); got != "s0,s1,s2,show:extras" {
+		t.Fatalf("show = %s", got)
+	}
+	if seasons[0].ID != "s0" {
+		t.Fatal("arranging reordered the cached slice")
+	}
+}
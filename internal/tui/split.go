@@ -0,0 +1,158 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// Split view (|) shows two column stacks side by side, each browsing on its
+// own: Movies beside a playlist, or one library in two sorts. Only one pane
+// is focused at a time; its stack and navigation context live in the
+// Model's usual fields, and the other pane's wait in splitView until
+// Ctrl+w swaps them. The inspector is hidden while split, as each pane is
+// only half the width.
+
+// pane is the unfocused half of the split: its stack and the navigation
+// context the handlers read while it is focused
+type pane struct {
+	stack      *ColumnStack
+	libID      string
+	showID     string
+	playlistID string
+}
+
+// splitView is the state of the split layout
+type splitView struct {
+	other      pane // The unfocused pane
+	focusRight bool // Whether the focused pane is the right one
+	inspector  bool // ShowInspector to restore when the split closes
+}
+
+// handleSplit opens the split view with a new pane on the library list, or
+// closes it keeping the focused pane
+func (m Model) handleSplit() (tea.Model, tea.Cmd) {
+	if m.split != nil {
+		m.closeSplit()
+		return m, nil
+	}
+	if m.ColumnStack.Len() == 0 {
+		return m, nil
+	}
+	if m.peeking {
+		m.closePeek()
+	}
+
+	m.split = &splitView{
+		other:      m.currentPane(),
+		focusRight: true,
+		inspector:  m.ShowInspector,
+	}
+	m.ShowInspector = false
+	m.navPlan = nil
+	m.ColumnStack.Top().SetFocused(false)
+
+	libID := ""
+	if lib, ok := m.ColumnStack.Get(0).SelectedItem().(domain.Library); ok {
+		libID = lib.ID
+	}
+	m.ColumnStack = NewColumnStack()
+	m.currentLibID, m.currentShowID, m.currentPlaylistID = "", "", ""
+	m.resetToLibrary(libID)
+	m.updateLayout()
+	return m, m.notifyHint(NoticeInfo, i18n.T("status.split_view"), i18n.T("hint.split_keys"))
+}
+
+// closeSplit drops the unfocused pane and brings the inspector back if it
+// was shown before
+func (m *Model) closeSplit() {
+	m.ShowInspector = m.split.inspector
+	m.split = nil
+	m.updateLayout()
+	m.updateInspector()
+}
+
+// handleSwitchPane moves focus to the other pane (Ctrl+w)
+func (m Model) handleSwitchPane() (tea.Model, tea.Cmd) {
+	if m.split == nil {
+		return m, m.notifyHint(NoticeInfo, i18n.T("status.not_split"), i18n.T("hint.split"))
+	}
+	m.switchPane()
+	return m, nil
+}
+
+// switchPane swaps the focused pane with the other. A deep-link plan in
+// flight belongs to the pane being left and is dropped.
+func (m *Model) switchPane() {
+	if top := m.ColumnStack.Top(); top != nil {
+		top.SetFocused(false)
+	}
+	focused := m.currentPane()
+	other := m.split.other
+	m.ColumnStack = other.stack
+	m.currentLibID, m.currentShowID, m.currentPlaylistID = other.libID, other.showID, other.playlistID
+	m.split.other = focused
+	m.split.focusRight = !m.split.focusRight
+	m.navPlan = nil
+	if top := m.ColumnStack.Top(); top != nil {
+		top.SetFocused(true)
+	}
+	m.updateLayout()
+}
+
+// currentPane captures the focused pane
+func (m Model) currentPane() pane {
+	return pane{
+		stack:      m.ColumnStack,
+		libID:      m.currentLibID,
+		showID:     m.currentShowID,
+		playlistID: m.currentPlaylistID,
+	}
+}
+
+// otherPaneModel is a copy of the model showing the unfocused pane, for
+// laying it out and rendering it with the same code as the focused one
+func (m Model) otherPaneModel() Model {
+	o := m
+	o.ColumnStack = m.split.other.stack
+	return o
+}
+
+// focusedPaneArea returns the left edge and width of the focused pane; the
+// left pane gets the odd column
+func (m Model) focusedPaneArea() (left, width int) {
+	half := m.Width / 2
+	if m.split.focusRight {
+		return m.Width - half, half
+	}
+	return 0, m.Width - half
+}
+
+// allColumns returns every open column, those of the unfocused pane
+// included, for updates that must reach whatever is on screen
+func (m Model) allColumns() []*components.ListColumn {
+	stacks := []*ColumnStack{m.ColumnStack}
+	if m.split != nil {
+		stacks = append(stacks, m.split.other.stack)
+	}
+	var cols []*components.ListColumn
+	for _, cs := range stacks {
+		for i := 0; i < cs.Len(); i++ {
+			cols = append(cols, cs.Get(i))
+		}
+	}
+	return cols
+}
+
+// renderSplit renders both panes side by side
+func (m Model) renderSplit() string {
+	_, width := m.focusedPaneArea()
+	focused := m.renderColumns(width)
+	other := m.otherPaneModel().renderColumns(m.Width - width)
+	if m.split.focusRight {
+		return lipgloss.JoinHorizontal(lipgloss.Top, other, focused)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, focused, other)
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/store"
+)
+
+// | opens a second pane on the library list beside the first, Ctrl+w moves
+// focus between them, loads land in either, and | again keeps the focused
+// pane
+func TestSplitView(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := st.SaveMovies("movies", []*domain.MediaItem{{ID: "m1", Title: "Heat"}, {ID: "m2", Title: "Ronin"}}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{
+		ColumnStack:   NewColumnStack(),
+		Store:         st,
+		State:         StateBrowsing,
+		Width:         120,
+		Height:        20,
+		ShowInspector: true,
+		Libraries:     []domain.Library{{ID: "movies", Name: "Movies", Type: "movie"}, {ID: "tv", Name: "TV", Type: "show"}},
+	}
+	m.restoreSession(&config.Session{LibraryID: "movies", Columns: []config.ColumnSession{{SelectedID: "m2"}}})
+	movies := m.ColumnStack.Top()
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("|")})
+	m = updated.(Model)
+	if m.split == nil || m.ColumnStack.Len() != 1 || m.split.other.stack.Len() != 2 || m.ShowInspector {
+		t.Fatalf("split did not open a new pane beside the first (stack %d)", m.ColumnStack.Len())
+	}
+	if movies.IsFocused() || !m.ColumnStack.Top().IsFocused() {
+		t.Fatal("focus did not move to the new pane")
+	}
+	if view := m.renderSplit(); !strings.Contains(view, "Ronin") {
+		t.Fatalf("unfocused pane not rendered:\n%s", view)
+	}
+
+	// A load for the unfocused pane's column still lands there
+	movies.SetLoading(true)
+	m.Update(MoviesLoadedMsg{LibraryID: "movies", Movies: []*domain.MediaItem{{ID: "m3", Title: "Thief"}}})
+	if movies.IsLoading() || movies.ItemCount() != 1 {
+		t.Fatal("load for the unfocused pane was dropped")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlW})
+	m = updated.(Model)
+	if m.ColumnStack.Top() != movies || !movies.IsFocused() || m.split.focusRight {
+		t.Fatal("ctrl+w did not focus the left pane")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("|")})
+	m = updated.(Model)
+	if m.split != nil || m.ColumnStack.Top() != movies || !m.ShowInspector {
+		t.Fatal("closing the split did not keep the focused pane and the inspector")
+	}
+}
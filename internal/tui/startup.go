@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// Startup sync modes (config.StartupSync*): eager checks every library
+// and syncs the changed ones; lazy shows the cache and syncs a library the
+// first time it is opened; cache-only shows the cache and leaves the
+// server alone, no syncs, pings, activity polls or notifications, until
+// refresh-all (R). Content never cached is fetched when opened in every
+// mode. A refresh-all always syncs eagerly.
+
+// SetStartupSync sets what startup does with the libraries; empty or
+// unknown behaves as eager
+func (m *Model) SetStartupSync(mode string) {
+	m.startupSync = mode
+	m.quiet = mode == config.StartupSyncCacheOnly
+}
+
+// LoadCachedLibrariesCmd lists the libraries from the cache. A first run
+// has none cached and asks the server after all.
+func LoadCachedLibrariesCmd(store domain.Store, svc *library.Service) tea.Cmd {
+	libraries, ok := store.GetLibraries()
+	if !ok || len(libraries) == 0 {
+		return LoadLibrariesCmd(svc)
+	}
+	return func() tea.Msg {
+		return LibrariesLoadedMsg{Libraries: libraries}
+	}
+}
+
+// serverWatchCmds start the background checks on the server: Live TV
+// detection, health pings, activity polls and change notifications
+func (m Model) serverWatchCmds() tea.Cmd {
+	return tea.Batch(
+		DetectLiveTVCmd(m.LibraryService),
+		HealthCheckCmd(m.LibraryService, m.health.gen),
+		m.initActivitiesCmd(),
+		WatchUpdatesCmd(m.LibraryService, m.live.gen),
+	)
+}
+
+// beginStartupSyncs starts the syncs for freshly loaded libraries as the
+// startup mode asks. Lazy and cache-only modes show what is cached as
+// synced from disk without checking it; a refresh-all syncs eagerly and
+// ends cache-only's quiet.
+func (m *Model) beginStartupSyncs(libs []domain.Library, refresh bool) []tea.Cmd {
+	var cmds []tea.Cmd
+	if refresh && m.quiet {
+		m.quiet = false
+		cmds = append(cmds, m.serverWatchCmds())
+	}
+	m.lazySynced = nil
+	if !refresh && (m.startupSync == config.StartupSyncLazy || m.quiet) {
+		for _, lib := range libs {
+			if count, unwatched, ok := m.LibraryService.CachedCount(lib); ok {
+				m.LibraryStates[lib.ID] = components.LibrarySyncState{
+					Status: components.StatusSynced, Loaded: count, Total: count, FromCache: true,
+					Unwatched: unwatched, HasUnwatched: true,
+				}
+				cmds = append(cmds, ClearLibraryStatusCmd(lib.ID, 2*time.Second))
+			}
+		}
+		return cmds
+	}
+
+	cmds = append(cmds, m.beginLibrarySyncs(libs)...)
+	m.LibraryStates[playlistsLibraryID] = components.LibrarySyncState{Status: components.StatusSyncing}
+	return append(cmds, m.startPlaylistSyncJob())
+}
+
+// syncOnOpen syncs a library opened for the first time in lazy mode: a
+// count check when its cache timestamp is current, else a sync. A library
+// with nothing cached needs neither; opening it fetches it.
+func (m *Model) syncOnOpen(lib domain.Library) tea.Cmd {
+	if m.startupSync != config.StartupSyncLazy || m.lazySynced[lib.ID] {
+		return nil
+	}
+	if m.lazySynced == nil {
+		m.lazySynced = make(map[string]bool)
+	}
+	m.lazySynced[lib.ID] = true
+	if _, _, ok := m.LibraryService.CachedCount(lib); ok {
+		return CheckFreshnessCmd(m.LibraryService, []domain.Library{lib}, m.SyncGen)
+	}
+	if _, _, ok := m.Store.GetContentPage(lib.ID, 0, 1); ok {
+		cmd := m.queueSync(lib, false)
+		m.updateLibraryStates()
+		return cmd
+	}
+	return nil
+}
+
+// showSynced refreshes an open library's column from the cache once a
+// background sync, lazy or auto-refresh, has fetched new content
+func (m *Model) showSynced(libID string) {
+	col, lib := m.loadTarget(libID), m.findLibrary(libID)
+	if col == nil || lib == nil {
+		return
+	}
+	switch lib.Type {
+	case "movie":
+		if movies, ok := m.Store.GetMovies(libID); ok {
+			col.ReplaceItems(movies)
+		}
+	case "show":
+		if shows, ok := m.Store.GetShows(libID); ok {
+			col.ReplaceItems(shows)
+		}
+	default:
+		if items, ok := m.Store.GetMixedContent(libID); ok {
+			col.ReplaceItems(items)
+		}
+	}
+	m.updateInspector()
+}
@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/mmcdole/kino/internal/config"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// Lazy and cache-only startups show the cache without syncing; lazy syncs
+// a library when it is first opened, and a refresh-all ends cache-only's
+// quiet with a full sync
+func TestStartupSyncModes(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	libs := []domain.Library{{ID: "a", Type: "movie", UpdatedAt: 100}, {ID: "b", Type: "movie", UpdatedAt: 100}}
+	_ = st.SaveLibraries(libs)
+	_ = st.SaveMovies("a", []*domain.MediaItem{{ID: "m1", Title: "Heat", Type: domain.MediaTypeMovie}}, 100)
+	newModel := func(mode string) Model {
+		m := Model{ColumnStack: NewColumnStack(), LibraryStates: map[string]components.LibrarySyncState{}, jobs: NewJobs(),
+			Store: st, LibraryService: library.NewService(nil, st, nil)}
+		m.SetStartupSync(mode)
+		m.resetSyncQueue()
+		return m
+	}
+
+	m := newModel(config.StartupSyncLazy)
+	m.beginStartupSyncs(libs, false)
+	if m.jobs.Active() != 0 || !m.LibraryStates["a"].FromCache || m.LibraryStates["b"].Status != components.StatusIdle {
+		t.Fatalf("lazy startup: %d jobs, states %+v", m.jobs.Active(), m.LibraryStates)
+	}
+	if m.syncOnOpen(libs[0]) == nil || m.syncOnOpen(libs[0]) != nil {
+		t.Error("opening a cached library should check it once")
+	}
+	if m.syncOnOpen(libs[1]) != nil {
+		t.Error("an uncached library is fetched by opening it, not synced")
+	}
+
+	m = newModel(config.StartupSyncCacheOnly)
+	msg := LoadCachedLibrariesCmd(st, m.LibraryService)()
+	if loaded, ok := msg.(LibrariesLoadedMsg); !ok || len(loaded.Libraries) != 2 {
+		t.Fatalf("cached libraries = %+v", msg)
+	}
+	m.beginStartupSyncs(libs, false)
+	if m.jobs.Active() != 0 || m.syncOnOpen(libs[0]) != nil {
+		t.Fatal("cache-only startup went to the server")
+	}
+	m.beginStartupSyncs(libs, true)
+	if m.quiet || m.jobs.Active() == 0 {
+		t.Errorf("refresh-all: quiet %v, %d jobs", m.quiet, m.jobs.Active())
+	}
+}
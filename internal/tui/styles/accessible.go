@@ -0,0 +1,54 @@
+package styles
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// accessible is set by EnableAccessible
+var accessible bool
+
+// asciiGlyphs maps the glyphs the interface draws to ASCII of the same
+// width, so a rendered screen can be converted without reflowing it
+var asciiGlyphs = strings.NewReplacer(
+	// Watch status, marks and ratings
+	"✓", "+", "✗", "x", "●", "*", "◐", "~", "○", "o", "•", "*",
+	"★", "*", "☆", ".", "▌", "|", "█", "#", "▶", ">", "▼", "v", "◉", "@",
+	// Arrows and separators
+	"↑", "^", "↓", "v", "←", "<", "→", ">", "›", ">", "⇄", "=", "⟳", "@", "↻", "@",
+	"·", "-", "—", "-", "…", ".", "🔍", "/ ",
+	// Borders and rules
+	"╭", "+", "╮", "+", "╰", "+", "╯", "+", "│", "|", "─", "-", "━", "=",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+",
+	// Spinner
+	"⠋", "|", "⠙", "/", "⠹", "-", "⠸", "\\", "⠼", "|", "⠴", "/", "⠦", "-", "⠧", "\\", "⠇", "|", "⠏", "/",
+)
+
+// EnableAccessible switches to ASCII glyphs and a high-contrast palette,
+// for fonts, terminals and screen readers that handle the defaults poorly.
+// Call it before anything renders.
+func EnableAccessible() {
+	accessible = true
+
+	PlexOrange = lipgloss.Color("#FFD700")
+	SlateDark = lipgloss.Color("#000000")
+	SlateLight = lipgloss.Color("#00005F")
+	DimGray = lipgloss.Color("#D0D0D0")
+	LightGray = lipgloss.Color("#FFFFFF")
+	White = lipgloss.Color("#FFFFFF")
+	Green = lipgloss.Color("#00FF00")
+	Red = lipgloss.Color("#FF5F5F")
+	buildStyles()
+
+	SpinnerFrames = []string{"|", "/", "-", "\\"}
+}
+
+// ASCII replaces the glyphs in rendered output with ASCII when the
+// accessible mode is on, and returns s unchanged otherwise
+func ASCII(s string) string {
+	if !accessible {
+		return s
+	}
+	return asciiGlyphs.Replace(s)
+}
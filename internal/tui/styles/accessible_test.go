@@ -0,0 +1,29 @@
+package styles
+
+import (
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestASCII(t *testing.T) {
+	line := "╭─╮ ✓ Heat · 1995 ↓ more ⠋"
+	if got := ASCII(line); got != line {
+		t.Fatalf("ASCII changed output outside the accessible mode: %q", got)
+	}
+
+	EnableAccessible()
+	got := ASCII(line)
+	if got != "+-+ + Heat - 1995 v more |" {
+		t.Errorf("ASCII = %q", got)
+	}
+	// Replacing in rendered output must not reflow it
+	if runewidth.StringWidth(got) != runewidth.StringWidth(line) {
+		t.Errorf("width changed from %d to %d", runewidth.StringWidth(line), runewidth.StringWidth(got))
+	}
+	for _, r := range got {
+		if r > 127 {
+			t.Errorf("non-ASCII %q left in %q", r, got)
+		}
+	}
+}
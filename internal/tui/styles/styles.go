@@ -19,41 +19,23 @@ var (
 
 // Borders
 var (
-	ActiveBorder = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(PlexOrange)
-
-	InactiveBorder = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(DimGray)
+	ActiveBorder   lipgloss.Style
+	InactiveBorder lipgloss.Style
 )
 
 // Text styles
 var (
-	TitleStyle = lipgloss.NewStyle().
-			Foreground(White).
-			Bold(true)
-
-	SubtitleStyle = lipgloss.NewStyle().
-			Foreground(LightGray)
-
-	DimStyle = lipgloss.NewStyle().
-			Foreground(DimGray)
-
-	AccentStyle = lipgloss.NewStyle().
-			Foreground(PlexOrange)
-
-	ErrorStyle = lipgloss.NewStyle().
-			Foreground(Red)
+	TitleStyle    lipgloss.Style
+	SubtitleStyle lipgloss.Style
+	DimStyle      lipgloss.Style
+	AccentStyle   lipgloss.Style
+	ErrorStyle    lipgloss.Style
 
 	// AlertStyle marks persistent, actionable notices (bold to stand apart
 	// from transient errors)
-	AlertStyle = lipgloss.NewStyle().
-			Foreground(Red).
-			Bold(true)
+	AlertStyle lipgloss.Style
 
-	SuccessStyle = lipgloss.NewStyle().
-			Foreground(Green)
+	SuccessStyle lipgloss.Style
 )
 
 // Raw watch status characters (unstyled)
@@ -61,53 +43,36 @@ const (
 	UnplayedChar   = "●"
 	InProgressChar = "◐"
 	PlayedChar     = "✓"
+	MarkChar       = "▌" // multi-select mark
 )
 
 // Watch status indicator styles
 var (
-	UnplayedStyle   = lipgloss.NewStyle().Foreground(PlexOrange)
-	InProgressStyle = lipgloss.NewStyle().Foreground(PlexOrange)
-	PlayedStyle     = lipgloss.NewStyle().Foreground(Green)
+	UnplayedStyle   lipgloss.Style
+	InProgressStyle lipgloss.Style
+	PlayedStyle     lipgloss.Style
 )
 
 // List item styles
 var (
-	SelectedItemStyle = lipgloss.NewStyle().
-				Foreground(White).
-				Background(SlateLight).
-				Padding(0, 1)
-
-	NormalItemStyle = lipgloss.NewStyle().
-			Foreground(LightGray).
-			Padding(0, 1)
+	SelectedItemStyle lipgloss.Style
+	NormalItemStyle   lipgloss.Style
 )
 
 // Modal styles
 var (
-	ModalStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(PlexOrange).
-			Padding(1, 2).
-			Background(SlateDark)
-
-	ModalTitleStyle = lipgloss.NewStyle().
-			Foreground(White).
-			Bold(true).
-			MarginBottom(1)
+	ModalStyle      lipgloss.Style
+	ModalTitleStyle lipgloss.Style
 )
 
 // Badge styles
 var (
-	DimBadgeStyle = lipgloss.NewStyle().
-		Foreground(LightGray).
-		Background(SlateLight).
-		Padding(0, 1)
+	DimBadgeStyle lipgloss.Style
 )
 
 // Spinner style
 var (
-	SpinnerStyle = lipgloss.NewStyle().
-		Foreground(PlexOrange)
+	SpinnerStyle lipgloss.Style
 )
 
 // SpinnerFrames contains the animation frames for the loading spinner
@@ -115,13 +80,77 @@ var SpinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "
 
 // Filter styles
 var (
-	FilterStyle = lipgloss.NewStyle().
-			Foreground(PlexOrange)
+	FilterStyle       lipgloss.Style
+	FilterPromptStyle lipgloss.Style
+)
+
+func init() {
+	buildStyles()
+}
+
+// buildStyles derives the styles from the palette
+func buildStyles() {
+	border := lipgloss.RoundedBorder()
+	ActiveBorder = lipgloss.NewStyle().
+		Border(border).
+		BorderForeground(PlexOrange)
+	InactiveBorder = lipgloss.NewStyle().
+		Border(border).
+		BorderForeground(DimGray)
 
+	TitleStyle = lipgloss.NewStyle().
+		Foreground(White).
+		Bold(true)
+	SubtitleStyle = lipgloss.NewStyle().
+		Foreground(LightGray)
+	DimStyle = lipgloss.NewStyle().
+		Foreground(DimGray)
+	AccentStyle = lipgloss.NewStyle().
+		Foreground(PlexOrange)
+	ErrorStyle = lipgloss.NewStyle().
+		Foreground(Red)
+	AlertStyle = lipgloss.NewStyle().
+		Foreground(Red).
+		Bold(true)
+	SuccessStyle = lipgloss.NewStyle().
+		Foreground(Green)
+
+	UnplayedStyle = lipgloss.NewStyle().Foreground(PlexOrange)
+	InProgressStyle = lipgloss.NewStyle().Foreground(PlexOrange)
+	PlayedStyle = lipgloss.NewStyle().Foreground(Green)
+
+	SelectedItemStyle = lipgloss.NewStyle().
+		Foreground(White).
+		Background(SlateLight).
+		Padding(0, 1)
+	NormalItemStyle = lipgloss.NewStyle().
+		Foreground(LightGray).
+		Padding(0, 1)
+
+	ModalStyle = lipgloss.NewStyle().
+		Border(border).
+		BorderForeground(PlexOrange).
+		Padding(1, 2).
+		Background(SlateDark)
+	ModalTitleStyle = lipgloss.NewStyle().
+		Foreground(White).
+		Bold(true).
+		MarginBottom(1)
+
+	DimBadgeStyle = lipgloss.NewStyle().
+		Foreground(LightGray).
+		Background(SlateLight).
+		Padding(0, 1)
+
+	SpinnerStyle = lipgloss.NewStyle().
+		Foreground(PlexOrange)
+
+	FilterStyle = lipgloss.NewStyle().
+		Foreground(PlexOrange)
 	FilterPromptStyle = lipgloss.NewStyle().
-				Foreground(PlexOrange).
-				Bold(true)
-)
+		Foreground(PlexOrange).
+		Bold(true)
+}
 
 // Helper functions
 
@@ -0,0 +1,172 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// queuedSync is a library sync waiting for a free slot
+type queuedSync struct {
+	lib     domain.Library
+	refetch bool
+}
+
+// SetSyncConcurrency limits how many library syncs run at once; the rest
+// wait in a queue. 0 runs every sync immediately.
+func (m *Model) SetSyncConcurrency(n int) {
+	m.syncLimit = max(n, 0)
+}
+
+// resetSyncQueue forgets queued and running syncs. Called when a library
+// reload starts a new sync generation and cancels the old chains.
+func (m *Model) resetSyncQueue() {
+	m.syncQueue = nil
+	m.syncRunning = make(map[int]string)
+}
+
+// queueSync schedules a library sync: it starts now if a slot is free and
+// otherwise waits, shown as queued in the library column
+func (m *Model) queueSync(lib domain.Library, refetch bool) tea.Cmd {
+	for i, q := range m.syncQueue {
+		if q.lib.ID == lib.ID {
+			m.syncQueue[i].refetch = q.refetch || refetch
+			return nil
+		}
+	}
+	m.LibraryStates[lib.ID] = components.LibrarySyncState{Status: components.StatusQueued}
+	m.syncQueue = append(m.syncQueue, queuedSync{lib: lib, refetch: refetch})
+	return m.drainSyncQueue()
+}
+
+// drainSyncQueue starts queued syncs, front first, while slots are free
+func (m *Model) drainSyncQueue() tea.Cmd {
+	var cmds []tea.Cmd
+	for len(m.syncQueue) > 0 && (m.syncLimit == 0 || len(m.syncRunning) < m.syncLimit) {
+		next := m.syncQueue[0]
+		m.syncQueue = m.syncQueue[1:]
+		m.LibraryStates[next.lib.ID] = components.LibrarySyncState{Status: components.StatusSyncing}
+		cmds = append(cmds, m.startSyncJob(next.lib, next.refetch))
+	}
+	return tea.Batch(cmds...)
+}
+
+// finishSync frees the slot of a finished, failed or cancelled sync and
+// starts the next queued one
+func (m *Model) finishSync(jobID int) tea.Cmd {
+	if jobID == m.playlistJob {
+		m.playlistJob = 0
+	}
+	if _, ok := m.syncRunning[jobID]; !ok {
+		return nil
+	}
+	delete(m.syncRunning, jobID)
+	return m.drainSyncQueue()
+}
+
+// syncPending reports whether a library has a sync running or queued
+func (m *Model) syncPending(libID string) bool {
+	if libID == playlistsLibraryID {
+		return m.playlistJob != 0
+	}
+	for _, id := range m.syncRunning {
+		if id == libID {
+			return true
+		}
+	}
+	for _, q := range m.syncQueue {
+		if q.lib.ID == libID {
+			return true
+		}
+	}
+	return false
+}
+
+// cancelLibrarySync stops a library's running sync and drops it from the
+// queue. Its slot is free at once; callers drain the queue when done. The
+// cancelled chain's last message still arrives and is dropped (see
+// LibrarySyncProgressMsg).
+func (m *Model) cancelLibrarySync(libID string) {
+	for i, q := range m.syncQueue {
+		if q.lib.ID == libID {
+			m.syncQueue = append(m.syncQueue[:i], m.syncQueue[i+1:]...)
+			break
+		}
+	}
+	for jobID, id := range m.syncRunning {
+		if id == libID {
+			m.jobs.Cancel(jobID)
+			delete(m.syncRunning, jobID)
+		}
+	}
+	if state, ok := m.LibraryStates[libID]; ok && (state.Status == components.StatusSyncing || state.Status == components.StatusQueued) {
+		state.Status = components.StatusIdle
+		m.LibraryStates[libID] = state
+	}
+}
+
+// restartSync cancels a library's sync, if any, and runs a fresh one ahead
+// of everything waiting (manual refresh)
+func (m *Model) restartSync(lib domain.Library) tea.Cmd {
+	m.cancelLibrarySync(lib.ID)
+	m.LibraryStates[lib.ID] = components.LibrarySyncState{Status: components.StatusQueued}
+	m.syncQueue = append([]queuedSync{{lib: lib}}, m.syncQueue...)
+	return m.drainSyncQueue()
+}
+
+// restartPlaylistSync cancels the playlist sync, if any, and runs a fresh
+// one against an emptied cache (manual refresh of the Playlists row)
+func (m *Model) restartPlaylistSync() tea.Cmd {
+	if m.playlistJob != 0 {
+		m.jobs.Cancel(m.playlistJob)
+	}
+	m.PlaylistService.InvalidatePlaylists()
+	m.LibraryStates[playlistsLibraryID] = components.LibrarySyncState{Status: components.StatusSyncing}
+	return m.startPlaylistSyncJob()
+}
+
+// prioritizeSync moves a queued library to the front of the queue
+func (m *Model) prioritizeSync(libID string) {
+	for i, q := range m.syncQueue {
+		if q.lib.ID == libID {
+			if i > 0 {
+				copy(m.syncQueue[1:i+1], m.syncQueue[:i])
+				m.syncQueue[0] = q
+			}
+			return
+		}
+	}
+}
+
+// prioritizeSelectedSync lets the library the user is looking at jump the
+// queue: the one under the cursor in the library list, or the one they are
+// inside
+func (m *Model) prioritizeSelectedSync() {
+	if len(m.syncQueue) == 0 {
+		return
+	}
+	if m.ColumnStack.Len() == 1 {
+		if lib := m.ColumnStack.Top().SelectedLibrary(); lib != nil {
+			m.prioritizeSync(lib.ID)
+		}
+		return
+	}
+	m.prioritizeSync(m.currentLibID)
+}
+
+// libraryFirst returns libs with the library id moved to the front, so the
+// library a restored session or deep link opens into syncs first
+func libraryFirst(libs []domain.Library, id string) []domain.Library {
+	ordered := make([]domain.Library, 0, len(libs))
+	for _, lib := range libs {
+		if lib.ID == id {
+			ordered = append(ordered, lib)
+		}
+	}
+	for _, lib := range libs {
+		if lib.ID != id {
+			ordered = append(ordered, lib)
+		}
+	}
+	return ordered
+}
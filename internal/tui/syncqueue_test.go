@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/playlist"
+	"github.com/mmcdole/kino/internal/store"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// Syncs beyond the limit wait their turn, and the library the user is on
+// jumps the queue when a slot frees up
+func TestSyncQueueLimitAndPriority(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack(), LibraryStates: map[string]components.LibrarySyncState{}, jobs: NewJobs()}
+	m.SetSyncConcurrency(1)
+	m.resetSyncQueue()
+
+	for _, id := range []string{"a", "b", "c"} {
+		m.queueSync(domain.Library{ID: id, Name: id}, false)
+	}
+	if m.LibraryStates["a"].Status != components.StatusSyncing ||
+		m.LibraryStates["b"].Status != components.StatusQueued || m.LibraryStates["c"].Status != components.StatusQueued {
+		t.Fatalf("states = %+v", m.LibraryStates)
+	}
+
+	m.currentLibID = "c"
+	m.ColumnStack.Push(components.NewLibraryColumn(nil), 0)
+	m.ColumnStack.Push(components.NewListColumn(components.ColumnTypeMovies, "c"), 0)
+	m.prioritizeSelectedSync()
+
+	running := m.jobs.List()[0]
+	m.jobs.Finish(running.ID, nil)
+	if cmd := m.finishSync(running.ID); cmd == nil {
+		t.Fatal("freed slot started nothing")
+	}
+	if m.LibraryStates["c"].Status != components.StatusSyncing || m.LibraryStates["b"].Status != components.StatusQueued {
+		t.Fatalf("prioritized library not started: %+v", m.LibraryStates)
+	}
+}
+
+// Refreshing a library mid-sync cancels the superseded chain without its
+// late message clobbering the new sync's row; quitting cancels everything
+func TestRefreshSupersedesRunningSync(t *testing.T) {
+	m := Model{ColumnStack: NewColumnStack(), LibraryStates: map[string]components.LibrarySyncState{}, jobs: NewJobs()}
+	m.SetSyncConcurrency(1)
+	m.resetSyncQueue()
+	lib := domain.Library{ID: "a", Name: "Movies"}
+
+	m.queueSync(lib, false)
+	old := m.jobs.List()[0].ID
+	m.restartSync(lib)
+	if !m.jobs.Cancelled(old) {
+		t.Fatal("superseded sync not cancelled")
+	}
+	if m.jobs.Active() != 1 || m.LibraryStates["a"].Status != components.StatusSyncing {
+		t.Fatalf("restart: active = %d, state = %+v", m.jobs.Active(), m.LibraryStates["a"])
+	}
+
+	updated, _ := m.Update(LibrarySyncProgressMsg{LibraryID: "a", JobID: old, Generation: m.SyncGen, Loaded: 5, Total: 10})
+	m = updated.(Model)
+	if m.LibraryStates["a"].Status != components.StatusSyncing {
+		t.Fatalf("late cancelled message reset the row: %+v", m.LibraryStates["a"])
+	}
+
+	updated, cmd := m.handleQuit()
+	m = updated.(Model)
+	if m.jobs.Active() != 0 || cmd == nil {
+		t.Fatalf("quit left %d jobs running", m.jobs.Active())
+	}
+}
+
+// r on the Playlists row restarts their sync like a library's: the
+// superseded run settles quietly and the new one reports the count
+func TestRefreshPlaylistsRow(t *testing.T) {
+	st, err := store.NewLibraryStore("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := Model{
+		ColumnStack:     NewColumnStack(),
+		Store:           st,
+		PlaylistService: playlist.NewService(nil, st, nil),
+		LibraryStates:   map[string]components.LibrarySyncState{},
+		jobs:            NewJobs(),
+	}
+	m.resetSyncQueue()
+	m.ColumnStack.Reset(components.NewLibraryColumn(m.allLibraryEntries()))
+	m.libraryColumn().SetSelectedByID(playlistsLibraryID)
+
+	refresh := func() int {
+		t.Helper()
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		m = updated.(Model)
+		if cmd == nil || m.LibraryStates[playlistsLibraryID].Status != components.StatusSyncing {
+			t.Fatalf("refresh did not start a sync: %+v", m.LibraryStates[playlistsLibraryID])
+		}
+		return m.playlistJob
+	}
+	first := refresh()
+	second := refresh()
+	if first == second || !m.jobs.Cancelled(first) {
+		t.Fatalf("jobs %d, %d: the first was not cancelled", first, second)
+	}
+
+	updated, _ := m.Update(LibrarySyncProgressMsg{LibraryID: playlistsLibraryID, JobID: first, Done: true})
+	m = updated.(Model)
+	if m.LibraryStates[playlistsLibraryID].Status != components.StatusSyncing {
+		t.Fatalf("cancelled run settled the row: %+v", m.LibraryStates[playlistsLibraryID])
+	}
+	updated, _ = m.Update(LibrarySyncProgressMsg{LibraryID: playlistsLibraryID, JobID: second, Loaded: 3, Total: 3, Done: true})
+	m = updated.(Model)
+	if state := m.LibraryStates[playlistsLibraryID]; state.Status != components.StatusSynced || state.Loaded != 3 || m.playlistJob != 0 {
+		t.Fatalf("after sync: %+v, job %d", state, m.playlistJob)
+	}
+}
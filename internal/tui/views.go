@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/player"
 	"github.com/mmcdole/kino/internal/tui/components"
 	"github.com/mmcdole/kino/internal/tui/styles"
 )
@@ -14,17 +16,34 @@ func RenderSpinner(frame int) string {
 	return styles.SpinnerStyle.Render(styles.SpinnerFrames[frame%len(styles.SpinnerFrames)])
 }
 
-// View renders the application
+// View renders the application, in ASCII in the accessible mode
 func (m Model) View() string {
+	return styles.ASCII(m.render())
+}
+
+// render renders the screen for the current state
+func (m Model) render() string {
 	if !m.Ready {
 		return "Loading..."
 	}
 
 	// Handle modal states
+	if m.State == StateLocked {
+		return m.renderLockScreen()
+	}
+
+	if m.State == StateAuthRequired {
+		return m.renderAuthPrompt()
+	}
+
 	if m.State == StateHelp {
 		return m.renderHelp()
 	}
 
+	if m.State == StateKeyConflicts {
+		return m.renderKeyConflicts()
+	}
+
 	if m.State == StateConfirmLogout {
 		return m.renderLogoutConfirmation()
 	}
@@ -33,51 +52,31 @@ func (m Model) View() string {
 		return m.renderDeletePlaylistConfirmation()
 	}
 
-	contentHeight := m.Height - ChromeHeight
-	stackLen := m.ColumnStack.Len()
-	layout := m.calculateColumnLayout(m.Width)
-
-	var content string
-
-	if stackLen == 0 {
-		content = ""
-	} else {
-		topIdx := stackLen - 1
-		currentCol := m.ColumnStack.Get(topIdx)
-
-		// Build columns list based on what's visible
-		var columnViews []string
-
-		// Add grandparent column if visible (3+ columns, inspector hidden)
-		if layout.grandparentWidth > 0 {
-			grandparentCol := m.ColumnStack.Get(topIdx - 2)
-			grandparentCol.SetSize(layout.grandparentWidth, contentHeight)
-			columnViews = append(columnViews, grandparentCol.View())
-		}
-
-		// Add parent column if visible (2+ columns)
-		if layout.parentWidth > 0 {
-			parentCol := m.ColumnStack.Get(topIdx - 1)
-			parentCol.SetSize(layout.parentWidth, contentHeight)
-			columnViews = append(columnViews, parentCol.View())
-		}
-
-		// Active column is always visible
-		currentCol.SetSize(layout.activeWidth, contentHeight)
-		columnViews = append(columnViews, currentCol.View())
+	if m.State == StateConfirmDeleteItem {
+		return m.renderDeleteItemConfirmation()
+	}
 
-		// Add inspector if visible
-		if layout.inspectorWidth > 0 {
-			m.Inspector.SetSize(layout.inspectorWidth, contentHeight)
-			m.Inspector.SetItem(currentCol.SelectedItem())
-			columnViews = append(columnViews, m.Inspector.View())
-		}
+	if m.State == StateConfirmNextEpisode {
+		return m.renderNextEpisodeConfirmation()
+	}
 
-		content = lipgloss.JoinHorizontal(lipgloss.Top, columnViews...)
+	var content string
+	switch {
+	case m.detail != nil:
+		content = m.renderDetailView()
+	case m.home != nil:
+		content = m.renderHome()
+	case m.split != nil:
+		content = m.renderSplit()
+	default:
+		content = m.renderColumns(m.Width)
 	}
 
 	// Footer
 	footer := m.renderFooter()
+	if m.cmdOpen {
+		footer = m.renderCommandLine()
+	}
 
 	// Combine all
 	view := lipgloss.JoinVertical(
@@ -100,6 +99,27 @@ func (m Model) View() string {
 			m.SortModal.View())
 	}
 
+	// Overlay resume prompt if visible
+	if m.ResumeModal.IsVisible() {
+		view = lipgloss.Place(m.Width, m.Height,
+			lipgloss.Center, lipgloss.Center,
+			m.ResumeModal.View())
+	}
+
+	// Overlay version picker if visible
+	if m.VersionModal.IsVisible() {
+		view = lipgloss.Place(m.Width, m.Height,
+			lipgloss.Center, lipgloss.Center,
+			m.VersionModal.View())
+	}
+
+	// Overlay rating picker if visible
+	if m.RatingModal.IsVisible() {
+		view = lipgloss.Place(m.Width, m.Height,
+			lipgloss.Center, lipgloss.Center,
+			m.RatingModal.View())
+	}
+
 	// Overlay playlist modal if visible
 	if m.PlaylistModal.IsVisible() {
 		view = lipgloss.Place(m.Width, m.Height,
@@ -114,9 +134,84 @@ func (m Model) View() string {
 			m.InputModal.View())
 	}
 
+	// Overlay playlist editor if visible
+	if m.PlaylistEditModal.IsVisible() {
+		view = lipgloss.Place(m.Width, m.Height,
+			lipgloss.Center, lipgloss.Center,
+			m.PlaylistEditModal.View())
+	}
+
+	// Overlay library visibility list if visible
+	if m.LibraryModal.IsVisible() {
+		view = lipgloss.Place(m.Width, m.Height,
+			lipgloss.Center, lipgloss.Center,
+			m.LibraryModal.View())
+	}
+
+	// Overlay jobs panel if open
+	if m.jobsPanelOpen {
+		view = m.renderJobsPanel()
+	}
+	if m.activitiesOpen {
+		view = m.renderActivitiesPanel()
+	}
+	if m.menu != nil {
+		view = m.renderContextMenu()
+	}
+	if m.debugOpen {
+		view = m.renderDebugOverlay()
+	}
+
 	return view
 }
 
+// renderColumns lays out the column stack, and the inspector when shown,
+// across width
+func (m Model) renderColumns(width int) string {
+	contentHeight := m.Height - ChromeHeight
+	cols := m.displayColumns()
+	stackLen := len(cols)
+	if stackLen == 0 {
+		return ""
+	}
+	layout := m.calculateColumnLayout(width)
+
+	// The last displayed column is the top of the stack, or the forward
+	// column to its right
+	topIdx := stackLen - 1
+	currentCol := cols[topIdx]
+
+	// Build columns list based on what's visible
+	var columnViews []string
+
+	// Add grandparent column if visible (3+ columns, inspector hidden)
+	if layout.grandparentWidth > 0 {
+		grandparentCol := cols[topIdx-2]
+		grandparentCol.SetSize(layout.grandparentWidth, contentHeight)
+		columnViews = append(columnViews, grandparentCol.View())
+	}
+
+	// Add parent column if visible (2+ columns)
+	if layout.parentWidth > 0 {
+		parentCol := cols[topIdx-1]
+		parentCol.SetSize(layout.parentWidth, contentHeight)
+		columnViews = append(columnViews, parentCol.View())
+	}
+
+	// Active column is always visible
+	currentCol.SetSize(layout.activeWidth, contentHeight)
+	columnViews = append(columnViews, currentCol.View())
+
+	// Add inspector if visible
+	if layout.inspectorWidth > 0 {
+		m.Inspector.SetSize(layout.inspectorWidth, contentHeight)
+		m.Inspector.SetItem(currentCol.SelectedItem())
+		columnViews = append(columnViews, m.Inspector.View())
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, columnViews...)
+}
+
 // renderFooter renders a single-line minimal footer.
 //
 // Feedback scope rules (see docs/design-review.md and notice.go):
@@ -124,21 +219,30 @@ func (m Model) View() string {
 //     carries a compact activity segment on the right, never full-width text.
 //   - Column-scoped work (loads, refreshes, failures) renders in the column.
 //   - The footer's left side is exclusively the notification slot: transient
-//     events and persistent alerts.
+//     events and persistent alerts. With none showing, it carries the
+//     now-playing strip while a watched player runs, or else the device
+//     playback was sent to.
 func (m Model) renderFooter() string {
 	// Left side: current notification, styled by kind
 	var left string
 	if m.notice.Text != "" {
 		switch m.notice.Kind {
 		case NoticeAlert:
-			left = styles.AlertStyle.Render(m.notice.Text) + styles.DimStyle.Render("  esc to dismiss")
+			left = styles.AlertStyle.Render(m.notice.Text) + styles.DimStyle.Render("  "+i18n.T("footer.dismiss"))
 		case NoticeError:
 			left = styles.ErrorStyle.Render(m.notice.Text)
+			if m.notice.Hint != "" {
+				left += styles.DimStyle.Render(" · " + m.notice.Hint)
+			}
 		case NoticeSuccess:
 			left = styles.SuccessStyle.Render(m.notice.Text)
 		default:
 			left = styles.DimStyle.Render(m.notice.Text)
 		}
+	} else if m.nowPlaying != nil {
+		left = m.renderNowPlaying()
+	} else {
+		left = m.renderCasting()
 	}
 
 	// Center section: context-specific hints based on column type
@@ -146,16 +250,39 @@ func (m Model) renderFooter() string {
 	if top := m.ColumnStack.Top(); top != nil {
 		switch top.ColumnType() {
 		case components.ColumnTypePlaylists:
-			center = styles.AccentStyle.Render("x") + styles.DimStyle.Render(" Delete")
+			center = styles.AccentStyle.Render("x") + styles.DimStyle.Render(" "+i18n.T("footer.delete"))
 		case components.ColumnTypePlaylistItems:
-			center = styles.AccentStyle.Render("x") + styles.DimStyle.Render(" Remove")
+			center = styles.AccentStyle.Render("x") + styles.DimStyle.Render(" "+i18n.T("footer.remove"))
+		}
+		if m.UIConfig.RemoteMode && center == "" {
+			center = styles.AccentStyle.Render("enter") + styles.DimStyle.Render(" "+i18n.T("footer.menu"))
+		}
+		if n := top.MarkedCount(); n > 0 {
+			center = styles.AccentStyle.Render(i18n.T("footer.marked", n)) +
+				styles.DimStyle.Render(" · "+i18n.T("footer.marked_hint"))
 		}
 	}
 
 	// Right side: compact background-sync segment + "? help" hint
-	right := styles.AccentStyle.Render("?") + styles.DimStyle.Render(" help")
-	if n := m.activeSyncCount(); n > 0 {
-		right = RenderSpinner(m.SpinnerFrame) + styles.DimStyle.Render(fmt.Sprintf(" %d syncing", n)) + "   " + right
+	right := styles.AccentStyle.Render("?") + styles.DimStyle.Render(" "+i18n.T("footer.help"))
+	if m.PlaybackSvc != nil && m.PlaybackSvc.MaxBitrate() > 0 {
+		right = styles.DimStyle.Render("▼ "+player.QualityLabel(m.PlaybackSvc.MaxBitrate())) + "   " + right
+	}
+	if m.PlaybackSvc != nil && m.PlaybackSvc.Private() {
+		right = styles.AlertStyle.Render("◉ "+i18n.T("footer.private")) + "   " + right
+	}
+	if n := m.jobs.Active(); n > 0 {
+		label := " " + i18n.T("footer.jobs", n)
+		if n == 1 {
+			label = " " + i18n.T("footer.job")
+		}
+		right = RenderSpinner(m.SpinnerFrame) + styles.DimStyle.Render(label+" · ctrl+j") + "   " + right
+	}
+	if activity := m.renderActivitySegment(); activity != "" {
+		right = activity + "   " + right
+	}
+	if health := m.renderHealth(); health != "" {
+		right = health + "   " + right
 	}
 
 	// Layout: left + centered hints + right
@@ -178,64 +305,158 @@ func (m Model) renderFooter() string {
 	return left + strings.Repeat(" ", leftPad) + center + strings.Repeat(" ", rightPad) + right
 }
 
+// helpSection is a titled group of help screen rows: a key and the
+// catalog key of its description
+type helpSection struct {
+	title string
+	rows  [][2]string
+}
+
+// Help screen columns, left and right
+var (
+	helpLeft = []helpSection{
+		{"help.navigation", [][2]string{
+			{"j/k", "help.up_down"},
+			{"h/l", "help.parent_drill"},
+			{"Backspace", "help.back"},
+			{"g/Home", "help.first"},
+			{"G/End", "help.last"},
+			{"PgUp/PgDn", "help.page"},
+			{"Ctrl+u/d", "help.half_page"},
+			{"v", "help.mark_batch"},
+			{"Tab", "help.peek"},
+		}},
+		{"help.search_view", [][2]string{
+			{"/", "help.filter"},
+			{"W", "help.watch_filter"},
+			{"f", "help.global_search"},
+			{"Tab", "help.arr_lookup"},
+			{"s", "help.sort"},
+			{"S", "help.specials"},
+			{"H", "help.hide_libraries"},
+			{"i", "help.inspector"},
+			{"I", "help.details"},
+			{"c", "help.people"},
+			{"o", "help.open_imdb"},
+			{"a", "help.new_items"},
+			{"~", "help.home"},
+			{"|", "help.split"},
+			{"Ctrl+w", "help.switch_pane"},
+		}},
+	}
+	helpRight = []helpSection{
+		{"help.playback", [][2]string{
+			{"Enter", "help.play_resume"},
+			{"p", "help.play_start"},
+			{"w", "help.mark_watched"},
+			{"u", "help.mark_unwatched"},
+			{"*", "help.rate"},
+			{"Q", "help.quality"},
+			{"P", "help.private"},
+			{"C", "help.cast"},
+			{".", "help.pause_player"},
+			{"< >", "help.seek_player"},
+			{"Ctrl+x", "help.stop_player"},
+		}},
+		{"help.playlists", [][2]string{
+			{"Space", "help.playlist_toggle"},
+			{"x", "help.delete"},
+			{"e", "help.edit_playlist"},
+			{"E", "help.export"},
+			{"n", "help.new_playlist"},
+		}},
+		{"help.other", [][2]string{
+			{"r", "help.refresh"},
+			{"R", "help.refresh_all"},
+			{"Ctrl+j", "help.jobs"},
+			{"+", "help.watchlist"},
+			{"A", "help.activity"},
+			{":", "help.command"},
+			{"m1-9", "help.bookmark"},
+			{"1-9", "help.jump_bookmark"},
+			{"D", "help.request_log"},
+			{"L", "help.logout"},
+			{"Esc", "help.close"},
+			{"q", "help.quit"},
+		}},
+	}
+)
+
 // renderHelp renders the help screen
 func (m Model) renderHelp() string {
-	help := `
-NAVIGATION                      PLAYBACK
-  j/k        Up/down               Enter  Play/resume
-  h/l        Back/drill in         p      Play from start
-  Backspace  Back                  w      Mark watched
-  g/Home     First item            u      Mark unwatched
-  G/End      Last item
-  PgUp/PgDn  Scroll page         PLAYLISTS
-  Ctrl+u/d   Scroll half page      Space  Add/remove item
-                                   x      Delete / remove
-SEARCH & VIEW
-  /          Filter              OTHER
-  f          Global search         r      Refresh view
-  s          Sort                  R      Refresh all
-  i          Toggle inspector      q      Quit
-                                   L      Logout
-                                   Esc    Close / Cancel
-
-Press any key to return...
-`
+	column := func(sections []helpSection) string {
+		keyWidth := 0
+		for _, s := range sections {
+			for _, r := range s.rows {
+				keyWidth = max(keyWidth, lipgloss.Width(r[0]))
+			}
+		}
+		var lines []string
+		for _, s := range sections {
+			lines = append(lines, i18n.T(s.title))
+			for _, r := range s.rows {
+				lines = append(lines, fmt.Sprintf("  %-*s %s", keyWidth, r[0], i18n.T(r[1])))
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+	help := lipgloss.JoinHorizontal(lipgloss.Top, column(helpLeft), "    ", column(helpRight)) +
+		"\n\n" + i18n.T("help.return")
 
 	return lipgloss.Place(m.Width, m.Height,
 		lipgloss.Center, lipgloss.Center,
 		styles.ModalStyle.Render(help))
 }
 
-// renderLogoutConfirmation renders the logout confirmation modal
-func (m Model) renderLogoutConfirmation() string {
-	modal := `
-              Log Out?
+// renderKeyConflicts renders the startup report of ambiguous key bindings
+func (m Model) renderKeyConflicts() string {
+	var b strings.Builder
+	b.WriteString(styles.ModalTitleStyle.Render(i18n.T("keys.conflicts_title")))
+	b.WriteString("\n\n")
+	for _, c := range m.keyConflicts {
+		b.WriteString("  " + c.String() + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render(i18n.T("keys.conflicts_disabled")))
+	b.WriteString("\n\n")
+	b.WriteString(i18n.T("keys.conflicts_continue"))
 
-  This will clear your credentials,
-  server URL, and all cached data.
+	return lipgloss.Place(m.Width, m.Height,
+		lipgloss.Center, lipgloss.Center,
+		styles.ModalStyle.Render(b.String()))
+}
 
-        [Y] Yes      [N] No
-`
+// renderConfirmation renders a yes/no modal: the question over its
+// centered body lines and the Y/N buttons
+func (m Model) renderConfirmation(question string, body ...string) string {
+	lines := append([]string{styles.ModalTitleStyle.Render(question)}, body...)
+	lines = append(lines, "", i18n.T("confirm.buttons"))
+	modal := lipgloss.JoinVertical(lipgloss.Center, lines...)
 
 	return lipgloss.Place(m.Width, m.Height,
 		lipgloss.Center, lipgloss.Center,
 		styles.ModalStyle.Render(modal))
 }
 
+// renderLogoutConfirmation renders the logout confirmation modal
+func (m Model) renderLogoutConfirmation() string {
+	return m.renderConfirmation(i18n.T("logout.title"),
+		i18n.T("logout.body1"), i18n.T("logout.body2"))
+}
+
 // renderDeletePlaylistConfirmation renders the playlist delete confirmation
 func (m Model) renderDeletePlaylistConfirmation() string {
 	name := styles.Truncate(m.pendingDeletePlaylistName, 30)
-	modal := fmt.Sprintf(`
-        Delete Playlist?
-
-  %q
-  will be permanently deleted
-  from the server.
-
-      [Y] Yes      [N] No
-`, name)
+	return m.renderConfirmation(i18n.T("playlist.delete_title"),
+		fmt.Sprintf("%q", name), i18n.T("playlist.delete_body1"), i18n.T("playlist.delete_body2"))
+}
 
-	return lipgloss.Place(m.Width, m.Height,
-		lipgloss.Center, lipgloss.Center,
-		styles.ModalStyle.Render(modal))
+// renderNextEpisodeConfirmation renders the up-next offer after an episode
+// plays to its end
+func (m Model) renderNextEpisodeConfirmation() string {
+	var code, title string
+	if next := m.pendingNextEpisode; next != nil {
+		code, title = next.EpisodeCode(), styles.Truncate(next.Title, 30)
+	}
+	return m.renderConfirmation(i18n.T("next_episode.title"), code+"  "+title)
 }
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+)
+
+// handleWatchlistToggle adds the selected movie or show to the watchlist,
+// or removes it when it is already there. In the watchlist column it
+// removes the selected title. Outside it, "already there" is judged by the
+// watchlist as last loaded.
+func (m Model) handleWatchlistToggle() (tea.Model, tea.Cmd) {
+	if m.LibraryService == nil || !m.LibraryService.HasWatchlist() {
+		return m, m.notify(NoticeInfo, i18n.T("status.watchlist_needs_plex"))
+	}
+	top := m.ColumnStack.Top()
+	if top == nil {
+		return m, nil
+	}
+	if entry := top.SelectedWatchlistItem(); entry != nil {
+		return m, RemoveFromWatchlistCmd(m.LibraryService, *entry)
+	}
+
+	entry, ok := watchlistEntryFor(top.SelectedItem())
+	if !ok {
+		return m, m.notify(NoticeInfo, i18n.T("status.watchlist_types"))
+	}
+	if listed := m.watchlisted(entry.LocalID); listed != nil {
+		return m, RemoveFromWatchlistCmd(m.LibraryService, *listed)
+	}
+	return m, AddToWatchlistCmd(m.LibraryService, entry)
+}
+
+// watchlistEntryFor describes a server movie or show as a watchlist title
+// (without its watchlist ID, which only the add returns)
+func watchlistEntryFor(item interface{}) (domain.WatchlistItem, bool) {
+	switch v := item.(type) {
+	case *domain.MediaItem:
+		if v.Type == domain.MediaTypeMovie {
+			return domain.WatchlistItem{
+				Title: v.Title, Year: v.Year, Type: domain.MediaTypeMovie, Summary: v.Summary,
+				LocalID: v.ID, LibraryID: v.LibraryID,
+			}, true
+		}
+	case *domain.Show:
+		return domain.WatchlistItem{
+			Title: v.Title, Year: v.Year, Type: domain.MediaTypeShow, Summary: v.Summary,
+			LocalID: v.ID, LibraryID: v.LibraryID,
+		}, true
+	}
+	return domain.WatchlistItem{}, false
+}
+
+// watchlisted returns the loaded watchlist's entry for a server item
+func (m Model) watchlisted(itemID string) *domain.WatchlistItem {
+	for _, w := range m.watchlist {
+		if w.LocalID == itemID {
+			return w
+		}
+	}
+	return nil
+}
+
+// handleWatchlistChanged applies an add or remove to the loaded watchlist
+// and, when open, its column
+func (m Model) handleWatchlistChanged(msg WatchlistChangedMsg) (tea.Model, tea.Cmd) {
+	kept := make([]*domain.WatchlistItem, 0, len(m.watchlist)+1)
+	if msg.Added {
+		item := msg.Item
+		kept = append(kept, &item)
+	}
+	for _, w := range m.watchlist {
+		if w.ID != msg.Item.ID {
+			kept = append(kept, w)
+		}
+	}
+	m.watchlist = kept
+	if col := m.loadTarget(watchlistLibraryID); col != nil {
+		col.ReplaceItems(m.watchlist)
+		m.updateInspector()
+	}
+
+	if msg.Added {
+		return m, m.notify(NoticeSuccess, i18n.T("status.watchlist_added", msg.Item.Title))
+	}
+	return m, m.notify(NoticeSuccess, i18n.T("status.watchlist_removed", msg.Item.Title))
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/kino/internal/domain"
+	"github.com/mmcdole/kino/internal/i18n"
+	"github.com/mmcdole/kino/internal/library"
+	"github.com/mmcdole/kino/internal/tui/components"
+)
+
+// + watchlists the selected movie, and removes it once listed; in the
+// watchlist column it removes the selected title
+func TestWatchlistToggle(t *testing.T) {
+	server := &fakeServer{}
+	m := Model{ColumnStack: NewColumnStack(), LibraryService: library.NewService(server, nil, nil)}
+	movies := components.NewListColumn(components.ColumnTypeMovies, "Movies")
+	movies.SetItems([]*domain.MediaItem{{ID: "m1", Title: "Heat", Type: domain.MediaTypeMovie}})
+	m.ColumnStack.Push(movies, 0)
+
+	toggle := func() {
+		t.Helper()
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+		m = updated.(Model)
+		if cmd == nil {
+			t.Fatal("+ did nothing")
+		}
+		updated, _ = m.Update(cmd())
+		m = updated.(Model)
+	}
+	toggle()
+	if len(server.watchlist) != 1 || m.watchlisted("m1") == nil || m.notice.Text != i18n.T("status.watchlist_added", "Heat") {
+		t.Fatalf("after add: listed %v, notice %q", server.watchlist, m.notice.Text)
+	}
+	toggle()
+	if len(server.watchlist) != 0 || m.watchlisted("m1") != nil {
+		t.Fatalf("after remove: listed %v", server.watchlist)
+	}
+
+	if !slices.ContainsFunc(m.allLibraryEntries(), func(l domain.Library) bool { return l.ID == watchlistLibraryID }) {
+		t.Fatal("no Watchlist entry for a server with one")
+	}
+	col := components.NewListColumn(components.ColumnTypeWatchlist, "Watchlist")
+	col.SetContentID(watchlistLibraryID)
+	m.ColumnStack.Push(col, 0)
+	items, _ := server.GetWatchlist(context.Background())
+	updated, _ := m.Update(WatchlistLoadedMsg{Items: items})
+	m = updated.(Model)
+	toggle()
+	if col.ItemCount() != 0 || len(m.watchlist) != 0 {
+		t.Fatalf("title not removed from the column: %d left", col.ItemCount())
+	}
+}
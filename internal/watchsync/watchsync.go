@@ -0,0 +1,260 @@
+// Package watchsync copies watch state from one media server to another,
+// for people running both (say, while moving from Plex to Jellyfin). Items
+// are matched by their IMDb, TMDB and TVDB IDs; episodes without IDs of
+// their own match by their show's IDs and episode number.
+package watchsync
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// offsetTolerance is how far apart two resume positions may be and still
+// count as the same
+const offsetTolerance = time.Minute
+
+// Library is the subset of library.Service a server's content is read
+// through
+type Library interface {
+	FetchLibraries(ctx context.Context) ([]domain.Library, error)
+	FetchMovies(ctx context.Context, libID string, serverTS int64, onProgress domain.ProgressFunc) ([]*domain.MediaItem, error)
+	FetchShows(ctx context.Context, libID string, serverTS int64, onProgress domain.ProgressFunc) ([]*domain.Show, error)
+	FetchMixedContent(ctx context.Context, libID string, serverTS int64, onProgress domain.ProgressFunc) ([]domain.ListItem, error)
+	FetchSeasons(ctx context.Context, libID, showID string) ([]*domain.Season, error)
+	FetchEpisodes(ctx context.Context, libID, showID, seasonID string) ([]*domain.MediaItem, error)
+}
+
+// Entry is one change to the target server
+type Entry struct {
+	ItemID string        // Target item ID
+	Label  string        // "Title (Year)" or "Show S01E02"
+	Offset time.Duration // Resume position to set; 0 marks watched
+}
+
+// Report summarizes a sync. Watch state only moves forward: nothing is
+// ever marked unwatched, and a target further along keeps its position.
+type Report struct {
+	DryRun    bool
+	ToMark    []Entry  // Watched on the source, not on the target
+	ToResume  []Entry  // Further along on the source
+	InSync    int      // Source items whose state the target already has
+	Unmatched []string // Watched or started on the source, not found on the target
+	Failed    []Entry  // Target rejected the change (not set in dry runs)
+
+	// ResumeUnsupported is set when the target can't take resume
+	// positions; ToResume is then empty
+	ResumeUnsupported bool
+}
+
+// Syncer copies watch state from one server to another
+type Syncer struct {
+	from, to Library
+	target   domain.PlaybackClient
+	logger   *slog.Logger
+}
+
+// NewSyncer creates a syncer reading from and writing to target through to
+func NewSyncer(from, to Library, target domain.PlaybackClient, logger *slog.Logger) *Syncer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Syncer{from: from, to: to, target: target, logger: logger}
+}
+
+// Run reads both servers, matches the source's watched and in-progress
+// items on the target, and applies what the target lacks. With dryRun
+// nothing is written; the report shows what would change.
+func (s *Syncer) Run(ctx context.Context, dryRun bool) (*Report, error) {
+	source, err := collect(ctx, s.from)
+	if err != nil {
+		return nil, fmt.Errorf("reading source server: %w", err)
+	}
+	target, err := collect(ctx, s.to)
+	if err != nil {
+		return nil, fmt.Errorf("reading target server: %w", err)
+	}
+	index := make(map[string][]*domain.MediaItem)
+	for _, it := range target {
+		for _, k := range it.keys() {
+			index[k] = append(index[k], it.item)
+		}
+	}
+
+	resumer, canResume := s.target.(domain.ResumeClient)
+	report := &Report{DryRun: dryRun, ResumeUnsupported: !canResume}
+	seen := make(map[string]bool) // Target items already planned
+	for _, it := range source {
+		src := it.item
+		if !src.IsPlayed && src.ViewOffset <= 0 {
+			continue
+		}
+		matches := lookup(index, it.keys())
+		if len(matches) == 0 {
+			report.Unmatched = append(report.Unmatched, it.label())
+			continue
+		}
+		for _, dst := range matches {
+			if seen[dst.ID] {
+				continue
+			}
+			seen[dst.ID] = true
+			switch {
+			case dst.IsPlayed:
+				report.InSync++
+			case src.IsPlayed:
+				report.ToMark = append(report.ToMark, Entry{ItemID: dst.ID, Label: it.label()})
+			case dst.ViewOffset >= src.ViewOffset-offsetTolerance:
+				report.InSync++
+			case canResume:
+				report.ToResume = append(report.ToResume, Entry{ItemID: dst.ID, Label: it.label(), Offset: src.ViewOffset})
+			}
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+	for _, e := range report.ToMark {
+		if err := s.target.MarkPlayed(ctx, e.ItemID); err != nil {
+			s.logger.Warn("watch sync: failed to mark watched", "error", err, "itemID", e.ItemID)
+			report.Failed = append(report.Failed, e)
+		}
+	}
+	for _, e := range report.ToResume {
+		if err := resumer.SetViewOffset(ctx, e.ItemID, e.Offset); err != nil {
+			s.logger.Warn("watch sync: failed to set resume position", "error", err, "itemID", e.ItemID)
+			report.Failed = append(report.Failed, e)
+		}
+	}
+	s.logger.Info("watch sync complete",
+		"marked", len(report.ToMark), "resumed", len(report.ToResume),
+		"failed", len(report.Failed), "unmatched", len(report.Unmatched))
+	return report, nil
+}
+
+// lookup returns the target items under the first key that has any
+func lookup(index map[string][]*domain.MediaItem, keys []string) []*domain.MediaItem {
+	for _, k := range keys {
+		if items := index[k]; len(items) > 0 {
+			return items
+		}
+	}
+	return nil
+}
+
+// entry is a movie or episode with its show's IDs, for matching
+type entry struct {
+	item *domain.MediaItem
+	show domain.ExternalIDs
+}
+
+// keys returns the item's match keys, most specific first: its own IDs,
+// then (episodes) its show's IDs with the episode number
+func (e entry) keys() []string {
+	kind := "movie"
+	if e.item.Type == domain.MediaTypeEpisode {
+		kind = "episode"
+	}
+	keys := idKeys(kind, e.item.External, "")
+	if e.item.Type == domain.MediaTypeEpisode {
+		code := fmt.Sprintf("/s%de%d", e.item.SeasonNum, e.item.EpisodeNum)
+		keys = append(keys, idKeys("show", e.show, code)...)
+	}
+	return keys
+}
+
+// idKeys builds one key per known ID
+func idKeys(kind string, ids domain.ExternalIDs, suffix string) []string {
+	var keys []string
+	for _, id := range []struct{ provider, value string }{
+		{"imdb", ids.IMDb}, {"tmdb", ids.TMDB}, {"tvdb", ids.TVDB},
+	} {
+		if id.value != "" {
+			keys = append(keys, kind+"/"+id.provider+"/"+id.value+suffix)
+		}
+	}
+	return keys
+}
+
+// label names the item in the report
+func (e entry) label() string {
+	if e.item.Type == domain.MediaTypeEpisode {
+		return e.item.ShowTitle + " " + e.item.EpisodeCode()
+	}
+	if e.item.Year == 0 {
+		return e.item.Title
+	}
+	return fmt.Sprintf("%s (%d)", e.item.Title, e.item.Year)
+}
+
+// collect reads every movie and episode of a server's movie, show and
+// mixed libraries
+func collect(ctx context.Context, lib Library) ([]entry, error) {
+	libs, err := lib.FetchLibraries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []entry
+	addShow := func(libID string, show *domain.Show) error {
+		seasons, err := lib.FetchSeasons(ctx, libID, show.ID)
+		if err != nil {
+			return err
+		}
+		for _, season := range seasons {
+			episodes, err := lib.FetchEpisodes(ctx, libID, show.ID, season.ID)
+			if err != nil {
+				return err
+			}
+			for _, ep := range episodes {
+				entries = append(entries, entry{item: ep, show: show.External})
+			}
+		}
+		return nil
+	}
+
+	for _, l := range libs {
+		switch l.Type {
+		case "movie":
+			movies, err := lib.FetchMovies(ctx, l.ID, l.UpdatedAt, nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range movies {
+				entries = append(entries, entry{item: m})
+			}
+		case "show":
+			shows, err := lib.FetchShows(ctx, l.ID, l.UpdatedAt, nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, show := range shows {
+				if err := addShow(l.ID, show); err != nil {
+					return nil, err
+				}
+			}
+		case "mixed":
+			items, err := lib.FetchMixedContent(ctx, l.ID, l.UpdatedAt, nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, it := range items {
+				switch v := it.(type) {
+				case *domain.MediaItem:
+					if v.Type == domain.MediaTypeMovie {
+						entries = append(entries, entry{item: v})
+					}
+				case *domain.Show:
+					if err := addShow(l.ID, v); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,132 @@
+package watchsync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/kino/internal/domain"
+)
+
+// fakeLibrary serves one movie library and one show with a single season
+type fakeLibrary struct {
+	movies   []*domain.MediaItem
+	show     *domain.Show
+	episodes []*domain.MediaItem
+}
+
+func (f *fakeLibrary) FetchLibraries(ctx context.Context) ([]domain.Library, error) {
+	return []domain.Library{{ID: "m", Type: "movie"}, {ID: "s", Type: "show"}}, nil
+}
+
+func (f *fakeLibrary) FetchMovies(ctx context.Context, libID string, serverTS int64, onProgress domain.ProgressFunc) ([]*domain.MediaItem, error) {
+	return f.movies, nil
+}
+
+func (f *fakeLibrary) FetchShows(ctx context.Context, libID string, serverTS int64, onProgress domain.ProgressFunc) ([]*domain.Show, error) {
+	return []*domain.Show{f.show}, nil
+}
+
+func (f *fakeLibrary) FetchMixedContent(ctx context.Context, libID string, serverTS int64, onProgress domain.ProgressFunc) ([]domain.ListItem, error) {
+	return nil, nil
+}
+
+func (f *fakeLibrary) FetchSeasons(ctx context.Context, libID, showID string) ([]*domain.Season, error) {
+	return []*domain.Season{{ID: "season1", ShowID: showID, SeasonNum: 1}}, nil
+}
+
+func (f *fakeLibrary) FetchEpisodes(ctx context.Context, libID, showID, seasonID string) ([]*domain.MediaItem, error) {
+	return f.episodes, nil
+}
+
+type fakeTarget struct {
+	marked  []string
+	offsets map[string]time.Duration
+}
+
+func (f *fakeTarget) ResolvePlayableURL(ctx context.Context, itemID string) (string, error) {
+	return "", nil
+}
+
+func (f *fakeTarget) MarkPlayed(ctx context.Context, itemID string) error {
+	f.marked = append(f.marked, itemID)
+	return nil
+}
+
+func (f *fakeTarget) MarkUnplayed(ctx context.Context, itemID string) error { return nil }
+
+func (f *fakeTarget) SetViewOffset(ctx context.Context, itemID string, offset time.Duration) error {
+	f.offsets[itemID] = offset
+	return nil
+}
+
+func TestSyncMatchesAndMovesStateForward(t *testing.T) {
+	show := domain.ExternalIDs{TVDB: "79126"}
+	source := &fakeLibrary{
+		movies: []*domain.MediaItem{
+			{ID: "p-heat", Title: "Heat", Year: 1995, Type: domain.MediaTypeMovie, IsPlayed: true, External: domain.ExternalIDs{IMDb: "tt0113277"}},
+			{ID: "p-alien", Title: "Alien", Year: 1979, Type: domain.MediaTypeMovie, ViewOffset: 40 * time.Minute, External: domain.ExternalIDs{TMDB: "348"}},
+			{ID: "p-ronin", Title: "Ronin", Year: 1998, Type: domain.MediaTypeMovie, IsPlayed: true, External: domain.ExternalIDs{IMDb: "tt0122690"}},
+			{ID: "p-nope", Title: "Not There", Type: domain.MediaTypeMovie, IsPlayed: true, External: domain.ExternalIDs{IMDb: "tt0000001"}},
+		},
+		show: &domain.Show{ID: "p-wire", Title: "The Wire", External: show},
+		episodes: []*domain.MediaItem{
+			{ID: "p-e1", ShowTitle: "The Wire", SeasonNum: 1, EpisodeNum: 1, Type: domain.MediaTypeEpisode, IsPlayed: true},
+			{ID: "p-e2", ShowTitle: "The Wire", SeasonNum: 1, EpisodeNum: 2, Type: domain.MediaTypeEpisode, ViewOffset: 10 * time.Minute},
+		},
+	}
+	target := &fakeLibrary{
+		movies: []*domain.MediaItem{
+			{ID: "j-heat", Type: domain.MediaTypeMovie, External: domain.ExternalIDs{IMDb: "tt0113277"}},
+			{ID: "j-alien", Type: domain.MediaTypeMovie, External: domain.ExternalIDs{TMDB: "348"}},
+			{ID: "j-ronin", Type: domain.MediaTypeMovie, IsPlayed: true, External: domain.ExternalIDs{IMDb: "tt0122690"}},
+		},
+		show: &domain.Show{ID: "j-wire", External: show},
+		episodes: []*domain.MediaItem{
+			{ID: "j-e1", SeasonNum: 1, EpisodeNum: 1, Type: domain.MediaTypeEpisode},
+			// Further along than the source: left alone
+			{ID: "j-e2", SeasonNum: 1, EpisodeNum: 2, Type: domain.MediaTypeEpisode, ViewOffset: 30 * time.Minute},
+		},
+	}
+
+	dst := &fakeTarget{offsets: make(map[string]time.Duration)}
+	syncer := NewSyncer(source, target, dst, nil)
+
+	if _, err := syncer.Run(context.Background(), true); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(dst.marked) != 0 || len(dst.offsets) != 0 {
+		t.Fatalf("dry run wrote to the target: marked %v, offsets %v", dst.marked, dst.offsets)
+	}
+
+	report, err := syncer.Run(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := ids(report.ToMark); got != "j-heat,j-e1" {
+		t.Errorf("ToMark = %s, want j-heat,j-e1", got)
+	}
+	if dst.offsets["j-alien"] != 40*time.Minute || len(dst.offsets) != 1 {
+		t.Errorf("offsets = %v, want only j-alien at 40m", dst.offsets)
+	}
+	if report.InSync != 2 {
+		t.Errorf("InSync = %d, want 2 (Ronin, episode 2)", report.InSync)
+	}
+	if len(report.Unmatched) != 1 || report.Unmatched[0] != "Not There" {
+		t.Errorf("Unmatched = %v, want [Not There]", report.Unmatched)
+	}
+	if len(dst.marked) != 2 || len(report.Failed) != 0 {
+		t.Errorf("marked %v, failed %v", dst.marked, report.Failed)
+	}
+}
+
+func ids(entries []Entry) string {
+	var s string
+	for i, e := range entries {
+		if i > 0 {
+			s += ","
+		}
+		s += e.ItemID
+	}
+	return s
+}